@@ -0,0 +1,462 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/chzyer/readline"
+
+	"github.com/MaDal776/polymarket-go-client/pkg/client"
+	"github.com/MaDal776/polymarket-go-client/pkg/config"
+	"github.com/MaDal776/polymarket-go-client/pkg/export"
+	"github.com/MaDal776/polymarket-go-client/pkg/types"
+)
+
+// runPrice prints the best price for one or more tokens
+func runPrice(cfg *config.Config, args []string) error {
+	side := types.BUY
+	if n := len(args); n > 0 {
+		last := types.OrderSide(strings.ToUpper(args[n-1]))
+		if last == types.BUY || last == types.SELL {
+			side = last
+			args = args[:n-1]
+		}
+	}
+	if len(args) < 1 {
+		return fmt.Errorf("usage: price <token-id>... [BUY|SELL]")
+	}
+
+	clobClient, err := newClient(cfg, types.L0)
+	if err != nil {
+		return err
+	}
+	if len(args) == 1 {
+		price, err := clobClient.GetPrice(args[0], side)
+		if err != nil {
+			return err
+		}
+		return printJSON(price)
+	}
+
+	prices, err := clobClient.GetPricesBySide(context.Background(), args, side, 0)
+	if err != nil {
+		return err
+	}
+	return printJSON(prices)
+}
+
+// runBook prints the order book for one or more tokens
+func runBook(cfg *config.Config, args []string) error {
+	if len(args) < 1 {
+		return fmt.Errorf("usage: book <token-id>...")
+	}
+
+	clobClient, err := newClient(cfg, types.L0)
+	if err != nil {
+		return err
+	}
+	if len(args) == 1 {
+		book, err := clobClient.GetOrderBook(args[0])
+		if err != nil {
+			return err
+		}
+		return printJSON(book)
+	}
+
+	books, err := clobClient.GetOrderBooks(context.Background(), args, 0)
+	if err != nil {
+		return err
+	}
+	return printJSON(books)
+}
+
+// runMarkets prints a page of markets, or a single market by condition ID
+func runMarkets(cfg *config.Config, args []string) error {
+	fs := flag.NewFlagSet("markets", flag.ContinueOnError)
+	cursor := fs.String("cursor", "", "pagination cursor")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+
+	clobClient, err := newClient(cfg, types.L0)
+	if err != nil {
+		return err
+	}
+	if fs.NArg() > 0 {
+		market, err := clobClient.GetMarket(fs.Arg(0))
+		if err != nil {
+			return err
+		}
+		return printJSON(market)
+	}
+
+	markets, err := clobClient.GetMarkets(*cursor)
+	if err != nil {
+		return err
+	}
+	return printJSON(markets)
+}
+
+// runBalance prints the collateral balance, or a conditional token balance
+func runBalance(cfg *config.Config, args []string) error {
+	fs := flag.NewFlagSet("balance", flag.ContinueOnError)
+	tokenID := fs.String("token", "", "conditional token ID (collateral when empty)")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+
+	clobClient, err := newClient(cfg, types.L2)
+	if err != nil {
+		return err
+	}
+	resp, err := clobClient.GetBalanceAllowance(balanceParams(cfg, *tokenID))
+	if err != nil {
+		return err
+	}
+	return printJSON(map[string]string{"balance": resp.Balance})
+}
+
+// runAllowance prints (and optionally refreshes) the allowance for collateral or a token
+func runAllowance(cfg *config.Config, args []string) error {
+	fs := flag.NewFlagSet("allowance", flag.ContinueOnError)
+	tokenID := fs.String("token", "", "conditional token ID (collateral when empty)")
+	update := fs.Bool("update", false, "ask the server to refresh the cached allowance first")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+
+	clobClient, err := newClient(cfg, types.L2)
+	if err != nil {
+		return err
+	}
+	params := balanceParams(cfg, *tokenID)
+	if *update {
+		if _, err := clobClient.UpdateBalanceAllowance(params); err != nil {
+			return err
+		}
+	}
+	resp, err := clobClient.GetBalanceAllowance(params)
+	if err != nil {
+		return err
+	}
+	return printJSON(map[string]string{"allowance": resp.Allowance})
+}
+
+// runOrder dispatches the order subcommands
+func runOrder(cfg *config.Config, args []string) error {
+	if len(args) < 1 {
+		return fmt.Errorf("usage: order create|post|cancel ...")
+	}
+
+	switch args[0] {
+	case "create":
+		return runOrderCreate(cfg, args[1:])
+	case "post":
+		return runOrderPost(cfg, args[1:])
+	case "cancel":
+		return runOrderCancel(cfg, args[1:])
+	default:
+		return fmt.Errorf("unknown order command %q", args[0])
+	}
+}
+
+// runOrderCreate signs a limit order and prints it, posting it when -post is given
+func runOrderCreate(cfg *config.Config, args []string) error {
+	fs := flag.NewFlagSet("order create", flag.ContinueOnError)
+	tokenID := fs.String("token", "", "token ID")
+	side := fs.String("side", "BUY", "BUY or SELL")
+	price := fs.Float64("price", 0, "limit price")
+	size := fs.Float64("size", 0, "size in shares")
+	expiration := fs.Int64("expiration", 0, "expiration unix timestamp (GTD only)")
+	orderType := fs.String("type", "GTC", "order type used with -post; GTD when -expiration is set")
+	post := fs.Bool("post", false, "post the order after signing")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	if *tokenID == "" || *price <= 0 || *size <= 0 {
+		return fmt.Errorf("-token, -price and -size are required")
+	}
+	postType := types.OrderType(strings.ToUpper(*orderType))
+	if *expiration != 0 && !flagGiven(fs, "type") {
+		postType = types.GTD
+	}
+	if *post && *expiration != 0 && postType != types.GTD {
+		return fmt.Errorf("-expiration requires -type GTD, got %s", postType)
+	}
+	if *post && *expiration == 0 && postType == types.GTD {
+		return fmt.Errorf("-type GTD requires -expiration")
+	}
+
+	level := types.L1
+	if *post {
+		level = types.L2
+	}
+	clobClient, err := newClient(cfg, level)
+	if err != nil {
+		return err
+	}
+
+	signedOrder, err := clobClient.CreateOrder(types.OrderArgs{
+		TokenID:    *tokenID,
+		Price:      *price,
+		Size:       *size,
+		Side:       types.OrderSide(strings.ToUpper(*side)),
+		Expiration: *expiration,
+	}, nil)
+	if err != nil {
+		return err
+	}
+	if !*post {
+		return printJSON(signedOrder)
+	}
+
+	resp, err := clobClient.PostOrder(signedOrder, postType)
+	if err != nil {
+		return err
+	}
+	return printJSON(resp)
+}
+
+// runOrderPost posts a signed order read from a file or stdin
+func runOrderPost(cfg *config.Config, args []string) error {
+	fs := flag.NewFlagSet("order post", flag.ContinueOnError)
+	file := fs.String("file", "-", "signed order JSON file (- for stdin)")
+	orderType := fs.String("type", "GTC", "order type; GTD when the order expires")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+
+	var data []byte
+	var err error
+	if *file == "-" {
+		data, err = io.ReadAll(os.Stdin)
+	} else {
+		data, err = os.ReadFile(*file)
+	}
+	if err != nil {
+		return fmt.Errorf("failed to read signed order: %w", err)
+	}
+
+	var signedOrder types.SignedOrder
+	if err := json.Unmarshal(data, &signedOrder); err != nil {
+		return fmt.Errorf("failed to parse signed order: %w", err)
+	}
+
+	postType := types.OrderType(strings.ToUpper(*orderType))
+	if !flagGiven(fs, "type") {
+		postType = client.PostOrderType(&signedOrder)
+	}
+
+	clobClient, err := newClient(cfg, types.L2)
+	if err != nil {
+		return err
+	}
+	resp, err := clobClient.PostOrder(&signedOrder, postType)
+	if err != nil {
+		return err
+	}
+	return printJSON(resp)
+}
+
+// flagGiven reports whether a flag was set on the command line
+func flagGiven(fs *flag.FlagSet, name string) bool {
+	given := false
+	fs.Visit(func(f *flag.Flag) {
+		if f.Name == name {
+			given = true
+		}
+	})
+	return given
+}
+
+// runOrderCancel cancels one or more orders by ID
+func runOrderCancel(cfg *config.Config, args []string) error {
+	if len(args) == 0 {
+		return fmt.Errorf("usage: order cancel <order-id>...")
+	}
+
+	clobClient, err := newClient(cfg, types.L2)
+	if err != nil {
+		return err
+	}
+
+	var resp map[string]interface{}
+	if len(args) == 1 {
+		resp, err = clobClient.CancelOrder(args[0])
+	} else {
+		resp, err = clobClient.CancelOrders(args)
+	}
+	if err != nil {
+		return err
+	}
+	return printJSON(resp)
+}
+
+// runCancelAll cancels every open order
+func runCancelAll(cfg *config.Config, args []string) error {
+	clobClient, err := newClient(cfg, types.L2)
+	if err != nil {
+		return err
+	}
+	resp, err := clobClient.CancelAll()
+	if err != nil {
+		return err
+	}
+	return printJSON(resp)
+}
+
+// runTrades prints the trade history
+func runTrades(cfg *config.Config, args []string) error {
+	fs := flag.NewFlagSet("trades", flag.ContinueOnError)
+	market := fs.String("market", "", "condition ID filter")
+	assetID := fs.String("asset", "", "token ID filter")
+	after := fs.Int64("after", 0, "only trades after this unix timestamp")
+	before := fs.Int64("before", 0, "only trades before this unix timestamp")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+
+	clobClient, err := newClient(cfg, types.L2)
+	if err != nil {
+		return err
+	}
+	trades, err := clobClient.GetTrades(&types.TradeParams{
+		Market:  *market,
+		AssetID: *assetID,
+		After:   *after,
+		Before:  *before,
+	})
+	if err != nil {
+		return err
+	}
+	return printJSON(trades)
+}
+
+// runExport writes the normalized trade history as CSV or JSONL
+func runExport(cfg *config.Config, args []string) error {
+	fs := flag.NewFlagSet("export", flag.ContinueOnError)
+	format := fs.String("format", "csv", "output format: csv or jsonl")
+	output := fs.String("o", "-", "output file (- for stdout)")
+	market := fs.String("market", "", "condition ID filter")
+	after := fs.Int64("after", 0, "only trades after this unix timestamp")
+	before := fs.Int64("before", 0, "only trades before this unix timestamp")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+
+	clobClient, err := newClient(cfg, types.L2)
+	if err != nil {
+		return err
+	}
+
+	w := io.Writer(os.Stdout)
+	if *output != "-" {
+		file, err := os.Create(*output)
+		if err != nil {
+			return fmt.Errorf("failed to create output file: %w", err)
+		}
+		defer file.Close()
+		w = file
+	}
+
+	// Maker orders are matched against the funding address
+	address := clobClient.GetAddress()
+	if cfg.Funder != "" {
+		address = cfg.Funder
+	}
+
+	params := &types.TradeParams{Market: *market, After: *after, Before: *before}
+	count, err := export.Export(clobClient, params, address, export.Format(strings.ToLower(*format)), w)
+	if err != nil {
+		return err
+	}
+	fmt.Fprintf(os.Stderr, "exported %d records\n", count)
+	return nil
+}
+
+// runHealth checks API reachability (and credentials when configured), failing when unhealthy
+func runHealth(cfg *config.Config, args []string) error {
+	fs := flag.NewFlagSet("health", flag.ContinueOnError)
+	timeout := fs.Duration("timeout", 10*time.Second, "overall timeout")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+
+	clobClient, err := newClient(cfg, types.L0)
+	if err != nil {
+		return err
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), *timeout)
+	defer cancel()
+
+	status, err := clobClient.HealthCheck(ctx)
+	if err != nil {
+		return err
+	}
+	if err := printJSON(status); err != nil {
+		return err
+	}
+	if !status.Healthy {
+		return fmt.Errorf("unhealthy: %s", status.Error)
+	}
+	return nil
+}
+
+// runEncryptKey writes the configured private key to an encrypted key file
+func runEncryptKey(cfg *config.Config, args []string) error {
+	fs := flag.NewFlagSet("encrypt-key", flag.ContinueOnError)
+	output := fs.String("o", "", "key file to write")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	if *output == "" {
+		return fmt.Errorf("usage: encrypt-key -o FILE")
+	}
+	if cfg.PrivateKey == "" {
+		return fmt.Errorf("private key is required (set PRIVATE_KEY)")
+	}
+
+	passphrase := []byte(os.Getenv(config.EnvKeyPassphrase))
+	if len(passphrase) == 0 {
+		var err error
+		if passphrase, err = readline.Password("Passphrase: "); err != nil {
+			return err
+		}
+		confirm, err := readline.Password("Repeat passphrase: ")
+		if err != nil {
+			return err
+		}
+		if string(confirm) != string(passphrase) {
+			return fmt.Errorf("passphrases do not match")
+		}
+	}
+
+	data, err := config.EncryptPrivateKey(cfg.PrivateKey, passphrase)
+	if err != nil {
+		return err
+	}
+	if err := os.WriteFile(*output, append(data, '\n'), 0600); err != nil {
+		return fmt.Errorf("failed to write key file: %w", err)
+	}
+	fmt.Fprintf(os.Stderr, "Wrote %s; set %s=%s and %s to use it\n", *output, config.EnvPrivateKeyFile, *output, config.EnvKeyPassphrase)
+	return nil
+}
+
+// balanceParams builds balance/allowance params for collateral or a conditional token
+func balanceParams(cfg *config.Config, tokenID string) *types.BalanceAllowanceParams {
+	params := &types.BalanceAllowanceParams{
+		AssetType:     types.COLLATERAL,
+		SignatureType: cfg.SignatureType,
+	}
+	if tokenID != "" {
+		params.AssetType = types.CONDITIONAL
+		params.TokenID = tokenID
+	}
+	return params
+}
@@ -0,0 +1,145 @@
+// Command polyclob is a command-line tool for the Polymarket CLOB.
+//
+// Usage:
+//
+//	polyclob [global flags] <command> [command flags] [args]
+//
+// Configuration is loaded with pkg/config from the file given by -config (or
+// POLYMARKET_CONFIG) and the environment; explicit flags take precedence.
+// Secrets, the private key and API credentials, are only read from the file or
+// the environment, never from flags, which other users can see in the process list.
+package main
+
+import (
+	"encoding/json"
+	"flag"
+	"fmt"
+	"os"
+
+	"github.com/MaDal776/polymarket-go-client/pkg/client"
+	"github.com/MaDal776/polymarket-go-client/pkg/config"
+	"github.com/MaDal776/polymarket-go-client/pkg/types"
+)
+
+// command is a single CLI subcommand
+type command struct {
+	name  string
+	usage string
+	run   func(cfg *config.Config, args []string) error
+}
+
+var commands = []command{
+	{"price", "price <token-id>... [BUY|SELL]", runPrice},
+	{"book", "book <token-id>...", runBook},
+	{"markets", "markets [-cursor C] | markets <condition-id>", runMarkets},
+	{"balance", "balance [-token ID]", runBalance},
+	{"allowance", "allowance [-token ID] [-update]", runAllowance},
+	{"order", "order create|post|cancel ...", runOrder},
+	{"cancel-all", "cancel-all", runCancelAll},
+	{"trades", "trades [-market M] [-asset A] [-after TS]", runTrades},
+	{"repl", "repl", runREPL},
+	{"export", "export [-format csv|jsonl] [-o FILE] [-after TS] [-before TS]", runExport},
+	{"health", "health [-timeout D]", runHealth},
+	{"encrypt-key", "encrypt-key -o FILE", runEncryptKey},
+}
+
+func main() {
+	global := flag.NewFlagSet("polyclob", flag.ExitOnError)
+	configPath := global.String("config", os.Getenv("POLYMARKET_CONFIG"), "config file (.yaml, .toml or .env)")
+	host := global.String("host", "", "CLOB API host")
+	chainID := global.Int64("chain-id", 0, "chain ID")
+	keyFile := global.String("key-file", "", "encrypted private key file, used when PRIVATE_KEY is unset")
+	signatureType := global.Int("signature-type", 0, "signature type (0 EOA, 1 proxy, 2 safe)")
+	funder := global.String("funder", "", "funder (proxy wallet) address")
+	global.Usage = usage(global)
+	global.Parse(os.Args[1:])
+
+	cfg, err := config.Read(*configPath)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "polyclob: %v\n", err)
+		os.Exit(2)
+	}
+
+	// Explicit flags override the file and environment
+	global.Visit(func(f *flag.Flag) {
+		switch f.Name {
+		case "host":
+			cfg.Host = *host
+		case "chain-id":
+			cfg.ChainID = *chainID
+		case "key-file":
+			cfg.PrivateKeyFile = *keyFile
+		case "signature-type":
+			cfg.SignatureType = *signatureType
+		case "funder":
+			cfg.Funder = *funder
+		}
+	})
+	if err := cfg.Resolve(); err != nil {
+		fmt.Fprintf(os.Stderr, "polyclob: %v\n", err)
+		os.Exit(2)
+	}
+
+	args := global.Args()
+	if len(args) == 0 {
+		global.Usage()
+		os.Exit(2)
+	}
+
+	for _, cmd := range commands {
+		if cmd.name != args[0] {
+			continue
+		}
+		if err := cmd.run(cfg, args[1:]); err != nil {
+			fmt.Fprintf(os.Stderr, "polyclob %s: %v\n", cmd.name, err)
+			os.Exit(1)
+		}
+		return
+	}
+
+	fmt.Fprintf(os.Stderr, "polyclob: unknown command %q\n", args[0])
+	global.Usage()
+	os.Exit(2)
+}
+
+// usage prints the global flags and the list of commands
+func usage(fs *flag.FlagSet) func() {
+	return func() {
+		fmt.Fprintf(os.Stderr, "Usage: polyclob [flags] <command> [args]\n\nCommands:\n")
+		for _, cmd := range commands {
+			fmt.Fprintf(os.Stderr, "  %s\n", cmd.usage)
+		}
+		fmt.Fprintf(os.Stderr, "\nFlags:\n")
+		fs.PrintDefaults()
+	}
+}
+
+// newClient creates a client at the highest auth level the configuration allows.
+// Commands that need L2 derive API credentials when none are configured.
+func newClient(cfg *config.Config, level types.AuthLevel) (*client.ClobClient, error) {
+	if level >= types.L1 && cfg.PrivateKey == "" {
+		return nil, fmt.Errorf("private key is required (set PRIVATE_KEY, PRIVATE_KEY_FILE or -key-file)")
+	}
+
+	signatureType := cfg.SignatureType
+	clobClient, err := client.NewClobClient(cfg.Host, cfg.ChainID, cfg.PrivateKey, cfg.Creds(), &signatureType, cfg.FunderAddress())
+	if err != nil {
+		return nil, err
+	}
+
+	if level >= types.L2 && clobClient.GetAuthLevel() < types.L2 {
+		derived, err := clobClient.CreateOrDeriveAPIKey(0)
+		if err != nil {
+			return nil, fmt.Errorf("failed to derive API credentials: %w", err)
+		}
+		clobClient.SetAPICredentials(derived)
+	}
+	return clobClient, nil
+}
+
+// printJSON writes v to stdout as indented JSON
+func printJSON(v interface{}) error {
+	encoder := json.NewEncoder(os.Stdout)
+	encoder.SetIndent("", "  ")
+	return encoder.Encode(v)
+}
@@ -0,0 +1,291 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/chzyer/readline"
+
+	"github.com/MaDal776/polymarket-go-client/pkg/client"
+	"github.com/MaDal776/polymarket-go-client/pkg/config"
+	"github.com/MaDal776/polymarket-go-client/pkg/portfolio"
+	"github.com/MaDal776/polymarket-go-client/pkg/types"
+)
+
+const replHelp = `Commands:
+  book <token-id> [depth]                 show the top of the order book
+  mid <token-id>                          show the midpoint
+  buy|sell <token-id> <price> <size> [T]  place a limit order (T: GTC, FOK, FAK)
+  orders                                  list open orders
+  cancel <order-id>...                    cancel orders
+  cancel-all                              cancel every open order
+  watch on|off                            stream fills as they happen
+  help                                    show this help
+  exit                                    leave the shell`
+
+// repl is an interactive operator shell
+type repl struct {
+	client  *client.ClobClient
+	address string
+	out     io.Writer
+
+	mu          sync.Mutex
+	stopWatch   context.CancelFunc
+	lastTradeTS int64
+}
+
+// runREPL starts the interactive shell
+func runREPL(cfg *config.Config, args []string) error {
+	clobClient, err := newClient(cfg, types.L2)
+	if err != nil {
+		return err
+	}
+
+	rl, err := readline.NewEx(&readline.Config{
+		Prompt:          "polyclob> ",
+		InterruptPrompt: "^C",
+		EOFPrompt:       "exit",
+		AutoComplete: readline.NewPrefixCompleter(
+			readline.PcItem("book"), readline.PcItem("mid"), readline.PcItem("buy"),
+			readline.PcItem("sell"), readline.PcItem("orders"), readline.PcItem("cancel"),
+			readline.PcItem("cancel-all"), readline.PcItem("watch", readline.PcItem("on"), readline.PcItem("off")),
+			readline.PcItem("help"), readline.PcItem("exit"),
+		),
+	})
+	if err != nil {
+		return fmt.Errorf("failed to start readline: %w", err)
+	}
+	defer rl.Close()
+
+	address := clobClient.GetAddress()
+	if cfg.Funder != "" {
+		address = cfg.Funder
+	}
+	r := &repl{client: clobClient, address: address, out: rl.Stdout()}
+	defer r.watch(false)
+
+	fmt.Fprintf(r.out, "Connected to %s as %s. Type 'help' for commands.\n", cfg.Host, address)
+	for {
+		line, err := rl.Readline()
+		if err == readline.ErrInterrupt {
+			continue
+		}
+		if err != nil {
+			return nil
+		}
+
+		fields := strings.Fields(line)
+		if len(fields) == 0 {
+			continue
+		}
+		if fields[0] == "exit" || fields[0] == "quit" {
+			return nil
+		}
+		if err := r.exec(fields[0], fields[1:]); err != nil {
+			fmt.Fprintf(r.out, "error: %v\n", err)
+		}
+	}
+}
+
+// exec runs a single shell command
+func (r *repl) exec(name string, args []string) error {
+	switch name {
+	case "help":
+		fmt.Fprintln(r.out, replHelp)
+	case "book":
+		return r.book(args)
+	case "mid":
+		if len(args) != 1 {
+			return fmt.Errorf("usage: mid <token-id>")
+		}
+		mid, err := r.client.GetMidpoint(args[0])
+		if err != nil {
+			return err
+		}
+		fmt.Fprintf(r.out, "mid %s\n", mid.Mid)
+	case "buy", "sell":
+		return r.place(types.OrderSide(strings.ToUpper(name)), args)
+	case "orders":
+		return r.orders()
+	case "cancel":
+		if len(args) == 0 {
+			return fmt.Errorf("usage: cancel <order-id>...")
+		}
+		resp, err := r.client.CancelOrders(args)
+		if err != nil {
+			return err
+		}
+		fmt.Fprintf(r.out, "%v\n", resp)
+	case "cancel-all":
+		resp, err := r.client.CancelAll()
+		if err != nil {
+			return err
+		}
+		fmt.Fprintf(r.out, "%v\n", resp)
+	case "watch":
+		if len(args) != 1 || (args[0] != "on" && args[0] != "off") {
+			return fmt.Errorf("usage: watch on|off")
+		}
+		r.watch(args[0] == "on")
+	default:
+		return fmt.Errorf("unknown command %q (type 'help')", name)
+	}
+	return nil
+}
+
+// book prints the best levels on each side of the book
+func (r *repl) book(args []string) error {
+	if len(args) < 1 {
+		return fmt.Errorf("usage: book <token-id> [depth]")
+	}
+	depth := 5
+	if len(args) > 1 {
+		if n, err := strconv.Atoi(args[1]); err == nil && n > 0 {
+			depth = n
+		}
+	}
+
+	book, err := r.client.GetOrderBook(args[0])
+	if err != nil {
+		return err
+	}
+
+	// The API returns bids ascending and asks descending; best levels are last
+	asks := book.Asks
+	if len(asks) > depth {
+		asks = asks[len(asks)-depth:]
+	}
+	for _, level := range asks {
+		fmt.Fprintf(r.out, "  ask %8s  %s\n", level.Price, level.Size)
+	}
+	fmt.Fprintln(r.out, "  ----")
+	for i := len(book.Bids) - 1; i >= 0 && i >= len(book.Bids)-depth; i-- {
+		fmt.Fprintf(r.out, "  bid %8s  %s\n", book.Bids[i].Price, book.Bids[i].Size)
+	}
+	return nil
+}
+
+// place signs and posts a limit order
+func (r *repl) place(side types.OrderSide, args []string) error {
+	if len(args) < 3 {
+		return fmt.Errorf("usage: %s <token-id> <price> <size> [GTC|FOK|FAK]", strings.ToLower(string(side)))
+	}
+	price, err := strconv.ParseFloat(args[1], 64)
+	if err != nil {
+		return fmt.Errorf("invalid price: %s", args[1])
+	}
+	size, err := strconv.ParseFloat(args[2], 64)
+	if err != nil {
+		return fmt.Errorf("invalid size: %s", args[2])
+	}
+	orderType := types.GTC
+	if len(args) > 3 {
+		orderType = types.OrderType(strings.ToUpper(args[3]))
+	}
+
+	signedOrder, err := r.client.CreateOrder(types.OrderArgs{
+		TokenID: args[0],
+		Price:   price,
+		Size:    size,
+		Side:    side,
+	}, nil)
+	if err != nil {
+		return err
+	}
+	resp, err := r.client.PostOrder(signedOrder, orderType)
+	if err != nil {
+		return err
+	}
+	fmt.Fprintf(r.out, "order %v status %v\n", resp["orderID"], resp["status"])
+	return nil
+}
+
+// orders lists open orders
+func (r *repl) orders() error {
+	orders, err := r.client.GetOpenOrders(nil)
+	if err != nil {
+		return err
+	}
+	if len(orders) == 0 {
+		fmt.Fprintln(r.out, "no open orders")
+		return nil
+	}
+	for _, order := range orders {
+		fmt.Fprintf(r.out, "  %s %s %s @ %s matched %s/%s\n",
+			order.ID, order.Side, order.AssetID, order.Price, order.SizeMatched, order.OriginalSize)
+	}
+	return nil
+}
+
+// watch starts or stops the background fill poller
+func (r *repl) watch(enable bool) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if r.stopWatch != nil {
+		r.stopWatch()
+		r.stopWatch = nil
+	}
+	if !enable {
+		return
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	r.stopWatch = cancel
+	if r.lastTradeTS == 0 {
+		r.lastTradeTS = time.Now().Unix()
+	}
+	go r.pollFills(ctx)
+	fmt.Fprintln(r.out, "watching fills")
+}
+
+// pollFills prints new fills until ctx is cancelled
+func (r *repl) pollFills(ctx context.Context) {
+	ticker := time.NewTicker(2 * time.Second)
+	defer ticker.Stop()
+	seen := make(map[string]bool)
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+
+		r.mu.Lock()
+		after := r.lastTradeTS
+		r.mu.Unlock()
+
+		trades, err := r.client.GetTrades(&types.TradeParams{After: after - 1})
+		if err != nil {
+			fmt.Fprintf(r.out, "watch: %v\n", err)
+			continue
+		}
+
+		for _, trade := range trades {
+			fills, err := portfolio.FillsFromTrade(trade, r.address)
+			if err != nil {
+				continue
+			}
+			for _, fill := range fills {
+				key := fill.TradeID + "/" + fill.OrderID
+				if seen[key] {
+					continue
+				}
+				seen[key] = true
+				fmt.Fprintf(r.out, "FILL %s %s %g @ %g (order %s)\n", fill.Side, fill.TokenID, fill.Size, fill.Price, fill.OrderID)
+
+				r.mu.Lock()
+				if ts := fill.Timestamp.Unix(); ts > r.lastTradeTS {
+					r.lastTradeTS = ts
+				}
+				r.mu.Unlock()
+			}
+		}
+	}
+}
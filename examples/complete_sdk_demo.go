@@ -8,8 +8,10 @@ import (
 	"strings"
 	"time"
 
-	"polymarket-clob-go/pkg/client"
-	"polymarket-clob-go/pkg/types"
+	"github.com/MaDal776/polymarket-go-client/pkg/amount"
+	"github.com/MaDal776/polymarket-go-client/pkg/client"
+	"github.com/MaDal776/polymarket-go-client/pkg/config"
+	"github.com/MaDal776/polymarket-go-client/pkg/types"
 )
 
 // 配置结构
@@ -65,7 +67,7 @@ func main() {
 	
 	fmt.Printf("✅ API 凭证设置完成 (耗时: %v)\n", authDuration)
 	fmt.Printf("   认证级别: %d\n", clobClient.GetAuthLevel())
-	fmt.Printf("   API Key: %s...\n", apiCreds.Key[:10])
+	fmt.Printf("   API Key: %s...\n", apiCreds.ApiKey[:10])
 
 	// 4. 获取余额信息
 	fmt.Println("\n💰 步骤 3: 检查账户余额")
@@ -94,7 +96,7 @@ func main() {
 	
 	marketStart := time.Now()
 	tickSize, negRisk := getMarketData(clobClient, config.TokenID)
-	buyPrice, sellPrice := getPriceData(clobClient, config.TokenID)
+	getPriceData(clobClient, config.TokenID)
 	marketDuration := time.Since(marketStart)
 	
 	fmt.Printf("✅ 市场数据获取完成 (耗时: %v)\n", marketDuration)
@@ -133,19 +135,22 @@ func main() {
 
 // 加载配置
 func loadConfig() *Config {
-	config := &Config{
-		PrivateKey:    os.Getenv("PRIVATE_KEY"),
-		Host:          getEnvOrDefault("POLYMARKET_HOST", "https://clob.polymarket.com"),
-		ChainID:       getEnvAsIntOrDefault("CHAIN_ID", 137),
-		SignatureType: int(getEnvAsIntOrDefault("SIGNATURE_TYPE", 1)),
-		TokenID:       getEnvOrDefault("TOKEN_ID", "91094360697357622623953793720402150934374522251651348543981406747516093190659"),
+	cfg, err := config.Load(os.Getenv("POLYMARKET_CONFIG"))
+	if err != nil {
+		log.Fatalf("❌ 配置无效: %v", err)
 	}
 
-	if config.PrivateKey == "" {
+	if cfg.PrivateKey == "" {
 		log.Fatal("❌ 请设置 PRIVATE_KEY 环境变量")
 	}
 
-	return config
+	return &Config{
+		PrivateKey:    cfg.PrivateKey,
+		Host:          cfg.Host,
+		ChainID:       cfg.ChainID,
+		SignatureType: cfg.SignatureType,
+		TokenID:       config.EnvOrDefault("TOKEN_ID", "91094360697357622623953793720402150934374522251651348543981406747516093190659"),
+	}
 }
 
 // 打印配置信息
@@ -179,8 +184,8 @@ func checkUSDCBalance(client *client.ClobClient, signatureType int) (float64, bo
 	fmt.Printf("📊 授权额度: %s\n", usdcBalance.Allowance)
 
 	if usdcBalance.Balance != "" && usdcBalance.Balance != "0" {
-		if balance, err := strconv.ParseFloat(usdcBalance.Balance, 64); err == nil {
-			usdcAmount = balance / 1000000 // USDC 有 6 位小数
+		if balance, err := amount.USDCFromUnits(usdcBalance.Balance); err == nil {
+			usdcAmount = balance.Float64()
 			hasBalance = usdcAmount > 0
 			fmt.Printf("💰 USDC 余额: %.6f USDC\n", usdcAmount)
 			
@@ -216,9 +221,8 @@ func checkTokenBalance(client *client.ClobClient, tokenID string, signatureType
 	fmt.Printf("📊 代币原始余额: %s\n", tokenBalance.Balance)
 
 	if tokenBalance.Balance != "" && tokenBalance.Balance != "0" {
-		if balance, err := strconv.ParseFloat(tokenBalance.Balance, 64); err == nil {
-			tokenAmount := balance / 1000000 // 假设 6 位小数
-			fmt.Printf("🎯 代币数量: %.6f tokens\n", tokenAmount)
+		if balance, err := amount.SharesFromUnits(tokenBalance.Balance); err == nil {
+			fmt.Printf("🎯 代币数量: %s tokens\n", balance.ToHuman())
 		}
 	} else {
 		fmt.Printf("🎯 代币数量: 0.000000 tokens\n")
@@ -464,20 +468,3 @@ func printPerformanceSummary(client *client.ClobClient, durations map[string]tim
 	fmt.Println("\n📊 详细性能指标:")
 	client.PrintMetrics()
 }
-
-// 辅助函数
-func getEnvOrDefault(key, defaultValue string) string {
-	if value := os.Getenv(key); value != "" {
-		return value
-	}
-	return defaultValue
-}
-
-func getEnvAsIntOrDefault(key string, defaultValue int64) int64 {
-	if value := os.Getenv(key); value != "" {
-		if intValue, err := strconv.ParseInt(value, 10, 64); err == nil {
-			return intValue
-		}
-	}
-	return defaultValue
-}
\ No newline at end of file
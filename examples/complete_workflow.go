@@ -6,8 +6,9 @@ import (
 	"os"
 	"time"
 
-	"polymarket-clob-go/pkg/client"
-	"polymarket-clob-go/pkg/types"
+	"github.com/MaDal776/polymarket-go-client/pkg/client"
+	"github.com/MaDal776/polymarket-go-client/pkg/config"
+	"github.com/MaDal776/polymarket-go-client/pkg/types"
 )
 
 const (
@@ -22,8 +23,12 @@ func main() {
 	fmt.Println("=== Polymarket CLOB Go SDK - Complete Workflow ===\n")
 	
 	// Load configuration from environment
-	host := getEnvOrDefault("CLOB_API_URL", "https://clob.polymarket.com")
-	privateKey := os.Getenv("PRIVATE_KEY")
+	cfg, err := config.Load(os.Getenv("POLYMARKET_CONFIG"))
+	if err != nil {
+		log.Fatalf("Invalid configuration: %v", err)
+	}
+	host := cfg.Host
+	privateKey := cfg.PrivateKey
 	
 	if privateKey == "" {
 		log.Fatal("PRIVATE_KEY environment variable is required")
@@ -182,7 +187,7 @@ func main() {
 	fmt.Println("       Side:       types.SELL,")
 	fmt.Println("       FeeRateBps: 0,")
 	fmt.Println("       Nonce:      time.Now().Unix() + 2,")
-	fmt.Println("       Expiration: time.Now().Add(12 * time.Hour).Unix(),")
+	fmt.Println("       ExpireAfter: 12 * time.Hour,")
 	fmt.Println("       Taker:      \"0x0000000000000000000000000000000000000000\",")
 	fmt.Println("   }")
 	fmt.Println("   ")
@@ -221,10 +226,3 @@ func main() {
 	fmt.Printf("Total Time: %v\n", totalDuration)
 	fmt.Printf("Average Time per Operation: %v\n", totalDuration/time.Duration(len(metrics)))
 }
-
-func getEnvOrDefault(key, defaultValue string) string {
-	if value := os.Getenv(key); value != "" {
-		return value
-	}
-	return defaultValue
-}
\ No newline at end of file
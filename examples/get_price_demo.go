@@ -6,8 +6,9 @@ import (
 	"os"
 	"strconv"
 
-	"polymarket-clob-go/pkg/client"
-	"polymarket-clob-go/pkg/types"
+	"github.com/MaDal776/polymarket-go-client/pkg/client"
+	"github.com/MaDal776/polymarket-go-client/pkg/config"
+	"github.com/MaDal776/polymarket-go-client/pkg/types"
 )
 
 func main() {
@@ -15,14 +16,18 @@ func main() {
 	fmt.Println("==========================================")
 
 	// 配置
-	host := getEnvOrDefault("POLYMARKET_HOST", "https://clob.polymarket.com")
-	chainID := getEnvAsIntOrDefault("CHAIN_ID", 137)
-	privateKey := os.Getenv("PRIVATE_KEY")
-	signatureType := int(getEnvAsIntOrDefault("SIGNATURE_TYPE", 0))
+	cfg, err := config.Load(os.Getenv("POLYMARKET_CONFIG"))
+	if err != nil {
+		log.Fatalf("❌ 配置无效: %v", err)
+	}
+	host := cfg.Host
+	chainID := cfg.ChainID
+	privateKey := cfg.PrivateKey
+	signatureType := cfg.SignatureType
 
 	// 示例代币 ID (可以通过环境变量覆盖)
 	// 使用 Python 示例中验证有效的 token ID
-	tokenID := getEnvOrDefault("TOKEN_ID", "91094360697357622623953793720402150934374522251651348543981406747516093190659")
+	tokenID := config.EnvOrDefault("TOKEN_ID", "91094360697357622623953793720402150934374522251651348543981406747516093190659")
 
 	fmt.Printf("📋 配置信息:\n")
 	fmt.Printf("   Host: %s\n", host)
@@ -31,7 +36,6 @@ func main() {
 
 	// 创建客户端 (价格查询不需要私钥，但为了完整性还是包含)
 	var clobClient *client.ClobClient
-	var err error
 
 	if privateKey != "" {
 		clobClient, err = client.NewClobClient(
@@ -192,20 +196,3 @@ func demonstratePriceUtilities(buyPrice, sellPrice *types.PriceResponse) {
 		fmt.Printf("   ⚠️  风险提示: 价差较大，注意流动性风险\n")
 	}
 }
-
-// 辅助函数
-func getEnvOrDefault(key, defaultValue string) string {
-	if value := os.Getenv(key); value != "" {
-		return value
-	}
-	return defaultValue
-}
-
-func getEnvAsIntOrDefault(key string, defaultValue int64) int64 {
-	if value := os.Getenv(key); value != "" {
-		if intValue, err := strconv.ParseInt(value, 10, 64); err == nil {
-			return intValue
-		}
-	}
-	return defaultValue
-}
\ No newline at end of file
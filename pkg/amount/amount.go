@@ -0,0 +1,172 @@
+// Package amount converts between human amounts and the 6-decimal base units
+// used on-chain and in the CLOB API for both USDC and outcome shares.
+//
+// Amounts are held as integer base units, so they add and compare exactly.
+// Conversions from float64 round to the nearest unit rather than truncating,
+// so 0.29 becomes 290000 and not 289999.
+package amount
+
+import (
+	"fmt"
+	"math"
+	"math/big"
+	"strconv"
+	"strings"
+)
+
+// Decimals is the number of decimals of USDC and outcome shares
+const Decimals = 6
+
+// Scale is the number of base units in one whole USDC or share
+const Scale = 1_000_000
+
+// USDC is an amount of USDC (or USDC.e) in base units
+type USDC int64
+
+// Shares is an amount of outcome tokens in base units
+type Shares int64
+
+// USDCFromFloat converts a human amount (e.g. 12.5) to USDC, rounding to the nearest unit
+func USDCFromFloat(value float64) USDC {
+	return USDC(ToUnits(value))
+}
+
+// USDCFromString parses a human decimal amount (e.g. "12.5")
+func USDCFromString(value string) (USDC, error) {
+	units, err := parseDecimal(value)
+	return USDC(units), err
+}
+
+// USDCFromUnits parses a base-unit integer amount (e.g. "12500000"), as returned
+// by the balance endpoints and carried in signed orders
+func USDCFromUnits(value string) (USDC, error) {
+	units, err := parseUnits(value)
+	return USDC(units), err
+}
+
+// ToBigInt returns the amount in base units
+func (u USDC) ToBigInt() *big.Int {
+	return big.NewInt(int64(u))
+}
+
+// ToHuman formats the amount as a decimal without trailing zeros (e.g. "12.5")
+func (u USDC) ToHuman() string {
+	return formatDecimal(int64(u))
+}
+
+// Float64 returns the human amount
+func (u USDC) Float64() float64 {
+	return float64(u) / Scale
+}
+
+// String implements fmt.Stringer
+func (u USDC) String() string {
+	return u.ToHuman()
+}
+
+// SharesFromFloat converts a human share quantity to Shares, rounding to the nearest unit
+func SharesFromFloat(value float64) Shares {
+	return Shares(ToUnits(value))
+}
+
+// SharesFromString parses a human decimal share quantity (e.g. "100.25")
+func SharesFromString(value string) (Shares, error) {
+	units, err := parseDecimal(value)
+	return Shares(units), err
+}
+
+// SharesFromUnits parses a base-unit integer share quantity (e.g. "100250000")
+func SharesFromUnits(value string) (Shares, error) {
+	units, err := parseUnits(value)
+	return Shares(units), err
+}
+
+// ToBigInt returns the quantity in base units
+func (s Shares) ToBigInt() *big.Int {
+	return big.NewInt(int64(s))
+}
+
+// ToHuman formats the quantity as a decimal without trailing zeros
+func (s Shares) ToHuman() string {
+	return formatDecimal(int64(s))
+}
+
+// Float64 returns the human quantity
+func (s Shares) Float64() float64 {
+	return float64(s) / Scale
+}
+
+// String implements fmt.Stringer
+func (s Shares) String() string {
+	return s.ToHuman()
+}
+
+// ToUnits converts a human amount to base units, rounding to the nearest unit.
+// NaN converts to 0.
+func ToUnits(value float64) int64 {
+	if math.IsNaN(value) {
+		return 0
+	}
+	return int64(math.Round(value * Scale))
+}
+
+// parseDecimal parses a human decimal with at most Decimals decimals into base units
+func parseDecimal(value string) (int64, error) {
+	text := strings.TrimSpace(value)
+	// At most one sign; a second is caught as a non-digit below
+	negative := strings.HasPrefix(text, "-")
+	if negative || strings.HasPrefix(text, "+") {
+		text = text[1:]
+	}
+
+	whole, fraction, _ := strings.Cut(text, ".")
+	if whole == "" && fraction == "" {
+		return 0, fmt.Errorf("invalid amount %q", value)
+	}
+	if len(fraction) > Decimals {
+		return 0, fmt.Errorf("amount %q has more than %d decimals", value, Decimals)
+	}
+	if whole == "" {
+		whole = "0"
+	}
+	digits := whole + fraction + strings.Repeat("0", Decimals-len(fraction))
+	for _, r := range digits {
+		if r < '0' || r > '9' {
+			return 0, fmt.Errorf("invalid amount %q", value)
+		}
+	}
+	units, err := strconv.ParseInt(digits, 10, 64)
+	if err != nil {
+		return 0, fmt.Errorf("invalid amount %q: %w", value, err)
+	}
+	if negative {
+		units = -units
+	}
+	return units, nil
+}
+
+// parseUnits parses a base-unit integer
+func parseUnits(value string) (int64, error) {
+	units, err := strconv.ParseInt(strings.TrimSpace(value), 10, 64)
+	if err != nil {
+		return 0, fmt.Errorf("invalid base unit amount %q: %w", value, err)
+	}
+	return units, nil
+}
+
+// formatDecimal formats base units as a human decimal without trailing zeros
+func formatDecimal(units int64) string {
+	sign := ""
+	magnitude := uint64(units)
+	if units < 0 {
+		sign = "-"
+		magnitude = uint64(-units)
+	}
+	whole := magnitude / Scale
+	fraction := magnitude % Scale
+	if fraction == 0 {
+		return fmt.Sprintf("%s%d", sign, whole)
+	}
+	decimals := strings.TrimRight(fmt.Sprintf("%06d", fraction), "0")
+	return fmt.Sprintf("%s%d.%s", sign, whole, decimals)
+}
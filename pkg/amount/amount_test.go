@@ -0,0 +1,42 @@
+package amount
+
+import "testing"
+
+func TestFromFloatRounds(t *testing.T) {
+	// 0.29 * 1e6 is 289999.99999999997 in float64; truncation would lose a unit
+	if got := USDCFromFloat(0.29); got != 290000 {
+		t.Errorf("USDCFromFloat(0.29) = %d, want 290000", got)
+	}
+	if got := SharesFromFloat(1.1); got.ToBigInt().String() != "1100000" {
+		t.Errorf("SharesFromFloat(1.1) = %s, want 1100000", got.ToBigInt())
+	}
+}
+
+func TestFromString(t *testing.T) {
+	cases := map[string]int64{"12.5": 12500000, "0.000001": 1, ".25": 250000, "-3": -3000000, "7": 7000000, "+5": 5000000}
+	for input, want := range cases {
+		got, err := USDCFromString(input)
+		if err != nil || int64(got) != want {
+			t.Errorf("USDCFromString(%q) = %d, %v, want %d", input, got, err, want)
+		}
+	}
+	for _, input := range []string{"", "1.0000001", "abc", "1.2.3", ".", "-+5", "+-5", "--5", "-"} {
+		if _, err := SharesFromString(input); err == nil {
+			t.Errorf("SharesFromString(%q) should fail", input)
+		}
+	}
+}
+
+func TestToHuman(t *testing.T) {
+	cases := map[USDC]string{12500000: "12.5", 1: "0.000001", 0: "0", -2500000: "-2.5", 3000000: "3"}
+	for value, want := range cases {
+		if got := value.ToHuman(); got != want {
+			t.Errorf("%d.ToHuman() = %q, want %q", int64(value), got, want)
+		}
+	}
+
+	units, err := SharesFromUnits("100250000")
+	if err != nil || units.ToHuman() != "100.25" || units.Float64() != 100.25 {
+		t.Errorf("SharesFromUnits = %v, %v", units, err)
+	}
+}
@@ -1,16 +1,22 @@
 package auth
 
 import (
+	"bytes"
 	"crypto/hmac"
 	"crypto/sha256"
 	"encoding/base64"
 	"encoding/json"
 	"fmt"
+	"hash"
+	"strconv"
 	"strings"
+	"sync"
+	"sync/atomic"
 	"time"
 
-	"polymarket-clob-go/pkg/signer"
-	"polymarket-clob-go/pkg/types"
+	"github.com/MaDal776/polymarket-go-client/pkg/clock"
+	"github.com/MaDal776/polymarket-go-client/pkg/signer"
+	"github.com/MaDal776/polymarket-go-client/pkg/types"
 )
 
 const (
@@ -24,8 +30,25 @@ const (
 
 // HeaderBuilder handles authentication header creation
 type HeaderBuilder struct {
-	signer  *signer.Signer
-	metrics []types.PerformanceMetrics
+	signer    *signer.Signer
+	metrics   []types.PerformanceMetrics
+	metricsMu sync.Mutex
+	
+	// Metrics output, set by SetMetricsOutput and guarded by metricsMu
+	onMetric       func(metric types.PerformanceMetrics)
+	discardMetrics bool
+	
+	// Decoded API secret and its HMAC pool, set by SetSecret
+	secret atomic.Pointer[hmacSecret]
+	
+	// Source of header timestamps, set by SetClock
+	clock clock.Clock
+}
+
+// hmacSecret caches a decoded API secret together with a pool of keyed HMACs
+type hmacSecret struct {
+	encoded string
+	macs    sync.Pool
 }
 
 // NewHeaderBuilder creates a new header builder
@@ -33,14 +56,24 @@ func NewHeaderBuilder(s *signer.Signer) *HeaderBuilder {
 	return &HeaderBuilder{
 		signer:  s,
 		metrics: make([]types.PerformanceMetrics, 0),
+		clock:   clock.System,
 	}
 }
 
+// SetClock sets the clock header timestamps are read from, e.g. one synced to the
+// server. Call it before building headers.
+func (h *HeaderBuilder) SetClock(clk clock.Clock) {
+	if clk == nil {
+		clk = clock.System
+	}
+	h.clock = clk
+}
+
 // CreateLevel1Headers creates Level 1 authentication headers
 func (h *HeaderBuilder) CreateLevel1Headers(nonce int64) (map[string]string, error) {
 	start := time.Now()
 	
-	timestamp := time.Now().Unix()
+	timestamp := h.clock.Now().Unix()
 	
 	// Sign CLOB auth message
 	signature, err := h.signer.SignClobAuth(timestamp, nonce)
@@ -64,7 +97,7 @@ func (h *HeaderBuilder) CreateLevel1Headers(nonce int64) (map[string]string, err
 func (h *HeaderBuilder) CreateLevel2Headers(creds *types.ApiCreds, requestArgs types.RequestArgs) (map[string]string, error) {
 	start := time.Now()
 	
-	timestamp := time.Now().Unix()
+	timestamp := h.clock.Now().Unix()
 	
 	// Build HMAC signature
 	hmacSig, err := h.buildHMACSignature(creds.ApiSecret, timestamp, requestArgs)
@@ -85,16 +118,72 @@ func (h *HeaderBuilder) CreateLevel2Headers(creds *types.ApiCreds, requestArgs t
 	return headers, nil
 }
 
+// SetSecret decodes the base64 API secret once so Level 2 headers signed with it
+// reuse the raw key. Headers for other secrets are still signed, decoding per request.
+func (h *HeaderBuilder) SetSecret(secret string) error {
+	decodedSecret, err := base64.URLEncoding.DecodeString(secret)
+	if err != nil {
+		return fmt.Errorf("failed to decode secret: %w", err)
+	}
+	
+	cached := &hmacSecret{encoded: secret}
+	cached.macs.New = func() interface{} {
+		return hmac.New(sha256.New, decodedSecret)
+	}
+	h.secret.Store(cached)
+	return nil
+}
+
+// macFor returns an HMAC keyed with secret and a function returning it to its pool
+func (h *HeaderBuilder) macFor(secret string) (hash.Hash, func(), error) {
+	if cached := h.secret.Load(); cached != nil && cached.encoded == secret {
+		mac := cached.macs.Get().(hash.Hash)
+		mac.Reset()
+		return mac, func() { cached.macs.Put(mac) }, nil
+	}
+	
+	decodedSecret, err := base64.URLEncoding.DecodeString(secret)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to decode secret: %w", err)
+	}
+	return hmac.New(sha256.New, decodedSecret), func() {}, nil
+}
+
+// SignLevel2 computes the Level 2 HMAC signature for a pre-encoded request body
+// without recording metrics. It is used on latency-critical paths.
+func (h *HeaderBuilder) SignLevel2(secret string, timestamp int64, method, requestPath string, body []byte) (string, error) {
+	mac, release, err := h.macFor(secret)
+	if err != nil {
+		return "", err
+	}
+	defer release()
+	
+	var buf [512]byte
+	message := strconv.AppendInt(buf[:0], timestamp, 10)
+	message = append(message, method...)
+	message = append(message, requestPath...)
+	message = append(message, body...)
+	if bytes.IndexByte(body, '\'') >= 0 {
+		// Match the single-quote replacement applied to marshaled bodies
+		message = bytes.ReplaceAll(message, []byte("'"), []byte("\""))
+	}
+	mac.Write(message)
+	
+	var sum [sha256.Size]byte
+	return base64.URLEncoding.EncodeToString(mac.Sum(sum[:0])), nil
+}
+
 // buildHMACSignature builds HMAC signature for Level 2 auth
 func (h *HeaderBuilder) buildHMACSignature(secret string, timestamp int64, requestArgs types.RequestArgs) (string, error) {
 	start := time.Now()
 	
-	// Decode base64 secret
-	decodedSecret, err := base64.URLEncoding.DecodeString(secret)
+	// Get an HMAC keyed with the decoded secret
+	mac, release, err := h.macFor(secret)
 	if err != nil {
 		h.recordMetric("hmac_signature_build", start, false, err.Error())
-		return "", fmt.Errorf("failed to decode secret: %w", err)
+		return "", err
 	}
+	defer release()
 	
 	// Build message to sign
 	message := fmt.Sprintf("%d%s%s", timestamp, requestArgs.Method, requestArgs.RequestPath)
@@ -111,8 +200,7 @@ func (h *HeaderBuilder) buildHMACSignature(secret string, timestamp int64, reque
 		message += bodyStr
 	}
 	
-	// Create HMAC
-	mac := hmac.New(sha256.New, decodedSecret)
+	// Sign message
 	mac.Write([]byte(message))
 	signature := mac.Sum(nil)
 	
@@ -123,13 +211,29 @@ func (h *HeaderBuilder) buildHMACSignature(secret string, timestamp int64, reque
 	return encodedSignature, nil
 }
 
+// SetMetricsOutput sets a callback for every recorded metric and whether
+// metrics are also kept for GetMetrics; disabling buffering drops those kept so far
+func (h *HeaderBuilder) SetMetricsOutput(onMetric func(metric types.PerformanceMetrics), buffer bool) {
+	h.metricsMu.Lock()
+	defer h.metricsMu.Unlock()
+	h.onMetric = onMetric
+	h.discardMetrics = !buffer
+	if !buffer {
+		h.metrics = make([]types.PerformanceMetrics, 0)
+	}
+}
+
 // GetMetrics returns performance metrics
 func (h *HeaderBuilder) GetMetrics() []types.PerformanceMetrics {
-	return h.metrics
+	h.metricsMu.Lock()
+	defer h.metricsMu.Unlock()
+	return append([]types.PerformanceMetrics(nil), h.metrics...)
 }
 
 // ClearMetrics clears performance metrics
 func (h *HeaderBuilder) ClearMetrics() {
+	h.metricsMu.Lock()
+	defer h.metricsMu.Unlock()
 	h.metrics = make([]types.PerformanceMetrics, 0)
 }
 
@@ -142,5 +246,14 @@ func (h *HeaderBuilder) recordMetric(operation string, startTime time.Time, succ
 		Success:   success,
 		Error:     errorMsg,
 	}
-	h.metrics = append(h.metrics, metric)
+	h.metricsMu.Lock()
+	if !h.discardMetrics {
+		h.metrics = append(h.metrics, metric)
+	}
+	onMetric := h.onMetric
+	h.metricsMu.Unlock()
+	
+	if onMetric != nil {
+		onMetric(metric)
+	}
 }
\ No newline at end of file
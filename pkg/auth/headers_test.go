@@ -0,0 +1,72 @@
+package auth
+
+import (
+	"encoding/base64"
+	"testing"
+
+	"github.com/MaDal776/polymarket-go-client/pkg/signer"
+	"github.com/MaDal776/polymarket-go-client/pkg/types"
+)
+
+const testPrivateKey = "0xac0974bec39a17e36ba4a6b4d238ff944bacb478cbed5efcae784d7bf4f2ff80"
+
+var testSecret = base64.URLEncoding.EncodeToString([]byte("0123456789abcdef0123456789abcdef"))
+
+func newTestHeaderBuilder(t testing.TB) *HeaderBuilder {
+	s, err := signer.NewSigner(testPrivateKey, 137)
+	if err != nil {
+		t.Fatalf("Failed to create signer: %v", err)
+	}
+	return NewHeaderBuilder(s)
+}
+
+func TestCachedSecretSignsIdentically(t *testing.T) {
+	h := newTestHeaderBuilder(t)
+	args := types.RequestArgs{Method: "POST", RequestPath: "/order", Body: map[string]string{"a": "b"}}
+
+	uncached, err := h.buildHMACSignature(testSecret, 1700000000, args)
+	if err != nil {
+		t.Fatalf("Failed to sign without cache: %v", err)
+	}
+
+	if err := h.SetSecret(testSecret); err != nil {
+		t.Fatalf("Failed to set secret: %v", err)
+	}
+	for i := 0; i < 3; i++ {
+		cached, err := h.buildHMACSignature(testSecret, 1700000000, args)
+		if err != nil {
+			t.Fatalf("Failed to sign with cache: %v", err)
+		}
+		if cached != uncached {
+			t.Fatalf("Cached signature %s differs from %s", cached, uncached)
+		}
+	}
+
+	// A different secret still works and is not confused with the cached one
+	other := base64.URLEncoding.EncodeToString([]byte("another secret"))
+	otherSig, err := h.buildHMACSignature(other, 1700000000, args)
+	if err != nil {
+		t.Fatalf("Failed to sign with other secret: %v", err)
+	}
+	if otherSig == uncached {
+		t.Errorf("Expected a different signature for a different secret")
+	}
+}
+
+func TestSetSecretRejectsInvalidBase64(t *testing.T) {
+	h := newTestHeaderBuilder(t)
+	if err := h.SetSecret("not base64!"); err == nil {
+		t.Errorf("Expected invalid secret to be rejected")
+	}
+}
+
+func BenchmarkBuildHMACSignature(b *testing.B) {
+	h := newTestHeaderBuilder(b)
+	h.SetSecret(testSecret)
+	args := types.RequestArgs{Method: "DELETE", RequestPath: "/order"}
+
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		h.buildHMACSignature(testSecret, 1700000000, args)
+	}
+}
@@ -0,0 +1,275 @@
+// Package basket builds orders across every outcome of a neg risk event. Exactly
+// one outcome of such an event resolves Yes, so a basket of one share of each
+// outcome has a known payout: 1 USDC for a Yes basket and N-1 USDC for a No
+// basket of N outcomes. A basket bought for less than its payout locks in the
+// difference, provided the markets cover every outcome of the event.
+package basket
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"strconv"
+
+	"github.com/MaDal776/polymarket-go-client/pkg/client"
+	"github.com/MaDal776/polymarket-go-client/pkg/fees"
+	"github.com/MaDal776/polymarket-go-client/pkg/types"
+	"github.com/MaDal776/polymarket-go-client/pkg/utils"
+)
+
+// Spec describes the basket to build
+type Spec struct {
+	Outcome  string  // client.OutcomeYes or client.OutcomeNo; default No
+	Size     float64 // Shares bought of each outcome; required
+	Slippage float64 // Added to each leg's limit price
+}
+
+// Leg is the order on one outcome of the event
+type Leg struct {
+	ConditionID string                   `json:"condition_id"`
+	Question    string                   `json:"question"`
+	TokenID     string                   `json:"token_id"`
+	TickSize    types.TickSize           `json:"tick_size"`
+	Price       float64                  `json:"price"`   // Limit price: the last ask level needed plus slippage
+	Average     float64                  `json:"average"` // Size-weighted price of the asks filling the leg
+	Size        float64                  `json:"size"`
+	Received    float64                  `json:"received"` // Shares received after taker fees
+	Cost        float64                  `json:"cost"`     // Average * Size
+	Options     types.CreateOrderOptions `json:"-"`
+}
+
+// Basket is a set of orders across every outcome of a neg risk event
+type Basket struct {
+	NegRiskMarketID string  `json:"neg_risk_market_id"`
+	Outcome         string  `json:"outcome"`
+	Legs            []Leg   `json:"legs"`
+	Cost            float64 `json:"cost"`     // Expected USDC spent at the book's prices
+	MaxCost         float64 `json:"max_cost"` // USDC spent if every leg fills at its limit price
+	Payout          float64 `json:"payout"`   // USDC the basket redeems for whichever outcome wins
+	Edge            float64 `json:"edge"`     // Payout - Cost
+}
+
+// EdgeBps returns the edge relative to the cost, in basis points
+func (b *Basket) EdgeBps() float64 {
+	if b.Cost <= 0 {
+		return 0
+	}
+	return b.Edge / b.Cost * 10000
+}
+
+// BookSource fetches order books (e.g. the CLOB client)
+type BookSource interface {
+	GetOrderBooks(ctx context.Context, tokenIDs []string, concurrency int) (map[string]*types.OrderBookSummary, error)
+}
+
+// Quote fetches the books of every outcome and builds a basket from them
+func Quote(ctx context.Context, source BookSource, markets []types.Market, spec Spec) (*Basket, error) {
+	outcome := outcomeOf(spec)
+	tokenIDs := make([]string, 0, len(markets))
+	for i := range markets {
+		tokenID, err := client.OutcomeTokenID(&markets[i], outcome)
+		if err != nil {
+			return nil, err
+		}
+		tokenIDs = append(tokenIDs, tokenID)
+	}
+
+	books, err := source.GetOrderBooks(ctx, tokenIDs, len(tokenIDs))
+	if err != nil {
+		return nil, fmt.Errorf("failed to get order books: %w", err)
+	}
+	return Build(markets, books, spec)
+}
+
+// Build prices a basket buying spec.Size shares of spec.Outcome in every market
+// of a neg risk event, taking each leg's liquidity from books (keyed by token ID)
+func Build(markets []types.Market, books map[string]*types.OrderBookSummary, spec Spec) (*Basket, error) {
+	if spec.Size <= 0 {
+		return nil, fmt.Errorf("basket size must be positive")
+	}
+	if len(markets) < 2 {
+		return nil, fmt.Errorf("a basket needs at least 2 markets, got %d", len(markets))
+	}
+
+	basket := &Basket{
+		NegRiskMarketID: markets[0].NegRiskMarketID,
+		Outcome:         outcomeOf(spec),
+		Legs:            make([]Leg, 0, len(markets)),
+	}
+	for i := range markets {
+		market := &markets[i]
+		if !market.NegRisk {
+			return nil, fmt.Errorf("market %s is not neg risk", market.ConditionID)
+		}
+		if market.NegRiskMarketID != basket.NegRiskMarketID {
+			return nil, fmt.Errorf("market %s belongs to event %s, not %s", market.ConditionID, market.NegRiskMarketID, basket.NegRiskMarketID)
+		}
+		if market.Closed {
+			return nil, fmt.Errorf("market %s is closed", market.ConditionID)
+		}
+
+		leg, err := buildLeg(market, books, basket.Outcome, spec)
+		if err != nil {
+			return nil, err
+		}
+		basket.Legs = append(basket.Legs, leg)
+		basket.Cost += leg.Cost
+		basket.MaxCost += leg.Price * leg.Size
+	}
+
+	basket.Payout = payout(basket.Outcome, basket.Legs)
+	basket.Edge = basket.Payout - basket.Cost
+	return basket, nil
+}
+
+// buildLeg prices the order on one market's outcome
+func buildLeg(market *types.Market, books map[string]*types.OrderBookSummary, outcome string, spec Spec) (Leg, error) {
+	tokenID, err := client.OutcomeTokenID(market, outcome)
+	if err != nil {
+		return Leg{}, err
+	}
+	tickSize, err := utils.ParseTickSizeString(strconv.FormatFloat(market.MinimumTickSize, 'g', -1, 64))
+	if err != nil {
+		return Leg{}, fmt.Errorf("market %s: %w", market.ConditionID, err)
+	}
+	book, ok := books[tokenID]
+	if !ok || book == nil {
+		return Leg{}, fmt.Errorf("no order book for token %s", tokenID)
+	}
+
+	_, asks, err := book.Levels()
+	if err != nil {
+		return Leg{}, fmt.Errorf("invalid order book for token %s: %w", tokenID, err)
+	}
+	last, cost, ok := fill(asks, spec.Size)
+	if !ok {
+		return Leg{}, fmt.Errorf("asks of token %s cannot fill %.2f shares", tokenID, spec.Size)
+	}
+
+	average := cost / spec.Size
+	fee := fees.ModelFromMarket(market).Fee(types.BUY, average, spec.Size, true)
+	return Leg{
+		ConditionID: market.ConditionID,
+		Question:    market.Question,
+		TokenID:     tokenID,
+		TickSize:    tickSize,
+		Price:       utils.SnapPrice(last+spec.Slippage, tickSize, types.RoundUp),
+		Average:     average,
+		Size:        spec.Size,
+		Received:    spec.Size - fee.Shares,
+		Cost:        cost,
+		Options:     types.CreateOrderOptions{TickSize: tickSize, NegRisk: true},
+	}, nil
+}
+
+// fill walks best-first asks until size shares are bought, returning the last
+// price reached and the total cost
+func fill(asks []types.PriceLevel, size float64) (last, cost float64, ok bool) {
+	remaining := size
+	for _, level := range asks {
+		take := math.Min(level.Size, remaining)
+		cost += take * level.Price
+		last = level.Price
+		remaining -= take
+		if remaining <= 1e-9 {
+			return last, cost, true
+		}
+	}
+	return 0, 0, false
+}
+
+// payout returns what the basket redeems for in the worst case: the winning
+// outcome's Yes shares, or every No share except the winner's
+func payout(outcome string, legs []Leg) float64 {
+	least, most, total := math.Inf(1), 0.0, 0.0
+	for _, leg := range legs {
+		least = math.Min(least, leg.Received)
+		most = math.Max(most, leg.Received)
+		total += leg.Received
+	}
+	if outcome == client.OutcomeYes {
+		return least
+	}
+	return total - most
+}
+
+// outcomeOf returns the outcome a spec buys
+func outcomeOf(spec Spec) string {
+	if spec.Outcome == client.OutcomeYes {
+		return client.OutcomeYes
+	}
+	return client.OutcomeNo
+}
+
+// Submitter signs and batch-posts orders (e.g. the CLOB client)
+type Submitter interface {
+	CreateOrder(orderArgs types.OrderArgs, options *types.CreateOrderOptions) (*types.SignedOrder, error)
+	PostOrders(orders []types.PostOrdersArgs) ([]map[string]interface{}, error)
+}
+
+// orderTypeValidator is implemented by submitters that check signed orders
+// against their order type on their own clock, like the CLOB client
+type orderTypeValidator interface {
+	ValidateOrderType(signedOrder *types.SignedOrder, orderType types.OrderType) error
+}
+
+var (
+	_ BookSource         = (*client.ClobClient)(nil)
+	_ Submitter          = (*client.ClobClient)(nil)
+	_ orderTypeValidator = (*client.ClobClient)(nil)
+)
+
+// LegResult is the outcome of posting one leg
+type LegResult struct {
+	Leg     Leg                    `json:"leg"`
+	OrderID string                 `json:"order_id,omitempty"`
+	Result  map[string]interface{} `json:"result,omitempty"`
+}
+
+// Submit signs every leg on the neg risk exchange and posts them in as few
+// batches as possible. Nothing is posted unless every leg signs and passes the
+// submitter's order type checks (ClobClient.ValidateOrderType), but the exchange fills each leg independently: a leg it
+// rejects or cannot fill leaves the rest of the basket in place. FOK and FAK
+// legs need a cost in whole cents, e.g. whole shares at a 0.01 tick.
+func Submit(submitter Submitter, basket *Basket, orderType types.OrderType) ([]LegResult, error) {
+	orders := make([]types.PostOrdersArgs, 0, len(basket.Legs))
+	for _, leg := range basket.Legs {
+		options := leg.Options
+		signedOrder, err := submitter.CreateOrder(types.OrderArgs{
+			TokenID: leg.TokenID,
+			Price:   leg.Price,
+			Size:    leg.Size,
+			Side:    types.BUY,
+		}, &options)
+		if err != nil {
+			return nil, fmt.Errorf("failed to create order for token %s: %w", leg.TokenID, err)
+		}
+		if validator, ok := submitter.(orderTypeValidator); ok {
+			if err := validator.ValidateOrderType(signedOrder, orderType); err != nil {
+				return nil, fmt.Errorf("order for token %s: %w", leg.TokenID, err)
+			}
+		}
+		orders = append(orders, types.PostOrdersArgs{Order: signedOrder, OrderType: orderType})
+	}
+
+	results := make([]LegResult, 0, len(orders))
+	for start := 0; start < len(orders); start += client.MaxBatchOrders {
+		end := start + client.MaxBatchOrders
+		if end > len(orders) {
+			end = len(orders)
+		}
+		posted, err := submitter.PostOrders(orders[start:end])
+		if err != nil {
+			return results, fmt.Errorf("failed to post legs %d to %d: %w", start+1, end, err)
+		}
+		for i := start; i < end; i++ {
+			result := LegResult{Leg: basket.Legs[i]}
+			if i-start < len(posted) {
+				result.Result = posted[i-start]
+				result.OrderID, _ = result.Result["orderID"].(string)
+			}
+			results = append(results, result)
+		}
+	}
+	return results, nil
+}
@@ -0,0 +1,139 @@
+package basket
+
+import (
+	"fmt"
+	"math"
+	"testing"
+
+	"github.com/MaDal776/polymarket-go-client/pkg/risk"
+	"github.com/MaDal776/polymarket-go-client/pkg/types"
+)
+
+// Orders can be placed through the risk checks
+var _ Submitter = (*risk.RiskTrader)(nil)
+
+// testEvent returns n neg risk markets of one event with their No books. The
+// No of candidate i is offered at 0.80 for 10 shares and 0.81 beyond that.
+func testEvent(n int) ([]types.Market, map[string]*types.OrderBookSummary) {
+	markets := make([]types.Market, 0, n)
+	books := make(map[string]*types.OrderBookSummary)
+	for i := 0; i < n; i++ {
+		yes, no := fmt.Sprintf("yes-%d", i), fmt.Sprintf("no-%d", i)
+		markets = append(markets, types.Market{
+			ConditionID:     fmt.Sprintf("0x%d", i),
+			NegRisk:         true,
+			NegRiskMarketID: "0xevent",
+			MinimumTickSize: 0.01,
+			Tokens:          []types.MarketToken{{TokenID: yes, Outcome: "Yes"}, {TokenID: no, Outcome: "No"}},
+		})
+		books[no] = &types.OrderBookSummary{Asks: []types.OrderSummary{{Price: "0.81", Size: "100"}, {Price: "0.80", Size: "10"}}}
+		books[yes] = &types.OrderBookSummary{Asks: []types.OrderSummary{{Price: "0.30", Size: "100"}}}
+	}
+	return markets, books
+}
+
+func TestBuildNoBasket(t *testing.T) {
+	markets, books := testEvent(4)
+	basket, err := Build(markets, books, Spec{Size: 20, Slippage: 0.005})
+	if err != nil {
+		t.Fatalf("Build failed: %v", err)
+	}
+
+	if len(basket.Legs) != 4 || basket.Outcome != "No" || basket.NegRiskMarketID != "0xevent" {
+		t.Fatalf("Unexpected basket %+v", basket)
+	}
+	leg := basket.Legs[0]
+	if leg.TokenID != "no-0" || leg.Price != 0.82 || math.Abs(leg.Average-0.805) > 1e-9 || !leg.Options.NegRisk || leg.Options.TickSize != types.TickSize001 {
+		t.Errorf("Unexpected leg %+v", leg)
+	}
+
+	// Three of the four No legs pay out whichever candidate wins
+	if math.Abs(basket.Cost-4*16.1) > 1e-9 || basket.Payout != 60 || math.Abs(basket.Edge-(60-64.4)) > 1e-9 {
+		t.Errorf("Unexpected cost %v, payout %v, edge %v", basket.Cost, basket.Payout, basket.Edge)
+	}
+	if math.Abs(basket.MaxCost-4*0.82*20) > 1e-9 {
+		t.Errorf("Unexpected max cost %v", basket.MaxCost)
+	}
+}
+
+func TestBuildYesBasketChargesFees(t *testing.T) {
+	markets, books := testEvent(3)
+	for i := range markets {
+		markets[i].TakerBaseFee = 100
+	}
+	basket, err := Build(markets, books, Spec{Outcome: "Yes", Size: 10})
+	if err != nil {
+		t.Fatalf("Build failed: %v", err)
+	}
+	// 1% of min(0.3, 0.7) * 10 / 0.3 = 0.1 shares withheld per leg
+	if math.Abs(basket.Payout-9.9) > 1e-9 || math.Abs(basket.Cost-9) > 1e-9 {
+		t.Errorf("Unexpected payout %v and cost %v", basket.Payout, basket.Cost)
+	}
+	if bps := basket.EdgeBps(); math.Abs(bps-10000*0.9/9) > 1e-6 {
+		t.Errorf("Unexpected edge %v bps", bps)
+	}
+}
+
+func TestBuildRejectsInconsistentEvents(t *testing.T) {
+	markets, books := testEvent(3)
+	markets[2].NegRiskMarketID = "0xother"
+	if _, err := Build(markets, books, Spec{Size: 5}); err == nil {
+		t.Errorf("Expected markets of another event to be rejected")
+	}
+
+	markets, books = testEvent(3)
+	if _, err := Build(markets, books, Spec{Size: 500}); err == nil {
+		t.Errorf("Expected a size beyond the book to be rejected")
+	}
+
+	markets, books = testEvent(3)
+	markets[1].NegRisk = false
+	if _, err := Build(markets, books, Spec{Size: 5}); err == nil {
+		t.Errorf("Expected a market outside neg risk to be rejected")
+	}
+}
+
+// fakeSubmitter signs orders trivially and records the batches posted
+type fakeSubmitter struct {
+	options []types.CreateOrderOptions
+	batches [][]types.PostOrdersArgs
+}
+
+func (f *fakeSubmitter) CreateOrder(orderArgs types.OrderArgs, options *types.CreateOrderOptions) (*types.SignedOrder, error) {
+	f.options = append(f.options, *options)
+	return &types.SignedOrder{TokenID: orderArgs.TokenID, Side: orderArgs.Side, Expiration: "0"}, nil
+}
+
+func (f *fakeSubmitter) PostOrders(orders []types.PostOrdersArgs) ([]map[string]interface{}, error) {
+	f.batches = append(f.batches, orders)
+	results := make([]map[string]interface{}, 0, len(orders))
+	for _, order := range orders {
+		results = append(results, map[string]interface{}{"success": true, "orderID": "order-" + order.Order.TokenID})
+	}
+	return results, nil
+}
+
+func TestSubmitBatchesLegs(t *testing.T) {
+	markets, books := testEvent(17)
+	basket, err := Build(markets, books, Spec{Size: 5})
+	if err != nil {
+		t.Fatalf("Build failed: %v", err)
+	}
+
+	submitter := &fakeSubmitter{}
+	results, err := Submit(submitter, basket, types.GTC)
+	if err != nil {
+		t.Fatalf("Submit failed: %v", err)
+	}
+	if len(submitter.batches) != 2 || len(submitter.batches[0]) != 15 || len(submitter.batches[1]) != 2 {
+		t.Fatalf("Expected batches of 15 and 2, got %d batches", len(submitter.batches))
+	}
+	if len(results) != 17 || results[16].OrderID != "order-no-16" {
+		t.Errorf("Unexpected results %+v", results)
+	}
+	for _, options := range submitter.options {
+		if !options.NegRisk {
+			t.Fatalf("Expected every leg to be signed for the neg risk exchange")
+		}
+	}
+}
@@ -0,0 +1,145 @@
+// Package candles aggregates prices into OHLCV candles, from the prices history
+// endpoint, recorded trades or a live feed.
+package candles
+
+import (
+	"sort"
+	"strconv"
+	"time"
+
+	"github.com/MaDal776/polymarket-go-client/pkg/types"
+)
+
+// Tick is one observed price. Size is the traded size, or 0 for price samples.
+type Tick struct {
+	Time  time.Time
+	Price float64
+	Size  float64
+}
+
+// Candle summarizes the ticks of one interval
+type Candle struct {
+	Start  time.Time `json:"start"`
+	Open   float64   `json:"open"`
+	High   float64   `json:"high"`
+	Low    float64   `json:"low"`
+	Close  float64   `json:"close"`
+	Volume float64   `json:"volume"` // Sum of tick sizes
+	Count  int       `json:"count"`  // Number of ticks
+}
+
+// add extends the candle with a tick
+func (c *Candle) add(tick Tick) {
+	if c.Count == 0 {
+		c.Open, c.High, c.Low = tick.Price, tick.Price, tick.Price
+	}
+	if tick.Price > c.High {
+		c.High = tick.Price
+	}
+	if tick.Price < c.Low {
+		c.Low = tick.Price
+	}
+	c.Close = tick.Price
+	c.Volume += tick.Size
+	c.Count++
+}
+
+// Build aggregates ticks into candles of the given interval, aligned to the Unix
+// epoch and ordered by start time. Intervals without ticks are omitted; see Fill.
+func Build(ticks []Tick, interval time.Duration) []Candle {
+	sorted := make([]Tick, len(ticks))
+	copy(sorted, ticks)
+	sort.SliceStable(sorted, func(i, j int) bool { return sorted[i].Time.Before(sorted[j].Time) })
+
+	builder := NewBuilder(interval)
+	candles := make([]Candle, 0)
+	for _, tick := range sorted {
+		if closed, ok := builder.Add(tick); ok {
+			candles = append(candles, closed)
+		}
+	}
+	if current, ok := builder.Current(); ok {
+		candles = append(candles, current)
+	}
+	return candles
+}
+
+// Fill inserts a flat, zero-volume candle at the previous close for every interval
+// missing between the given candles
+func Fill(candles []Candle, interval time.Duration) []Candle {
+	if len(candles) == 0 {
+		return candles
+	}
+	filled := make([]Candle, 0, len(candles))
+	for i, candle := range candles {
+		if i > 0 {
+			prev := filled[len(filled)-1]
+			for start := prev.Start.Add(interval); start.Before(candle.Start); start = start.Add(interval) {
+				filled = append(filled, Candle{Start: start, Open: prev.Close, High: prev.Close, Low: prev.Close, Close: prev.Close})
+			}
+		}
+		filled = append(filled, candle)
+	}
+	return filled
+}
+
+// Builder aggregates ticks arriving in time order, e.g. from a websocket feed
+type Builder struct {
+	interval time.Duration
+	current  Candle
+}
+
+// NewBuilder creates a builder for candles of the given interval
+func NewBuilder(interval time.Duration) *Builder {
+	return &Builder{interval: interval}
+}
+
+// Add adds a tick. When the tick starts a new interval the finished candle is returned.
+// Ticks older than the current candle are added to it.
+func (b *Builder) Add(tick Tick) (Candle, bool) {
+	start := tick.Time.Truncate(b.interval)
+	var closed Candle
+	finished := false
+	if b.current.Count > 0 && start.After(b.current.Start) {
+		closed, finished = b.current, true
+		b.current = Candle{}
+	}
+	if b.current.Count == 0 {
+		b.current.Start = start
+	}
+	b.current.add(tick)
+	return closed, finished
+}
+
+// Current returns the candle in progress
+func (b *Builder) Current() (Candle, bool) {
+	return b.current, b.current.Count > 0
+}
+
+// FromHistory converts a prices history into ticks
+func FromHistory(points []types.PricePoint) []Tick {
+	ticks := make([]Tick, 0, len(points))
+	for _, point := range points {
+		ticks = append(ticks, Tick{Time: time.Unix(point.T, 0), Price: point.P})
+	}
+	return ticks
+}
+
+// FromTrades converts trades into ticks sized by the traded shares. Trades with an
+// unparseable price or match time are skipped.
+func FromTrades(trades []types.Trade) []Tick {
+	ticks := make([]Tick, 0, len(trades))
+	for _, trade := range trades {
+		price, err := strconv.ParseFloat(trade.Price, 64)
+		if err != nil {
+			continue
+		}
+		matched, err := strconv.ParseInt(trade.MatchTime, 10, 64)
+		if err != nil {
+			continue
+		}
+		size, _ := strconv.ParseFloat(trade.Size, 64)
+		ticks = append(ticks, Tick{Time: time.Unix(matched, 0), Price: price, Size: size})
+	}
+	return ticks
+}
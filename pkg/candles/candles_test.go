@@ -0,0 +1,74 @@
+package candles
+
+import (
+	"testing"
+	"time"
+
+	"github.com/MaDal776/polymarket-go-client/pkg/types"
+)
+
+func TestBuild(t *testing.T) {
+	base := time.Unix(1700000000, 0).Truncate(time.Minute)
+	ticks := []Tick{
+		{Time: base.Add(70 * time.Second), Price: 0.52, Size: 5},
+		{Time: base, Price: 0.50, Size: 10},
+		{Time: base.Add(20 * time.Second), Price: 0.55, Size: 1},
+		{Time: base.Add(40 * time.Second), Price: 0.48, Size: 2},
+		{Time: base.Add(200 * time.Second), Price: 0.60, Size: 3},
+	}
+
+	candles := Build(ticks, time.Minute)
+	if len(candles) != 3 {
+		t.Fatalf("Expected 3 candles, got %d", len(candles))
+	}
+	first := candles[0]
+	if !first.Start.Equal(base) || first.Open != 0.50 || first.High != 0.55 || first.Low != 0.48 || first.Close != 0.48 {
+		t.Errorf("Unexpected first candle %+v", first)
+	}
+	if first.Volume != 13 || first.Count != 3 {
+		t.Errorf("Expected volume 13 over 3 ticks, got %v over %d", first.Volume, first.Count)
+	}
+	if candles[2].Start != base.Add(3*time.Minute) || candles[2].Close != 0.60 {
+		t.Errorf("Unexpected last candle %+v", candles[2])
+	}
+
+	filled := Fill(candles, time.Minute)
+	if len(filled) != 4 {
+		t.Fatalf("Expected a gap candle, got %d candles", len(filled))
+	}
+	gap := filled[2]
+	if gap.Open != 0.52 || gap.Close != 0.52 || gap.Volume != 0 || gap.Start != base.Add(2*time.Minute) {
+		t.Errorf("Unexpected gap candle %+v", gap)
+	}
+}
+
+func TestBuilderStreams(t *testing.T) {
+	builder := NewBuilder(time.Hour)
+	start := time.Unix(1700000000, 0).Truncate(time.Hour)
+	if _, done := builder.Add(Tick{Time: start, Price: 0.4}); done {
+		t.Fatal("First tick should not finish a candle")
+	}
+	closed, done := builder.Add(Tick{Time: start.Add(time.Hour), Price: 0.5})
+	if !done || closed.Close != 0.4 {
+		t.Errorf("Expected the first candle to close at 0.4, got %+v (%v)", closed, done)
+	}
+	if current, ok := builder.Current(); !ok || current.Open != 0.5 {
+		t.Errorf("Unexpected current candle %+v", current)
+	}
+}
+
+func TestFromTradesAndHistory(t *testing.T) {
+	trades := []types.Trade{
+		{Price: "0.5", Size: "10", MatchTime: "1700000000"},
+		{Price: "bad", Size: "1", MatchTime: "1700000001"},
+	}
+	ticks := FromTrades(trades)
+	if len(ticks) != 1 || ticks[0].Size != 10 || ticks[0].Time.Unix() != 1700000000 {
+		t.Errorf("Unexpected ticks %+v", ticks)
+	}
+
+	history := FromHistory([]types.PricePoint{{T: 1700000000, P: 0.25}})
+	if len(history) != 1 || history[0].Price != 0.25 || history[0].Size != 0 {
+		t.Errorf("Unexpected history ticks %+v", history)
+	}
+}
@@ -0,0 +1,134 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+
+	"github.com/MaDal776/polymarket-go-client/pkg/types"
+)
+
+// DefaultBatchConcurrency is used when a batch is given a non-positive concurrency
+const DefaultBatchConcurrency = 8
+
+// BatchRequest is a single unit of work in a batch
+type BatchRequest[R any] func(ctx context.Context) (R, error)
+
+// BatchResult holds the outcome of one batch request
+type BatchResult[R any] struct {
+	Value R
+	Err   error
+}
+
+// Batch runs requests with at most concurrency in flight and returns their results
+// in request order. Requests not started before ctx is cancelled fail with ctx.Err().
+// Requests made through a ClobClient still pass through its rate limiter.
+func Batch[R any](ctx context.Context, requests []BatchRequest[R], concurrency int) []BatchResult[R] {
+	if concurrency <= 0 {
+		concurrency = DefaultBatchConcurrency
+	}
+
+	results := make([]BatchResult[R], len(requests))
+	sem := make(chan struct{}, concurrency)
+	var wg sync.WaitGroup
+
+	for i, request := range requests {
+		select {
+		case <-ctx.Done():
+			results[i].Err = ctx.Err()
+			continue
+		case sem <- struct{}{}:
+		}
+
+		wg.Add(1)
+		go func(i int, request BatchRequest[R]) {
+			defer wg.Done()
+			defer func() { <-sem }()
+			results[i].Value, results[i].Err = request(ctx)
+		}(i, request)
+	}
+
+	wg.Wait()
+	return results
+}
+
+// collectByToken maps batch results back to their token IDs, joining the failures
+func collectByToken[R any](tokenIDs []string, results []BatchResult[R]) (map[string]R, error) {
+	values := make(map[string]R, len(results))
+	var errs []error
+	for i, result := range results {
+		if result.Err != nil {
+			errs = append(errs, fmt.Errorf("%s: %w", tokenIDs[i], result.Err))
+			continue
+		}
+		values[tokenIDs[i]] = result.Value
+	}
+	return values, errors.Join(errs...)
+}
+
+// GetOrderBooks gets the order books for several tokens in parallel.
+// Books that were fetched are returned even when others fail.
+func (c *ClobClient) GetOrderBooks(ctx context.Context, tokenIDs []string, concurrency int) (map[string]*types.OrderBookSummary, error) {
+	requests := make([]BatchRequest[*types.OrderBookSummary], len(tokenIDs))
+	for i, tokenID := range tokenIDs {
+		tokenID := tokenID
+		requests[i] = func(context.Context) (*types.OrderBookSummary, error) {
+			return c.GetOrderBook(tokenID)
+		}
+	}
+	return collectByToken(tokenIDs, Batch(ctx, requests, concurrency))
+}
+
+// GetMidpoints gets the midpoints for several tokens in parallel
+func (c *ClobClient) GetMidpoints(ctx context.Context, tokenIDs []string, concurrency int) (map[string]*types.MidpointResponse, error) {
+	requests := make([]BatchRequest[*types.MidpointResponse], len(tokenIDs))
+	for i, tokenID := range tokenIDs {
+		tokenID := tokenID
+		requests[i] = func(context.Context) (*types.MidpointResponse, error) {
+			return c.GetMidpoint(tokenID)
+		}
+	}
+	return collectByToken(tokenIDs, Batch(ctx, requests, concurrency))
+}
+
+// GetPricesBySide gets the price on one side for several tokens in parallel
+func (c *ClobClient) GetPricesBySide(ctx context.Context, tokenIDs []string, side types.OrderSide, concurrency int) (map[string]*types.PriceResponse, error) {
+	requests := make([]BatchRequest[*types.PriceResponse], len(tokenIDs))
+	for i, tokenID := range tokenIDs {
+		tokenID := tokenID
+		requests[i] = func(context.Context) (*types.PriceResponse, error) {
+			return c.GetPrice(tokenID, side)
+		}
+	}
+	return collectByToken(tokenIDs, Batch(ctx, requests, concurrency))
+}
+
+// GetTickSizes gets (and caches) the tick sizes for several tokens in parallel
+func (c *ClobClient) GetTickSizes(ctx context.Context, tokenIDs []string, concurrency int) (map[string]types.TickSize, error) {
+	requests := make([]BatchRequest[types.TickSize], len(tokenIDs))
+	for i, tokenID := range tokenIDs {
+		tokenID := tokenID
+		requests[i] = func(context.Context) (types.TickSize, error) {
+			return c.GetTickSize(tokenID)
+		}
+	}
+	return collectByToken(tokenIDs, Batch(ctx, requests, concurrency))
+}
+
+// GetBalanceAllowances gets the conditional token balances for several tokens in parallel.
+// params supplies the shared fields (e.g. signature type); its token ID is replaced per request.
+func (c *ClobClient) GetBalanceAllowances(ctx context.Context, tokenIDs []string, params types.BalanceAllowanceParams, concurrency int) (map[string]*types.BalanceAllowanceResponse, error) {
+	requests := make([]BatchRequest[*types.BalanceAllowanceResponse], len(tokenIDs))
+	for i, tokenID := range tokenIDs {
+		tokenParams := params
+		tokenParams.TokenID = tokenID
+		if tokenParams.AssetType == "" {
+			tokenParams.AssetType = types.CONDITIONAL
+		}
+		requests[i] = func(context.Context) (*types.BalanceAllowanceResponse, error) {
+			return c.GetBalanceAllowance(&tokenParams)
+		}
+	}
+	return collectByToken(tokenIDs, Batch(ctx, requests, concurrency))
+}
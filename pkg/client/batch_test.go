@@ -0,0 +1,75 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestBatchPreservesOrderAndBoundsConcurrency(t *testing.T) {
+	var inFlight, peak int32
+	requests := make([]BatchRequest[int], 20)
+	for i := range requests {
+		i := i
+		requests[i] = func(context.Context) (int, error) {
+			n := atomic.AddInt32(&inFlight, 1)
+			for {
+				p := atomic.LoadInt32(&peak)
+				if n <= p || atomic.CompareAndSwapInt32(&peak, p, n) {
+					break
+				}
+			}
+			time.Sleep(5 * time.Millisecond)
+			atomic.AddInt32(&inFlight, -1)
+			if i == 7 {
+				return 0, errors.New("boom")
+			}
+			return i * i, nil
+		}
+	}
+
+	results := Batch(context.Background(), requests, 3)
+	if peak > 3 {
+		t.Errorf("Expected at most 3 requests in flight, saw %d", peak)
+	}
+	for i, result := range results {
+		if i == 7 {
+			if result.Err == nil {
+				t.Errorf("Expected request 7 to fail")
+			}
+			continue
+		}
+		if result.Err != nil || result.Value != i*i {
+			t.Errorf("Result %d = %+v, want %d", i, result, i*i)
+		}
+	}
+}
+
+func TestBatchStopsStartingAfterCancel(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	requests := []BatchRequest[int]{
+		func(context.Context) (int, error) { cancel(); return 1, nil },
+		func(context.Context) (int, error) { return 2, nil },
+	}
+
+	results := Batch(ctx, requests, 1)
+	if results[0].Err != nil || results[0].Value != 1 {
+		t.Errorf("Expected first request to succeed, got %+v", results[0])
+	}
+	if !errors.Is(results[1].Err, context.Canceled) {
+		t.Errorf("Expected second request to be cancelled, got %+v", results[1])
+	}
+}
+
+func TestRateLimiterSpacesRequests(t *testing.T) {
+	limiter := newRateLimiter(100, 1)
+	start := time.Now()
+	for i := 0; i < 4; i++ {
+		limiter.Wait()
+	}
+	if elapsed := time.Since(start); elapsed < 25*time.Millisecond {
+		t.Errorf("Expected 3 waits of ~10ms after the burst, took %v", elapsed)
+	}
+}
@@ -0,0 +1,144 @@
+package client
+
+import (
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+)
+
+// cacheablePaths are the GET endpoints whose responses rarely change
+var cacheablePaths = []string{GetMarkets, GetTickSize, GetNegRisk}
+
+// ResponseCache caches responses of stable GET endpoints (markets, tick size,
+// neg risk). Entries are served directly while younger than the TTL and are
+// then revalidated with If-None-Match when the server sent an ETag.
+// A single cache may be shared by several clients.
+type ResponseCache struct {
+	mu      sync.Mutex
+	ttl     time.Duration
+	entries map[string]*cachedResponse
+}
+
+// cachedResponse is a stored response body and its validator
+type cachedResponse struct {
+	body    []byte
+	etag    string
+	expires time.Time
+}
+
+// NewResponseCache creates a response cache. A zero TTL revalidates every request.
+func NewResponseCache(ttl time.Duration) *ResponseCache {
+	return &ResponseCache{
+		ttl:     ttl,
+		entries: make(map[string]*cachedResponse),
+	}
+}
+
+// Clear removes every cached response
+func (rc *ResponseCache) Clear() {
+	rc.mu.Lock()
+	defer rc.mu.Unlock()
+	rc.entries = make(map[string]*cachedResponse)
+}
+
+// Len returns the number of cached responses
+func (rc *ResponseCache) Len() int {
+	rc.mu.Lock()
+	defer rc.mu.Unlock()
+	return len(rc.entries)
+}
+
+// lookup returns the entry for key and whether it can be served without revalidation
+func (rc *ResponseCache) lookup(key string) (*cachedResponse, bool) {
+	rc.mu.Lock()
+	defer rc.mu.Unlock()
+
+	entry, exists := rc.entries[key]
+	if !exists {
+		return nil, false
+	}
+	return entry, time.Now().Before(entry.expires)
+}
+
+// store records a fresh response. Responses without an ETag are only kept
+// when they can be served from the TTL.
+func (rc *ResponseCache) store(key string, body []byte, etag string) {
+	if etag == "" && rc.ttl <= 0 {
+		return
+	}
+
+	rc.mu.Lock()
+	defer rc.mu.Unlock()
+	rc.entries[key] = &cachedResponse{body: body, etag: etag, expires: time.Now().Add(rc.ttl)}
+}
+
+// revalidated extends an entry after the server answered 304 Not Modified
+func (rc *ResponseCache) revalidated(key string) {
+	rc.mu.Lock()
+	defer rc.mu.Unlock()
+	if entry, exists := rc.entries[key]; exists {
+		entry.expires = time.Now().Add(rc.ttl)
+	}
+}
+
+// remove drops the entry for key
+func (rc *ResponseCache) remove(key string) {
+	rc.mu.Lock()
+	defer rc.mu.Unlock()
+	delete(rc.entries, key)
+}
+
+// removePrefix drops the entries whose key starts with prefix
+func (rc *ResponseCache) removePrefix(prefix string) {
+	rc.mu.Lock()
+	defer rc.mu.Unlock()
+	for key := range rc.entries {
+		if strings.HasPrefix(key, prefix) {
+			delete(rc.entries, key)
+		}
+	}
+}
+
+// SetResponseCache enables caching of stable GET responses. Passing nil disables it.
+func (c *ClobClient) SetResponseCache(cache *ResponseCache) {
+	c.responseCache = cache
+}
+
+// cacheKey returns the cache key for a request, or "" when it must not be cached.
+// Only unauthenticated GETs of cacheable endpoints qualify.
+func (c *ClobClient) cacheKey(method, url string, headers map[string]string) string {
+	if c.responseCache == nil || method != "GET" || len(headers) > 0 {
+		return ""
+	}
+
+	path := strings.TrimPrefix(url, c.host)
+	for _, prefix := range cacheablePaths {
+		if strings.HasPrefix(path, prefix) {
+			return url
+		}
+	}
+	return ""
+}
+
+// forgetTokenResponses drops a token's cached responses from the given
+// endpoints, so the next request for them reaches the exchange
+func (c *ClobClient) forgetTokenResponses(tokenID string, paths ...string) {
+	if c.responseCache == nil {
+		return
+	}
+	for _, path := range paths {
+		c.responseCache.remove(fmt.Sprintf("%s%s?token_id=%s", c.host, path, tokenID))
+	}
+}
+
+// forgetEndpointResponses drops every cached response from the given endpoints
+// of this client's host
+func (c *ClobClient) forgetEndpointResponses(paths ...string) {
+	if c.responseCache == nil {
+		return
+	}
+	for _, path := range paths {
+		c.responseCache.removePrefix(c.host + path + "?")
+	}
+}
@@ -0,0 +1,78 @@
+package client
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func newMarketsServer(t *testing.T, hits, notModified *int32) *httptest.Server {
+	t.Helper()
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(hits, 1)
+		if r.Header.Get("If-None-Match") == `"v1"` {
+			atomic.AddInt32(notModified, 1)
+			w.WriteHeader(http.StatusNotModified)
+			return
+		}
+		w.Header().Set("ETag", `"v1"`)
+		w.Write([]byte(`{"condition_id":"0x1","question":"Will it rain?"}`))
+	}))
+}
+
+func TestResponseCacheRevalidatesWithETag(t *testing.T) {
+	var hits, notModified int32
+	server := newMarketsServer(t, &hits, &notModified)
+	defer server.Close()
+
+	c, err := NewClobClient(server.URL, 137, "", nil, nil, nil)
+	if err != nil {
+		t.Fatalf("Failed to create client: %v", err)
+	}
+	c.SetResponseCache(NewResponseCache(0))
+
+	for i := 0; i < 3; i++ {
+		market, err := c.GetMarket("0x1")
+		if err != nil {
+			t.Fatalf("GetMarket failed: %v", err)
+		}
+		if market.Question != "Will it rain?" {
+			t.Fatalf("Unexpected market: %+v", market)
+		}
+	}
+	if hits != 3 || notModified != 2 {
+		t.Errorf("Expected 3 requests with 2 revalidations, got %d and %d", hits, notModified)
+	}
+}
+
+func TestResponseCacheServesFreshEntries(t *testing.T) {
+	var hits, notModified int32
+	server := newMarketsServer(t, &hits, &notModified)
+	defer server.Close()
+
+	c, err := NewClobClient(server.URL, 137, "", nil, nil, nil)
+	if err != nil {
+		t.Fatalf("Failed to create client: %v", err)
+	}
+	cache := NewResponseCache(time.Minute)
+	c.SetResponseCache(cache)
+
+	for i := 0; i < 3; i++ {
+		if _, err := c.GetMarket("0x1"); err != nil {
+			t.Fatalf("GetMarket failed: %v", err)
+		}
+	}
+	if hits != 1 {
+		t.Errorf("Expected a single request within the TTL, got %d", hits)
+	}
+
+	cache.Clear()
+	if _, err := c.GetMarket("0x1"); err != nil {
+		t.Fatalf("GetMarket failed: %v", err)
+	}
+	if hits != 2 {
+		t.Errorf("Expected a request after Clear, got %d total", hits)
+	}
+}
@@ -1,45 +1,66 @@
 package client
 
 import (
-	"bytes"
+	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
-	"io"
+	"math"
 	"net/http"
+	"sort"
+	"strconv"
 	"strings"
+	"sync"
+	"sync/atomic"
 	"time"
 
-	"polymarket-clob-go/pkg/auth"
-	"polymarket-clob-go/pkg/orderbuilder"
-	"polymarket-clob-go/pkg/signer"
-	"polymarket-clob-go/pkg/types"
-	"polymarket-clob-go/pkg/utils"
+	"github.com/MaDal776/polymarket-go-client/pkg/auth"
+	"github.com/MaDal776/polymarket-go-client/pkg/clock"
+	"github.com/MaDal776/polymarket-go-client/pkg/orderbuilder"
+	"github.com/MaDal776/polymarket-go-client/pkg/signer"
+	"github.com/MaDal776/polymarket-go-client/pkg/slo"
+	"github.com/MaDal776/polymarket-go-client/pkg/types"
+	"github.com/MaDal776/polymarket-go-client/pkg/utils"
+	"github.com/MaDal776/polymarket-go-client/pkg/version"
 )
 
 // API endpoints
 const (
-	CreateAPIKey    = "/auth/api-key"
-	DeriveAPIKey    = "/auth/derive-api-key"
-	GetAPIKeys      = "/auth/api-keys"
-	DeleteAPIKey    = "/auth/api-key"
-	PostOrder       = "/order"
-	PostOrders      = "/orders"
-	GetOrder        = "/order/"
-	GetOrders       = "/orders"
-	CancelOrder     = "/order"
-	CancelOrders    = "/orders"
-	CancelAll       = "/orders/cancel-all"
-	GetOrderBook    = "/book"
-	GetTrades       = "/trades"
-	GetTickSize     = "/tick-size"
-	GetNegRisk      = "/neg-risk"
-	GetMidpoint     = "/midpoint"
-	GetPrice        = "/price"
-	GetPrices       = "/prices"
-	GetSpread       = "/spread"
-	Time            = "/time"
-	GetBalanceAllowance     = "/balance-allowance"
-	UpdateBalanceAllowance  = "/balance-allowance/update"
+	CreateAPIKey           = "/auth/api-key"
+	DeriveAPIKey           = "/auth/derive-api-key"
+	GetAPIKeys             = "/auth/api-keys"
+	DeleteAPIKey           = "/auth/api-key"
+	PostOrder              = "/order"
+	PostOrders             = "/orders"
+	GetOrder               = "/data/order/"
+	GetOrders              = "/data/orders"
+	CancelOrder            = "/order"
+	CancelOrders           = "/orders"
+	CancelAll              = "/cancel-all"
+	GetOrderBook           = "/book"
+	GetTrades              = "/data/trades"
+	GetTickSize            = "/tick-size"
+	GetNegRisk             = "/neg-risk"
+	GetMidpoint            = "/midpoint"
+	GetPrice               = "/price"
+	GetPrices              = "/prices"
+	GetSpread              = "/spread"
+	Time                   = "/time"
+	GetMarkets             = "/markets"
+	GetMarket              = "/markets/"
+	Notifications          = "/notifications"
+	GetPricesHistory       = "/prices-history"
+	GetBalanceAllowance    = "/balance-allowance"
+	UpdateBalanceAllowance = "/balance-allowance/update"
+)
+
+// ErrSlippageExceeded is returned when the book cannot fill a market order within MaxSlippageBps
+var ErrSlippageExceeded = errors.New("market order exceeds max slippage")
+
+// Pagination cursors
+const (
+	InitialCursor = "MA=="
+	EndCursor     = "LTE="
 )
 
 // Contract addresses for different chains
@@ -53,6 +74,7 @@ var contractConfigs = map[int64]types.ContractConfig{
 		Exchange:          "0x4bFb41d5B3570DeFd03C39a9A4D8dE6Bd8B8982E",
 		Collateral:        "0x2791Bca1f2de4661ED88A30C99A7a9449Aa84174",
 		ConditionalTokens: "0x4D97DCd97eC945f40cF65F87097ACe5EA0476045",
+		NativeCollateral:  "0x3c499c542cEF5E3811e1192ce70d8cC03d5c3359",
 	},
 }
 
@@ -67,12 +89,25 @@ var negRiskContractConfigs = map[int64]types.ContractConfig{
 		Exchange:          "0xC5d563A36AE78145C45a50134d48A1215220f80a",
 		Collateral:        "0x2791bca1f2de4661ed88a30c99a7a9449aa84174",
 		ConditionalTokens: "0x4D97DCd97eC945f40cF65F87097ACe5EA0476045",
+		NegRiskAdapter:    "0xd91E80cF2E7be2e162c6513ceD06f1dD0dA35296",
+		NativeCollateral:  "0x3c499c542cEF5E3811e1192ce70d8cC03d5c3359",
 	},
 }
 
+// GetContractConfig returns the contract addresses for a chain
+func GetContractConfig(chainID int64, negRisk bool) (types.ContractConfig, bool) {
+	if negRisk {
+		config, exists := negRiskContractConfigs[chainID]
+		return config, exists
+	}
+	config, exists := contractConfigs[chainID]
+	return config, exists
+}
+
 // ClobClient represents the main CLOB client
 type ClobClient struct {
 	host          string
+	userAgent     string
 	chainID       int64
 	signer        *signer.Signer
 	creds         *types.ApiCreds
@@ -81,10 +116,43 @@ type ClobClient struct {
 	orderBuilder  *orderbuilder.OrderBuilder
 	httpClient    *http.Client
 	metrics       []types.PerformanceMetrics
+	orderEncoding types.OrderEncoding
+	limiter       atomic.Pointer[rateLimiter]
+	budget        serverBudget
+	fast          fastPath
+	responseCache *ResponseCache
+	sloTracker    *slo.Tracker
+	minNotional   float64
+	fokFillCheck  bool
+	tickSizeRetry bool
+	marketCheck   bool
+	crossCheck    CrossCheck
+	onCross       func(cross *CrossError)
+	midpointCheck MidpointCheck
+	midpointBps   int
+	onMidpoint    func(violation *MidpointError)
+	timeouts      requestTimeouts
+	hedge         hedger
+	compression   compressor
+	retry         retrier
+	hosts         hostPool
+	clock         *clock.Offset // Timestamps of signatures and expirations, synced by SyncServerTime
+	
+	// Guards metrics, the order defaults and the caches below, which batch helpers touch concurrently
+	mu            sync.Mutex
+	orderDefaults OrderDefaults
+	signatureType int    // Signature type and funder given to NewClobClient, used
+	funder        string // when the order defaults leave them unset
 	
 	// Cache
-	tickSizes map[string]types.TickSize
-	negRisks  map[string]bool
+	tickSizes    map[string]types.TickSize
+	negRisks     map[string]bool
+	tokenMarkets map[string]string // Token ID -> condition ID
+	complements  map[string]string // Token ID -> other token of its binary market
+	
+	// Metrics output, set by OnMetric and SetMetricsBuffering
+	onMetric       func(metric types.PerformanceMetrics)
+	discardMetrics bool
 }
 
 // NewClobClient creates a new CLOB client
@@ -97,13 +165,17 @@ func NewClobClient(host string, chainID int64, privateKey string, creds *types.A
 	}
 	
 	client := &ClobClient{
-		host:       host,
-		chainID:    chainID,
-		creds:      creds,
-		httpClient: &http.Client{Timeout: 30 * time.Second},
-		metrics:    make([]types.PerformanceMetrics, 0),
-		tickSizes:  make(map[string]types.TickSize),
-		negRisks:   make(map[string]bool),
+		host:         host,
+		userAgent:    version.UserAgent(""),
+		chainID:      chainID,
+		creds:        creds,
+		httpClient:   &http.Client{Transport: newTransport()}, // Timeouts are per request, see SetEndpointTimeout
+		metrics:      make([]types.PerformanceMetrics, 0),
+		tickSizes:    make(map[string]types.TickSize),
+		negRisks:     make(map[string]bool),
+		tokenMarkets: make(map[string]string),
+		complements:  make(map[string]string),
+		clock:        clock.NewOffset(nil),
 	}
 	
 	// Initialize signer if private key provided
@@ -115,11 +187,14 @@ func NewClobClient(host string, chainID int64, privateKey string, creds *types.A
 		}
 		client.signer = s
 		client.headerBuilder = auth.NewHeaderBuilder(s)
+		client.headerBuilder.SetClock(client.clock)
 		client.orderBuilder = orderbuilder.NewOrderBuilder(s, signatureType, funder)
+		client.signatureType, client.funder = client.orderBuilder.Wallet()
 	}
 	
 	// Determine auth level
 	client.authLevel = client.getAuthLevel()
+	client.cacheSecret()
 	
 	client.recordMetric("client_creation", start, true, "")
 	return client, nil
@@ -142,9 +217,9 @@ func (c *ClobClient) GetAuthLevel() types.AuthLevel {
 func (c *ClobClient) CreateAPIKey(nonce int64) (*types.ApiCreds, error) {
 	start := time.Now()
 	
-	if c.authLevel < types.L1 {
+	if err := c.requireAuth(types.L1); err != nil {
 		c.recordMetric("api_key_creation", start, false, "insufficient auth level")
-		return nil, fmt.Errorf("Level 1 authentication required")
+		return nil, err
 	}
 	
 	// Create headers
@@ -163,16 +238,14 @@ func (c *ClobClient) CreateAPIKey(nonce int64) (*types.ApiCreds, error) {
 	}
 	
 	// Parse response
-	var result map[string]interface{}
-	if err := json.Unmarshal(resp, &result); err != nil {
+	creds := &types.ApiCreds{}
+	if err := json.Unmarshal(resp, creds); err != nil {
 		c.recordMetric("api_key_creation", start, false, err.Error())
 		return nil, fmt.Errorf("failed to parse response: %w", err)
 	}
-	
-	creds := &types.ApiCreds{
-		ApiKey:        result["apiKey"].(string),
-		ApiSecret:     result["secret"].(string),
-		ApiPassphrase: result["passphrase"].(string),
+	if creds.ApiKey == "" || creds.ApiSecret == "" || creds.ApiPassphrase == "" {
+		c.recordMetric("api_key_creation", start, false, "incomplete credentials")
+		return nil, fmt.Errorf("incomplete API credentials in response")
 	}
 	
 	c.recordMetric("api_key_creation", start, true, "")
@@ -183,9 +256,9 @@ func (c *ClobClient) CreateAPIKey(nonce int64) (*types.ApiCreds, error) {
 func (c *ClobClient) DeriveAPIKey(nonce int64) (*types.ApiCreds, error) {
 	start := time.Now()
 	
-	if c.authLevel < types.L1 {
+	if err := c.requireAuth(types.L1); err != nil {
 		c.recordMetric("api_key_derivation", start, false, "insufficient auth level")
-		return nil, fmt.Errorf("Level 1 authentication required")
+		return nil, err
 	}
 	
 	// Create headers
@@ -204,16 +277,14 @@ func (c *ClobClient) DeriveAPIKey(nonce int64) (*types.ApiCreds, error) {
 	}
 	
 	// Parse response
-	var result map[string]interface{}
-	if err := json.Unmarshal(resp, &result); err != nil {
+	creds := &types.ApiCreds{}
+	if err := json.Unmarshal(resp, creds); err != nil {
 		c.recordMetric("api_key_derivation", start, false, err.Error())
 		return nil, fmt.Errorf("failed to parse response: %w", err)
 	}
-	
-	creds := &types.ApiCreds{
-		ApiKey:        result["apiKey"].(string),
-		ApiSecret:     result["secret"].(string),
-		ApiPassphrase: result["passphrase"].(string),
+	if creds.ApiKey == "" || creds.ApiSecret == "" || creds.ApiPassphrase == "" {
+		c.recordMetric("api_key_derivation", start, false, "incomplete credentials")
+		return nil, fmt.Errorf("incomplete API credentials in response")
 	}
 	
 	c.recordMetric("api_key_derivation", start, true, "")
@@ -235,6 +306,92 @@ func (c *ClobClient) CreateOrDeriveAPIKey(nonce int64) (*types.ApiCreds, error)
 func (c *ClobClient) SetAPICredentials(creds *types.ApiCreds) {
 	c.creds = creds
 	c.authLevel = c.getAuthLevel()
+	c.cacheSecret()
+}
+
+// cacheSecret lets the header builder decode the API secret once. A secret that
+// fails to decode is left uncached, so requests report the error instead.
+func (c *ClobClient) cacheSecret() {
+	if c.headerBuilder != nil && c.creds != nil {
+		c.headerBuilder.SetSecret(c.creds.ApiSecret)
+	}
+}
+
+// SetSLOTracker feeds every client metric into tracker so latency and error-rate
+// objectives (e.g. p99 of order posting) can raise alerts. Passing nil detaches it.
+func (c *ClobClient) SetSLOTracker(tracker *slo.Tracker) {
+	c.sloTracker = tracker
+}
+
+// OnMetric calls fn with every metric recorded by the client, its signer, header
+// builder and order builder as soon as it is recorded, so metrics can be streamed
+// into an application's own pipeline. Passing nil removes the callback.
+// fn may be called concurrently and should not block.
+func (c *ClobClient) OnMetric(fn func(metric types.PerformanceMetrics)) {
+	c.mu.Lock()
+	c.onMetric = fn
+	c.mu.Unlock()
+	c.applyMetricsOutput()
+}
+
+// SetMetricsBuffering controls whether metrics are kept in memory for GetMetrics and
+// PrintMetrics (the default). Long-running processes that stream metrics through
+// OnMetric can disable it so the buffers do not grow without bound; disabling it
+// drops the metrics kept so far.
+func (c *ClobClient) SetMetricsBuffering(enabled bool) {
+	c.mu.Lock()
+	c.discardMetrics = !enabled
+	if !enabled {
+		c.metrics = make([]types.PerformanceMetrics, 0)
+	}
+	c.mu.Unlock()
+	c.applyMetricsOutput()
+}
+
+// applyMetricsOutput passes the metrics output settings on to the components
+func (c *ClobClient) applyMetricsOutput() {
+	c.mu.Lock()
+	onMetric, buffer := c.onMetric, !c.discardMetrics
+	c.mu.Unlock()
+	
+	if c.signer != nil {
+		c.signer.SetMetricsOutput(onMetric, buffer)
+	}
+	if c.headerBuilder != nil {
+		c.headerBuilder.SetMetricsOutput(onMetric, buffer)
+	}
+	if orderBuilder := c.builder(); orderBuilder != nil {
+		orderBuilder.SetMetricsOutput(onMetric, buffer)
+	}
+}
+
+// SetOrderEncoding sets the wire encoding used when posting signed orders.
+// The default matches the CLOB API; override it only for compatible deployments that differ.
+func (c *ClobClient) SetOrderEncoding(enc types.OrderEncoding) {
+	c.orderEncoding = enc
+}
+
+// GetServerTime gets the exchange server time as a unix timestamp in seconds
+func (c *ClobClient) GetServerTime() (int64, error) {
+	start := time.Now()
+	
+	// Make request
+	url := c.host + Time
+	resp, err := c.makeRequest("GET", url, nil, nil)
+	if err != nil {
+		c.recordMetric("server_time_retrieval", start, false, err.Error())
+		return 0, fmt.Errorf("failed to get server time: %w", err)
+	}
+	
+	// Parse response
+	var serverTime int64
+	if err := json.Unmarshal(resp, &serverTime); err != nil {
+		c.recordMetric("server_time_retrieval", start, false, err.Error())
+		return 0, fmt.Errorf("failed to parse server time response: %w", err)
+	}
+	
+	c.recordMetric("server_time_retrieval", start, true, "")
+	return serverTime, nil
 }
 
 // GetTickSize gets the tick size for a token
@@ -242,11 +399,18 @@ func (c *ClobClient) GetTickSize(tokenID string) (types.TickSize, error) {
 	start := time.Now()
 	
 	// Check cache first
-	if tickSize, exists := c.tickSizes[tokenID]; exists {
+	c.mu.Lock()
+	tickSize, exists := c.tickSizes[tokenID]
+	c.mu.Unlock()
+	if exists {
 		c.recordMetric("tick_size_retrieval", start, true, "from_cache")
 		return tickSize, nil
 	}
-	
+	return c.fetchTickSize(tokenID, start)
+}
+
+// fetchTickSize requests a token's tick size, bypassing the cached value, and caches it
+func (c *ClobClient) fetchTickSize(tokenID string, start time.Time) (types.TickSize, error) {
 	// Make request
 	url := fmt.Sprintf("%s%s?token_id=%s", c.host, GetTickSize, tokenID)
 	resp, err := c.makeRequest("GET", url, nil, nil)
@@ -268,19 +432,22 @@ func (c *ClobClient) GetTickSize(tokenID string) (types.TickSize, error) {
 	case string:
 		tickSizeStr = v
 	case float64:
-		tickSizeStr = fmt.Sprintf("%.4f", v)
-		// Remove trailing zeros
-		tickSizeStr = strings.TrimRight(tickSizeStr, "0")
-		tickSizeStr = strings.TrimRight(tickSizeStr, ".")
+		tickSizeStr = strconv.FormatFloat(v, 'g', -1, 64)
 	default:
 		c.recordMetric("tick_size_retrieval", start, false, "invalid tick size type")
 		return "", fmt.Errorf("invalid tick size type: %T", v)
 	}
 	
-	tickSize := types.TickSize(tickSizeStr)
+	tickSize, err := utils.ParseTickSizeString(tickSizeStr)
+	if err != nil {
+		c.recordMetric("tick_size_retrieval", start, false, err.Error())
+		return "", err
+	}
 	
 	// Cache the result
+	c.mu.Lock()
 	c.tickSizes[tokenID] = tickSize
+	c.mu.Unlock()
 	
 	c.recordMetric("tick_size_retrieval", start, true, "")
 	return tickSize, nil
@@ -291,11 +458,18 @@ func (c *ClobClient) GetNegRisk(tokenID string) (bool, error) {
 	start := time.Now()
 	
 	// Check cache first
-	if negRisk, exists := c.negRisks[tokenID]; exists {
+	c.mu.Lock()
+	negRisk, exists := c.negRisks[tokenID]
+	c.mu.Unlock()
+	if exists {
 		c.recordMetric("neg_risk_retrieval", start, true, "from_cache")
 		return negRisk, nil
 	}
-	
+	return c.fetchNegRisk(tokenID, start)
+}
+
+// fetchNegRisk requests a token's neg risk flag, bypassing the cached value, and caches it
+func (c *ClobClient) fetchNegRisk(tokenID string, start time.Time) (bool, error) {
 	// Make request
 	url := fmt.Sprintf("%s%s?token_id=%s", c.host, GetNegRisk, tokenID)
 	resp, err := c.makeRequest("GET", url, nil, nil)
@@ -311,10 +485,16 @@ func (c *ClobClient) GetNegRisk(tokenID string) (bool, error) {
 		return false, fmt.Errorf("failed to parse neg risk response: %w", err)
 	}
 	
-	negRisk := result["neg_risk"].(bool)
+	negRisk, ok := result["neg_risk"].(bool)
+	if !ok {
+		c.recordMetric("neg_risk_retrieval", start, false, "invalid neg risk type")
+		return false, fmt.Errorf("invalid neg risk type: %T", result["neg_risk"])
+	}
 	
 	// Cache the result
+	c.mu.Lock()
 	c.negRisks[tokenID] = negRisk
+	c.mu.Unlock()
 	
 	c.recordMetric("neg_risk_retrieval", start, true, "")
 	return negRisk, nil
@@ -391,13 +571,292 @@ func (c *ClobClient) GetPrices(params []types.BookParams) ([]types.PriceResponse
 	return result, nil
 }
 
+// GetMarkets gets one page of CLOB markets. An empty cursor starts from the first page.
+func (c *ClobClient) GetMarkets(nextCursor string) (*types.PaginatedMarkets, error) {
+	start := time.Now()
+	
+	if nextCursor == "" {
+		nextCursor = InitialCursor
+	}
+	
+	// Make request
+	url := fmt.Sprintf("%s%s?next_cursor=%s", c.host, GetMarkets, nextCursor)
+	resp, err := c.makeRequest("GET", url, nil, nil)
+	if err != nil {
+		c.recordMetric("markets_retrieval", start, false, err.Error())
+		return nil, fmt.Errorf("failed to get markets: %w", err)
+	}
+	
+	// Parse response
+	var result types.PaginatedMarkets
+	if err := json.Unmarshal(resp, &result); err != nil {
+		c.recordMetric("markets_retrieval", start, false, err.Error())
+		return nil, fmt.Errorf("failed to parse markets response: %w", err)
+	}
+	
+	c.recordMetric("markets_retrieval", start, true, "")
+	return &result, nil
+}
+
+// GetMarket gets a single CLOB market by condition ID
+func (c *ClobClient) GetMarket(conditionID string) (*types.Market, error) {
+	start := time.Now()
+	
+	// Make request
+	url := c.host + GetMarket + conditionID
+	resp, err := c.makeRequest("GET", url, nil, nil)
+	if err != nil {
+		c.recordMetric("market_retrieval", start, false, err.Error())
+		return nil, fmt.Errorf("failed to get market: %w", err)
+	}
+	
+	// Parse response
+	var result types.Market
+	if err := json.Unmarshal(resp, &result); err != nil {
+		c.recordMetric("market_retrieval", start, false, err.Error())
+		return nil, fmt.Errorf("failed to parse market response: %w", err)
+	}
+	
+	c.recordMetric("market_retrieval", start, true, "")
+	return &result, nil
+}
+
+// GetOrderBook gets the order book for a token
+func (c *ClobClient) GetOrderBook(tokenID string) (*types.OrderBookSummary, error) {
+	start := time.Now()
+	
+	// Make request
+	url := fmt.Sprintf("%s%s?token_id=%s", c.host, GetOrderBook, tokenID)
+	resp, err := c.makeRequest("GET", url, nil, nil)
+	if err != nil {
+		c.recordMetric("order_book_retrieval", start, false, err.Error())
+		return nil, fmt.Errorf("failed to get order book: %w", err)
+	}
+	
+	// Parse response
+	var result types.OrderBookSummary
+	if err := json.Unmarshal(resp, &result); err != nil {
+		c.recordMetric("order_book_retrieval", start, false, err.Error())
+		return nil, fmt.Errorf("failed to parse order book response: %w", err)
+	}
+	
+	c.recordMetric("order_book_retrieval", start, true, "")
+	return &result, nil
+}
+
+// GetMidpoint gets the midpoint price for a token
+func (c *ClobClient) GetMidpoint(tokenID string) (*types.MidpointResponse, error) {
+	start := time.Now()
+	
+	// Make request
+	url := fmt.Sprintf("%s%s?token_id=%s", c.host, GetMidpoint, tokenID)
+	resp, err := c.makeRequest("GET", url, nil, nil)
+	if err != nil {
+		c.recordMetric("midpoint_retrieval", start, false, err.Error())
+		return nil, fmt.Errorf("failed to get midpoint: %w", err)
+	}
+	
+	// Parse response
+	var result types.MidpointResponse
+	if err := json.Unmarshal(resp, &result); err != nil {
+		c.recordMetric("midpoint_retrieval", start, false, err.Error())
+		return nil, fmt.Errorf("failed to parse midpoint response: %w", err)
+	}
+	
+	c.recordMetric("midpoint_retrieval", start, true, "")
+	return &result, nil
+}
+
+// GetTrades gets the trade history for the authenticated account, following pagination
+func (c *ClobClient) GetTrades(params *types.TradeParams) ([]types.Trade, error) {
+	start := time.Now()
+	
+	if err := c.requireAuth(types.L2); err != nil {
+		c.recordMetric("trades_retrieval", start, false, "insufficient auth level")
+		return nil, err
+	}
+	
+	trades := make([]types.Trade, 0)
+	cursor := InitialCursor
+	for cursor != EndCursor {
+		page, err := c.getTradesPage(params, cursor)
+		if err != nil {
+			c.recordMetric("trades_retrieval", start, false, err.Error())
+			return nil, err
+		}
+		trades = append(trades, page.Data...)
+		if page.NextCursor == "" {
+			break
+		}
+		cursor = page.NextCursor
+	}
+	
+	c.recordMetric("trades_retrieval", start, true, "")
+	return trades, nil
+}
+
+// getTradesPage fetches a single page of trades starting at cursor
+func (c *ClobClient) getTradesPage(params *types.TradeParams, cursor string) (*types.PaginatedTrades, error) {
+	requestArgs := types.RequestArgs{
+		Method:      "GET",
+		RequestPath: GetTrades,
+		Body:        nil,
+	}
+	
+	headers, err := c.headerBuilder.CreateLevel2Headers(c.creds, requestArgs)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create headers: %w", err)
+	}
+	
+	// Build URL with query parameters
+	queryParams := []string{fmt.Sprintf("next_cursor=%s", cursor)}
+	if params != nil {
+		if params.ID != "" {
+			queryParams = append(queryParams, fmt.Sprintf("id=%s", params.ID))
+		}
+		if params.MakerAddress != "" {
+			queryParams = append(queryParams, fmt.Sprintf("maker_address=%s", params.MakerAddress))
+		}
+		if params.Market != "" {
+			queryParams = append(queryParams, fmt.Sprintf("market=%s", params.Market))
+		}
+		if params.AssetID != "" {
+			queryParams = append(queryParams, fmt.Sprintf("asset_id=%s", params.AssetID))
+		}
+		if params.Before != 0 {
+			queryParams = append(queryParams, fmt.Sprintf("before=%d", params.Before))
+		}
+		if params.After != 0 {
+			queryParams = append(queryParams, fmt.Sprintf("after=%d", params.After))
+		}
+	}
+	url := c.host + GetTrades + "?" + strings.Join(queryParams, "&")
+	
+	resp, err := c.makeRequest("GET", url, headers, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get trades: %w", err)
+	}
+	
+	var page types.PaginatedTrades
+	if err := json.Unmarshal(resp, &page); err != nil {
+		return nil, fmt.Errorf("failed to parse trades response: %w", err)
+	}
+	return &page, nil
+}
+
+// GetOrder gets a single order by ID
+func (c *ClobClient) GetOrder(orderID string) (*types.OpenOrder, error) {
+	start := time.Now()
+	
+	if err := c.requireAuth(types.L2); err != nil {
+		c.recordMetric("order_retrieval", start, false, "insufficient auth level")
+		return nil, err
+	}
+	
+	// Create headers
+	requestArgs := types.RequestArgs{
+		Method:      "GET",
+		RequestPath: GetOrder + orderID,
+		Body:        nil,
+	}
+	
+	headers, err := c.headerBuilder.CreateLevel2Headers(c.creds, requestArgs)
+	if err != nil {
+		c.recordMetric("order_retrieval", start, false, err.Error())
+		return nil, fmt.Errorf("failed to create headers: %w", err)
+	}
+	
+	// Make request
+	url := c.host + GetOrder + orderID
+	resp, err := c.makeRequest("GET", url, headers, nil)
+	if err != nil {
+		c.recordMetric("order_retrieval", start, false, err.Error())
+		return nil, fmt.Errorf("failed to get order: %w", err)
+	}
+	
+	// Parse response
+	var result types.OpenOrder
+	if err := json.Unmarshal(resp, &result); err != nil {
+		c.recordMetric("order_retrieval", start, false, err.Error())
+		return nil, fmt.Errorf("failed to parse order response: %w", err)
+	}
+	
+	c.recordMetric("order_retrieval", start, true, "")
+	return &result, nil
+}
+
+// GetOpenOrders gets the open orders for the authenticated account, following pagination
+func (c *ClobClient) GetOpenOrders(params *types.OpenOrderParams) ([]types.OpenOrder, error) {
+	start := time.Now()
+	
+	if err := c.requireAuth(types.L2); err != nil {
+		c.recordMetric("open_orders_retrieval", start, false, "insufficient auth level")
+		return nil, err
+	}
+	
+	orders := make([]types.OpenOrder, 0)
+	cursor := InitialCursor
+	for cursor != EndCursor {
+		requestArgs := types.RequestArgs{
+			Method:      "GET",
+			RequestPath: GetOrders,
+			Body:        nil,
+		}
+		
+		headers, err := c.headerBuilder.CreateLevel2Headers(c.creds, requestArgs)
+		if err != nil {
+			c.recordMetric("open_orders_retrieval", start, false, err.Error())
+			return nil, fmt.Errorf("failed to create headers: %w", err)
+		}
+		
+		// Build URL with query parameters
+		queryParams := []string{fmt.Sprintf("next_cursor=%s", cursor)}
+		if params != nil {
+			if params.ID != "" {
+				queryParams = append(queryParams, fmt.Sprintf("id=%s", params.ID))
+			}
+			if params.Market != "" {
+				queryParams = append(queryParams, fmt.Sprintf("market=%s", params.Market))
+			}
+			if params.AssetID != "" {
+				queryParams = append(queryParams, fmt.Sprintf("asset_id=%s", params.AssetID))
+			}
+		}
+		url := c.host + GetOrders + "?" + strings.Join(queryParams, "&")
+		
+		resp, err := c.makeRequest("GET", url, headers, nil)
+		if err != nil {
+			c.recordMetric("open_orders_retrieval", start, false, err.Error())
+			return nil, fmt.Errorf("failed to get open orders: %w", err)
+		}
+		
+		var page struct {
+			Data       []types.OpenOrder `json:"data"`
+			NextCursor string            `json:"next_cursor"`
+		}
+		if err := json.Unmarshal(resp, &page); err != nil {
+			c.recordMetric("open_orders_retrieval", start, false, err.Error())
+			return nil, fmt.Errorf("failed to parse open orders response: %w", err)
+		}
+		
+		orders = append(orders, page.Data...)
+		if page.NextCursor == "" {
+			break
+		}
+		cursor = page.NextCursor
+	}
+	
+	c.recordMetric("open_orders_retrieval", start, true, "")
+	return orders, nil
+}
+
 // GetBalanceAllowance gets balance and allowance information
 func (c *ClobClient) GetBalanceAllowance(params *types.BalanceAllowanceParams) (*types.BalanceAllowanceResponse, error) {
 	start := time.Now()
 	
-	if c.authLevel < types.L2 {
+	if err := c.requireAuth(types.L2); err != nil {
 		c.recordMetric("balance_retrieval", start, false, "insufficient auth level")
-		return nil, fmt.Errorf("Level 2 authentication required")
+		return nil, err
 	}
 	
 	// Create headers for authenticated request
@@ -455,9 +914,9 @@ func (c *ClobClient) GetBalanceAllowance(params *types.BalanceAllowanceParams) (
 func (c *ClobClient) UpdateBalanceAllowance(params *types.BalanceAllowanceParams) (*types.BalanceAllowanceResponse, error) {
 	start := time.Now()
 	
-	if c.authLevel < types.L2 {
+	if err := c.requireAuth(types.L2); err != nil {
 		c.recordMetric("balance_update", start, false, "insufficient auth level")
-		return nil, fmt.Errorf("Level 2 authentication required")
+		return nil, err
 	}
 	
 	// Create headers for authenticated request
@@ -524,12 +983,18 @@ func (c *ClobClient) UpdateBalanceAllowance(params *types.BalanceAllowanceParams
 
 // CreateOrder creates and signs a limit order
 func (c *ClobClient) CreateOrder(orderArgs types.OrderArgs, options *types.CreateOrderOptions) (*types.SignedOrder, error) {
-	start := time.Now()
+	return c.createOrder(orderArgs, options, types.NewStageTimer(time.Now()))
+}
+
+// createOrder is CreateOrder, marking the creation stages on timer
+func (c *ClobClient) createOrder(orderArgs types.OrderArgs, options *types.CreateOrderOptions, timer *types.StageTimer) (*types.SignedOrder, error) {
+	start := timer.Start()
 	
-	if c.authLevel < types.L1 {
+	if err := c.requireAuth(types.L1); err != nil {
 		c.recordMetric("order_creation", start, false, "insufficient auth level")
-		return nil, fmt.Errorf("Level 1 authentication required")
+		return nil, err
 	}
+	c.applyOrderDefaults(&orderArgs)
 	
 	// Resolve options
 	resolvedOptions, err := c.resolveOrderOptions(orderArgs.TokenID, options)
@@ -537,11 +1002,28 @@ func (c *ClobClient) CreateOrder(orderArgs types.OrderArgs, options *types.Creat
 		c.recordMetric("order_creation", start, false, err.Error())
 		return nil, fmt.Errorf("failed to resolve order options: %w", err)
 	}
+	timer.Mark(types.StageOptions)
+	
+	// Snap the price to the tick grid when rounding is enabled for this side
+	var adjustment *types.PriceAdjustment
+	if mode := resolvedOptions.PriceRounding.Mode(orderArgs.Side); mode != "" {
+		snapped := utils.SnapPrice(orderArgs.Price, resolvedOptions.TickSize, mode)
+		if snapped != orderArgs.Price {
+			adjustment = &types.PriceAdjustment{Requested: orderArgs.Price, Price: snapped, Mode: mode}
+			orderArgs.Price = snapped
+		}
+	}
 	
-	// Validate price
-	if !utils.ValidatePrice(orderArgs.Price, resolvedOptions.TickSize) {
-		c.recordMetric("order_creation", start, false, "invalid price")
-		return nil, fmt.Errorf("invalid price %.6f for tick size %s", orderArgs.Price, resolvedOptions.TickSize)
+	// Size by notional once the price is final
+	if err := sizeFromNotional(&orderArgs, resolvedOptions.TickSize); err != nil {
+		c.recordMetric("order_creation", start, false, err.Error())
+		return nil, err
+	}
+	
+	// Validate price, size, notional and taker
+	if err := c.validateOrder(orderArgs, resolvedOptions.TickSize); err != nil {
+		c.recordMetric("order_creation", start, false, err.Error())
+		return nil, err
 	}
 	
 	// Get contract config
@@ -556,99 +1038,156 @@ func (c *ClobClient) CreateOrder(orderArgs types.OrderArgs, options *types.Creat
 	
 	if !exists {
 		c.recordMetric("order_creation", start, false, "unsupported chain")
-		return nil, fmt.Errorf("unsupported chain ID: %d", c.chainID)
+		return nil, fmt.Errorf("%w: %d", ErrUnsupportedChainID, c.chainID)
 	}
+	timer.Mark(types.StageValidate)
 	
 	// Create order
-	signedOrder, err := c.orderBuilder.CreateOrder(orderArgs, *resolvedOptions, contractConfig.Exchange)
+	signedOrder, err := c.builder().CreateOrderTimed(orderArgs, *resolvedOptions, contractConfig.Exchange, timer)
 	if err != nil {
 		c.recordMetric("order_creation", start, false, err.Error())
 		return nil, fmt.Errorf("failed to create order: %w", err)
 	}
+	signedOrder.PriceAdjustment = adjustment
+	signedOrder.AllowCross = resolvedOptions.AllowCross
 	
-	c.recordMetric("order_creation", start, true, "")
+	c.recordStages("order_creation", start, timer.Stages())
 	return signedOrder, nil
 }
 
-// CreateMarketOrder creates and signs a market order
-// func (c *ClobClient) CreateMarketOrder(orderArgs types.MarketOrderArgs, options *types.CreateOrderOptions) (*types.SignedOrder, error) {
-// 	start := time.Now()
-	
-// 	if c.authLevel < types.L1 {
-// 		c.recordMetric("market_order_creation", start, false, "insufficient auth level")
-// 		return nil, fmt.Errorf("Level 1 authentication required")
-// 	}
-	
-// 	// Resolve options
-// 	resolvedOptions, err := c.resolveOrderOptions(orderArgs.TokenID, options)
-// 	if err != nil {
-// 		c.recordMetric("market_order_creation", start, false, err.Error())
-// 		return nil, fmt.Errorf("failed to resolve order options: %w", err)
-// 	}
-	
-// 	// Calculate market price if not provided
-// 	if orderArgs.Price <= 0 {
-// 		price, err := c.calculateMarketPrice(orderArgs.TokenID, orderArgs.Side, orderArgs.Amount, orderArgs.OrderType)
-// 		if err != nil {
-// 			c.recordMetric("market_order_creation", start, false, err.Error())
-// 			return nil, fmt.Errorf("failed to calculate market price: %w", err)
-// 		}
-// 		orderArgs.Price = price
-// 	}
-	
-// 	// Validate price
-// 	if !utils.ValidatePrice(orderArgs.Price, resolvedOptions.TickSize) {
-// 		c.recordMetric("market_order_creation", start, false, "invalid price")
-// 		return nil, fmt.Errorf("invalid price %.6f for tick size %s", orderArgs.Price, resolvedOptions.TickSize)
-// 	}
-	
-// 	// Get contract config
-// 	var contractConfig types.ContractConfig
-// 	var exists bool
-	
-// 	if resolvedOptions.NegRisk {
-// 		contractConfig, exists = negRiskContractConfigs[c.chainID]
-// 	} else {
-// 		contractConfig, exists = contractConfigs[c.chainID]
-// 	}
-	
-// 	if !exists {
-// 		c.recordMetric("market_order_creation", start, false, "unsupported chain")
-// 		return nil, fmt.Errorf("unsupported chain ID: %d", c.chainID)
-// 	}
-	
-// 	// Create market order
-// 	signedOrder, err := c.orderBuilder.CreateMarketOrder(orderArgs, *resolvedOptions, contractConfig.Exchange)
-// 	if err != nil {
-// 		c.recordMetric("market_order_creation", start, false, err.Error())
-// 		return nil, fmt.Errorf("failed to create market order: %w", err)
-// 	}
-	
-// 	c.recordMetric("market_order_creation", start, true, "")
-// 	return signedOrder, nil
-// }
+// CreateMarketOrder creates and signs a market order. Without a price, the price is
+// computed from the current book; with MaxSlippageBps set, the order is rejected
+// locally unless the book fills within that distance of the best price, and the
+// limit price is set at the bound.
+func (c *ClobClient) CreateMarketOrder(orderArgs types.MarketOrderArgs, options *types.CreateOrderOptions) (*types.SignedOrder, error) {
+	start := time.Now()
+	
+	if err := c.requireAuth(types.L1); err != nil {
+		c.recordMetric("market_order_creation", start, false, "insufficient auth level")
+		return nil, err
+	}
+	c.applyMarketOrderDefaults(&orderArgs)
+	if err := resolveMarketAmount(&orderArgs); err != nil {
+		c.recordMetric("market_order_creation", start, false, err.Error())
+		return nil, err
+	}
+	
+	timer := types.NewStageTimer(start)
+	
+	// Resolve options
+	resolvedOptions, err := c.resolveOrderOptions(orderArgs.TokenID, options)
+	if err != nil {
+		c.recordMetric("market_order_creation", start, false, err.Error())
+		return nil, fmt.Errorf("failed to resolve order options: %w", err)
+	}
+	timer.Mark(types.StageOptions)
+	
+	// Calculate market price if not provided
+	if orderArgs.Price <= 0 {
+		price, err := c.calculateMarketPrice(orderArgs, resolvedOptions.TickSize)
+		if err != nil {
+			c.recordMetric("market_order_creation", start, false, err.Error())
+			return nil, fmt.Errorf("failed to calculate market price: %w", err)
+		}
+		orderArgs.Price = price
+	}
+	
+	// Validate price, amount, notional and taker
+	if err := c.validateMarketOrder(orderArgs, resolvedOptions.TickSize); err != nil {
+		c.recordMetric("market_order_creation", start, false, err.Error())
+		return nil, err
+	}
+	
+	// Get contract config
+	var contractConfig types.ContractConfig
+	var exists bool
+	
+	if resolvedOptions.NegRisk {
+		contractConfig, exists = negRiskContractConfigs[c.chainID]
+	} else {
+		contractConfig, exists = contractConfigs[c.chainID]
+	}
+	
+	if !exists {
+		c.recordMetric("market_order_creation", start, false, "unsupported chain")
+		return nil, fmt.Errorf("%w: %d", ErrUnsupportedChainID, c.chainID)
+	}
+	timer.Mark(types.StageValidate)
+	
+	// Create market order
+	signedOrder, err := c.builder().CreateMarketOrderTimed(orderArgs, *resolvedOptions, contractConfig.Exchange, timer)
+	if err != nil {
+		c.recordMetric("market_order_creation", start, false, err.Error())
+		return nil, fmt.Errorf("failed to create market order: %w", err)
+	}
+	
+	c.recordStages("market_order_creation", start, timer.Stages())
+	return signedOrder, nil
+}
 
 // PostOrder posts a signed order
 func (c *ClobClient) PostOrder(signedOrder *types.SignedOrder, orderType types.OrderType) (map[string]interface{}, error) {
-	start := time.Now()
+	return c.postOrder(signedOrder, orderType, types.NewStageTimer(time.Now()))
+}
+
+// postOrder is PostOrder, marking the posting stages on timer
+func (c *ClobClient) postOrder(signedOrder *types.SignedOrder, orderType types.OrderType, timer *types.StageTimer) (map[string]interface{}, error) {
+	start := timer.Start()
 	
-	if c.authLevel < types.L2 {
+	if err := c.requireAuth(types.L2); err != nil {
 		c.recordMetric("order_posting", start, false, "insufficient auth level")
-		return nil, fmt.Errorf("Level 2 authentication required")
+		return nil, err
+	}
+	
+	// Reject combinations the exchange would refuse after signing
+	if err := validateOrderType(signedOrder, orderType, c.clock.Now()); err != nil {
+		c.recordMetric("order_posting", start, false, "invalid order type")
+		return nil, err
+	}
+	if c.marketCheck {
+		if _, err := c.IsMarketAccepting(signedOrder.TokenID); err != nil {
+			c.recordMetric("order_posting", start, false, "market check")
+			return nil, fmt.Errorf("market check failed: %w", err)
+		}
+	}
+	if orderType == types.FOK && c.fokFillCheck {
+		if err := c.checkFOKFill(signedOrder); err != nil {
+			c.recordMetric("order_posting", start, false, "fok fill check")
+			return nil, fmt.Errorf("FOK fill check failed: %w", err)
+		}
+	}
+	if c.crossCheck != CrossCheckOff && !signedOrder.AllowCross && (orderType == types.GTC || orderType == types.GTD) {
+		if err := c.checkCross(signedOrder); err != nil {
+			c.recordMetric("order_posting", start, false, "cross check")
+			return nil, fmt.Errorf("cross check failed: %w", err)
+		}
 	}
+	if c.midpointCheck != MidpointCheckOff {
+		if err := c.checkMidpoint(signedOrder); err != nil {
+			c.recordMetric("order_posting", start, false, "midpoint check")
+			return nil, fmt.Errorf("midpoint check failed: %w", err)
+		}
+	}
+	timer.Mark(types.StageChecks)
 	
 	// Create request body
 	orderRequest := types.OrderRequest{
 		Order:     *signedOrder,
-		Owner:     c.creds.ApiKey,
+		Owner:     c.orderOwner(signedOrder),
 		OrderType: orderType,
 	}
+	body, err := orderRequest.MarshalWire(c.orderEncoding)
+	if err != nil {
+		c.recordMetric("order_posting", start, false, err.Error())
+		return nil, fmt.Errorf("failed to encode order: %w", err)
+	}
+	timer.Mark(types.StageMarshal)
 	
 	// Create headers
 	requestArgs := types.RequestArgs{
 		Method:      "POST",
 		RequestPath: PostOrder,
-		Body:        orderRequest,
+		Body:        body,
 	}
 	
 	headers, err := c.headerBuilder.CreateLevel2Headers(c.creds, requestArgs)
@@ -656,14 +1195,30 @@ func (c *ClobClient) PostOrder(signedOrder *types.SignedOrder, orderType types.O
 		c.recordMetric("order_posting", start, false, err.Error())
 		return nil, fmt.Errorf("failed to create headers: %w", err)
 	}
+	timer.Mark(types.StageHeaders)
+	
+	// Refuse an order already posted when dedupe is enabled
+	if err := c.claimOrders(signedOrder); err != nil {
+		c.recordMetric("order_posting", start, false, "duplicate order")
+		return nil, err
+	}
 	
 	// Make request
 	url := c.host + PostOrder
-	resp, err := c.makeRequest("POST", url, headers, orderRequest)
+	resp, err := c.makeRequest("POST", url, headers, body)
+	if err != nil && !rejected(err) && c.dedupeEnabled() {
+		// An attempt may have placed the order; only the exchange knows
+		if result, found := c.findPostedOrder(signedOrder); found {
+			c.recordStages("order_posting", start, timer.Stages())
+			return result, nil
+		}
+	}
 	if err != nil {
+		c.releaseRejected(err, signedOrder)
 		c.recordMetric("order_posting", start, false, err.Error())
-		return nil, fmt.Errorf("failed to post order: %w", err)
+		return nil, fmt.Errorf("failed to post order: %w", c.recoverTickSize(signedOrder.TokenID, err))
 	}
+	timer.Mark(types.StageHTTP)
 	
 	// Parse response
 	var result map[string]interface{}
@@ -672,7 +1227,133 @@ func (c *ClobClient) PostOrder(signedOrder *types.SignedOrder, orderType types.O
 		return nil, fmt.Errorf("failed to parse response: %w", err)
 	}
 	
-	c.recordMetric("order_posting", start, true, "")
+	c.recordStages("order_posting", start, timer.Stages())
+	return result, nil
+}
+
+// CancelOrder cancels a single order by ID
+func (c *ClobClient) CancelOrder(orderID string) (map[string]interface{}, error) {
+	start := time.Now()
+	
+	if err := c.requireAuth(types.L2); err != nil {
+		c.recordMetric("order_cancellation", start, false, "insufficient auth level")
+		return nil, err
+	}
+	
+	// Create request body
+	body := map[string]string{"orderID": orderID}
+	
+	// Create headers
+	requestArgs := types.RequestArgs{
+		Method:      "DELETE",
+		RequestPath: CancelOrder,
+		Body:        body,
+	}
+	
+	headers, err := c.headerBuilder.CreateLevel2Headers(c.creds, requestArgs)
+	if err != nil {
+		c.recordMetric("order_cancellation", start, false, err.Error())
+		return nil, fmt.Errorf("failed to create headers: %w", err)
+	}
+	
+	// Make request
+	url := c.host + CancelOrder
+	resp, err := c.makeRequest("DELETE", url, headers, body)
+	if err != nil {
+		c.recordMetric("order_cancellation", start, false, err.Error())
+		return nil, fmt.Errorf("failed to cancel order: %w", err)
+	}
+	
+	// Parse response
+	var result map[string]interface{}
+	if err := json.Unmarshal(resp, &result); err != nil {
+		c.recordMetric("order_cancellation", start, false, err.Error())
+		return nil, fmt.Errorf("failed to parse response: %w", err)
+	}
+	
+	c.recordMetric("order_cancellation", start, true, "")
+	return result, nil
+}
+
+// CancelOrders cancels multiple orders by ID
+func (c *ClobClient) CancelOrders(orderIDs []string) (map[string]interface{}, error) {
+	start := time.Now()
+	
+	if err := c.requireAuth(types.L2); err != nil {
+		c.recordMetric("orders_cancellation", start, false, "insufficient auth level")
+		return nil, err
+	}
+	
+	// Create headers
+	requestArgs := types.RequestArgs{
+		Method:      "DELETE",
+		RequestPath: CancelOrders,
+		Body:        orderIDs,
+	}
+	
+	headers, err := c.headerBuilder.CreateLevel2Headers(c.creds, requestArgs)
+	if err != nil {
+		c.recordMetric("orders_cancellation", start, false, err.Error())
+		return nil, fmt.Errorf("failed to create headers: %w", err)
+	}
+	
+	// Make request
+	url := c.host + CancelOrders
+	resp, err := c.makeRequest("DELETE", url, headers, orderIDs)
+	if err != nil {
+		c.recordMetric("orders_cancellation", start, false, err.Error())
+		return nil, fmt.Errorf("failed to cancel orders: %w", err)
+	}
+	
+	// Parse response
+	var result map[string]interface{}
+	if err := json.Unmarshal(resp, &result); err != nil {
+		c.recordMetric("orders_cancellation", start, false, err.Error())
+		return nil, fmt.Errorf("failed to parse response: %w", err)
+	}
+	
+	c.recordMetric("orders_cancellation", start, true, "")
+	return result, nil
+}
+
+// CancelAll cancels all open orders for the authenticated account
+func (c *ClobClient) CancelAll() (map[string]interface{}, error) {
+	start := time.Now()
+	
+	if err := c.requireAuth(types.L2); err != nil {
+		c.recordMetric("cancel_all", start, false, "insufficient auth level")
+		return nil, err
+	}
+	
+	// Create headers
+	requestArgs := types.RequestArgs{
+		Method:      "DELETE",
+		RequestPath: CancelAll,
+		Body:        nil,
+	}
+	
+	headers, err := c.headerBuilder.CreateLevel2Headers(c.creds, requestArgs)
+	if err != nil {
+		c.recordMetric("cancel_all", start, false, err.Error())
+		return nil, fmt.Errorf("failed to create headers: %w", err)
+	}
+	
+	// Make request
+	url := c.host + CancelAll
+	resp, err := c.makeRequest("DELETE", url, headers, nil)
+	if err != nil {
+		c.recordMetric("cancel_all", start, false, err.Error())
+		return nil, fmt.Errorf("failed to cancel all orders: %w", err)
+	}
+	
+	// Parse response
+	var result map[string]interface{}
+	if err := json.Unmarshal(resp, &result); err != nil {
+		c.recordMetric("cancel_all", start, false, err.Error())
+		return nil, fmt.Errorf("failed to parse response: %w", err)
+	}
+	
+	c.recordMetric("cancel_all", start, true, "")
 	return result, nil
 }
 
@@ -681,20 +1362,41 @@ func (c *ClobClient) CreateAndPostOrder(orderArgs types.OrderArgs, options *type
 	start := time.Now()
 	
 	// Create order
-	signedOrder, err := c.CreateOrder(orderArgs, options)
+	creating := types.NewStageTimer(start)
+	signedOrder, err := c.createOrder(orderArgs, options, creating)
 	if err != nil {
 		c.recordMetric("create_and_post_order", start, false, err.Error())
 		return nil, fmt.Errorf("failed to create order: %w", err)
 	}
 	
-	// Post order
-	result, err := c.PostOrder(signedOrder, types.GTC)
+	// Post order, as GTD when it expires
+	posting := types.NewStageTimer(time.Now())
+	result, err := c.postOrder(signedOrder, PostOrderType(signedOrder), posting)
+	
+	// Recreate the order once if it was rejected because the tick size changed
+	var tickErr *TickSizeError
+	if err != nil && c.tickSizeRetry && errors.As(err, &tickErr) {
+		// Keep the caller's options, such as AllowCross, changing only the tick size
+		var retryOptions types.CreateOrderOptions
+		if options != nil {
+			retryOptions = *options
+		}
+		retryOptions.TickSize = tickErr.Current
+		creating = types.NewStageTimer(time.Now())
+		signedOrder, err = c.createOrder(orderArgs, &retryOptions, creating)
+		if err != nil {
+			c.recordMetric("create_and_post_order", start, false, err.Error())
+			return nil, fmt.Errorf("failed to recreate order after tick size change: %w", err)
+		}
+		posting = types.NewStageTimer(time.Now())
+		result, err = c.postOrder(signedOrder, PostOrderType(signedOrder), posting)
+	}
 	if err != nil {
 		c.recordMetric("create_and_post_order", start, false, err.Error())
 		return nil, fmt.Errorf("failed to post order: %w", err)
 	}
 	
-	c.recordMetric("create_and_post_order", start, true, "")
+	c.recordStages("create_and_post_order", start, append(creating.Stages(), posting.Stages()...))
 	return result, nil
 }
 
@@ -715,83 +1417,254 @@ func (c *ClobClient) resolveOrderOptions(tokenID string, options *types.CreateOr
 		options = &types.CreateOrderOptions{}
 	}
 	
-	// Get tick size if not provided
+	// A new token needs both its tick size and neg risk flag; fetch them concurrently
+	var tickSize types.TickSize
+	var tickErr error
+	var wg sync.WaitGroup
 	if options.TickSize == "" {
-		tickSize, err := c.GetTickSize(tokenID)
-		if err != nil {
-			return nil, err
+		if cached, exists := c.CachedTickSize(tokenID); exists {
+			options.TickSize = cached
+		} else {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				tickSize, tickErr = c.GetTickSize(tokenID)
+			}()
 		}
-		options.TickSize = tickSize
 	}
-	
-	// Get neg risk if not set
 	negRisk, err := c.GetNegRisk(tokenID)
+	wg.Wait()
+	if tickErr != nil {
+		return nil, tickErr
+	}
 	if err != nil {
 		return nil, err
 	}
+	if options.TickSize == "" {
+		options.TickSize = tickSize
+	}
 	options.NegRisk = negRisk
 	
 	return options, nil
 }
 
-// func (c *ClobClient) calculateMarketPrice(tokenID string, side types.OrderSide, amount float64, orderType types.OrderType) (float64, error) {
-// 	// This is a simplified implementation
-// 	// In production, you'd fetch the order book and calculate the matching price
+// calculateMarketPrice prices a market order against the current book
+func (c *ClobClient) calculateMarketPrice(orderArgs types.MarketOrderArgs, tickSize types.TickSize) (float64, error) {
+	book, err := c.GetOrderBook(orderArgs.TokenID)
+	if err != nil {
+		return 0, err
+	}
+	return marketPrice(book, orderArgs.Side, orderArgs.Amount, orderArgs.OrderType, orderArgs.MaxSlippageBps, tickSize)
+}
+
+// marketPrice walks the opposite side of the book to find the worst price needed to fill
+// amount (collateral for BUY, shares for SELL). FOK orders and orders with a slippage
+// bound must fill completely; otherwise the worst available level is used.
+func marketPrice(book *types.OrderBookSummary, side types.OrderSide, amount float64, orderType types.OrderType, maxSlippageBps int, tickSize types.TickSize) (float64, error) {
+	bids, asks, err := book.Levels()
+	if err != nil {
+		return 0, err
+	}
+	
+	levels := asks
+	if side == types.SELL {
+		levels = bids
+	} else if side != types.BUY {
+		return 0, fmt.Errorf("invalid order side: %s", side)
+	}
+	if len(levels) == 0 {
+		return 0, fmt.Errorf("no liquidity on the book for a %s market order", side)
+	}
+	
+	// Find the level at which the cumulative amount covers the order
+	filled := 0.0
+	price := 0.0
+	for _, level := range levels {
+		price = level.Price
+		if side == types.BUY {
+			filled += level.Price * level.Size
+		} else {
+			filled += level.Size
+		}
+		if filled >= amount {
+			break
+		}
+	}
+	if filled < amount && (orderType == types.FOK || maxSlippageBps > 0) {
+		return 0, fmt.Errorf("insufficient liquidity: book holds %.6f of %.6f", filled, amount)
+	}
+	if maxSlippageBps <= 0 {
+		return price, nil
+	}
+	
+	// Bound the price to the allowed distance from the best level
+	best := levels[0].Price
+	tick := utils.ParseTickSize(tickSize)
+	slippage := float64(maxSlippageBps) / 10000
+	if side == types.BUY {
+		bound := best * (1 + slippage)
+		if price > bound+1e-9 {
+			return 0, fmt.Errorf("%w: filling needs %.4f, above %.4f (%d bps over best ask %.4f)", ErrSlippageExceeded, price, bound, maxSlippageBps, best)
+		}
+		limit := math.Min(math.Floor(bound/tick+1e-9)*tick, 1-tick)
+		return utils.RoundNormal(math.Max(limit, price), utils.GetRoundingConfig(tickSize).Price), nil
+	}
 	
-// 	// For now, return a default price
-// 	if side == types.BUY {
-// 		return 0.5, nil // Default buy price
-// 	}
-// 	return 0.5, nil // Default sell price
-// }
+	bound := best * (1 - slippage)
+	if price < bound-1e-9 {
+		return 0, fmt.Errorf("%w: filling needs %.4f, below %.4f (%d bps under best bid %.4f)", ErrSlippageExceeded, price, bound, maxSlippageBps, best)
+	}
+	limit := math.Max(math.Ceil(bound/tick-1e-9)*tick, tick)
+	return utils.RoundNormal(math.Min(limit, price), utils.GetRoundingConfig(tickSize).Price), nil
+}
 
 func (c *ClobClient) makeRequest(method, url string, headers map[string]string, body interface{}) ([]byte, error) {
+	return c.makeRequestContext(context.Background(), method, url, headers, body)
+}
+
+// makeRequestContext is makeRequest bound to ctx
+func (c *ClobClient) makeRequestContext(ctx context.Context, method, url string, headers map[string]string, body interface{}) ([]byte, error) {
 	start := time.Now()
 	
-	var reqBody io.Reader
+	// Serve stable GETs from the response cache while fresh
+	cacheKey := c.cacheKey(method, url, headers)
+	var cached *cachedResponse
+	if cacheKey != "" {
+		entry, fresh := c.responseCache.lookup(cacheKey)
+		if fresh {
+			c.recordMetric("http_request", start, true, "from_cache")
+			return entry.body, nil
+		}
+		if entry != nil && entry.etag != "" {
+			cached = entry
+		}
+	}
+	
+	// Tag the request so failures can be correlated
+	requestID := newRequestID()
+	
+	// Respect the client-side rate limit, if one is set
+	c.waitRateLimit()
+	
+	// Wait out an exhausted server budget rather than collecting a 429
+	if err := c.waitForBudget(ctx, method, url); err != nil {
+		c.recordRequestMetric(requestID, start, false, err.Error())
+		return nil, &RequestError{RequestID: requestID, Err: fmt.Errorf("failed waiting for rate limit reset: %w", err)}
+	}
+	
+	var bodyBytes, sentBody []byte
+	var compressed bool
 	if body != nil {
-		bodyBytes, err := json.Marshal(body)
+		var err error
+		bodyBytes, err = json.Marshal(body)
 		if err != nil {
-			c.recordMetric("http_request", start, false, err.Error())
-			return nil, fmt.Errorf("failed to marshal body: %w", err)
+			c.recordRequestMetric(requestID, start, false, err.Error())
+			return nil, &RequestError{RequestID: requestID, Err: fmt.Errorf("failed to marshal body: %w", err)}
 		}
-		reqBody = bytes.NewReader(bodyBytes)
+		sentBody, compressed = c.compression.compress(method, bodyBytes)
 	}
 	
-	req, err := http.NewRequest(method, url, reqBody)
+	// Bound the exchange by the call's, endpoint's or client's timeout
+	reqCtx, cancel := c.requestContext(ctx, url)
+	defer func() { cancel() }()
+	
+	// Send to the active host when failover is configured
+	target, hostIndex := c.routeURL(method, url)
+	
+	req, err := http.NewRequestWithContext(reqCtx, method, target, nil)
 	if err != nil {
-		c.recordMetric("http_request", start, false, err.Error())
-		return nil, fmt.Errorf("failed to create request: %w", err)
+		c.recordRequestMetric(requestID, start, false, err.Error())
+		return nil, &RequestError{RequestID: requestID, Err: fmt.Errorf("failed to create request: %w", err)}
+	}
+	if body != nil {
+		setBody(req, sentBody)
 	}
 	
 	// Set headers
 	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("User-Agent", c.userAgent)
+	req.Header.Set(version.Header, version.Version)
+	req.Header.Set(RequestIDHeader, requestID)
 	for key, value := range headers {
 		req.Header.Set(key, value)
 	}
+	if cached != nil {
+		req.Header.Set("If-None-Match", cached.etag)
+	}
+	if compressed {
+		req.Header.Set("Content-Encoding", "gzip")
+	}
 	
-	// Make request
-	resp, err := c.httpClient.Do(req)
+	// Make request and read the response, hedging market data reads when enabled
+	var resp *http.Response
+	var respBody []byte
+	if delay := c.hedgeDelay(method, url); delay > 0 {
+		resp, respBody, err = c.hedgedRoundTrip(req, delay)
+	} else {
+		resp, respBody, err = c.roundTrip(req)
+	}
+	
+	// Resend uncompressed when the server does not take gzip bodies
+	if err == nil && compressed && resp.StatusCode == http.StatusUnsupportedMediaType {
+		c.compression.reject()
+		req.Header.Del("Content-Encoding")
+		setBody(req, bodyBytes)
+		resp, respBody, err = c.roundTrip(req)
+	}
+	
+	// Resend failures the endpoint is safe to repeat, each with its own timeout.
+	// A resent request follows one whose outcome is unknown, so a rejection of
+	// the resend does not show that nothing took effect.
+	uncertain := false
+	for attempt := 1; c.retryable(method, url, attempt, resp, err); attempt++ {
+		uncertain = true
+		c.reportHost(hostIndex, err, statusCode(resp))
+		if !c.waitRetry(ctx, attempt) {
+			break
+		}
+		cancel()
+		reqCtx, cancel = c.requestContext(ctx, url)
+		target, hostIndex = c.routeURL(method, url)
+		retry, retryErr := retryRequest(reqCtx, req, target)
+		if retryErr != nil {
+			break
+		}
+		req = retry
+		resp, respBody, err = c.roundTrip(req)
+	}
 	if err != nil {
-		c.recordMetric("http_request", start, false, err.Error())
-		return nil, fmt.Errorf("failed to make request: %w", err)
+		c.reportHost(hostIndex, err, 0)
+		c.recordRequestMetric(requestID, start, false, err.Error())
+		return nil, &RequestError{RequestID: requestID, Err: err}
 	}
-	defer resp.Body.Close()
+	c.reportHost(hostIndex, nil, resp.StatusCode)
+	c.observeRateLimit(method, url, resp.Header, resp.StatusCode)
 	
-	// Read response
-	respBody, err := io.ReadAll(resp.Body)
-	if err != nil {
-		c.recordMetric("http_request", start, false, err.Error())
-		return nil, fmt.Errorf("failed to read response: %w", err)
+	// Unchanged since the cached copy
+	if resp.StatusCode == http.StatusNotModified && cached != nil {
+		c.responseCache.revalidated(cacheKey)
+		c.recordRequestMetric(requestID, start, true, "not_modified")
+		return cached.body, nil
 	}
 	
 	// Check status code
 	if resp.StatusCode >= 400 {
-		c.recordMetric("http_request", start, false, fmt.Sprintf("HTTP %d: %s", resp.StatusCode, string(respBody)))
-		return nil, fmt.Errorf("HTTP %d: %s", resp.StatusCode, string(respBody))
+		apiErr := &APIError{StatusCode: resp.StatusCode, Message: string(respBody)}
+		c.recordRequestMetric(requestID, start, false, apiErr.Error())
+		return nil, &RequestError{RequestID: requestID, Err: apiErr, Uncertain: uncertain}
 	}
 	
-	c.recordMetric("http_request", start, true, "")
+	// Some endpoints answer 200 with an error envelope
+	if apiErr := errorEnvelope(resp.StatusCode, respBody); apiErr != nil {
+		c.recordRequestMetric(requestID, start, false, apiErr.Error())
+		return nil, &RequestError{RequestID: requestID, Err: apiErr, Uncertain: uncertain}
+	}
+	
+	if cacheKey != "" {
+		c.responseCache.store(cacheKey, respBody, resp.Header.Get("ETag"))
+	}
+	
+	c.recordRequestMetric(requestID, start, true, "")
 	return respBody, nil
 }
 
@@ -800,7 +1673,9 @@ func (c *ClobClient) GetMetrics() []types.PerformanceMetrics {
 	allMetrics := make([]types.PerformanceMetrics, 0)
 	
 	// Add client metrics
+	c.mu.Lock()
 	allMetrics = append(allMetrics, c.metrics...)
+	c.mu.Unlock()
 	
 	// Add signer metrics
 	if c.signer != nil {
@@ -813,8 +1688,8 @@ func (c *ClobClient) GetMetrics() []types.PerformanceMetrics {
 	}
 	
 	// Add order builder metrics
-	if c.orderBuilder != nil {
-		allMetrics = append(allMetrics, c.orderBuilder.GetMetrics()...)
+	if orderBuilder := c.builder(); orderBuilder != nil {
+		allMetrics = append(allMetrics, orderBuilder.GetMetrics()...)
 	}
 	
 	return allMetrics
@@ -822,7 +1697,9 @@ func (c *ClobClient) GetMetrics() []types.PerformanceMetrics {
 
 // ClearMetrics clears all performance metrics
 func (c *ClobClient) ClearMetrics() {
+	c.mu.Lock()
 	c.metrics = make([]types.PerformanceMetrics, 0)
+	c.mu.Unlock()
 	
 	if c.signer != nil {
 		c.signer.ClearMetrics()
@@ -832,29 +1709,46 @@ func (c *ClobClient) ClearMetrics() {
 		c.headerBuilder.ClearMetrics()
 	}
 	
-	if c.orderBuilder != nil {
-		c.orderBuilder.ClearMetrics()
+	if orderBuilder := c.builder(); orderBuilder != nil {
+		orderBuilder.ClearMetrics()
 	}
 }
 
-// PrintMetrics prints performance metrics in a readable format
+// PrintMetrics prints a per-operation summary of the performance metrics,
+// followed by the most recent failures
 func (c *ClobClient) PrintMetrics() {
 	metrics := c.GetMetrics()
 	
 	fmt.Println("\n=== Performance Metrics ===")
+	fmt.Printf("%-32s %7s %8s %10s %10s %10s %10s\n", "operation", "count", "success", "avg", "p95", "p99", "max")
+	for _, op := range SummarizeMetrics(metrics) {
+		fmt.Printf("%-32s %7d %7.1f%% %10v %10v %10v %10v\n", op.Operation, op.Count, op.SuccessRate*100,
+			op.Avg.Round(time.Microsecond), op.P95.Round(time.Microsecond), op.P99.Round(time.Microsecond), op.Max.Round(time.Microsecond))
+	}
+	
+	// Recent failures, oldest first
+	failures := make([]types.PerformanceMetrics, 0)
 	for _, metric := range metrics {
-		status := "✓"
 		if !metric.Success {
-			status = "✗"
+			failures = append(failures, metric)
 		}
-		
-		fmt.Printf("%s %s: %v", status, metric.Operation, metric.Duration)
-		if metric.Error != "" {
-			fmt.Printf(" (Error: %s)", metric.Error)
+	}
+	sort.Slice(failures, func(i, j int) bool { return failures[i].StartTime.Before(failures[j].StartTime) })
+	if len(failures) > 10 {
+		failures = failures[len(failures)-10:]
+	}
+	if len(failures) > 0 {
+		fmt.Println("\nRecent failures:")
+	}
+	for _, metric := range failures {
+		fmt.Printf("✗ %s: %v", metric.Operation, metric.Duration)
+		if metric.RequestID != "" {
+			fmt.Printf(" [%s]", metric.RequestID)
 		}
-		fmt.Println()
+		fmt.Printf(" (Error: %s)\n", metric.Error)
 	}
-	fmt.Println("===========================\n")
+	fmt.Println("===========================")
+	fmt.Println()
 }
 
 // recordMetric records a performance metric
@@ -866,5 +1760,47 @@ func (c *ClobClient) recordMetric(operation string, startTime time.Time, success
 		Success:   success,
 		Error:     errorMsg,
 	}
-	c.metrics = append(c.metrics, metric)
+	c.appendMetric(metric)
+}
+
+// recordStages records a successful operation's metric with its stage breakdown
+func (c *ClobClient) recordStages(operation string, startTime time.Time, stages []types.Stage) {
+	metric := types.PerformanceMetrics{
+		Operation: operation,
+		StartTime: startTime,
+		Duration:  time.Since(startTime),
+		Success:   true,
+		Stages:    stages,
+	}
+	c.appendMetric(metric)
+}
+
+// recordRequestMetric records the metric of a single HTTP request along with its ID
+func (c *ClobClient) recordRequestMetric(requestID string, startTime time.Time, success bool, errorMsg string) {
+	metric := types.PerformanceMetrics{
+		Operation: "http_request",
+		StartTime: startTime,
+		Duration:  time.Since(startTime),
+		Success:   success,
+		Error:     errorMsg,
+		RequestID: requestID,
+	}
+	c.appendMetric(metric)
+}
+
+// appendMetric stores a metric and passes it to the metric callback and SLO tracker, if any
+func (c *ClobClient) appendMetric(metric types.PerformanceMetrics) {
+	c.mu.Lock()
+	if !c.discardMetrics {
+		c.metrics = append(c.metrics, metric)
+	}
+	onMetric := c.onMetric
+	c.mu.Unlock()
+	
+	if onMetric != nil {
+		onMetric(metric)
+	}
+	if c.sloTracker != nil {
+		c.sloTracker.Record(metric)
+	}
 }
\ No newline at end of file
@@ -1,10 +1,12 @@
 package client
 
 import (
+	"errors"
+	"sync"
 	"testing"
 	"time"
 
-	"polymarket-clob-go/pkg/types"
+	"github.com/MaDal776/polymarket-go-client/pkg/types"
 )
 
 const (
@@ -34,6 +36,7 @@ func TestCreateOrder(t *testing.T) {
 	if err != nil {
 		t.Fatalf("Failed to create client: %v", err)
 	}
+	client.negRisks[testTokenID] = false // Avoid a network lookup
 
 	orderArgs := types.OrderArgs{
 		TokenID:    testTokenID,
@@ -56,7 +59,7 @@ func TestCreateOrder(t *testing.T) {
 		t.Fatalf("Failed to create order: %v", err)
 	}
 
-	if signedOrder.Salt == "" {
+	if signedOrder.Salt == 0 {
 		t.Error("Expected non-empty salt")
 	}
 
@@ -78,6 +81,7 @@ func TestCreateMarketOrder(t *testing.T) {
 	if err != nil {
 		t.Fatalf("Failed to create client: %v", err)
 	}
+	client.negRisks[testTokenID] = false // Avoid a network lookup
 
 	marketOrderArgs := types.MarketOrderArgs{
 		TokenID:   testTokenID,
@@ -105,11 +109,58 @@ func TestCreateMarketOrder(t *testing.T) {
 	}
 }
 
+func TestCreateOrderPriceRounding(t *testing.T) {
+	client, err := NewClobClient(testHost, testChainID, testPrivateKey, nil, nil, nil)
+	if err != nil {
+		t.Fatalf("Failed to create client: %v", err)
+	}
+	client.negRisks[testTokenID] = false // Avoid a network lookup
+
+	orderArgs := types.OrderArgs{
+		TokenID:    testTokenID,
+		Price:      0.999,
+		Size:       10.0,
+		Side:       types.BUY,
+		Nonce:      time.Now().Unix(),
+		Expiration: time.Now().Add(24 * time.Hour).Unix(),
+		Taker:      "0x0000000000000000000000000000000000000000",
+	}
+
+	// Out-of-range prices are rejected by default
+	if _, err := client.CreateOrder(orderArgs, &types.CreateOrderOptions{TickSize: types.TickSize001}); err == nil {
+		t.Fatal("Expected out-of-range price to be rejected")
+	}
+
+	options := &types.CreateOrderOptions{TickSize: types.TickSize001, PriceRounding: types.RoundPassive}
+	signedOrder, err := client.CreateOrder(orderArgs, options)
+	if err != nil {
+		t.Fatalf("Failed to create rounded order: %v", err)
+	}
+	adjustment := signedOrder.PriceAdjustment
+	if adjustment == nil {
+		t.Fatal("Expected a price adjustment")
+	}
+	if adjustment.Requested != 0.999 || adjustment.Price != 0.99 || adjustment.Mode != types.RoundDown {
+		t.Errorf("Unexpected adjustment: %+v", adjustment)
+	}
+
+	// On-tick prices are left alone
+	orderArgs.Price = 0.55
+	signedOrder, err = client.CreateOrder(orderArgs, options)
+	if err != nil {
+		t.Fatalf("Failed to create order: %v", err)
+	}
+	if signedOrder.PriceAdjustment != nil {
+		t.Errorf("Expected no adjustment, got %+v", signedOrder.PriceAdjustment)
+	}
+}
+
 func TestMetrics(t *testing.T) {
 	client, err := NewClobClient(testHost, testChainID, testPrivateKey, nil, nil, nil)
 	if err != nil {
 		t.Fatalf("Failed to create client: %v", err)
 	}
+	client.negRisks[testTokenID] = false // Avoid a network lookup
 
 	// Perform some operations to generate metrics
 	orderArgs := types.OrderArgs{
@@ -156,6 +207,43 @@ func TestMetrics(t *testing.T) {
 	}
 }
 
+func TestOnMetricWithoutBuffering(t *testing.T) {
+	client, err := NewClobClient(testHost, testChainID, testPrivateKey, nil, nil, nil)
+	if err != nil {
+		t.Fatalf("Failed to create client: %v", err)
+	}
+	client.negRisks[testTokenID] = false // Avoid a network lookup
+
+	var mu sync.Mutex
+	seen := make(map[string]int)
+	client.OnMetric(func(metric types.PerformanceMetrics) {
+		mu.Lock()
+		seen[metric.Operation]++
+		mu.Unlock()
+	})
+	client.SetMetricsBuffering(false)
+
+	orderArgs := types.OrderArgs{
+		TokenID: testTokenID,
+		Price:   0.55,
+		Size:    10.0,
+		Side:    types.BUY,
+	}
+	if _, err := client.CreateOrder(orderArgs, &types.CreateOrderOptions{TickSize: types.TickSize001}); err != nil {
+		t.Fatalf("Failed to create order: %v", err)
+	}
+
+	// Metrics from the client, the order builder and the signer are all streamed
+	for _, operation := range []string{"order_creation", "order_signing", "message_signing"} {
+		if seen[operation] == 0 {
+			t.Errorf("Expected a %s metric, got %v", operation, seen)
+		}
+	}
+	if metrics := client.GetMetrics(); len(metrics) != 0 {
+		t.Errorf("Expected no buffered metrics, got %d", len(metrics))
+	}
+}
+
 // Benchmark tests
 func BenchmarkCreateOrder(b *testing.B) {
 	client, err := NewClobClient(testHost, testChainID, testPrivateKey, nil, nil, nil)
@@ -229,4 +317,62 @@ func BenchmarkOrderAmountCalculation(b *testing.B) {
 			b.Fatalf("Failed to create order: %v", err)
 		}
 	}
-}
\ No newline at end of file
+}
+func testBook() *types.OrderBookSummary {
+	return &types.OrderBookSummary{
+		Bids: []types.OrderSummary{{Price: "0.48", Size: "100"}, {Price: "0.50", Size: "50"}},
+		Asks: []types.OrderSummary{{Price: "0.55", Size: "20"}, {Price: "0.52", Size: "10"}, {Price: "0.53", Size: "10"}},
+	}
+}
+
+func TestMarketPriceWalksBook(t *testing.T) {
+	// 10 shares at 0.52 cost 5.2; the next 4.8 of collateral fills at 0.53
+	price, err := marketPrice(testBook(), types.BUY, 10, types.FOK, 0, types.TickSize001)
+	if err != nil {
+		t.Fatalf("Failed to price buy: %v", err)
+	}
+	if price != 0.53 {
+		t.Errorf("Expected buy price 0.53, got %v", price)
+	}
+
+	price, err = marketPrice(testBook(), types.SELL, 120, types.FOK, 0, types.TickSize001)
+	if err != nil {
+		t.Fatalf("Failed to price sell: %v", err)
+	}
+	if price != 0.48 {
+		t.Errorf("Expected sell price 0.48, got %v", price)
+	}
+
+	if _, err := marketPrice(testBook(), types.SELL, 1000, types.FOK, 0, types.TickSize001); err == nil {
+		t.Error("Expected FOK order larger than the book to fail")
+	}
+	if price, err := marketPrice(testBook(), types.SELL, 1000, types.FAK, 0, types.TickSize001); err != nil || price != 0.48 {
+		t.Errorf("Expected FAK order to use the worst level, got %v (%v)", price, err)
+	}
+}
+
+func TestMarketPriceMaxSlippage(t *testing.T) {
+	// 200 bps over the best ask of 0.52 allows up to 0.5304, so the limit is 0.53
+	price, err := marketPrice(testBook(), types.BUY, 10, types.FOK, 200, types.TickSize001)
+	if err != nil {
+		t.Fatalf("Failed to price buy: %v", err)
+	}
+	if price != 0.53 {
+		t.Errorf("Expected bounded buy price 0.53, got %v", price)
+	}
+
+	// Filling 15 needs the 0.55 level, beyond 0.5304
+	_, err = marketPrice(testBook(), types.BUY, 15, types.FOK, 200, types.TickSize001)
+	if !errors.Is(err, ErrSlippageExceeded) {
+		t.Errorf("Expected ErrSlippageExceeded, got %v", err)
+	}
+
+	// 500 bps under the best bid of 0.50 allows down to 0.475; the limit is 0.48
+	price, err = marketPrice(testBook(), types.SELL, 20, types.FAK, 500, types.TickSize001)
+	if err != nil {
+		t.Fatalf("Failed to price sell: %v", err)
+	}
+	if price != 0.48 {
+		t.Errorf("Expected bounded sell price 0.48, got %v", price)
+	}
+}
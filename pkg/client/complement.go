@@ -0,0 +1,56 @@
+package client
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/MaDal776/polymarket-go-client/pkg/types"
+)
+
+// Complement returns the token ID of the other outcome of a binary market
+func Complement(market *types.Market, tokenID string) (string, error) {
+	if len(market.Tokens) != 2 {
+		return "", fmt.Errorf("market %s is not binary: %d outcomes", market.ConditionID, len(market.Tokens))
+	}
+	switch tokenID {
+	case market.Tokens[0].TokenID:
+		return market.Tokens[1].TokenID, nil
+	case market.Tokens[1].TokenID:
+		return market.Tokens[0].TokenID, nil
+	}
+	return "", fmt.Errorf("token %s is not an outcome of market %s", tokenID, market.ConditionID)
+}
+
+// GetComplement returns the token ID of the opposite outcome of tokenID, the
+// other token of the same condition. Pairs are cached whenever a market is
+// seen, so only the first lookup of a token costs a book and a market request.
+func (c *ClobClient) GetComplement(tokenID string) (string, error) {
+	start := time.Now()
+
+	c.mu.Lock()
+	complement, exists := c.complements[tokenID]
+	c.mu.Unlock()
+	if exists {
+		return complement, nil
+	}
+
+	conditionID, err := c.conditionIDOf(tokenID)
+	if err != nil {
+		c.recordMetric("complement_lookup", start, false, err.Error())
+		return "", err
+	}
+	market, err := c.GetMarket(conditionID)
+	if err != nil {
+		c.recordMetric("complement_lookup", start, false, err.Error())
+		return "", err
+	}
+	c.cacheMarket(market)
+
+	complement, err = Complement(market, tokenID)
+	if err != nil {
+		c.recordMetric("complement_lookup", start, false, err.Error())
+		return "", err
+	}
+	c.recordMetric("complement_lookup", start, true, "")
+	return complement, nil
+}
@@ -0,0 +1,66 @@
+package client
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/MaDal776/polymarket-go-client/pkg/types"
+)
+
+func TestComplement(t *testing.T) {
+	binary := &types.Market{ConditionID: "0x1", Tokens: []types.MarketToken{{TokenID: "111"}, {TokenID: "222"}}}
+	if got, err := Complement(binary, "111"); err != nil || got != "222" {
+		t.Errorf("Complement(111) = %q, %v, want 222", got, err)
+	}
+	if got, err := Complement(binary, "222"); err != nil || got != "111" {
+		t.Errorf("Complement(222) = %q, %v, want 111", got, err)
+	}
+	if _, err := Complement(binary, "333"); err == nil {
+		t.Error("Expected unknown token to fail")
+	}
+	multi := &types.Market{Tokens: []types.MarketToken{{TokenID: "1"}, {TokenID: "2"}, {TokenID: "3"}}}
+	if _, err := Complement(multi, "1"); err == nil {
+		t.Error("Expected non-binary market to fail")
+	}
+}
+
+func TestGetComplement(t *testing.T) {
+	requests := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		switch r.URL.Path {
+		case GetOrderBook:
+			w.Write([]byte(`{"market":"0xcond","asset_id":"111","bids":[],"asks":[]}`))
+		case GetMarket + "0xcond":
+			w.Write([]byte(`{"condition_id":"0xcond","minimum_tick_size":0.01,"tokens":[{"token_id":"111","outcome":"Yes"},{"token_id":"222","outcome":"No"}]}`))
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	defer server.Close()
+
+	c, err := NewClobClient(server.URL, 137, testPrivateKey, nil, nil, nil)
+	if err != nil {
+		t.Fatalf("Failed to create client: %v", err)
+	}
+
+	got, err := c.GetComplement("111")
+	if err != nil || got != "222" {
+		t.Fatalf("GetComplement(111) = %q, %v, want 222", got, err)
+	}
+	if requests != 2 {
+		t.Errorf("Expected a book and a market request, got %d", requests)
+	}
+
+	// Both directions are cached
+	if got, err := c.GetComplement("222"); err != nil || got != "111" {
+		t.Errorf("GetComplement(222) = %q, %v, want 111", got, err)
+	}
+	if got, _ := c.GetComplement("111"); got != "222" {
+		t.Errorf("Cached GetComplement(111) = %q, want 222", got)
+	}
+	if requests != 2 {
+		t.Errorf("Expected cached lookups, got %d requests", requests)
+	}
+}
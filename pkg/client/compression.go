@@ -0,0 +1,85 @@
+package client
+
+import (
+	"bytes"
+	"compress/gzip"
+	"io"
+	"net/http"
+	"sync"
+)
+
+// DefaultCompressionThreshold is the smallest body SetRequestCompression gzips
+// when given 0; a batch of about ten orders
+const DefaultCompressionThreshold = 8 * 1024
+
+// compressor gzips large request bodies until the server rejects one
+type compressor struct {
+	mu       sync.Mutex
+	minBytes int // 0 disables compression
+	rejected bool
+}
+
+// SetRequestCompression gzips POST and DELETE bodies of at least minBytes, such
+// as batch order posts and batch price requests, and sends them with
+// Content-Encoding: gzip. A minBytes of 0 uses DefaultCompressionThreshold and
+// a negative one disables compression. When the server answers a compressed
+// request with 415 Unsupported Media Type, the request is resent uncompressed
+// and compression stays off until it is set again.
+func (c *ClobClient) SetRequestCompression(minBytes int) {
+	if minBytes == 0 {
+		minBytes = DefaultCompressionThreshold
+	}
+	if minBytes < 0 {
+		minBytes = 0
+	}
+
+	c.compression.mu.Lock()
+	defer c.compression.mu.Unlock()
+	c.compression.minBytes = minBytes
+	c.compression.rejected = false
+}
+
+// RequestCompression reports whether large bodies are currently compressed
+func (c *ClobClient) RequestCompression() bool {
+	c.compression.mu.Lock()
+	defer c.compression.mu.Unlock()
+	return c.compression.minBytes > 0 && !c.compression.rejected
+}
+
+// compress returns the body to send and whether it was gzipped. Bodies that
+// are small, sent with other methods or fail to compress are left as they are.
+func (p *compressor) compress(method string, body []byte) ([]byte, bool) {
+	p.mu.Lock()
+	minBytes, rejected := p.minBytes, p.rejected
+	p.mu.Unlock()
+	if minBytes == 0 || rejected || len(body) < minBytes || (method != "POST" && method != "DELETE") {
+		return body, false
+	}
+
+	var buf bytes.Buffer
+	buf.Grow(len(body) / 4)
+	zw := gzip.NewWriter(&buf)
+	if _, err := zw.Write(body); err != nil {
+		return body, false
+	}
+	if err := zw.Close(); err != nil {
+		return body, false
+	}
+	return buf.Bytes(), true
+}
+
+// reject turns compression off after the server refused a compressed body
+func (p *compressor) reject() {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.rejected = true
+}
+
+// setBody replaces req's body, keeping it replayable for redirects and retries
+func setBody(req *http.Request, body []byte) {
+	req.ContentLength = int64(len(body))
+	req.GetBody = func() (io.ReadCloser, error) {
+		return io.NopCloser(bytes.NewReader(body)), nil
+	}
+	req.Body, _ = req.GetBody()
+}
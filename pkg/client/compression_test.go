@@ -0,0 +1,107 @@
+package client
+
+import (
+	"compress/gzip"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/MaDal776/polymarket-go-client/pkg/types"
+)
+
+// priceParams returns enough book params for a body over 1KB
+func priceParams() []types.BookParams {
+	params := make([]types.BookParams, 20)
+	for i := range params {
+		params[i] = types.BookParams{TokenID: fmt.Sprintf("%077d", i), Side: types.BUY}
+	}
+	return params
+}
+
+// decodeBody reads a request body, gunzipping it when it is marked as gzip
+func decodeBody(t *testing.T, r *http.Request) []types.PricesRequest {
+	var reader io.Reader = r.Body
+	if r.Header.Get("Content-Encoding") == "gzip" {
+		zr, err := gzip.NewReader(r.Body)
+		if err != nil {
+			t.Fatalf("Invalid gzip body: %v", err)
+		}
+		reader = zr
+	}
+	var body []types.PricesRequest
+	if err := json.NewDecoder(reader).Decode(&body); err != nil {
+		t.Errorf("Invalid body: %v", err)
+	}
+	return body
+}
+
+func TestRequestCompression(t *testing.T) {
+	var encodings []string
+	var received []types.PricesRequest
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		encodings = append(encodings, r.Header.Get("Content-Encoding"))
+		received = decodeBody(t, r)
+		w.Write([]byte(`[]`))
+	}))
+	defer server.Close()
+
+	c, err := NewClobClient(server.URL, 137, "", nil, nil, nil)
+	if err != nil {
+		t.Fatalf("Failed to create client: %v", err)
+	}
+	c.SetRequestCompression(1024)
+
+	if _, err := c.GetPrices(priceParams()); err != nil {
+		t.Fatalf("GetPrices failed: %v", err)
+	}
+	if _, err := c.GetPrices(priceParams()[:1]); err != nil {
+		t.Fatalf("GetPrices failed: %v", err)
+	}
+	// Only the large body is compressed
+	if len(encodings) != 2 || encodings[0] != "gzip" || encodings[1] != "" {
+		t.Errorf("Unexpected encodings %q", encodings)
+	}
+	if len(received) != 1 || received[0].TokenID != priceParams()[0].TokenID {
+		t.Errorf("Unexpected body %+v", received)
+	}
+}
+
+func TestRequestCompressionRejected(t *testing.T) {
+	var encodings []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		encodings = append(encodings, r.Header.Get("Content-Encoding"))
+		if r.Header.Get("Content-Encoding") != "" {
+			w.Header().Set("Accept-Encoding", "identity")
+			w.WriteHeader(http.StatusUnsupportedMediaType)
+			return
+		}
+		if body := decodeBody(t, r); len(body) != 20 {
+			t.Errorf("Expected 20 params, got %d", len(body))
+		}
+		w.Write([]byte(`[]`))
+	}))
+	defer server.Close()
+
+	c, err := NewClobClient(server.URL, 137, "", nil, nil, nil)
+	if err != nil {
+		t.Fatalf("Failed to create client: %v", err)
+	}
+	c.SetRequestCompression(1024)
+
+	if _, err := c.GetPrices(priceParams()); err != nil {
+		t.Fatalf("Expected the uncompressed resend to succeed: %v", err)
+	}
+	if _, err := c.GetPrices(priceParams()); err != nil {
+		t.Fatalf("GetPrices failed: %v", err)
+	}
+	// Compression stays off after the server refused it
+	if len(encodings) != 3 || encodings[0] != "gzip" || encodings[1] != "" || encodings[2] != "" {
+		t.Errorf("Unexpected encodings %q", encodings)
+	}
+	if c.RequestCompression() {
+		t.Error("Expected compression to be off")
+	}
+}
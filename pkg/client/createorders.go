@@ -0,0 +1,77 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/MaDal776/polymarket-go-client/pkg/types"
+)
+
+// ResolveTokens caches the tick sizes and neg risk flags of tokens that are not
+// cached yet. Both lookups for every token run in one batch of at most
+// concurrency requests (0 means DefaultBatchConcurrency).
+func (c *ClobClient) ResolveTokens(ctx context.Context, tokenIDs []string, concurrency int) error {
+	requests := make([]BatchRequest[struct{}], 0, 2*len(tokenIDs))
+	labels := make([]string, 0, 2*len(tokenIDs))
+	seen := make(map[string]bool, len(tokenIDs))
+	for _, tokenID := range tokenIDs {
+		tokenID := tokenID
+		if seen[tokenID] {
+			continue
+		}
+		seen[tokenID] = true
+
+		if _, exists := c.CachedTickSize(tokenID); !exists {
+			labels = append(labels, tokenID)
+			requests = append(requests, func(context.Context) (struct{}, error) {
+				_, err := c.GetTickSize(tokenID)
+				return struct{}{}, err
+			})
+		}
+		if _, exists := c.CachedNegRisk(tokenID); !exists {
+			labels = append(labels, tokenID)
+			requests = append(requests, func(context.Context) (struct{}, error) {
+				_, err := c.GetNegRisk(tokenID)
+				return struct{}{}, err
+			})
+		}
+	}
+
+	var errs []error
+	for i, result := range Batch(ctx, requests, concurrency) {
+		if result.Err != nil {
+			errs = append(errs, fmt.Errorf("%s: %w", labels[i], result.Err))
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// CreateOrders creates and signs several orders. The market data of every new
+// token is fetched up front in one batch rather than two round trips per order.
+// options applies to every order; its tick size, when set, is used for all of them.
+func (c *ClobClient) CreateOrders(ctx context.Context, orders []types.OrderArgs, options *types.CreateOrderOptions) ([]*types.SignedOrder, error) {
+	tokenIDs := make([]string, len(orders))
+	for i, order := range orders {
+		tokenIDs[i] = order.TokenID
+	}
+	if err := c.ResolveTokens(ctx, tokenIDs, 0); err != nil {
+		return nil, fmt.Errorf("failed to resolve tokens: %w", err)
+	}
+
+	signed := make([]*types.SignedOrder, len(orders))
+	for i, order := range orders {
+		// resolveOrderOptions fills in the options it is given, so each order gets a copy
+		var orderOptions *types.CreateOrderOptions
+		if options != nil {
+			copied := *options
+			orderOptions = &copied
+		}
+		signedOrder, err := c.CreateOrder(order, orderOptions)
+		if err != nil {
+			return signed[:i], fmt.Errorf("order %d (%s): %w", i, order.TokenID, err)
+		}
+		signed[i] = signedOrder
+	}
+	return signed, nil
+}
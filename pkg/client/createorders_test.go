@@ -0,0 +1,52 @@
+package client
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+
+	"github.com/MaDal776/polymarket-go-client/pkg/types"
+)
+
+func TestCreateOrdersResolvesEachTokenOnce(t *testing.T) {
+	var mu sync.Mutex
+	requests := make(map[string]int)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		requests[r.URL.Path+"?"+r.URL.Query().Get("token_id")]++
+		mu.Unlock()
+		switch r.URL.Path {
+		case GetTickSize:
+			w.Write([]byte(`{"minimum_tick_size":0.01}`))
+		case GetNegRisk:
+			w.Write([]byte(`{"neg_risk":false}`))
+		default:
+			t.Errorf("Unexpected path %s", r.URL.Path)
+		}
+	}))
+	defer server.Close()
+	c, err := NewClobClient(server.URL, testChainID, testPrivateKey, nil, nil, nil)
+	if err != nil {
+		t.Fatalf("Failed to create client: %v", err)
+	}
+
+	orders := []types.OrderArgs{
+		{TokenID: "1", Price: 0.4, Size: 10, Side: types.BUY},
+		{TokenID: "2", Price: 0.6, Size: 10, Side: types.SELL},
+		{TokenID: "1", Price: 0.39, Size: 10, Side: types.BUY},
+	}
+	signed, err := c.CreateOrders(context.Background(), orders, nil)
+	if err != nil {
+		t.Fatalf("CreateOrders failed: %v", err)
+	}
+	if len(signed) != 3 {
+		t.Fatalf("Expected 3 orders, got %d", len(signed))
+	}
+	for _, key := range []string{GetTickSize + "?1", GetTickSize + "?2", GetNegRisk + "?1", GetNegRisk + "?2"} {
+		if requests[key] != 1 {
+			t.Errorf("Expected one request for %s, got %d", key, requests[key])
+		}
+	}
+}
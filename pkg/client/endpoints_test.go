@@ -0,0 +1,58 @@
+package client
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+// TestEndpointPaths pins the request paths of endpoints whose paths have
+// changed, so a change shows up here rather than as a 404 in production.
+func TestEndpointPaths(t *testing.T) {
+	tests := []struct {
+		name   string
+		method string
+		path   string
+		body   string
+		call   func(c *ClobClient) error
+	}{
+		{
+			name: "GetTrades", method: http.MethodGet, path: "/data/trades",
+			body: `{"data":[],"next_cursor":"LTE="}`,
+			call: func(c *ClobClient) error { _, err := c.GetTrades(nil); return err },
+		},
+		{
+			name: "GetOrder", method: http.MethodGet, path: "/data/order/0xabc",
+			body: `{"id":"0xabc"}`,
+			call: func(c *ClobClient) error { _, err := c.GetOrder("0xabc"); return err },
+		},
+		{
+			name: "GetOpenOrders", method: http.MethodGet, path: "/data/orders",
+			body: `{"data":[],"next_cursor":"LTE="}`,
+			call: func(c *ClobClient) error { _, err := c.GetOpenOrders(nil); return err },
+		},
+		{
+			name: "CancelAll", method: http.MethodDelete, path: "/cancel-all",
+			body: `{"canceled":[],"not_canceled":{}}`,
+			call: func(c *ClobClient) error { _, err := c.CancelAll(); return err },
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var method, path string
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				method, path = r.Method, r.URL.Path
+				w.Write([]byte(tt.body))
+			}))
+			defer server.Close()
+
+			if err := tt.call(newTradesClient(t, server.URL)); err != nil {
+				t.Fatalf("%s failed: %v", tt.name, err)
+			}
+			if method != tt.method || path != tt.path {
+				t.Errorf("Expected %s %s, got %s %s", tt.method, tt.path, method, path)
+			}
+		})
+	}
+}
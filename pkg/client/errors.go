@@ -0,0 +1,76 @@
+package client
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"github.com/MaDal776/polymarket-go-client/pkg/types"
+)
+
+// Sentinel errors returned (wrapped) by client methods; test for them with errors.Is
+var (
+	// ErrL1Required is returned by methods that sign with the private key when the client has none
+	ErrL1Required = errors.New("Level 1 authentication required")
+	// ErrL2Required is returned by methods that need API credentials when the client has none
+	ErrL2Required = errors.New("Level 2 authentication required")
+	// ErrUnsupportedChainID is returned when no contracts are known for the client's chain
+	ErrUnsupportedChainID = errors.New("unsupported chain ID")
+	// ErrNoSigner is returned alongside ErrL1Required or ErrL2Required when no private key was configured
+	ErrNoSigner = errors.New("no signer configured")
+)
+
+// requireAuth returns an error matching ErrL1Required or ErrL2Required, and ErrNoSigner
+// when the private key is missing, unless the client is authenticated at level or above
+func (c *ClobClient) requireAuth(level types.AuthLevel) error {
+	if c.authLevel >= level {
+		return nil
+	}
+
+	err := ErrL2Required
+	if level <= types.L1 {
+		err = ErrL1Required
+	}
+	if c.signer == nil {
+		return fmt.Errorf("%w: %w", err, ErrNoSigner)
+	}
+	return err
+}
+
+// APIError is an error reported by the exchange, either as an HTTP error status
+// or as a 2xx response whose body carries success false or an errorMsg
+type APIError struct {
+	StatusCode int
+	Message    string // The errorMsg of an error envelope, or the body of an error status
+}
+
+func (e *APIError) Error() string {
+	return fmt.Sprintf("HTTP %d: %s", e.StatusCode, e.Message)
+}
+
+// errorEnvelope returns the APIError carried by a successful response body, or
+// nil when the body is not an error envelope. Arrays, such as the per-order
+// results of a batch, are left to the caller.
+func errorEnvelope(statusCode int, body []byte) *APIError {
+	trimmed := bytes.TrimSpace(body)
+	if len(trimmed) == 0 || trimmed[0] != '{' ||
+		(!bytes.Contains(trimmed, []byte(`"errorMsg"`)) && !bytes.Contains(trimmed, []byte(`"success"`))) {
+		return nil
+	}
+	var envelope struct {
+		Success  *bool  `json:"success"`
+		ErrorMsg string `json:"errorMsg"`
+	}
+	if err := json.Unmarshal(trimmed, &envelope); err != nil {
+		return nil
+	}
+	if envelope.ErrorMsg == "" && (envelope.Success == nil || *envelope.Success) {
+		return nil
+	}
+	message := envelope.ErrorMsg
+	if message == "" {
+		message = "request was not successful"
+	}
+	return &APIError{StatusCode: statusCode, Message: message}
+}
@@ -0,0 +1,88 @@
+package client
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/MaDal776/polymarket-go-client/pkg/types"
+)
+
+func TestAuthErrors(t *testing.T) {
+	anonymous, err := NewClobClient(testHost, testChainID, "", nil, nil, nil)
+	if err != nil {
+		t.Fatalf("Failed to create client: %v", err)
+	}
+	_, err = anonymous.CreateAPIKey(0)
+	if !errors.Is(err, ErrL1Required) || !errors.Is(err, ErrNoSigner) {
+		t.Errorf("Expected ErrL1Required and ErrNoSigner, got %v", err)
+	}
+	_, err = anonymous.GetTrades(nil)
+	if !errors.Is(err, ErrL2Required) || !errors.Is(err, ErrNoSigner) {
+		t.Errorf("Expected ErrL2Required and ErrNoSigner, got %v", err)
+	}
+
+	signerOnly, err := NewClobClient(testHost, testChainID, testPrivateKey, nil, nil, nil)
+	if err != nil {
+		t.Fatalf("Failed to create client: %v", err)
+	}
+	_, err = signerOnly.GetTrades(nil)
+	if !errors.Is(err, ErrL2Required) || errors.Is(err, ErrNoSigner) {
+		t.Errorf("Expected ErrL2Required alone, got %v", err)
+	}
+}
+
+func TestUnsupportedChainError(t *testing.T) {
+	client, err := NewClobClient(testHost, 1, testPrivateKey, nil, nil, nil)
+	if err != nil {
+		t.Fatalf("Failed to create client: %v", err)
+	}
+	client.negRisks[testTokenID] = false
+	client.tickSizes[testTokenID] = "0.01"
+
+	_, err = client.CreateOrder(types.OrderArgs{TokenID: testTokenID, Price: 0.5, Size: 10, Side: types.BUY}, nil)
+	if !errors.Is(err, ErrUnsupportedChainID) {
+		t.Errorf("Expected ErrUnsupportedChainID, got %v", err)
+	}
+}
+
+func TestErrorEnvelope(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case GetMidpoint:
+			w.Write([]byte(`{"success":false,"errorMsg":"market not found"}`))
+		case GetNegRisk:
+			w.Write([]byte(`{"success":false}`))
+		case GetPrice:
+			w.Write([]byte(`{"price":"0.5","success":true,"errorMsg":""}`))
+		default:
+			w.WriteHeader(http.StatusBadRequest)
+			w.Write([]byte(`{"error":"bad token"}`))
+		}
+	}))
+	defer server.Close()
+
+	c, err := NewClobClient(server.URL, testChainID, "", nil, nil, nil)
+	if err != nil {
+		t.Fatalf("Failed to create client: %v", err)
+	}
+
+	var apiErr *APIError
+	_, err = c.GetMidpoint("123")
+	if !errors.As(err, &apiErr) || apiErr.StatusCode != http.StatusOK || apiErr.Message != "market not found" {
+		t.Errorf("Expected an APIError from the envelope, got %v", err)
+	}
+	if RequestIDOf(err) == "" {
+		t.Errorf("Expected the request ID on %v", err)
+	}
+	if _, err := c.GetNegRisk("123"); !errors.As(err, &apiErr) || apiErr.Message == "" {
+		t.Errorf("Expected success false alone to fail, got %v", err)
+	}
+	if _, err := c.GetPrice("123", types.BUY); err != nil {
+		t.Errorf("Expected an empty errorMsg to succeed, got %v", err)
+	}
+	if _, err := c.GetTickSize("123"); !errors.As(err, &apiErr) || apiErr.StatusCode != http.StatusBadRequest {
+		t.Errorf("Expected an APIError from the status, got %v", err)
+	}
+}
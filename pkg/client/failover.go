@@ -0,0 +1,326 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Failover defaults
+const (
+	DefaultFailoverThreshold = 3
+	DefaultRecoveryInterval  = 30 * time.Second
+)
+
+// latencyWeight is the weight of a new RTT sample in a host's smoothed latency
+const latencyWeight = 0.3
+
+// latencyEndpoints are the order placement and cancel endpoints, sent to the
+// fastest healthy host once latencies are measured. Every other request uses
+// the active host.
+var latencyEndpoints = map[string]bool{
+	PostOrder:  true, // Also CancelOrder
+	PostOrders: true, // Also CancelOrders
+	CancelAll:  true,
+}
+
+// FailoverConfig configures backup CLOB hosts
+type FailoverConfig struct {
+	Backups          []string      // Backup base URLs, in order of preference after the primary
+	Threshold        int           // Consecutive 5xx responses or timeouts that mark a host down; default DefaultFailoverThreshold
+	RecoveryInterval time.Duration // How often hosts marked down are probed; default DefaultRecoveryInterval
+}
+
+// HostStatus is the health of one configured host
+type HostStatus struct {
+	URL       string        `json:"url"`
+	Active    bool          `json:"active"`   // Requests are sent to this host
+	Healthy   bool          `json:"healthy"`  // False once marked down, until a probe succeeds
+	Failures  int           `json:"failures"` // Consecutive failures
+	DownSince time.Time     `json:"down_since,omitempty"`
+	Latency   time.Duration `json:"latency"` // Smoothed RTT of pings; 0 until measured
+}
+
+// hostState tracks one host of the pool
+type hostState struct {
+	url       string
+	healthy   bool
+	failures  int
+	downSince time.Time
+	latency   time.Duration
+}
+
+// hostPool holds the primary and backup hosts. It is empty until SetFailover.
+type hostPool struct {
+	mu        sync.Mutex
+	hosts     []*hostState // Primary first
+	active    int
+	config    FailoverConfig
+	probing   bool
+	lastProbe time.Time
+}
+
+// SetFailover adds backup hosts behind the client's host. Requests go to the
+// most preferred healthy host: a host is marked down when a connection to it is
+// refused, or after Threshold consecutive 5xx responses or timeouts, and
+// requests move to the next healthy one. Hosts marked down are probed in the
+// background every RecoveryInterval and used again, in order of preference,
+// once they answer. A failed request is only resent as SetRetries allows, to
+// the host active by then. Passing no backups turns failover off.
+func (c *ClobClient) SetFailover(config FailoverConfig) error {
+	if config.Threshold < 0 || config.RecoveryInterval < 0 {
+		return fmt.Errorf("failover threshold and recovery interval must not be negative")
+	}
+	if config.Threshold == 0 {
+		config.Threshold = DefaultFailoverThreshold
+	}
+	if config.RecoveryInterval == 0 {
+		config.RecoveryInterval = DefaultRecoveryInterval
+	}
+
+	hosts := make([]*hostState, 0, len(config.Backups)+1)
+	if len(config.Backups) > 0 {
+		hosts = append(hosts, &hostState{url: c.host, healthy: true})
+	}
+	for _, backup := range config.Backups {
+		parsed, err := url.Parse(backup)
+		if err != nil || (parsed.Scheme != "http" && parsed.Scheme != "https") || parsed.Host == "" {
+			return fmt.Errorf("invalid backup host: %s", backup)
+		}
+		hosts = append(hosts, &hostState{url: strings.TrimSuffix(backup, "/"), healthy: true})
+	}
+
+	c.hosts.mu.Lock()
+	defer c.hosts.mu.Unlock()
+	c.hosts.hosts = hosts
+	c.hosts.active = 0
+	c.hosts.config = config
+	return nil
+}
+
+// ActiveHost returns the base URL requests are currently sent to
+func (c *ClobClient) ActiveHost() string {
+	c.hosts.mu.Lock()
+	defer c.hosts.mu.Unlock()
+	if len(c.hosts.hosts) == 0 {
+		return c.host
+	}
+	return c.hosts.hosts[c.hosts.active].url
+}
+
+// HostStatus returns the health of every configured host, primary first. It
+// is empty when failover is off.
+func (c *ClobClient) HostStatus() []HostStatus {
+	c.hosts.mu.Lock()
+	defer c.hosts.mu.Unlock()
+
+	statuses := make([]HostStatus, 0, len(c.hosts.hosts))
+	for i, host := range c.hosts.hosts {
+		statuses = append(statuses, HostStatus{
+			URL:       host.url,
+			Active:    i == c.hosts.active,
+			Healthy:   host.healthy,
+			Failures:  host.failures,
+			DownSince: host.downSince,
+			Latency:   host.latency,
+		})
+	}
+	return statuses
+}
+
+// CheckHosts pings every host marked down and restores the ones that answer,
+// switching back to the most preferred healthy host. It returns the resulting
+// host statuses.
+func (c *ClobClient) CheckHosts(ctx context.Context) []HostStatus {
+	return c.probeHosts(ctx, false)
+}
+
+// MeasureHosts pings every host, updating their latencies and restoring hosts
+// marked down that answer. It returns the resulting host statuses.
+func (c *ClobClient) MeasureHosts(ctx context.Context) []HostStatus {
+	return c.probeHosts(ctx, true)
+}
+
+// MeasureLatency calls MeasureHosts now and every interval until ctx is
+// cancelled. Order placements and cancels then go to the healthy host with the
+// lowest latency, while reads stay on the active host.
+func (c *ClobClient) MeasureLatency(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	c.MeasureHosts(ctx)
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			c.MeasureHosts(ctx)
+		}
+	}
+}
+
+// probeHosts pings the hosts marked down, or every host when all is set, in
+// parallel and records the results
+func (c *ClobClient) probeHosts(ctx context.Context, all bool) []HostStatus {
+	c.hosts.mu.Lock()
+	targets := make(map[int]string)
+	for i, host := range c.hosts.hosts {
+		if all || !host.healthy {
+			targets[i] = host.url
+		}
+	}
+	c.hosts.lastProbe = time.Now()
+	c.hosts.mu.Unlock()
+
+	var wg sync.WaitGroup
+	for i, base := range targets {
+		wg.Add(1)
+		go func(i int, base string) {
+			defer wg.Done()
+			start := time.Now()
+			if err := c.pingHost(ctx, c.httpClient, base); err != nil {
+				return
+			}
+			c.hostAnswered(i, base, time.Since(start))
+		}(i, base)
+	}
+	wg.Wait()
+	return c.HostStatus()
+}
+
+// hostAnswered records a successful ping of host index
+func (c *ClobClient) hostAnswered(index int, base string, rtt time.Duration) {
+	c.hosts.mu.Lock()
+	defer c.hosts.mu.Unlock()
+
+	if index >= len(c.hosts.hosts) || c.hosts.hosts[index].url != base {
+		// SetFailover replaced the pool meanwhile
+		return
+	}
+	host := c.hosts.hosts[index]
+	host.healthy, host.failures, host.downSince = true, 0, time.Time{}
+	if host.latency == 0 {
+		host.latency = rtt
+	} else {
+		host.latency = time.Duration(latencyWeight*float64(rtt) + (1-latencyWeight)*float64(host.latency))
+	}
+	if index < c.hosts.active {
+		c.hosts.active = index
+	}
+}
+
+// routeURL points a request URL built on the primary host at the host that
+// should serve it: the fastest healthy host for order placements and cancels,
+// the active host otherwise. It returns the index of the host used, or -1 when
+// failover does not apply.
+func (c *ClobClient) routeURL(method, rawURL string) (string, int) {
+	c.hosts.mu.Lock()
+	defer c.hosts.mu.Unlock()
+
+	pool := &c.hosts
+	if len(pool.hosts) == 0 || !strings.HasPrefix(rawURL, c.host) {
+		return rawURL, -1
+	}
+	c.probeIfDue()
+
+	rest := rawURL[len(c.host):]
+	index := pool.active
+	if path, _, _ := strings.Cut(rest, "?"); method != "GET" && latencyEndpoints[path] {
+		index = pool.fastest()
+	}
+	return pool.hosts[index].url + rest, index
+}
+
+// fastest returns the healthy host with the lowest measured latency, or the
+// active host when none is measured. Callers hold pool.mu.
+func (pool *hostPool) fastest() int {
+	best := pool.active
+	for i, host := range pool.hosts {
+		if !host.healthy || host.latency == 0 {
+			continue
+		}
+		current := pool.hosts[best]
+		if !current.healthy || current.latency == 0 || host.latency < current.latency {
+			best = i
+		}
+	}
+	return best
+}
+
+// probeIfDue starts a background CheckHosts when a host is down and the last
+// probe is older than the recovery interval. Callers hold c.hosts.mu.
+func (c *ClobClient) probeIfDue() {
+	pool := &c.hosts
+	if pool.probing || time.Since(pool.lastProbe) < pool.config.RecoveryInterval {
+		return
+	}
+	for _, host := range pool.hosts {
+		if !host.healthy {
+			pool.probing = true
+			go func() {
+				c.CheckHosts(context.Background())
+				c.hosts.mu.Lock()
+				c.hosts.probing = false
+				c.hosts.mu.Unlock()
+			}()
+			return
+		}
+	}
+}
+
+// reportHost records the outcome of a request sent to host index, marking the
+// host down and moving to the next healthy host when it keeps failing
+func (c *ClobClient) reportHost(index int, err error, statusCode int) {
+	if index < 0 {
+		return
+	}
+	c.hosts.mu.Lock()
+	defer c.hosts.mu.Unlock()
+
+	pool := &c.hosts
+	if index >= len(pool.hosts) {
+		return
+	}
+	host := pool.hosts[index]
+	if errors.Is(err, context.Canceled) {
+		// The caller gave up; says nothing about the host
+		return
+	}
+	if err == nil && statusCode < 500 {
+		host.failures = 0
+		return
+	}
+
+	host.failures++
+	if !isConnectionRefused(err) && host.failures < pool.config.Threshold {
+		return
+	}
+	if host.healthy {
+		host.healthy = false
+		host.downSince = time.Now()
+		// Give the host a full interval before the first probe
+		pool.lastProbe = host.downSince
+	}
+	if index != pool.active {
+		return
+	}
+	for i, candidate := range pool.hosts {
+		if candidate.healthy {
+			pool.active = i
+			return
+		}
+	}
+	// Every host is down: stay put until a probe restores one
+}
+
+// isConnectionRefused reports whether err is a failure to connect at all, as
+// opposed to a slow or failed response
+func isConnectionRefused(err error) bool {
+	var opErr *net.OpError
+	return errors.As(err, &opErr) && opErr.Op == "dial"
+}
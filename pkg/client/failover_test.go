@@ -0,0 +1,191 @@
+package client
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/MaDal776/polymarket-go-client/pkg/types"
+)
+
+func TestFailoverOn5xx(t *testing.T) {
+	var primaryDown atomic.Bool
+	primaryDown.Store(true)
+	var primaryCalls, backupCalls atomic.Int32
+	primary := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		primaryCalls.Add(1)
+		if primaryDown.Load() {
+			w.WriteHeader(http.StatusBadGateway)
+			return
+		}
+		w.Write([]byte(`{"mid":"0.4"}`))
+	}))
+	defer primary.Close()
+	backup := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		backupCalls.Add(1)
+		w.Write([]byte(`{"mid":"0.5"}`))
+	}))
+	defer backup.Close()
+
+	c, err := NewClobClient(primary.URL, testChainID, "", nil, nil, nil)
+	if err != nil {
+		t.Fatalf("Failed to create client: %v", err)
+	}
+	if err := c.SetFailover(FailoverConfig{Backups: []string{backup.URL + "/"}, Threshold: 2, RecoveryInterval: time.Hour}); err != nil {
+		t.Fatalf("SetFailover failed: %v", err)
+	}
+
+	// Failed requests are not retried elsewhere; the threshold moves later ones
+	for i := 0; i < 2; i++ {
+		if _, err := c.GetMidpoint("123"); err == nil {
+			t.Fatalf("Expected request %d to fail on the primary", i)
+		}
+	}
+	if c.ActiveHost() != backup.URL {
+		t.Fatalf("Expected the backup to be active, got %s", c.ActiveHost())
+	}
+	mid, err := c.GetMidpoint("123")
+	if err != nil || mid.Mid != "0.5" {
+		t.Fatalf("Expected the backup to answer, got %+v, %v", mid, err)
+	}
+	if primaryCalls.Load() != 2 || backupCalls.Load() != 1 {
+		t.Errorf("Unexpected calls: primary %d, backup %d", primaryCalls.Load(), backupCalls.Load())
+	}
+	statuses := c.HostStatus()
+	if len(statuses) != 2 || statuses[0].Healthy || !statuses[1].Active {
+		t.Errorf("Unexpected statuses %+v", statuses)
+	}
+
+	// Recovery prefers the primary again
+	primaryDown.Store(false)
+	statuses = c.CheckHosts(context.Background())
+	if !statuses[0].Healthy || !statuses[0].Active || statuses[0].Failures != 0 {
+		t.Errorf("Expected the primary to recover, got %+v", statuses)
+	}
+	if mid, err := c.GetMidpoint("123"); err != nil || mid.Mid != "0.4" {
+		t.Errorf("Expected the primary to answer, got %+v, %v", mid, err)
+	}
+}
+
+func TestFailoverOnConnectionRefused(t *testing.T) {
+	backup := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{"mid":"0.5"}`))
+	}))
+	defer backup.Close()
+	primary := httptest.NewServer(http.NotFoundHandler())
+	primaryURL := primary.URL
+	primary.Close()
+
+	c, err := NewClobClient(primaryURL, testChainID, "", nil, nil, nil)
+	if err != nil {
+		t.Fatalf("Failed to create client: %v", err)
+	}
+	if err := c.SetFailover(FailoverConfig{Backups: []string{backup.URL}}); err != nil {
+		t.Fatalf("SetFailover failed: %v", err)
+	}
+
+	// A refused connection marks the host down at once
+	if _, err := c.GetMidpoint("123"); err == nil {
+		t.Fatalf("Expected the closed primary to fail")
+	}
+	if mid, err := c.GetMidpoint("123"); err != nil || mid.Mid != "0.5" {
+		t.Errorf("Expected the backup to answer, got %+v, %v", mid, err)
+	}
+	if statuses := c.CheckHosts(context.Background()); statuses[0].Healthy || !statuses[1].Active {
+		t.Errorf("Expected the primary to stay down, got %+v", statuses)
+	}
+}
+
+func TestPostOrderFastFailsOver(t *testing.T) {
+	backup := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{"success":true,"orderID":"0xabc"}`))
+	}))
+	defer backup.Close()
+	primary := httptest.NewServer(http.NotFoundHandler())
+	primaryURL := primary.URL
+	primary.Close()
+
+	creds := &types.ApiCreds{ApiKey: "key", ApiSecret: "c2VjcmV0", ApiPassphrase: "pass"}
+	c, err := NewClobClient(primaryURL, testChainID, testPrivateKey, creds, nil, nil)
+	if err != nil {
+		t.Fatalf("Failed to create client: %v", err)
+	}
+	if err := c.SetFailover(FailoverConfig{Backups: []string{backup.URL}}); err != nil {
+		t.Fatalf("SetFailover failed: %v", err)
+	}
+	signed := &types.SignedOrder{Salt: 1, Maker: c.GetAddress(), Signer: c.GetAddress(), TokenID: "1", Side: types.BUY, Signature: "0x00"}
+	prepared, err := c.PrepareOrder(signed, types.GTC)
+	if err != nil {
+		t.Fatalf("Failed to prepare order: %v", err)
+	}
+
+	// The refused connection marks the primary down for fast posts too
+	if _, err := c.PostOrderFast(prepared); err == nil {
+		t.Fatalf("Expected the closed primary to fail")
+	}
+	if resp, err := c.PostOrderFast(prepared); err != nil || string(resp) != `{"success":true,"orderID":"0xabc"}` {
+		t.Errorf("Expected the backup to take the post, got %s, %v", resp, err)
+	}
+}
+
+func TestSetFailoverValidation(t *testing.T) {
+	c, err := NewClobClient("https://clob.example.com", testChainID, "", nil, nil, nil)
+	if err != nil {
+		t.Fatalf("Failed to create client: %v", err)
+	}
+	if err := c.SetFailover(FailoverConfig{Backups: []string{"clob-backup"}}); err == nil {
+		t.Errorf("Expected a host without a scheme to be rejected")
+	}
+	if c.ActiveHost() != "https://clob.example.com" || len(c.HostStatus()) != 0 {
+		t.Errorf("Expected failover to stay off")
+	}
+}
+
+func TestLatencyRouting(t *testing.T) {
+	var primaryPosts, backupPosts atomic.Int32
+	handler := func(delay time.Duration, posts *atomic.Int32) http.HandlerFunc {
+		return func(w http.ResponseWriter, r *http.Request) {
+			switch {
+			case r.URL.Path == "/":
+				time.Sleep(delay)
+			case r.Method == "POST":
+				posts.Add(1)
+			}
+			w.Write([]byte(`{"mid":"0.5"}`))
+		}
+	}
+	primary := httptest.NewServer(handler(50*time.Millisecond, &primaryPosts))
+	defer primary.Close()
+	backup := httptest.NewServer(handler(0, &backupPosts))
+	defer backup.Close()
+
+	c, err := NewClobClient(primary.URL, testChainID, "", nil, nil, nil)
+	if err != nil {
+		t.Fatalf("Failed to create client: %v", err)
+	}
+	if err := c.SetFailover(FailoverConfig{Backups: []string{backup.URL}}); err != nil {
+		t.Fatalf("SetFailover failed: %v", err)
+	}
+
+	// Unmeasured hosts leave everything on the active host
+	if _, err := c.makeRequest("POST", c.host+PostOrder, nil, map[string]string{}); err != nil {
+		t.Fatalf("Post failed: %v", err)
+	}
+	statuses := c.MeasureHosts(context.Background())
+	if statuses[0].Latency <= statuses[1].Latency || statuses[1].Latency == 0 {
+		t.Fatalf("Expected the backup to measure faster, got %+v", statuses)
+	}
+
+	if _, err := c.makeRequest("POST", c.host+PostOrder, nil, map[string]string{}); err != nil {
+		t.Fatalf("Post failed: %v", err)
+	}
+	if primaryPosts.Load() != 1 || backupPosts.Load() != 1 {
+		t.Errorf("Expected the second post on the backup, got primary %d, backup %d", primaryPosts.Load(), backupPosts.Load())
+	}
+	if c.ActiveHost() != primary.URL {
+		t.Errorf("Expected reads to stay on the primary, got %s", c.ActiveHost())
+	}
+}
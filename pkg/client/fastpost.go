@@ -0,0 +1,155 @@
+package client
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"sync"
+
+	"github.com/MaDal776/polymarket-go-client/pkg/auth"
+	"github.com/MaDal776/polymarket-go-client/pkg/types"
+	"github.com/MaDal776/polymarket-go-client/pkg/version"
+)
+
+// Canonical forms of the headers set by PostOrderFast, computed once
+var (
+	headerContentType = http.CanonicalHeaderKey("Content-Type")
+	headerUserAgent   = http.CanonicalHeaderKey("User-Agent")
+	headerVersion     = http.CanonicalHeaderKey(version.Header)
+	headerRequestID   = http.CanonicalHeaderKey(RequestIDHeader)
+	headerAddress     = http.CanonicalHeaderKey(auth.PolyAddress)
+	headerSignature   = http.CanonicalHeaderKey(auth.PolySignature)
+	headerTimestamp   = http.CanonicalHeaderKey(auth.PolyTimestamp)
+	headerApiKey      = http.CanonicalHeaderKey(auth.PolyApiKey)
+	headerPassphrase  = http.CanonicalHeaderKey(auth.PolyPassphrase)
+)
+
+// PreparedOrder is a signed order whose request body has been encoded ahead of
+// time, so posting it only costs the HMAC and the round trip
+type PreparedOrder struct {
+	body  []byte
+	order *types.SignedOrder // Claimed when posted, with dedupe enabled
+}
+
+// Body returns the encoded request body
+func (p *PreparedOrder) Body() []byte {
+	return p.body
+}
+
+// fastPath holds the dedicated connection used by PostOrderFast
+type fastPath struct {
+	once       sync.Once
+	httpClient *http.Client
+}
+
+// fastHTTPClient returns the client used by PostOrderFast. It has its own
+// transport so order posts never queue behind market data requests.
+func (c *ClobClient) fastHTTPClient() *http.Client {
+	c.fast.once.Do(func() {
+		transport := newTransport()
+		transport.MaxIdleConnsPerHost = 2
+		transport.DisableCompression = true
+		c.fast.httpClient = &http.Client{Transport: transport}
+	})
+	return c.fast.httpClient
+}
+
+// PrepareOrder encodes a signed order for PostOrderFast
+func (c *ClobClient) PrepareOrder(signedOrder *types.SignedOrder, orderType types.OrderType) (*PreparedOrder, error) {
+	if err := c.requireAuth(types.L2); err != nil {
+		return nil, err
+	}
+	if err := validateOrderType(signedOrder, orderType, c.clock.Now()); err != nil {
+		return nil, err
+	}
+
+	orderRequest := types.OrderRequest{
+		Order:     *signedOrder,
+		Owner:     c.orderOwner(signedOrder),
+		OrderType: orderType,
+	}
+	body, err := orderRequest.MarshalWire(c.orderEncoding)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode order: %w", err)
+	}
+	return &PreparedOrder{body: body, order: signedOrder}, nil
+}
+
+// PostOrderFast posts a prepared order on a dedicated keep-alive connection.
+// It skips metrics recording and response parsing, returning the raw response body.
+// With failover configured it goes to the same host as PostOrder. With dedupe
+// enabled it refuses an order already posted by either path.
+func (c *ClobClient) PostOrderFast(order *PreparedOrder) (json.RawMessage, error) {
+	if err := c.requireAuth(types.L2); err != nil {
+		return nil, err
+	}
+	httpClient := c.fastHTTPClient()
+
+	c.waitRateLimit()
+
+	timestamp := c.clock.Now().Unix()
+	signature, err := c.headerBuilder.SignLevel2(c.creds.ApiSecret, timestamp, "POST", PostOrder, order.body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to build HMAC signature: %w", err)
+	}
+
+	timeout := c.endpointTimeout(PostOrder)
+	if timeout <= 0 {
+		timeout = fastPostTimeout
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+
+	// Send to the fastest healthy host when failover is configured
+	target, hostIndex := c.routeURL("POST", c.host+PostOrder)
+
+	requestID := newRequestID()
+	req, err := http.NewRequestWithContext(ctx, "POST", target, bytes.NewReader(order.body))
+	if err != nil {
+		return nil, &RequestError{RequestID: requestID, Err: fmt.Errorf("failed to create request: %w", err)}
+	}
+	req.Header = http.Header{
+		headerRequestID:   {requestID},
+		headerContentType: {"application/json"},
+		headerUserAgent:   {c.userAgent},
+		headerVersion:     {version.Version},
+		headerAddress:     {c.signer.AddressHex()},
+		headerSignature:   {signature},
+		headerTimestamp:   {strconv.FormatInt(timestamp, 10)},
+		headerApiKey:      {c.creds.ApiKey},
+		headerPassphrase:  {c.creds.ApiPassphrase},
+	}
+
+	// Refuse an order already posted when dedupe is enabled
+	if err := c.claimOrders(order.order); err != nil {
+		return nil, err
+	}
+
+	resp, err := httpClient.Do(req)
+	if err != nil {
+		c.reportHost(hostIndex, err, 0)
+		return nil, &RequestError{RequestID: requestID, Err: fmt.Errorf("failed to post order: %w", err)}
+	}
+	defer resp.Body.Close()
+	c.reportHost(hostIndex, nil, resp.StatusCode)
+
+	respBody, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, &RequestError{RequestID: requestID, Err: fmt.Errorf("failed to read response: %w", err)}
+	}
+	if resp.StatusCode >= 400 {
+		err := &RequestError{RequestID: requestID, Err: &APIError{StatusCode: resp.StatusCode, Message: string(respBody)}}
+		c.releaseRejected(err, order.order)
+		return nil, err
+	}
+	if apiErr := errorEnvelope(resp.StatusCode, respBody); apiErr != nil {
+		err := &RequestError{RequestID: requestID, Err: apiErr}
+		c.releaseRejected(err, order.order)
+		return nil, err
+	}
+	return respBody, nil
+}
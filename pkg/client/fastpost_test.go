@@ -0,0 +1,123 @@
+package client
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+
+	"github.com/MaDal776/polymarket-go-client/pkg/auth"
+	"github.com/MaDal776/polymarket-go-client/pkg/types"
+)
+
+func TestPostOrderFastSignsPreparedBody(t *testing.T) {
+	secret := base64.URLEncoding.EncodeToString([]byte("0123456789abcdef0123456789abcdef"))
+	creds := &types.ApiCreds{ApiKey: "key", ApiSecret: secret, ApiPassphrase: "pass"}
+
+	var gotBody []byte
+	var gotHeader http.Header
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		gotBody, _ = io.ReadAll(r.Body)
+		gotHeader = r.Header
+		w.Write([]byte(`{"success":true,"orderID":"0xabc"}`))
+	}))
+	defer server.Close()
+
+	c, err := NewClobClient(server.URL, 137, testPrivateKey, creds, nil, nil)
+	if err != nil {
+		t.Fatalf("Failed to create client: %v", err)
+	}
+
+	signed := &types.SignedOrder{Salt: 1, Maker: c.GetAddress(), Signer: c.GetAddress(), TokenID: "1", Side: types.BUY, Signature: "0x00"}
+	prepared, err := c.PrepareOrder(signed, types.GTC)
+	if err != nil {
+		t.Fatalf("Failed to prepare order: %v", err)
+	}
+
+	resp, err := c.PostOrderFast(prepared)
+	if err != nil {
+		t.Fatalf("PostOrderFast failed: %v", err)
+	}
+	if string(resp) != `{"success":true,"orderID":"0xabc"}` {
+		t.Errorf("Unexpected response: %s", resp)
+	}
+	if string(gotBody) != string(prepared.Body()) {
+		t.Errorf("Server received %s, want %s", gotBody, prepared.Body())
+	}
+
+	key, _ := base64.URLEncoding.DecodeString(secret)
+	mac := hmac.New(sha256.New, key)
+	mac.Write([]byte(gotHeader.Get(auth.PolyTimestamp) + "POST" + PostOrder + string(gotBody)))
+	want := base64.URLEncoding.EncodeToString(mac.Sum(nil))
+	if got := gotHeader.Get(auth.PolySignature); got != want {
+		t.Errorf("Signature %s, want %s", got, want)
+	}
+	if gotHeader.Get(auth.PolyApiKey) != "key" || gotHeader.Get(auth.PolyPassphrase) != "pass" {
+		t.Errorf("Missing credential headers: %v", gotHeader)
+	}
+}
+
+func TestPostOrderFastDedupes(t *testing.T) {
+	secret := base64.URLEncoding.EncodeToString([]byte("0123456789abcdef0123456789abcdef"))
+	creds := &types.ApiCreds{ApiKey: "key", ApiSecret: secret, ApiPassphrase: "pass"}
+
+	var reject atomic.Bool
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if reject.Load() {
+			http.Error(w, `{"error":"not enough balance"}`, http.StatusBadRequest)
+			return
+		}
+		w.Write([]byte(`{"success":true,"orderID":"0xabc"}`))
+	}))
+	defer server.Close()
+
+	c, err := NewClobClient(server.URL, 137, testPrivateKey, creds, nil, nil)
+	if err != nil {
+		t.Fatalf("Failed to create client: %v", err)
+	}
+	c.SetRetries(RetryConfig{DedupeOrders: true})
+	newOrder := func(salt int64) *types.SignedOrder {
+		return &types.SignedOrder{Salt: salt, Maker: c.GetAddress(), Signer: c.GetAddress(), TokenID: "1", Side: types.BUY, Signature: fmt.Sprintf("0x%02x", salt)}
+	}
+
+	order := newOrder(1)
+	prepared, err := c.PrepareOrder(order, types.GTC)
+	if err != nil {
+		t.Fatalf("Failed to prepare order: %v", err)
+	}
+	if _, err := c.PostOrderFast(prepared); err != nil {
+		t.Fatalf("PostOrderFast failed: %v", err)
+	}
+	if _, err := c.PostOrderFast(prepared); !errors.Is(err, ErrDuplicateOrder) {
+		t.Errorf("Expected a second fast post to be refused, got %v", err)
+	}
+	if _, err := c.PostOrder(order, types.GTC); !errors.Is(err, ErrDuplicateOrder) {
+		t.Errorf("Expected PostOrder to refuse a fast-posted order, got %v", err)
+	}
+
+	// An order posted through PostOrder is refused on the fast path
+	posted := newOrder(2)
+	if _, err := c.PostOrder(posted, types.GTC); err != nil {
+		t.Fatalf("PostOrder failed: %v", err)
+	}
+	prepared, _ = c.PrepareOrder(posted, types.GTC)
+	if _, err := c.PostOrderFast(prepared); !errors.Is(err, ErrDuplicateOrder) {
+		t.Errorf("Expected a fast post of a posted order to be refused, got %v", err)
+	}
+
+	// An order the exchange rejected can be posted again
+	reject.Store(true)
+	prepared, _ = c.PrepareOrder(newOrder(3), types.GTC)
+	if _, err := c.PostOrderFast(prepared); err == nil || errors.Is(err, ErrDuplicateOrder) {
+		t.Fatalf("Expected a rejection, got %v", err)
+	}
+	if _, err := c.PostOrderFast(prepared); errors.Is(err, ErrDuplicateOrder) {
+		t.Errorf("Expected the rejected order to be released: %v", err)
+	}
+}
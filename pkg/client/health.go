@@ -0,0 +1,86 @@
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/MaDal776/polymarket-go-client/pkg/types"
+)
+
+// HealthStatus is the result of a health check, suitable for readiness probes
+type HealthStatus struct {
+	Healthy     bool            `json:"healthy"`
+	Reachable   bool            `json:"reachable"`
+	Latency     time.Duration   `json:"latency"`     // Round trip of the /time request
+	ServerTime  int64           `json:"server_time"` // Unix seconds reported by the server
+	ClockSkew   time.Duration   `json:"clock_skew"`  // Local clock minus server clock, latency-corrected
+	AuthLevel   types.AuthLevel `json:"auth_level"`
+	AuthChecked bool            `json:"auth_checked"` // Credentials were validated (L2 only)
+	AuthValid   bool            `json:"auth_valid"`
+	Error       string          `json:"error,omitempty"`
+	CheckedAt   time.Time       `json:"checked_at"`
+}
+
+// HealthCheck verifies the API is reachable and measures the round-trip latency.
+// With Level 2 credentials it also validates them with a cheap authenticated call.
+// Failures are reported in the status; the error is only set when ctx ends first.
+func (c *ClobClient) HealthCheck(ctx context.Context) (*HealthStatus, error) {
+	start := time.Now()
+	status := &HealthStatus{AuthLevel: c.authLevel, CheckedAt: start}
+
+	resp, err := c.makeRequestContext(ctx, "GET", c.host+Time, nil, nil)
+	status.Latency = time.Since(start)
+	if err != nil {
+		if ctx.Err() != nil {
+			c.recordMetric("health_check", start, false, err.Error())
+			return nil, ctx.Err()
+		}
+		status.Error = fmt.Sprintf("API unreachable: %v", err)
+		c.recordMetric("health_check", start, false, status.Error)
+		return status, nil
+	}
+	status.Reachable = true
+
+	if err := json.Unmarshal(resp, &status.ServerTime); err != nil {
+		status.Error = fmt.Sprintf("invalid server time response: %v", err)
+		c.recordMetric("health_check", start, false, status.Error)
+		return status, nil
+	}
+	midpoint := start.Add(status.Latency / 2)
+	status.ClockSkew = midpoint.Sub(time.Unix(status.ServerTime, 0)).Truncate(time.Second)
+
+	if c.authLevel >= types.L2 {
+		status.AuthChecked = true
+		if err := c.checkCredentials(ctx); err != nil {
+			if ctx.Err() != nil {
+				c.recordMetric("health_check", start, false, err.Error())
+				return nil, ctx.Err()
+			}
+			status.Error = fmt.Sprintf("credential check failed: %v", err)
+			c.recordMetric("health_check", start, false, status.Error)
+			return status, nil
+		}
+		status.AuthValid = true
+	}
+
+	status.Healthy = true
+	c.recordMetric("health_check", start, true, "")
+	return status, nil
+}
+
+// checkCredentials lists the API keys, which succeeds only with valid Level 2 credentials
+func (c *ClobClient) checkCredentials(ctx context.Context) error {
+	requestArgs := types.RequestArgs{
+		Method:      "GET",
+		RequestPath: GetAPIKeys,
+	}
+	headers, err := c.headerBuilder.CreateLevel2Headers(c.creds, requestArgs)
+	if err != nil {
+		return fmt.Errorf("failed to create headers: %w", err)
+	}
+
+	_, err = c.makeRequestContext(ctx, "GET", c.host+GetAPIKeys, headers, nil)
+	return err
+}
@@ -0,0 +1,73 @@
+package client
+
+import (
+	"context"
+	"encoding/base64"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/MaDal776/polymarket-go-client/pkg/types"
+)
+
+func TestHealthCheckValidatesCredentials(t *testing.T) {
+	authorized := true
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case Time:
+			fmt.Fprintf(w, "%d", time.Now().Unix())
+		case GetAPIKeys:
+			if !authorized {
+				http.Error(w, `{"error":"Unauthorized/Invalid api key"}`, http.StatusUnauthorized)
+				return
+			}
+			w.Write([]byte(`{"apiKeys":["key"]}`))
+		}
+	}))
+	defer server.Close()
+
+	creds := &types.ApiCreds{ApiKey: "key", ApiSecret: base64.URLEncoding.EncodeToString([]byte("secret")), ApiPassphrase: "pass"}
+	c, err := NewClobClient(server.URL, 137, testPrivateKey, creds, nil, nil)
+	if err != nil {
+		t.Fatalf("Failed to create client: %v", err)
+	}
+
+	status, err := c.HealthCheck(context.Background())
+	if err != nil {
+		t.Fatalf("HealthCheck failed: %v", err)
+	}
+	if !status.Healthy || !status.Reachable || !status.AuthChecked || !status.AuthValid {
+		t.Errorf("Expected healthy status, got %+v", status)
+	}
+	if status.ClockSkew > 2*time.Second || status.ClockSkew < -2*time.Second {
+		t.Errorf("Unexpected clock skew %v", status.ClockSkew)
+	}
+
+	authorized = false
+	status, err = c.HealthCheck(context.Background())
+	if err != nil {
+		t.Fatalf("HealthCheck failed: %v", err)
+	}
+	if status.Healthy || !status.Reachable || status.AuthValid || status.Error == "" {
+		t.Errorf("Expected reachable but unauthorized status, got %+v", status)
+	}
+}
+
+func TestHealthCheckUnreachable(t *testing.T) {
+	server := httptest.NewServer(http.NotFoundHandler())
+	server.Close()
+
+	c, err := NewClobClient(server.URL, 137, "", nil, nil, nil)
+	if err != nil {
+		t.Fatalf("Failed to create client: %v", err)
+	}
+	status, err := c.HealthCheck(context.Background())
+	if err != nil {
+		t.Fatalf("HealthCheck failed: %v", err)
+	}
+	if status.Healthy || status.Reachable || status.AuthChecked {
+		t.Errorf("Expected unreachable status, got %+v", status)
+	}
+}
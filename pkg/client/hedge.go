@@ -0,0 +1,218 @@
+package client
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"sync"
+	"time"
+)
+
+// Hedging defaults
+const (
+	DefaultHedgeRatio = 0.1 // One hedge or retry per ten requests
+	DefaultHedgeBurst = 10
+)
+
+// hedgedEndpoints are the market data reads that may be hedged. They are
+// idempotent, cheap and polled by quoting loops; slow reads such as price
+// history are left alone.
+var hedgedEndpoints = map[string]bool{
+	GetOrderBook: true,
+	GetPrice:     true,
+	GetPrices:    true,
+	GetMidpoint:  true,
+	GetSpread:    true,
+	GetTickSize:  true,
+	GetNegRisk:   true,
+}
+
+// HedgeConfig configures hedged market data reads
+type HedgeConfig struct {
+	Delay time.Duration // Wait before sending a second request; 0 disables hedging
+	Ratio float64       // Hedges and retries earned per request; default DefaultHedgeRatio
+	Burst int           // Hedges and retries that can be saved up; default DefaultHedgeBurst
+}
+
+// HedgeStats counts hedged reads since hedging was configured
+type HedgeStats struct {
+	Requests  int `json:"requests"`   // Hedgeable requests made
+	Hedges    int `json:"hedges"`     // Second requests sent after Delay
+	Retries   int `json:"retries"`    // Second requests sent after the first failed
+	HedgeWins int `json:"hedge_wins"` // Second requests that answered first
+	Denied    int `json:"denied"`     // Second requests the budget did not allow
+}
+
+// hedger holds the hedging configuration and its retry budget
+type hedger struct {
+	mu     sync.Mutex
+	config HedgeConfig
+	tokens float64
+	stats  HedgeStats
+}
+
+// SetHedging makes GETs of books, prices, midpoints, spreads, tick sizes and neg
+// risk flags send a second, identical request when the first has not answered
+// within config.Delay, or has failed or answered with a 5xx, and use whichever
+// answers first with a status below 500. Each request earns config.Ratio of a
+// token and each second request spends one, so hedges stay a bounded fraction
+// of traffic even when the host is slow.
+func (c *ClobClient) SetHedging(config HedgeConfig) error {
+	if config.Delay < 0 || config.Ratio < 0 || config.Burst < 0 {
+		return fmt.Errorf("hedging delay, ratio and burst must not be negative")
+	}
+	if config.Ratio == 0 {
+		config.Ratio = DefaultHedgeRatio
+	}
+	if config.Burst == 0 {
+		config.Burst = DefaultHedgeBurst
+	}
+
+	c.hedge.mu.Lock()
+	defer c.hedge.mu.Unlock()
+	c.hedge.config = config
+	c.hedge.tokens = float64(config.Burst)
+	c.hedge.stats = HedgeStats{}
+	return nil
+}
+
+// HedgeStats returns the hedging counters
+func (c *ClobClient) HedgeStats() HedgeStats {
+	c.hedge.mu.Lock()
+	defer c.hedge.mu.Unlock()
+	return c.hedge.stats
+}
+
+// hedgeDelay returns the hedging delay for a request, or 0 when it is not hedged
+func (c *ClobClient) hedgeDelay(method, rawURL string) time.Duration {
+	if method != "GET" {
+		return 0
+	}
+	parsed, err := url.Parse(rawURL)
+	if err != nil || !hedgedEndpoints[parsed.Path] {
+		return 0
+	}
+
+	c.hedge.mu.Lock()
+	defer c.hedge.mu.Unlock()
+	if c.hedge.config.Delay <= 0 {
+		return 0
+	}
+	c.hedge.stats.Requests++
+	c.hedge.tokens += c.hedge.config.Ratio
+	if burst := float64(c.hedge.config.Burst); c.hedge.tokens > burst {
+		c.hedge.tokens = burst
+	}
+	return c.hedge.config.Delay
+}
+
+// withdrawHedge spends a token on a second request, counting it as a hedge or a retry
+func (c *ClobClient) withdrawHedge(retry bool) bool {
+	c.hedge.mu.Lock()
+	defer c.hedge.mu.Unlock()
+	if c.hedge.tokens < 1 {
+		c.hedge.stats.Denied++
+		return false
+	}
+	c.hedge.tokens--
+	if retry {
+		c.hedge.stats.Retries++
+	} else {
+		c.hedge.stats.Hedges++
+	}
+	return true
+}
+
+// roundTrip sends req and reads the whole response
+func (c *ClobClient) roundTrip(req *http.Request) (*http.Response, []byte, error) {
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to make request: %w", err)
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to read response: %w", err)
+	}
+	return resp, body, nil
+}
+
+// attempt is the outcome of one of a hedged read's requests
+type attempt struct {
+	resp   *http.Response
+	body   []byte
+	err    error
+	second bool
+}
+
+// failed reports whether the request gave no usable answer: a transport error
+// or a 5xx, which another request may still beat
+func (a attempt) failed() bool {
+	return a.err != nil || a.resp.StatusCode >= 500
+}
+
+// hedgedRoundTrip sends req, and a copy of it when it is slower than delay or
+// fails, returning the first response below 500. The other request is
+// cancelled. When both fail, the last failure is returned. The copy waits for
+// the client-side rate limit and the server budget like any other request.
+func (c *ClobClient) hedgedRoundTrip(req *http.Request, delay time.Duration) (*http.Response, []byte, error) {
+	ctx, cancel := context.WithCancel(req.Context())
+	defer cancel()
+
+	results := make(chan attempt, 2)
+	send := func(second bool) {
+		go func() {
+			if second {
+				c.waitRateLimit()
+				if err := c.waitForBudget(ctx, req.Method, req.URL.String()); err != nil {
+					results <- attempt{err: fmt.Errorf("failed waiting for rate limit reset: %w", err), second: second}
+					return
+				}
+			}
+			resp, body, err := c.roundTrip(req.Clone(ctx))
+			results <- attempt{resp: resp, body: body, err: err, second: second}
+		}()
+	}
+	send(false)
+
+	timer := time.NewTimer(delay)
+	defer timer.Stop()
+	inFlight, sentSecond := 1, false
+	for {
+		select {
+		case result := <-results:
+			inFlight--
+			if !result.failed() {
+				if result.second {
+					c.hedge.mu.Lock()
+					c.hedge.stats.HedgeWins++
+					c.hedge.mu.Unlock()
+				}
+				return result.resp, result.body, nil
+			}
+			// Retry a failed first request at once, unless the caller gave up
+			if !sentSecond && req.Context().Err() == nil {
+				sentSecond = true
+				if c.withdrawHedge(true) {
+					send(true)
+					inFlight++
+					continue
+				}
+			}
+			if inFlight == 0 {
+				return result.resp, result.body, result.err
+			}
+		case <-timer.C:
+			if !sentSecond {
+				sentSecond = true
+				if c.withdrawHedge(false) {
+					send(true)
+					inFlight++
+				}
+			}
+		}
+	}
+}
@@ -0,0 +1,129 @@
+package client
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestHedgedRead(t *testing.T) {
+	var mu sync.Mutex
+	calls := map[string]int{}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		calls[r.URL.Path]++
+		n := calls[r.URL.Path]
+		mu.Unlock()
+		if n == 1 {
+			// The first request of each path stalls
+			select {
+			case <-r.Context().Done():
+				return
+			case <-time.After(2 * time.Second):
+			}
+		}
+		w.Write([]byte(`{"mid":"0.5"}`))
+	}))
+	defer server.Close()
+
+	c, err := NewClobClient(server.URL, testChainID, "", nil, nil, nil)
+	if err != nil {
+		t.Fatalf("Failed to create client: %v", err)
+	}
+	if err := c.SetHedging(HedgeConfig{Delay: 20 * time.Millisecond, Burst: 1}); err != nil {
+		t.Fatalf("SetHedging failed: %v", err)
+	}
+
+	start := time.Now()
+	mid, err := c.GetMidpoint("123")
+	if err != nil {
+		t.Fatalf("GetMidpoint failed: %v", err)
+	}
+	if mid.Mid != "0.5" {
+		t.Errorf("Unexpected midpoint %+v", mid)
+	}
+	if elapsed := time.Since(start); elapsed > time.Second {
+		t.Errorf("Expected the hedge to answer quickly, took %v", elapsed)
+	}
+	stats := c.HedgeStats()
+	if stats.Requests != 1 || stats.Hedges != 1 || stats.HedgeWins != 1 {
+		t.Errorf("Unexpected stats %+v", stats)
+	}
+
+	// The budget is spent, so the next slow read is not hedged
+	c.SetEndpointTimeout(GetSpread, 100*time.Millisecond)
+	if _, err := c.makeRequest("GET", server.URL+GetSpread+"?token_id=123", nil, nil); err == nil {
+		t.Errorf("Expected the unhedged read to time out")
+	}
+	if stats := c.HedgeStats(); stats.Denied != 1 || stats.Hedges != 1 {
+		t.Errorf("Expected the hedge to be denied, got %+v", stats)
+	}
+}
+
+func TestHedgingSkipsOtherRequests(t *testing.T) {
+	c, err := NewClobClient("http://localhost", testChainID, "", nil, nil, nil)
+	if err != nil {
+		t.Fatalf("Failed to create client: %v", err)
+	}
+	if err := c.SetHedging(HedgeConfig{Delay: time.Millisecond}); err != nil {
+		t.Fatalf("SetHedging failed: %v", err)
+	}
+	if c.hedgeDelay("GET", "http://localhost"+GetPricesHistory+"?market=1") != 0 {
+		t.Errorf("Expected price history not to be hedged")
+	}
+	if c.hedgeDelay("POST", "http://localhost"+GetPrices) != 0 {
+		t.Errorf("Expected POSTs not to be hedged")
+	}
+	if c.hedgeDelay("GET", "http://localhost"+GetOrderBook+"?token_id=1") == 0 {
+		t.Errorf("Expected book reads to be hedged")
+	}
+	if err := c.SetHedging(HedgeConfig{Delay: -time.Second}); err == nil {
+		t.Errorf("Expected a negative delay to be rejected")
+	}
+}
+
+func TestHedgedReadRetriesServerErrors(t *testing.T) {
+	var mu sync.Mutex
+	calls := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		calls++
+		n := calls
+		mu.Unlock()
+		if n == 1 {
+			w.WriteHeader(http.StatusBadGateway)
+			return
+		}
+		w.Write([]byte(`{"mid":"0.5"}`))
+	}))
+	defer server.Close()
+
+	c, err := NewClobClient(server.URL, testChainID, "", nil, nil, nil)
+	if err != nil {
+		t.Fatalf("Failed to create client: %v", err)
+	}
+	if err := c.SetHedging(HedgeConfig{Delay: time.Second, Burst: 1}); err != nil {
+		t.Fatalf("SetHedging failed: %v", err)
+	}
+
+	mid, err := c.GetMidpoint("123")
+	if err != nil {
+		t.Fatalf("Expected the retry to answer, got %v", err)
+	}
+	if mid.Mid != "0.5" {
+		t.Errorf("Unexpected midpoint %+v", mid)
+	}
+	if stats := c.HedgeStats(); stats.Retries != 1 || stats.HedgeWins != 1 {
+		t.Errorf("Expected one winning retry, got %+v", stats)
+	}
+
+	// Without budget for a retry the 5xx is returned
+	mu.Lock()
+	calls = 0
+	mu.Unlock()
+	if _, err := c.GetMidpoint("123"); err == nil {
+		t.Errorf("Expected the 5xx to be returned")
+	}
+}
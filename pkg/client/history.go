@@ -0,0 +1,107 @@
+package client
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/url"
+	"strconv"
+	"time"
+
+	"github.com/MaDal776/polymarket-go-client/pkg/types"
+)
+
+// ErrNoPriceHistory is returned by GetPriceAt when the token has no price near the requested time
+var ErrNoPriceHistory = errors.New("no price history near the requested time")
+
+// historyFidelity returns the history resolution, in minutes, for a lookup at
+// time t: finer for recent times, coarser for old ones, which the server only
+// keeps at low resolution
+func historyFidelity(t, now time.Time) int {
+	age := now.Sub(t)
+	switch {
+	case age <= 24*time.Hour:
+		return 1
+	case age <= 7*24*time.Hour:
+		return 5
+	case age <= 30*24*time.Hour:
+		return 60
+	}
+	return 1440
+}
+
+// GetPriceAt returns the historical price point of a token nearest to t. It
+// searches a window around t at a fidelity suited to t's age, widening the
+// window up to twice when it holds no points.
+func (c *ClobClient) GetPriceAt(tokenID string, t time.Time) (*types.PricePoint, error) {
+	fidelity := historyFidelity(t, time.Now())
+	window := time.Duration(fidelity) * 30 * time.Minute
+
+	for attempt := 0; attempt < 3; attempt++ {
+		points, err := c.GetPricesHistory(types.PriceHistoryParams{
+			Market:   tokenID,
+			Fidelity: fidelity,
+			StartTs:  t.Add(-window).Unix(),
+			EndTs:    t.Add(window).Unix(),
+		})
+		if err != nil {
+			return nil, err
+		}
+		if nearest, ok := nearestPoint(points, t.Unix()); ok {
+			return &nearest, nil
+		}
+		window *= 10
+	}
+	return nil, fmt.Errorf("%w: token %s at %s", ErrNoPriceHistory, tokenID, t.UTC().Format(time.RFC3339))
+}
+
+// nearestPoint returns the point closest to ts, preferring the earlier one on ties
+func nearestPoint(points []types.PricePoint, ts int64) (types.PricePoint, bool) {
+	var nearest types.PricePoint
+	best := int64(-1)
+	for _, point := range points {
+		distance := point.T - ts
+		if distance < 0 {
+			distance = -distance
+		}
+		if best < 0 || distance < best || (distance == best && point.T < nearest.T) {
+			nearest, best = point, distance
+		}
+	}
+	return nearest, best >= 0
+}
+
+// GetPricesHistory gets a token's price history, oldest first
+func (c *ClobClient) GetPricesHistory(params types.PriceHistoryParams) ([]types.PricePoint, error) {
+	start := time.Now()
+
+	query := url.Values{}
+	query.Set("market", params.Market)
+	if params.Interval != "" {
+		query.Set("interval", params.Interval)
+	}
+	if params.Fidelity > 0 {
+		query.Set("fidelity", strconv.Itoa(params.Fidelity))
+	}
+	if params.StartTs > 0 {
+		query.Set("startTs", strconv.FormatInt(params.StartTs, 10))
+	}
+	if params.EndTs > 0 {
+		query.Set("endTs", strconv.FormatInt(params.EndTs, 10))
+	}
+
+	resp, err := c.makeRequest("GET", c.host+GetPricesHistory+"?"+query.Encode(), nil, nil)
+	if err != nil {
+		c.recordMetric("prices_history_retrieval", start, false, err.Error())
+		return nil, fmt.Errorf("failed to get prices history: %w", err)
+	}
+
+	var result types.PriceHistory
+	if err := json.Unmarshal(resp, &result); err != nil {
+		c.recordMetric("prices_history_retrieval", start, false, err.Error())
+		return nil, fmt.Errorf("failed to parse prices history response: %w", err)
+	}
+
+	c.recordMetric("prices_history_retrieval", start, true, "")
+	return result.History, nil
+}
@@ -0,0 +1,74 @@
+package client
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/MaDal776/polymarket-go-client/pkg/types"
+)
+
+func TestGetPricesHistory(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		query := r.URL.Query()
+		if r.URL.Path != GetPricesHistory || query.Get("market") != "123" || query.Get("interval") != "1d" || query.Get("fidelity") != "60" {
+			t.Errorf("Unexpected request %s", r.URL)
+		}
+		w.Write([]byte(`{"history":[{"t":1700000000,"p":0.5},{"t":1700003600,"p":0.52}]}`))
+	}))
+	defer server.Close()
+
+	c, err := NewClobClient(server.URL, testChainID, "", nil, nil, nil)
+	if err != nil {
+		t.Fatalf("Failed to create client: %v", err)
+	}
+	points, err := c.GetPricesHistory(types.PriceHistoryParams{Market: "123", Interval: "1d", Fidelity: 60})
+	if err != nil {
+		t.Fatalf("GetPricesHistory failed: %v", err)
+	}
+	if len(points) != 2 || points[1].T != 1700003600 || points[1].P != 0.52 {
+		t.Errorf("Unexpected history %+v", points)
+	}
+}
+
+func TestGetPriceAt(t *testing.T) {
+	target := time.Now().Add(-2 * time.Hour).Truncate(time.Minute)
+	var requests int
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		if r.URL.Query().Get("fidelity") != "1" {
+			t.Errorf("Expected 1 minute fidelity for a recent time, got %s", r.URL.Query().Get("fidelity"))
+		}
+		if requests == 1 {
+			w.Write([]byte(`{"history":[]}`))
+			return
+		}
+		fmt.Fprintf(w, `{"history":[{"t":%d,"p":0.4},{"t":%d,"p":0.45},{"t":%d,"p":0.5}]}`,
+			target.Unix()-600, target.Unix()-60, target.Unix()+120)
+	}))
+	defer server.Close()
+
+	c, err := NewClobClient(server.URL, testChainID, "", nil, nil, nil)
+	if err != nil {
+		t.Fatalf("Failed to create client: %v", err)
+	}
+	point, err := c.GetPriceAt("123", target)
+	if err != nil {
+		t.Fatalf("GetPriceAt failed: %v", err)
+	}
+	if point.P != 0.45 || requests != 2 {
+		t.Errorf("Got %+v after %d requests, want 0.45 after widening once", point, requests)
+	}
+}
+
+func TestHistoryFidelity(t *testing.T) {
+	now := time.Now()
+	cases := map[time.Duration]int{time.Hour: 1, 3 * 24 * time.Hour: 5, 20 * 24 * time.Hour: 60, 400 * 24 * time.Hour: 1440}
+	for age, want := range cases {
+		if got := historyFidelity(now.Add(-age), now); got != want {
+			t.Errorf("historyFidelity(%s ago) = %d, want %d", age, got, want)
+		}
+	}
+}
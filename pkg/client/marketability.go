@@ -0,0 +1,119 @@
+package client
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/MaDal776/polymarket-go-client/pkg/types"
+	"github.com/MaDal776/polymarket-go-client/pkg/utils"
+)
+
+// ErrWouldCross is returned when a limit order would trade against the book as
+// soon as it is posted
+var ErrWouldCross = errors.New("limit order would cross the book")
+
+// CrossCheck selects what PostOrder does with a GTC or GTD order that would
+// trade immediately instead of resting
+type CrossCheck int
+
+const (
+	CrossCheckOff    CrossCheck = iota // Post without looking at the book
+	CrossCheckWarn                     // Post, and report the cross to the callback
+	CrossCheckReject                   // Return a *CrossError without posting
+)
+
+// CrossError describes a limit order priced through the opposite side of the book
+type CrossError struct {
+	TokenID   string
+	Side      types.OrderSide
+	Price     float64 // The order's limit price
+	BestPrice float64 // The best opposite price: the best ask for a buy, the best bid for a sell
+	Shares    float64 // Shares that would fill immediately, up to the order's size
+}
+
+func (e *CrossError) Error() string {
+	opposite := "ask"
+	if e.Side == types.SELL {
+		opposite = "bid"
+	}
+	return fmt.Sprintf("%v: %s at %s against best %s %s would fill %s shares as taker",
+		ErrWouldCross, e.Side, formatFloat(e.Price), opposite, formatFloat(e.BestPrice), formatFloat(e.Shares))
+}
+
+func (e *CrossError) Unwrap() error {
+	return ErrWouldCross
+}
+
+// SetCrossCheck makes PostOrder compare GTC and GTD orders with the current book
+// (the response cache serves it when enabled) and warn about or reject the ones
+// that would take liquidity, e.g. a quote with a mistyped price. onCross is
+// called for every crossing order in both modes and may be nil. SetFOKFillCheck
+// covers the opposite case, a FOK order the book cannot fill. Orders created
+// with CreateOrderOptions.AllowCross are posted without the check.
+func (c *ClobClient) SetCrossCheck(mode CrossCheck, onCross func(cross *CrossError)) {
+	c.crossCheck = mode
+	c.onCross = onCross
+}
+
+// CheckCross returns a *CrossError when a limit order would trade immediately
+// against the given book, or nil when it would rest
+func CheckCross(signedOrder *types.SignedOrder, book *types.OrderBookSummary) error {
+	price, size, err := utils.SignedOrderPriceAndSize(signedOrder)
+	if err != nil {
+		return err
+	}
+	bids, asks, err := book.Levels()
+	if err != nil {
+		return err
+	}
+
+	cross := &CrossError{TokenID: signedOrder.TokenID, Side: signedOrder.Side, Price: price}
+	if signedOrder.Side == types.BUY {
+		for _, level := range asks {
+			if level.Price > price+1e-9 {
+				break
+			}
+			if cross.Shares == 0 {
+				cross.BestPrice = level.Price
+			}
+			cross.Shares += level.Size
+		}
+	} else {
+		for _, level := range bids {
+			if level.Price < price-1e-9 {
+				break
+			}
+			if cross.Shares == 0 {
+				cross.BestPrice = level.Price
+			}
+			cross.Shares += level.Size
+		}
+	}
+	if cross.Shares == 0 {
+		return nil
+	}
+	if cross.Shares > size {
+		cross.Shares = size
+	}
+	return cross
+}
+
+// checkCross applies the cross check to an order about to be posted
+func (c *ClobClient) checkCross(signedOrder *types.SignedOrder) error {
+	book, err := c.GetOrderBook(signedOrder.TokenID)
+	if err != nil {
+		return err
+	}
+	err = CheckCross(signedOrder, book)
+	var cross *CrossError
+	if !errors.As(err, &cross) {
+		return err
+	}
+	if c.onCross != nil {
+		c.onCross(cross)
+	}
+	if c.crossCheck == CrossCheckReject {
+		return cross
+	}
+	return nil
+}
@@ -0,0 +1,57 @@
+package client
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/MaDal776/polymarket-go-client/pkg/types"
+)
+
+func TestCheckCross(t *testing.T) {
+	client, err := NewClobClient(testHost, testChainID, testPrivateKey, nil, nil, nil)
+	if err != nil {
+		t.Fatalf("Failed to create client: %v", err)
+	}
+	client.PrimeNegRisk(testTokenID, false)
+	book := &types.OrderBookSummary{
+		Bids: []types.OrderSummary{{Price: "0.48", Size: "100"}},
+		Asks: []types.OrderSummary{{Price: "0.52", Size: "30"}, {Price: "0.53", Size: "50"}},
+	}
+
+	tests := []struct {
+		name   string
+		side   types.OrderSide
+		price  float64
+		shares float64 // 0 when the order rests
+	}{
+		{"resting bid", types.BUY, 0.50, 0},
+		{"bid at the ask", types.BUY, 0.52, 30},
+		{"bid through two levels", types.BUY, 0.60, 40},
+		{"resting ask", types.SELL, 0.50, 0},
+		{"ask through the bid", types.SELL, 0.40, 40},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			orderArgs := types.OrderArgs{TokenID: testTokenID, Price: tt.price, Size: 40, Side: tt.side}
+			signedOrder, err := client.CreateOrder(orderArgs, &types.CreateOrderOptions{TickSize: types.TickSize001})
+			if err != nil {
+				t.Fatalf("Failed to create order: %v", err)
+			}
+
+			err = CheckCross(signedOrder, book)
+			var cross *CrossError
+			if tt.shares == 0 {
+				if err != nil {
+					t.Errorf("Expected no cross, got %v", err)
+				}
+				return
+			}
+			if !errors.As(err, &cross) || !errors.Is(err, ErrWouldCross) {
+				t.Fatalf("Expected a *CrossError, got %v", err)
+			}
+			if cross.Shares != tt.shares {
+				t.Errorf("Expected %v shares to fill, got %v", tt.shares, cross.Shares)
+			}
+		})
+	}
+}
@@ -0,0 +1,144 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/MaDal776/polymarket-go-client/pkg/types"
+)
+
+// DefaultMarketCacheRefresh is the interval used by RefreshMarketCacheEvery when none is given
+const DefaultMarketCacheRefresh = 10 * time.Minute
+
+// PrimeTickSize caches a token's tick size, e.g. from a market listing, so orders
+// on it need no lookup
+func (c *ClobClient) PrimeTickSize(tokenID string, tickSize types.TickSize) {
+	c.mu.Lock()
+	c.tickSizes[tokenID] = tickSize
+	c.mu.Unlock()
+}
+
+// PrimeNegRisk caches a token's neg risk flag
+func (c *ClobClient) PrimeNegRisk(tokenID string, negRisk bool) {
+	c.mu.Lock()
+	c.negRisks[tokenID] = negRisk
+	c.mu.Unlock()
+}
+
+// CachedTickSize returns a token's cached tick size without fetching it
+func (c *ClobClient) CachedTickSize(tokenID string) (types.TickSize, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	tickSize, exists := c.tickSizes[tokenID]
+	return tickSize, exists
+}
+
+// CachedNegRisk returns a token's cached neg risk flag without fetching it
+func (c *ClobClient) CachedNegRisk(tokenID string) (negRisk bool, exists bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	negRisk, exists = c.negRisks[tokenID]
+	return negRisk, exists
+}
+
+// CachedTokens returns the sorted IDs of the tokens with a cached tick size or neg risk flag
+func (c *ClobClient) CachedTokens() []string {
+	c.mu.Lock()
+	seen := make(map[string]bool, len(c.tickSizes)+len(c.negRisks))
+	for tokenID := range c.tickSizes {
+		seen[tokenID] = true
+	}
+	for tokenID := range c.negRisks {
+		seen[tokenID] = true
+	}
+	c.mu.Unlock()
+
+	tokenIDs := make([]string, 0, len(seen))
+	for tokenID := range seen {
+		tokenIDs = append(tokenIDs, tokenID)
+	}
+	sort.Strings(tokenIDs)
+	return tokenIDs
+}
+
+// InvalidateMarketCache drops the cached tick sizes and neg risk flags of the given
+// tokens, or of every token when none are given, so they are fetched again on next
+// use. Their entries in the response cache, if one is set, are dropped too.
+func (c *ClobClient) InvalidateMarketCache(tokenIDs ...string) {
+	c.mu.Lock()
+	if len(tokenIDs) == 0 {
+		c.tickSizes = make(map[string]types.TickSize)
+		c.negRisks = make(map[string]bool)
+	}
+	for _, tokenID := range tokenIDs {
+		delete(c.tickSizes, tokenID)
+		delete(c.negRisks, tokenID)
+	}
+	c.mu.Unlock()
+
+	if len(tokenIDs) == 0 {
+		c.forgetEndpointResponses(GetTickSize, GetNegRisk)
+	}
+	for _, tokenID := range tokenIDs {
+		c.forgetTokenResponses(tokenID, GetTickSize, GetNegRisk)
+	}
+}
+
+// RefreshMarketCache refetches the tick size and neg risk flag of every cached
+// token in parallel. Entries that fail to refresh keep their previous value; the
+// failures are joined into the returned error.
+func (c *ClobClient) RefreshMarketCache(ctx context.Context, concurrency int) error {
+	start := time.Now()
+
+	tokenIDs := c.CachedTokens()
+	requests := make([]BatchRequest[struct{}], len(tokenIDs))
+	for i, tokenID := range tokenIDs {
+		tokenID := tokenID
+		requests[i] = func(context.Context) (struct{}, error) {
+			return struct{}{}, c.refreshToken(tokenID)
+		}
+	}
+	_, err := collectByToken(tokenIDs, Batch(ctx, requests, concurrency))
+	if err != nil {
+		c.recordMetric("market_cache_refresh", start, false, err.Error())
+		return fmt.Errorf("failed to refresh market cache: %w", err)
+	}
+
+	c.recordMetric("market_cache_refresh", start, true, "")
+	return nil
+}
+
+// refreshToken refetches one token's entries from the exchange, bypassing the
+// response cache. The old entries stay in use until the new ones replace them,
+// and are kept when a fetch fails.
+func (c *ClobClient) refreshToken(tokenID string) error {
+	c.forgetTokenResponses(tokenID, GetTickSize, GetNegRisk)
+	_, tickErr := c.fetchTickSize(tokenID, time.Now())
+	_, negRiskErr := c.fetchNegRisk(tokenID, time.Now())
+	return errors.Join(tickErr, negRiskErr)
+}
+
+// RefreshMarketCacheEvery calls RefreshMarketCache every interval (default
+// DefaultMarketCacheRefresh) until ctx is cancelled, passing failures to onError
+// when it is set
+func (c *ClobClient) RefreshMarketCacheEvery(ctx context.Context, interval time.Duration, onError func(err error)) {
+	if interval <= 0 {
+		interval = DefaultMarketCacheRefresh
+	}
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			if err := c.RefreshMarketCache(ctx, 0); err != nil && onError != nil {
+				onError(err)
+			}
+		}
+	}
+}
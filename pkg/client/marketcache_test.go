@@ -0,0 +1,152 @@
+package client
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/MaDal776/polymarket-go-client/pkg/types"
+)
+
+func TestMarketCacheControl(t *testing.T) {
+	c, err := NewClobClient(testHost, testChainID, "", nil, nil, nil)
+	if err != nil {
+		t.Fatalf("Failed to create client: %v", err)
+	}
+
+	c.PrimeTickSize("1", types.TickSize001)
+	c.PrimeNegRisk("2", true)
+	if tickSize, ok := c.CachedTickSize("1"); !ok || tickSize != types.TickSize001 {
+		t.Errorf("CachedTickSize = %s, %v", tickSize, ok)
+	}
+	if negRisk, ok := c.CachedNegRisk("2"); !ok || !negRisk {
+		t.Errorf("CachedNegRisk = %v, %v", negRisk, ok)
+	}
+	if got := c.CachedTokens(); !reflect.DeepEqual(got, []string{"1", "2"}) {
+		t.Errorf("CachedTokens = %v", got)
+	}
+
+	c.InvalidateMarketCache("1")
+	if _, ok := c.CachedTickSize("1"); ok {
+		t.Error("Expected token 1 to be invalidated")
+	}
+	c.InvalidateMarketCache()
+	if got := c.CachedTokens(); len(got) != 0 {
+		t.Errorf("Expected an empty cache, got %v", got)
+	}
+}
+
+func TestRefreshMarketCache(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Query().Get("token_id") == "bad" {
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+		switch r.URL.Path {
+		case GetTickSize:
+			w.Write([]byte(`{"minimum_tick_size":"0.001"}`))
+		case GetNegRisk:
+			w.Write([]byte(`{"neg_risk":true}`))
+		}
+	}))
+	defer server.Close()
+
+	c, err := NewClobClient(server.URL, testChainID, "", nil, nil, nil)
+	if err != nil {
+		t.Fatalf("Failed to create client: %v", err)
+	}
+	c.PrimeTickSize("good", types.TickSize001)
+	c.PrimeNegRisk("good", false)
+	c.PrimeTickSize("bad", types.TickSize001)
+
+	if err := c.RefreshMarketCache(context.Background(), 2); err == nil {
+		t.Error("Expected the failing token to be reported")
+	}
+	if tickSize, _ := c.CachedTickSize("good"); tickSize != types.TickSize0001 {
+		t.Errorf("Expected the refreshed tick size, got %s", tickSize)
+	}
+	if negRisk, _ := c.CachedNegRisk("good"); !negRisk {
+		t.Error("Expected the refreshed neg risk flag")
+	}
+	if tickSize, ok := c.CachedTickSize("bad"); !ok || tickSize != types.TickSize001 {
+		t.Errorf("Expected the failed token to keep its tick size, got %s, %v", tickSize, ok)
+	}
+	if _, ok := c.CachedNegRisk("bad"); ok {
+		t.Error("Expected no neg risk flag for the failed token")
+	}
+}
+
+func TestMarketCacheBypassesResponseCache(t *testing.T) {
+	var tick atomic.Value
+	tick.Store("0.01")
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case GetTickSize:
+			w.Write([]byte(`{"minimum_tick_size":"` + tick.Load().(string) + `"}`))
+		case GetNegRisk:
+			w.Write([]byte(`{"neg_risk":false}`))
+		}
+	}))
+	defer server.Close()
+
+	c, err := NewClobClient(server.URL, testChainID, "", nil, nil, nil)
+	if err != nil {
+		t.Fatalf("Failed to create client: %v", err)
+	}
+	c.SetResponseCache(NewResponseCache(time.Hour))
+	if tickSize, err := c.GetTickSize("1"); err != nil || tickSize != types.TickSize001 {
+		t.Fatalf("GetTickSize = %s, %v", tickSize, err)
+	}
+
+	tick.Store("0.001")
+	if err := c.RefreshMarketCache(context.Background(), 1); err != nil {
+		t.Fatalf("RefreshMarketCache failed: %v", err)
+	}
+	if tickSize, _ := c.CachedTickSize("1"); tickSize != types.TickSize0001 {
+		t.Errorf("Expected the refresh to reach the exchange, got %s", tickSize)
+	}
+
+	tick.Store("0.1")
+	c.InvalidateMarketCache("1")
+	if tickSize, _ := c.GetTickSize("1"); tickSize != types.TickSize01 {
+		t.Errorf("Expected the invalidated token to be refetched, got %s", tickSize)
+	}
+
+	tick.Store("0.0001")
+	c.InvalidateMarketCache()
+	if tickSize, _ := c.GetTickSize("1"); tickSize != types.TickSize00001 {
+		t.Errorf("Expected every token to be refetched, got %s", tickSize)
+	}
+}
+
+func TestGetNegRiskRejectsMissingField(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{"error":"market not found"}`))
+	}))
+	defer server.Close()
+
+	c, err := NewClobClient(server.URL, testChainID, "", nil, nil, nil)
+	if err != nil {
+		t.Fatalf("Failed to create client: %v", err)
+	}
+	if _, err := c.GetNegRisk("1"); err == nil {
+		t.Fatal("Expected a response without neg_risk to fail")
+	}
+	if _, ok := c.CachedNegRisk("1"); ok {
+		t.Error("Expected nothing to be cached")
+	}
+
+	failed := false
+	for _, metric := range c.GetMetrics() {
+		if metric.Operation == "neg_risk_retrieval" && !metric.Success {
+			failed = true
+		}
+	}
+	if !failed {
+		t.Error("Expected a failed neg_risk_retrieval metric")
+	}
+}
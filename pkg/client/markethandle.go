@@ -0,0 +1,150 @@
+package client
+
+import (
+	"fmt"
+	"strconv"
+
+	"github.com/MaDal776/polymarket-go-client/pkg/types"
+	"github.com/MaDal776/polymarket-go-client/pkg/utils"
+)
+
+// SlugSource looks up a market by slug (e.g. the Gamma client)
+type SlugSource interface {
+	GetMarketBySlug(slug string) (*types.Market, error)
+}
+
+// MarketHandle is a binary market resolved once, carrying what orders on it need
+// so user code can refer to outcomes ("Yes" / "No") instead of raw token IDs
+type MarketHandle struct {
+	Market    *types.Market
+	YesToken  string
+	NoToken   string
+	TickSize  types.TickSize
+	NegRisk   bool
+	Contracts types.ContractConfig // The exchange contracts orders on this market are signed for
+
+	client *ClobClient
+}
+
+// OpenMarket returns a handle on the market with the given condition ID
+func (c *ClobClient) OpenMarket(conditionID string) (*MarketHandle, error) {
+	market, err := c.GetMarket(conditionID)
+	if err != nil {
+		return nil, err
+	}
+	return c.HandleFor(market)
+}
+
+// OpenMarketBySlug returns a handle on the market source finds for slug
+func (c *ClobClient) OpenMarketBySlug(source SlugSource, slug string) (*MarketHandle, error) {
+	market, err := source.GetMarketBySlug(slug)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get market %s: %w", slug, err)
+	}
+	return c.HandleFor(market)
+}
+
+// HandleFor returns a handle on a market already fetched, caching its tick size,
+// neg risk flag and token IDs
+func (c *ClobClient) HandleFor(market *types.Market) (*MarketHandle, error) {
+	yes, err := OutcomeTokenID(market, OutcomeYes)
+	if err != nil {
+		return nil, err
+	}
+	no, err := OutcomeTokenID(market, OutcomeNo)
+	if err != nil {
+		return nil, err
+	}
+	tickSize, err := utils.ParseTickSizeString(strconv.FormatFloat(market.MinimumTickSize, 'g', -1, 64))
+	if err != nil {
+		return nil, fmt.Errorf("market %s: %w", market.ConditionID, err)
+	}
+	contracts, exists := GetContractConfig(c.chainID, market.NegRisk)
+	if !exists {
+		return nil, fmt.Errorf("%w: %d", ErrUnsupportedChainID, c.chainID)
+	}
+	c.cacheMarket(market)
+
+	return &MarketHandle{
+		Market:    market,
+		YesToken:  yes,
+		NoToken:   no,
+		TickSize:  tickSize,
+		NegRisk:   market.NegRisk,
+		Contracts: contracts,
+		client:    c,
+	}, nil
+}
+
+// TokenID returns the token ID of OutcomeYes or OutcomeNo
+func (h *MarketHandle) TokenID(outcome string) (string, error) {
+	switch outcome {
+	case OutcomeYes:
+		return h.YesToken, nil
+	case OutcomeNo:
+		return h.NoToken, nil
+	}
+	return OutcomeTokenID(h.Market, outcome)
+}
+
+// Book gets an outcome's order book
+func (h *MarketHandle) Book(outcome string) (*types.OrderBookSummary, error) {
+	tokenID, err := h.TokenID(outcome)
+	if err != nil {
+		return nil, err
+	}
+	return h.client.GetOrderBook(tokenID)
+}
+
+// Midpoint gets an outcome's midpoint
+func (h *MarketHandle) Midpoint(outcome string) (*types.MidpointResponse, error) {
+	tokenID, err := h.TokenID(outcome)
+	if err != nil {
+		return nil, err
+	}
+	return h.client.GetMidpoint(tokenID)
+}
+
+// Price gets an outcome's price on one side
+func (h *MarketHandle) Price(outcome string, side types.OrderSide) (*types.PriceResponse, error) {
+	tokenID, err := h.TokenID(outcome)
+	if err != nil {
+		return nil, err
+	}
+	return h.client.GetPrice(tokenID, side)
+}
+
+// Order creates and signs a limit order on an outcome without posting it
+func (h *MarketHandle) Order(outcome string, side types.OrderSide, price, size float64) (*types.SignedOrder, error) {
+	tokenID, err := h.TokenID(outcome)
+	if err != nil {
+		return nil, err
+	}
+	orderArgs := types.OrderArgs{TokenID: tokenID, Price: price, Size: size, Side: side}
+	return h.client.CreateOrder(orderArgs, h.options())
+}
+
+// Place creates and posts a GTC limit order on an outcome
+func (h *MarketHandle) Place(outcome string, side types.OrderSide, price, size float64) (map[string]interface{}, error) {
+	return h.client.PlaceOutcomeOrder(h.Market, outcome, side, price, size, h.options())
+}
+
+// Refresh refetches the market, e.g. after its tick size changed or it closed
+func (h *MarketHandle) Refresh() error {
+	market, err := h.client.GetMarket(h.Market.ConditionID)
+	if err != nil {
+		return err
+	}
+	h.client.InvalidateMarketCache(h.YesToken, h.NoToken)
+	refreshed, err := h.client.HandleFor(market)
+	if err != nil {
+		return err
+	}
+	*h = *refreshed
+	return nil
+}
+
+// options returns the order options for this market
+func (h *MarketHandle) options() *types.CreateOrderOptions {
+	return &types.CreateOrderOptions{TickSize: h.TickSize, NegRisk: h.NegRisk}
+}
@@ -0,0 +1,57 @@
+package client
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/MaDal776/polymarket-go-client/pkg/types"
+)
+
+type fakeSlugSource map[string]*types.Market
+
+func (s fakeSlugSource) GetMarketBySlug(slug string) (*types.Market, error) {
+	if market, ok := s[slug]; ok {
+		return market, nil
+	}
+	return nil, errors.New("not found")
+}
+
+func TestMarketHandle(t *testing.T) {
+	c, err := NewClobClient(testHost, 137, testPrivateKey, nil, nil, nil)
+	if err != nil {
+		t.Fatalf("Failed to create client: %v", err)
+	}
+	market := &types.Market{
+		ConditionID:     "0xabc",
+		Tokens:          []types.MarketToken{{TokenID: "111", Outcome: "Yes"}, {TokenID: "222", Outcome: "No"}},
+		MinimumTickSize: 0.001,
+		NegRisk:         true,
+	}
+
+	handle, err := c.OpenMarketBySlug(fakeSlugSource{"will-it-rain": market}, "will-it-rain")
+	if err != nil {
+		t.Fatalf("Failed to open market: %v", err)
+	}
+	if handle.YesToken != "111" || handle.NoToken != "222" {
+		t.Errorf("Unexpected tokens %s / %s", handle.YesToken, handle.NoToken)
+	}
+	if handle.TickSize != types.TickSize0001 || !handle.NegRisk {
+		t.Errorf("Unexpected tick size %s or neg risk %v", handle.TickSize, handle.NegRisk)
+	}
+	if handle.Contracts.Exchange != negRiskContractConfigs[137].Exchange {
+		t.Errorf("Expected the neg risk exchange, got %s", handle.Contracts.Exchange)
+	}
+
+	// Everything the order needs is on the handle, so no request is made
+	order, err := handle.Order(OutcomeNo, types.BUY, 0.125, 10)
+	if err != nil {
+		t.Fatalf("Failed to create order: %v", err)
+	}
+	if order.TokenID != "222" {
+		t.Errorf("Order on token %s, want 222", order.TokenID)
+	}
+
+	if _, err := c.OpenMarketBySlug(fakeSlugSource{}, "missing"); err == nil {
+		t.Error("Expected an unknown slug to fail")
+	}
+}
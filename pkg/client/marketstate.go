@@ -0,0 +1,115 @@
+package client
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/MaDal776/polymarket-go-client/pkg/types"
+)
+
+// ErrMarketNotAccepting is returned (wrapped in a *MarketNotAcceptingError) for
+// markets that would reject orders
+var ErrMarketNotAccepting = errors.New("market not accepting orders")
+
+// MarketNotAcceptingError reports why a market does not accept orders
+type MarketNotAcceptingError struct {
+	ConditionID string
+	Reason      string // e.g. "closed", "inactive"
+}
+
+func (e *MarketNotAcceptingError) Error() string {
+	return fmt.Sprintf("%v: market %s is %s", ErrMarketNotAccepting, e.ConditionID, e.Reason)
+}
+
+func (e *MarketNotAcceptingError) Unwrap() error {
+	return ErrMarketNotAccepting
+}
+
+// CheckMarketAccepting returns a *MarketNotAcceptingError unless the market is
+// active, open, has an order book and accepts orders at now
+func CheckMarketAccepting(market *types.Market, now time.Time) error {
+	reason := ""
+	switch {
+	case market.Closed:
+		reason = "closed"
+	case market.Archived:
+		reason = "archived"
+	case !market.Active:
+		reason = "inactive"
+	case !market.EnableOrderBook:
+		reason = "without an order book"
+	case !market.AcceptingOrders:
+		reason = "not accepting orders"
+	}
+	if reason == "" && market.AcceptingFrom != "" {
+		if from, err := time.Parse(time.RFC3339, market.AcceptingFrom); err == nil && now.Before(from) {
+			reason = "not accepting orders until " + from.UTC().Format(time.RFC3339)
+		}
+	}
+	if reason == "" {
+		return nil
+	}
+	return &MarketNotAcceptingError{ConditionID: market.ConditionID, Reason: reason}
+}
+
+// IsMarketAccepting reports whether the market with the given condition ID (0x...)
+// or token ID accepts orders. When it does not, the error matches
+// ErrMarketNotAccepting and says why; other errors mean the lookup failed.
+func (c *ClobClient) IsMarketAccepting(id string) (bool, error) {
+	start := time.Now()
+
+	conditionID, err := c.conditionIDOf(id)
+	if err != nil {
+		c.recordMetric("market_accepting_check", start, false, err.Error())
+		return false, err
+	}
+	market, err := c.GetMarket(conditionID)
+	if err != nil {
+		c.recordMetric("market_accepting_check", start, false, err.Error())
+		return false, err
+	}
+	c.cacheMarket(market)
+
+	if err := CheckMarketAccepting(market, time.Now()); err != nil {
+		c.recordMetric("market_accepting_check", start, true, "not accepting")
+		return false, err
+	}
+	c.recordMetric("market_accepting_check", start, true, "")
+	return true, nil
+}
+
+// SetMarketCheck makes PostOrder call IsMarketAccepting for the order's token
+// first, failing fast with an error matching ErrMarketNotAccepting instead of
+// posting to a closed or paused market
+func (c *ClobClient) SetMarketCheck(enabled bool) {
+	c.marketCheck = enabled
+}
+
+// conditionIDOf returns id if it is a condition ID, or the condition ID of the
+// market the token id belongs to, looked up from its order book and cached
+func (c *ClobClient) conditionIDOf(id string) (string, error) {
+	if strings.HasPrefix(id, "0x") {
+		return id, nil
+	}
+
+	c.mu.Lock()
+	conditionID, exists := c.tokenMarkets[id]
+	c.mu.Unlock()
+	if exists {
+		return conditionID, nil
+	}
+
+	book, err := c.GetOrderBook(id)
+	if err != nil {
+		return "", fmt.Errorf("failed to look up market of token %s: %w", id, err)
+	}
+	if book.Market == "" {
+		return "", fmt.Errorf("no market found for token %s", id)
+	}
+	c.mu.Lock()
+	c.tokenMarkets[id] = book.Market
+	c.mu.Unlock()
+	return book.Market, nil
+}
@@ -0,0 +1,75 @@
+package client
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/MaDal776/polymarket-go-client/pkg/types"
+)
+
+func TestCheckMarketAccepting(t *testing.T) {
+	now := time.Date(2025, 6, 1, 12, 0, 0, 0, time.UTC)
+	open := types.Market{ConditionID: "0x1", Active: true, EnableOrderBook: true, AcceptingOrders: true}
+	if err := CheckMarketAccepting(&open, now); err != nil {
+		t.Fatalf("Expected an open market to accept orders, got %v", err)
+	}
+
+	closed, paused, pending := open, open, open
+	closed.Closed = true
+	paused.AcceptingOrders = false
+	pending.AcceptingFrom = "2025-06-01T13:00:00Z"
+	for name, market := range map[string]types.Market{"closed": closed, "paused": paused, "pending": pending} {
+		market := market
+		err := CheckMarketAccepting(&market, now)
+		var notAccepting *MarketNotAcceptingError
+		if !errors.As(err, &notAccepting) || !errors.Is(err, ErrMarketNotAccepting) {
+			t.Errorf("%s: expected a MarketNotAcceptingError, got %v", name, err)
+		}
+	}
+
+	pending.AcceptingFrom = "2025-06-01T11:00:00Z"
+	if err := CheckMarketAccepting(&pending, now); err != nil {
+		t.Errorf("Expected a market past its accepting timestamp to accept orders, got %v", err)
+	}
+}
+
+func TestPostOrderMarketCheck(t *testing.T) {
+	var books, posts int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case GetOrderBook:
+			atomic.AddInt32(&books, 1)
+			w.Write([]byte(`{"market":"0xabc","asset_id":"1","bids":[],"asks":[]}`))
+		case GetMarket + "0xabc":
+			w.Write([]byte(`{"condition_id":"0xabc","active":true,"closed":true,"enable_order_book":true,"accepting_orders":false}`))
+		case PostOrder:
+			atomic.AddInt32(&posts, 1)
+			w.Write([]byte(`{"success":true}`))
+		}
+	}))
+	defer server.Close()
+
+	creds := &types.ApiCreds{ApiKey: "key", ApiSecret: "c2VjcmV0", ApiPassphrase: "pass"}
+	c, err := NewClobClient(server.URL, testChainID, testPrivateKey, creds, nil, nil)
+	if err != nil {
+		t.Fatalf("Failed to create client: %v", err)
+	}
+	c.SetMarketCheck(true)
+
+	order := &types.SignedOrder{TokenID: "1", Side: types.BUY, MakerAmount: "5000000", TakerAmount: "10000000", Expiration: "0"}
+	for i := 0; i < 2; i++ {
+		if _, err := c.PostOrder(order, types.GTC); !errors.Is(err, ErrMarketNotAccepting) {
+			t.Fatalf("Expected ErrMarketNotAccepting, got %v", err)
+		}
+	}
+	if posts != 0 {
+		t.Errorf("Expected no order to be posted, got %d", posts)
+	}
+	if books != 1 {
+		t.Errorf("Expected the token's market to be looked up once, got %d", books)
+	}
+}
@@ -0,0 +1,125 @@
+package client
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"math"
+	"sort"
+	"strconv"
+	"time"
+
+	"github.com/MaDal776/polymarket-go-client/pkg/types"
+)
+
+// OperationSummary aggregates the metrics of one operation
+type OperationSummary struct {
+	Operation   string        `json:"operation"`
+	Count       int           `json:"count"`
+	Failures    int           `json:"failures"`
+	SuccessRate float64       `json:"success_rate"` // 0-1
+	Min         time.Duration `json:"min"`
+	Avg         time.Duration `json:"avg"`
+	P95         time.Duration `json:"p95"`
+	P99         time.Duration `json:"p99"`
+	Max         time.Duration `json:"max"`
+}
+
+// MetricsSummary is a per-operation summary of metrics, sorted by operation
+type MetricsSummary []OperationSummary
+
+// metricsCSVHeader lists the CSV columns in output order; durations are in milliseconds
+var metricsCSVHeader = []string{"operation", "count", "failures", "success_rate", "min_ms", "avg_ms", "p95_ms", "p99_ms", "max_ms"}
+
+// SummarizeMetrics aggregates metrics per operation
+func SummarizeMetrics(metrics []types.PerformanceMetrics) MetricsSummary {
+	durations := make(map[string][]time.Duration)
+	failures := make(map[string]int)
+	for _, metric := range metrics {
+		durations[metric.Operation] = append(durations[metric.Operation], metric.Duration)
+		if !metric.Success {
+			failures[metric.Operation]++
+		}
+	}
+
+	summary := make(MetricsSummary, 0, len(durations))
+	for operation, values := range durations {
+		sort.Slice(values, func(i, j int) bool { return values[i] < values[j] })
+		var total time.Duration
+		for _, value := range values {
+			total += value
+		}
+
+		summary = append(summary, OperationSummary{
+			Operation:   operation,
+			Count:       len(values),
+			Failures:    failures[operation],
+			SuccessRate: float64(len(values)-failures[operation]) / float64(len(values)),
+			Min:         values[0],
+			Avg:         total / time.Duration(len(values)),
+			P95:         percentile(values, 0.95),
+			P99:         percentile(values, 0.99),
+			Max:         values[len(values)-1],
+		})
+	}
+	sort.Slice(summary, func(i, j int) bool { return summary[i].Operation < summary[j].Operation })
+	return summary
+}
+
+// SummarizeMetrics aggregates the metrics returned by GetMetrics per operation
+func (c *ClobClient) SummarizeMetrics() MetricsSummary {
+	return SummarizeMetrics(c.GetMetrics())
+}
+
+// WriteJSON writes the summary as a JSON array; durations are in nanoseconds
+func (s MetricsSummary) WriteJSON(w io.Writer) error {
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+	if err := encoder.Encode(s); err != nil {
+		return fmt.Errorf("failed to write JSON summary: %w", err)
+	}
+	return nil
+}
+
+// WriteCSV writes the summary as CSV with a header row; durations are in milliseconds
+func (s MetricsSummary) WriteCSV(w io.Writer) error {
+	writer := csv.NewWriter(w)
+	if err := writer.Write(metricsCSVHeader); err != nil {
+		return fmt.Errorf("failed to write CSV header: %w", err)
+	}
+
+	for _, op := range s {
+		row := []string{
+			op.Operation,
+			strconv.Itoa(op.Count),
+			strconv.Itoa(op.Failures),
+			strconv.FormatFloat(op.SuccessRate, 'f', 4, 64),
+			milliseconds(op.Min),
+			milliseconds(op.Avg),
+			milliseconds(op.P95),
+			milliseconds(op.P99),
+			milliseconds(op.Max),
+		}
+		if err := writer.Write(row); err != nil {
+			return fmt.Errorf("failed to write CSV row: %w", err)
+		}
+	}
+
+	writer.Flush()
+	return writer.Error()
+}
+
+// percentile returns the nearest-rank percentile of sorted durations
+func percentile(sorted []time.Duration, p float64) time.Duration {
+	rank := int(math.Ceil(p*float64(len(sorted)))) - 1
+	if rank < 0 {
+		rank = 0
+	}
+	return sorted[rank]
+}
+
+// milliseconds formats a duration in milliseconds with microsecond precision
+func milliseconds(d time.Duration) string {
+	return strconv.FormatFloat(float64(d)/float64(time.Millisecond), 'f', 3, 64)
+}
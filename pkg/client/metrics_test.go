@@ -0,0 +1,131 @@
+package client
+
+import (
+	"bytes"
+	"encoding/base64"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/MaDal776/polymarket-go-client/pkg/types"
+)
+
+func testMetrics() []types.PerformanceMetrics {
+	metrics := make([]types.PerformanceMetrics, 0)
+	for i := 1; i <= 100; i++ {
+		metrics = append(metrics, types.PerformanceMetrics{
+			Operation: "order_posting",
+			Duration:  time.Duration(i) * time.Millisecond,
+			Success:   i%10 != 0,
+		})
+	}
+	metrics = append(metrics, types.PerformanceMetrics{Operation: "http_request", Duration: 5 * time.Millisecond, Success: true})
+	return metrics
+}
+
+func TestSummarizeMetrics(t *testing.T) {
+	summary := SummarizeMetrics(testMetrics())
+	if len(summary) != 2 || summary[0].Operation != "http_request" || summary[1].Operation != "order_posting" {
+		t.Fatalf("Unexpected summary %+v", summary)
+	}
+
+	posting := summary[1]
+	if posting.Count != 100 || posting.Failures != 10 || posting.SuccessRate != 0.9 {
+		t.Errorf("Count %d, failures %d, success rate %v", posting.Count, posting.Failures, posting.SuccessRate)
+	}
+	if posting.Min != time.Millisecond || posting.Max != 100*time.Millisecond {
+		t.Errorf("Min %v, max %v", posting.Min, posting.Max)
+	}
+	if posting.Avg != 50500*time.Microsecond {
+		t.Errorf("Avg %v, want 50.5ms", posting.Avg)
+	}
+	if posting.P95 != 95*time.Millisecond || posting.P99 != 99*time.Millisecond {
+		t.Errorf("P95 %v, p99 %v", posting.P95, posting.P99)
+	}
+}
+
+func TestMetricsSummaryExport(t *testing.T) {
+	summary := SummarizeMetrics(testMetrics())
+
+	var csvOut bytes.Buffer
+	if err := summary.WriteCSV(&csvOut); err != nil {
+		t.Fatalf("WriteCSV failed: %v", err)
+	}
+	lines := strings.Split(strings.TrimSpace(csvOut.String()), "\n")
+	if len(lines) != 3 || !strings.HasPrefix(lines[0], "operation,count,failures") {
+		t.Fatalf("Unexpected CSV:\n%s", csvOut.String())
+	}
+	if lines[2] != "order_posting,100,10,0.9000,1.000,50.500,95.000,99.000,100.000" {
+		t.Errorf("Unexpected CSV row %q", lines[2])
+	}
+
+	var jsonOut bytes.Buffer
+	if err := summary.WriteJSON(&jsonOut); err != nil {
+		t.Fatalf("WriteJSON failed: %v", err)
+	}
+	var decoded MetricsSummary
+	if err := json.Unmarshal(jsonOut.Bytes(), &decoded); err != nil {
+		t.Fatalf("Invalid JSON: %v", err)
+	}
+	if len(decoded) != 2 || decoded[1] != summary[1] {
+		t.Errorf("Round trip mismatch: %+v", decoded)
+	}
+}
+
+func TestOrderMetricsCarryStages(t *testing.T) {
+	secret := base64.URLEncoding.EncodeToString([]byte("0123456789abcdef0123456789abcdef"))
+	creds := &types.ApiCreds{ApiKey: "key", ApiSecret: secret, ApiPassphrase: "pass"}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case GetNegRisk:
+			w.Write([]byte(`{"neg_risk":false}`))
+		default:
+			w.Write([]byte(`{"success":true,"orderID":"0xabc"}`))
+		}
+	}))
+	defer server.Close()
+
+	c, err := NewClobClient(server.URL, 137, testPrivateKey, creds, nil, nil)
+	if err != nil {
+		t.Fatalf("Failed to create client: %v", err)
+	}
+	orderArgs := types.OrderArgs{TokenID: "1", Price: 0.5, Size: 10, Side: types.BUY}
+	if _, err := c.CreateAndPostOrder(orderArgs, &types.CreateOrderOptions{TickSize: "0.01"}); err != nil {
+		t.Fatalf("CreateAndPostOrder failed: %v", err)
+	}
+
+	want := map[string][]string{
+		"order_posting": {types.StageChecks, types.StageMarshal, types.StageHeaders, types.StageHTTP},
+		"create_and_post_order": {
+			types.StageOptions, types.StageValidate, types.StageAmounts, types.StageHash, types.StageSign,
+			types.StageChecks, types.StageMarshal, types.StageHeaders, types.StageHTTP,
+		},
+	}
+	for _, metric := range c.GetMetrics() {
+		names, ok := want[metric.Operation]
+		if !ok {
+			continue
+		}
+		delete(want, metric.Operation)
+		if len(metric.Stages) != len(names) {
+			t.Errorf("%s: expected stages %v, got %+v", metric.Operation, names, metric.Stages)
+			continue
+		}
+		var total time.Duration
+		for i, stage := range metric.Stages {
+			if stage.Name != names[i] {
+				t.Errorf("%s: stage %d is %s, want %s", metric.Operation, i, stage.Name, names[i])
+			}
+			total += stage.Duration
+		}
+		if total > metric.Duration {
+			t.Errorf("%s: stages add up to %v, more than the %v recorded", metric.Operation, total, metric.Duration)
+		}
+	}
+	if len(want) != 0 {
+		t.Errorf("Missing metrics for %v", want)
+	}
+}
@@ -0,0 +1,150 @@
+package client
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+
+	"github.com/MaDal776/polymarket-go-client/pkg/types"
+	"github.com/MaDal776/polymarket-go-client/pkg/utils"
+)
+
+// ErrThroughMidpoint is returned when an order is priced further through the
+// midpoint than SetMidpointCheck allows
+var ErrThroughMidpoint = errors.New("order priced too far through the midpoint")
+
+// MidpointCheck selects what PostOrder does with an order priced too far
+// through the midpoint
+type MidpointCheck int
+
+const (
+	MidpointCheckOff    MidpointCheck = iota // Post without looking at the midpoint
+	MidpointCheckWarn                        // Post, and report the order to the callback
+	MidpointCheckReject                      // Return a *MidpointError without posting
+)
+
+// MidpointError describes an order priced further through the midpoint than allowed
+type MidpointError struct {
+	TokenID  string
+	Side     types.OrderSide
+	Price    float64 // The order's limit price
+	Midpoint float64
+	Bps      float64 // How far through the midpoint the price is, in basis points of the midpoint
+	MaxBps   int     // The configured limit
+}
+
+func (e *MidpointError) Error() string {
+	return fmt.Sprintf("%v: %s at %s is %.0f bps through the midpoint %s, limit %d bps",
+		ErrThroughMidpoint, e.Side, formatFloat(e.Price), e.Bps, formatFloat(e.Midpoint), e.MaxBps)
+}
+
+func (e *MidpointError) Unwrap() error {
+	return ErrThroughMidpoint
+}
+
+// SetMidpointCheck makes PostOrder, PostOrders and RequoteLadder compare every
+// order with the token's current
+// midpoint and warn about or reject buys priced more than maxBps above it and
+// sells priced more than maxBps below it. It catches prices entered in the wrong
+// unit, e.g. 0.95 meant as 0.55, before the order trades. onViolation is called
+// for every such order in both modes and may be nil. Market orders priced from
+// the book are checked too, so leave room for their slippage.
+func (c *ClobClient) SetMidpointCheck(mode MidpointCheck, maxBps int, onViolation func(violation *MidpointError)) error {
+	if mode != MidpointCheckOff && maxBps <= 0 {
+		return fmt.Errorf("midpoint check limit must be positive, got %d bps", maxBps)
+	}
+	c.midpointCheck = mode
+	c.midpointBps = maxBps
+	c.onMidpoint = onViolation
+	return nil
+}
+
+// CheckMidpoint returns a *MidpointError when a signed order is priced more than
+// maxBps through midpoint, or nil
+func CheckMidpoint(signedOrder *types.SignedOrder, midpoint float64, maxBps int) error {
+	if midpoint <= 0 {
+		return nil
+	}
+	price, _, err := utils.SignedOrderPriceAndSize(signedOrder)
+	if err != nil {
+		return err
+	}
+	through := price - midpoint
+	if signedOrder.Side == types.SELL {
+		through = midpoint - price
+	}
+	bps := through / midpoint * 10000
+	if bps <= float64(maxBps)+1e-6 {
+		return nil
+	}
+	return &MidpointError{
+		TokenID:  signedOrder.TokenID,
+		Side:     signedOrder.Side,
+		Price:    price,
+		Midpoint: midpoint,
+		Bps:      bps,
+		MaxBps:   maxBps,
+	}
+}
+
+// checkMidpoint applies the midpoint check to an order about to be posted
+func (c *ClobClient) checkMidpoint(signedOrder *types.SignedOrder) error {
+	midpoint, err := c.midpoint(signedOrder.TokenID)
+	if err != nil {
+		return err
+	}
+	return c.applyMidpointCheck(signedOrder, midpoint)
+}
+
+// checkMidpoints applies the midpoint check to a batch of orders about to be
+// posted, fetching each token's midpoint once
+func (c *ClobClient) checkMidpoints(orders []types.PostOrdersArgs) error {
+	midpoints := make(map[string]float64)
+	for i, order := range orders {
+		if order.Order == nil {
+			continue
+		}
+		midpoint, known := midpoints[order.Order.TokenID]
+		if !known {
+			var err error
+			if midpoint, err = c.midpoint(order.Order.TokenID); err != nil {
+				return fmt.Errorf("order %d: midpoint check failed: %w", i, err)
+			}
+			midpoints[order.Order.TokenID] = midpoint
+		}
+		if err := c.applyMidpointCheck(order.Order, midpoint); err != nil {
+			return fmt.Errorf("order %d: midpoint check failed: %w", i, err)
+		}
+	}
+	return nil
+}
+
+// midpoint fetches a token's current midpoint
+func (c *ClobClient) midpoint(tokenID string) (float64, error) {
+	response, err := c.GetMidpoint(tokenID)
+	if err != nil {
+		return 0, err
+	}
+	midpoint, err := strconv.ParseFloat(response.Mid, 64)
+	if err != nil {
+		return 0, fmt.Errorf("invalid midpoint %q: %w", response.Mid, err)
+	}
+	return midpoint, nil
+}
+
+// applyMidpointCheck compares an order with midpoint, reporting a violation to
+// the callback and returning it in reject mode
+func (c *ClobClient) applyMidpointCheck(signedOrder *types.SignedOrder, midpoint float64) error {
+	err := CheckMidpoint(signedOrder, midpoint, c.midpointBps)
+	var violation *MidpointError
+	if !errors.As(err, &violation) {
+		return err
+	}
+	if c.onMidpoint != nil {
+		c.onMidpoint(violation)
+	}
+	if c.midpointCheck == MidpointCheckReject {
+		return violation
+	}
+	return nil
+}
@@ -0,0 +1,159 @@
+package client
+
+import (
+	"context"
+	"encoding/base64"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/MaDal776/polymarket-go-client/pkg/types"
+)
+
+func TestCheckMidpoint(t *testing.T) {
+	client, err := NewClobClient(testHost, testChainID, testPrivateKey, nil, nil, nil)
+	if err != nil {
+		t.Fatalf("Failed to create client: %v", err)
+	}
+	client.PrimeNegRisk(testTokenID, false)
+
+	tests := []struct {
+		name  string
+		side  types.OrderSide
+		price float64
+		bps   float64 // 0 when the order passes
+	}{
+		{"bid below the mid", types.BUY, 0.45, 0},
+		{"bid within the limit", types.BUY, 0.52, 0},
+		{"bid far through the mid", types.BUY, 0.95, 9000},
+		{"ask above the mid", types.SELL, 0.60, 0},
+		{"ask far through the mid", types.SELL, 0.10, 8000},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			orderArgs := types.OrderArgs{TokenID: testTokenID, Price: tt.price, Size: 40, Side: tt.side}
+			signedOrder, err := client.CreateOrder(orderArgs, &types.CreateOrderOptions{TickSize: types.TickSize001})
+			if err != nil {
+				t.Fatalf("Failed to create order: %v", err)
+			}
+
+			err = CheckMidpoint(signedOrder, 0.5, 500)
+			var violation *MidpointError
+			if tt.bps == 0 {
+				if err != nil {
+					t.Errorf("Expected the order to pass, got %v", err)
+				}
+				return
+			}
+			if !errors.As(err, &violation) || !errors.Is(err, ErrThroughMidpoint) {
+				t.Fatalf("Expected a *MidpointError, got %v", err)
+			}
+			if violation.Bps < tt.bps-1 || violation.Bps > tt.bps+1 {
+				t.Errorf("Expected %v bps through the midpoint, got %v", tt.bps, violation.Bps)
+			}
+		})
+	}
+}
+
+func TestPostOrderMidpointCheck(t *testing.T) {
+	posts := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case GetMidpoint:
+			w.Write([]byte(`{"mid":"0.55"}`))
+		case PostOrder:
+			posts++
+			w.Write([]byte(`{"success":true,"orderID":"0x1"}`))
+		}
+	}))
+	defer server.Close()
+
+	secret := base64.URLEncoding.EncodeToString([]byte("0123456789abcdef0123456789abcdef"))
+	creds := &types.ApiCreds{ApiKey: "key", ApiSecret: secret, ApiPassphrase: "pass"}
+	client, err := NewClobClient(server.URL, testChainID, testPrivateKey, creds, nil, nil)
+	if err != nil {
+		t.Fatalf("Failed to create client: %v", err)
+	}
+	client.PrimeNegRisk(testTokenID, false)
+	if err := client.SetMidpointCheck(MidpointCheckReject, 0, nil); err == nil {
+		t.Errorf("Expected a zero limit to be rejected")
+	}
+	var reported []*MidpointError
+	if err := client.SetMidpointCheck(MidpointCheckReject, 1000, func(v *MidpointError) { reported = append(reported, v) }); err != nil {
+		t.Fatalf("SetMidpointCheck failed: %v", err)
+	}
+
+	// 0.95 typed for 0.55
+	orderArgs := types.OrderArgs{TokenID: testTokenID, Price: 0.95, Size: 10, Side: types.BUY}
+	signedOrder, err := client.CreateOrder(orderArgs, &types.CreateOrderOptions{TickSize: types.TickSize001})
+	if err != nil {
+		t.Fatalf("Failed to create order: %v", err)
+	}
+	if _, err := client.PostOrder(signedOrder, types.GTC); !errors.Is(err, ErrThroughMidpoint) {
+		t.Errorf("Expected ErrThroughMidpoint, got %v", err)
+	}
+	if posts != 0 || len(reported) != 1 {
+		t.Errorf("Expected no post and one report, got %d posts and %d reports", posts, len(reported))
+	}
+
+	// Warn mode posts anyway
+	client.SetMidpointCheck(MidpointCheckWarn, 1000, func(v *MidpointError) { reported = append(reported, v) })
+	if _, err := client.PostOrder(signedOrder, types.GTC); err != nil {
+		t.Fatalf("PostOrder failed: %v", err)
+	}
+	if posts != 1 || len(reported) != 2 {
+		t.Errorf("Expected a post and a second report, got %d posts and %d reports", posts, len(reported))
+	}
+}
+
+func TestPostOrdersMidpointCheck(t *testing.T) {
+	posts, midpoints := 0, 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case GetMidpoint:
+			midpoints++
+			w.Write([]byte(`{"mid":"0.55"}`))
+		case PostOrders:
+			posts++
+			w.Write([]byte(`[{"success":true,"orderID":"0x1"},{"success":true,"orderID":"0x2"}]`))
+		}
+	}))
+	defer server.Close()
+
+	secret := base64.URLEncoding.EncodeToString([]byte("0123456789abcdef0123456789abcdef"))
+	creds := &types.ApiCreds{ApiKey: "key", ApiSecret: secret, ApiPassphrase: "pass"}
+	client, err := NewClobClient(server.URL, testChainID, testPrivateKey, creds, nil, nil)
+	if err != nil {
+		t.Fatalf("Failed to create client: %v", err)
+	}
+	client.PrimeNegRisk(testTokenID, false)
+	if err := client.SetMidpointCheck(MidpointCheckReject, 1000, nil); err != nil {
+		t.Fatalf("SetMidpointCheck failed: %v", err)
+	}
+
+	batch := make([]types.PostOrdersArgs, 0, 2)
+	for _, price := range []float64{0.54, 0.95} {
+		orderArgs := types.OrderArgs{TokenID: testTokenID, Price: price, Size: 10, Side: types.BUY}
+		signedOrder, err := client.CreateOrder(orderArgs, &types.CreateOrderOptions{TickSize: types.TickSize001})
+		if err != nil {
+			t.Fatalf("Failed to create order: %v", err)
+		}
+		batch = append(batch, types.PostOrdersArgs{Order: signedOrder, OrderType: types.GTC})
+	}
+
+	// One bad price stops the whole batch; the token's midpoint is fetched once
+	if _, err := client.PostOrders(batch); !errors.Is(err, ErrThroughMidpoint) {
+		t.Errorf("Expected ErrThroughMidpoint, got %v", err)
+	}
+	if posts != 0 || midpoints != 1 {
+		t.Errorf("Expected no post and one midpoint request, got %d posts and %d requests", posts, midpoints)
+	}
+	if _, err := client.RequoteLadder(context.Background(), nil, batch); !errors.Is(err, ErrThroughMidpoint) {
+		t.Errorf("Expected RequoteLadder to run the midpoint check, got %v", err)
+	}
+
+	if _, err := client.PostOrders(batch[:1]); err != nil || posts != 1 {
+		t.Errorf("Expected the batch within the limit to post, got %d posts: %v", posts, err)
+	}
+}
@@ -0,0 +1,78 @@
+package client
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/MaDal776/polymarket-go-client/pkg/types"
+)
+
+// GetNotifications returns the account's pending notifications
+func (c *ClobClient) GetNotifications() ([]types.Notification, error) {
+	start := time.Now()
+
+	if err := c.requireAuth(types.L2); err != nil {
+		c.recordMetric("notifications_retrieval", start, false, "insufficient auth level")
+		return nil, err
+	}
+
+	requestArgs := types.RequestArgs{
+		Method:      "GET",
+		RequestPath: Notifications,
+	}
+	headers, err := c.headerBuilder.CreateLevel2Headers(c.creds, requestArgs)
+	if err != nil {
+		c.recordMetric("notifications_retrieval", start, false, err.Error())
+		return nil, fmt.Errorf("failed to create headers: %w", err)
+	}
+
+	url := fmt.Sprintf("%s%s?signature_type=%d", c.host, Notifications, c.builder().SignatureType())
+	resp, err := c.makeRequest("GET", url, headers, nil)
+	if err != nil {
+		c.recordMetric("notifications_retrieval", start, false, err.Error())
+		return nil, fmt.Errorf("failed to get notifications: %w", err)
+	}
+
+	var notifications []types.Notification
+	if err := json.Unmarshal(resp, &notifications); err != nil {
+		c.recordMetric("notifications_retrieval", start, false, err.Error())
+		return nil, fmt.Errorf("failed to parse notifications response: %w", err)
+	}
+
+	c.recordMetric("notifications_retrieval", start, true, "")
+	return notifications, nil
+}
+
+// DropNotifications acknowledges notifications so they are no longer returned
+func (c *ClobClient) DropNotifications(ids []string) error {
+	start := time.Now()
+
+	if err := c.requireAuth(types.L2); err != nil {
+		c.recordMetric("notifications_drop", start, false, "insufficient auth level")
+		return err
+	}
+	if len(ids) == 0 {
+		return nil
+	}
+
+	requestArgs := types.RequestArgs{
+		Method:      "DELETE",
+		RequestPath: Notifications,
+	}
+	headers, err := c.headerBuilder.CreateLevel2Headers(c.creds, requestArgs)
+	if err != nil {
+		c.recordMetric("notifications_drop", start, false, err.Error())
+		return fmt.Errorf("failed to create headers: %w", err)
+	}
+
+	url := c.host + Notifications + "?ids=" + strings.Join(ids, ",")
+	if _, err := c.makeRequest("DELETE", url, headers, nil); err != nil {
+		c.recordMetric("notifications_drop", start, false, err.Error())
+		return fmt.Errorf("failed to drop notifications: %w", err)
+	}
+
+	c.recordMetric("notifications_drop", start, true, "")
+	return nil
+}
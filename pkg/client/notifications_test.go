@@ -0,0 +1,50 @@
+package client
+
+import (
+	"encoding/base64"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/MaDal776/polymarket-go-client/pkg/types"
+)
+
+func TestNotifications(t *testing.T) {
+	secret := base64.URLEncoding.EncodeToString([]byte("0123456789abcdef0123456789abcdef"))
+	creds := &types.ApiCreds{ApiKey: "key", ApiSecret: secret, ApiPassphrase: "pass"}
+
+	var dropped string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.Method {
+		case "GET":
+			if r.URL.Query().Get("signature_type") != "0" {
+				t.Errorf("Unexpected query %s", r.URL.RawQuery)
+			}
+			w.Write([]byte(`[{"id":7,"owner":"key","type":2,"payload":{"order_id":"0xabc"}}]`))
+		case "DELETE":
+			dropped = r.URL.Query().Get("ids")
+			w.Write([]byte(`"OK"`))
+		}
+	}))
+	defer server.Close()
+
+	c, err := NewClobClient(server.URL, 137, testPrivateKey, creds, nil, nil)
+	if err != nil {
+		t.Fatalf("Failed to create client: %v", err)
+	}
+
+	notifications, err := c.GetNotifications()
+	if err != nil {
+		t.Fatalf("GetNotifications failed: %v", err)
+	}
+	if len(notifications) != 1 || notifications[0].ID != "7" || notifications[0].Type != types.NotificationOrderFilled {
+		t.Fatalf("Unexpected notifications %+v", notifications)
+	}
+
+	if err := c.DropNotifications([]string{"7", "8"}); err != nil {
+		t.Fatalf("DropNotifications failed: %v", err)
+	}
+	if dropped != "7,8" {
+		t.Errorf("Dropped %q, want 7,8", dropped)
+	}
+}
@@ -0,0 +1,151 @@
+package client
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+
+	"github.com/MaDal776/polymarket-go-client/pkg/orderbuilder"
+	"github.com/MaDal776/polymarket-go-client/pkg/types"
+)
+
+// OrderDefaults fill in the order fields callers leave at their zero value
+type OrderDefaults struct {
+	Taker         string        // Default orderbuilder.ZeroAddress, a public order
+	FeeRateBps    int           // Used when an order's FeeRateBps is 0
+	Nonce         int64         // Used when an order's Nonce is 0; the exchange nonce orders are cancelled by
+	ExpireAfter   time.Duration // Lifetime of limit orders without an expiration, at least a second; they get one from ExpirationIn and are posted as GTD by CreateAndPostOrder
+	Owner         string        // API key orders are posted under when SignedOrder.Owner is empty; default the client's API key
+	SignatureType *int          // Signature type of orders signed from now on: 0 (EOA), 1 (proxy) or 2 (Safe); default the one given to NewClobClient
+	Funder        string        // Proxy or Safe wallet orders signed from now on are made from; default the one given to NewClobClient
+}
+
+// minExpireAfter is the shortest ExpireAfter accepted; expirations are whole seconds
+const minExpireAfter = time.Second
+
+// SetOrderDefaults sets the defaults CreateOrder and CreateMarketOrder apply
+func (c *ClobClient) SetOrderDefaults(defaults OrderDefaults) error {
+	if defaults.Taker != "" && !common.IsHexAddress(defaults.Taker) {
+		return fmt.Errorf("invalid default taker: %s", defaults.Taker)
+	}
+	if defaults.ExpireAfter != 0 && defaults.ExpireAfter < minExpireAfter {
+		return fmt.Errorf("default order lifetime must be at least %s", minExpireAfter)
+	}
+	if defaults.SignatureType != nil || defaults.Funder != "" {
+		if c.signer == nil {
+			return ErrNoSigner
+		}
+		if defaults.SignatureType != nil && (*defaults.SignatureType < 0 || *defaults.SignatureType > 2) {
+			return fmt.Errorf("invalid signature type: %d", *defaults.SignatureType)
+		}
+		if defaults.Funder != "" && !common.IsHexAddress(defaults.Funder) {
+			return fmt.Errorf("invalid funder address: %s", defaults.Funder)
+		}
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.orderDefaults = defaults
+	if c.orderBuilder != nil {
+		signatureType, funder := c.signatureType, c.funder
+		if defaults.SignatureType != nil {
+			signatureType = *defaults.SignatureType
+		}
+		if defaults.Funder != "" {
+			funder = defaults.Funder
+		}
+		c.orderBuilder.SetWallet(signatureType, funder)
+	}
+	return nil
+}
+
+// OrderDefaults returns the order defaults in effect
+func (c *ClobClient) OrderDefaults() OrderDefaults {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.orderDefaults
+}
+
+// SetOrderSigner changes the signature type and funder of orders signed from
+// now on, keeping the other order defaults. An empty funder is the one given
+// to NewClobClient.
+func (c *ClobClient) SetOrderSigner(signatureType int, funder string) error {
+	defaults := c.OrderDefaults()
+	defaults.SignatureType = &signatureType
+	defaults.Funder = funder
+	return c.SetOrderDefaults(defaults)
+}
+
+// builder returns the order builder orders are signed with
+func (c *ClobClient) builder() *orderbuilder.OrderBuilder {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.orderBuilder
+}
+
+// applyOrderDefaults fills in a limit order's unset fields
+func (c *ClobClient) applyOrderDefaults(orderArgs *types.OrderArgs) {
+	defaults := c.OrderDefaults()
+	orderArgs.Taker = defaultTaker(orderArgs.Taker, defaults.Taker)
+	if orderArgs.FeeRateBps == 0 {
+		orderArgs.FeeRateBps = defaults.FeeRateBps
+	}
+	if orderArgs.Nonce == 0 {
+		orderArgs.Nonce = defaults.Nonce
+	}
+	if orderArgs.Expiration == 0 {
+		ttl := orderArgs.ExpireAfter
+		if ttl == 0 {
+			ttl = defaults.ExpireAfter
+		}
+		if ttl > 0 {
+			orderArgs.Expiration = c.ExpirationIn(ttl)
+		}
+	}
+}
+
+// applyMarketOrderDefaults fills in a market order's unset fields; market orders never expire
+func (c *ClobClient) applyMarketOrderDefaults(orderArgs *types.MarketOrderArgs) {
+	defaults := c.OrderDefaults()
+	orderArgs.Taker = defaultTaker(orderArgs.Taker, defaults.Taker)
+	if orderArgs.FeeRateBps == 0 {
+		orderArgs.FeeRateBps = defaults.FeeRateBps
+	}
+	if orderArgs.Nonce == 0 {
+		orderArgs.Nonce = defaults.Nonce
+	}
+}
+
+// defaultTaker returns the order's taker, the configured one, or the zero address
+func defaultTaker(taker, configured string) string {
+	switch {
+	case taker != "":
+		return taker
+	case configured != "":
+		return configured
+	}
+	return orderbuilder.ZeroAddress
+}
+
+// orderOwner returns the API key a signed order is posted under: the order's
+// own Owner, the default owner, or the client's API key
+func (c *ClobClient) orderOwner(signedOrder *types.SignedOrder) string {
+	if signedOrder.Owner != "" {
+		return signedOrder.Owner
+	}
+	if owner := c.OrderDefaults().Owner; owner != "" {
+		return owner
+	}
+	return c.creds.ApiKey
+}
+
+// PostOrderType is the order type CreateAndPostOrder posts a signed limit order
+// as: GTD when it expires, e.g. from OrderDefaults.ExpireAfter, otherwise GTC.
+// Traders wrapping a client use it to post the same way.
+func PostOrderType(signedOrder *types.SignedOrder) types.OrderType {
+	if signedOrder.Expiration != "" && signedOrder.Expiration != "0" {
+		return types.GTD
+	}
+	return types.GTC
+}
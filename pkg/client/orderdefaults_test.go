@@ -0,0 +1,158 @@
+package client
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/MaDal776/polymarket-go-client/pkg/orderbuilder"
+	"github.com/MaDal776/polymarket-go-client/pkg/types"
+)
+
+func TestOrderDefaults(t *testing.T) {
+	client, err := NewClobClient(testHost, testChainID, testPrivateKey, nil, nil, nil)
+	if err != nil {
+		t.Fatalf("Failed to create client: %v", err)
+	}
+	client.PrimeNegRisk(testTokenID, false)
+	orderArgs := types.OrderArgs{TokenID: testTokenID, Price: 0.55, Size: 10, Side: types.BUY}
+	options := &types.CreateOrderOptions{TickSize: types.TickSize001}
+
+	signedOrder, err := client.CreateOrder(orderArgs, options)
+	if err != nil {
+		t.Fatalf("Failed to create order: %v", err)
+	}
+	if signedOrder.Taker != orderbuilder.ZeroAddress || PostOrderType(signedOrder) != types.GTC {
+		t.Errorf("Expected a public GTC order, got taker %q type %s", signedOrder.Taker, PostOrderType(signedOrder))
+	}
+
+	// The wallet changes on the same order builder, keeping its domain cache
+	builder := client.builder()
+	proxy := 1
+	funder := "0x1111111111111111111111111111111111111111"
+	if err := client.SetOrderDefaults(OrderDefaults{FeeRateBps: 10, ExpireAfter: time.Hour, SignatureType: &proxy, Funder: funder}); err != nil {
+		t.Fatalf("SetOrderDefaults failed: %v", err)
+	}
+	if client.builder() != builder {
+		t.Error("Expected the order builder to be kept")
+	}
+	signedOrder, err = client.CreateOrder(orderArgs, options)
+	if err != nil {
+		t.Fatalf("Failed to create order: %v", err)
+	}
+	expiration, _ := strconv.ParseInt(signedOrder.Expiration, 10, 64)
+	if time.Until(time.Unix(expiration, 0)) < 59*time.Minute || PostOrderType(signedOrder) != types.GTD {
+		t.Errorf("Expected a GTD order expiring in an hour, got %s", signedOrder.Expiration)
+	}
+	if signedOrder.FeeRateBps != "10" || signedOrder.Maker != funder || signedOrder.SignatureType != 1 {
+		t.Errorf("Defaults not applied: %+v", signedOrder)
+	}
+
+	// Without a wallet in the defaults, orders are made from the client's own again
+	if err := client.SetOrderDefaults(OrderDefaults{}); err != nil {
+		t.Fatalf("SetOrderDefaults failed: %v", err)
+	}
+	signedOrder, err = client.CreateOrder(orderArgs, options)
+	if err != nil {
+		t.Fatalf("Failed to create order: %v", err)
+	}
+	if signedOrder.Maker != client.GetAddress() || signedOrder.SignatureType != 0 {
+		t.Errorf("Expected an EOA order from the signer, got maker %s type %d", signedOrder.Maker, signedOrder.SignatureType)
+	}
+
+	if err := client.SetOrderDefaults(OrderDefaults{ExpireAfter: 500 * time.Millisecond}); err == nil {
+		t.Error("Expected a lifetime shorter than a second to be rejected")
+	}
+	invalid := 3
+	if err := client.SetOrderDefaults(OrderDefaults{SignatureType: &invalid}); err == nil {
+		t.Error("Expected an invalid signature type to be rejected")
+	}
+	if err := client.SetOrderDefaults(OrderDefaults{Funder: "0x123"}); err == nil {
+		t.Error("Expected an invalid funder to be rejected")
+	}
+}
+
+func TestSetOrderSignerWhileCreatingOrders(t *testing.T) {
+	client, err := NewClobClient(testHost, testChainID, testPrivateKey, nil, nil, nil)
+	if err != nil {
+		t.Fatalf("Failed to create client: %v", err)
+	}
+	client.PrimeNegRisk(testTokenID, false)
+	orderArgs := types.OrderArgs{TokenID: testTokenID, Price: 0.55, Size: 10, Side: types.BUY}
+	options := &types.CreateOrderOptions{TickSize: types.TickSize001}
+
+	// Run with -race: swapping the signer must not race with signing
+	var wg sync.WaitGroup
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		for i := 0; i < 20; i++ {
+			if _, err := client.CreateOrder(orderArgs, options); err != nil {
+				t.Errorf("Failed to create order: %v", err)
+				return
+			}
+		}
+	}()
+	for i := 0; i < 20; i++ {
+		if err := client.SetOrderSigner(i%2, ""); err != nil {
+			t.Fatalf("SetOrderSigner failed: %v", err)
+		}
+	}
+	wg.Wait()
+}
+
+func TestShortestOrderLifetimePosts(t *testing.T) {
+	var posted int
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		posted++
+		w.Write([]byte(`{"success":true,"orderID":"0xabc"}`))
+	}))
+	defer server.Close()
+
+	creds := &types.ApiCreds{ApiKey: "key", ApiSecret: "c2VjcmV0", ApiPassphrase: "pass"}
+	client, err := NewClobClient(server.URL, testChainID, testPrivateKey, creds, nil, nil)
+	if err != nil {
+		t.Fatalf("Failed to create client: %v", err)
+	}
+	client.PrimeNegRisk(testTokenID, false)
+	if err := client.SetOrderDefaults(OrderDefaults{ExpireAfter: minExpireAfter}); err != nil {
+		t.Fatalf("SetOrderDefaults failed: %v", err)
+	}
+	options := &types.CreateOrderOptions{TickSize: types.TickSize001}
+
+	// Signed at the end of a second and posted almost a lifetime later, the
+	// default and a per-order lifetime still clear the GTD threshold
+	local := &fixedClock{now: time.Unix(1700000000, 999000000)}
+	client.SetClock(local)
+	for _, orderArgs := range []types.OrderArgs{
+		{TokenID: testTokenID, Price: 0.55, Size: 10, Side: types.BUY},
+		{TokenID: testTokenID, Price: 0.55, Size: 10, Side: types.BUY, ExpireAfter: minExpireAfter},
+	} {
+		local.now = time.Unix(1700000000, 999000000)
+		signedOrder, err := client.CreateOrder(orderArgs, options)
+		if err != nil {
+			t.Fatalf("Failed to create order: %v", err)
+		}
+		local.now = local.now.Add(minExpireAfter - time.Millisecond)
+		if _, err := client.PostOrder(signedOrder, PostOrderType(signedOrder)); err != nil {
+			t.Errorf("Expected an order with a %s lifetime to post, got %v", minExpireAfter, err)
+		}
+	}
+	if posted != 2 {
+		t.Errorf("Expected 2 orders posted, got %d", posted)
+	}
+
+	// A shorter per-order lifetime is rejected before signing
+	_, err = client.CreateOrder(types.OrderArgs{TokenID: testTokenID, Price: 0.55, Size: 10, Side: types.BUY, ExpireAfter: 500 * time.Millisecond}, options)
+	var verr *ValidationError
+	if !errors.As(err, &verr) {
+		t.Fatalf("Expected a ValidationError, got %v", err)
+	}
+	if _, ok := verr.Field(FieldExpiration); !ok {
+		t.Errorf("Expected the expiration to be invalid: %v", verr)
+	}
+}
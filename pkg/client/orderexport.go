@@ -0,0 +1,193 @@
+package client
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math/big"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/crypto"
+
+	"github.com/MaDal776/polymarket-go-client/pkg/types"
+	"github.com/MaDal776/polymarket-go-client/pkg/utils"
+)
+
+// ErrBadOrderSignature is returned when an imported order's signature does not
+// match its fields and signer
+var ErrBadOrderSignature = errors.New("order signature does not match")
+
+// ExportOrder packages a signed order for posting later with PostExportedOrder,
+// e.g. on a host that holds API credentials but not the private key. The order
+// type is checked now so a bad combination fails on the signing side.
+func (c *ClobClient) ExportOrder(signedOrder *types.SignedOrder, orderType types.OrderType) (*types.ExportedOrder, error) {
+	if signedOrder == nil || signedOrder.Signature == "" {
+		return nil, fmt.Errorf("order is not signed")
+	}
+	if err := validateOrderType(signedOrder, orderType, c.clock.Now()); err != nil {
+		return nil, err
+	}
+	negRisk, err := c.GetNegRisk(signedOrder.TokenID)
+	if err != nil {
+		return nil, err
+	}
+	config, exists := GetContractConfig(c.chainID, negRisk)
+	if !exists {
+		return nil, fmt.Errorf("%w: %d", ErrUnsupportedChainID, c.chainID)
+	}
+
+	return &types.ExportedOrder{
+		Version:   types.ExportedOrderVersion,
+		ChainID:   c.chainID,
+		Exchange:  config.Exchange,
+		NegRisk:   negRisk,
+		OrderType: orderType,
+		CreatedAt: time.Now().Unix(),
+		Flags:     signedOrder.OrderFlags,
+		Order:     *signedOrder,
+	}, nil
+}
+
+// ImportOrder decodes an exported order and verifies it was signed by its signer
+// for this client's chain and exchange
+func (c *ClobClient) ImportOrder(data []byte) (*types.ExportedOrder, error) {
+	exported, err := types.ParseExportedOrder(data)
+	if err != nil {
+		return nil, err
+	}
+	if exported.ChainID != c.chainID {
+		return nil, fmt.Errorf("order was signed for chain ID %d, client uses %d", exported.ChainID, c.chainID)
+	}
+	config, exists := GetContractConfig(c.chainID, exported.NegRisk)
+	if !exists {
+		return nil, fmt.Errorf("%w: %d", ErrUnsupportedChainID, c.chainID)
+	}
+	if !strings.EqualFold(config.Exchange, exported.Exchange) {
+		return nil, fmt.Errorf("order was signed for exchange %s, expected %s", exported.Exchange, config.Exchange)
+	}
+	if err := VerifyOrderSignature(&exported.Order, exported.Exchange, exported.ChainID); err != nil {
+		return nil, err
+	}
+	return exported, nil
+}
+
+// PostExportedOrder posts an exported order with the order type it was exported with
+func (c *ClobClient) PostExportedOrder(exported *types.ExportedOrder) (map[string]interface{}, error) {
+	if exported == nil {
+		return nil, fmt.Errorf("exported order is required")
+	}
+	if exported.ChainID != c.chainID {
+		return nil, fmt.Errorf("order was signed for chain ID %d, client uses %d", exported.ChainID, c.chainID)
+	}
+	signedOrder := exported.Order
+	signedOrder.OrderFlags = exported.Flags
+	return c.PostOrder(&signedOrder, exported.OrderType)
+}
+
+// WriteOrderFile saves an exported order as indented JSON, readable only by the owner
+func WriteOrderFile(path string, exported *types.ExportedOrder) error {
+	data, err := json.MarshalIndent(exported, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to encode exported order: %w", err)
+	}
+	if err := os.WriteFile(path, append(data, '\n'), 0600); err != nil {
+		return fmt.Errorf("failed to write order file: %w", err)
+	}
+	return nil
+}
+
+// ReadOrderFile loads an exported order saved by WriteOrderFile and verifies it with ImportOrder
+func (c *ClobClient) ReadOrderFile(path string) (*types.ExportedOrder, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read order file: %w", err)
+	}
+	return c.ImportOrder(data)
+}
+
+// OrderHash returns a signed order's EIP-712 hash for the given exchange and
+// chain, which is also its CLOB order ID
+func OrderHash(order *types.SignedOrder, exchange string, chainID int64) (string, error) {
+	hash, err := orderHash(order, exchange, chainID)
+	if err != nil {
+		return "", err
+	}
+	return hexutil.Encode(hash), nil
+}
+
+// OrderID returns the ID the exchange gives a signed order, its order hash for
+// the exchange contract of the order's market on this client's chain. It is
+// known before the order is posted.
+func (c *ClobClient) OrderID(signedOrder *types.SignedOrder) (string, error) {
+	negRisk, err := c.GetNegRisk(signedOrder.TokenID)
+	if err != nil {
+		return "", err
+	}
+	config, exists := GetContractConfig(c.chainID, negRisk)
+	if !exists {
+		return "", fmt.Errorf("%w: %d", ErrUnsupportedChainID, c.chainID)
+	}
+	return OrderHash(signedOrder, config.Exchange, c.chainID)
+}
+
+// orderHash computes the EIP-712 hash an order's signature was made over
+func orderHash(order *types.SignedOrder, exchange string, chainID int64) ([]byte, error) {
+	makerAmount, ok := new(big.Int).SetString(order.MakerAmount, 10)
+	if !ok {
+		return nil, fmt.Errorf("invalid maker amount %q", order.MakerAmount)
+	}
+	takerAmount, ok := new(big.Int).SetString(order.TakerAmount, 10)
+	if !ok {
+		return nil, fmt.Errorf("invalid taker amount %q", order.TakerAmount)
+	}
+	side := 0
+	if order.Side == types.SELL {
+		side = 1
+	}
+	orderData := types.OrderData{
+		Maker:         order.Maker,
+		Taker:         order.Taker,
+		TokenID:       order.TokenID,
+		MakerAmount:   makerAmount,
+		TakerAmount:   takerAmount,
+		Side:          side,
+		FeeRateBps:    order.FeeRateBps,
+		Nonce:         order.Nonce,
+		Signer:        order.Signer,
+		Expiration:    order.Expiration,
+		SignatureType: order.SignatureType,
+	}
+	return utils.CreateOrderEIP712Hash(orderData, order.Salt, exchange, chainID), nil
+}
+
+// VerifyOrderSignature checks that a signed order's signature was made by its
+// signer over its fields, for the given exchange and chain
+func VerifyOrderSignature(order *types.SignedOrder, exchange string, chainID int64) error {
+	hash, err := orderHash(order, exchange, chainID)
+	if err != nil {
+		return err
+	}
+
+	signature, err := hexutil.Decode(order.Signature)
+	if err != nil || len(signature) != 65 {
+		return fmt.Errorf("%w: malformed signature", ErrBadOrderSignature)
+	}
+	// Signatures carry v as 27/28; recovery expects 0/1
+	signature = append([]byte(nil), signature...)
+	if signature[64] >= 27 {
+		signature[64] -= 27
+	}
+	pub, err := crypto.SigToPub(hash, signature)
+	if err != nil {
+		return fmt.Errorf("%w: %v", ErrBadOrderSignature, err)
+	}
+	if !bytes.Equal(crypto.PubkeyToAddress(*pub).Bytes(), common.HexToAddress(order.Signer).Bytes()) {
+		return fmt.Errorf("%w: signed by %s, not %s", ErrBadOrderSignature, crypto.PubkeyToAddress(*pub).Hex(), order.Signer)
+	}
+	return nil
+}
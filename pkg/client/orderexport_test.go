@@ -0,0 +1,51 @@
+package client
+
+import (
+	"encoding/json"
+	"errors"
+	"path/filepath"
+	"testing"
+
+	"github.com/MaDal776/polymarket-go-client/pkg/types"
+)
+
+func TestExportImportOrder(t *testing.T) {
+	client, err := NewClobClient(testHost, testChainID, testPrivateKey, nil, nil, nil)
+	if err != nil {
+		t.Fatalf("Failed to create client: %v", err)
+	}
+	client.PrimeNegRisk(testTokenID, false)
+
+	orderArgs := types.OrderArgs{TokenID: testTokenID, Price: 0.55, Size: 10, Side: types.BUY}
+	signedOrder, err := client.CreateOrder(orderArgs, &types.CreateOrderOptions{TickSize: types.TickSize001, AllowCross: true})
+	if err != nil {
+		t.Fatalf("Failed to create order: %v", err)
+	}
+	signedOrder.Owner = "other-key"
+
+	exported, err := client.ExportOrder(signedOrder, types.GTC)
+	if err != nil {
+		t.Fatalf("ExportOrder failed: %v", err)
+	}
+	path := filepath.Join(t.TempDir(), "order.json")
+	if err := WriteOrderFile(path, exported); err != nil {
+		t.Fatalf("WriteOrderFile failed: %v", err)
+	}
+	imported, err := client.ReadOrderFile(path)
+	if err != nil {
+		t.Fatalf("ReadOrderFile failed: %v", err)
+	}
+	if imported.Order.Signature != signedOrder.Signature || imported.Order.Salt != signedOrder.Salt || imported.OrderType != types.GTC {
+		t.Errorf("Round trip changed the order: %+v", imported)
+	}
+	if !imported.Flags.AllowCross || imported.Flags.Owner != "other-key" {
+		t.Errorf("Round trip lost the client-side flags: %+v", imported.Flags)
+	}
+
+	// A changed field no longer matches the signature
+	exported.Order.MakerAmount = "6000000"
+	data, _ := json.Marshal(exported)
+	if _, err := client.ImportOrder(data); !errors.Is(err, ErrBadOrderSignature) {
+		t.Errorf("Expected ErrBadOrderSignature, got %v", err)
+	}
+}
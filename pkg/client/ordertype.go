@@ -0,0 +1,116 @@
+package client
+
+import (
+	"math/big"
+	"strconv"
+	"time"
+
+	"github.com/MaDal776/polymarket-go-client/pkg/types"
+	"github.com/MaDal776/polymarket-go-client/pkg/utils"
+)
+
+// MinGTDLifetime is how far in the future a GTD expiration must be; the exchange
+// rejects anything sooner
+const MinGTDLifetime = time.Minute
+
+// makerAmountUnit is the precision of a FOK/FAK maker amount in token units
+// (6 decimals): the exchange accepts at most 2 decimals
+var makerAmountUnit = big.NewInt(10000)
+
+// SetFOKFillCheck makes PostOrder fetch the book before posting a FOK order and
+// reject it locally when the book cannot fill it in full at its limit price
+func (c *ClobClient) SetFOKFillCheck(enabled bool) {
+	c.fokFillCheck = enabled
+}
+
+// ValidateOrderType checks that a signed order is consistent with the order type it
+// is posted as. It returns a *ValidationError matching ErrInvalidOrder.
+//
+//   - GTD orders need an expiration at least MinGTDLifetime away on the client's
+//     clock, the one PostOrder checks against; other types need none.
+//   - FOK and FAK orders are market orders: their maker amount (USDC for buys,
+//     shares for sells) allows at most 2 decimals.
+func (c *ClobClient) ValidateOrderType(signedOrder *types.SignedOrder, orderType types.OrderType) error {
+	return validateOrderType(signedOrder, orderType, c.clock.Now())
+}
+
+// validateOrderType is ValidateOrderType with GTD expirations checked against now
+func validateOrderType(signedOrder *types.SignedOrder, orderType types.OrderType, now time.Time) error {
+	verr := &ValidationError{}
+
+	switch orderType {
+	case types.GTC, types.GTD, types.FOK, types.FAK:
+	default:
+		verr.add(FieldOrderType, string(orderType), "must be one of GTC, GTD, FOK or FAK")
+		return verr
+	}
+
+	expiration, err := strconv.ParseInt(signedOrder.Expiration, 10, 64)
+	if signedOrder.Expiration == "" {
+		expiration, err = 0, nil
+	}
+	switch {
+	case err != nil:
+		verr.add(FieldExpiration, signedOrder.Expiration, "not a Unix timestamp")
+	case orderType == types.GTD && expiration == 0:
+		verr.add(FieldExpiration, signedOrder.Expiration, "required for GTD orders")
+	case orderType == types.GTD && time.Unix(expiration, 0).Before(now.Add(MinGTDLifetime)):
+		verr.add(FieldExpiration, signedOrder.Expiration, "must be at least %s in the future", MinGTDLifetime)
+	case orderType != types.GTD && expiration != 0:
+		verr.add(FieldExpiration, signedOrder.Expiration, "only GTD orders expire; use 0 for %s", orderType)
+	}
+
+	// Market orders are sized by their maker amount: USDC for buys, shares for sells
+	if (orderType == types.FOK || orderType == types.FAK) && !multipleOf(signedOrder.MakerAmount, makerAmountUnit) {
+		verr.add(FieldAmount, signedOrder.MakerAmount, "%s maker amount allows at most 2 decimals", orderType)
+	}
+	return verr.orNil()
+}
+
+// checkFOKFill rejects a FOK order the current book cannot fill in full
+func (c *ClobClient) checkFOKFill(signedOrder *types.SignedOrder) error {
+	price, size, err := utils.SignedOrderPriceAndSize(signedOrder)
+	if err != nil {
+		return err
+	}
+	book, err := c.GetOrderBook(signedOrder.TokenID)
+	if err != nil {
+		return err
+	}
+	bids, asks, err := book.Levels()
+	if err != nil {
+		return err
+	}
+
+	available := 0.0
+	if signedOrder.Side == types.BUY {
+		for _, level := range asks {
+			if level.Price > price+1e-9 {
+				break
+			}
+			available += level.Size
+		}
+	} else {
+		for _, level := range bids {
+			if level.Price < price-1e-9 {
+				break
+			}
+			available += level.Size
+		}
+	}
+	if available+1e-9 < size {
+		verr := &ValidationError{}
+		verr.add(FieldPrice, formatFloat(price), "FOK for %s shares but only %s available at this price", formatFloat(size), formatFloat(available))
+		return verr
+	}
+	return nil
+}
+
+// multipleOf reports whether a decimal integer string is a multiple of unit
+func multipleOf(amount string, unit *big.Int) bool {
+	value, ok := new(big.Int).SetString(amount, 10)
+	if !ok {
+		return false
+	}
+	return new(big.Int).Mod(value, unit).Sign() == 0
+}
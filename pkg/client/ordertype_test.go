@@ -0,0 +1,88 @@
+package client
+
+import (
+	"errors"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/MaDal776/polymarket-go-client/pkg/types"
+)
+
+func TestValidateOrderType(t *testing.T) {
+	c, err := NewClobClient(testHost, testChainID, "", nil, nil, nil)
+	if err != nil {
+		t.Fatalf("Failed to create client: %v", err)
+	}
+	future := strconv.FormatInt(time.Now().Add(time.Hour).Unix(), 10)
+	soon := strconv.FormatInt(time.Now().Add(10*time.Second).Unix(), 10)
+
+	limit := types.SignedOrder{Side: types.BUY, MakerAmount: "5555000", TakerAmount: "10100000", Expiration: "0"}
+	marketBuy := types.SignedOrder{Side: types.BUY, MakerAmount: "5000000", TakerAmount: "9090900", Expiration: "0"}
+	marketSell := types.SignedOrder{Side: types.SELL, MakerAmount: "10000000", TakerAmount: "5555500", Expiration: "0"}
+	gtd := limit
+	gtd.Expiration = future
+
+	valid := []struct {
+		order     types.SignedOrder
+		orderType types.OrderType
+	}{
+		{limit, types.GTC},
+		{gtd, types.GTD},
+		{marketBuy, types.FOK},
+		{marketSell, types.FAK},
+	}
+	for _, tc := range valid {
+		order := tc.order
+		if err := c.ValidateOrderType(&order, tc.orderType); err != nil {
+			t.Errorf("Expected %s order %+v to be valid, got %v", tc.orderType, order, err)
+		}
+	}
+
+	staleGTD := limit
+	staleGTD.Expiration = soon
+	expiringGTC := gtd
+	oddSell := marketSell
+	oddSell.MakerAmount = "10005000"
+
+	invalid := []struct {
+		order     types.SignedOrder
+		orderType types.OrderType
+		field     string
+	}{
+		{limit, types.OrderType("IOC"), FieldOrderType},
+		{limit, types.GTD, FieldExpiration},
+		{staleGTD, types.GTD, FieldExpiration},
+		{expiringGTC, types.GTC, FieldExpiration},
+		{limit, types.FOK, FieldAmount},
+		{oddSell, types.FAK, FieldAmount},
+	}
+	for _, tc := range invalid {
+		order := tc.order
+		err := c.ValidateOrderType(&order, tc.orderType)
+		var verr *ValidationError
+		if !errors.As(err, &verr) {
+			t.Fatalf("Expected a ValidationError for %s order %+v, got %v", tc.orderType, order, err)
+		}
+		if _, ok := verr.Field(tc.field); !ok {
+			t.Errorf("Expected %s to be reported for %s order, got %v", tc.field, tc.orderType, verr)
+		}
+	}
+}
+
+func TestPostOrderRejectsInvalidOrderType(t *testing.T) {
+	creds := &types.ApiCreds{ApiKey: "key", ApiSecret: "c2VjcmV0", ApiPassphrase: "pass"}
+	client, err := NewClobClient(testHost, testChainID, testPrivateKey, creds, nil, nil)
+	if err != nil {
+		t.Fatalf("Failed to create client: %v", err)
+	}
+
+	order := &types.SignedOrder{Side: types.BUY, MakerAmount: "5000000", TakerAmount: "10000000", Expiration: "0"}
+	_, err = client.PostOrder(order, types.GTD)
+	if !errors.Is(err, ErrInvalidOrder) {
+		t.Fatalf("Expected ErrInvalidOrder, got %v", err)
+	}
+	if _, err := client.PrepareOrder(order, types.GTD); !errors.Is(err, ErrInvalidOrder) {
+		t.Errorf("Expected PrepareOrder to reject the order, got %v", err)
+	}
+}
@@ -0,0 +1,119 @@
+package client
+
+import (
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/MaDal776/polymarket-go-client/pkg/types"
+	"github.com/MaDal776/polymarket-go-client/pkg/utils"
+)
+
+// Outcomes of a binary market
+const (
+	OutcomeYes = "Yes"
+	OutcomeNo  = "No"
+)
+
+// OutcomeTokenID returns the token ID of the Yes or No outcome of a binary market.
+// Markets whose outcomes carry other labels (e.g. team names) map Yes to the
+// first token and No to the second, following the CLOB's ordering.
+func OutcomeTokenID(market *types.Market, outcome string) (string, error) {
+	if token, ok := market.TokenByOutcome(outcome); ok {
+		return token.TokenID, nil
+	}
+	if len(market.Tokens) != 2 {
+		return "", fmt.Errorf("market %s is not binary: %d outcomes", market.ConditionID, len(market.Tokens))
+	}
+
+	switch outcome {
+	case OutcomeYes:
+		return market.Tokens[0].TokenID, nil
+	case OutcomeNo:
+		return market.Tokens[1].TokenID, nil
+	}
+	return "", fmt.Errorf("market %s has no outcome %q", market.ConditionID, outcome)
+}
+
+// PlaceOutcomeOrder creates and posts a GTC limit order on an outcome of a market.
+// The market's tick size and neg risk flag are cached, so no lookups are needed
+// unless options override them.
+func (c *ClobClient) PlaceOutcomeOrder(market *types.Market, outcome string, side types.OrderSide, price, size float64, options *types.CreateOrderOptions) (map[string]interface{}, error) {
+	start := time.Now()
+
+	tokenID, err := OutcomeTokenID(market, outcome)
+	if err != nil {
+		c.recordMetric("outcome_order", start, false, err.Error())
+		return nil, err
+	}
+	c.cacheMarket(market)
+
+	orderArgs := types.OrderArgs{
+		TokenID: tokenID,
+		Price:   price,
+		Size:    size,
+		Side:    side,
+	}
+	result, err := c.CreateAndPostOrder(orderArgs, options)
+	if err != nil {
+		c.recordMetric("outcome_order", start, false, err.Error())
+		return nil, err
+	}
+
+	c.recordMetric("outcome_order", start, true, "")
+	return result, nil
+}
+
+// PlaceOutcomeOrderByCondition looks up a market by condition ID and places an outcome order on it
+func (c *ClobClient) PlaceOutcomeOrderByCondition(conditionID string, outcome string, side types.OrderSide, price, size float64, options *types.CreateOrderOptions) (map[string]interface{}, error) {
+	market, err := c.GetMarket(conditionID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get market: %w", err)
+	}
+	return c.PlaceOutcomeOrder(market, outcome, side, price, size, options)
+}
+
+// BuyYes buys size Yes shares at price
+func (c *ClobClient) BuyYes(market *types.Market, price, size float64) (map[string]interface{}, error) {
+	return c.PlaceOutcomeOrder(market, OutcomeYes, types.BUY, price, size, nil)
+}
+
+// SellYes sells size Yes shares at price
+func (c *ClobClient) SellYes(market *types.Market, price, size float64) (map[string]interface{}, error) {
+	return c.PlaceOutcomeOrder(market, OutcomeYes, types.SELL, price, size, nil)
+}
+
+// BuyNo buys size No shares at price
+func (c *ClobClient) BuyNo(market *types.Market, price, size float64) (map[string]interface{}, error) {
+	return c.PlaceOutcomeOrder(market, OutcomeNo, types.BUY, price, size, nil)
+}
+
+// SellNo sells size No shares at price
+func (c *ClobClient) SellNo(market *types.Market, price, size float64) (map[string]interface{}, error) {
+	return c.PlaceOutcomeOrder(market, OutcomeNo, types.SELL, price, size, nil)
+}
+
+// cacheMarket stores the market's tick size and neg risk flag for each of its
+// tokens, keeping values already fetched from the token endpoints, and pairs
+// the two tokens of a binary market for GetComplement
+func (c *ClobClient) cacheMarket(market *types.Market) {
+	tickSize, err := utils.ParseTickSizeString(strconv.FormatFloat(market.MinimumTickSize, 'g', -1, 64))
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	for _, token := range market.Tokens {
+		if _, exists := c.tickSizes[token.TokenID]; !exists && err == nil {
+			c.tickSizes[token.TokenID] = tickSize
+		}
+		if _, exists := c.negRisks[token.TokenID]; !exists {
+			c.negRisks[token.TokenID] = market.NegRisk
+		}
+		if market.ConditionID != "" {
+			c.tokenMarkets[token.TokenID] = market.ConditionID
+		}
+	}
+	if len(market.Tokens) == 2 {
+		c.complements[market.Tokens[0].TokenID] = market.Tokens[1].TokenID
+		c.complements[market.Tokens[1].TokenID] = market.Tokens[0].TokenID
+	}
+}
@@ -0,0 +1,83 @@
+package client
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/MaDal776/polymarket-go-client/pkg/types"
+)
+
+func TestOutcomeTokenID(t *testing.T) {
+	labelled := &types.Market{Tokens: []types.MarketToken{{TokenID: "no", Outcome: "No"}, {TokenID: "yes", Outcome: "Yes"}}}
+	teams := &types.Market{Tokens: []types.MarketToken{{TokenID: "a", Outcome: "Lakers"}, {TokenID: "b", Outcome: "Celtics"}}}
+	multi := &types.Market{Tokens: []types.MarketToken{{TokenID: "1"}, {TokenID: "2"}, {TokenID: "3"}}}
+
+	cases := []struct {
+		market  *types.Market
+		outcome string
+		want    string
+	}{
+		{labelled, OutcomeYes, "yes"},
+		{labelled, OutcomeNo, "no"},
+		{teams, OutcomeYes, "a"},
+		{teams, OutcomeNo, "b"},
+		{teams, "celtics", "b"},
+	}
+	for _, c := range cases {
+		got, err := OutcomeTokenID(c.market, c.outcome)
+		if err != nil || got != c.want {
+			t.Errorf("OutcomeTokenID(%s) = %q, %v, want %q", c.outcome, got, err, c.want)
+		}
+	}
+
+	if _, err := OutcomeTokenID(multi, OutcomeYes); err == nil {
+		t.Error("Expected non-binary market to fail")
+	}
+	if _, err := OutcomeTokenID(teams, "Draw"); err == nil {
+		t.Error("Expected unknown outcome to fail")
+	}
+}
+
+func TestBuyNoUsesMarketMetadata(t *testing.T) {
+	var posted map[string]interface{}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != PostOrder {
+			t.Errorf("Unexpected request to %s", r.URL.Path)
+			http.NotFound(w, r)
+			return
+		}
+		body, _ := io.ReadAll(r.Body)
+		json.Unmarshal(body, &posted)
+		w.Write([]byte(`{"success":true,"orderID":"0xabc"}`))
+	}))
+	defer server.Close()
+
+	secret := base64.URLEncoding.EncodeToString([]byte("0123456789abcdef0123456789abcdef"))
+	creds := &types.ApiCreds{ApiKey: "key", ApiSecret: secret, ApiPassphrase: "pass"}
+	c, err := NewClobClient(server.URL, 137, testPrivateKey, creds, nil, nil)
+	if err != nil {
+		t.Fatalf("Failed to create client: %v", err)
+	}
+
+	market := &types.Market{
+		ConditionID:     "0x1",
+		MinimumTickSize: 0.01,
+		NegRisk:         true,
+		Tokens:          []types.MarketToken{{TokenID: "111", Outcome: "Yes"}, {TokenID: "222", Outcome: "No"}},
+	}
+	if _, err := c.BuyNo(market, 0.4, 10); err != nil {
+		t.Fatalf("BuyNo failed: %v", err)
+	}
+
+	order, _ := posted["order"].(map[string]interface{})
+	if order["tokenId"] != "222" || order["side"] != string(types.BUY) {
+		t.Errorf("Unexpected order posted: %v", order)
+	}
+	if negRisk, _ := c.GetNegRisk("222"); !negRisk {
+		t.Error("Expected neg risk to be taken from the market")
+	}
+}
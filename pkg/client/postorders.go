@@ -0,0 +1,133 @@
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/MaDal776/polymarket-go-client/pkg/types"
+)
+
+// MaxBatchOrders is the most orders the exchange accepts in one batch post
+const MaxBatchOrders = 15
+
+// PostOrders posts up to MaxBatchOrders signed orders in one request and returns
+// the exchange's result for each, in order. Every order is checked against its
+// type and, when SetMidpointCheck is on, the midpoint first; the market, FOK
+// fill and cross checks of PostOrder are not run.
+// An order the exchange rejects does not fail the batch: its result carries the
+// error message.
+func (c *ClobClient) PostOrders(orders []types.PostOrdersArgs) ([]map[string]interface{}, error) {
+	start := time.Now()
+
+	if err := c.requireAuth(types.L2); err != nil {
+		c.recordMetric("batch_order_posting", start, false, "insufficient auth level")
+		return nil, err
+	}
+	if len(orders) == 0 || len(orders) > MaxBatchOrders {
+		c.recordMetric("batch_order_posting", start, false, "invalid batch size")
+		return nil, fmt.Errorf("a batch holds 1 to %d orders, got %d", MaxBatchOrders, len(orders))
+	}
+
+	if c.midpointCheck != MidpointCheckOff {
+		if err := c.checkMidpoints(orders); err != nil {
+			c.recordMetric("batch_order_posting", start, false, "midpoint check")
+			return nil, err
+		}
+	}
+
+	// Encode each order as PostOrder would
+	requests := make([]json.RawMessage, 0, len(orders))
+	for i, order := range orders {
+		encoded, err := c.encodeBatchOrder(order)
+		if err != nil {
+			c.recordMetric("batch_order_posting", start, false, err.Error())
+			return nil, fmt.Errorf("order %d: %w", i, err)
+		}
+		requests = append(requests, encoded)
+	}
+	body, headers, err := c.orderBatchRequest(requests)
+	if err != nil {
+		c.recordMetric("batch_order_posting", start, false, err.Error())
+		return nil, err
+	}
+
+	// Refuse orders already posted when dedupe is enabled
+	signed := make([]*types.SignedOrder, len(orders))
+	for i, order := range orders {
+		signed[i] = order.Order
+	}
+	if err := c.claimOrders(signed...); err != nil {
+		c.recordMetric("batch_order_posting", start, false, "duplicate order")
+		return nil, err
+	}
+
+	results, err := c.postOrderBatch(context.Background(), body, headers)
+	if err != nil {
+		c.releaseRejected(err, signed...)
+		c.recordMetric("batch_order_posting", start, false, err.Error())
+		return nil, err
+	}
+	for i, result := range results {
+		if i < len(signed) && orderRejection(result) != nil {
+			c.releaseOrders(signed[i])
+		}
+	}
+
+	c.recordMetric("batch_order_posting", start, true, "")
+	return results, nil
+}
+
+// encodeBatchOrder checks a batch order against its type and encodes it as
+// PostOrder would
+func (c *ClobClient) encodeBatchOrder(order types.PostOrdersArgs) (json.RawMessage, error) {
+	if order.Order == nil {
+		return nil, fmt.Errorf("order is missing")
+	}
+	if err := validateOrderType(order.Order, order.OrderType, c.clock.Now()); err != nil {
+		return nil, err
+	}
+	orderRequest := types.OrderRequest{
+		Order:     *order.Order,
+		Owner:     c.orderOwner(order.Order),
+		OrderType: order.OrderType,
+	}
+	encoded, err := orderRequest.MarshalWire(c.orderEncoding)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode order: %w", err)
+	}
+	return encoded, nil
+}
+
+// orderBatchRequest builds the body and L2 headers of a batch post
+func (c *ClobClient) orderBatchRequest(requests []json.RawMessage) (json.RawMessage, map[string]string, error) {
+	body, err := json.Marshal(requests)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to encode orders: %w", err)
+	}
+	requestArgs := types.RequestArgs{
+		Method:      "POST",
+		RequestPath: PostOrders,
+		Body:        json.RawMessage(body),
+	}
+	headers, err := c.headerBuilder.CreateLevel2Headers(c.creds, requestArgs)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to create headers: %w", err)
+	}
+	return body, headers, nil
+}
+
+// postOrderBatch sends a batch built by orderBatchRequest and decodes the
+// per-order results
+func (c *ClobClient) postOrderBatch(ctx context.Context, body json.RawMessage, headers map[string]string) ([]map[string]interface{}, error) {
+	resp, err := c.makeRequestContext(ctx, "POST", c.host+PostOrders, headers, body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to post orders: %w", err)
+	}
+	var results []map[string]interface{}
+	if err := json.Unmarshal(resp, &results); err != nil {
+		return nil, fmt.Errorf("failed to parse response: %w", err)
+	}
+	return results, nil
+}
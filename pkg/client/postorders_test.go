@@ -0,0 +1,69 @@
+package client
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/MaDal776/polymarket-go-client/pkg/types"
+)
+
+func TestPostOrders(t *testing.T) {
+	secret := base64.URLEncoding.EncodeToString([]byte("0123456789abcdef0123456789abcdef"))
+	creds := &types.ApiCreds{ApiKey: "key", ApiSecret: secret, ApiPassphrase: "pass"}
+
+	var posted []map[string]interface{}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != "POST" || r.URL.Path != PostOrders {
+			t.Errorf("Unexpected request %s %s", r.Method, r.URL.Path)
+		}
+		body, _ := io.ReadAll(r.Body)
+		if err := json.Unmarshal(body, &posted); err != nil {
+			t.Errorf("Body is not a list of orders: %s", body)
+		}
+		w.Write([]byte(`[{"success":true,"orderID":"0x1"},{"success":false,"errorMsg":"not enough balance"}]`))
+	}))
+	defer server.Close()
+
+	c, err := NewClobClient(server.URL, 137, testPrivateKey, creds, nil, nil)
+	if err != nil {
+		t.Fatalf("Failed to create client: %v", err)
+	}
+	orders := []types.PostOrdersArgs{
+		{Order: &types.SignedOrder{Salt: 1, TokenID: "1", Side: types.BUY, Signature: "0x00"}, OrderType: types.GTC},
+		{Order: &types.SignedOrder{Salt: 2, TokenID: "2", Side: types.BUY, Signature: "0x00"}, OrderType: types.GTC},
+	}
+
+	results, err := c.PostOrders(orders)
+	if err != nil {
+		t.Fatalf("PostOrders failed: %v", err)
+	}
+	if len(posted) != 2 || posted[0]["owner"] != "key" || posted[1]["orderType"] != "GTC" {
+		t.Errorf("Unexpected posted orders %v", posted)
+	}
+	if len(results) != 2 || results[0]["orderID"] != "0x1" || results[1]["errorMsg"] != "not enough balance" {
+		t.Errorf("Unexpected results %v", results)
+	}
+
+	// Owners other than the client's API key
+	c.SetOrderDefaults(OrderDefaults{Owner: "shared-key"})
+	orders[1].Order.Owner = "other-key"
+	if _, err := c.PostOrders(orders); err != nil {
+		t.Fatalf("PostOrders failed: %v", err)
+	}
+	if posted[0]["owner"] != "shared-key" || posted[1]["owner"] != "other-key" {
+		t.Errorf("Expected the default and per-order owners, got %v and %v", posted[0]["owner"], posted[1]["owner"])
+	}
+
+	orders[1].Order.Expiration = "1700000000"
+	if _, err := c.PostOrders(orders); !errors.Is(err, ErrInvalidOrder) {
+		t.Errorf("Expected an invalid order to fail the batch, got %v", err)
+	}
+	if _, err := c.PostOrders(make([]types.PostOrdersArgs, MaxBatchOrders+1)); err == nil {
+		t.Errorf("Expected an oversized batch to be rejected")
+	}
+}
@@ -0,0 +1,231 @@
+package client
+
+import (
+	"context"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// rateLimiter is a token bucket shared by every request the client makes
+type rateLimiter struct {
+	mu       sync.Mutex
+	interval time.Duration // Time to earn one token
+	burst    float64
+	tokens   float64
+	last     time.Time
+}
+
+// newRateLimiter creates a limiter allowing perSecond requests with the given burst
+func newRateLimiter(perSecond float64, burst int) *rateLimiter {
+	if burst < 1 {
+		burst = 1
+	}
+	return &rateLimiter{
+		interval: time.Duration(float64(time.Second) / perSecond),
+		burst:    float64(burst),
+		tokens:   float64(burst),
+		last:     time.Now(),
+	}
+}
+
+// Wait blocks until a token is available and takes it
+func (l *rateLimiter) Wait() {
+	l.mu.Lock()
+	now := time.Now()
+	l.tokens += float64(now.Sub(l.last)) / float64(l.interval)
+	if l.tokens > l.burst {
+		l.tokens = l.burst
+	}
+	l.last = now
+
+	// Take the token now; a negative balance is the caller's place in the queue
+	l.tokens--
+	var delay time.Duration
+	if l.tokens < 0 {
+		delay = time.Duration(-l.tokens * float64(l.interval))
+	}
+	l.mu.Unlock()
+
+	if delay > 0 {
+		time.Sleep(delay)
+	}
+}
+
+// SetRateLimit limits the client to perSecond requests with bursts of up to burst.
+// A non-positive perSecond removes the limit.
+// It is safe to call while requests are in flight.
+func (c *ClobClient) SetRateLimit(perSecond float64, burst int) {
+	if perSecond <= 0 {
+		c.limiter.Store(nil)
+		return
+	}
+	c.limiter.Store(newRateLimiter(perSecond, burst))
+}
+
+// waitRateLimit waits for the client-side rate limit, if one is set
+func (c *ClobClient) waitRateLimit() {
+	if limiter := c.limiter.Load(); limiter != nil {
+		limiter.Wait()
+	}
+}
+
+// RateLimitStatus is the request budget of an endpoint last reported by the server in rate limit headers
+type RateLimitStatus struct {
+	Endpoint  string    `json:"endpoint"`  // Method and path the budget applies to, e.g. "GET /book"
+	Limit     int       `json:"limit"`     // Requests allowed per window; 0 when not reported
+	Remaining int       `json:"remaining"` // Requests left in the window, less those sent since the report
+	Reset     time.Time `json:"reset"`     // When the window resets; zero when not reported
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+// Rate limit header prefixes: the common X-RateLimit-* and the IETF draft RateLimit-*
+var rateLimitHeaderPrefixes = []string{"X-Ratelimit-", "Ratelimit-"}
+
+// serverBudget tracks the budgets the server reports for each endpoint so
+// requests wait for their window to reset instead of running into 429s
+type serverBudget struct {
+	mu      sync.Mutex
+	budgets map[string]*RateLimitStatus // Endpoint -> budget
+	last    string                      // Endpoint of the latest report
+}
+
+// RateLimit returns the budget most recently reported by the server, for
+// whichever endpoint reported it, and false when no response has carried rate
+// limit headers
+func (c *ClobClient) RateLimit() (RateLimitStatus, bool) {
+	c.budget.mu.Lock()
+	defer c.budget.mu.Unlock()
+	status, known := c.budget.budgets[c.budget.last]
+	if !known {
+		return RateLimitStatus{}, false
+	}
+	return *status, true
+}
+
+// RateLimitFor returns the last budget reported for requests with method to
+// path, e.g. ("GET", GetOrderBook), and false when none has been reported
+func (c *ClobClient) RateLimitFor(method, path string) (RateLimitStatus, bool) {
+	c.budget.mu.Lock()
+	defer c.budget.mu.Unlock()
+	status, known := c.budget.budgets[c.budgetEndpoint(method, path)]
+	if !known {
+		return RateLimitStatus{}, false
+	}
+	return *status, true
+}
+
+// budgetEndpoint names the endpoint whose budget a request counts against: its
+// method and path, without query or order and market IDs
+func (c *ClobClient) budgetEndpoint(method, url string) string {
+	path := strings.TrimPrefix(url, c.host)
+	if i := strings.IndexByte(path, '?'); i >= 0 {
+		path = path[:i]
+	}
+	for _, prefix := range []string{GetOrder, GetMarket} {
+		if strings.HasPrefix(path, prefix) {
+			path = prefix
+			break
+		}
+	}
+	return method + " " + path
+}
+
+// isCancel reports whether requests to endpoint cancel orders
+func isCancel(endpoint string) bool {
+	return endpoint == "DELETE "+CancelOrder || endpoint == "DELETE "+CancelOrders || endpoint == "DELETE "+CancelAll
+}
+
+// waitForBudget takes one request from the known budget of the request's
+// endpoint, first waiting for the window to reset when it is exhausted. Cancels
+// never wait: a late cancel costs more than a 429.
+func (c *ClobClient) waitForBudget(ctx context.Context, method, url string) error {
+	endpoint := c.budgetEndpoint(method, url)
+
+	c.budget.mu.Lock()
+	var delay time.Duration
+	if status, known := c.budget.budgets[endpoint]; known {
+		now := time.Now()
+		if status.Remaining <= 0 && now.Before(status.Reset) {
+			delay = status.Reset.Sub(now)
+		} else if status.Remaining <= 0 {
+			// The window has reset; the next response reports the new budget
+			delete(c.budget.budgets, endpoint)
+		} else {
+			status.Remaining--
+		}
+	}
+	c.budget.mu.Unlock()
+
+	if delay <= 0 || isCancel(endpoint) {
+		return nil
+	}
+	timer := time.NewTimer(delay)
+	defer timer.Stop()
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
+
+// observeRateLimit records the budget reported by a response to a request with method to url
+func (c *ClobClient) observeRateLimit(method, url string, header http.Header, statusCode int) {
+	now := time.Now()
+	status, ok := parseRateLimit(header, now)
+	if statusCode == http.StatusTooManyRequests {
+		// Out of budget whether or not the response says so
+		status.Remaining = 0
+		if retryAfter, err := strconv.Atoi(header.Get("Retry-After")); err == nil {
+			status.Reset = now.Add(time.Duration(retryAfter) * time.Second)
+		} else if status.Reset.IsZero() {
+			status.Reset = now.Add(time.Second)
+		}
+		ok = true
+	}
+	if !ok {
+		return
+	}
+
+	status.Endpoint = c.budgetEndpoint(method, url)
+	c.budget.mu.Lock()
+	defer c.budget.mu.Unlock()
+	if c.budget.budgets == nil {
+		c.budget.budgets = make(map[string]*RateLimitStatus)
+	}
+	c.budget.budgets[status.Endpoint] = &status
+	c.budget.last = status.Endpoint
+}
+
+// parseRateLimit reads rate limit headers; ok is false when the remaining count is absent
+func parseRateLimit(header http.Header, now time.Time) (status RateLimitStatus, ok bool) {
+	for _, prefix := range rateLimitHeaderPrefixes {
+		remaining, err := strconv.Atoi(header.Get(prefix + "Remaining"))
+		if err != nil {
+			continue
+		}
+		status = RateLimitStatus{Remaining: remaining, UpdatedAt: now}
+		status.Limit, _ = strconv.Atoi(header.Get(prefix + "Limit"))
+		if reset, err := strconv.ParseFloat(header.Get(prefix+"Reset"), 64); err == nil {
+			status.Reset = resetTime(reset, now)
+		}
+		return status, true
+	}
+	return RateLimitStatus{}, false
+}
+
+// resetTime interprets a reset header, which servers send either as seconds
+// until the reset or as a Unix timestamp in seconds or milliseconds
+func resetTime(value float64, now time.Time) time.Time {
+	switch {
+	case value > 1e12:
+		return time.UnixMilli(int64(value))
+	case value > 1e9:
+		return time.Unix(int64(value), 0)
+	default:
+		return now.Add(time.Duration(value * float64(time.Second)))
+	}
+}
@@ -0,0 +1,164 @@
+package client
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestParseRateLimit(t *testing.T) {
+	now := time.Unix(1700000000, 0)
+	header := http.Header{}
+	header.Set("X-RateLimit-Limit", "100")
+	header.Set("X-RateLimit-Remaining", "42")
+	header.Set("X-RateLimit-Reset", "1700000010")
+
+	status, ok := parseRateLimit(header, now)
+	if !ok || status.Limit != 100 || status.Remaining != 42 || !status.Reset.Equal(now.Add(10*time.Second)) {
+		t.Errorf("Unexpected status %+v", status)
+	}
+
+	header = http.Header{}
+	header.Set("RateLimit-Remaining", "5")
+	header.Set("RateLimit-Reset", "2")
+	status, ok = parseRateLimit(header, now)
+	if !ok || status.Remaining != 5 || !status.Reset.Equal(now.Add(2*time.Second)) {
+		t.Errorf("Unexpected status %+v", status)
+	}
+
+	if _, ok := parseRateLimit(http.Header{}, now); ok {
+		t.Error("Expected no status without headers")
+	}
+}
+
+func TestRequestsWaitForExhaustedBudget(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("X-RateLimit-Limit", "10")
+		w.Header().Set("X-RateLimit-Remaining", "0")
+		w.Header().Set("X-RateLimit-Reset", "0.2")
+		w.Write([]byte(`{}`))
+	}))
+	defer server.Close()
+
+	c, err := NewClobClient(server.URL, testChainID, "", nil, nil, nil)
+	if err != nil {
+		t.Fatalf("Failed to create client: %v", err)
+	}
+	c.SetRateLimit(1000, 10)
+
+	if _, err := c.makeRequest("GET", server.URL+"/time", nil, nil); err != nil {
+		t.Fatalf("Request failed: %v", err)
+	}
+	status, ok := c.RateLimit()
+	if !ok || status.Limit != 10 || status.Remaining != 0 {
+		t.Fatalf("Unexpected budget %+v (%v)", status, ok)
+	}
+
+	start := time.Now()
+	if _, err := c.makeRequest("GET", server.URL+"/time", nil, nil); err != nil {
+		t.Fatalf("Request failed: %v", err)
+	}
+	if elapsed := time.Since(start); elapsed < 150*time.Millisecond {
+		t.Errorf("Request sent after %v, expected to wait for the reset", elapsed)
+	}
+}
+
+func TestTooManyRequestsExhaustsBudget(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Retry-After", "3")
+		w.WriteHeader(http.StatusTooManyRequests)
+	}))
+	defer server.Close()
+
+	c, err := NewClobClient(server.URL, testChainID, "", nil, nil, nil)
+	if err != nil {
+		t.Fatalf("Failed to create client: %v", err)
+	}
+	if _, err := c.makeRequest("GET", server.URL+"/time", nil, nil); err == nil {
+		t.Fatal("Expected an error for HTTP 429")
+	}
+
+	status, ok := c.RateLimit()
+	if !ok || status.Remaining != 0 || time.Until(status.Reset) < 2*time.Second {
+		t.Errorf("Unexpected budget %+v (%v)", status, ok)
+	}
+}
+
+func TestBudgetsAreKeptPerEndpoint(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("X-RateLimit-Remaining", "0")
+		w.Header().Set("X-RateLimit-Reset", "1")
+		w.Write([]byte(`{}`))
+	}))
+	defer server.Close()
+
+	c, err := NewClobClient(server.URL, testChainID, "", nil, nil, nil)
+	if err != nil {
+		t.Fatalf("Failed to create client: %v", err)
+	}
+	if _, err := c.makeRequest("GET", server.URL+GetOrderBook+"?token_id=1", nil, nil); err != nil {
+		t.Fatalf("Request failed: %v", err)
+	}
+	if status, ok := c.RateLimitFor("GET", GetOrderBook); !ok || status.Endpoint != "GET /book" || status.Remaining != 0 {
+		t.Fatalf("Unexpected book budget %+v (%v)", status, ok)
+	}
+	if _, ok := c.RateLimitFor("GET", Time); ok {
+		t.Fatal("Expected no budget for an endpoint that reported none")
+	}
+
+	// Neither another endpoint nor a cancel waits for the book's window
+	start := time.Now()
+	if _, err := c.makeRequest("GET", server.URL+Time, nil, nil); err != nil {
+		t.Fatalf("Request failed: %v", err)
+	}
+	for i := 0; i < 2; i++ {
+		if _, err := c.makeRequest("DELETE", server.URL+CancelOrder, nil, nil); err != nil {
+			t.Fatalf("Cancel failed: %v", err)
+		}
+	}
+	if elapsed := time.Since(start); elapsed > 500*time.Millisecond {
+		t.Errorf("Requests waited %v for another endpoint's budget", elapsed)
+	}
+
+	// Order lookups share one budget whatever the order ID
+	if _, err := c.makeRequest("GET", server.URL+GetOrder+"0xabc", nil, nil); err != nil {
+		t.Fatalf("Request failed: %v", err)
+	}
+	if status, ok := c.RateLimitFor("GET", GetOrder+"0xdef"); !ok || status.Endpoint != "GET "+GetOrder {
+		t.Errorf("Unexpected order budget %+v (%v)", status, ok)
+	}
+}
+
+func TestSetRateLimitWhileRequesting(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{}`))
+	}))
+	defer server.Close()
+
+	c, err := NewClobClient(server.URL, testChainID, "", nil, nil, nil)
+	if err != nil {
+		t.Fatalf("Failed to create client: %v", err)
+	}
+
+	// Run with -race: changing the limit must not race with requests reading it
+	var wg sync.WaitGroup
+	for i := 0; i < 4; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for j := 0; j < 10; j++ {
+				if _, err := c.makeRequest("GET", server.URL+"/time", nil, nil); err != nil {
+					t.Errorf("Request failed: %v", err)
+					return
+				}
+			}
+		}()
+	}
+	for i := 0; i < 20; i++ {
+		c.SetRateLimit(float64(10000+i), 10)
+		c.SetRateLimit(0, 0)
+	}
+	wg.Wait()
+}
@@ -0,0 +1,43 @@
+package client
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"fmt"
+)
+
+// RequestIDHeader carries the client-generated ID of each request
+const RequestIDHeader = "X-Request-Id"
+
+// RequestError is returned for a failed HTTP request and carries the ID it was sent
+// with, so the failure can be matched against metrics and server-side logs
+type RequestError struct {
+	RequestID string
+	Err       error
+	Uncertain bool // An earlier attempt failed without a clear answer and may have reached the server
+}
+
+func (e *RequestError) Error() string {
+	return fmt.Sprintf("%v (request_id=%s)", e.Err, e.RequestID)
+}
+
+func (e *RequestError) Unwrap() error {
+	return e.Err
+}
+
+// RequestIDOf returns the request ID carried by err, or "" if there is none
+func RequestIDOf(err error) string {
+	var reqErr *RequestError
+	if errors.As(err, &reqErr) {
+		return reqErr.RequestID
+	}
+	return ""
+}
+
+// newRequestID returns a random 128-bit request ID in hex
+func newRequestID() string {
+	var id [16]byte
+	rand.Read(id[:])
+	return hex.EncodeToString(id[:])
+}
@@ -0,0 +1,46 @@
+package client
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestRequestIDSentAndReported(t *testing.T) {
+	var sent string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		sent = r.Header.Get(RequestIDHeader)
+		http.Error(w, `{"error":"boom"}`, http.StatusInternalServerError)
+	}))
+	defer server.Close()
+
+	c, err := NewClobClient(server.URL, 137, "", nil, nil, nil)
+	if err != nil {
+		t.Fatalf("Failed to create client: %v", err)
+	}
+
+	_, err = c.GetMidpoint("1")
+	if err == nil {
+		t.Fatal("Expected request to fail")
+	}
+	if sent == "" {
+		t.Fatal("Expected a request ID header")
+	}
+	if got := RequestIDOf(err); got != sent {
+		t.Errorf("RequestIDOf = %q, want %q", got, sent)
+	}
+	if !strings.Contains(err.Error(), sent) {
+		t.Errorf("Expected error %q to mention the request ID", err)
+	}
+
+	found := false
+	for _, metric := range c.GetMetrics() {
+		if metric.Operation == "http_request" && metric.RequestID == sent {
+			found = true
+		}
+	}
+	if !found {
+		t.Errorf("Expected an http_request metric tagged %s", sent)
+	}
+}
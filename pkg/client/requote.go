@@ -0,0 +1,197 @@
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/MaDal776/polymarket-go-client/pkg/types"
+)
+
+// RequoteResult reports what a RequoteLadder cancelled and posted
+type RequoteResult struct {
+	Cancelled    []string                 `json:"cancelled"`               // Orders the exchange cancelled
+	NotCancelled map[string]string        `json:"not_cancelled,omitempty"` // Orders the exchange did not cancel, with its reason
+	Results      []map[string]interface{} `json:"results"`                 // The exchange's result of each new order, in order; nil when its batch failed
+	Errors       []error                  `json:"-"`                       // Per new order: why it was not posted, or nil
+	Unquoted     time.Duration            `json:"unquoted"`                // From sending the cancel to the last post answered
+}
+
+// Failed returns the indexes of the new orders that were not posted
+func (r *RequoteResult) Failed() []int {
+	failed := make([]int, 0)
+	for i, err := range r.Errors {
+		if err != nil {
+			failed = append(failed, i)
+		}
+	}
+	return failed
+}
+
+// RequoteLadder replaces resting orders: it cancels cancelIDs in one request
+// and, once the exchange has answered, posts newOrders in batches of
+// MaxBatchOrders sent in parallel. The replacements are never posted before the
+// cancel is acknowledged, so old and new quotes are not live together. Orders
+// are validated, midpoint-checked as PostOrders does, encoded and their batches
+// signed before the cancel is sent so that the book is left unquoted for as
+// short as possible.
+//
+// An error is returned, and nothing is posted, when an order is invalid or the
+// cancel request fails. Orders the exchange refuses to cancel do not stop the
+// posts; they are listed in NotCancelled. Posts that fail, whole batches or
+// single orders, are reported in Errors.
+func (c *ClobClient) RequoteLadder(ctx context.Context, cancelIDs []string, newOrders []types.PostOrdersArgs) (*RequoteResult, error) {
+	start := time.Now()
+
+	if err := c.requireAuth(types.L2); err != nil {
+		c.recordMetric("ladder_requote", start, false, "insufficient auth level")
+		return nil, err
+	}
+	if len(cancelIDs) == 0 && len(newOrders) == 0 {
+		c.recordMetric("ladder_requote", start, false, "nothing to requote")
+		return nil, fmt.Errorf("no orders to cancel or post")
+	}
+
+	if c.midpointCheck != MidpointCheckOff {
+		if err := c.checkMidpoints(newOrders); err != nil {
+			c.recordMetric("ladder_requote", start, false, "midpoint check")
+			return nil, err
+		}
+	}
+
+	// Prepare every batch up front
+	type batch struct {
+		first   int
+		size    int
+		body    json.RawMessage
+		headers map[string]string
+	}
+	batches := make([]batch, 0, (len(newOrders)+MaxBatchOrders-1)/MaxBatchOrders)
+	for first := 0; first < len(newOrders); first += MaxBatchOrders {
+		last := min(first+MaxBatchOrders, len(newOrders))
+		requests := make([]json.RawMessage, 0, last-first)
+		for i := first; i < last; i++ {
+			encoded, err := c.encodeBatchOrder(newOrders[i])
+			if err != nil {
+				c.recordMetric("ladder_requote", start, false, err.Error())
+				return nil, fmt.Errorf("order %d: %w", i, err)
+			}
+			requests = append(requests, encoded)
+		}
+		body, headers, err := c.orderBatchRequest(requests)
+		if err != nil {
+			c.recordMetric("ladder_requote", start, false, err.Error())
+			return nil, err
+		}
+		batches = append(batches, batch{first: first, size: last - first, body: body, headers: headers})
+	}
+
+	// Refuse orders already posted when dedupe is enabled
+	signed := make([]*types.SignedOrder, len(newOrders))
+	for i, order := range newOrders {
+		signed[i] = order.Order
+	}
+	if err := c.claimOrders(signed...); err != nil {
+		c.recordMetric("ladder_requote", start, false, "duplicate order")
+		return nil, err
+	}
+
+	result := &RequoteResult{
+		Cancelled: make([]string, 0, len(cancelIDs)),
+		Results:   make([]map[string]interface{}, len(newOrders)),
+		Errors:    make([]error, len(newOrders)),
+	}
+	sent := time.Now()
+	if len(cancelIDs) > 0 {
+		if err := c.cancelForRequote(ctx, cancelIDs, result); err != nil {
+			c.releaseOrders(signed...)
+			c.recordMetric("ladder_requote", start, false, err.Error())
+			return nil, fmt.Errorf("failed to cancel orders, nothing was posted: %w", err)
+		}
+	}
+
+	requests := make([]BatchRequest[[]map[string]interface{}], len(batches))
+	for i, b := range batches {
+		b := b
+		requests[i] = func(ctx context.Context) ([]map[string]interface{}, error) {
+			return c.postOrderBatch(ctx, b.body, b.headers)
+		}
+	}
+	failures := 0
+	for i, posted := range Batch(ctx, requests, len(requests)) {
+		b := batches[i]
+		for j := 0; j < b.size; j++ {
+			index := b.first + j
+			switch {
+			case posted.Err != nil:
+				result.Errors[index] = posted.Err
+				c.releaseRejected(posted.Err, signed[index])
+			case j >= len(posted.Value):
+				result.Errors[index] = fmt.Errorf("no result returned for order %d", index)
+			default:
+				result.Results[index] = posted.Value[j]
+				result.Errors[index] = orderRejection(posted.Value[j])
+				if result.Errors[index] != nil {
+					c.releaseOrders(signed[index])
+				}
+			}
+			if result.Errors[index] != nil {
+				failures++
+			}
+		}
+	}
+	result.Unquoted = time.Since(sent)
+
+	if failures > 0 || len(result.NotCancelled) > 0 {
+		c.recordMetric("ladder_requote", start, false, fmt.Sprintf("%d not cancelled, %d not posted", len(result.NotCancelled), failures))
+	} else {
+		c.recordMetric("ladder_requote", start, true, "")
+	}
+	return result, nil
+}
+
+// cancelForRequote cancels orderIDs in one request and records the outcome
+func (c *ClobClient) cancelForRequote(ctx context.Context, orderIDs []string, result *RequoteResult) error {
+	requestArgs := types.RequestArgs{
+		Method:      "DELETE",
+		RequestPath: CancelOrders,
+		Body:        orderIDs,
+	}
+	headers, err := c.headerBuilder.CreateLevel2Headers(c.creds, requestArgs)
+	if err != nil {
+		return fmt.Errorf("failed to create headers: %w", err)
+	}
+	resp, err := c.makeRequestContext(ctx, "DELETE", c.host+CancelOrders, headers, orderIDs)
+	if err != nil {
+		return err
+	}
+
+	var cancelled struct {
+		Canceled    []string               `json:"canceled"`
+		NotCanceled map[string]interface{} `json:"not_canceled"`
+	}
+	if err := json.Unmarshal(resp, &cancelled); err != nil {
+		return fmt.Errorf("failed to parse response: %w", err)
+	}
+	result.Cancelled = append(result.Cancelled, cancelled.Canceled...)
+	if len(cancelled.NotCanceled) > 0 {
+		result.NotCancelled = make(map[string]string, len(cancelled.NotCanceled))
+		for id, reason := range cancelled.NotCanceled {
+			result.NotCancelled[id] = fmt.Sprint(reason)
+		}
+	}
+	return nil
+}
+
+// orderRejection returns the error carried by one result of a batch post, or nil
+func orderRejection(result map[string]interface{}) error {
+	message, _ := result["errorMsg"].(string)
+	if success, _ := result["success"].(bool); success && message == "" {
+		return nil
+	}
+	if message == "" {
+		message = "order was not accepted"
+	}
+	return fmt.Errorf("order rejected: %s", message)
+}
@@ -0,0 +1,96 @@
+package client
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/MaDal776/polymarket-go-client/pkg/types"
+)
+
+func TestRequoteLadder(t *testing.T) {
+	secret := base64.URLEncoding.EncodeToString([]byte("0123456789abcdef0123456789abcdef"))
+	creds := &types.ApiCreds{ApiKey: "key", ApiSecret: secret, ApiPassphrase: "pass"}
+
+	var mu sync.Mutex
+	var events []string
+	failCancel := false
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		mu.Lock()
+		events = append(events, r.Method)
+		mu.Unlock()
+		if r.Method == "DELETE" {
+			if failCancel {
+				w.WriteHeader(http.StatusServiceUnavailable)
+				return
+			}
+			w.Write([]byte(`{"canceled":["0xa"],"not_canceled":{"0xb":"order already matched"}}`))
+			return
+		}
+		var orders []map[string]interface{}
+		json.Unmarshal(body, &orders)
+		if len(orders) < MaxBatchOrders {
+			// The second batch fails as a whole
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+		results := make([]string, len(orders))
+		for i := range results {
+			results[i] = `{"success":true,"orderID":"0x1"}`
+		}
+		results[1] = `{"success":false,"errorMsg":"not enough balance"}`
+		w.Write([]byte("[" + strings.Join(results, ",") + "]"))
+	}))
+	defer server.Close()
+
+	c, err := NewClobClient(server.URL, testChainID, testPrivateKey, creds, nil, nil)
+	if err != nil {
+		t.Fatalf("Failed to create client: %v", err)
+	}
+	orders := make([]types.PostOrdersArgs, MaxBatchOrders+2)
+	for i := range orders {
+		orders[i] = types.PostOrdersArgs{Order: &types.SignedOrder{Salt: int64(i), TokenID: "1", Side: types.BUY, Signature: "0x00"}, OrderType: types.GTC}
+	}
+
+	result, err := c.RequoteLadder(context.Background(), []string{"0xa", "0xb"}, orders)
+	if err != nil {
+		t.Fatalf("RequoteLadder failed: %v", err)
+	}
+	if len(events) != 3 || events[0] != "DELETE" {
+		t.Errorf("Expected the cancel before both batches, got %v", events)
+	}
+	if len(result.Cancelled) != 1 || result.NotCancelled["0xb"] != "order already matched" {
+		t.Errorf("Unexpected cancels %v, %v", result.Cancelled, result.NotCancelled)
+	}
+	failed := result.Failed()
+	if len(failed) != 3 || failed[0] != 1 || failed[1] != MaxBatchOrders || failed[2] != MaxBatchOrders+1 {
+		t.Errorf("Expected the rejected order and the failed batch, got %v", failed)
+	}
+	if result.Results[0]["orderID"] != "0x1" || result.Results[MaxBatchOrders] != nil {
+		t.Errorf("Unexpected results %v", result.Results)
+	}
+
+	// Nothing is posted when the cancel fails
+	failCancel = true
+	events = nil
+	if _, err := c.RequoteLadder(context.Background(), []string{"0xa"}, orders[:1]); err == nil {
+		t.Errorf("Expected the failed cancel to be returned")
+	}
+	if len(events) != 1 {
+		t.Errorf("Expected only the cancel request, got %v", events)
+	}
+
+	// Invalid orders fail before anything is cancelled
+	events = nil
+	orders[0].OrderType = types.GTD
+	if _, err := c.RequoteLadder(context.Background(), []string{"0xa"}, orders[:1]); err == nil || len(events) != 0 {
+		t.Errorf("Expected a local failure, got %v after %v", err, events)
+	}
+}
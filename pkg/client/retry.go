@@ -0,0 +1,304 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/MaDal776/polymarket-go-client/pkg/types"
+)
+
+// Retry defaults
+const (
+	DefaultRetryBackoff = 100 * time.Millisecond
+	DefaultDedupeWindow = 10000 // Orders remembered by the order dedupe
+)
+
+// ErrDuplicateOrder is returned when order dedupe is enabled and an order with
+// the same signature, and so the same order hash, was already posted
+var ErrDuplicateOrder = errors.New("order already posted")
+
+// RetrySafety classifies whether a failed request to an endpoint may be resent
+type RetrySafety int
+
+const (
+	// RetrySafe endpoints can be resent freely: reads and cancels have no
+	// further effect when repeated
+	RetrySafe RetrySafety = iota
+	// RetryWithDedupe endpoints post orders. A resent post carries the same
+	// signed order, which the exchange identifies by its hash, but they are only
+	// resent when order dedupe is enabled so an order is never posted twice by
+	// the client
+	RetryWithDedupe
+	// RetryUnsafe endpoints are never resent, e.g. API key creation
+	RetryUnsafe
+)
+
+func (s RetrySafety) String() string {
+	switch s {
+	case RetrySafe:
+		return "safe"
+	case RetryWithDedupe:
+		return "with_dedupe"
+	default:
+		return "unsafe"
+	}
+}
+
+// endpointSafety classifies the endpoints written to, by method and path. GETs
+// are always safe; anything not listed is unsafe.
+var endpointSafety = map[string]RetrySafety{
+	"POST " + GetPrices:       RetrySafe,
+	"DELETE " + CancelOrder:   RetrySafe,
+	"DELETE " + CancelOrders:  RetrySafe,
+	"DELETE " + CancelAll:     RetrySafe,
+	"DELETE " + Notifications: RetrySafe,
+	"POST " + PostOrder:       RetryWithDedupe,
+	"POST " + PostOrders:      RetryWithDedupe,
+	"POST " + CreateAPIKey:    RetryUnsafe,
+}
+
+// EndpointRetrySafety returns whether a failed request with method to path may be resent
+func EndpointRetrySafety(method, path string) RetrySafety {
+	if method == "GET" {
+		return RetrySafe
+	}
+	if safety, ok := endpointSafety[method+" "+path]; ok {
+		return safety
+	}
+	return RetryUnsafe
+}
+
+// RetryConfig configures the resending of failed requests
+type RetryConfig struct {
+	Attempts     int           // Resends after the first attempt; 0 disables retries
+	Backoff      time.Duration // Wait before the first resend, doubled for each one after; default DefaultRetryBackoff
+	DedupeOrders bool          // Remember posted orders, refuse to post one twice and let order posts be resent
+	DedupeWindow int           // Orders remembered; default DefaultDedupeWindow
+}
+
+// retrier holds the retry configuration and the orders posted under dedupe
+type retrier struct {
+	mu     sync.Mutex
+	config RetryConfig
+	posted map[string]uint64 // Order signature -> sequence of its claim
+	claims []postedOrder     // Oldest first
+	seq    uint64
+}
+
+// postedOrder is one claim in the dedupe window
+type postedOrder struct {
+	key string
+	seq uint64
+}
+
+// SetRetries resends requests that failed with a transport error, a timeout or
+// a 5xx response, up to config.Attempts times with exponential backoff, when
+// the endpoint is safe to repeat (see EndpointRetrySafety). Reads and cancels
+// are resent freely. Order posts are only resent with config.DedupeOrders,
+// which also makes PostOrder, PostOrders and RequoteLadder refuse, with
+// ErrDuplicateOrder, an order already posted and not rejected by the exchange.
+// A rejection only releases an order when no earlier attempt may have reached
+// the exchange; after an unclear attempt PostOrder looks the order up by its
+// hash and reports it as posted when the exchange has it.
+// Each resend gets its own timeout and goes to the active host when failover
+// is configured. PostOrderFast is never retried.
+func (c *ClobClient) SetRetries(config RetryConfig) error {
+	if config.Attempts < 0 || config.Backoff < 0 || config.DedupeWindow < 0 {
+		return fmt.Errorf("retry attempts, backoff and dedupe window must not be negative")
+	}
+	if config.Backoff == 0 {
+		config.Backoff = DefaultRetryBackoff
+	}
+	if config.DedupeWindow == 0 {
+		config.DedupeWindow = DefaultDedupeWindow
+	}
+
+	c.retry.mu.Lock()
+	defer c.retry.mu.Unlock()
+	c.retry.config = config
+	if !config.DedupeOrders {
+		c.retry.posted = nil
+		c.retry.claims = nil
+	} else if c.retry.posted == nil {
+		c.retry.posted = make(map[string]uint64)
+	}
+	return nil
+}
+
+// retryable reports whether a request that ended with resp or err should be
+// resent as attempt
+func (c *ClobClient) retryable(method, rawURL string, attempt int, resp *http.Response, err error) bool {
+	if err == nil && resp.StatusCode < 500 {
+		return false
+	}
+	c.retry.mu.Lock()
+	config := c.retry.config
+	c.retry.mu.Unlock()
+	if attempt > config.Attempts {
+		return false
+	}
+
+	parsed, parseErr := url.Parse(rawURL)
+	if parseErr != nil {
+		return false
+	}
+	switch EndpointRetrySafety(method, parsed.Path) {
+	case RetrySafe:
+		return true
+	case RetryWithDedupe:
+		return config.DedupeOrders
+	default:
+		return false
+	}
+}
+
+// waitRetry waits out the backoff before attempt, returning false when ctx is done first
+func (c *ClobClient) waitRetry(ctx context.Context, attempt int) bool {
+	c.retry.mu.Lock()
+	backoff := c.retry.config.Backoff << (attempt - 1)
+	c.retry.mu.Unlock()
+
+	timer := time.NewTimer(backoff)
+	defer timer.Stop()
+	select {
+	case <-ctx.Done():
+		return false
+	case <-timer.C:
+	}
+	c.waitRateLimit()
+	return true
+}
+
+// retryRequest copies req for another attempt under ctx, sent to target
+func retryRequest(ctx context.Context, req *http.Request, target string) (*http.Request, error) {
+	retry := req.Clone(ctx)
+	parsed, err := url.Parse(target)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse URL: %w", err)
+	}
+	retry.URL = parsed
+	retry.Host = ""
+	if req.GetBody != nil {
+		if retry.Body, err = req.GetBody(); err != nil {
+			return nil, fmt.Errorf("failed to reset body: %w", err)
+		}
+	}
+	return retry, nil
+}
+
+// statusCode returns resp's status code, or 0 without a response
+func statusCode(resp *http.Response) int {
+	if resp == nil {
+		return 0
+	}
+	return resp.StatusCode
+}
+
+// claimOrders records orders as posted when dedupe is enabled. It fails, and
+// claims none of them, when one was already posted.
+func (c *ClobClient) claimOrders(orders ...*types.SignedOrder) error {
+	c.retry.mu.Lock()
+	defer c.retry.mu.Unlock()
+	if !c.retry.config.DedupeOrders {
+		return nil
+	}
+
+	for i, order := range orders {
+		key := dedupeKey(order)
+		if _, posted := c.retry.posted[key]; posted && key != "" {
+			return fmt.Errorf("%w: order %d (salt %d)", ErrDuplicateOrder, i, order.Salt)
+		}
+	}
+	for _, order := range orders {
+		key := dedupeKey(order)
+		if key == "" {
+			continue
+		}
+		c.retry.seq++
+		c.retry.posted[key] = c.retry.seq
+		c.retry.claims = append(c.retry.claims, postedOrder{key: key, seq: c.retry.seq})
+	}
+
+	// Forget the oldest claims beyond the window
+	for len(c.retry.claims) > c.retry.config.DedupeWindow {
+		oldest := c.retry.claims[0]
+		if c.retry.posted[oldest.key] == oldest.seq {
+			delete(c.retry.posted, oldest.key)
+		}
+		c.retry.claims = c.retry.claims[1:]
+	}
+	return nil
+}
+
+// releaseOrders forgets orders claimed by claimOrders, so they can be posted again
+func (c *ClobClient) releaseOrders(orders ...*types.SignedOrder) {
+	c.retry.mu.Lock()
+	defer c.retry.mu.Unlock()
+	for _, order := range orders {
+		delete(c.retry.posted, dedupeKey(order))
+	}
+}
+
+// releaseRejected releases orders when err shows the exchange refused them.
+// Otherwise the orders may be live, so they stay claimed.
+func (c *ClobClient) releaseRejected(err error, orders ...*types.SignedOrder) {
+	if rejected(err) {
+		c.releaseOrders(orders...)
+	}
+}
+
+// rejected reports whether err shows the exchange answered and refused a
+// request that no earlier attempt may have carried out. A 4xx after a timeout
+// or 5xx, such as a duplicate order error, leaves the outcome unknown.
+func rejected(err error) bool {
+	var reqErr *RequestError
+	if errors.As(err, &reqErr) && reqErr.Uncertain {
+		return false
+	}
+	var apiErr *APIError
+	return errors.As(err, &apiErr) && apiErr.StatusCode < 500
+}
+
+// IsRejected reports whether err from posting an order shows the exchange
+// refused it, so the order cannot be live. A transport error, a 5xx or a
+// refusal after an unclear attempt leaves the order's fate unknown.
+func IsRejected(err error) bool {
+	return rejected(err)
+}
+
+// dedupeEnabled reports whether posted orders are being claimed
+func (c *ClobClient) dedupeEnabled() bool {
+	c.retry.mu.Lock()
+	defer c.retry.mu.Unlock()
+	return c.retry.config.DedupeOrders
+}
+
+// findPostedOrder looks a signed order up on the exchange by its order hash
+// after a post whose outcome is unknown. When the exchange has it, it returns
+// the response a successful post would have given.
+func (c *ClobClient) findPostedOrder(order *types.SignedOrder) (map[string]interface{}, bool) {
+	hash, err := c.OrderID(order)
+	if err != nil {
+		return nil, false
+	}
+
+	found, err := c.GetOrder(hash)
+	if err != nil || found == nil || found.ID == "" {
+		return nil, false
+	}
+	return map[string]interface{}{"success": true, "orderID": found.ID, "status": found.Status}, true
+}
+
+// dedupeKey identifies an order by its signature, which commits to the order hash
+func dedupeKey(order *types.SignedOrder) string {
+	if order == nil {
+		return ""
+	}
+	return strings.ToLower(order.Signature)
+}
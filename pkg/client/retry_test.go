@@ -0,0 +1,190 @@
+package client
+
+import (
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/MaDal776/polymarket-go-client/pkg/types"
+)
+
+func TestEndpointRetrySafety(t *testing.T) {
+	cases := []struct {
+		method, path string
+		want         RetrySafety
+	}{
+		{"GET", GetOrderBook, RetrySafe},
+		{"GET", GetOrder + "0xabc", RetrySafe},
+		{"POST", GetPrices, RetrySafe},
+		{"DELETE", CancelOrder, RetrySafe},
+		{"DELETE", CancelAll, RetrySafe},
+		{"POST", PostOrder, RetryWithDedupe},
+		{"POST", PostOrders, RetryWithDedupe},
+		{"POST", CreateAPIKey, RetryUnsafe},
+		{"PUT", "/unknown", RetryUnsafe},
+	}
+	for _, tc := range cases {
+		if got := EndpointRetrySafety(tc.method, tc.path); got != tc.want {
+			t.Errorf("%s %s: got %s, want %s", tc.method, tc.path, got, tc.want)
+		}
+	}
+}
+
+func TestRetriesResendReads(t *testing.T) {
+	var calls atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if calls.Add(1) < 3 {
+			http.Error(w, "unavailable", http.StatusServiceUnavailable)
+			return
+		}
+		w.Write([]byte(`{"mid":"0.5"}`))
+	}))
+	defer server.Close()
+
+	c, err := NewClobClient(server.URL, 137, "", nil, nil, nil)
+	if err != nil {
+		t.Fatalf("Failed to create client: %v", err)
+	}
+	if _, err := c.GetMidpoint("1"); err == nil {
+		t.Fatal("Expected the first read to fail without retries")
+	}
+
+	calls.Store(0)
+	if err := c.SetRetries(RetryConfig{Attempts: 2, Backoff: time.Millisecond}); err != nil {
+		t.Fatalf("SetRetries failed: %v", err)
+	}
+	if _, err := c.GetMidpoint("1"); err != nil {
+		t.Fatalf("Expected the read to succeed on the third attempt: %v", err)
+	}
+	if calls.Load() != 3 {
+		t.Errorf("Expected 3 attempts, got %d", calls.Load())
+	}
+}
+
+func TestOrderPostsRetryOnlyWithDedupe(t *testing.T) {
+	secret := base64.URLEncoding.EncodeToString([]byte("0123456789abcdef0123456789abcdef"))
+	creds := &types.ApiCreds{ApiKey: "key", ApiSecret: secret, ApiPassphrase: "pass"}
+
+	var calls atomic.Int32
+	var reject atomic.Bool
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case reject.Load():
+			http.Error(w, `{"error":"not enough balance"}`, http.StatusBadRequest)
+		case calls.Add(1)%2 == 1:
+			http.Error(w, "bad gateway", http.StatusBadGateway)
+		default:
+			w.Write([]byte(`{"success":true,"orderID":"0xabc"}`))
+		}
+	}))
+	defer server.Close()
+
+	c, err := NewClobClient(server.URL, 137, testPrivateKey, creds, nil, nil)
+	if err != nil {
+		t.Fatalf("Failed to create client: %v", err)
+	}
+	newOrder := func(salt int64) *types.SignedOrder {
+		return &types.SignedOrder{Salt: salt, Maker: c.GetAddress(), Signer: c.GetAddress(), TokenID: "1", Side: types.BUY, Signature: fmt.Sprintf("0x%02x", salt)}
+	}
+
+	// Without dedupe a post is never resent
+	c.SetRetries(RetryConfig{Attempts: 2, Backoff: time.Millisecond})
+	if _, err := c.PostOrder(newOrder(1), types.GTC); err == nil || calls.Load() != 1 {
+		t.Fatalf("Expected one failed attempt, got %d: %v", calls.Load(), err)
+	}
+
+	calls.Store(0)
+	c.SetRetries(RetryConfig{Attempts: 2, Backoff: time.Millisecond, DedupeOrders: true})
+	order := newOrder(2)
+	if _, err := c.PostOrder(order, types.GTC); err != nil || calls.Load() != 2 {
+		t.Fatalf("Expected the post to succeed on the second attempt, got %d: %v", calls.Load(), err)
+	}
+	if _, err := c.PostOrder(order, types.GTC); !errors.Is(err, ErrDuplicateOrder) {
+		t.Errorf("Expected ErrDuplicateOrder, got %v", err)
+	}
+
+	// An order the exchange rejected can be posted again
+	reject.Store(true)
+	rejected := newOrder(3)
+	if _, err := c.PostOrder(rejected, types.GTC); err == nil || errors.Is(err, ErrDuplicateOrder) {
+		t.Fatalf("Expected a rejection, got %v", err)
+	}
+	if _, err := c.PostOrder(rejected, types.GTC); errors.Is(err, ErrDuplicateOrder) {
+		t.Errorf("Expected the rejected order to be released: %v", err)
+	}
+}
+
+func TestDuplicateAfterTimeoutKeepsClaim(t *testing.T) {
+	secret := base64.URLEncoding.EncodeToString([]byte("0123456789abcdef0123456789abcdef"))
+	creds := &types.ApiCreds{ApiKey: "key", ApiSecret: secret, ApiPassphrase: "pass"}
+
+	var posts atomic.Int32
+	var lookedUp atomic.Value
+	lookedUp.Store("")
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.URL.Path == GetNegRisk:
+			w.Write([]byte(`{"neg_risk":false}`))
+		case r.Method == "POST" && r.URL.Path == PostOrder:
+			if posts.Add(1) == 1 {
+				// The exchange takes the order, but the answer comes too late
+				time.Sleep(100 * time.Millisecond)
+				w.Write([]byte(`{"success":true}`))
+				return
+			}
+			http.Error(w, `{"error":"order already exists"}`, http.StatusBadRequest)
+		case strings.HasPrefix(r.URL.Path, GetOrder):
+			id := strings.TrimPrefix(r.URL.Path, GetOrder)
+			lookedUp.Store(id)
+			fmt.Fprintf(w, `{"id":%q,"status":"LIVE"}`, id)
+		default:
+			t.Errorf("Unexpected request %s %s", r.Method, r.URL.Path)
+		}
+	}))
+	defer server.Close()
+
+	c, err := NewClobClient(server.URL, 137, testPrivateKey, creds, nil, nil)
+	if err != nil {
+		t.Fatalf("Failed to create client: %v", err)
+	}
+	c.SetEndpointTimeout(PostOrder, 30*time.Millisecond)
+	c.SetRetries(RetryConfig{Attempts: 1, Backoff: time.Millisecond, DedupeOrders: true})
+
+	order := &types.SignedOrder{
+		Salt: 7, Maker: c.GetAddress(), Signer: c.GetAddress(), Taker: "0x0000000000000000000000000000000000000000",
+		TokenID: "1", MakerAmount: "5000000", TakerAmount: "10000000", Expiration: "0", Nonce: "0",
+		FeeRateBps: "0", Side: types.BUY, Signature: "0x07",
+	}
+	hash, err := OrderHash(order, contractConfigs[137].Exchange, 137)
+	if err != nil {
+		t.Fatalf("OrderHash failed: %v", err)
+	}
+
+	result, err := c.PostOrder(order, types.GTC)
+	if err != nil {
+		t.Fatalf("Expected the live order to be found, got %v", err)
+	}
+	if result["orderID"] != hash || lookedUp.Load() != hash {
+		t.Errorf("Expected the order looked up by hash %s, got %v (looked up %v)", hash, result, lookedUp.Load())
+	}
+	if _, err := c.PostOrder(order, types.GTC); !errors.Is(err, ErrDuplicateOrder) {
+		t.Errorf("Expected the order to stay claimed, got %v", err)
+	}
+}
+
+func TestUncertainRejectionIsNotReleased(t *testing.T) {
+	err := &RequestError{RequestID: "1", Err: &APIError{StatusCode: 400, Message: "duplicate"}, Uncertain: true}
+	if rejected(err) {
+		t.Error("Expected a 4xx after an unclear attempt not to count as a rejection")
+	}
+	err.Uncertain = false
+	if !rejected(err) {
+		t.Error("Expected a plain 4xx to count as a rejection")
+	}
+}
@@ -0,0 +1,72 @@
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/MaDal776/polymarket-go-client/pkg/clock"
+)
+
+// SyncServerTime measures the offset of the exchange clock from the client's
+// clock, corrected for half the round trip. L1 and L2 header timestamps, GTD
+// expirations computed from durations and the GTD lifetime check all use the
+// synced time afterwards. It returns the offset: positive when the server is
+// ahead.
+func (c *ClobClient) SyncServerTime(ctx context.Context) (time.Duration, error) {
+	start := time.Now()
+	sent := c.clock.BaseNow()
+
+	resp, err := c.makeRequestContext(ctx, "GET", c.host+Time, nil, nil)
+	if err != nil {
+		c.recordMetric("server_time_sync", start, false, err.Error())
+		return 0, fmt.Errorf("failed to get server time: %w", err)
+	}
+	received := c.clock.BaseNow()
+	var serverTime int64
+	if err := json.Unmarshal(resp, &serverTime); err != nil {
+		c.recordMetric("server_time_sync", start, false, err.Error())
+		return 0, fmt.Errorf("failed to parse server time response: %w", err)
+	}
+
+	// The server reports whole seconds; assume the middle of the second
+	offset := c.clock.Measure(sent, received, time.Unix(serverTime, 0).Add(500*time.Millisecond))
+	c.recordMetric("server_time_sync", start, true, "")
+	return offset, nil
+}
+
+// ServerTimeOffset returns the offset measured by SyncServerTime, or 0
+func (c *ClobClient) ServerTimeOffset() time.Duration {
+	return c.clock.Offset()
+}
+
+// ServerNow returns the current time on the exchange clock, as last synced
+func (c *ClobClient) ServerNow() time.Time {
+	return c.clock.Now()
+}
+
+// ExpirationIn returns the Unix expiration, for OrderArgs.Expiration, of an
+// order living ttl on the exchange from now on the exchange clock. The exchange
+// only accepts expirations more than its MinGTDLifetime security threshold
+// ahead, so that is added to ttl, and the result is rounded up to a whole second.
+func (c *ClobClient) ExpirationIn(ttl time.Duration) int64 {
+	at := c.ServerNow().Add(MinGTDLifetime + ttl)
+	expiration := at.Unix()
+	if at.After(time.Unix(expiration, 0)) {
+		expiration++
+	}
+	return expiration
+}
+
+// SetClock replaces the clock the client reads time from, e.g. with a fake one
+// in tests. The offset measured by SyncServerTime is applied on top of it.
+func (c *ClobClient) SetClock(clk clock.Clock) {
+	c.clock.SetBase(clk)
+}
+
+// Clock returns the client's synced clock, so that other components can stamp
+// their own timestamps consistently with the client's
+func (c *ClobClient) Clock() clock.Clock {
+	return c.clock
+}
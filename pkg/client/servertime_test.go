@@ -0,0 +1,97 @@
+package client
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/MaDal776/polymarket-go-client/pkg/types"
+)
+
+func TestSyncServerTime(t *testing.T) {
+	// The exchange clock runs ten minutes ahead
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, time.Now().Add(10*time.Minute).Unix())
+	}))
+	defer server.Close()
+
+	c, err := NewClobClient(server.URL, testChainID, testPrivateKey, nil, nil, nil)
+	if err != nil {
+		t.Fatalf("Failed to create client: %v", err)
+	}
+	offset, err := c.SyncServerTime(context.Background())
+	if err != nil {
+		t.Fatalf("SyncServerTime failed: %v", err)
+	}
+	if offset < 599*time.Second || offset > 601*time.Second || c.ServerTimeOffset() != offset {
+		t.Fatalf("Expected a ten minute offset, got %v", offset)
+	}
+
+	// A per-order TTL is counted on the server clock and wins over the default
+	if err := c.SetOrderDefaults(OrderDefaults{ExpireAfter: time.Hour}); err != nil {
+		t.Fatalf("SetOrderDefaults failed: %v", err)
+	}
+	c.PrimeNegRisk(testTokenID, false)
+	orderArgs := types.OrderArgs{TokenID: testTokenID, Price: 0.55, Size: 10, Side: types.BUY, ExpireAfter: 5 * time.Minute}
+	signedOrder, err := c.CreateOrder(orderArgs, &types.CreateOrderOptions{TickSize: types.TickSize001})
+	if err != nil {
+		t.Fatalf("Failed to create order: %v", err)
+	}
+	expiration, _ := strconv.ParseInt(signedOrder.Expiration, 10, 64)
+	if until := time.Until(time.Unix(expiration, 0)); until < 15*time.Minute || until > 17*time.Minute {
+		t.Errorf("Expected an expiration 16 minutes ahead on the local clock, got %v", until)
+	}
+
+	// An explicit expiration is kept
+	orderArgs.Expiration = 1767225600
+	signedOrder, err = c.CreateOrder(orderArgs, &types.CreateOrderOptions{TickSize: types.TickSize001})
+	if err != nil {
+		t.Fatalf("Failed to create order: %v", err)
+	}
+	if signedOrder.Expiration != "1767225600" {
+		t.Errorf("Expected the explicit expiration, got %s", signedOrder.Expiration)
+	}
+}
+
+type fixedClock struct{ now time.Time }
+
+func (f *fixedClock) Now() time.Time { return f.now }
+
+func TestSetClock(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, 1700000060)
+	}))
+	defer server.Close()
+
+	c, err := NewClobClient(server.URL, testChainID, testPrivateKey, nil, nil, nil)
+	if err != nil {
+		t.Fatalf("Failed to create client: %v", err)
+	}
+	c.SetClock(&fixedClock{now: time.Unix(1700000000, 0)})
+	if _, err := c.SyncServerTime(context.Background()); err != nil {
+		t.Fatalf("SyncServerTime failed: %v", err)
+	}
+	if offset := c.ServerTimeOffset(); offset != 60*time.Second+500*time.Millisecond {
+		t.Fatalf("Expected the offset from the injected clock, got %v", offset)
+	}
+
+	// Header timestamps and the GTD lifetime check read the synced clock
+	headers, err := c.headerBuilder.CreateLevel1Headers(0)
+	if err != nil {
+		t.Fatalf("Failed to create headers: %v", err)
+	}
+	if headers["POLY_TIMESTAMP"] != "1700000060" {
+		t.Errorf("Expected the server timestamp, got %s", headers["POLY_TIMESTAMP"])
+	}
+	signedOrder := &types.SignedOrder{Expiration: strconv.FormatInt(c.ExpirationIn(5*time.Minute), 10), MakerAmount: "1000000"}
+	if err := c.ValidateOrderType(signedOrder, types.GTD); err != nil {
+		t.Errorf("Expected the expiration to be valid on the synced clock: %v", err)
+	}
+	if err := validateOrderType(signedOrder, types.GTD, time.Now()); err == nil {
+		t.Errorf("Expected the expiration to be in the past on the system clock")
+	}
+}
@@ -0,0 +1,67 @@
+package client
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/MaDal776/polymarket-go-client/pkg/types"
+)
+
+// ErrTickSizeChanged is returned (wrapped in a *TickSizeError) when the exchange
+// rejects an order for its price and the market's tick size turns out to differ
+// from the one the order was created with
+var ErrTickSizeChanged = errors.New("market tick size changed")
+
+// TickSizeError reports an order rejected after a tick size change. The cached tick
+// size has already been replaced by Current, so the order can be recreated as is.
+type TickSizeError struct {
+	TokenID  string
+	Previous types.TickSize // The cached tick size, or "" if none was cached
+	Current  types.TickSize
+	Err      error // The exchange's rejection
+}
+
+func (e *TickSizeError) Error() string {
+	return fmt.Sprintf("%v: token %s tick size is now %s (was %s): %v", ErrTickSizeChanged, e.TokenID, e.Current, e.Previous, e.Err)
+}
+
+func (e *TickSizeError) Unwrap() []error {
+	return []error{ErrTickSizeChanged, e.Err}
+}
+
+// SetTickSizeRetry makes CreateAndPostOrder recreate, re-sign and post an order
+// once more when it was rejected after a tick size change. The order is validated
+// against the new tick size, so prices off the new grid still fail unless price
+// rounding is enabled.
+func (c *ClobClient) SetTickSizeRetry(enabled bool) {
+	c.tickSizeRetry = enabled
+}
+
+// isTickSizeRejection reports whether err is the exchange rejecting a price off the tick grid
+func isTickSizeRejection(err error) bool {
+	message := strings.ToLower(err.Error())
+	return strings.Contains(message, "min_tick_size") || strings.Contains(message, "tick size")
+}
+
+// recoverTickSize handles a rejected order: when the rejection was for the tick
+// size, it drops the cached tick size, including any response cache entry, and
+// refetches it. It returns a *TickSizeError if the tick size changed, and err
+// otherwise.
+func (c *ClobClient) recoverTickSize(tokenID string, err error) error {
+	if tokenID == "" || !isTickSizeRejection(err) {
+		return err
+	}
+
+	c.mu.Lock()
+	previous := c.tickSizes[tokenID]
+	delete(c.tickSizes, tokenID)
+	c.mu.Unlock()
+	c.forgetTokenResponses(tokenID, GetTickSize)
+
+	current, fetchErr := c.GetTickSize(tokenID)
+	if fetchErr != nil || current == previous {
+		return err
+	}
+	return &TickSizeError{TokenID: tokenID, Previous: previous, Current: current, Err: err}
+}
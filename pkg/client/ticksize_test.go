@@ -0,0 +1,170 @@
+package client
+
+import (
+	"encoding/base64"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/MaDal776/polymarket-go-client/pkg/types"
+)
+
+// newTickChangeServer serves a 0.001 tick size and rejects the first posted order
+func newTickChangeServer(t *testing.T, posts *int32) *httptest.Server {
+	t.Helper()
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case GetTickSize:
+			w.Write([]byte(`{"minimum_tick_size":0.001}`))
+		case GetNegRisk:
+			w.Write([]byte(`{"neg_risk":false}`))
+		case PostOrder:
+			if atomic.AddInt32(posts, 1) == 1 {
+				w.WriteHeader(http.StatusBadRequest)
+				w.Write([]byte(`{"error":"INVALID_ORDER_MIN_TICK_SIZE"}`))
+				return
+			}
+			w.Write([]byte(`{"success":true,"orderID":"0xabc"}`))
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+}
+
+func newTickChangeClient(t *testing.T, host string) *ClobClient {
+	t.Helper()
+	secret := base64.URLEncoding.EncodeToString([]byte("0123456789abcdef0123456789abcdef"))
+	creds := &types.ApiCreds{ApiKey: "key", ApiSecret: secret, ApiPassphrase: "pass"}
+	c, err := NewClobClient(host, 137, testPrivateKey, creds, nil, nil)
+	if err != nil {
+		t.Fatalf("Failed to create client: %v", err)
+	}
+	c.tickSizes[testTokenID] = types.TickSize001
+	return c
+}
+
+func TestPostOrderReportsTickSizeChange(t *testing.T) {
+	var posts int32
+	server := newTickChangeServer(t, &posts)
+	defer server.Close()
+	c := newTickChangeClient(t, server.URL)
+
+	orderArgs := types.OrderArgs{TokenID: testTokenID, Price: 0.55, Size: 10, Side: types.BUY}
+	_, err := c.CreateAndPostOrder(orderArgs, nil)
+	if !errors.Is(err, ErrTickSizeChanged) {
+		t.Fatalf("Expected ErrTickSizeChanged, got %v", err)
+	}
+	var tickErr *TickSizeError
+	if !errors.As(err, &tickErr) || tickErr.Previous != types.TickSize001 || tickErr.Current != types.TickSize0001 {
+		t.Errorf("Unexpected tick size error: %v", err)
+	}
+	if c.tickSizes[testTokenID] != types.TickSize0001 {
+		t.Errorf("Expected the cache to hold the new tick size, got %s", c.tickSizes[testTokenID])
+	}
+	if posts != 1 {
+		t.Errorf("Expected no retry without SetTickSizeRetry, got %d posts", posts)
+	}
+}
+
+func TestCreateAndPostOrderRetriesAfterTickSizeChange(t *testing.T) {
+	var posts int32
+	server := newTickChangeServer(t, &posts)
+	defer server.Close()
+	c := newTickChangeClient(t, server.URL)
+	c.SetTickSizeRetry(true)
+
+	orderArgs := types.OrderArgs{TokenID: testTokenID, Price: 0.55, Size: 10, Side: types.BUY}
+	result, err := c.CreateAndPostOrder(orderArgs, nil)
+	if err != nil {
+		t.Fatalf("Expected the retry to succeed, got %v", err)
+	}
+	if result["orderID"] != "0xabc" || posts != 2 {
+		t.Errorf("Unexpected result %v after %d posts", result, posts)
+	}
+}
+
+func TestTickSizeRetryKeepsAllowCross(t *testing.T) {
+	var posts, books int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case GetTickSize:
+			w.Write([]byte(`{"minimum_tick_size":0.001}`))
+		case GetNegRisk:
+			w.Write([]byte(`{"neg_risk":false}`))
+		case GetOrderBook:
+			// Asks at 0.5, so a BUY at 0.55 crosses
+			atomic.AddInt32(&books, 1)
+			w.Write([]byte(`{"asset_id":"` + testTokenID + `","bids":[],"asks":[{"price":"0.5","size":"100"}]}`))
+		case PostOrder:
+			if atomic.AddInt32(&posts, 1) == 1 {
+				w.WriteHeader(http.StatusBadRequest)
+				w.Write([]byte(`{"error":"INVALID_ORDER_MIN_TICK_SIZE"}`))
+				return
+			}
+			w.Write([]byte(`{"success":true,"orderID":"0xabc"}`))
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	defer server.Close()
+	c := newTickChangeClient(t, server.URL)
+	c.SetTickSizeRetry(true)
+	c.SetCrossCheck(CrossCheckReject, nil)
+
+	orderArgs := types.OrderArgs{TokenID: testTokenID, Price: 0.55, Size: 10, Side: types.BUY}
+	options := &types.CreateOrderOptions{TickSize: types.TickSize001, AllowCross: true}
+	result, err := c.CreateAndPostOrder(orderArgs, options)
+	if err != nil {
+		t.Fatalf("Expected the retried order to skip the cross check, got %v", err)
+	}
+	if result["orderID"] != "0xabc" || posts != 2 || books != 0 {
+		t.Errorf("Unexpected result %v after %d posts and %d book requests", result, posts, books)
+	}
+}
+
+func TestRecoverTickSizeBypassesResponseCache(t *testing.T) {
+	var posts int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case GetTickSize:
+			if atomic.LoadInt32(&posts) == 0 {
+				w.Write([]byte(`{"minimum_tick_size":0.01}`))
+				return
+			}
+			w.Write([]byte(`{"minimum_tick_size":0.001}`))
+		case GetNegRisk:
+			w.Write([]byte(`{"neg_risk":false}`))
+		case PostOrder:
+			atomic.AddInt32(&posts, 1)
+			w.WriteHeader(http.StatusBadRequest)
+			w.Write([]byte(`{"error":"INVALID_ORDER_MIN_TICK_SIZE"}`))
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	defer server.Close()
+	c := newTickChangeClient(t, server.URL)
+	delete(c.tickSizes, testTokenID)
+	c.SetResponseCache(NewResponseCache(time.Hour))
+
+	orderArgs := types.OrderArgs{TokenID: testTokenID, Price: 0.55, Size: 10, Side: types.BUY}
+	_, err := c.CreateAndPostOrder(orderArgs, nil)
+	var tickErr *TickSizeError
+	if !errors.As(err, &tickErr) || tickErr.Previous != types.TickSize001 || tickErr.Current != types.TickSize0001 {
+		t.Fatalf("Expected the refetched tick size to bypass the response cache, got %v", err)
+	}
+}
+
+func TestRecoverTickSizeIgnoresOtherErrors(t *testing.T) {
+	c := &ClobClient{tickSizes: map[string]types.TickSize{testTokenID: types.TickSize001}}
+	original := errors.New("HTTP 400: not enough balance")
+	if err := c.recoverTickSize(testTokenID, original); err != original {
+		t.Errorf("Expected the original error, got %v", err)
+	}
+	if c.tickSizes[testTokenID] != types.TickSize001 {
+		t.Error("Expected the cached tick size to be kept")
+	}
+}
@@ -0,0 +1,101 @@
+package client
+
+import (
+	"context"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+)
+
+// DefaultRequestTimeout bounds requests without a more specific timeout
+const DefaultRequestTimeout = 30 * time.Second
+
+// fastPostTimeout bounds PostOrderFast when PostOrder has no timeout of its own
+const fastPostTimeout = 10 * time.Second
+
+// requestTimeouts holds the client-wide and per-endpoint request timeouts
+type requestTimeouts struct {
+	mu        sync.RWMutex
+	fallback  time.Duration
+	endpoints map[string]time.Duration
+}
+
+// timeoutKey is the context key of WithRequestTimeout
+type timeoutKey struct{}
+
+// WithRequestTimeout returns a context whose requests time out after d, taking
+// precedence over the client's endpoint and default timeouts. Unlike
+// context.WithTimeout the clock starts per request, so retries and each page of
+// a paginated call get the full d.
+func WithRequestTimeout(ctx context.Context, d time.Duration) context.Context {
+	return context.WithValue(ctx, timeoutKey{}, d)
+}
+
+// SetRequestTimeout sets the timeout of requests without an endpoint timeout.
+// 0 restores DefaultRequestTimeout.
+func (c *ClobClient) SetRequestTimeout(d time.Duration) {
+	c.timeouts.mu.Lock()
+	defer c.timeouts.mu.Unlock()
+	c.timeouts.fallback = d
+}
+
+// SetEndpointTimeout sets the timeout of requests to an endpoint, e.g. a few
+// hundred milliseconds for PostOrder or a minute for GetPricesHistory. The path
+// matches exactly unless it ends in "/", in which case it covers every path
+// below it (GetOrder covers each order). The timeout applies to every method
+// on the path: PostOrder's also bounds CancelOrder. 0 removes the override.
+func (c *ClobClient) SetEndpointTimeout(path string, d time.Duration) {
+	c.timeouts.mu.Lock()
+	defer c.timeouts.mu.Unlock()
+	if d <= 0 {
+		delete(c.timeouts.endpoints, path)
+		return
+	}
+	if c.timeouts.endpoints == nil {
+		c.timeouts.endpoints = make(map[string]time.Duration)
+	}
+	c.timeouts.endpoints[path] = d
+}
+
+// RequestTimeout returns the timeout requests to path are made with
+func (c *ClobClient) RequestTimeout(path string) time.Duration {
+	if d := c.endpointTimeout(path); d > 0 {
+		return d
+	}
+	c.timeouts.mu.RLock()
+	defer c.timeouts.mu.RUnlock()
+	if c.timeouts.fallback > 0 {
+		return c.timeouts.fallback
+	}
+	return DefaultRequestTimeout
+}
+
+// endpointTimeout returns the timeout set for path, preferring the longest
+// matching endpoint, or 0 when none is set
+func (c *ClobClient) endpointTimeout(path string) time.Duration {
+	c.timeouts.mu.RLock()
+	defer c.timeouts.mu.RUnlock()
+
+	var match string
+	var timeout time.Duration
+	for endpoint, d := range c.timeouts.endpoints {
+		matches := path == endpoint || (strings.HasSuffix(endpoint, "/") && strings.HasPrefix(path, endpoint))
+		if matches && len(endpoint) > len(match) {
+			match, timeout = endpoint, d
+		}
+	}
+	return timeout
+}
+
+// requestContext bounds ctx by the timeout of a request to rawURL
+func (c *ClobClient) requestContext(ctx context.Context, rawURL string) (context.Context, context.CancelFunc) {
+	if d, ok := ctx.Value(timeoutKey{}).(time.Duration); ok && d > 0 {
+		return context.WithTimeout(ctx, d)
+	}
+	path := rawURL
+	if parsed, err := url.Parse(rawURL); err == nil {
+		path = parsed.Path
+	}
+	return context.WithTimeout(ctx, c.RequestTimeout(path))
+}
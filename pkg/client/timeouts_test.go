@@ -0,0 +1,98 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/MaDal776/polymarket-go-client/pkg/types"
+)
+
+func TestRequestTimeoutMatching(t *testing.T) {
+	c, err := NewClobClient("http://localhost", testChainID, "", nil, nil, nil)
+	if err != nil {
+		t.Fatalf("Failed to create client: %v", err)
+	}
+	if got := c.RequestTimeout(GetPrice); got != DefaultRequestTimeout {
+		t.Errorf("Expected the default timeout, got %v", got)
+	}
+
+	c.SetRequestTimeout(5 * time.Second)
+	c.SetEndpointTimeout(PostOrder, 300*time.Millisecond)
+	c.SetEndpointTimeout(GetPrice, time.Second)
+	c.SetEndpointTimeout(GetOrder, 2*time.Second)
+
+	cases := map[string]time.Duration{
+		PostOrder:              300 * time.Millisecond,
+		PostOrders:             5 * time.Second, // "/order" does not cover "/orders"
+		GetPrice:               time.Second,
+		GetPricesHistory:       5 * time.Second,
+		GetOrder + "0xabc":     2 * time.Second,
+		GetOrders:              5 * time.Second,
+		UpdateBalanceAllowance: 5 * time.Second,
+	}
+	for path, want := range cases {
+		if got := c.RequestTimeout(path); got != want {
+			t.Errorf("RequestTimeout(%q) = %v, want %v", path, got, want)
+		}
+	}
+
+	c.SetEndpointTimeout(PostOrder, 0)
+	if got := c.RequestTimeout(PostOrder); got != 5*time.Second {
+		t.Errorf("Expected the removed override to fall back, got %v", got)
+	}
+}
+
+func TestEndpointTimeout(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == GetPricesHistory {
+			time.Sleep(200 * time.Millisecond)
+		}
+		w.Write([]byte(`{"history":[]}`))
+	}))
+	defer server.Close()
+
+	c, err := NewClobClient(server.URL, testChainID, "", nil, nil, nil)
+	if err != nil {
+		t.Fatalf("Failed to create client: %v", err)
+	}
+	c.SetEndpointTimeout(GetPricesHistory, 50*time.Millisecond)
+
+	_, err = c.GetPricesHistory(types.PriceHistoryParams{Market: "123", Interval: "1d"})
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("Expected a deadline error, got %v", err)
+	}
+
+	c.SetEndpointTimeout(GetPricesHistory, time.Second)
+	if _, err := c.GetPricesHistory(types.PriceHistoryParams{Market: "123", Interval: "1d"}); err != nil {
+		t.Fatalf("Expected the longer timeout to succeed: %v", err)
+	}
+}
+
+func TestWithRequestTimeout(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		time.Sleep(200 * time.Millisecond)
+		w.Write([]byte(`{}`))
+	}))
+	defer server.Close()
+
+	c, err := NewClobClient(server.URL, testChainID, "", nil, nil, nil)
+	if err != nil {
+		t.Fatalf("Failed to create client: %v", err)
+	}
+	c.SetEndpointTimeout(GetMidpoint, 50*time.Millisecond)
+
+	// The per-call timeout wins over the endpoint's
+	ctx := WithRequestTimeout(context.Background(), time.Second)
+	if _, err := c.makeRequestContext(ctx, "GET", server.URL+GetMidpoint, nil, nil); err != nil {
+		t.Fatalf("Expected the per-call timeout to allow the request: %v", err)
+	}
+
+	ctx = WithRequestTimeout(context.Background(), 50*time.Millisecond)
+	if _, err := c.makeRequestContext(ctx, "GET", server.URL+GetSpread, nil, nil); !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("Expected a deadline error, got %v", err)
+	}
+}
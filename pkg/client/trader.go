@@ -0,0 +1,29 @@
+package client
+
+import (
+	"github.com/MaDal776/polymarket-go-client/pkg/types"
+)
+
+// Trader is the trading surface of the client. Components that place orders
+// or read market data depend on this interface so that the live client,
+// the paper trading client and wrappers such as risk checks are interchangeable.
+type Trader interface {
+	GetAddress() string
+	GetTickSize(tokenID string) (types.TickSize, error)
+	GetNegRisk(tokenID string) (bool, error)
+	GetOrderBook(tokenID string) (*types.OrderBookSummary, error)
+	GetMidpoint(tokenID string) (*types.MidpointResponse, error)
+	GetPrice(tokenID string, side types.OrderSide) (*types.PriceResponse, error)
+	GetBalanceAllowance(params *types.BalanceAllowanceParams) (*types.BalanceAllowanceResponse, error)
+	GetOrder(orderID string) (*types.OpenOrder, error)
+	GetOpenOrders(params *types.OpenOrderParams) ([]types.OpenOrder, error)
+	GetTrades(params *types.TradeParams) ([]types.Trade, error)
+	CreateOrder(orderArgs types.OrderArgs, options *types.CreateOrderOptions) (*types.SignedOrder, error)
+	PostOrder(signedOrder *types.SignedOrder, orderType types.OrderType) (map[string]interface{}, error)
+	CreateAndPostOrder(orderArgs types.OrderArgs, options *types.CreateOrderOptions) (map[string]interface{}, error)
+	CancelOrder(orderID string) (map[string]interface{}, error)
+	CancelOrders(orderIDs []string) (map[string]interface{}, error)
+	CancelAll() (map[string]interface{}, error)
+}
+
+var _ Trader = (*ClobClient)(nil)
@@ -0,0 +1,92 @@
+package client
+
+import (
+	"context"
+	"time"
+
+	"github.com/MaDal776/polymarket-go-client/pkg/types"
+)
+
+// DefaultTradesPollInterval is how often TradesIter polls for new trades when tailing
+const DefaultTradesPollInterval = 5 * time.Second
+
+// TradesIterOptions configures TradesIter
+type TradesIterOptions struct {
+	Tail         bool          // Keep polling for new trades after the last page
+	PollInterval time.Duration // Tail polling interval; default DefaultTradesPollInterval
+}
+
+// TradeResult is one trade, or an error, delivered by TradesIter
+type TradeResult struct {
+	Trade types.Trade
+	Err   error
+}
+
+// TradesIter streams the trades matching params, following next_cursor page by
+// page. Without Tail the channel is closed after the last page or the first
+// error. With Tail the last page is polled again every PollInterval and trades
+// not delivered before are sent; errors are sent and polling continues. The
+// channel is closed when ctx is cancelled.
+func (c *ClobClient) TradesIter(ctx context.Context, params *types.TradeParams, options *TradesIterOptions) <-chan TradeResult {
+	if options == nil {
+		options = &TradesIterOptions{}
+	}
+	interval := options.PollInterval
+	if interval <= 0 {
+		interval = DefaultTradesPollInterval
+	}
+
+	results := make(chan TradeResult, 1)
+	go func() {
+		defer close(results)
+		send := func(result TradeResult) bool {
+			select {
+			case results <- result:
+				return true
+			case <-ctx.Done():
+				return false
+			}
+		}
+
+		if err := c.requireAuth(types.L2); err != nil {
+			send(TradeResult{Err: err})
+			return
+		}
+
+		cursor := InitialCursor
+		delivered := make(map[string]bool) // IDs delivered from the page at cursor
+		for {
+			page, err := c.getTradesPage(params, cursor)
+			if err != nil {
+				if !send(TradeResult{Err: err}) || !options.Tail {
+					return
+				}
+			} else {
+				for _, trade := range page.Data {
+					if delivered[trade.ID] {
+						continue
+					}
+					delivered[trade.ID] = true
+					if !send(TradeResult{Trade: trade}) {
+						return
+					}
+				}
+				if page.NextCursor != "" && page.NextCursor != EndCursor {
+					cursor = page.NextCursor
+					delivered = make(map[string]bool)
+					continue
+				}
+				if !options.Tail {
+					return
+				}
+			}
+
+			select {
+			case <-ctx.Done():
+				return
+			case <-time.After(interval):
+			}
+		}
+	}()
+	return results
+}
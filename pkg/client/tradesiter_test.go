@@ -0,0 +1,96 @@
+package client
+
+import (
+	"context"
+	"encoding/base64"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/MaDal776/polymarket-go-client/pkg/types"
+)
+
+func newTradesServer(t *testing.T) *httptest.Server {
+	t.Helper()
+	var lastPagePolls int32
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Query().Get("next_cursor") {
+		case InitialCursor:
+			w.Write([]byte(`{"data":[{"id":"1"},{"id":"2"}],"next_cursor":"MQ=="}`))
+		case "MQ==":
+			// A new trade appears on the last page after the first poll
+			if atomic.AddInt32(&lastPagePolls, 1) == 1 {
+				w.Write([]byte(`{"data":[{"id":"3"}],"next_cursor":"LTE="}`))
+			} else {
+				w.Write([]byte(`{"data":[{"id":"3"},{"id":"4"}],"next_cursor":"LTE="}`))
+			}
+		default:
+			t.Errorf("Unexpected cursor %q", r.URL.Query().Get("next_cursor"))
+		}
+	}))
+}
+
+func newTradesClient(t *testing.T, host string) *ClobClient {
+	t.Helper()
+	secret := base64.URLEncoding.EncodeToString([]byte("0123456789abcdef0123456789abcdef"))
+	creds := &types.ApiCreds{ApiKey: "key", ApiSecret: secret, ApiPassphrase: "pass"}
+	c, err := NewClobClient(host, testChainID, testPrivateKey, creds, nil, nil)
+	if err != nil {
+		t.Fatalf("Failed to create client: %v", err)
+	}
+	return c
+}
+
+func TestTradesIterFollowsCursor(t *testing.T) {
+	server := newTradesServer(t)
+	defer server.Close()
+	c := newTradesClient(t, server.URL)
+
+	var ids []string
+	for result := range c.TradesIter(context.Background(), nil, nil) {
+		if result.Err != nil {
+			t.Fatalf("Unexpected error: %v", result.Err)
+		}
+		ids = append(ids, result.Trade.ID)
+	}
+	if len(ids) != 3 || ids[0] != "1" || ids[2] != "3" {
+		t.Errorf("Got trades %v, want [1 2 3]", ids)
+	}
+}
+
+func TestTradesIterTails(t *testing.T) {
+	server := newTradesServer(t)
+	defer server.Close()
+	c := newTradesClient(t, server.URL)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	results := c.TradesIter(ctx, nil, &TradesIterOptions{Tail: true, PollInterval: 10 * time.Millisecond})
+
+	var ids []string
+	for result := range results {
+		if result.Err != nil {
+			t.Fatalf("Unexpected error: %v", result.Err)
+		}
+		ids = append(ids, result.Trade.ID)
+		if len(ids) == 4 {
+			cancel()
+		}
+	}
+	if len(ids) != 4 || ids[3] != "4" {
+		t.Errorf("Got trades %v, want [1 2 3 4] without repeats", ids)
+	}
+}
+
+func TestTradesIterRequiresL2(t *testing.T) {
+	c, err := NewClobClient(testHost, testChainID, "", nil, nil, nil)
+	if err != nil {
+		t.Fatalf("Failed to create client: %v", err)
+	}
+	result, ok := <-c.TradesIter(context.Background(), nil, nil)
+	if !ok || result.Err == nil {
+		t.Error("Expected an auth error")
+	}
+}
@@ -0,0 +1,14 @@
+package client
+
+import "github.com/MaDal776/polymarket-go-client/pkg/version"
+
+// SetUserAgent names the application in the User-Agent sent with every request,
+// e.g. "my-bot/2.1", ahead of the library version. An empty app restores the default.
+func (c *ClobClient) SetUserAgent(app string) {
+	c.userAgent = version.UserAgent(app)
+}
+
+// UserAgent returns the User-Agent sent with every request
+func (c *ClobClient) UserAgent() string {
+	return c.userAgent
+}
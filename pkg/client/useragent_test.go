@@ -0,0 +1,38 @@
+package client
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/MaDal776/polymarket-go-client/pkg/version"
+)
+
+func TestUserAgentSent(t *testing.T) {
+	var userAgent, clientVersion string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		userAgent = r.Header.Get("User-Agent")
+		clientVersion = r.Header.Get(version.Header)
+		w.Write([]byte(`{"mid":"0.5"}`))
+	}))
+	defer server.Close()
+
+	c, err := NewClobClient(server.URL, 137, "", nil, nil, nil)
+	if err != nil {
+		t.Fatalf("Failed to create client: %v", err)
+	}
+	if _, err := c.GetMidpoint("1"); err != nil {
+		t.Fatalf("GetMidpoint failed: %v", err)
+	}
+	if userAgent != version.UserAgent("") || clientVersion != version.Version {
+		t.Errorf("Unexpected headers: User-Agent %q, version %q", userAgent, clientVersion)
+	}
+
+	c.SetUserAgent("my-bot/2.1")
+	if _, err := c.GetMidpoint("2"); err != nil {
+		t.Fatalf("GetMidpoint failed: %v", err)
+	}
+	if userAgent != version.UserAgent("my-bot/2.1") || c.UserAgent() != userAgent {
+		t.Errorf("Expected the app in the User-Agent, got %q", userAgent)
+	}
+}
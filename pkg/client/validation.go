@@ -0,0 +1,207 @@
+package client
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/common"
+
+	"github.com/MaDal776/polymarket-go-client/pkg/types"
+	"github.com/MaDal776/polymarket-go-client/pkg/utils"
+)
+
+// ErrInvalidOrder is matched (via errors.Is) by every ValidationError
+var ErrInvalidOrder = errors.New("invalid order")
+
+// Fields reported by ValidationError
+const (
+	FieldPrice      = "price"
+	FieldSize       = "size"   // Shares for limit orders
+	FieldAmount     = "amount" // USDC for market buys, shares for market sells
+	FieldNotional   = "notional"
+	FieldTaker      = "taker"
+	FieldOrderType  = "order_type"
+	FieldExpiration = "expiration"
+)
+
+// FieldError describes one invalid field of an order
+type FieldError struct {
+	Field   string `json:"field"`
+	Value   string `json:"value"`
+	Message string `json:"message"`
+}
+
+// ValidationError lists every invalid field of an order rejected before signing
+type ValidationError struct {
+	Fields []FieldError `json:"fields"`
+}
+
+// Error implements error
+func (e *ValidationError) Error() string {
+	problems := make([]string, len(e.Fields))
+	for i, field := range e.Fields {
+		problems[i] = fmt.Sprintf("%s %s: %s", field.Field, field.Value, field.Message)
+	}
+	return "invalid order: " + strings.Join(problems, "; ")
+}
+
+// Unwrap allows errors.Is(err, ErrInvalidOrder)
+func (e *ValidationError) Unwrap() error {
+	return ErrInvalidOrder
+}
+
+// Field returns the error for a field, if that field is invalid
+func (e *ValidationError) Field(name string) (FieldError, bool) {
+	for _, field := range e.Fields {
+		if field.Field == name {
+			return field, true
+		}
+	}
+	return FieldError{}, false
+}
+
+// add records an invalid field
+func (e *ValidationError) add(field string, value string, format string, args ...interface{}) {
+	e.Fields = append(e.Fields, FieldError{Field: field, Value: value, Message: fmt.Sprintf(format, args...)})
+}
+
+// orNil returns e if any field is invalid
+func (e *ValidationError) orNil() error {
+	if len(e.Fields) == 0 {
+		return nil
+	}
+	return e
+}
+
+// SetMinOrderNotional sets the smallest order value, in USDC, CreateOrder and
+// CreateMarketOrder accept. The check is off by default and 0 disables it; the
+// exchange's own minimums vary by market.
+func (c *ClobClient) SetMinOrderNotional(min float64) {
+	c.minNotional = min
+}
+
+// validateOrder checks a limit order against the tick size and rounding rules
+func (c *ClobClient) validateOrder(orderArgs types.OrderArgs, tickSize types.TickSize) error {
+	verr := &ValidationError{}
+	validatePrice(verr, orderArgs.Price, tickSize)
+
+	size := formatFloat(orderArgs.Size)
+	if decimals := utils.GetRoundingConfig(tickSize).Size; orderArgs.Size <= 0 {
+		verr.add(FieldSize, size, "must be positive")
+	} else if utils.DecimalPlaces(orderArgs.Size) > decimals {
+		verr.add(FieldSize, size, "more than %d decimal places", decimals)
+	}
+
+	if orderArgs.Size > 0 && orderArgs.Price > 0 {
+		c.validateNotional(verr, orderArgs.Size*orderArgs.Price)
+	}
+	if orderArgs.ExpireAfter != 0 && orderArgs.ExpireAfter < minExpireAfter {
+		verr.add(FieldExpiration, orderArgs.ExpireAfter.String(), "order lifetime must be at least %s", minExpireAfter)
+	}
+	validateTaker(verr, orderArgs.Taker)
+	return verr.orNil()
+}
+
+// validateMarketOrder checks a market order whose price has been resolved
+func (c *ClobClient) validateMarketOrder(orderArgs types.MarketOrderArgs, tickSize types.TickSize) error {
+	verr := &ValidationError{}
+	validatePrice(verr, orderArgs.Price, tickSize)
+
+	amount := formatFloat(orderArgs.Amount)
+	if decimals := utils.GetRoundingConfig(tickSize).Size; orderArgs.Amount <= 0 {
+		verr.add(FieldAmount, amount, "must be positive")
+	} else if utils.DecimalPlaces(orderArgs.Amount) > decimals {
+		verr.add(FieldAmount, amount, "more than %d decimal places", decimals)
+	}
+
+	if orderArgs.Amount > 0 && orderArgs.Price > 0 {
+		notional := orderArgs.Amount
+		if orderArgs.Side == types.SELL {
+			notional *= orderArgs.Price
+		}
+		c.validateNotional(verr, notional)
+	}
+	validateTaker(verr, orderArgs.Taker)
+	return verr.orNil()
+}
+
+// sizeFromNotional sets a limit order's Size from its Notional and Price
+func sizeFromNotional(orderArgs *types.OrderArgs, tickSize types.TickSize) error {
+	if orderArgs.Notional == 0 {
+		return nil
+	}
+	verr := &ValidationError{}
+	switch {
+	case orderArgs.Size != 0:
+		verr.add(FieldSize, formatFloat(orderArgs.Size), "set either Size or Notional, not both")
+	case orderArgs.Notional < 0:
+		verr.add(FieldNotional, formatFloat(orderArgs.Notional), "must be positive")
+	default:
+		// A price out of range leaves the size at 0 for validateOrder to report
+		orderArgs.Size = utils.SizeForNotional(orderArgs.Notional, orderArgs.Price, tickSize)
+	}
+	return verr.orNil()
+}
+
+// resolveMarketAmount sets a market order's Amount from its typed Spend or
+// Shares, rejecting a buy sized in shares, a sell sized in USDC and an Amount
+// that disagrees with the typed field
+func resolveMarketAmount(orderArgs *types.MarketOrderArgs) error {
+	verr := &ValidationError{}
+	switch {
+	case orderArgs.Side == types.BUY && orderArgs.Shares != 0:
+		verr.add(FieldAmount, orderArgs.Shares.ToHuman(), "BUY market orders spend USDC; set Spend, not Shares")
+	case orderArgs.Side == types.SELL && orderArgs.Spend != 0:
+		verr.add(FieldAmount, orderArgs.Spend.ToHuman(), "SELL market orders sell shares; set Shares, not Spend")
+	}
+	if err := verr.orNil(); err != nil {
+		return err
+	}
+
+	typed := orderArgs.Spend.Float64() + orderArgs.Shares.Float64()
+	if typed == 0 {
+		return nil
+	}
+	if orderArgs.Amount != 0 && math.Abs(orderArgs.Amount-typed) > 1e-9 {
+		verr.add(FieldAmount, formatFloat(orderArgs.Amount), "disagrees with %s; set one of them", formatFloat(typed))
+		return verr
+	}
+	orderArgs.Amount = typed
+	return nil
+}
+
+// validateNotional checks the order value against the configured minimum
+func (c *ClobClient) validateNotional(verr *ValidationError, notional float64) {
+	if c.minNotional > 0 && notional < c.minNotional-1e-9 {
+		verr.add(FieldNotional, formatFloat(utils.RoundNormal(notional, 6)), "below the minimum of %s USDC", formatFloat(c.minNotional))
+	}
+}
+
+// validatePrice checks that price is within range and a multiple of the tick size
+func validatePrice(verr *ValidationError, price float64, tickSize types.TickSize) {
+	value := formatFloat(price)
+	if !utils.ValidatePrice(price, tickSize) {
+		tick := utils.ParseTickSize(tickSize)
+		verr.add(FieldPrice, value, "must be between %s and %s", formatFloat(tick), formatFloat(1-tick))
+		return
+	}
+	steps := price / utils.ParseTickSize(tickSize)
+	if math.Abs(steps-math.Round(steps)) > 1e-9 {
+		verr.add(FieldPrice, value, "not a multiple of tick size %s", tickSize)
+	}
+}
+
+// validateTaker checks the taker is empty (public order) or a hex address
+func validateTaker(verr *ValidationError, taker string) {
+	if taker != "" && !common.IsHexAddress(taker) {
+		verr.add(FieldTaker, taker, "not a valid address")
+	}
+}
+
+// formatFloat formats a value without trailing zeros
+func formatFloat(value float64) string {
+	return strconv.FormatFloat(value, 'f', -1, 64)
+}
@@ -0,0 +1,165 @@
+package client
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/MaDal776/polymarket-go-client/pkg/amount"
+	"github.com/MaDal776/polymarket-go-client/pkg/types"
+)
+
+func TestCreateOrderValidationError(t *testing.T) {
+	client, err := NewClobClient(testHost, testChainID, testPrivateKey, nil, nil, nil)
+	if err != nil {
+		t.Fatalf("Failed to create client: %v", err)
+	}
+	client.negRisks[testTokenID] = false // Avoid a network lookup
+	client.SetMinOrderNotional(1)
+
+	orderArgs := types.OrderArgs{
+		TokenID:    testTokenID,
+		Price:      0.555,
+		Size:       1.125,
+		Side:       types.BUY,
+		Nonce:      time.Now().Unix(),
+		Expiration: time.Now().Add(24 * time.Hour).Unix(),
+		Taker:      "not-an-address",
+	}
+	_, err = client.CreateOrder(orderArgs, &types.CreateOrderOptions{TickSize: types.TickSize001})
+
+	var verr *ValidationError
+	if !errors.As(err, &verr) {
+		t.Fatalf("Expected a ValidationError, got %v", err)
+	}
+	if !errors.Is(err, ErrInvalidOrder) {
+		t.Error("Expected errors.Is(err, ErrInvalidOrder)")
+	}
+	for _, field := range []string{FieldPrice, FieldSize, FieldNotional, FieldTaker} {
+		if _, ok := verr.Field(field); !ok {
+			t.Errorf("Expected %s to be reported, got %v", field, verr)
+		}
+	}
+	if len(verr.Fields) != 4 {
+		t.Errorf("Expected 4 invalid fields, got %d", len(verr.Fields))
+	}
+}
+
+func TestValidateOrder(t *testing.T) {
+	client := &ClobClient{minNotional: 1}
+	valid := types.OrderArgs{Price: 0.55, Size: 10, Side: types.BUY}
+
+	if err := client.validateOrder(valid, types.TickSize001); err != nil {
+		t.Fatalf("Expected valid order, got %v", err)
+	}
+
+	cases := map[string]types.OrderArgs{
+		FieldPrice: {Price: 0.999, Size: 10},
+		FieldSize:  {Price: 0.5, Size: 0},
+		FieldTaker: {Price: 0.5, Size: 10, Taker: "0x1234"},
+	}
+	for field, orderArgs := range cases {
+		err := client.validateOrder(orderArgs, types.TickSize001)
+		var verr *ValidationError
+		if !errors.As(err, &verr) {
+			t.Errorf("%s: expected a ValidationError, got %v", field, err)
+			continue
+		}
+		if _, ok := verr.Field(field); !ok || len(verr.Fields) != 1 {
+			t.Errorf("%s: unexpected fields %+v", field, verr.Fields)
+		}
+	}
+
+	// The notional check can be disabled
+	client.SetMinOrderNotional(0)
+	if err := client.validateOrder(types.OrderArgs{Price: 0.01, Size: 5}, types.TickSize001); err != nil {
+		t.Errorf("Expected small order to pass with no minimum, got %v", err)
+	}
+}
+
+func TestMinOrderNotionalIsOffByDefault(t *testing.T) {
+	client, err := NewClobClient(testHost, testChainID, "", nil, nil, nil)
+	if err != nil {
+		t.Fatalf("Failed to create client: %v", err)
+	}
+	if err := client.validateOrder(types.OrderArgs{Price: 0.01, Size: 5}, types.TickSize001); err != nil {
+		t.Errorf("Expected no minimum notional by default, got %v", err)
+	}
+}
+
+func TestValidateMarketOrderNotional(t *testing.T) {
+	client := &ClobClient{minNotional: 1}
+
+	// Buys are sized in USDC, sells in shares
+	if err := client.validateMarketOrder(types.MarketOrderArgs{Amount: 1, Price: 0.5, Side: types.BUY}, types.TickSize001); err != nil {
+		t.Errorf("Expected 1 USDC buy to pass, got %v", err)
+	}
+	err := client.validateMarketOrder(types.MarketOrderArgs{Amount: 1, Price: 0.5, Side: types.SELL}, types.TickSize001)
+	var verr *ValidationError
+	if !errors.As(err, &verr) {
+		t.Fatalf("Expected a ValidationError, got %v", err)
+	}
+	if field, ok := verr.Field(FieldNotional); !ok || field.Value != "0.5" {
+		t.Errorf("Unexpected notional error: %+v", verr.Fields)
+	}
+}
+
+func TestResolveMarketAmount(t *testing.T) {
+	buy := types.MarketBuy("1", amount.USDCFromFloat(25), types.FAK)
+	if err := resolveMarketAmount(&buy); err != nil || buy.Amount != 25 {
+		t.Errorf("Expected a 25 USDC buy, got %g, %v", buy.Amount, err)
+	}
+	sell := types.MarketSell("1", amount.SharesFromFloat(10.5), types.FAK)
+	if err := resolveMarketAmount(&sell); err != nil || sell.Amount != 10.5 {
+		t.Errorf("Expected a 10.5 share sell, got %g, %v", sell.Amount, err)
+	}
+
+	// Each side takes only its own unit
+	var verr *ValidationError
+	wrongUnit := types.MarketOrderArgs{TokenID: "1", Side: types.SELL, Spend: amount.USDCFromFloat(25)}
+	if err := resolveMarketAmount(&wrongUnit); !errors.As(err, &verr) {
+		t.Errorf("Expected a sell sized in USDC to be rejected, got %v", err)
+	}
+	wrongUnit = types.MarketOrderArgs{TokenID: "1", Side: types.BUY, Shares: amount.SharesFromFloat(10)}
+	if err := resolveMarketAmount(&wrongUnit); !errors.As(err, &verr) {
+		t.Errorf("Expected a buy sized in shares to be rejected, got %v", err)
+	}
+	conflicting := types.MarketBuy("1", amount.USDCFromFloat(25), types.FAK)
+	conflicting.Amount = 20
+	if err := resolveMarketAmount(&conflicting); !errors.As(err, &verr) {
+		t.Errorf("Expected a conflicting Amount to be rejected, got %v", err)
+	}
+
+	// The untyped Amount still works alone
+	legacy := types.MarketOrderArgs{TokenID: "1", Side: types.SELL, Amount: 3}
+	if err := resolveMarketAmount(&legacy); err != nil || legacy.Amount != 3 {
+		t.Errorf("Expected Amount to be kept, got %g, %v", legacy.Amount, err)
+	}
+}
+
+func TestCreateOrderByNotional(t *testing.T) {
+	client, err := NewClobClient(testHost, testChainID, testPrivateKey, nil, nil, nil)
+	if err != nil {
+		t.Fatalf("Failed to create client: %v", err)
+	}
+	client.PrimeNegRisk(testTokenID, false)
+	options := &types.CreateOrderOptions{TickSize: types.TickSize001}
+
+	// Buy $100 at 0.55: 181.81 shares, never more than the notional
+	signedOrder, err := client.CreateOrder(types.OrderArgs{TokenID: testTokenID, Price: 0.55, Notional: 100, Side: types.BUY}, options)
+	if err != nil {
+		t.Fatalf("Failed to create order: %v", err)
+	}
+	if signedOrder.TakerAmount != "181810000" {
+		t.Errorf("Expected 181.81 shares, got %s", signedOrder.TakerAmount)
+	}
+
+	_, err = client.CreateOrder(types.OrderArgs{TokenID: testTokenID, Price: 0.55, Size: 10, Notional: 100, Side: types.BUY}, options)
+	var verr *ValidationError
+	if !errors.As(err, &verr) {
+		t.Fatalf("Expected Size with Notional to be rejected, got %v", err)
+	}
+	if _, ok := verr.Field(FieldSize); !ok {
+		t.Errorf("Unexpected fields %+v", verr.Fields)
+	}
+}
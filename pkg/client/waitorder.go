@@ -0,0 +1,95 @@
+package client
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/MaDal776/polymarket-go-client/pkg/types"
+)
+
+// DefaultOrderPollInterval is how often WaitForOrder polls the order
+const DefaultOrderPollInterval = 2 * time.Second
+
+// WaitOrderOptions configures WaitForOrder
+type WaitOrderOptions struct {
+	MinFilled    float64         // Return once at least this many shares matched; 0 waits for a terminal state
+	PollInterval time.Duration   // Default DefaultOrderPollInterval
+	Wake         <-chan struct{} // Polls immediately when signalled, e.g. by a user channel or notifications.Poller fill callback
+	SkipTrades   bool            // Do not fetch the order's trades
+}
+
+// OrderWaitResult is the state of an order when WaitForOrder returned
+type OrderWaitResult struct {
+	Order    *types.OpenOrder
+	Filled   float64       // Shares matched
+	Terminal bool          // Fully matched, cancelled or otherwise off the book
+	Trades   []types.Trade // The trades listed in Order.AssociateTrades
+}
+
+// WaitForOrder polls an order until it reaches a terminal state, or until at
+// least MinFilled shares matched when MinFilled is set. This client has no
+// websocket; pass a Wake channel fed by one to react to fills without waiting
+// for the next poll. When ctx ends the last state seen is returned with ctx's error.
+func (c *ClobClient) WaitForOrder(ctx context.Context, orderID string, options *WaitOrderOptions) (*OrderWaitResult, error) {
+	if options == nil {
+		options = &WaitOrderOptions{}
+	}
+	interval := options.PollInterval
+	if interval <= 0 {
+		interval = DefaultOrderPollInterval
+	}
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	var result *OrderWaitResult
+	for {
+		order, err := c.GetOrder(orderID)
+		if err != nil {
+			return result, err
+		}
+		result = &OrderWaitResult{Order: order, Terminal: IsTerminalOrderStatus(order.Status)}
+		result.Filled, _ = strconv.ParseFloat(order.SizeMatched, 64)
+
+		if result.Terminal || (options.MinFilled > 0 && result.Filled >= options.MinFilled-1e-9) {
+			if !options.SkipTrades {
+				if result.Trades, err = c.orderTrades(order); err != nil {
+					return result, err
+				}
+			}
+			return result, nil
+		}
+
+		select {
+		case <-ctx.Done():
+			return result, ctx.Err()
+		case <-ticker.C:
+		case <-options.Wake:
+		}
+	}
+}
+
+// IsTerminalOrderStatus reports whether an order status means the order is no
+// longer on the book
+func IsTerminalOrderStatus(status string) bool {
+	switch strings.TrimPrefix(strings.ToUpper(status), "ORDER_STATUS_") {
+	case "MATCHED", "CANCELED", "CANCELLED", "CANCELED_MARKET_RESOLVED", "INVALID", "UNMATCHED":
+		return true
+	}
+	return false
+}
+
+// orderTrades fetches the trades an order took part in
+func (c *ClobClient) orderTrades(order *types.OpenOrder) ([]types.Trade, error) {
+	trades := make([]types.Trade, 0, len(order.AssociateTrades))
+	for _, tradeID := range order.AssociateTrades {
+		found, err := c.GetTrades(&types.TradeParams{ID: tradeID})
+		if err != nil {
+			return trades, fmt.Errorf("failed to get trade %s: %w", tradeID, err)
+		}
+		trades = append(trades, found...)
+	}
+	return trades, nil
+}
@@ -0,0 +1,70 @@
+package client
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestWaitForOrderUntilMatched(t *testing.T) {
+	var polls int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case strings.HasPrefix(r.URL.Path, GetOrder):
+			if atomic.AddInt32(&polls, 1) < 3 {
+				w.Write([]byte(`{"id":"0xabc","status":"LIVE","size_matched":"0"}`))
+			} else {
+				w.Write([]byte(`{"id":"0xabc","status":"MATCHED","size_matched":"10","associate_trades":["t1"]}`))
+			}
+		case r.URL.Path == GetTrades:
+			if r.URL.Query().Get("id") != "t1" {
+				t.Errorf("Expected trade t1, got %q", r.URL.Query().Get("id"))
+			}
+			w.Write([]byte(`{"data":[{"id":"t1","size":"10"}],"next_cursor":"LTE="}`))
+		default:
+			t.Errorf("Unexpected path %s", r.URL.Path)
+		}
+	}))
+	defer server.Close()
+	c := newTradesClient(t, server.URL)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	result, err := c.WaitForOrder(ctx, "0xabc", &WaitOrderOptions{PollInterval: 10 * time.Millisecond})
+	if err != nil {
+		t.Fatalf("WaitForOrder failed: %v", err)
+	}
+	if !result.Terminal || result.Filled != 10 {
+		t.Errorf("Expected a terminal order with 10 filled, got %+v", result)
+	}
+	if len(result.Trades) != 1 || result.Trades[0].ID != "t1" {
+		t.Errorf("Expected trade t1, got %+v", result.Trades)
+	}
+}
+
+func TestWaitForOrderMinFilled(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{"id":"0xabc","status":"LIVE","size_matched":"4"}`))
+	}))
+	defer server.Close()
+	c := newTradesClient(t, server.URL)
+
+	result, err := c.WaitForOrder(context.Background(), "0xabc", &WaitOrderOptions{MinFilled: 3, SkipTrades: true})
+	if err != nil {
+		t.Fatalf("WaitForOrder failed: %v", err)
+	}
+	if result.Terminal || result.Filled != 4 {
+		t.Errorf("Expected a live order with 4 filled, got %+v", result)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	result, err = c.WaitForOrder(ctx, "0xabc", &WaitOrderOptions{MinFilled: 5, PollInterval: 10 * time.Millisecond})
+	if err != context.DeadlineExceeded || result == nil {
+		t.Errorf("Expected the last state with DeadlineExceeded, got %+v, %v", result, err)
+	}
+}
@@ -0,0 +1,117 @@
+package client
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"net/url"
+	"time"
+)
+
+// WarmupConnections is the number of connections Warmup opens to the CLOB host
+const WarmupConnections = 4
+
+// newTransport returns the HTTP transport used by the client. It keeps enough
+// idle connections per host for batch requests and warmed-up connections to be reused.
+func newTransport() *http.Transport {
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	transport.MaxIdleConns = 100
+	transport.MaxIdleConnsPerHost = DefaultBatchConcurrency
+	transport.IdleConnTimeout = 90 * time.Second
+	return transport
+}
+
+// Warmup resolves the CLOB host and opens keep-alive TLS connections to it, including
+// the dedicated PostOrderFast connection, so the first order after startup does not
+// pay for DNS and handshakes. When token IDs are given their tick sizes and neg risk
+// flags are cached as well.
+func (c *ClobClient) Warmup(ctx context.Context, tokenIDs ...string) error {
+	start := time.Now()
+
+	hostURL, err := url.Parse(c.host)
+	if err != nil {
+		c.recordMetric("warmup", start, false, err.Error())
+		return fmt.Errorf("invalid host %q: %w", c.host, err)
+	}
+	if _, err := net.DefaultResolver.LookupHost(ctx, hostURL.Hostname()); err != nil {
+		c.recordMetric("warmup", start, false, err.Error())
+		return fmt.Errorf("failed to resolve %s: %w", hostURL.Hostname(), err)
+	}
+
+	// Open the connections in parallel so each ping gets its own
+	pings := make([]BatchRequest[struct{}], WarmupConnections+1)
+	for i := range pings {
+		httpClient := c.httpClient
+		if i == WarmupConnections {
+			// The dedicated PostOrderFast connection
+			httpClient = c.fastHTTPClient()
+		}
+		pings[i] = func(ctx context.Context) (struct{}, error) {
+			return struct{}{}, c.ping(ctx, httpClient)
+		}
+	}
+	for _, result := range Batch(ctx, pings, len(pings)) {
+		if result.Err != nil {
+			c.recordMetric("warmup", start, false, result.Err.Error())
+			return fmt.Errorf("failed to open connection: %w", result.Err)
+		}
+	}
+
+	if err := c.ResolveTokens(ctx, tokenIDs, 0); err != nil {
+		c.recordMetric("warmup", start, false, err.Error())
+		return fmt.Errorf("failed to prime market data: %w", err)
+	}
+
+	c.recordMetric("warmup", start, true, "")
+	return nil
+}
+
+// KeepWarm pings the CLOB host every interval until ctx is cancelled so idle
+// connections are not closed by the transport or the server
+func (c *ClobClient) KeepWarm(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			c.ping(ctx, c.httpClient)
+			c.ping(ctx, c.fastHTTPClient())
+		}
+	}
+}
+
+// ping makes a cheap request to the host root, draining the body so the
+// connection returns to the idle pool
+func (c *ClobClient) ping(ctx context.Context, httpClient *http.Client) error {
+	return c.pingHost(ctx, httpClient, c.host)
+}
+
+// pingHost is ping against the base URL host
+func (c *ClobClient) pingHost(ctx context.Context, httpClient *http.Client, host string) error {
+	c.waitRateLimit()
+
+	ctx, cancel := c.requestContext(ctx, host+"/")
+	defer cancel()
+
+	req, err := http.NewRequestWithContext(ctx, "GET", host+"/", nil)
+	if err != nil {
+		return fmt.Errorf("failed to create request: %w", err)
+	}
+	req.Header.Set("User-Agent", c.userAgent)
+	resp, err := httpClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("failed to make request: %w", err)
+	}
+	defer resp.Body.Close()
+
+	io.Copy(io.Discard, resp.Body)
+	if resp.StatusCode >= 500 {
+		return fmt.Errorf("HTTP %d", resp.StatusCode)
+	}
+	return nil
+}
@@ -0,0 +1,47 @@
+package client
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+)
+
+func TestWarmupPrimesCaches(t *testing.T) {
+	var tickRequests int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case GetTickSize:
+			atomic.AddInt32(&tickRequests, 1)
+			w.Write([]byte(`{"minimum_tick_size":0.01}`))
+		case GetNegRisk:
+			w.Write([]byte(`{"neg_risk":true}`))
+		default:
+			w.Write([]byte(`"OK"`))
+		}
+	}))
+	defer server.Close()
+
+	c, err := NewClobClient(server.URL, 137, "", nil, nil, nil)
+	if err != nil {
+		t.Fatalf("Failed to create client: %v", err)
+	}
+
+	if err := c.Warmup(context.Background(), "1", "2"); err != nil {
+		t.Fatalf("Warmup failed: %v", err)
+	}
+	if n := atomic.LoadInt32(&tickRequests); n != 2 {
+		t.Fatalf("Expected 2 tick size requests during warmup, got %d", n)
+	}
+
+	if _, err := c.GetTickSize("1"); err != nil {
+		t.Fatalf("GetTickSize failed: %v", err)
+	}
+	if negRisk, err := c.GetNegRisk("2"); err != nil || !negRisk {
+		t.Fatalf("Expected cached neg risk true, got %v (%v)", negRisk, err)
+	}
+	if n := atomic.LoadInt32(&tickRequests); n != 2 {
+		t.Errorf("Expected tick size to come from cache, got %d requests", n)
+	}
+}
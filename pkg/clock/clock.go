@@ -0,0 +1,86 @@
+// Package clock is the time source of signed timestamps and expirations. The
+// client reads every timestamp it signs from one Offset clock, so that L1 auth,
+// L2 headers and GTD expirations agree on the exchange's time.
+package clock
+
+import (
+	"sync"
+	"time"
+)
+
+// Clock tells the current time. Tests and replays substitute their own.
+type Clock interface {
+	Now() time.Time
+}
+
+// System is the local system clock
+var System Clock = systemClock{}
+
+type systemClock struct{}
+
+func (systemClock) Now() time.Time { return time.Now() }
+
+// Offset is a clock running a measured offset ahead of a base clock, e.g. the
+// exchange's clock over the local one. It is safe for concurrent use.
+type Offset struct {
+	mu     sync.RWMutex
+	base   Clock
+	offset time.Duration
+}
+
+// NewOffset creates an offset clock over base, with no offset. A nil base is
+// the system clock.
+func NewOffset(base Clock) *Offset {
+	if base == nil {
+		base = System
+	}
+	return &Offset{base: base}
+}
+
+// Now returns the base clock's time plus the offset
+func (o *Offset) Now() time.Time {
+	o.mu.RLock()
+	defer o.mu.RUnlock()
+	return o.base.Now().Add(o.offset)
+}
+
+// BaseNow returns the base clock's time, without the offset
+func (o *Offset) BaseNow() time.Time {
+	o.mu.RLock()
+	defer o.mu.RUnlock()
+	return o.base.Now()
+}
+
+// Offset returns the offset applied to the base clock
+func (o *Offset) Offset() time.Duration {
+	o.mu.RLock()
+	defer o.mu.RUnlock()
+	return o.offset
+}
+
+// SetOffset sets the offset applied to the base clock
+func (o *Offset) SetOffset(offset time.Duration) {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+	o.offset = offset
+}
+
+// SetBase replaces the base clock, keeping the offset. A nil base is the
+// system clock.
+func (o *Offset) SetBase(base Clock) {
+	if base == nil {
+		base = System
+	}
+	o.mu.Lock()
+	defer o.mu.Unlock()
+	o.base = base
+}
+
+// Measure sets the offset from a reading of the reference clock, taken by a
+// request sent and answered at the given base clock times. The reading is
+// assumed to be taken halfway through the round trip. It returns the offset.
+func (o *Offset) Measure(sent, received, reference time.Time) time.Duration {
+	offset := reference.Sub(sent.Add(received.Sub(sent) / 2))
+	o.SetOffset(offset)
+	return offset
+}
@@ -0,0 +1,37 @@
+package clock
+
+import (
+	"testing"
+	"time"
+)
+
+type fixedClock struct{ now time.Time }
+
+func (f *fixedClock) Now() time.Time { return f.now }
+
+func TestOffset(t *testing.T) {
+	base := &fixedClock{now: time.Unix(1000, 0)}
+	clk := NewOffset(base)
+	if !clk.Now().Equal(base.now) {
+		t.Fatalf("Expected no offset, got %v", clk.Now())
+	}
+
+	// The reading is taken halfway through a two second round trip
+	offset := clk.Measure(time.Unix(1000, 0), time.Unix(1002, 0), time.Unix(1031, 0))
+	if offset != 30*time.Second || clk.Offset() != offset {
+		t.Fatalf("Expected a 30s offset, got %v", offset)
+	}
+	if !clk.Now().Equal(time.Unix(1030, 0)) || !clk.BaseNow().Equal(base.now) {
+		t.Errorf("Unexpected times %v, %v", clk.Now(), clk.BaseNow())
+	}
+
+	// A new base keeps the offset
+	clk.SetBase(&fixedClock{now: time.Unix(2000, 0)})
+	if !clk.Now().Equal(time.Unix(2030, 0)) {
+		t.Errorf("Expected the offset over the new base, got %v", clk.Now())
+	}
+	clk.SetBase(nil)
+	if d := time.Until(clk.Now()); d < 29*time.Second || d > 31*time.Second {
+		t.Errorf("Expected the system clock plus 30s, got %v ahead", d)
+	}
+}
@@ -0,0 +1,257 @@
+package config
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"github.com/BurntSushi/toml"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+	"gopkg.in/yaml.v3"
+
+	"github.com/MaDal776/polymarket-go-client/pkg/types"
+)
+
+// Defaults
+const (
+	DefaultHost    = "https://clob.polymarket.com"
+	DefaultChainID = 137
+)
+
+// Environment variable names. Environment values take precedence over files.
+const (
+	EnvHost             = "CLOB_API_URL"
+	EnvHostAlias        = "POLYMARKET_HOST"
+	EnvChainID          = "CHAIN_ID"
+	EnvPrivateKey       = "PRIVATE_KEY"
+	EnvPrivateKeyFile   = "PRIVATE_KEY_FILE"           // Encrypted key file, used when PRIVATE_KEY is unset
+	EnvKeyPassphrase    = "PRIVATE_KEY_PASSPHRASE"     // Decrypts a scrypt key file
+	EnvKeyEncryptionKey = "PRIVATE_KEY_ENCRYPTION_KEY" // Decrypts a key file without a KDF; 32 bytes of hex
+	EnvSignatureType    = "SIGNATURE_TYPE"
+	EnvFunder           = "FUNDER"
+	EnvAPIKey           = types.EnvApiKey
+	EnvAPISecret        = types.EnvApiSecret
+	EnvAPIPassphrase    = types.EnvApiPassphrase
+	EnvDataAPIURL       = "DATA_API_URL"
+	EnvGammaURL         = "GAMMA_API_URL"
+	EnvWebSocketURL     = "CLOB_WS_URL"
+)
+
+// Endpoints holds optional overrides for auxiliary API hosts
+type Endpoints struct {
+	DataAPI   string `yaml:"data_api" toml:"data_api"`
+	Gamma     string `yaml:"gamma" toml:"gamma"`
+	WebSocket string `yaml:"websocket" toml:"websocket"`
+}
+
+// Config holds everything needed to construct a client
+type Config struct {
+	Host           string    `yaml:"host" toml:"host"`
+	ChainID        int64     `yaml:"chain_id" toml:"chain_id"`
+	PrivateKey     string    `yaml:"private_key" toml:"private_key"`
+	PrivateKeyFile string    `yaml:"private_key_file" toml:"private_key_file"` // Encrypted alternative to PrivateKey
+	SignatureType  int       `yaml:"signature_type" toml:"signature_type"`
+	Funder         string    `yaml:"funder" toml:"funder"`
+	APIKey         string    `yaml:"api_key" toml:"api_key"`
+	APISecret      string    `yaml:"api_secret" toml:"api_secret"`
+	APIPassphrase  string    `yaml:"api_passphrase" toml:"api_passphrase"`
+	Endpoints      Endpoints `yaml:"endpoints" toml:"endpoints"`
+}
+
+// Default returns a configuration with default values
+func Default() *Config {
+	return &Config{
+		Host:    DefaultHost,
+		ChainID: DefaultChainID,
+	}
+}
+
+// Load builds a configuration from defaults, then the optional file at path,
+// then environment variables. The file format is chosen by extension:
+// .yaml/.yml, .toml or .env. The result is resolved and validated.
+func Load(path string) (*Config, error) {
+	cfg, err := Read(path)
+	if err != nil {
+		return nil, err
+	}
+	if err := cfg.Resolve(); err != nil {
+		return nil, err
+	}
+	return cfg, nil
+}
+
+// Read builds a configuration as Load does, but leaves it unresolved and
+// unvalidated, so callers can apply overrides such as flags before calling
+// Resolve
+func Read(path string) (*Config, error) {
+	cfg := Default()
+
+	if path != "" {
+		if err := cfg.loadFile(path); err != nil {
+			return nil, err
+		}
+	}
+	if err := cfg.applyEnv(os.LookupEnv); err != nil {
+		return nil, err
+	}
+	return cfg, nil
+}
+
+// Resolve reads the private key from PrivateKeyFile when no private key is
+// set, then validates the configuration
+func (c *Config) Resolve() error {
+	if c.PrivateKey == "" && c.PrivateKeyFile != "" {
+		privateKey, err := ReadKeyFile(c.PrivateKeyFile, os.LookupEnv)
+		if err != nil {
+			return err
+		}
+		c.PrivateKey = privateKey
+	}
+	return c.Validate()
+}
+
+// Validate checks that the configuration is usable
+func (c *Config) Validate() error {
+	if c.Host == "" {
+		return fmt.Errorf("host is required")
+	}
+	if !strings.HasPrefix(c.Host, "http://") && !strings.HasPrefix(c.Host, "https://") {
+		return fmt.Errorf("invalid host %q: must start with http:// or https://", c.Host)
+	}
+	if c.ChainID != 137 && c.ChainID != 80002 {
+		return fmt.Errorf("unsupported chain ID: %d", c.ChainID)
+	}
+	if c.SignatureType < 0 || c.SignatureType > 2 {
+		return fmt.Errorf("invalid signature type: %d", c.SignatureType)
+	}
+	if c.PrivateKey != "" {
+		if _, err := crypto.HexToECDSA(strings.TrimPrefix(c.PrivateKey, "0x")); err != nil {
+			return fmt.Errorf("invalid private key: %w", err)
+		}
+	}
+	if c.Funder != "" && !common.IsHexAddress(c.Funder) {
+		return fmt.Errorf("invalid funder address: %s", c.Funder)
+	}
+
+	set := 0
+	for _, value := range []string{c.APIKey, c.APISecret, c.APIPassphrase} {
+		if value != "" {
+			set++
+		}
+	}
+	if set != 0 && set != 3 {
+		return fmt.Errorf("API key, secret and passphrase must be set together")
+	}
+	return nil
+}
+
+// Creds returns the configured API credentials, or nil when none are set
+func (c *Config) Creds() *types.ApiCreds {
+	if c.APIKey == "" {
+		return nil
+	}
+	return &types.ApiCreds{
+		ApiKey:        c.APIKey,
+		ApiSecret:     c.APISecret,
+		ApiPassphrase: c.APIPassphrase,
+	}
+}
+
+// FunderAddress returns the funder as a pointer, or nil when unset
+func (c *Config) FunderAddress() *string {
+	if c.Funder == "" {
+		return nil
+	}
+	funder := c.Funder
+	return &funder
+}
+
+// EnvOrDefault returns the environment variable or a default value
+func EnvOrDefault(key, defaultValue string) string {
+	if value := os.Getenv(key); value != "" {
+		return value
+	}
+	return defaultValue
+}
+
+// EnvIntOrDefault returns the environment variable parsed as an integer or a default value
+func EnvIntOrDefault(key string, defaultValue int64) int64 {
+	if value := os.Getenv(key); value != "" {
+		if intValue, err := strconv.ParseInt(value, 10, 64); err == nil {
+			return intValue
+		}
+	}
+	return defaultValue
+}
+
+// loadFile merges a configuration file into c
+func (c *Config) loadFile(path string) error {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return fmt.Errorf("failed to read config file: %w", err)
+	}
+
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".yaml", ".yml":
+		err = yaml.Unmarshal(data, c)
+	case ".toml":
+		err = toml.Unmarshal(data, c)
+	case ".env":
+		var values map[string]string
+		values, err = types.ParseDotEnv(string(data))
+		if err == nil {
+			err = c.applyEnv(func(key string) (string, bool) {
+				value, ok := values[key]
+				return value, ok
+			})
+		}
+	default:
+		return fmt.Errorf("unsupported config file type: %s", path)
+	}
+	if err != nil {
+		return fmt.Errorf("failed to parse config file %s: %w", path, err)
+	}
+	return nil
+}
+
+// applyEnv overrides fields with values found by lookup
+func (c *Config) applyEnv(lookup func(key string) (string, bool)) error {
+	fields := map[string]*string{
+		EnvHostAlias:      &c.Host,
+		EnvHost:           &c.Host, // Applied after the alias so it wins
+		EnvPrivateKey:     &c.PrivateKey,
+		EnvPrivateKeyFile: &c.PrivateKeyFile,
+		EnvFunder:         &c.Funder,
+		EnvAPIKey:         &c.APIKey,
+		EnvAPISecret:      &c.APISecret,
+		EnvAPIPassphrase:  &c.APIPassphrase,
+		EnvDataAPIURL:     &c.Endpoints.DataAPI,
+		EnvGammaURL:       &c.Endpoints.Gamma,
+		EnvWebSocketURL:   &c.Endpoints.WebSocket,
+	}
+	for _, key := range []string{EnvHostAlias, EnvHost, EnvPrivateKey, EnvPrivateKeyFile, EnvFunder, EnvAPIKey,
+		EnvAPISecret, EnvAPIPassphrase, EnvDataAPIURL, EnvGammaURL, EnvWebSocketURL} {
+		if value, ok := lookup(key); ok && value != "" {
+			*fields[key] = value
+		}
+	}
+
+	if value, ok := lookup(EnvChainID); ok && value != "" {
+		chainID, err := strconv.ParseInt(value, 10, 64)
+		if err != nil {
+			return fmt.Errorf("invalid %s: %s", EnvChainID, value)
+		}
+		c.ChainID = chainID
+	}
+	if value, ok := lookup(EnvSignatureType); ok && value != "" {
+		signatureType, err := strconv.Atoi(value)
+		if err != nil {
+			return fmt.Errorf("invalid %s: %s", EnvSignatureType, value)
+		}
+		c.SignatureType = signatureType
+	}
+	return nil
+}
@@ -0,0 +1,113 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestLoadFileWithEnvPrecedence(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "config.yaml")
+	content := "host: https://file.example.com\nchain_id: 80002\nsignature_type: 1\nendpoints:\n  gamma: https://gamma.example.com\n"
+	if err := os.WriteFile(path, []byte(content), 0600); err != nil {
+		t.Fatalf("Failed to write config: %v", err)
+	}
+
+	t.Setenv(EnvHost, "https://env.example.com")
+	t.Setenv(EnvChainID, "")
+
+	cfg, err := Load(path)
+	if err != nil {
+		t.Fatalf("Failed to load config: %v", err)
+	}
+	if cfg.Host != "https://env.example.com" {
+		t.Errorf("Expected env host to win, got %s", cfg.Host)
+	}
+	if cfg.ChainID != 80002 || cfg.SignatureType != 1 {
+		t.Errorf("Expected file values, got chain %d signature type %d", cfg.ChainID, cfg.SignatureType)
+	}
+	if cfg.Endpoints.Gamma != "https://gamma.example.com" {
+		t.Errorf("Expected gamma override, got %s", cfg.Endpoints.Gamma)
+	}
+}
+
+func TestLoadDotEnvAndTOML(t *testing.T) {
+	dir := t.TempDir()
+	envPath := filepath.Join(dir, "test.env")
+	if err := os.WriteFile(envPath, []byte("# comment\nexport CHAIN_ID=80002\nFUNDER=\"0x1111111111111111111111111111111111111111\"\n"), 0600); err != nil {
+		t.Fatalf("Failed to write env file: %v", err)
+	}
+	cfg, err := Load(envPath)
+	if err != nil {
+		t.Fatalf("Failed to load env file: %v", err)
+	}
+	if cfg.ChainID != 80002 || cfg.Funder != "0x1111111111111111111111111111111111111111" {
+		t.Errorf("Unexpected config from env file: %+v", cfg)
+	}
+
+	tomlPath := filepath.Join(dir, "config.toml")
+	if err := os.WriteFile(tomlPath, []byte("chain_id = 80002\n[endpoints]\ndata_api = \"https://data.example.com\"\n"), 0600); err != nil {
+		t.Fatalf("Failed to write toml file: %v", err)
+	}
+	cfg, err = Load(tomlPath)
+	if err != nil {
+		t.Fatalf("Failed to load toml file: %v", err)
+	}
+	if cfg.Endpoints.DataAPI != "https://data.example.com" {
+		t.Errorf("Expected data API override, got %s", cfg.Endpoints.DataAPI)
+	}
+}
+
+func TestValidate(t *testing.T) {
+	cfg := Default()
+	cfg.APIKey = "key"
+	if err := cfg.Validate(); err == nil {
+		t.Error("Expected partial API credentials to be rejected")
+	}
+
+	cfg = Default()
+	cfg.ChainID = 1
+	if err := cfg.Validate(); err == nil {
+		t.Error("Expected unsupported chain ID to be rejected")
+	}
+
+	cfg = Default()
+	cfg.PrivateKey = "0x" + strings.Repeat("zz", 32)
+	if err := cfg.Validate(); err == nil {
+		t.Error("Expected a private key that is not hex to be rejected")
+	}
+
+	cfg = Default()
+	cfg.Funder = "0x" + strings.Repeat("g", 40)
+	if err := cfg.Validate(); err == nil {
+		t.Error("Expected a funder that is not hex to be rejected")
+	}
+
+	cfg = Default()
+	cfg.PrivateKey = "0x4c0883a69102937d6231471b5dbb6204fe5129617082792ae468d01a3f362318"
+	cfg.Funder = "0x1111111111111111111111111111111111111111"
+	if err := cfg.Validate(); err != nil {
+		t.Errorf("Expected a valid key and funder to pass: %v", err)
+	}
+}
+
+func TestReadLeavesValidationToResolve(t *testing.T) {
+	t.Setenv(EnvHost, "")
+	t.Setenv(EnvHostAlias, "")
+	t.Setenv(EnvChainID, "1")
+
+	if _, err := Load(""); err == nil {
+		t.Fatal("Expected Load to reject the unsupported chain ID")
+	}
+	// An override applied after Read fixes what Load would reject
+	cfg, err := Read("")
+	if err != nil {
+		t.Fatalf("Read failed: %v", err)
+	}
+	cfg.ChainID = 137
+	if err := cfg.Resolve(); err != nil {
+		t.Errorf("Expected the overridden config to be valid, got %v", err)
+	}
+}
@@ -0,0 +1,217 @@
+package config
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+
+	"golang.org/x/crypto/scrypt"
+)
+
+// Key file KDFs
+const (
+	KDFScrypt = "scrypt" // The AES key is derived from a passphrase
+	KDFNone   = "none"   // The AES key is given directly, e.g. from a secret manager
+)
+
+// scrypt parameters for new key files
+const (
+	scryptN = 1 << 15
+	scryptR = 8
+	scryptP = 1
+)
+
+// Bounds on the scrypt parameters of a key file being decrypted, so a file
+// cannot ask for a weak key or for more memory and time than a signer has
+const (
+	minScryptN      = 1 << 14
+	maxScryptMemory = 1 << 30 // Bytes: 128 * N * r
+	maxScryptP      = 16
+	minSaltLength   = 16
+)
+
+// ErrWrongPassphrase is returned when a key file cannot be decrypted with the given secret
+var ErrWrongPassphrase = errors.New("wrong passphrase or encryption key")
+
+// KeyFile is a private key encrypted with AES-256-GCM. Every field but the
+// nonce and ciphertext is authenticated as additional data, so the header
+// cannot be changed without failing decryption.
+type KeyFile struct {
+	Version    int    `json:"version"`
+	KDF        string `json:"kdf"`
+	Salt       string `json:"salt,omitempty"` // Hex; scrypt only
+	N          int    `json:"n,omitempty"`
+	R          int    `json:"r,omitempty"`
+	P          int    `json:"p,omitempty"`
+	Nonce      string `json:"nonce"`      // Hex
+	Ciphertext string `json:"ciphertext"` // Hex
+}
+
+// EncryptPrivateKey encrypts a hex private key with a key derived from passphrase.
+// The result is the JSON key file read by DecryptPrivateKey.
+func EncryptPrivateKey(privateKey string, passphrase []byte) ([]byte, error) {
+	if len(passphrase) == 0 {
+		return nil, fmt.Errorf("passphrase is required")
+	}
+	salt := make([]byte, 32)
+	if _, err := rand.Read(salt); err != nil {
+		return nil, fmt.Errorf("failed to generate salt: %w", err)
+	}
+	aesKey, err := scrypt.Key(passphrase, salt, scryptN, scryptR, scryptP, 32)
+	if err != nil {
+		return nil, fmt.Errorf("failed to derive key: %w", err)
+	}
+	file := KeyFile{KDF: KDFScrypt, Salt: hex.EncodeToString(salt), N: scryptN, R: scryptR, P: scryptP}
+	return sealKey(file, privateKey, aesKey)
+}
+
+// EncryptPrivateKeyWithKey encrypts a hex private key with a 32-byte AES key
+func EncryptPrivateKeyWithKey(privateKey string, aesKey []byte) ([]byte, error) {
+	if len(aesKey) != 32 {
+		return nil, fmt.Errorf("encryption key must be 32 bytes, got %d", len(aesKey))
+	}
+	return sealKey(KeyFile{KDF: KDFNone}, privateKey, aesKey)
+}
+
+// DecryptPrivateKey decrypts a key file. secret is the passphrase for scrypt
+// files and the 32-byte AES key for files without a KDF.
+func DecryptPrivateKey(data []byte, secret []byte) (string, error) {
+	var file KeyFile
+	if err := json.Unmarshal(data, &file); err != nil {
+		return "", fmt.Errorf("failed to parse key file: %w", err)
+	}
+	if file.Version != 1 {
+		return "", fmt.Errorf("unsupported key file version %d", file.Version)
+	}
+
+	aesKey := secret
+	switch file.KDF {
+	case KDFScrypt:
+		salt, err := hex.DecodeString(file.Salt)
+		if err != nil || len(salt) < minSaltLength {
+			return "", fmt.Errorf("invalid key file salt")
+		}
+		if err := checkScryptParams(file.N, file.R, file.P); err != nil {
+			return "", err
+		}
+		if aesKey, err = scrypt.Key(secret, salt, file.N, file.R, file.P, 32); err != nil {
+			return "", fmt.Errorf("failed to derive key: %w", err)
+		}
+	case KDFNone:
+		if len(aesKey) != 32 {
+			return "", fmt.Errorf("encryption key must be 32 bytes, got %d", len(aesKey))
+		}
+	default:
+		return "", fmt.Errorf("unsupported key file KDF %q", file.KDF)
+	}
+
+	gcm, err := newGCM(aesKey)
+	if err != nil {
+		return "", err
+	}
+	nonce, err := hex.DecodeString(file.Nonce)
+	if err != nil || len(nonce) != gcm.NonceSize() {
+		return "", fmt.Errorf("invalid key file nonce")
+	}
+	ciphertext, err := hex.DecodeString(file.Ciphertext)
+	if err != nil {
+		return "", fmt.Errorf("invalid key file ciphertext: %w", err)
+	}
+	plaintext, err := gcm.Open(nil, nonce, ciphertext, additionalData(file))
+	if err != nil {
+		return "", ErrWrongPassphrase
+	}
+	return string(plaintext), nil
+}
+
+// ReadKeyFile decrypts the key file at path. The secret comes from lookup:
+// PRIVATE_KEY_ENCRYPTION_KEY (hex) for files without a KDF, otherwise
+// PRIVATE_KEY_PASSPHRASE.
+func ReadKeyFile(path string, lookup func(key string) (string, bool)) (string, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return "", fmt.Errorf("failed to read key file: %w", err)
+	}
+
+	var file KeyFile
+	if err := json.Unmarshal(data, &file); err != nil {
+		return "", fmt.Errorf("failed to parse key file %s: %w", path, err)
+	}
+	var secret []byte
+	if file.KDF == KDFNone {
+		value, _ := lookup(EnvKeyEncryptionKey)
+		if value == "" {
+			return "", fmt.Errorf("%s is required to decrypt %s", EnvKeyEncryptionKey, path)
+		}
+		if secret, err = hex.DecodeString(strings.TrimPrefix(value, "0x")); err != nil {
+			return "", fmt.Errorf("invalid %s: %w", EnvKeyEncryptionKey, err)
+		}
+	} else {
+		value, _ := lookup(EnvKeyPassphrase)
+		if value == "" {
+			return "", fmt.Errorf("%s is required to decrypt %s", EnvKeyPassphrase, path)
+		}
+		secret = []byte(value)
+	}
+
+	privateKey, err := DecryptPrivateKey(data, secret)
+	if err != nil {
+		return "", fmt.Errorf("failed to decrypt %s: %w", path, err)
+	}
+	return privateKey, nil
+}
+
+// sealKey fills in the nonce and ciphertext of a key file
+func sealKey(file KeyFile, privateKey string, aesKey []byte) ([]byte, error) {
+	gcm, err := newGCM(aesKey)
+	if err != nil {
+		return nil, err
+	}
+	nonce := make([]byte, gcm.NonceSize())
+	if _, err := rand.Read(nonce); err != nil {
+		return nil, fmt.Errorf("failed to generate nonce: %w", err)
+	}
+
+	file.Version = 1
+	file.Nonce = hex.EncodeToString(nonce)
+	file.Ciphertext = hex.EncodeToString(gcm.Seal(nil, nonce, []byte(privateKey), additionalData(file)))
+	return json.MarshalIndent(file, "", "  ")
+}
+
+// additionalData is the header of a key file authenticated along with its
+// ciphertext: the file's JSON without the nonce and ciphertext
+func additionalData(file KeyFile) []byte {
+	file.Nonce = ""
+	file.Ciphertext = ""
+	data, _ := json.Marshal(file)
+	return data
+}
+
+// checkScryptParams refuses scrypt parameters outside the key file bounds
+func checkScryptParams(n, r, p int) error {
+	if n < minScryptN || n&(n-1) != 0 {
+		return fmt.Errorf("key file scrypt N must be a power of two of at least %d, got %d", minScryptN, n)
+	}
+	if r < 1 || p < 1 || p > maxScryptP {
+		return fmt.Errorf("key file scrypt r and p must be positive and p at most %d, got r=%d p=%d", maxScryptP, r, p)
+	}
+	if r > maxScryptMemory/128/n {
+		return fmt.Errorf("key file scrypt parameters need more than %d MiB", maxScryptMemory>>20)
+	}
+	return nil
+}
+
+// newGCM returns an AES-GCM cipher for a 32-byte key
+func newGCM(aesKey []byte) (cipher.AEAD, error) {
+	block, err := aes.NewCipher(aesKey)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create cipher: %w", err)
+	}
+	return cipher.NewGCM(block)
+}
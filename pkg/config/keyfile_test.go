@@ -0,0 +1,91 @@
+package config
+
+import (
+	"encoding/json"
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+const testPrivateKey = "0x4c0883a69102937d6231471b5dbb6204fe5129617082792ae468d01a3f362318"
+
+func TestLoadEncryptedKeyFile(t *testing.T) {
+	data, err := EncryptPrivateKey(testPrivateKey, []byte("correct horse"))
+	if err != nil {
+		t.Fatalf("EncryptPrivateKey failed: %v", err)
+	}
+	if strings.Contains(string(data), strings.TrimPrefix(testPrivateKey, "0x")) {
+		t.Fatal("Key file contains the plaintext key")
+	}
+	path := filepath.Join(t.TempDir(), "key.json")
+	if err := os.WriteFile(path, data, 0600); err != nil {
+		t.Fatalf("Failed to write key file: %v", err)
+	}
+
+	t.Setenv(EnvPrivateKey, "")
+	t.Setenv(EnvPrivateKeyFile, path)
+	t.Setenv(EnvKeyPassphrase, "correct horse")
+	cfg, err := Load("")
+	if err != nil {
+		t.Fatalf("Failed to load config: %v", err)
+	}
+	if cfg.PrivateKey != testPrivateKey {
+		t.Errorf("Expected the decrypted key, got %q", cfg.PrivateKey)
+	}
+
+	t.Setenv(EnvKeyPassphrase, "wrong")
+	if _, err := Load(""); !errors.Is(err, ErrWrongPassphrase) {
+		t.Errorf("Expected ErrWrongPassphrase, got %v", err)
+	}
+}
+
+func TestDecryptWithEncryptionKey(t *testing.T) {
+	aesKey := []byte("0123456789abcdef0123456789abcdef")
+	data, err := EncryptPrivateKeyWithKey(testPrivateKey, aesKey)
+	if err != nil {
+		t.Fatalf("EncryptPrivateKeyWithKey failed: %v", err)
+	}
+	privateKey, err := DecryptPrivateKey(data, aesKey)
+	if err != nil || privateKey != testPrivateKey {
+		t.Errorf("Expected the original key, got %q, %v", privateKey, err)
+	}
+}
+
+func TestDecryptRefusesChangedHeader(t *testing.T) {
+	data, err := EncryptPrivateKey(testPrivateKey, []byte("correct horse"))
+	if err != nil {
+		t.Fatalf("EncryptPrivateKey failed: %v", err)
+	}
+	edit := func(change func(file *KeyFile)) []byte {
+		var file KeyFile
+		if err := json.Unmarshal(data, &file); err != nil {
+			t.Fatalf("Failed to parse key file: %v", err)
+		}
+		change(&file)
+		edited, _ := json.Marshal(file)
+		return edited
+	}
+
+	// The header is authenticated, so a change the KDF still accepts fails to decrypt
+	edited := edit(func(file *KeyFile) { file.P = 2 })
+	if _, err := DecryptPrivateKey(edited, []byte("correct horse")); !errors.Is(err, ErrWrongPassphrase) {
+		t.Errorf("Expected a changed header to fail decryption, got %v", err)
+	}
+
+	for name, change := range map[string]func(file *KeyFile){
+		"small N":     func(file *KeyFile) { file.N = 1 << 10 },
+		"N not pow2":  func(file *KeyFile) { file.N = 3 << 14 },
+		"huge memory": func(file *KeyFile) { file.N = 1 << 30 },
+		"huge r":      func(file *KeyFile) { file.R = 1 << 20 },
+		"zero p":      func(file *KeyFile) { file.P = 0 },
+		"huge p":      func(file *KeyFile) { file.P = 1 << 20 },
+		"short salt":  func(file *KeyFile) { file.Salt = "00" },
+	} {
+		_, err := DecryptPrivateKey(edit(change), []byte("correct horse"))
+		if err == nil || errors.Is(err, ErrWrongPassphrase) {
+			t.Errorf("%s: expected the parameters to be refused before deriving, got %v", name, err)
+		}
+	}
+}
@@ -0,0 +1,319 @@
+package dataapi
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/MaDal776/polymarket-go-client/pkg/types"
+	"github.com/MaDal776/polymarket-go-client/pkg/version"
+)
+
+// DefaultHost is the public Polymarket data API host
+const DefaultHost = "https://data-api.polymarket.com"
+
+// Position paging limits of the data API
+const (
+	PositionsPageSize  = 500   // Positions requested per page, the API's maximum
+	MaxPositionsOffset = 10000 // Largest offset the API accepts
+)
+
+// API endpoints
+const (
+	GetPositions    = "/positions"
+	GetOpenInterest = "/oi"
+	GetActivity     = "/activity"
+	GetTrades       = "/trades"
+)
+
+// ActivityParams selects a user's activity. Zero values are omitted.
+type ActivityParams struct {
+	User   string   // Proxy wallet address; required
+	Market []string // Condition IDs
+	Types  []string // e.g. types.ActivityTrade
+	Side   types.OrderSide
+	Start  int64 // Unix seconds
+	End    int64 // Unix seconds
+	Limit  int
+	Offset int
+}
+
+// TradeParams filters the trade feed. Zero values are omitted.
+type TradeParams struct {
+	Market    []string // Condition IDs
+	User      string   // Proxy wallet address
+	Side      types.OrderSide
+	TakerOnly bool
+	Limit     int
+	Offset    int
+}
+
+// DataClient is a client for the public Polymarket data API
+type DataClient struct {
+	host       string
+	userAgent  string
+	httpClient *http.Client
+	metrics    []types.PerformanceMetrics
+}
+
+// NewDataClient creates a new data API client. An empty host uses DefaultHost.
+func NewDataClient(host string) *DataClient {
+	if host == "" {
+		host = DefaultHost
+	}
+	host = strings.TrimSuffix(host, "/")
+
+	return &DataClient{
+		host:       host,
+		userAgent:  version.UserAgent(""),
+		httpClient: &http.Client{Timeout: 30 * time.Second},
+		metrics:    make([]types.PerformanceMetrics, 0),
+	}
+}
+
+// SetUserAgent names the application in the User-Agent sent with every request,
+// ahead of the library version
+func (d *DataClient) SetUserAgent(app string) {
+	d.userAgent = version.UserAgent(app)
+}
+
+// GetPositions gets every current position held by a user (proxy wallet)
+// address, paging until the data API returns a short page. It fails rather
+// than return a partial list, so a token missing from the result is not held.
+func (d *DataClient) GetPositions(user string) ([]types.UserPosition, error) {
+	start := time.Now()
+
+	positions := make([]types.UserPosition, 0)
+	for offset := 0; ; offset += PositionsPageSize {
+		if offset > MaxPositionsOffset {
+			err := fmt.Errorf("user holds more positions than the data API can page through (%d)", MaxPositionsOffset)
+			d.recordMetric("positions_retrieval", start, false, err.Error())
+			return nil, err
+		}
+		query := url.Values{}
+		query.Set("user", user)
+		query.Set("sizeThreshold", "0")
+		query.Set("limit", strconv.Itoa(PositionsPageSize))
+		query.Set("offset", strconv.Itoa(offset))
+		resp, err := d.get(d.host + GetPositions + "?" + query.Encode())
+		if err != nil {
+			d.recordMetric("positions_retrieval", start, false, err.Error())
+			return nil, fmt.Errorf("failed to get positions: %w", err)
+		}
+
+		var page []types.UserPosition
+		if err := json.Unmarshal(resp, &page); err != nil {
+			d.recordMetric("positions_retrieval", start, false, err.Error())
+			return nil, fmt.Errorf("failed to parse positions response: %w", err)
+		}
+		positions = append(positions, page...)
+		if len(page) < PositionsPageSize {
+			break
+		}
+	}
+
+	d.recordMetric("positions_retrieval", start, true, "")
+	return positions, nil
+}
+
+// CompletePositions reports that GetPositions returns all of a user's
+// positions or fails (see portfolio.CompletePositionSource)
+func (d *DataClient) CompletePositions() bool {
+	return true
+}
+
+// GetOpenInterest gets the open interest, in USDC, of markets by condition ID
+func (d *DataClient) GetOpenInterest(conditionIDs ...string) (map[string]float64, error) {
+	start := time.Now()
+
+	url := fmt.Sprintf("%s%s?market=%s", d.host, GetOpenInterest, strings.Join(conditionIDs, ","))
+	resp, err := d.get(url)
+	if err != nil {
+		d.recordMetric("open_interest_retrieval", start, false, err.Error())
+		return nil, fmt.Errorf("failed to get open interest: %w", err)
+	}
+
+	var entries []struct {
+		Market string  `json:"market"`
+		Value  float64 `json:"value"`
+	}
+	if err := json.Unmarshal(resp, &entries); err != nil {
+		d.recordMetric("open_interest_retrieval", start, false, err.Error())
+		return nil, fmt.Errorf("failed to parse open interest response: %w", err)
+	}
+
+	openInterest := make(map[string]float64, len(entries))
+	for _, entry := range entries {
+		openInterest[entry.Market] = entry.Value
+	}
+	d.recordMetric("open_interest_retrieval", start, true, "")
+	return openInterest, nil
+}
+
+// AddOpenInterest fills in the OpenInterest of market stats (e.g. from
+// gamma.GammaClient.GetMarketStats)
+func (d *DataClient) AddOpenInterest(stats []types.MarketStats) error {
+	if len(stats) == 0 {
+		return nil
+	}
+	conditionIDs := make([]string, len(stats))
+	for i, stat := range stats {
+		conditionIDs[i] = stat.ConditionID
+	}
+	openInterest, err := d.GetOpenInterest(conditionIDs...)
+	if err != nil {
+		return err
+	}
+	for i := range stats {
+		stats[i].OpenInterest = openInterest[stats[i].ConditionID]
+	}
+	return nil
+}
+
+// GetActivity gets a user's activity feed (trades, splits, merges, redemptions,
+// rewards and conversions), newest first
+func (d *DataClient) GetActivity(params *ActivityParams) ([]types.Activity, error) {
+	start := time.Now()
+
+	if params == nil || params.User == "" {
+		d.recordMetric("activity_retrieval", start, false, "missing user")
+		return nil, fmt.Errorf("activity user is required")
+	}
+	query := url.Values{}
+	query.Set("user", params.User)
+	if len(params.Market) > 0 {
+		query.Set("market", strings.Join(params.Market, ","))
+	}
+	if len(params.Types) > 0 {
+		query.Set("type", strings.Join(params.Types, ","))
+	}
+	if params.Side != "" {
+		query.Set("side", string(params.Side))
+	}
+	if params.Start > 0 {
+		query.Set("start", strconv.FormatInt(params.Start, 10))
+	}
+	if params.End > 0 {
+		query.Set("end", strconv.FormatInt(params.End, 10))
+	}
+	if params.Limit > 0 {
+		query.Set("limit", strconv.Itoa(params.Limit))
+	}
+	if params.Offset > 0 {
+		query.Set("offset", strconv.Itoa(params.Offset))
+	}
+
+	resp, err := d.get(d.host + GetActivity + "?" + query.Encode())
+	if err != nil {
+		d.recordMetric("activity_retrieval", start, false, err.Error())
+		return nil, fmt.Errorf("failed to get activity: %w", err)
+	}
+
+	var activity []types.Activity
+	if err := json.Unmarshal(resp, &activity); err != nil {
+		d.recordMetric("activity_retrieval", start, false, err.Error())
+		return nil, fmt.Errorf("failed to parse activity response: %w", err)
+	}
+
+	d.recordMetric("activity_retrieval", start, true, "")
+	return activity, nil
+}
+
+// GetTrades gets recent trades across all markets, or those matching params, newest first
+func (d *DataClient) GetTrades(params *TradeParams) ([]types.PublicTrade, error) {
+	start := time.Now()
+
+	query := url.Values{}
+	if params != nil {
+		if len(params.Market) > 0 {
+			query.Set("market", strings.Join(params.Market, ","))
+		}
+		if params.User != "" {
+			query.Set("user", params.User)
+		}
+		if params.Side != "" {
+			query.Set("side", string(params.Side))
+		}
+		if params.TakerOnly {
+			query.Set("takerOnly", "true")
+		}
+		if params.Limit > 0 {
+			query.Set("limit", strconv.Itoa(params.Limit))
+		}
+		if params.Offset > 0 {
+			query.Set("offset", strconv.Itoa(params.Offset))
+		}
+	}
+
+	requestURL := d.host + GetTrades
+	if len(query) > 0 {
+		requestURL += "?" + query.Encode()
+	}
+	resp, err := d.get(requestURL)
+	if err != nil {
+		d.recordMetric("trades_retrieval", start, false, err.Error())
+		return nil, fmt.Errorf("failed to get trades: %w", err)
+	}
+
+	var trades []types.PublicTrade
+	if err := json.Unmarshal(resp, &trades); err != nil {
+		d.recordMetric("trades_retrieval", start, false, err.Error())
+		return nil, fmt.Errorf("failed to parse trades response: %w", err)
+	}
+
+	d.recordMetric("trades_retrieval", start, true, "")
+	return trades, nil
+}
+
+// get performs a GET request and returns the response body
+func (d *DataClient) get(url string) ([]byte, error) {
+	req, err := http.NewRequest("GET", url, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %w", err)
+	}
+	req.Header.Set("User-Agent", d.userAgent)
+	req.Header.Set(version.Header, version.Version)
+
+	resp, err := d.httpClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to make request: %w", err)
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read response: %w", err)
+	}
+
+	if resp.StatusCode >= 400 {
+		return nil, fmt.Errorf("HTTP %d: %s", resp.StatusCode, string(body))
+	}
+	return body, nil
+}
+
+// GetMetrics returns performance metrics
+func (d *DataClient) GetMetrics() []types.PerformanceMetrics {
+	return d.metrics
+}
+
+// ClearMetrics clears performance metrics
+func (d *DataClient) ClearMetrics() {
+	d.metrics = make([]types.PerformanceMetrics, 0)
+}
+
+// recordMetric records a performance metric
+func (d *DataClient) recordMetric(operation string, startTime time.Time, success bool, errorMsg string) {
+	metric := types.PerformanceMetrics{
+		Operation: operation,
+		StartTime: startTime,
+		Duration:  time.Since(startTime),
+		Success:   success,
+		Error:     errorMsg,
+	}
+	d.metrics = append(d.metrics, metric)
+}
@@ -0,0 +1,39 @@
+package dataapi
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"testing"
+
+	"github.com/MaDal776/polymarket-go-client/pkg/types"
+)
+
+func TestGetPositionsPagesUntilShortPage(t *testing.T) {
+	total := PositionsPageSize + 3
+	var offsets []int
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		offset, _ := strconv.Atoi(r.URL.Query().Get("offset"))
+		limit, _ := strconv.Atoi(r.URL.Query().Get("limit"))
+		offsets = append(offsets, offset)
+		page := make([]types.UserPosition, 0)
+		for i := offset; i < total && i < offset+limit; i++ {
+			page = append(page, types.UserPosition{Asset: fmt.Sprint(i), Size: 1})
+		}
+		json.NewEncoder(w).Encode(page)
+	}))
+	defer server.Close()
+
+	positions, err := NewDataClient(server.URL).GetPositions("0xme")
+	if err != nil {
+		t.Fatalf("GetPositions: %v", err)
+	}
+	if len(positions) != total || positions[total-1].Asset != fmt.Sprint(total-1) {
+		t.Errorf("Expected all %d positions, got %d", total, len(positions))
+	}
+	if len(offsets) != 2 || offsets[1] != PositionsPageSize {
+		t.Errorf("Expected two pages, requested offsets %v", offsets)
+	}
+}
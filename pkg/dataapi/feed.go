@@ -0,0 +1,153 @@
+package dataapi
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/MaDal776/polymarket-go-client/pkg/types"
+)
+
+// TradeSource fetches recent trades (e.g. the data API client)
+type TradeSource interface {
+	GetTrades(params *TradeParams) ([]types.PublicTrade, error)
+}
+
+// FeedConfig configures a TradeFeed
+type FeedConfig struct {
+	Interval    time.Duration // Polling interval used by Run; default 5s
+	Params      TradeParams   // Filters; Limit defaults to 500
+	DedupWindow time.Duration // How long delivered trades are remembered; default 10m
+
+	OnTrade func(trade types.PublicTrade)
+	OnError func(err error) // Called when a poll fails in Run
+}
+
+// TradeFeed delivers the platform-wide trade feed by polling, so flow can be
+// watched without subscribing to every market
+type TradeFeed struct {
+	source TradeSource
+	config FeedConfig
+
+	mu   sync.Mutex
+	seen map[string]time.Time
+}
+
+// NewTradeFeed creates a feed reading from source
+func NewTradeFeed(source TradeSource, config FeedConfig) (*TradeFeed, error) {
+	if source == nil {
+		return nil, fmt.Errorf("trade source is required")
+	}
+	if config.Interval <= 0 {
+		config.Interval = 5 * time.Second
+	}
+	if config.Params.Limit <= 0 {
+		config.Params.Limit = 500
+	}
+	if config.DedupWindow <= 0 {
+		config.DedupWindow = 10 * time.Minute
+	}
+
+	return &TradeFeed{
+		source: source,
+		config: config,
+		seen:   make(map[string]time.Time),
+	}, nil
+}
+
+// Poll fetches recent trades once and passes the ones not delivered before to
+// OnTrade, oldest first. It returns the trades delivered.
+func (f *TradeFeed) Poll() ([]types.PublicTrade, error) {
+	params := f.config.Params
+	trades, err := f.source.GetTrades(&params)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get trades: %w", err)
+	}
+
+	now := time.Now()
+	fresh := make([]types.PublicTrade, 0, len(trades))
+	f.mu.Lock()
+	for key, at := range f.seen {
+		if now.Sub(at) > f.config.DedupWindow {
+			delete(f.seen, key)
+		}
+	}
+	for _, trade := range trades {
+		key := tradeKey(trade)
+		if _, delivered := f.seen[key]; delivered {
+			continue
+		}
+		f.seen[key] = now
+		fresh = append(fresh, trade)
+	}
+	f.mu.Unlock()
+
+	sort.SliceStable(fresh, func(i, j int) bool { return fresh[i].Timestamp < fresh[j].Timestamp })
+	if f.config.OnTrade != nil {
+		for _, trade := range fresh {
+			f.config.OnTrade(trade)
+		}
+	}
+	return fresh, nil
+}
+
+// Run calls Poll every Interval until ctx is cancelled
+func (f *TradeFeed) Run(ctx context.Context) {
+	ticker := time.NewTicker(f.config.Interval)
+	defer ticker.Stop()
+
+	for {
+		if _, err := f.Poll(); err != nil && f.config.OnError != nil {
+			f.config.OnError(err)
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// tradeKey identifies a trade; one transaction can fill several wallets and assets
+func tradeKey(trade types.PublicTrade) string {
+	return fmt.Sprintf("%s|%s|%s|%s|%g|%g", trade.TransactionHash, trade.ProxyWallet, trade.Asset, trade.Side, trade.Size, trade.Price)
+}
+
+// MarketFlow is the traded volume of one market
+type MarketFlow struct {
+	ConditionID string  `json:"condition_id"`
+	Title       string  `json:"title"`
+	Trades      int     `json:"trades"`
+	Notional    float64 `json:"notional"`     // USDC traded
+	NetNotional float64 `json:"net_notional"` // USDC bought minus sold, across outcomes
+}
+
+// Flow aggregates trades by market, largest notional first
+func Flow(trades []types.PublicTrade) []MarketFlow {
+	byMarket := make(map[string]*MarketFlow)
+	for _, trade := range trades {
+		flow, ok := byMarket[trade.ConditionID]
+		if !ok {
+			flow = &MarketFlow{ConditionID: trade.ConditionID, Title: trade.Title}
+			byMarket[trade.ConditionID] = flow
+		}
+		notional := trade.Size * trade.Price
+		flow.Trades++
+		flow.Notional += notional
+		if trade.Side == types.BUY {
+			flow.NetNotional += notional
+		} else {
+			flow.NetNotional -= notional
+		}
+	}
+
+	flows := make([]MarketFlow, 0, len(byMarket))
+	for _, flow := range byMarket {
+		flows = append(flows, *flow)
+	}
+	sort.Slice(flows, func(i, j int) bool { return flows[i].Notional > flows[j].Notional })
+	return flows
+}
@@ -0,0 +1,54 @@
+package dataapi
+
+import (
+	"testing"
+
+	"github.com/MaDal776/polymarket-go-client/pkg/types"
+)
+
+type fakeTradeSource struct {
+	pages [][]types.PublicTrade
+	calls int
+}
+
+func (s *fakeTradeSource) GetTrades(params *TradeParams) ([]types.PublicTrade, error) {
+	page := s.pages[s.calls]
+	s.calls++
+	return page, nil
+}
+
+func TestTradeFeedDeliversNewTradesOnce(t *testing.T) {
+	a := types.PublicTrade{TransactionHash: "0x1", ConditionID: "m1", Side: types.BUY, Size: 10, Price: 0.5, Timestamp: 100}
+	b := types.PublicTrade{TransactionHash: "0x2", ConditionID: "m2", Side: types.SELL, Size: 4, Price: 0.25, Timestamp: 101}
+	c := types.PublicTrade{TransactionHash: "0x3", ConditionID: "m1", Side: types.SELL, Size: 2, Price: 0.5, Timestamp: 102}
+	source := &fakeTradeSource{pages: [][]types.PublicTrade{{b, a}, {c, b, a}}}
+
+	var delivered []string
+	feed, err := NewTradeFeed(source, FeedConfig{OnTrade: func(trade types.PublicTrade) {
+		delivered = append(delivered, trade.TransactionHash)
+	}})
+	if err != nil {
+		t.Fatalf("Failed to create feed: %v", err)
+	}
+
+	for i := 0; i < 2; i++ {
+		if _, err := feed.Poll(); err != nil {
+			t.Fatalf("Poll failed: %v", err)
+		}
+	}
+	want := []string{"0x1", "0x2", "0x3"}
+	if len(delivered) != len(want) {
+		t.Fatalf("Delivered %v, want %v", delivered, want)
+	}
+	for i := range want {
+		if delivered[i] != want[i] {
+			t.Errorf("Delivered %v, want %v", delivered, want)
+			break
+		}
+	}
+
+	flows := Flow([]types.PublicTrade{a, b, c})
+	if len(flows) != 2 || flows[0].ConditionID != "m1" || flows[0].Notional != 6 || flows[0].NetNotional != 4 || flows[0].Trades != 2 {
+		t.Errorf("Unexpected flows %+v", flows)
+	}
+}
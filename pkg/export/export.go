@@ -0,0 +1,211 @@
+package export
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"math"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/MaDal776/polymarket-go-client/pkg/types"
+)
+
+// Format is an export file format
+type Format string
+
+const (
+	CSV   Format = "csv"
+	JSONL Format = "jsonl"
+)
+
+// Record is a single normalized execution belonging to the exporting account
+type Record struct {
+	Timestamp       time.Time       `json:"timestamp"`
+	TradeID         string          `json:"trade_id"`
+	OrderID         string          `json:"order_id"`
+	Role            string          `json:"role"` // TAKER or MAKER
+	Market          string          `json:"market"`
+	TokenID         string          `json:"token_id"`
+	Outcome         string          `json:"outcome"`
+	Side            types.OrderSide `json:"side"`
+	Price           float64         `json:"price"`
+	Size            float64         `json:"size"`
+	Notional        float64         `json:"notional"`
+	FeeRateBps      int             `json:"fee_rate_bps"`
+	Fee             float64         `json:"fee"` // USDC
+	Status          string          `json:"status"`
+	TransactionHash string          `json:"transaction_hash"`
+}
+
+// csvHeader lists the CSV columns in output order
+var csvHeader = []string{
+	"timestamp", "trade_id", "order_id", "role", "market", "token_id", "outcome", "side",
+	"price", "size", "notional", "fee_rate_bps", "fee", "status", "transaction_hash",
+}
+
+// TradeSource provides the paginated trade history (e.g. the CLOB client)
+type TradeSource interface {
+	GetTrades(params *types.TradeParams) ([]types.Trade, error)
+}
+
+// Export pulls the full trade history matching params and writes the records
+// belonging to address to w. It returns the number of records written.
+func Export(src TradeSource, params *types.TradeParams, address string, format Format, w io.Writer) (int, error) {
+	trades, err := src.GetTrades(params)
+	if err != nil {
+		return 0, fmt.Errorf("failed to get trades: %w", err)
+	}
+
+	records, err := Normalize(trades, address)
+	if err != nil {
+		return 0, err
+	}
+
+	switch format {
+	case CSV:
+		err = WriteCSV(w, records)
+	case JSONL:
+		err = WriteJSONL(w, records)
+	default:
+		return 0, fmt.Errorf("unsupported export format: %s", format)
+	}
+	if err != nil {
+		return 0, err
+	}
+	return len(records), nil
+}
+
+// Normalize converts trades into records for address, sorted by time.
+// Taker trades yield one record; maker trades yield one record per maker order owned by address.
+func Normalize(trades []types.Trade, address string) ([]Record, error) {
+	records := make([]Record, 0, len(trades))
+	for _, trade := range trades {
+		timestamp := time.Time{}
+		if seconds, err := strconv.ParseInt(trade.MatchTime, 10, 64); err == nil {
+			timestamp = time.Unix(seconds, 0).UTC()
+		}
+
+		if strings.EqualFold(trade.TraderSide, "TAKER") {
+			record := Record{
+				Timestamp:       timestamp,
+				TradeID:         trade.ID,
+				OrderID:         trade.TakerOrderID,
+				Role:            "TAKER",
+				Market:          trade.Market,
+				TokenID:         trade.AssetID,
+				Outcome:         trade.Outcome,
+				Side:            trade.Side,
+				Status:          trade.Status,
+				TransactionHash: trade.TransactionHash,
+			}
+			if err := fillAmounts(&record, trade.Price, trade.Size, trade.FeeRateBps); err != nil {
+				return nil, fmt.Errorf("invalid trade %s: %w", trade.ID, err)
+			}
+			records = append(records, record)
+			continue
+		}
+
+		for _, makerOrder := range trade.MakerOrders {
+			if !strings.EqualFold(makerOrder.MakerAddress, address) {
+				continue
+			}
+			record := Record{
+				Timestamp:       timestamp,
+				TradeID:         trade.ID,
+				OrderID:         makerOrder.OrderID,
+				Role:            "MAKER",
+				Market:          trade.Market,
+				TokenID:         makerOrder.AssetID,
+				Outcome:         makerOrder.Outcome,
+				Side:            makerOrder.Side,
+				Status:          trade.Status,
+				TransactionHash: trade.TransactionHash,
+			}
+			if err := fillAmounts(&record, makerOrder.Price, makerOrder.MatchedAmount, makerOrder.FeeRateBps); err != nil {
+				return nil, fmt.Errorf("invalid maker order in trade %s: %w", trade.ID, err)
+			}
+			records = append(records, record)
+		}
+	}
+
+	sort.SliceStable(records, func(i, j int) bool {
+		return records[i].Timestamp.Before(records[j].Timestamp)
+	})
+	return records, nil
+}
+
+// WriteCSV writes records as CSV with a header row
+func WriteCSV(w io.Writer, records []Record) error {
+	writer := csv.NewWriter(w)
+	if err := writer.Write(csvHeader); err != nil {
+		return fmt.Errorf("failed to write CSV header: %w", err)
+	}
+
+	for _, r := range records {
+		row := []string{
+			r.Timestamp.Format(time.RFC3339),
+			r.TradeID,
+			r.OrderID,
+			r.Role,
+			r.Market,
+			r.TokenID,
+			r.Outcome,
+			string(r.Side),
+			formatFloat(r.Price),
+			formatFloat(r.Size),
+			formatFloat(r.Notional),
+			strconv.Itoa(r.FeeRateBps),
+			formatFloat(r.Fee),
+			r.Status,
+			r.TransactionHash,
+		}
+		if err := writer.Write(row); err != nil {
+			return fmt.Errorf("failed to write CSV row: %w", err)
+		}
+	}
+
+	writer.Flush()
+	return writer.Error()
+}
+
+// WriteJSONL writes records as one JSON object per line
+func WriteJSONL(w io.Writer, records []Record) error {
+	encoder := json.NewEncoder(w)
+	for _, r := range records {
+		if err := encoder.Encode(r); err != nil {
+			return fmt.Errorf("failed to write JSON record: %w", err)
+		}
+	}
+	return nil
+}
+
+// fillAmounts parses price, size and fee rate into the record and derives notional and fee.
+// The fee follows the exchange formula: rate * min(price, 1-price) * size.
+func fillAmounts(record *Record, price, size, feeRateBps string) error {
+	var err error
+	if record.Price, err = strconv.ParseFloat(price, 64); err != nil {
+		return fmt.Errorf("invalid price %q", price)
+	}
+	if record.Size, err = strconv.ParseFloat(size, 64); err != nil {
+		return fmt.Errorf("invalid size %q", size)
+	}
+	if feeRateBps != "" {
+		if record.FeeRateBps, err = strconv.Atoi(feeRateBps); err != nil {
+			return fmt.Errorf("invalid fee rate %q", feeRateBps)
+		}
+	}
+
+	record.Notional = record.Price * record.Size
+	rate := float64(record.FeeRateBps) / 10000
+	record.Fee = rate * math.Min(record.Price, 1-record.Price) * record.Size
+	return nil
+}
+
+// formatFloat formats a float without trailing zeros
+func formatFloat(value float64) string {
+	return strconv.FormatFloat(value, 'f', -1, 64)
+}
@@ -0,0 +1,83 @@
+package export
+
+import (
+	"bytes"
+	"encoding/csv"
+	"math"
+	"testing"
+
+	"github.com/MaDal776/polymarket-go-client/pkg/types"
+)
+
+func TestNormalizeTakerAndMaker(t *testing.T) {
+	trades := []types.Trade{
+		{
+			ID:         "t2",
+			TraderSide: "MAKER",
+			MatchTime:  "1700000100",
+			MakerOrders: []types.MakerOrder{
+				{OrderID: "o2", MakerAddress: "0xABC", MatchedAmount: "5", Price: "0.6", FeeRateBps: "0", AssetID: "tok", Side: types.SELL},
+				{OrderID: "o3", MakerAddress: "0xdef", MatchedAmount: "7", Price: "0.6", AssetID: "tok", Side: types.SELL},
+			},
+		},
+		{
+			ID:           "t1",
+			TraderSide:   "TAKER",
+			TakerOrderID: "o1",
+			MatchTime:    "1700000000",
+			AssetID:      "tok",
+			Side:         types.BUY,
+			Price:        "0.8",
+			Size:         "10",
+			FeeRateBps:   "100",
+		},
+	}
+
+	records, err := Normalize(trades, "0xabc")
+	if err != nil {
+		t.Fatalf("Failed to normalize trades: %v", err)
+	}
+	if len(records) != 2 {
+		t.Fatalf("Expected 2 records, got %d", len(records))
+	}
+
+	taker := records[0]
+	if taker.TradeID != "t1" || taker.Role != "TAKER" {
+		t.Fatalf("Expected taker record first, got %+v", taker)
+	}
+	if math.Abs(taker.Notional-8) > 1e-9 {
+		t.Errorf("Expected notional 8, got %f", taker.Notional)
+	}
+	// 1% of min(0.8, 0.2) * 10
+	if math.Abs(taker.Fee-0.02) > 1e-9 {
+		t.Errorf("Expected fee 0.02, got %f", taker.Fee)
+	}
+
+	maker := records[1]
+	if maker.OrderID != "o2" || maker.Size != 5 || maker.Side != types.SELL {
+		t.Errorf("Unexpected maker record: %+v", maker)
+	}
+}
+
+func TestWriteCSV(t *testing.T) {
+	records := []Record{{TradeID: "t1", Side: types.BUY, Price: 0.5, Size: 2, Notional: 1}}
+
+	var buf bytes.Buffer
+	if err := WriteCSV(&buf, records); err != nil {
+		t.Fatalf("Failed to write CSV: %v", err)
+	}
+
+	rows, err := csv.NewReader(&buf).ReadAll()
+	if err != nil {
+		t.Fatalf("Failed to read CSV: %v", err)
+	}
+	if len(rows) != 2 {
+		t.Fatalf("Expected header and 1 row, got %d rows", len(rows))
+	}
+	if len(rows[1]) != len(csvHeader) {
+		t.Fatalf("Expected %d columns, got %d", len(csvHeader), len(rows[1]))
+	}
+	if rows[1][8] != "0.5" || rows[1][9] != "2" {
+		t.Errorf("Unexpected price/size columns: %v", rows[1])
+	}
+}
@@ -0,0 +1,100 @@
+// Package fees implements the CTF exchange fee formula. The fee scales with
+// min(price, 1-price), so trades near 0 or 1 pay almost nothing:
+//
+//	BUY:  fee = rate * min(p, 1-p) * size / p   charged in shares received
+//	SELL: fee = rate * min(p, 1-p) * size       charged in USDC received
+//
+// where rate is the fee rate in basis points / 10000 and size is in shares.
+package fees
+
+import (
+	"math"
+
+	"github.com/MaDal776/polymarket-go-client/pkg/types"
+)
+
+// bpsDivisor converts basis points to a fraction
+const bpsDivisor = 10000
+
+// Fee is the fee charged on a fill
+type Fee struct {
+	Shares float64 `json:"shares"` // Deducted from the shares received (BUY)
+	USDC   float64 `json:"usdc"`   // Deducted from the USDC received (SELL)
+	Value  float64 `json:"value"`  // The fee valued in USDC at the fill price
+}
+
+// Calculate returns the fee for filling size shares at price with the given fee rate
+func Calculate(side types.OrderSide, price, size float64, feeRateBps int) Fee {
+	if feeRateBps <= 0 || price <= 0 || price >= 1 || size <= 0 {
+		return Fee{}
+	}
+	base := float64(feeRateBps) / bpsDivisor * math.Min(price, 1-price) * size
+
+	if side == types.BUY {
+		shares := base / price
+		return Fee{Shares: shares, Value: shares * price}
+	}
+	return Fee{USDC: base, Value: base}
+}
+
+// EffectivePrice returns the price per share after fees: what a buyer pays per
+// share actually received, or what a seller receives per share sold
+func EffectivePrice(side types.OrderSide, price float64, feeRateBps int) float64 {
+	fee := Calculate(side, price, 1, feeRateBps)
+	if side == types.BUY {
+		if fee.Shares >= 1 {
+			return math.Inf(1)
+		}
+		return price / (1 - fee.Shares)
+	}
+	return price - fee.USDC
+}
+
+// Model holds a market's maker and taker fee rates
+type Model struct {
+	MakerBps int `json:"maker_bps"`
+	TakerBps int `json:"taker_bps"`
+}
+
+// ModelFromMarket returns the fee rates a market reports
+func ModelFromMarket(market *types.Market) Model {
+	return Model{MakerBps: market.MakerBaseFee, TakerBps: market.TakerBaseFee}
+}
+
+// Rate returns the taker or maker fee rate
+func (m Model) Rate(taker bool) int {
+	if taker {
+		return m.TakerBps
+	}
+	return m.MakerBps
+}
+
+// Fee returns the fee for a fill as maker or taker
+func (m Model) Fee(side types.OrderSide, price, size float64, taker bool) Fee {
+	return Calculate(side, price, size, m.Rate(taker))
+}
+
+// EffectivePrice returns the per-share price after fees as maker or taker
+func (m Model) EffectivePrice(side types.OrderSide, price float64, taker bool) float64 {
+	return EffectivePrice(side, price, m.Rate(taker))
+}
+
+// NetPnL returns the profit of buying size shares at entry and selling them at
+// exit, after the fees on both fills
+func (m Model) NetPnL(entry, exit, size float64, takerEntry, takerExit bool) float64 {
+	buy := m.Fee(types.BUY, entry, size, takerEntry)
+	held := size - buy.Shares
+	sell := m.Fee(types.SELL, exit, held, takerExit)
+	return held*exit - sell.USDC - entry*size
+}
+
+// CompleteSetCost returns the cost, after taker fees, of one share of each
+// outcome bought at the given prices. Buying every outcome is profitable when
+// this is below 1, since a complete set always redeems for 1 USDC.
+func (m Model) CompleteSetCost(prices ...float64) float64 {
+	cost := 0.0
+	for _, price := range prices {
+		cost += m.EffectivePrice(types.BUY, price, true)
+	}
+	return cost
+}
@@ -0,0 +1,58 @@
+package fees
+
+import (
+	"math"
+	"testing"
+
+	"github.com/MaDal776/polymarket-go-client/pkg/types"
+)
+
+func near(a, b float64) bool {
+	return math.Abs(a-b) < 1e-9
+}
+
+func TestCalculate(t *testing.T) {
+	// 200 bps on 100 shares at 0.4: 0.02 * 0.4 * 100 = 0.8
+	sell := Calculate(types.SELL, 0.4, 100, 200)
+	if !near(sell.USDC, 0.8) || sell.Shares != 0 || !near(sell.Value, 0.8) {
+		t.Errorf("Unexpected sell fee %+v", sell)
+	}
+
+	// Buys pay in shares: 0.8 / 0.4 = 2 shares, worth 0.8 USDC
+	buy := Calculate(types.BUY, 0.4, 100, 200)
+	if !near(buy.Shares, 2) || buy.USDC != 0 || !near(buy.Value, 0.8) {
+		t.Errorf("Unexpected buy fee %+v", buy)
+	}
+
+	// Symmetric around 0.5: 0.02 * min(0.7, 0.3) * 100 = 0.6
+	if fee := Calculate(types.SELL, 0.7, 100, 200); !near(fee.USDC, 0.6) {
+		t.Errorf("Sell fee at 0.7 = %v, want 0.6", fee.USDC)
+	}
+	if fee := Calculate(types.BUY, 0.5, 100, 0); fee != (Fee{}) {
+		t.Errorf("Expected no fee at 0 bps, got %+v", fee)
+	}
+}
+
+func TestModel(t *testing.T) {
+	model := ModelFromMarket(&types.Market{MakerBaseFee: 0, TakerBaseFee: 200})
+	if fee := model.Fee(types.BUY, 0.5, 10, false); fee != (Fee{}) {
+		t.Errorf("Expected no maker fee, got %+v", fee)
+	}
+
+	// Taker buy at 0.5 keeps 98% of the shares
+	if price := model.EffectivePrice(types.BUY, 0.5, true); !near(price, 0.5/0.98) {
+		t.Errorf("Effective buy price %v", price)
+	}
+	if price := model.EffectivePrice(types.SELL, 0.5, true); !near(price, 0.49) {
+		t.Errorf("Effective sell price %v", price)
+	}
+
+	// Buy 100 at 0.5 as taker (98 shares kept), sell them at 0.6 as maker
+	if pnl := model.NetPnL(0.5, 0.6, 100, true, false); !near(pnl, 98*0.6-50) {
+		t.Errorf("Net PnL %v", pnl)
+	}
+
+	if cost := model.CompleteSetCost(0.45, 0.5); !near(cost, 0.95/0.98) {
+		t.Errorf("Complete set cost %v", cost)
+	}
+}
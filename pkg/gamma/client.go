@@ -0,0 +1,357 @@
+package gamma
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/MaDal776/polymarket-go-client/pkg/types"
+	"github.com/MaDal776/polymarket-go-client/pkg/version"
+)
+
+// DefaultHost is the public Polymarket Gamma (market metadata) API host
+const DefaultHost = "https://gamma-api.polymarket.com"
+
+// API endpoints
+const (
+	GetMarkets  = "/markets"
+	GetComments = "/comments"
+)
+
+// MarketParams filters a Gamma markets query. Zero values are omitted.
+type MarketParams struct {
+	Limit        int
+	Offset       int
+	Active       *bool
+	Closed       *bool
+	Slugs        []string
+	ConditionIDs []string
+	TokenIDs     []string
+}
+
+// CommentParams selects the comments on an event, series or market. Zero values are omitted.
+type CommentParams struct {
+	ParentEntityType string // "Event", "Series" or "market"; required
+	ParentEntityID   int64  // Required
+	Limit            int
+	Offset           int
+	Order            string // Field to sort by, e.g. "createdAt"
+	Ascending        bool
+}
+
+// GammaClient is a client for the Polymarket Gamma API
+type GammaClient struct {
+	host       string
+	userAgent  string
+	httpClient *http.Client
+	metrics    []types.PerformanceMetrics
+}
+
+// NewGammaClient creates a new Gamma API client. An empty host uses DefaultHost.
+func NewGammaClient(host string) *GammaClient {
+	if host == "" {
+		host = DefaultHost
+	}
+	host = strings.TrimSuffix(host, "/")
+
+	return &GammaClient{
+		host:       host,
+		userAgent:  version.UserAgent(""),
+		httpClient: &http.Client{Timeout: 30 * time.Second},
+		metrics:    make([]types.PerformanceMetrics, 0),
+	}
+}
+
+// SetUserAgent names the application in the User-Agent sent with every request,
+// ahead of the library version
+func (g *GammaClient) SetUserAgent(app string) {
+	g.userAgent = version.UserAgent(app)
+}
+
+// GetMarkets gets markets matching params
+func (g *GammaClient) GetMarkets(params *MarketParams) ([]types.Market, error) {
+	start := time.Now()
+
+	raw, err := g.getMarkets(params)
+	if err != nil {
+		g.recordMetric("gamma_markets_retrieval", start, false, err.Error())
+		return nil, err
+	}
+
+	markets := make([]types.Market, 0, len(raw))
+	for _, m := range raw {
+		market, err := m.toMarket()
+		if err != nil {
+			g.recordMetric("gamma_markets_retrieval", start, false, err.Error())
+			return nil, fmt.Errorf("invalid market %s: %w", m.ConditionID, err)
+		}
+		markets = append(markets, market)
+	}
+
+	g.recordMetric("gamma_markets_retrieval", start, true, "")
+	return markets, nil
+}
+
+// GetMarketStats gets the volume and liquidity of markets matching params. Open
+// interest is not reported by Gamma; see dataapi.DataClient.AddOpenInterest.
+func (g *GammaClient) GetMarketStats(params *MarketParams) ([]types.MarketStats, error) {
+	start := time.Now()
+
+	raw, err := g.getMarkets(params)
+	if err != nil {
+		g.recordMetric("gamma_market_stats_retrieval", start, false, err.Error())
+		return nil, err
+	}
+
+	stats := make([]types.MarketStats, 0, len(raw))
+	for _, m := range raw {
+		stats = append(stats, types.MarketStats{
+			ConditionID: m.ConditionID,
+			Slug:        m.Slug,
+			Volume24h:   m.Volume24hr,
+			Volume:      m.VolumeNum,
+			Liquidity:   m.LiquidityNum,
+		})
+	}
+
+	g.recordMetric("gamma_market_stats_retrieval", start, true, "")
+	return stats, nil
+}
+
+// getMarkets queries the markets endpoint and decodes the wire format
+func (g *GammaClient) getMarkets(params *MarketParams) ([]gammaMarket, error) {
+	query := url.Values{}
+	if params != nil {
+		if params.Limit > 0 {
+			query.Set("limit", strconv.Itoa(params.Limit))
+		}
+		if params.Offset > 0 {
+			query.Set("offset", strconv.Itoa(params.Offset))
+		}
+		if params.Active != nil {
+			query.Set("active", strconv.FormatBool(*params.Active))
+		}
+		if params.Closed != nil {
+			query.Set("closed", strconv.FormatBool(*params.Closed))
+		}
+		for _, slug := range params.Slugs {
+			query.Add("slug", slug)
+		}
+		for _, conditionID := range params.ConditionIDs {
+			query.Add("condition_ids", conditionID)
+		}
+		for _, tokenID := range params.TokenIDs {
+			query.Add("clob_token_ids", tokenID)
+		}
+	}
+
+	requestURL := g.host + GetMarkets
+	if len(query) > 0 {
+		requestURL += "?" + query.Encode()
+	}
+	resp, err := g.get(requestURL)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get markets: %w", err)
+	}
+
+	var raw []gammaMarket
+	if err := json.Unmarshal(resp, &raw); err != nil {
+		return nil, fmt.Errorf("failed to parse markets response: %w", err)
+	}
+	return raw, nil
+}
+
+// GetMarketBySlug gets a single market by its slug
+func (g *GammaClient) GetMarketBySlug(slug string) (*types.Market, error) {
+	markets, err := g.GetMarkets(&MarketParams{Slugs: []string{slug}})
+	if err != nil {
+		return nil, err
+	}
+	if len(markets) == 0 {
+		return nil, fmt.Errorf("market %s not found", slug)
+	}
+	return &markets[0], nil
+}
+
+// gammaMarket is the Gamma wire format. Outcomes, prices and token IDs are JSON-encoded strings.
+type gammaMarket struct {
+	ConditionID           string  `json:"conditionId"`
+	QuestionID            string  `json:"questionID"`
+	Question              string  `json:"question"`
+	Description           string  `json:"description"`
+	Slug                  string  `json:"slug"`
+	Outcomes              string  `json:"outcomes"`
+	OutcomePrices         string  `json:"outcomePrices"`
+	ClobTokenIDs          string  `json:"clobTokenIds"`
+	OrderPriceMinTickSize float64 `json:"orderPriceMinTickSize"`
+	OrderMinSize          float64 `json:"orderMinSize"`
+	NegRisk               bool    `json:"negRisk"`
+	NegRiskMarketID       string  `json:"negRiskMarketID"`
+	Active                bool    `json:"active"`
+	Closed                bool    `json:"closed"`
+	Archived              bool    `json:"archived"`
+	AcceptingOrders       bool    `json:"acceptingOrders"`
+	AcceptingFrom         string  `json:"acceptingOrdersTimestamp"`
+	EnableOrderBook       bool    `json:"enableOrderBook"`
+	EndDate               string  `json:"endDate"`
+	Icon                  string  `json:"icon"`
+	Image                 string  `json:"image"`
+	RewardsMinSize        float64 `json:"rewardsMinSize"`
+	RewardsMaxSpread      float64 `json:"rewardsMaxSpread"`
+	Volume24hr            float64 `json:"volume24hr"`
+	VolumeNum             float64 `json:"volumeNum"`
+	LiquidityNum          float64 `json:"liquidityNum"`
+}
+
+// toMarket converts the Gamma wire format to a Market
+func (m gammaMarket) toMarket() (types.Market, error) {
+	market := types.Market{
+		ConditionID:      m.ConditionID,
+		QuestionID:       m.QuestionID,
+		Question:         m.Question,
+		Description:      m.Description,
+		Slug:             m.Slug,
+		MinimumTickSize:  m.OrderPriceMinTickSize,
+		MinimumOrderSize: m.OrderMinSize,
+		NegRisk:          m.NegRisk,
+		NegRiskMarketID:  m.NegRiskMarketID,
+		Active:           m.Active,
+		Closed:           m.Closed,
+		Archived:         m.Archived,
+		AcceptingOrders:  m.AcceptingOrders,
+		AcceptingFrom:    m.AcceptingFrom,
+		EnableOrderBook:  m.EnableOrderBook,
+		EndDate:          m.EndDate,
+		Icon:             m.Icon,
+		Image:            m.Image,
+		Rewards:          types.MarketRewards{MinSize: m.RewardsMinSize, MaxSpread: m.RewardsMaxSpread},
+	}
+
+	outcomes, err := decodeStringList(m.Outcomes)
+	if err != nil {
+		return market, fmt.Errorf("invalid outcomes: %w", err)
+	}
+	tokenIDs, err := decodeStringList(m.ClobTokenIDs)
+	if err != nil {
+		return market, fmt.Errorf("invalid token IDs: %w", err)
+	}
+	prices, err := decodeStringList(m.OutcomePrices)
+	if err != nil {
+		return market, fmt.Errorf("invalid outcome prices: %w", err)
+	}
+
+	for i, tokenID := range tokenIDs {
+		token := types.MarketToken{TokenID: tokenID}
+		if i < len(outcomes) {
+			token.Outcome = outcomes[i]
+		}
+		if i < len(prices) {
+			token.Price, _ = strconv.ParseFloat(prices[i], 64)
+		}
+		market.Tokens = append(market.Tokens, token)
+	}
+	return market, nil
+}
+
+// decodeStringList decodes a JSON-encoded list of strings, treating empty input as an empty list
+func decodeStringList(encoded string) ([]string, error) {
+	if encoded == "" {
+		return nil, nil
+	}
+	var values []string
+	if err := json.Unmarshal([]byte(encoded), &values); err != nil {
+		return nil, err
+	}
+	return values, nil
+}
+
+// GetComments gets the comments on an event, series or market
+func (g *GammaClient) GetComments(params *CommentParams) ([]types.Comment, error) {
+	start := time.Now()
+
+	if params == nil || params.ParentEntityType == "" {
+		g.recordMetric("gamma_comments_retrieval", start, false, "missing parent entity")
+		return nil, fmt.Errorf("comment parent entity type and ID are required")
+	}
+	query := url.Values{}
+	query.Set("parent_entity_type", params.ParentEntityType)
+	query.Set("parent_entity_id", strconv.FormatInt(params.ParentEntityID, 10))
+	if params.Limit > 0 {
+		query.Set("limit", strconv.Itoa(params.Limit))
+	}
+	if params.Offset > 0 {
+		query.Set("offset", strconv.Itoa(params.Offset))
+	}
+	if params.Order != "" {
+		query.Set("order", params.Order)
+		query.Set("ascending", strconv.FormatBool(params.Ascending))
+	}
+
+	resp, err := g.get(g.host + GetComments + "?" + query.Encode())
+	if err != nil {
+		g.recordMetric("gamma_comments_retrieval", start, false, err.Error())
+		return nil, fmt.Errorf("failed to get comments: %w", err)
+	}
+
+	var comments []types.Comment
+	if err := json.Unmarshal(resp, &comments); err != nil {
+		g.recordMetric("gamma_comments_retrieval", start, false, err.Error())
+		return nil, fmt.Errorf("failed to parse comments response: %w", err)
+	}
+
+	g.recordMetric("gamma_comments_retrieval", start, true, "")
+	return comments, nil
+}
+
+// get performs a GET request and returns the response body
+func (g *GammaClient) get(url string) ([]byte, error) {
+	req, err := http.NewRequest("GET", url, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %w", err)
+	}
+	req.Header.Set("User-Agent", g.userAgent)
+	req.Header.Set(version.Header, version.Version)
+
+	resp, err := g.httpClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to make request: %w", err)
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read response: %w", err)
+	}
+
+	if resp.StatusCode >= 400 {
+		return nil, fmt.Errorf("HTTP %d: %s", resp.StatusCode, string(body))
+	}
+	return body, nil
+}
+
+// GetMetrics returns performance metrics
+func (g *GammaClient) GetMetrics() []types.PerformanceMetrics {
+	return g.metrics
+}
+
+// ClearMetrics clears performance metrics
+func (g *GammaClient) ClearMetrics() {
+	g.metrics = make([]types.PerformanceMetrics, 0)
+}
+
+// recordMetric records a performance metric
+func (g *GammaClient) recordMetric(operation string, startTime time.Time, success bool, errorMsg string) {
+	metric := types.PerformanceMetrics{
+		Operation: operation,
+		StartTime: startTime,
+		Duration:  time.Since(startTime),
+		Success:   success,
+		Error:     errorMsg,
+	}
+	g.metrics = append(g.metrics, metric)
+}
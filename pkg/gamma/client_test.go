@@ -0,0 +1,78 @@
+package gamma
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestGetMarketsConvertsGammaFormat(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Query().Get("slug") != "will-it-rain" {
+			t.Errorf("Expected slug filter, got %s", r.URL.RawQuery)
+		}
+		w.Write([]byte(`[{"conditionId":"0xc","question":"Will it rain?","slug":"will-it-rain",` +
+			`"outcomes":"[\"Yes\",\"No\"]","outcomePrices":"[\"0.3\",\"0.7\"]","clobTokenIds":"[\"111\",\"222\"]",` +
+			`"orderPriceMinTickSize":0.01,"negRisk":true,"active":true,"endDate":"2030-01-01T00:00:00Z"}]`))
+	}))
+	defer server.Close()
+
+	market, err := NewGammaClient(server.URL).GetMarketBySlug("will-it-rain")
+	if err != nil {
+		t.Fatalf("Failed to get market: %v", err)
+	}
+	if market.ConditionID != "0xc" || !market.NegRisk || market.MinimumTickSize != 0.01 {
+		t.Errorf("Unexpected market: %+v", market)
+	}
+
+	no, ok := market.TokenByOutcome("no")
+	if !ok || no.TokenID != "222" || no.Price != 0.7 {
+		t.Errorf("Unexpected NO token: %+v", no)
+	}
+	if _, ok := market.EndTime(); !ok {
+		t.Error("Expected end date to parse")
+	}
+}
+
+func TestGetMarketStats(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`[{"conditionId":"0xc","slug":"will-it-rain","volume24hr":1250.5,"volumeNum":98000,"liquidityNum":4300.25}]`))
+	}))
+	defer server.Close()
+
+	stats, err := NewGammaClient(server.URL).GetMarketStats(&MarketParams{Slugs: []string{"will-it-rain"}})
+	if err != nil {
+		t.Fatalf("Failed to get market stats: %v", err)
+	}
+	if len(stats) != 1 {
+		t.Fatalf("Expected 1 market, got %d", len(stats))
+	}
+	if stats[0].ConditionID != "0xc" || stats[0].Volume24h != 1250.5 || stats[0].Volume != 98000 || stats[0].Liquidity != 4300.25 {
+		t.Errorf("Unexpected stats: %+v", stats[0])
+	}
+}
+
+func TestGetComments(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		query := r.URL.Query()
+		if r.URL.Path != GetComments || query.Get("parent_entity_type") != "Event" || query.Get("parent_entity_id") != "42" {
+			t.Errorf("Unexpected request %s", r.URL)
+		}
+		w.Write([]byte(`[{"id":"7","body":"Rain is coming","parentEntityType":"Event","parentEntityID":42,` +
+			`"userAddress":"0xabc","createdAt":"2025-01-01T00:00:00Z","reactionCount":3,"profile":{"name":"alice"}}]`))
+	}))
+	defer server.Close()
+
+	gamma := NewGammaClient(server.URL)
+	comments, err := gamma.GetComments(&CommentParams{ParentEntityType: "Event", ParentEntityID: 42})
+	if err != nil {
+		t.Fatalf("Failed to get comments: %v", err)
+	}
+	if len(comments) != 1 || comments[0].Body != "Rain is coming" || comments[0].ReactionCount != 3 || comments[0].Profile.Name != "alice" {
+		t.Errorf("Unexpected comments: %+v", comments)
+	}
+
+	if _, err := gamma.GetComments(nil); err == nil {
+		t.Error("Expected a missing parent entity to fail")
+	}
+}
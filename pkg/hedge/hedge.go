@@ -0,0 +1,305 @@
+// Package hedge keeps positions in correlated markets at a target ratio. Each
+// Pair names a primary token and a hedge token; whenever the hedge position
+// strays from Ratio times the primary position by more than a threshold, the
+// Hedger trades the hedge token to close the gap.
+package hedge
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/MaDal776/polymarket-go-client/pkg/client"
+	"github.com/MaDal776/polymarket-go-client/pkg/portfolio"
+	"github.com/MaDal776/polymarket-go-client/pkg/types"
+	"github.com/MaDal776/polymarket-go-client/pkg/utils"
+)
+
+// Pair hedges the position in Primary with a position in Hedge, e.g. another
+// candidate of a mutually exclusive event or the same event at a later date
+type Pair struct {
+	Primary   string  `json:"primary"`
+	Hedge     string  `json:"hedge"`
+	Ratio     float64 `json:"ratio"`               // Hedge shares wanted per primary share
+	Threshold float64 `json:"threshold,omitempty"` // Shares the hedge may be off before trading; default Config.Threshold
+}
+
+// Exposure returns the hedge shares missing (positive) or in excess (negative)
+// given the primary and hedge positions
+func (p Pair) Exposure(primary, hedge float64) float64 {
+	return p.Ratio*primary - hedge
+}
+
+// key identifies a pair
+func (p Pair) key() string {
+	return p.Primary + "/" + p.Hedge
+}
+
+// PositionProvider provides current positions (e.g. portfolio.Portfolio)
+type PositionProvider interface {
+	Position(tokenID string) (portfolio.Position, bool)
+}
+
+// Result describes one hedging order
+type Result struct {
+	Pair     Pair            `json:"pair"`
+	Exposure float64         `json:"exposure"` // Before the order
+	Order    types.OrderArgs `json:"order"`
+	OrderID  string          `json:"order_id,omitempty"`
+	Err      error           `json:"-"`
+}
+
+// Config configures a Hedger
+type Config struct {
+	Trader       client.Trader    // Required
+	Positions    PositionProvider // Required
+	Threshold    float64          // Default shares a hedge may be off before trading; default 5
+	MaxOrderSize float64          // Largest hedging order in shares; 0 means no limit
+	Slippage     float64          // Price allowed past the best quote; default 0
+	OrderType    types.OrderType  // Default FAK
+	Cooldown     time.Duration    // Wait after hedging a pair, so fills reach the positions; default 30s
+	Interval     time.Duration    // Interval used by Run; default 10s
+	OnHedge      func(result Result)
+	OnError      func(err error) // Called for each failed hedging order in Run
+}
+
+// Hedger trades hedge tokens to keep each pair's exposure within its threshold
+type Hedger struct {
+	config Config
+
+	mu      sync.Mutex
+	pairs   map[string]Pair
+	hedged  map[string]time.Time // When each pair was last traded
+	nowFunc func() time.Time
+}
+
+// NewHedger creates a hedger without pairs
+func NewHedger(config Config) (*Hedger, error) {
+	if config.Trader == nil {
+		return nil, fmt.Errorf("trader is required")
+	}
+	if config.Positions == nil {
+		return nil, fmt.Errorf("position provider is required")
+	}
+	if config.Threshold <= 0 {
+		config.Threshold = 5
+	}
+	if config.OrderType == "" {
+		config.OrderType = types.FAK
+	}
+	if config.Cooldown <= 0 {
+		config.Cooldown = 30 * time.Second
+	}
+	if config.Interval <= 0 {
+		config.Interval = 10 * time.Second
+	}
+
+	return &Hedger{
+		config:  config,
+		pairs:   make(map[string]Pair),
+		hedged:  make(map[string]time.Time),
+		nowFunc: time.Now,
+	}, nil
+}
+
+// Add starts hedging a pair, replacing one with the same tokens
+func (h *Hedger) Add(pair Pair) error {
+	if pair.Primary == "" || pair.Hedge == "" {
+		return fmt.Errorf("pair needs a primary and a hedge token")
+	}
+	if pair.Primary == pair.Hedge {
+		return fmt.Errorf("pair cannot hedge a token with itself")
+	}
+	if pair.Ratio <= 0 {
+		return fmt.Errorf("invalid hedge ratio: %f", pair.Ratio)
+	}
+	if pair.Threshold <= 0 {
+		pair.Threshold = h.config.Threshold
+	}
+
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	h.pairs[pair.key()] = pair
+	return nil
+}
+
+// Remove stops hedging a pair
+func (h *Hedger) Remove(primary, hedge string) {
+	key := Pair{Primary: primary, Hedge: hedge}.key()
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	delete(h.pairs, key)
+	delete(h.hedged, key)
+}
+
+// Pairs returns the hedged pairs sorted by primary then hedge token
+func (h *Hedger) Pairs() []Pair {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	pairs := make([]Pair, 0, len(h.pairs))
+	for _, pair := range h.pairs {
+		pairs = append(pairs, pair)
+	}
+	sort.Slice(pairs, func(i, j int) bool { return pairs[i].key() < pairs[j].key() })
+	return pairs
+}
+
+// Exposure returns a pair's current exposure
+func (h *Hedger) Exposure(pair Pair) float64 {
+	return pair.Exposure(h.size(pair.Primary), h.size(pair.Hedge))
+}
+
+// Rebalance trades the hedge token of every pair whose exposure exceeds its
+// threshold and that was not hedged within the cooldown. Failed orders are
+// reported in their results.
+func (h *Hedger) Rebalance() []Result {
+	results := make([]Result, 0)
+	for _, pair := range h.Pairs() {
+		exposure := h.Exposure(pair)
+		if math.Abs(exposure) < pair.Threshold || h.coolingDown(pair) {
+			continue
+		}
+
+		result := h.hedge(pair, exposure)
+		if result == nil {
+			continue
+		}
+		results = append(results, *result)
+		if h.config.OnHedge != nil {
+			h.config.OnHedge(*result)
+		}
+	}
+	return results
+}
+
+// Run calls Rebalance every Interval until ctx is cancelled
+func (h *Hedger) Run(ctx context.Context) {
+	ticker := time.NewTicker(h.config.Interval)
+	defer ticker.Stop()
+
+	for {
+		for _, result := range h.Rebalance() {
+			if result.Err != nil && h.config.OnError != nil {
+				h.config.OnError(fmt.Errorf("failed to hedge %s: %w", result.Pair.Hedge, result.Err))
+			}
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// hedge sends the order closing a pair's exposure. It returns nil when there
+// is nothing to trade, e.g. an excess hedge that is not held.
+func (h *Hedger) hedge(pair Pair, exposure float64) *Result {
+	side := types.BUY
+	size := exposure
+	if exposure < 0 {
+		// Shares cannot be sold short, so at most the held hedge is sold
+		side = types.SELL
+		size = math.Min(-exposure, math.Max(h.size(pair.Hedge), 0))
+	}
+	if h.config.MaxOrderSize > 0 {
+		size = math.Min(size, h.config.MaxOrderSize)
+	}
+	size = utils.RoundDown(size, 2)
+	if size <= 0 {
+		return nil
+	}
+
+	result := &Result{Pair: pair, Exposure: exposure, Order: types.OrderArgs{TokenID: pair.Hedge, Size: size, Side: side}}
+	h.mu.Lock()
+	h.hedged[pair.key()] = h.nowFunc()
+	h.mu.Unlock()
+
+	price, err := h.price(pair.Hedge, side)
+	if err != nil {
+		result.Err = err
+		return result
+	}
+	result.Order.Price = price
+	if side == types.BUY && h.config.OrderType != types.GTC && h.config.OrderType != types.GTD {
+		// Market orders spend whole cents
+		result.Order.Size = centSize(size, price)
+		if result.Order.Size <= 0 {
+			result.Err = fmt.Errorf("%.2f shares at %v cannot be bought for whole cents", size, price)
+			return result
+		}
+	}
+
+	signedOrder, err := h.config.Trader.CreateOrder(result.Order, nil)
+	if err != nil {
+		result.Err = fmt.Errorf("failed to create order: %w", err)
+		return result
+	}
+	resp, err := h.config.Trader.PostOrder(signedOrder, h.config.OrderType)
+	if err != nil {
+		result.Err = fmt.Errorf("failed to post order: %w", err)
+		return result
+	}
+	result.OrderID, _ = resp["orderID"].(string)
+	return result
+}
+
+// price returns a marketable limit price for side: the best opposite quote
+// moved by Slippage and snapped onto the tick grid
+func (h *Hedger) price(tokenID string, side types.OrderSide) (float64, error) {
+	book, err := h.config.Trader.GetOrderBook(tokenID)
+	if err != nil {
+		return 0, fmt.Errorf("failed to get order book: %w", err)
+	}
+	tickSize, err := h.config.Trader.GetTickSize(tokenID)
+	if err != nil {
+		return 0, fmt.Errorf("failed to get tick size: %w", err)
+	}
+
+	if side == types.BUY {
+		ask, ok := book.BestAsk()
+		if !ok {
+			return 0, fmt.Errorf("no asks for token %s", tokenID)
+		}
+		return utils.SnapPrice(ask.Price+h.config.Slippage, tickSize, types.RoundUp), nil
+	}
+	bid, ok := book.BestBid()
+	if !ok {
+		return 0, fmt.Errorf("no bids for token %s", tokenID)
+	}
+	return utils.SnapPrice(bid.Price-h.config.Slippage, tickSize, types.RoundDown), nil
+}
+
+// centSize rounds a buy size down until it costs a whole number of cents at price
+func centSize(size, price float64) float64 {
+	decimals := utils.DecimalPlaces(price)
+	scale := int64(math.Pow10(decimals))
+	units := int64(math.Round(price * float64(scale)))
+	for cents := int64(math.Floor(size*100 + 1e-9)); cents > 0; cents-- {
+		if cents*units%scale == 0 {
+			return float64(cents) / 100
+		}
+	}
+	return 0
+}
+
+// coolingDown reports whether a pair was hedged within the cooldown
+func (h *Hedger) coolingDown(pair Pair) bool {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	at, ok := h.hedged[pair.key()]
+	return ok && h.nowFunc().Sub(at) < h.config.Cooldown
+}
+
+// size returns the position held in a token, 0 when none
+func (h *Hedger) size(tokenID string) float64 {
+	position, ok := h.config.Positions.Position(tokenID)
+	if !ok {
+		return 0
+	}
+	return position.Size
+}
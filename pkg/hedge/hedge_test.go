@@ -0,0 +1,132 @@
+package hedge
+
+import (
+	"testing"
+	"time"
+
+	"github.com/MaDal776/polymarket-go-client/pkg/client"
+	"github.com/MaDal776/polymarket-go-client/pkg/portfolio"
+	"github.com/MaDal776/polymarket-go-client/pkg/types"
+)
+
+// fakeTrader quotes every token at 0.40 / 0.45 and records the orders it posts
+type fakeTrader struct {
+	client.Trader
+	created    []types.OrderArgs
+	orderTypes []types.OrderType
+}
+
+func (f *fakeTrader) GetOrderBook(tokenID string) (*types.OrderBookSummary, error) {
+	return &types.OrderBookSummary{
+		Bids: []types.OrderSummary{{Price: "0.40", Size: "100"}},
+		Asks: []types.OrderSummary{{Price: "0.45", Size: "100"}},
+	}, nil
+}
+
+func (f *fakeTrader) GetTickSize(tokenID string) (types.TickSize, error) {
+	return types.TickSize001, nil
+}
+
+func (f *fakeTrader) CreateOrder(orderArgs types.OrderArgs, options *types.CreateOrderOptions) (*types.SignedOrder, error) {
+	f.created = append(f.created, orderArgs)
+	return &types.SignedOrder{}, nil
+}
+
+func (f *fakeTrader) PostOrder(signedOrder *types.SignedOrder, orderType types.OrderType) (map[string]interface{}, error) {
+	f.orderTypes = append(f.orderTypes, orderType)
+	return map[string]interface{}{"success": true, "orderID": "0xabc"}, nil
+}
+
+func TestRebalanceBuysMissingHedge(t *testing.T) {
+	positions := portfolio.NewPortfolio()
+	positions.SetPosition("a", 100, 0.5)
+	positions.SetPosition("b", 20, 0.4)
+
+	trader := &fakeTrader{}
+	h, err := NewHedger(Config{Trader: trader, Positions: positions, Slippage: 0.01})
+	if err != nil {
+		t.Fatalf("Failed to create hedger: %v", err)
+	}
+	if err := h.Add(Pair{Primary: "a", Hedge: "b", Ratio: 0.5}); err != nil {
+		t.Fatalf("Add failed: %v", err)
+	}
+
+	if exposure := h.Exposure(h.Pairs()[0]); exposure != 30 {
+		t.Fatalf("Expected exposure 30, got %v", exposure)
+	}
+	results := h.Rebalance()
+	if len(results) != 1 || results[0].Err != nil || results[0].OrderID != "0xabc" {
+		t.Fatalf("Unexpected results %+v", results)
+	}
+	order := trader.created[0]
+	if order.TokenID != "b" || order.Side != types.BUY || order.Price != 0.46 || order.Size != 30 || trader.orderTypes[0] != types.FAK {
+		t.Errorf("Unexpected hedge order %+v (%s)", order, trader.orderTypes[0])
+	}
+
+	// Within the cooldown the pair is left alone even though the position has not caught up
+	if results := h.Rebalance(); len(results) != 0 {
+		t.Errorf("Expected no orders during the cooldown, got %+v", results)
+	}
+	h.nowFunc = func() time.Time { return time.Now().Add(time.Minute) }
+	positions.SetPosition("b", 50, 0.45)
+	if results := h.Rebalance(); len(results) != 0 {
+		t.Errorf("Expected no orders once hedged, got %+v", results)
+	}
+}
+
+func TestRebalanceSellsExcessHedge(t *testing.T) {
+	positions := portfolio.NewPortfolio()
+	positions.SetPosition("a", 10, 0.5)
+	positions.SetPosition("b", 50, 0.4)
+
+	trader := &fakeTrader{}
+	h, err := NewHedger(Config{Trader: trader, Positions: positions, MaxOrderSize: 25})
+	if err != nil {
+		t.Fatalf("Failed to create hedger: %v", err)
+	}
+	h.Add(Pair{Primary: "a", Hedge: "b", Ratio: 1})
+
+	results := h.Rebalance()
+	if len(results) != 1 || results[0].Exposure != -40 {
+		t.Fatalf("Unexpected results %+v", results)
+	}
+	order := trader.created[0]
+	if order.Side != types.SELL || order.Price != 0.40 || order.Size != 25 {
+		t.Errorf("Expected a 25 share sell at the bid, got %+v", order)
+	}
+}
+
+func TestCentSize(t *testing.T) {
+	cases := []struct{ size, price, want float64 }{
+		{30, 0.46, 30},
+		{7.33, 0.47, 7},
+		{7.33, 0.5, 7.32},
+		{10.55, 0.125, 10.48},
+		{0.5, 0.47, 0},
+	}
+	for _, c := range cases {
+		if got := centSize(c.size, c.price); got != c.want {
+			t.Errorf("centSize(%v, %v) = %v, want %v", c.size, c.price, got, c.want)
+		}
+	}
+}
+
+func TestAddValidatesPairs(t *testing.T) {
+	h, err := NewHedger(Config{Trader: &fakeTrader{}, Positions: portfolio.NewPortfolio()})
+	if err != nil {
+		t.Fatalf("Failed to create hedger: %v", err)
+	}
+	for _, pair := range []Pair{{Primary: "a", Ratio: 1}, {Primary: "a", Hedge: "a", Ratio: 1}, {Primary: "a", Hedge: "b"}} {
+		if err := h.Add(pair); err == nil {
+			t.Errorf("Expected %+v to be rejected", pair)
+		}
+	}
+	h.Add(Pair{Primary: "a", Hedge: "b", Ratio: 1})
+	if pairs := h.Pairs(); len(pairs) != 1 || pairs[0].Threshold != 5 {
+		t.Errorf("Expected the default threshold, got %+v", pairs)
+	}
+	h.Remove("a", "b")
+	if len(h.Pairs()) != 0 {
+		t.Errorf("Expected the pair to be removed")
+	}
+}
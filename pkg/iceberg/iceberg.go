@@ -0,0 +1,357 @@
+// Package iceberg works a large order by showing only part of it on the book.
+// Each visible slice rests at the limit price; when a slice fills, the next one
+// is posted from the hidden reserve until the total size is done.
+package iceberg
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/MaDal776/polymarket-go-client/pkg/client"
+	"github.com/MaDal776/polymarket-go-client/pkg/portfolio"
+	"github.com/MaDal776/polymarket-go-client/pkg/types"
+	"github.com/MaDal776/polymarket-go-client/pkg/utils"
+)
+
+// sizeEpsilon is the smallest quantity treated as non-zero
+const sizeEpsilon = 1e-9
+
+// Config configures an Iceberg
+type Config struct {
+	Trader      client.Trader             // Required
+	TokenID     string                    // Required
+	Side        types.OrderSide           // Required
+	Price       float64                   // Limit price of every slice; required
+	TotalSize   float64                   // Shares to work in total; required
+	VisibleSize float64                   // Shares shown per slice; required
+	Options     *types.CreateOrderOptions // Passed to CreateOrder for every slice
+	OrderType   types.OrderType           // GTC or GTD; default GTC
+	ExpireAfter time.Duration             // Lifetime of each GTD slice; default the client's OrderDefaults.ExpireAfter
+	Interval    time.Duration             // Order polling interval used by Run; default 5s
+	OnSlice     func(slice Slice)         // Called after each slice is posted
+	OnError     func(err error)
+}
+
+// Slice is one visible order of the iceberg
+type Slice struct {
+	OrderID  string    `json:"order_id"`
+	Size     float64   `json:"size"`
+	Matched  float64   `json:"matched"`
+	PostedAt time.Time `json:"posted_at"`
+}
+
+// Status summarizes the progress of an iceberg
+type Status struct {
+	Filled    float64 `json:"filled"`    // Shares matched across every slice
+	Visible   float64 `json:"visible"`   // Unmatched shares of the resting slice
+	Reserve   float64 `json:"reserve"`   // Shares not yet posted
+	Slices    int     `json:"slices"`    // Slices posted so far
+	Done      bool    `json:"done"`      // The total size filled, or the iceberg was cancelled
+	Cancelled bool    `json:"cancelled"` // Stopped by Cancel or by the exchange cancelling a slice
+}
+
+// Iceberg posts the slices of one large order
+type Iceberg struct {
+	config Config
+
+	mu        sync.Mutex
+	slices    []Slice
+	filled    float64 // Matched by slices before the current one
+	fills     map[string]bool
+	posting   bool // A slice is being created and posted
+	done      bool
+	cancelled bool
+}
+
+// NewIceberg validates the config and creates an iceberg. Nothing is posted
+// until Start or Run.
+func NewIceberg(config Config) (*Iceberg, error) {
+	if config.Trader == nil {
+		return nil, fmt.Errorf("trader is required")
+	}
+	if config.TokenID == "" {
+		return nil, fmt.Errorf("token ID is required")
+	}
+	if config.Side != types.BUY && config.Side != types.SELL {
+		return nil, fmt.Errorf("invalid side: %s", config.Side)
+	}
+	if config.Price <= 0 || config.Price >= 1 {
+		return nil, fmt.Errorf("price must be between 0 and 1, got %g", config.Price)
+	}
+	if config.TotalSize <= 0 || config.VisibleSize <= 0 {
+		return nil, fmt.Errorf("total and visible sizes must be positive")
+	}
+	if config.VisibleSize > config.TotalSize {
+		return nil, fmt.Errorf("visible size %g exceeds total size %g", config.VisibleSize, config.TotalSize)
+	}
+	if config.OrderType == "" {
+		config.OrderType = types.GTC
+	}
+	if config.OrderType != types.GTC && config.OrderType != types.GTD {
+		return nil, fmt.Errorf("iceberg slices must rest on the book, got order type %s", config.OrderType)
+	}
+	if config.Interval <= 0 {
+		config.Interval = 5 * time.Second
+	}
+	return &Iceberg{config: config, fills: make(map[string]bool)}, nil
+}
+
+// Start posts the first slice
+func (i *Iceberg) Start() error {
+	i.mu.Lock()
+	if len(i.slices) > 0 || i.posting {
+		i.mu.Unlock()
+		return fmt.Errorf("iceberg already started")
+	}
+	i.posting = true
+	i.mu.Unlock()
+	return i.next()
+}
+
+// ApplyFill counts a fill of the resting slice, e.g. from the user channel, and
+// posts the next slice once it is fully matched. It returns false when the fill
+// belongs to another order.
+func (i *Iceberg) ApplyFill(fill portfolio.Fill) (bool, error) {
+	i.mu.Lock()
+	current := i.current()
+	if current == nil || fill.OrderID != current.OrderID {
+		i.mu.Unlock()
+		return false, nil
+	}
+	if fill.TradeID != "" && i.fills[fill.TradeID] {
+		i.mu.Unlock()
+		return true, nil
+	}
+	if fill.TradeID != "" {
+		i.fills[fill.TradeID] = true
+	}
+	current.Matched = math.Min(current.Matched+fill.Size, current.Size)
+	i.mu.Unlock()
+
+	return true, i.replenish()
+}
+
+// Poll looks up the resting slice and posts the next one when it is fully
+// matched. A slice cancelled by the exchange stops the iceberg.
+func (i *Iceberg) Poll(ctx context.Context) error {
+	i.mu.Lock()
+	current := i.current()
+	var orderID string
+	if current != nil && !i.done && !i.posting {
+		orderID = current.OrderID
+	}
+	i.mu.Unlock()
+	if orderID == "" {
+		return nil
+	}
+
+	order, err := i.config.Trader.GetOrder(orderID)
+	if err != nil {
+		return fmt.Errorf("failed to get slice %s: %w", orderID, err)
+	}
+	matched, err := strconv.ParseFloat(order.SizeMatched, 64)
+	if err != nil && order.SizeMatched != "" {
+		return fmt.Errorf("invalid size matched %q: %w", order.SizeMatched, err)
+	}
+
+	i.mu.Lock()
+	current = i.current()
+	if current.OrderID != orderID {
+		// A fill pushed meanwhile already replenished the slice
+		i.mu.Unlock()
+		return nil
+	}
+	if current.Matched < matched {
+		current.Matched = math.Min(matched, current.Size)
+	}
+	if current.Matched < current.Size-sizeEpsilon && client.IsTerminalOrderStatus(order.Status) {
+		i.filled += current.Matched
+		i.done, i.cancelled = true, true
+		i.mu.Unlock()
+		return fmt.Errorf("slice %s was %s by the exchange", orderID, order.Status)
+	}
+	i.mu.Unlock()
+
+	return i.replenish()
+}
+
+// Run starts the iceberg if needed and polls the resting slice every Interval
+// until the total size is filled, the iceberg is cancelled or ctx ends
+func (i *Iceberg) Run(ctx context.Context) error {
+	i.mu.Lock()
+	started := len(i.slices) > 0 || i.posting
+	i.mu.Unlock()
+	if !started {
+		if err := i.Start(); err != nil {
+			return err
+		}
+	}
+
+	ticker := time.NewTicker(i.config.Interval)
+	defer ticker.Stop()
+
+	for !i.Status().Done {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-ticker.C:
+		}
+		if err := i.Poll(ctx); err != nil && ctx.Err() == nil && i.config.OnError != nil {
+			i.config.OnError(err)
+		}
+	}
+	return nil
+}
+
+// Cancel cancels the resting slice and stops the iceberg
+func (i *Iceberg) Cancel() error {
+	i.mu.Lock()
+	if i.done {
+		i.mu.Unlock()
+		return nil
+	}
+	current := i.current()
+	i.done, i.cancelled = true, true
+	if current != nil {
+		i.filled += current.Matched
+	}
+	i.mu.Unlock()
+
+	if current == nil {
+		return nil
+	}
+	if _, err := i.config.Trader.CancelOrder(current.OrderID); err != nil {
+		return fmt.Errorf("failed to cancel slice %s: %w", current.OrderID, err)
+	}
+	return nil
+}
+
+// Status returns the progress of the iceberg
+func (i *Iceberg) Status() Status {
+	i.mu.Lock()
+	defer i.mu.Unlock()
+
+	status := Status{Filled: i.filled, Slices: len(i.slices), Done: i.done, Cancelled: i.cancelled}
+	if current := i.current(); current != nil && !i.done {
+		status.Filled += current.Matched
+		status.Visible = current.Size - current.Matched
+	}
+	if !i.cancelled {
+		status.Reserve = math.Max(i.config.TotalSize-status.Filled-status.Visible, 0)
+	}
+	return status
+}
+
+// Slices returns copies of the slices posted so far, oldest first
+func (i *Iceberg) Slices() []Slice {
+	i.mu.Lock()
+	defer i.mu.Unlock()
+	return append([]Slice(nil), i.slices...)
+}
+
+// replenish posts the next slice when the resting one is fully matched
+func (i *Iceberg) replenish() error {
+	i.mu.Lock()
+	current := i.current()
+	if i.done || i.posting || current == nil || current.Matched < current.Size-sizeEpsilon {
+		i.mu.Unlock()
+		return nil
+	}
+	i.posting = true
+	i.mu.Unlock()
+
+	return i.next()
+}
+
+// next posts a slice of the visible size, or of what is left when less. The
+// caller sets i.posting; a failed post leaves the filled slice resting so that
+// the next fill or poll tries again.
+func (i *Iceberg) next() (err error) {
+	i.mu.Lock()
+	if i.done {
+		i.posting = false
+		i.mu.Unlock()
+		return nil
+	}
+	completed := i.filled
+	if current := i.current(); current != nil {
+		completed += current.Matched
+	}
+	size := utils.RoundDown(math.Min(i.config.VisibleSize, i.config.TotalSize-completed)+sizeEpsilon, 2)
+	if size <= 0 {
+		i.done = true
+		i.filled = completed
+		i.posting = false
+		i.mu.Unlock()
+		return nil
+	}
+	i.mu.Unlock()
+
+	defer func() {
+		if err != nil {
+			i.mu.Lock()
+			i.posting = false
+			i.mu.Unlock()
+		}
+	}()
+
+	var options *types.CreateOrderOptions
+	if i.config.Options != nil {
+		copied := *i.config.Options
+		options = &copied
+	}
+	signedOrder, err := i.config.Trader.CreateOrder(types.OrderArgs{
+		TokenID:     i.config.TokenID,
+		Price:       i.config.Price,
+		Size:        size,
+		Side:        i.config.Side,
+		ExpireAfter: i.config.ExpireAfter,
+	}, options)
+	if err != nil {
+		return fmt.Errorf("failed to create slice: %w", err)
+	}
+	result, err := i.config.Trader.PostOrder(signedOrder, i.config.OrderType)
+	if err != nil {
+		return fmt.Errorf("failed to post slice: %w", err)
+	}
+	orderID, _ := result["orderID"].(string)
+	if success, _ := result["success"].(bool); !success || orderID == "" {
+		message, _ := result["errorMsg"].(string)
+		return fmt.Errorf("slice rejected: %s", message)
+	}
+
+	slice := Slice{OrderID: orderID, Size: size, PostedAt: time.Now()}
+	i.mu.Lock()
+	cancelled := i.cancelled
+	if !cancelled {
+		// Cancel already counted the previous slice's fills
+		i.filled = completed
+	}
+	i.slices = append(i.slices, slice)
+	i.posting = false
+	i.mu.Unlock()
+
+	if cancelled {
+		// Cancel was called while the slice was being posted
+		if _, err := i.config.Trader.CancelOrder(orderID); err != nil {
+			return fmt.Errorf("failed to cancel slice %s: %w", orderID, err)
+		}
+		return nil
+	}
+	if i.config.OnSlice != nil {
+		i.config.OnSlice(slice)
+	}
+	return nil
+}
+
+// current returns the resting slice, or nil before the first one. Callers hold i.mu.
+func (i *Iceberg) current() *Slice {
+	if len(i.slices) == 0 {
+		return nil
+	}
+	return &i.slices[len(i.slices)-1]
+}
@@ -0,0 +1,114 @@
+package iceberg
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/MaDal776/polymarket-go-client/pkg/client"
+	"github.com/MaDal776/polymarket-go-client/pkg/portfolio"
+	"github.com/MaDal776/polymarket-go-client/pkg/types"
+)
+
+// fakeTrader posts every order under a new ID and reports the orders set in matched
+type fakeTrader struct {
+	client.Trader
+	sizes     []float64
+	matched   map[string]types.OpenOrder
+	cancelled []string
+}
+
+func (f *fakeTrader) CreateOrder(orderArgs types.OrderArgs, options *types.CreateOrderOptions) (*types.SignedOrder, error) {
+	f.sizes = append(f.sizes, orderArgs.Size)
+	return &types.SignedOrder{TokenID: orderArgs.TokenID, Side: orderArgs.Side}, nil
+}
+
+func (f *fakeTrader) PostOrder(signedOrder *types.SignedOrder, orderType types.OrderType) (map[string]interface{}, error) {
+	return map[string]interface{}{"success": true, "orderID": fmt.Sprintf("slice-%d", len(f.sizes))}, nil
+}
+
+func (f *fakeTrader) GetOrder(orderID string) (*types.OpenOrder, error) {
+	order, ok := f.matched[orderID]
+	if !ok {
+		order = types.OpenOrder{ID: orderID, Status: "LIVE", SizeMatched: "0"}
+	}
+	return &order, nil
+}
+
+func (f *fakeTrader) CancelOrder(orderID string) (map[string]interface{}, error) {
+	f.cancelled = append(f.cancelled, orderID)
+	return map[string]interface{}{"canceled": []string{orderID}}, nil
+}
+
+func TestIcebergReplenishesFromReserve(t *testing.T) {
+	trader := &fakeTrader{matched: make(map[string]types.OpenOrder)}
+	posted := 0
+	ice, err := NewIceberg(Config{Trader: trader, TokenID: "1", Side: types.BUY, Price: 0.4, TotalSize: 25, VisibleSize: 10,
+		OnSlice: func(Slice) { posted++ }})
+	if err != nil {
+		t.Fatalf("Failed to create iceberg: %v", err)
+	}
+	if err := ice.Start(); err != nil {
+		t.Fatalf("Start failed: %v", err)
+	}
+
+	ice.ApplyFill(portfolio.Fill{TradeID: "t1", OrderID: "slice-1", Size: 6})
+	ice.ApplyFill(portfolio.Fill{TradeID: "t1", OrderID: "slice-1", Size: 6}) // duplicate
+	if status := ice.Status(); status.Filled != 6 || status.Visible != 4 || status.Reserve != 15 || status.Slices != 1 {
+		t.Fatalf("Unexpected status %+v", status)
+	}
+	if ok, _ := ice.ApplyFill(portfolio.Fill{TradeID: "t2", OrderID: "other", Size: 4}); ok {
+		t.Errorf("Expected a fill of another order to be ignored")
+	}
+
+	ice.ApplyFill(portfolio.Fill{TradeID: "t3", OrderID: "slice-1", Size: 4})
+	trader.matched["slice-2"] = types.OpenOrder{ID: "slice-2", Status: "MATCHED", SizeMatched: "10"}
+	if err := ice.Poll(context.Background()); err != nil {
+		t.Fatalf("Poll failed: %v", err)
+	}
+	if len(trader.sizes) != 3 || trader.sizes[0] != 10 || trader.sizes[1] != 10 || trader.sizes[2] != 5 {
+		t.Fatalf("Expected slices of 10, 10 and 5, got %v", trader.sizes)
+	}
+
+	ice.ApplyFill(portfolio.Fill{TradeID: "t4", OrderID: "slice-3", Size: 5})
+	status := ice.Status()
+	if !status.Done || status.Cancelled || status.Filled != 25 || status.Reserve != 0 || posted != 3 {
+		t.Errorf("Expected a completed iceberg, got %+v after %d slices", status, posted)
+	}
+}
+
+func TestIcebergStopsWhenCancelled(t *testing.T) {
+	trader := &fakeTrader{matched: make(map[string]types.OpenOrder)}
+	ice, err := NewIceberg(Config{Trader: trader, TokenID: "1", Side: types.SELL, Price: 0.6, TotalSize: 30, VisibleSize: 10})
+	if err != nil {
+		t.Fatalf("Failed to create iceberg: %v", err)
+	}
+	if err := ice.Start(); err != nil {
+		t.Fatalf("Start failed: %v", err)
+	}
+
+	trader.matched["slice-1"] = types.OpenOrder{ID: "slice-1", Status: "CANCELED", SizeMatched: "3"}
+	if err := ice.Poll(context.Background()); err == nil {
+		t.Fatalf("Expected an exchange cancel to be reported")
+	}
+	status := ice.Status()
+	if !status.Done || !status.Cancelled || status.Filled != 3 || len(trader.sizes) != 1 {
+		t.Errorf("Expected a stopped iceberg with 3 filled, got %+v", status)
+	}
+
+	ice, _ = NewIceberg(Config{Trader: trader, TokenID: "1", Side: types.SELL, Price: 0.6, TotalSize: 30, VisibleSize: 10})
+	ice.Start()
+	if err := ice.Cancel(); err != nil || len(trader.cancelled) != 1 || trader.cancelled[0] != "slice-2" {
+		t.Errorf("Expected the resting slice cancelled, got %v, %v", err, trader.cancelled)
+	}
+}
+
+func TestNewIcebergValidates(t *testing.T) {
+	trader := &fakeTrader{}
+	if _, err := NewIceberg(Config{Trader: trader, TokenID: "1", Side: types.BUY, Price: 0.5, TotalSize: 5, VisibleSize: 10}); err == nil {
+		t.Errorf("Expected a visible size above the total to be rejected")
+	}
+	if _, err := NewIceberg(Config{Trader: trader, TokenID: "1", Side: types.BUY, Price: 0.5, TotalSize: 50, VisibleSize: 10, OrderType: types.FOK}); err == nil {
+		t.Errorf("Expected a FOK iceberg to be rejected")
+	}
+}
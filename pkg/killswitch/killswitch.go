@@ -0,0 +1,192 @@
+package killswitch
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"os/signal"
+	"sync"
+	"syscall"
+	"time"
+)
+
+// Reason identifies why the kill switch fired
+type Reason string
+
+const (
+	ReasonSignal           Reason = "SIGNAL"            // SIGINT/SIGTERM received
+	ReasonDisconnect       Reason = "DISCONNECT"        // Feed reported a disconnect
+	ReasonHeartbeatTimeout Reason = "HEARTBEAT_TIMEOUT" // No heartbeat within the timeout
+	ReasonManual           Reason = "MANUAL"            // Triggered explicitly by the caller
+)
+
+// Canceler cancels every open order (e.g. the CLOB client)
+type Canceler interface {
+	CancelAll() (map[string]interface{}, error)
+}
+
+// Config configures a KillSwitch
+type Config struct {
+	MaxRetries       int                                      // CancelAll attempts after the first; default 3
+	RetryDelay       time.Duration                            // Delay between attempts; default 500ms
+	HeartbeatTimeout time.Duration                            // Fire when no Heartbeat arrives within this window; 0 disables
+	Signals          []os.Signal                              // Signals that fire the switch; default SIGINT and SIGTERM
+	OnTrigger        func(reason Reason, cause error)         // Called before cancelling
+	OnComplete       func(reason Reason, result error)        // Called after cancelling with the final CancelAll error
+	Logf             func(format string, args ...interface{}) // Optional logger
+}
+
+// KillSwitch cancels all open orders when the process loses its feed or is asked to exit
+type KillSwitch struct {
+	canceler Canceler
+	config   Config
+
+	mu            sync.Mutex
+	lastHeartbeat time.Time
+	triggered     bool
+	reason        Reason
+	result        error
+	done          chan struct{}
+	disconnects   chan error
+}
+
+// New creates a kill switch around the given canceler
+func New(canceler Canceler, config Config) *KillSwitch {
+	if config.MaxRetries <= 0 {
+		config.MaxRetries = 3
+	}
+	if config.RetryDelay <= 0 {
+		config.RetryDelay = 500 * time.Millisecond
+	}
+	if len(config.Signals) == 0 {
+		config.Signals = []os.Signal{syscall.SIGINT, syscall.SIGTERM}
+	}
+
+	return &KillSwitch{
+		canceler:      canceler,
+		config:        config,
+		lastHeartbeat: time.Now(),
+		done:          make(chan struct{}),
+		disconnects:   make(chan error, 1),
+	}
+}
+
+// Heartbeat records that the monitored feed is alive
+func (k *KillSwitch) Heartbeat() {
+	k.mu.Lock()
+	k.lastHeartbeat = time.Now()
+	k.mu.Unlock()
+}
+
+// Disconnected reports that the monitored feed (e.g. the user websocket) dropped
+func (k *KillSwitch) Disconnected(cause error) {
+	select {
+	case k.disconnects <- cause:
+	default:
+	}
+}
+
+// Run watches for signals, disconnects and heartbeat timeouts until one fires
+// or ctx is cancelled. When the switch fires, Run cancels all orders and returns
+// the reason; the caller is expected to exit afterwards.
+func (k *KillSwitch) Run(ctx context.Context) (Reason, error) {
+	signals := make(chan os.Signal, 1)
+	signal.Notify(signals, k.config.Signals...)
+	defer signal.Stop(signals)
+
+	var heartbeatCheck <-chan time.Time
+	if k.config.HeartbeatTimeout > 0 {
+		ticker := time.NewTicker(k.config.HeartbeatTimeout / 4)
+		defer ticker.Stop()
+		heartbeatCheck = ticker.C
+	}
+
+	for {
+		select {
+		case <-ctx.Done():
+			return "", ctx.Err()
+		case <-k.done:
+			return k.Result()
+		case sig := <-signals:
+			return k.fire(ReasonSignal, fmt.Errorf("received signal %s", sig))
+		case cause := <-k.disconnects:
+			return k.fire(ReasonDisconnect, cause)
+		case <-heartbeatCheck:
+			k.mu.Lock()
+			silence := time.Since(k.lastHeartbeat)
+			k.mu.Unlock()
+			if silence > k.config.HeartbeatTimeout {
+				return k.fire(ReasonHeartbeatTimeout, fmt.Errorf("no heartbeat for %v", silence))
+			}
+		}
+	}
+}
+
+// Trigger fires the switch immediately
+func (k *KillSwitch) Trigger() (Reason, error) {
+	return k.fire(ReasonManual, nil)
+}
+
+// Triggered reports whether the switch has fired
+func (k *KillSwitch) Triggered() bool {
+	k.mu.Lock()
+	defer k.mu.Unlock()
+	return k.triggered
+}
+
+// Result returns the reason the switch fired and the final CancelAll error
+func (k *KillSwitch) Result() (Reason, error) {
+	k.mu.Lock()
+	defer k.mu.Unlock()
+	return k.reason, k.result
+}
+
+// fire cancels all orders once, retrying up to MaxRetries times
+func (k *KillSwitch) fire(reason Reason, cause error) (Reason, error) {
+	k.mu.Lock()
+	if k.triggered {
+		k.mu.Unlock()
+		<-k.done
+		return k.Result()
+	}
+	k.triggered = true
+	k.reason = reason
+	k.mu.Unlock()
+
+	if k.config.OnTrigger != nil {
+		k.config.OnTrigger(reason, cause)
+	}
+	k.logf("kill switch fired (%s): %v", reason, cause)
+
+	var err error
+	for attempt := 0; attempt <= k.config.MaxRetries; attempt++ {
+		if attempt > 0 {
+			time.Sleep(k.config.RetryDelay)
+		}
+		if _, err = k.canceler.CancelAll(); err == nil {
+			k.logf("kill switch cancelled all orders on attempt %d", attempt+1)
+			break
+		}
+		k.logf("kill switch cancel attempt %d failed: %v", attempt+1, err)
+	}
+	if err != nil {
+		err = fmt.Errorf("failed to cancel all orders after %d attempts: %w", k.config.MaxRetries+1, err)
+	}
+
+	k.mu.Lock()
+	k.result = err
+	k.mu.Unlock()
+	close(k.done)
+
+	if k.config.OnComplete != nil {
+		k.config.OnComplete(reason, err)
+	}
+	return reason, err
+}
+
+// logf logs through the configured logger, if any
+func (k *KillSwitch) logf(format string, args ...interface{}) {
+	if k.config.Logf != nil {
+		k.config.Logf(format, args...)
+	}
+}
@@ -0,0 +1,64 @@
+package killswitch
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+)
+
+type fakeCanceler struct {
+	failures int
+	calls    int
+}
+
+func (f *fakeCanceler) CancelAll() (map[string]interface{}, error) {
+	f.calls++
+	if f.calls <= f.failures {
+		return nil, fmt.Errorf("temporary failure")
+	}
+	return map[string]interface{}{"canceled": []string{}}, nil
+}
+
+func TestTriggerRetriesUntilSuccess(t *testing.T) {
+	canceler := &fakeCanceler{failures: 2}
+	ks := New(canceler, Config{MaxRetries: 3, RetryDelay: time.Millisecond})
+
+	reason, err := ks.Trigger()
+	if err != nil {
+		t.Fatalf("Expected cancel to succeed, got %v", err)
+	}
+	if reason != ReasonManual {
+		t.Errorf("Expected reason %s, got %s", ReasonManual, reason)
+	}
+	if canceler.calls != 3 {
+		t.Errorf("Expected 3 cancel attempts, got %d", canceler.calls)
+	}
+
+	// Firing again must not cancel twice
+	if _, err := ks.Trigger(); err != nil {
+		t.Fatalf("Unexpected error on second trigger: %v", err)
+	}
+	if canceler.calls != 3 {
+		t.Errorf("Expected switch to fire once, got %d calls", canceler.calls)
+	}
+}
+
+func TestRunFiresOnHeartbeatTimeout(t *testing.T) {
+	canceler := &fakeCanceler{}
+	ks := New(canceler, Config{HeartbeatTimeout: 20 * time.Millisecond, RetryDelay: time.Millisecond})
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+
+	reason, err := ks.Run(ctx)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if reason != ReasonHeartbeatTimeout {
+		t.Errorf("Expected reason %s, got %s", ReasonHeartbeatTimeout, reason)
+	}
+	if canceler.calls != 1 {
+		t.Errorf("Expected 1 cancel call, got %d", canceler.calls)
+	}
+}
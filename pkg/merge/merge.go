@@ -0,0 +1,228 @@
+package merge
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"sort"
+	"time"
+
+	"github.com/MaDal776/polymarket-go-client/pkg/client"
+	"github.com/MaDal776/polymarket-go-client/pkg/types"
+	"github.com/MaDal776/polymarket-go-client/pkg/utils"
+)
+
+// Action identifies how a pair was unwound
+type Action string
+
+const (
+	ActionMerge Action = "MERGE" // Both legs merged back to USDC on-chain
+	ActionSell  Action = "SELL"  // The cheaper leg sold at the best bid
+)
+
+// Pair is a condition in which both outcomes are held. Holding one share of each
+// is worth exactly 1 USDC, so the smaller of the two sizes is locked collateral.
+type Pair struct {
+	ConditionID string  `json:"condition_id"`
+	Title       string  `json:"title"`
+	YesTokenID  string  `json:"yes_token_id"`
+	NoTokenID   string  `json:"no_token_id"`
+	YesSize     float64 `json:"yes_size"`
+	NoSize      float64 `json:"no_size"`
+	YesPrice    float64 `json:"yes_price"`
+	NoPrice     float64 `json:"no_price"`
+	NegRisk     bool    `json:"neg_risk"`
+}
+
+// Mergeable returns the number of complete sets held
+func (p Pair) Mergeable() float64 {
+	return math.Min(p.YesSize, p.NoSize)
+}
+
+// FindPairs returns the conditions in which positions holds both outcomes,
+// sorted by condition ID
+func FindPairs(positions []types.UserPosition) []Pair {
+	legs := make(map[string][2]*types.UserPosition)
+	for i := range positions {
+		position := &positions[i]
+		if position.Size <= 0 || position.ConditionID == "" || position.OutcomeIndex < 0 || position.OutcomeIndex > 1 {
+			continue
+		}
+		condition := legs[position.ConditionID]
+		condition[position.OutcomeIndex] = position
+		legs[position.ConditionID] = condition
+	}
+
+	pairs := make([]Pair, 0)
+	for conditionID, condition := range legs {
+		yes, no := condition[0], condition[1]
+		if yes == nil || no == nil {
+			continue
+		}
+		pairs = append(pairs, Pair{
+			ConditionID: conditionID,
+			Title:       yes.Title,
+			YesTokenID:  yes.Asset,
+			NoTokenID:   no.Asset,
+			YesSize:     yes.Size,
+			NoSize:      no.Size,
+			YesPrice:    yes.CurPrice,
+			NoPrice:     no.CurPrice,
+			NegRisk:     yes.NegativeRisk,
+		})
+	}
+	sort.Slice(pairs, func(i, j int) bool { return pairs[i].ConditionID < pairs[j].ConditionID })
+	return pairs
+}
+
+// PositionSource provides current positions (e.g. the data API client)
+type PositionSource interface {
+	GetPositions(user string) ([]types.UserPosition, error)
+}
+
+// PositionMerger merges complete sets on-chain (e.g. onchain.Client)
+type PositionMerger interface {
+	MergePositions(ctx context.Context, conditionID string, amount float64, negRisk bool) (string, error)
+}
+
+// Result describes how one pair was handled
+type Result struct {
+	Pair    Pair    `json:"pair"`
+	Action  Action  `json:"action"`
+	Size    float64 `json:"size"`
+	TxHash  string  `json:"tx_hash,omitempty"`  // Set for merges
+	OrderID string  `json:"order_id,omitempty"` // Set for sells
+	Err     error   `json:"-"`
+}
+
+// Config configures a Merger
+type Config struct {
+	User      string         // Address holding the positions (funder / proxy wallet)
+	Positions PositionSource // Required
+	Merger    PositionMerger // Merges on-chain when set; otherwise the cheaper leg is sold
+	Trader    client.Trader  // Required when Merger is nil
+	MinSize   float64        // Pairs with fewer complete sets are left alone; default 1
+	Interval  time.Duration  // Interval used by Run; default 10m
+	OnResult  func(result Result)
+	OnError   func(err error) // Called when positions cannot be fetched in Run
+}
+
+// Merger frees collateral locked in complementary positions
+type Merger struct {
+	config Config
+}
+
+// NewMerger creates a merger
+func NewMerger(config Config) (*Merger, error) {
+	if config.Positions == nil {
+		return nil, fmt.Errorf("position source is required")
+	}
+	if config.User == "" {
+		return nil, fmt.Errorf("user address is required")
+	}
+	if config.Merger == nil && config.Trader == nil {
+		return nil, fmt.Errorf("a position merger or a trader is required")
+	}
+	if config.MinSize <= 0 {
+		config.MinSize = 1
+	}
+	if config.Interval <= 0 {
+		config.Interval = 10 * time.Minute
+	}
+
+	return &Merger{config: config}, nil
+}
+
+// MergeAll finds complementary positions and unwinds each pair. Failures of
+// individual pairs are reported in their results.
+func (m *Merger) MergeAll(ctx context.Context) ([]Result, error) {
+	positions, err := m.config.Positions.GetPositions(m.config.User)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get positions: %w", err)
+	}
+
+	results := make([]Result, 0)
+	for _, pair := range FindPairs(positions) {
+		if pair.Mergeable() < m.config.MinSize {
+			continue
+		}
+		if ctx.Err() != nil {
+			return results, ctx.Err()
+		}
+
+		var result Result
+		if m.config.Merger != nil {
+			result = m.merge(ctx, pair)
+		} else {
+			result = m.sellCheaperLeg(pair)
+		}
+		results = append(results, result)
+		if m.config.OnResult != nil {
+			m.config.OnResult(result)
+		}
+	}
+	return results, nil
+}
+
+// Run calls MergeAll every Interval until ctx is cancelled
+func (m *Merger) Run(ctx context.Context) {
+	ticker := time.NewTicker(m.config.Interval)
+	defer ticker.Stop()
+
+	for {
+		if _, err := m.MergeAll(ctx); err != nil && ctx.Err() == nil && m.config.OnError != nil {
+			m.config.OnError(err)
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// merge merges the complete sets of a pair on-chain
+func (m *Merger) merge(ctx context.Context, pair Pair) Result {
+	result := Result{Pair: pair, Action: ActionMerge, Size: pair.Mergeable()}
+	result.TxHash, result.Err = m.config.Merger.MergePositions(ctx, pair.ConditionID, result.Size, pair.NegRisk)
+	return result
+}
+
+// sellCheaperLeg sells the complete sets' worth of the lower-priced outcome with a FOK order at the best bid
+func (m *Merger) sellCheaperLeg(pair Pair) Result {
+	tokenID := pair.YesTokenID
+	if pair.NoPrice < pair.YesPrice {
+		tokenID = pair.NoTokenID
+	}
+	result := Result{Pair: pair, Action: ActionSell, Size: utils.RoundDown(pair.Mergeable(), 2)}
+
+	book, err := m.config.Trader.GetOrderBook(tokenID)
+	if err != nil {
+		result.Err = fmt.Errorf("failed to get order book: %w", err)
+		return result
+	}
+	bid, ok := book.BestBid()
+	if !ok {
+		result.Err = fmt.Errorf("no bids for token %s", tokenID)
+		return result
+	}
+
+	signedOrder, err := m.config.Trader.CreateOrder(types.OrderArgs{
+		TokenID: tokenID,
+		Price:   bid.Price,
+		Size:    result.Size,
+		Side:    types.SELL,
+	}, nil)
+	if err != nil {
+		result.Err = fmt.Errorf("failed to create order: %w", err)
+		return result
+	}
+	resp, err := m.config.Trader.PostOrder(signedOrder, types.FOK)
+	if err != nil {
+		result.Err = fmt.Errorf("failed to post order: %w", err)
+		return result
+	}
+	result.OrderID, _ = resp["orderID"].(string)
+	return result
+}
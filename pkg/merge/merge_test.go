@@ -0,0 +1,112 @@
+package merge
+
+import (
+	"context"
+	"testing"
+
+	"github.com/MaDal776/polymarket-go-client/pkg/client"
+	"github.com/MaDal776/polymarket-go-client/pkg/types"
+)
+
+const testUser = "0x0000000000000000000000000000000000000001"
+
+type fakePositions []types.UserPosition
+
+func (f fakePositions) GetPositions(user string) ([]types.UserPosition, error) {
+	return f, nil
+}
+
+type fakeMerger struct {
+	merged map[string]float64
+}
+
+func (f *fakeMerger) MergePositions(ctx context.Context, conditionID string, amount float64, negRisk bool) (string, error) {
+	f.merged[conditionID] = amount
+	return "0xtx", nil
+}
+
+// fakeTrader records the order it is asked to create; other Trader methods are not used
+type fakeTrader struct {
+	client.Trader
+	created   types.OrderArgs
+	orderType types.OrderType
+}
+
+func (f *fakeTrader) GetOrderBook(tokenID string) (*types.OrderBookSummary, error) {
+	return &types.OrderBookSummary{Bids: []types.OrderSummary{{Price: "0.3", Size: "100"}}}, nil
+}
+
+func (f *fakeTrader) CreateOrder(orderArgs types.OrderArgs, options *types.CreateOrderOptions) (*types.SignedOrder, error) {
+	f.created = orderArgs
+	return &types.SignedOrder{}, nil
+}
+
+func (f *fakeTrader) PostOrder(signedOrder *types.SignedOrder, orderType types.OrderType) (map[string]interface{}, error) {
+	f.orderType = orderType
+	return map[string]interface{}{"success": true, "orderID": "0xabc"}, nil
+}
+
+func testPositions() fakePositions {
+	return fakePositions{
+		{ConditionID: "0xa", Asset: "a-yes", OutcomeIndex: 0, Size: 10, CurPrice: 0.7},
+		{ConditionID: "0xa", Asset: "a-no", OutcomeIndex: 1, Size: 4.5, CurPrice: 0.3},
+		{ConditionID: "0xb", Asset: "b-yes", OutcomeIndex: 0, Size: 20, CurPrice: 0.5},
+		{ConditionID: "0xc", Asset: "c-yes", OutcomeIndex: 0, Size: 0.5, CurPrice: 0.5},
+		{ConditionID: "0xc", Asset: "c-no", OutcomeIndex: 1, Size: 0.5, CurPrice: 0.5},
+	}
+}
+
+func TestFindPairs(t *testing.T) {
+	pairs := FindPairs(testPositions())
+	if len(pairs) != 2 {
+		t.Fatalf("Expected 2 pairs, got %d", len(pairs))
+	}
+	if pairs[0].ConditionID != "0xa" || pairs[0].YesTokenID != "a-yes" || pairs[0].NoTokenID != "a-no" {
+		t.Errorf("Unexpected pair %+v", pairs[0])
+	}
+	if pairs[0].Mergeable() != 4.5 {
+		t.Errorf("Mergeable = %v, want 4.5", pairs[0].Mergeable())
+	}
+}
+
+func TestMergeAllMergesOnChain(t *testing.T) {
+	merger := &fakeMerger{merged: make(map[string]float64)}
+	m, err := NewMerger(Config{User: testUser, Positions: testPositions(), Merger: merger})
+	if err != nil {
+		t.Fatalf("Failed to create merger: %v", err)
+	}
+
+	results, err := m.MergeAll(context.Background())
+	if err != nil {
+		t.Fatalf("MergeAll failed: %v", err)
+	}
+	// 0xc holds less than MinSize complete sets
+	if len(results) != 1 || results[0].Action != ActionMerge || results[0].TxHash != "0xtx" {
+		t.Fatalf("Unexpected results %+v", results)
+	}
+	if merger.merged["0xa"] != 4.5 {
+		t.Errorf("Merged %v, want 4.5", merger.merged["0xa"])
+	}
+}
+
+func TestMergeAllSellsCheaperLeg(t *testing.T) {
+	trader := &fakeTrader{}
+	m, err := NewMerger(Config{User: testUser, Positions: testPositions(), Trader: trader})
+	if err != nil {
+		t.Fatalf("Failed to create merger: %v", err)
+	}
+
+	results, err := m.MergeAll(context.Background())
+	if err != nil {
+		t.Fatalf("MergeAll failed: %v", err)
+	}
+	if len(results) != 1 || results[0].Action != ActionSell || results[0].Err != nil {
+		t.Fatalf("Unexpected results %+v", results)
+	}
+	if trader.created.TokenID != "a-no" || trader.created.Side != types.SELL || trader.created.Size != 4.5 || trader.created.Price != 0.3 {
+		t.Errorf("Unexpected order %+v", trader.created)
+	}
+	if trader.orderType != types.FOK {
+		t.Errorf("Order type %s, want FOK", trader.orderType)
+	}
+}
@@ -0,0 +1,241 @@
+// Package mint sells overpriced complete sets. A share of each outcome of a
+// binary market can be minted from exactly 1 USDC, so when the best bids of
+// the two outcomes add up to more than 1 USDC plus fees and gas, splitting
+// USDC on-chain and selling both shares on the CLOB locks in the difference.
+package mint
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"time"
+
+	"github.com/MaDal776/polymarket-go-client/pkg/client"
+	"github.com/MaDal776/polymarket-go-client/pkg/fees"
+	"github.com/MaDal776/polymarket-go-client/pkg/onchain"
+	"github.com/MaDal776/polymarket-go-client/pkg/types"
+	"github.com/MaDal776/polymarket-go-client/pkg/utils"
+)
+
+// PositionSplitter splits USDC into complete sets on-chain (e.g. onchain.Client)
+type PositionSplitter interface {
+	SplitPosition(ctx context.Context, conditionID string, amount float64, negRisk bool) (string, error)
+	WaitForReceipt(ctx context.Context, txHash string) (*onchain.Receipt, error)
+}
+
+// Leg is one outcome of an opportunity, sold at its best bid
+type Leg struct {
+	TokenID string  `json:"token_id"`
+	Bid     float64 `json:"bid"`
+	Depth   float64 `json:"depth"`    // Shares at the best bid
+	Net     float64 `json:"net"`      // Received per share after taker fees
+	OrderID string  `json:"order_id"` // Set once sold
+	Err     error   `json:"-"`        // Why the leg was not sold
+}
+
+// Opportunity is a market whose outcome bids pay more than minting costs.
+// Legs[0] is the richer leg.
+type Opportunity struct {
+	Market *types.Market `json:"market"`
+	Legs   [2]Leg        `json:"legs"`
+	Size   float64       `json:"size"`   // Complete sets to mint
+	Edge   float64       `json:"edge"`   // Net proceeds per set above 1 USDC
+	Profit float64       `json:"profit"` // Edge times Size, less GasCost
+}
+
+// Result describes one mint-and-sell
+type Result struct {
+	Opportunity Opportunity `json:"opportunity"`
+	TxHash      string      `json:"tx_hash,omitempty"`
+	Err         error       `json:"-"` // Set when the split failed; nothing was sold
+}
+
+// Sold reports whether both legs were sold
+func (r Result) Sold() bool {
+	return r.Err == nil && r.Opportunity.Legs[0].OrderID != "" && r.Opportunity.Legs[1].OrderID != ""
+}
+
+// Config configures a Minter
+type Config struct {
+	Trader    client.Trader    // Required
+	Splitter  PositionSplitter // Required
+	MinEdge   float64          // Net proceeds per set above 1 USDC required to act; default 0.005
+	GasCost   float64          // USDC cost of a split transaction, deducted from the profit
+	MinSize   float64          // Smallest number of sets worth minting; default 5
+	MaxSize   float64          // Most sets minted at once; 0 means the depth at the best bids
+	OrderType types.OrderType  // Default FOK
+	Interval  time.Duration    // Interval used by Run; default 10s
+	OnResult  func(result Result)
+	OnError   func(err error) // Called when a market cannot be evaluated in Run
+}
+
+// Minter mints complete sets and sells them when the bids pay for it
+type Minter struct {
+	config Config
+}
+
+// NewMinter creates a minter
+func NewMinter(config Config) (*Minter, error) {
+	if config.Trader == nil {
+		return nil, fmt.Errorf("trader is required")
+	}
+	if config.Splitter == nil {
+		return nil, fmt.Errorf("position splitter is required")
+	}
+	if config.MinEdge < 0 || config.GasCost < 0 || config.MaxSize < 0 {
+		return nil, fmt.Errorf("min edge, gas cost and max size must not be negative")
+	}
+	if config.MinEdge == 0 {
+		config.MinEdge = 0.005
+	}
+	if config.MinSize <= 0 {
+		config.MinSize = 5
+	}
+	if config.OrderType == "" {
+		config.OrderType = types.FOK
+	}
+	if config.Interval <= 0 {
+		config.Interval = 10 * time.Second
+	}
+
+	return &Minter{config: config}, nil
+}
+
+// Evaluate prices minting a binary market's complete sets and selling both
+// shares at the best bids. It returns nil when that does not clear MinEdge,
+// MinSize or the gas cost.
+func (m *Minter) Evaluate(market *types.Market) (*Opportunity, error) {
+	if len(market.Tokens) != 2 {
+		return nil, fmt.Errorf("market %s is not binary: %d outcomes", market.ConditionID, len(market.Tokens))
+	}
+	model := fees.ModelFromMarket(market)
+
+	opportunity := &Opportunity{Market: market}
+	for i, token := range market.Tokens {
+		book, err := m.config.Trader.GetOrderBook(token.TokenID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get order book of %s: %w", token.TokenID, err)
+		}
+		bid, ok := book.BestBid()
+		if !ok {
+			return nil, nil
+		}
+		opportunity.Legs[i] = Leg{
+			TokenID: token.TokenID,
+			Bid:     bid.Price,
+			Depth:   bid.Size,
+			Net:     model.EffectivePrice(types.SELL, bid.Price, true),
+		}
+	}
+	if opportunity.Legs[1].Bid > opportunity.Legs[0].Bid {
+		opportunity.Legs[0], opportunity.Legs[1] = opportunity.Legs[1], opportunity.Legs[0]
+	}
+
+	opportunity.Size = math.Min(opportunity.Legs[0].Depth, opportunity.Legs[1].Depth)
+	if m.config.MaxSize > 0 {
+		opportunity.Size = math.Min(opportunity.Size, m.config.MaxSize)
+	}
+	opportunity.Size = utils.RoundDown(opportunity.Size, 2)
+	opportunity.Edge = opportunity.Legs[0].Net + opportunity.Legs[1].Net - 1
+	opportunity.Profit = opportunity.Edge*opportunity.Size - m.config.GasCost
+
+	if opportunity.Edge < m.config.MinEdge || opportunity.Size < m.config.MinSize || opportunity.Profit <= 0 {
+		return nil, nil
+	}
+	return opportunity, nil
+}
+
+// Execute splits the opportunity's sets on-chain, waits for the transaction to
+// be mined and sells both legs, the richer first, at the bids evaluated. With
+// the default FOK orders a bid that moved away leaves its leg unsold and held;
+// such legs carry their error and can be sold later or merged back.
+func (m *Minter) Execute(ctx context.Context, opportunity Opportunity) Result {
+	result := Result{Opportunity: opportunity}
+	market := opportunity.Market
+
+	result.TxHash, result.Err = m.config.Splitter.SplitPosition(ctx, market.ConditionID, opportunity.Size, market.NegRisk)
+	if result.Err != nil {
+		result.Err = fmt.Errorf("failed to split position: %w", result.Err)
+		return m.report(result)
+	}
+	receipt, err := m.config.Splitter.WaitForReceipt(ctx, result.TxHash)
+	if err != nil {
+		result.Err = fmt.Errorf("failed to confirm split: %w", err)
+		return m.report(result)
+	}
+	if !receipt.Success {
+		result.Err = fmt.Errorf("split %s reverted", result.TxHash)
+		return m.report(result)
+	}
+
+	for i := range result.Opportunity.Legs {
+		leg := &result.Opportunity.Legs[i]
+		leg.OrderID, leg.Err = m.sell(leg, opportunity.Size)
+	}
+	return m.report(result)
+}
+
+// MintAndSell evaluates a market and executes the opportunity when there is
+// one. It returns nil when the market does not pay for minting.
+func (m *Minter) MintAndSell(ctx context.Context, market *types.Market) (*Result, error) {
+	opportunity, err := m.Evaluate(market)
+	if err != nil || opportunity == nil {
+		return nil, err
+	}
+	result := m.Execute(ctx, *opportunity)
+	return &result, nil
+}
+
+// Run calls MintAndSell on markets every Interval until ctx is cancelled
+func (m *Minter) Run(ctx context.Context, markets []*types.Market) {
+	ticker := time.NewTicker(m.config.Interval)
+	defer ticker.Stop()
+
+	for {
+		for _, market := range markets {
+			if ctx.Err() != nil {
+				return
+			}
+			if _, err := m.MintAndSell(ctx, market); err != nil && m.config.OnError != nil {
+				m.config.OnError(err)
+			}
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// sell sells size shares of a leg at its evaluated bid
+func (m *Minter) sell(leg *Leg, size float64) (string, error) {
+	signedOrder, err := m.config.Trader.CreateOrder(types.OrderArgs{
+		TokenID: leg.TokenID,
+		Price:   leg.Bid,
+		Size:    size,
+		Side:    types.SELL,
+	}, nil)
+	if err != nil {
+		return "", fmt.Errorf("failed to create order: %w", err)
+	}
+	resp, err := m.config.Trader.PostOrder(signedOrder, m.config.OrderType)
+	if err != nil {
+		return "", fmt.Errorf("failed to post order: %w", err)
+	}
+	if success, _ := resp["success"].(bool); !success {
+		message, _ := resp["errorMsg"].(string)
+		return "", fmt.Errorf("order not accepted: %s", message)
+	}
+	orderID, _ := resp["orderID"].(string)
+	return orderID, nil
+}
+
+// report passes a result to OnResult and returns it
+func (m *Minter) report(result Result) Result {
+	if m.config.OnResult != nil {
+		m.config.OnResult(result)
+	}
+	return result
+}
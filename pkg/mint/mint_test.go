@@ -0,0 +1,91 @@
+package mint
+
+import (
+	"context"
+	"testing"
+
+	"github.com/MaDal776/polymarket-go-client/pkg/client"
+	"github.com/MaDal776/polymarket-go-client/pkg/onchain"
+	"github.com/MaDal776/polymarket-go-client/pkg/types"
+)
+
+type fakeSplitter struct {
+	split float64
+}
+
+func (f *fakeSplitter) SplitPosition(ctx context.Context, conditionID string, amount float64, negRisk bool) (string, error) {
+	f.split = amount
+	return "0xtx", nil
+}
+
+func (f *fakeSplitter) WaitForReceipt(ctx context.Context, txHash string) (*onchain.Receipt, error) {
+	return &onchain.Receipt{TxHash: txHash, Success: true}, nil
+}
+
+// fakeTrader serves fixed books and records the orders posted; other Trader
+// methods are not used
+type fakeTrader struct {
+	client.Trader
+	books   map[string]*types.OrderBookSummary
+	created []types.OrderArgs
+}
+
+func (f *fakeTrader) GetOrderBook(tokenID string) (*types.OrderBookSummary, error) {
+	return f.books[tokenID], nil
+}
+
+func (f *fakeTrader) CreateOrder(orderArgs types.OrderArgs, options *types.CreateOrderOptions) (*types.SignedOrder, error) {
+	f.created = append(f.created, orderArgs)
+	return &types.SignedOrder{}, nil
+}
+
+func (f *fakeTrader) PostOrder(signedOrder *types.SignedOrder, orderType types.OrderType) (map[string]interface{}, error) {
+	return map[string]interface{}{"success": true, "orderID": "0xabc"}, nil
+}
+
+func testMarket() *types.Market {
+	return &types.Market{ConditionID: "0xc", Tokens: []types.MarketToken{{TokenID: "yes"}, {TokenID: "no"}}}
+}
+
+func testTrader(yesBid, noBid string) *fakeTrader {
+	return &fakeTrader{books: map[string]*types.OrderBookSummary{
+		"yes": {Bids: []types.OrderSummary{{Price: yesBid, Size: "40"}}},
+		"no":  {Bids: []types.OrderSummary{{Price: noBid, Size: "25.555"}}},
+	}}
+}
+
+func TestMintAndSell(t *testing.T) {
+	trader := testTrader("0.42", "0.6")
+	splitter := &fakeSplitter{}
+	minter, err := NewMinter(Config{Trader: trader, Splitter: splitter, GasCost: 0.05})
+	if err != nil {
+		t.Fatalf("Failed to create minter: %v", err)
+	}
+
+	result, err := minter.MintAndSell(context.Background(), testMarket())
+	if err != nil || result == nil {
+		t.Fatalf("MintAndSell = %v, %v", result, err)
+	}
+	if !result.Sold() || splitter.split != 25.55 {
+		t.Errorf("Expected 25.55 sets minted and sold, split %v: %+v", splitter.split, result)
+	}
+	// The richer leg is sold first, at the evaluated bids
+	if len(trader.created) != 2 || trader.created[0].TokenID != "no" || trader.created[0].Price != 0.6 ||
+		trader.created[1].TokenID != "yes" || trader.created[1].Size != 25.55 || trader.created[1].Side != types.SELL {
+		t.Errorf("Unexpected orders: %+v", trader.created)
+	}
+	if profit := result.Opportunity.Profit; profit < 0.46 || profit > 0.462 {
+		t.Errorf("Expected a profit of 0.02 * 25.55 - 0.05, got %v", profit)
+	}
+}
+
+func TestMintAndSellSkipsThinEdge(t *testing.T) {
+	trader := testTrader("0.42", "0.583")
+	splitter := &fakeSplitter{}
+	minter, _ := NewMinter(Config{Trader: trader, Splitter: splitter})
+
+	result, err := minter.MintAndSell(context.Background(), testMarket())
+	if err != nil || result != nil || splitter.split != 0 {
+		t.Errorf("Expected no mint below MinEdge, got %+v, %v", result, err)
+	}
+}
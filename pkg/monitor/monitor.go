@@ -0,0 +1,193 @@
+package monitor
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/MaDal776/polymarket-go-client/pkg/types"
+)
+
+// AlertKind identifies the condition that raised an alert
+type AlertKind string
+
+const (
+	AlertSpreadWide    AlertKind = "SPREAD_WIDE"    // Spread exceeded MaxSpread
+	AlertDepthCollapse AlertKind = "DEPTH_COLLAPSE" // Depth on a side fell below MinDepth
+	AlertOneSided      AlertKind = "ONE_SIDED"      // One side of the book is empty
+)
+
+// Alert describes a liquidity condition observed on a token's book
+type Alert struct {
+	Kind     AlertKind               `json:"kind"`
+	TokenID  string                  `json:"token_id"`
+	BestBid  float64                 `json:"best_bid"`
+	BestAsk  float64                 `json:"best_ask"`
+	Spread   float64                 `json:"spread"`
+	BidDepth float64                 `json:"bid_depth"`
+	AskDepth float64                 `json:"ask_depth"`
+	Time     time.Time               `json:"time"`
+	Book     *types.OrderBookSummary `json:"-"`
+}
+
+// Thresholds configures when alerts are raised
+type Thresholds struct {
+	MaxSpread float64 // Alert when best ask - best bid exceeds this; 0 disables
+	MinDepth  float64 // Alert when either side's depth (shares) falls below this; 0 disables
+	DepthBand float64 // Price distance from the best level counted as depth; 0 counts every level
+}
+
+// BookSource provides order books (e.g. the CLOB client)
+type BookSource interface {
+	GetOrderBook(tokenID string) (*types.OrderBookSummary, error)
+}
+
+// Config configures a Monitor
+type Config struct {
+	Tokens     []string
+	Interval   time.Duration // Polling interval used by Run; default 5s
+	Thresholds Thresholds
+	OnAlert    func(alert Alert)               // Called when a condition starts
+	OnClear    func(alert Alert)               // Called when a condition ends
+	OnError    func(tokenID string, err error) // Called when a book fetch fails
+}
+
+// Monitor watches order books and raises alerts on liquidity changes
+type Monitor struct {
+	source BookSource
+	config Config
+
+	mu     sync.Mutex
+	active map[string]map[AlertKind]bool
+}
+
+// NewMonitor creates a monitor for the configured tokens
+func NewMonitor(source BookSource, config Config) (*Monitor, error) {
+	if source == nil {
+		return nil, fmt.Errorf("book source is required")
+	}
+	if len(config.Tokens) == 0 {
+		return nil, fmt.Errorf("at least one token is required")
+	}
+	if config.Interval <= 0 {
+		config.Interval = 5 * time.Second
+	}
+
+	return &Monitor{
+		source: source,
+		config: config,
+		active: make(map[string]map[AlertKind]bool),
+	}, nil
+}
+
+// Run polls every configured token each Interval until ctx is cancelled
+func (m *Monitor) Run(ctx context.Context) {
+	ticker := time.NewTicker(m.config.Interval)
+	defer ticker.Stop()
+
+	for {
+		for _, tokenID := range m.config.Tokens {
+			if _, err := m.Check(tokenID); err != nil && m.config.OnError != nil {
+				m.config.OnError(tokenID, err)
+			}
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// Check fetches the book for a token, dispatches alert transitions and
+// returns the conditions currently active
+func (m *Monitor) Check(tokenID string) ([]Alert, error) {
+	book, err := m.source.GetOrderBook(tokenID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get order book for %s: %w", tokenID, err)
+	}
+
+	alerts, err := Evaluate(book, m.config.Thresholds)
+	if err != nil {
+		return nil, err
+	}
+	for i := range alerts {
+		alerts[i].TokenID = tokenID
+	}
+
+	m.dispatch(tokenID, alerts, book)
+	return alerts, nil
+}
+
+// dispatch invokes OnAlert for new conditions and OnClear for resolved ones
+func (m *Monitor) dispatch(tokenID string, alerts []Alert, book *types.OrderBookSummary) {
+	m.mu.Lock()
+	previous := m.active[tokenID]
+	current := make(map[AlertKind]bool, len(alerts))
+	started := make([]Alert, 0)
+	for _, alert := range alerts {
+		current[alert.Kind] = true
+		if !previous[alert.Kind] {
+			started = append(started, alert)
+		}
+	}
+	cleared := make([]AlertKind, 0)
+	for kind := range previous {
+		if !current[kind] {
+			cleared = append(cleared, kind)
+		}
+	}
+	m.active[tokenID] = current
+	m.mu.Unlock()
+
+	if m.config.OnAlert != nil {
+		for _, alert := range started {
+			m.config.OnAlert(alert)
+		}
+	}
+	if m.config.OnClear != nil {
+		for _, kind := range cleared {
+			m.config.OnClear(Alert{Kind: kind, TokenID: tokenID, Time: time.Now(), Book: book})
+		}
+	}
+}
+
+// Evaluate returns the alert conditions present in a book
+func Evaluate(book *types.OrderBookSummary, thresholds Thresholds) ([]Alert, error) {
+	bids, asks, err := book.Levels()
+	if err != nil {
+		return nil, err
+	}
+
+	base := Alert{TokenID: book.AssetID, Time: time.Now(), Book: book}
+	base.BidDepth, base.AskDepth = book.DepthWithin(thresholds.DepthBand)
+	if len(bids) > 0 {
+		base.BestBid = bids[0].Price
+	}
+	if len(asks) > 0 {
+		base.BestAsk = asks[0].Price
+	}
+
+	alerts := make([]Alert, 0)
+	if len(bids) == 0 || len(asks) == 0 {
+		alert := base
+		alert.Kind = AlertOneSided
+		alerts = append(alerts, alert)
+		return alerts, nil
+	}
+
+	base.Spread, _ = book.Spread()
+	if thresholds.MaxSpread > 0 && base.Spread > thresholds.MaxSpread {
+		alert := base
+		alert.Kind = AlertSpreadWide
+		alerts = append(alerts, alert)
+	}
+	if thresholds.MinDepth > 0 && (base.BidDepth < thresholds.MinDepth || base.AskDepth < thresholds.MinDepth) {
+		alert := base
+		alert.Kind = AlertDepthCollapse
+		alerts = append(alerts, alert)
+	}
+	return alerts, nil
+}
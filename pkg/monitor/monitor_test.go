@@ -0,0 +1,119 @@
+package monitor
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/MaDal776/polymarket-go-client/pkg/types"
+)
+
+const testToken = "123"
+
+// fakeBooks serves the current book for a token
+type fakeBooks struct {
+	book *types.OrderBookSummary
+	err  error
+}
+
+func (f *fakeBooks) GetOrderBook(tokenID string) (*types.OrderBookSummary, error) {
+	return f.book, f.err
+}
+
+func book(bids, asks []types.OrderSummary) *types.OrderBookSummary {
+	return &types.OrderBookSummary{AssetID: testToken, Bids: bids, Asks: asks}
+}
+
+func kinds(alerts []Alert) map[AlertKind]bool {
+	found := make(map[AlertKind]bool, len(alerts))
+	for _, alert := range alerts {
+		found[alert.Kind] = true
+	}
+	return found
+}
+
+func TestEvaluate(t *testing.T) {
+	thresholds := Thresholds{MaxSpread: 0.03, MinDepth: 20, DepthBand: 0.02}
+
+	healthy := book(
+		[]types.OrderSummary{{Price: "0.45", Size: "15"}, {Price: "0.44", Size: "10"}},
+		[]types.OrderSummary{{Price: "0.48", Size: "30"}},
+	)
+	alerts, err := Evaluate(healthy, thresholds)
+	if err != nil {
+		t.Fatalf("Evaluate: %v", err)
+	}
+	if len(alerts) != 0 {
+		t.Errorf("Expected no alerts at a spread of exactly MaxSpread, got %+v", alerts)
+	}
+
+	wide := book(
+		[]types.OrderSummary{{Price: "0.40", Size: "50"}},
+		[]types.OrderSummary{{Price: "0.50", Size: "5"}},
+	)
+	alerts, _ = Evaluate(wide, thresholds)
+	found := kinds(alerts)
+	if len(alerts) != 2 || !found[AlertSpreadWide] || !found[AlertDepthCollapse] {
+		t.Fatalf("Expected wide spread and depth collapse, got %+v", alerts)
+	}
+	if alert := alerts[0]; alert.BestBid != 0.40 || alert.BestAsk != 0.50 || alert.BidDepth != 50 || alert.AskDepth != 5 || alert.TokenID != testToken {
+		t.Errorf("Unexpected alert fields %+v", alert)
+	}
+
+	oneSided := book([]types.OrderSummary{{Price: "0.40", Size: "50"}}, nil)
+	alerts, _ = Evaluate(oneSided, thresholds)
+	if len(alerts) != 1 || alerts[0].Kind != AlertOneSided {
+		t.Errorf("Expected only a one-sided alert, got %+v", alerts)
+	}
+}
+
+func TestCheckDispatchesTransitions(t *testing.T) {
+	source := &fakeBooks{book: book(
+		[]types.OrderSummary{{Price: "0.40", Size: "50"}},
+		[]types.OrderSummary{{Price: "0.50", Size: "50"}},
+	)}
+	var raised, cleared []AlertKind
+	m, err := NewMonitor(source, Config{
+		Tokens:     []string{testToken},
+		Thresholds: Thresholds{MaxSpread: 0.05},
+		OnAlert:    func(alert Alert) { raised = append(raised, alert.Kind) },
+		OnClear:    func(alert Alert) { cleared = append(cleared, alert.Kind) },
+	})
+	if err != nil {
+		t.Fatalf("NewMonitor: %v", err)
+	}
+
+	for i := 0; i < 2; i++ {
+		if _, err := m.Check(testToken); err != nil {
+			t.Fatalf("Check: %v", err)
+		}
+	}
+	if len(raised) != 1 || raised[0] != AlertSpreadWide {
+		t.Fatalf("Expected the wide spread to be raised once, got %v", raised)
+	}
+
+	source.book = book(
+		[]types.OrderSummary{{Price: "0.47", Size: "50"}},
+		[]types.OrderSummary{{Price: "0.50", Size: "50"}},
+	)
+	active, err := m.Check(testToken)
+	if err != nil {
+		t.Fatalf("Check: %v", err)
+	}
+	if len(active) != 0 || len(cleared) != 1 || cleared[0] != AlertSpreadWide {
+		t.Errorf("Expected the wide spread to clear, got active %+v, cleared %v", active, cleared)
+	}
+
+	source.err = errors.New("unavailable")
+	if _, err := m.Check(testToken); err == nil {
+		t.Error("Expected the book fetch error")
+	}
+}
+
+func TestNewMonitorValidates(t *testing.T) {
+	if _, err := NewMonitor(nil, Config{Tokens: []string{testToken}}); err == nil {
+		t.Error("Expected a missing book source to be refused")
+	}
+	if _, err := NewMonitor(&fakeBooks{}, Config{}); err == nil {
+		t.Error("Expected a monitor without tokens to be refused")
+	}
+}
@@ -0,0 +1,135 @@
+package notifications
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/MaDal776/polymarket-go-client/pkg/types"
+)
+
+// Source fetches and acknowledges notifications (e.g. the CLOB client)
+type Source interface {
+	GetNotifications() ([]types.Notification, error)
+	DropNotifications(ids []string) error
+}
+
+// Config configures a Poller
+type Config struct {
+	Interval     time.Duration // Polling interval used by Run; default 10s
+	DedupWindow  time.Duration // How long delivered IDs are remembered; default 1h
+	KeepOnServer bool          // Do not acknowledge delivered notifications
+
+	OnFill       func(notification types.Notification)
+	OnCancel     func(notification types.Notification)
+	OnResolution func(notification types.Notification)
+	OnOther      func(notification types.Notification) // Types without a dedicated callback
+	OnError      func(err error)                       // Called when a poll or acknowledgement fails in Run
+}
+
+// Poller delivers account notifications over REST, as an alternative to the user websocket
+type Poller struct {
+	source Source
+	config Config
+
+	mu   sync.Mutex
+	seen map[string]time.Time
+}
+
+// NewPoller creates a poller reading from source
+func NewPoller(source Source, config Config) (*Poller, error) {
+	if source == nil {
+		return nil, fmt.Errorf("notification source is required")
+	}
+	if config.Interval <= 0 {
+		config.Interval = 10 * time.Second
+	}
+	if config.DedupWindow <= 0 {
+		config.DedupWindow = time.Hour
+	}
+
+	return &Poller{
+		source: source,
+		config: config,
+		seen:   make(map[string]time.Time),
+	}, nil
+}
+
+// Poll fetches notifications once, dispatches the ones not delivered before and
+// acknowledges them. It returns the number dispatched.
+func (p *Poller) Poll() (int, error) {
+	notifications, err := p.source.GetNotifications()
+	if err != nil {
+		return 0, fmt.Errorf("failed to get notifications: %w", err)
+	}
+
+	now := time.Now()
+	fresh := make([]types.Notification, 0, len(notifications))
+	ids := make([]string, 0, len(notifications))
+	p.mu.Lock()
+	for id, at := range p.seen {
+		if now.Sub(at) > p.config.DedupWindow {
+			delete(p.seen, id)
+		}
+	}
+	for _, notification := range notifications {
+		if notification.ID != "" {
+			ids = append(ids, notification.ID)
+			if _, delivered := p.seen[notification.ID]; delivered {
+				continue
+			}
+			p.seen[notification.ID] = now
+		}
+		fresh = append(fresh, notification)
+	}
+	p.mu.Unlock()
+
+	for _, notification := range fresh {
+		p.dispatch(notification)
+	}
+
+	// Acknowledge redelivered notifications too, in case an earlier drop failed
+	if !p.config.KeepOnServer && len(ids) > 0 {
+		if err := p.source.DropNotifications(ids); err != nil {
+			return len(fresh), fmt.Errorf("failed to drop notifications: %w", err)
+		}
+	}
+	return len(fresh), nil
+}
+
+// Run calls Poll every Interval until ctx is cancelled
+func (p *Poller) Run(ctx context.Context) {
+	ticker := time.NewTicker(p.config.Interval)
+	defer ticker.Stop()
+
+	for {
+		if _, err := p.Poll(); err != nil && p.config.OnError != nil {
+			p.config.OnError(err)
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// dispatch passes a notification to the callback for its type
+func (p *Poller) dispatch(notification types.Notification) {
+	var callback func(notification types.Notification)
+	switch notification.Type {
+	case types.NotificationOrderFilled:
+		callback = p.config.OnFill
+	case types.NotificationOrderCancelled:
+		callback = p.config.OnCancel
+	case types.NotificationMarketResolved:
+		callback = p.config.OnResolution
+	default:
+		callback = p.config.OnOther
+	}
+	if callback != nil {
+		callback(notification)
+	}
+}
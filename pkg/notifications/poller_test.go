@@ -0,0 +1,76 @@
+package notifications
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/MaDal776/polymarket-go-client/pkg/types"
+)
+
+const testResponse = `[
+	{"id": 101, "owner": "key", "type": 2, "payload": {"order_id": "0xabc", "matched_size": "4", "price": 0.55, "side": "BUY"}},
+	{"id": "102", "owner": "key", "type": 1, "payload": {"order_id": "0xdef"}},
+	{"id": 103, "owner": "key", "type": 4, "payload": {"market": "0xc0nd", "outcome": "Yes"}},
+	{"id": 104, "owner": "key", "type": 9, "payload": {}}
+]`
+
+type fakeSource struct {
+	notifications []types.Notification
+	dropped       [][]string
+}
+
+func (f *fakeSource) GetNotifications() ([]types.Notification, error) {
+	return f.notifications, nil
+}
+
+func (f *fakeSource) DropNotifications(ids []string) error {
+	f.dropped = append(f.dropped, ids)
+	return nil
+}
+
+func TestPollDispatchesAndAcknowledges(t *testing.T) {
+	source := &fakeSource{}
+	if err := json.Unmarshal([]byte(testResponse), &source.notifications); err != nil {
+		t.Fatalf("Failed to decode notifications: %v", err)
+	}
+
+	var fills, cancels, resolutions, others []types.Notification
+	p, err := NewPoller(source, Config{
+		OnFill:       func(n types.Notification) { fills = append(fills, n) },
+		OnCancel:     func(n types.Notification) { cancels = append(cancels, n) },
+		OnResolution: func(n types.Notification) { resolutions = append(resolutions, n) },
+		OnOther:      func(n types.Notification) { others = append(others, n) },
+	})
+	if err != nil {
+		t.Fatalf("Failed to create poller: %v", err)
+	}
+
+	dispatched, err := p.Poll()
+	if err != nil || dispatched != 4 {
+		t.Fatalf("Dispatched %d (%v), want 4", dispatched, err)
+	}
+	if len(fills) != 1 || fills[0].ID != "101" || fills[0].Payload.OrderID != "0xabc" || fills[0].Payload.Price != "0.55" {
+		t.Errorf("Unexpected fills %+v", fills)
+	}
+	if len(cancels) != 1 || cancels[0].ID != "102" {
+		t.Errorf("Unexpected cancels %+v", cancels)
+	}
+	if len(resolutions) != 1 || resolutions[0].Payload.Outcome != "Yes" {
+		t.Errorf("Unexpected resolutions %+v", resolutions)
+	}
+	if len(others) != 1 {
+		t.Errorf("Unexpected others %+v", others)
+	}
+	if len(source.dropped) != 1 || len(source.dropped[0]) != 4 {
+		t.Fatalf("Unexpected drops %v", source.dropped)
+	}
+
+	// Redelivered notifications are acknowledged again but not dispatched twice
+	dispatched, err = p.Poll()
+	if err != nil || dispatched != 0 {
+		t.Errorf("Dispatched %d (%v) on redelivery, want 0", dispatched, err)
+	}
+	if len(fills) != 1 || len(source.dropped) != 2 {
+		t.Errorf("Fills %d, drops %d after redelivery", len(fills), len(source.dropped))
+	}
+}
@@ -0,0 +1,141 @@
+package onchain
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"math/big"
+	"strings"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+
+	"github.com/MaDal776/polymarket-go-client/pkg/client"
+)
+
+// Function selectors
+var (
+	ctfMergeSelector     = crypto.Keccak256([]byte("mergePositions(address,bytes32,bytes32,uint256[],uint256)"))[:4]
+	adapterMergeSelector = crypto.Keccak256([]byte("mergePositions(bytes32,uint256)"))[:4]
+	ctfSplitSelector     = crypto.Keccak256([]byte("splitPosition(address,bytes32,bytes32,uint256[],uint256)"))[:4]
+	adapterSplitSelector = crypto.Keccak256([]byte("splitPosition(bytes32,uint256)"))[:4]
+)
+
+// MergePositions burns amount shares of both outcomes of a condition and returns
+// amount USDC. Neg risk conditions are merged through the neg risk adapter.
+// It returns once the transaction is sent; use WaitForReceipt to confirm it.
+func (c *Client) MergePositions(ctx context.Context, conditionID string, amount float64, negRisk bool) (string, error) {
+	return c.sendPositionCall(ctx, "merge_positions", conditionID, amount, negRisk, ctfMergeSelector, adapterMergeSelector)
+}
+
+// SplitPosition turns amount USDC into amount shares of both outcomes of a
+// condition. Neg risk conditions are split through the neg risk adapter. It
+// returns once the transaction is sent; use WaitForReceipt to confirm it.
+func (c *Client) SplitPosition(ctx context.Context, conditionID string, amount float64, negRisk bool) (string, error) {
+	return c.sendPositionCall(ctx, "split_position", conditionID, amount, negRisk, ctfSplitSelector, adapterSplitSelector)
+}
+
+// sendPositionCall sends a merge or split of a binary condition to the
+// conditional tokens contract, or to the neg risk adapter for neg risk conditions
+func (c *Client) sendPositionCall(ctx context.Context, operation, conditionID string, amount float64, negRisk bool, ctfSelector, adapterSelector []byte) (string, error) {
+	start := time.Now()
+
+	config, exists := client.GetContractConfig(c.chainID.Int64(), negRisk)
+	if !exists {
+		c.recordMetric(operation, start, false, "unsupported chain")
+		return "", fmt.Errorf("%w: %d", client.ErrUnsupportedChainID, c.chainID.Int64())
+	}
+	condition, err := parseBytes32(conditionID)
+	if err != nil {
+		c.recordMetric(operation, start, false, err.Error())
+		return "", err
+	}
+	units := shareUnits(amount)
+	if units.Sign() <= 0 {
+		c.recordMetric(operation, start, false, "invalid amount")
+		return "", fmt.Errorf("invalid amount: %v", amount)
+	}
+
+	var to string
+	var data []byte
+	if negRisk {
+		if config.NegRiskAdapter == "" {
+			c.recordMetric(operation, start, false, "no neg risk adapter")
+			return "", fmt.Errorf("no neg risk adapter for chain ID %d", c.chainID.Int64())
+		}
+		to = config.NegRiskAdapter
+		data = encodeAdapterCall(adapterSelector, condition, units)
+	} else {
+		to = config.ConditionalTokens
+		data = encodeCTFCall(ctfSelector, common.HexToAddress(config.Collateral), condition, units)
+	}
+
+	txHash, err := c.SendTransaction(ctx, to, data)
+	if err != nil {
+		c.recordMetric(operation, start, false, err.Error())
+		return "", fmt.Errorf("failed to %s: %w", strings.ReplaceAll(operation, "_", " "), err)
+	}
+
+	c.recordMetric(operation, start, true, "")
+	return txHash, nil
+}
+
+// encodeCTFMerge encodes ConditionalTokens.mergePositions for a binary condition
+// with no parent collection
+func encodeCTFMerge(collateral common.Address, conditionID [32]byte, amount *big.Int) []byte {
+	return encodeCTFCall(ctfMergeSelector, collateral, conditionID, amount)
+}
+
+// encodeCTFCall encodes mergePositions or splitPosition, which take the same
+// arguments, for a binary condition with no parent collection
+func encodeCTFCall(selector []byte, collateral common.Address, conditionID [32]byte, amount *big.Int) []byte {
+	data := make([]byte, 0, 4+32*8)
+	data = append(data, selector...)
+	data = append(data, common.LeftPadBytes(collateral.Bytes(), 32)...)
+	data = append(data, make([]byte, 32)...) // Parent collection
+	data = append(data, conditionID[:]...)
+	data = append(data, word(big.NewInt(5*32))...) // Offset of the partition
+	data = append(data, word(amount)...)
+
+	// Partition: index sets 1 (first outcome) and 2 (second outcome)
+	data = append(data, word(big.NewInt(2))...)
+	data = append(data, word(big.NewInt(1))...)
+	data = append(data, word(big.NewInt(2))...)
+	return data
+}
+
+// encodeAdapterCall encodes NegRiskAdapter.mergePositions or splitPosition
+func encodeAdapterCall(selector []byte, conditionID [32]byte, amount *big.Int) []byte {
+	data := make([]byte, 0, 4+32*2)
+	data = append(data, selector...)
+	data = append(data, conditionID[:]...)
+	data = append(data, word(amount)...)
+	return data
+}
+
+// shareUnits converts shares to 6-decimal base units, rounding down (past float
+// noise) so a merge or split never asks for more than is held
+func shareUnits(amount float64) *big.Int {
+	return big.NewInt(int64(math.Floor(amount*1e6 + 1e-3)))
+}
+
+// word left-pads a value to a 32-byte ABI word
+func word(value *big.Int) []byte {
+	return common.LeftPadBytes(value.Bytes(), 32)
+}
+
+// parseBytes32 parses a 0x-prefixed 32-byte hex value such as a condition ID
+func parseBytes32(value string) ([32]byte, error) {
+	var out [32]byte
+	hex := strings.TrimPrefix(value, "0x")
+	if len(hex) != 64 {
+		return out, fmt.Errorf("invalid condition ID: %s", value)
+	}
+	bytes := common.FromHex(hex)
+	if len(bytes) != 32 {
+		return out, fmt.Errorf("invalid condition ID: %s", value)
+	}
+	copy(out[:], bytes)
+	return out, nil
+}
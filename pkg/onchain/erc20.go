@@ -0,0 +1,167 @@
+package onchain
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/big"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/crypto"
+
+	"github.com/MaDal776/polymarket-go-client/pkg/amount"
+	"github.com/MaDal776/polymarket-go-client/pkg/client"
+	"github.com/MaDal776/polymarket-go-client/pkg/types"
+)
+
+// ERC-20 function selectors
+var (
+	balanceOfSelector = crypto.Keccak256([]byte("balanceOf(address)"))[:4]
+	allowanceSelector = crypto.Keccak256([]byte("allowance(address,address)"))[:4]
+)
+
+// ErrWrongCollateral is returned by CheckTradingCollateral when the account holds
+// native USDC but no USDC.e, the only collateral the exchange settles in
+var ErrWrongCollateral = errors.New("collateral held as native USDC; the exchange settles in USDC.e")
+
+// SetCollateral selects the USDC token CollateralBalance and CollateralAllowance
+// read; the default is types.CollateralBridged
+func (c *Client) SetCollateral(token types.CollateralToken) {
+	c.collateral = token
+}
+
+// CollateralBalance returns owner's balance of the selected collateral token.
+// An empty owner means the signer's address.
+func (c *Client) CollateralBalance(ctx context.Context, owner string) (amount.USDC, error) {
+	return c.TokenBalance(ctx, c.collateral, owner)
+}
+
+// TokenBalance returns owner's balance of a collateral token
+func (c *Client) TokenBalance(ctx context.Context, token types.CollateralToken, owner string) (amount.USDC, error) {
+	start := time.Now()
+
+	address, err := c.collateralAddress(token)
+	if err != nil {
+		c.recordMetric("collateral_balance", start, false, err.Error())
+		return 0, err
+	}
+	ownerAddress, err := c.resolveOwner(owner)
+	if err != nil {
+		c.recordMetric("collateral_balance", start, false, err.Error())
+		return 0, err
+	}
+
+	data := append(append([]byte{}, balanceOfSelector...), common.LeftPadBytes(ownerAddress.Bytes(), 32)...)
+	balance, err := c.callUint(ctx, address, data)
+	if err != nil {
+		c.recordMetric("collateral_balance", start, false, err.Error())
+		return 0, fmt.Errorf("failed to get %s balance: %w", token, err)
+	}
+
+	c.recordMetric("collateral_balance", start, true, "")
+	return amount.USDC(balance.Int64()), nil
+}
+
+// CollateralAllowance returns how much of the selected collateral token spender
+// may move on owner's behalf. An empty owner means the signer's address.
+func (c *Client) CollateralAllowance(ctx context.Context, owner, spender string) (amount.USDC, error) {
+	start := time.Now()
+
+	address, err := c.collateralAddress(c.collateral)
+	if err != nil {
+		c.recordMetric("collateral_allowance", start, false, err.Error())
+		return 0, err
+	}
+	ownerAddress, err := c.resolveOwner(owner)
+	if err != nil {
+		c.recordMetric("collateral_allowance", start, false, err.Error())
+		return 0, err
+	}
+	if !common.IsHexAddress(spender) {
+		c.recordMetric("collateral_allowance", start, false, "invalid spender")
+		return 0, fmt.Errorf("invalid spender address: %s", spender)
+	}
+
+	data := append(append([]byte{}, allowanceSelector...), common.LeftPadBytes(ownerAddress.Bytes(), 32)...)
+	data = append(data, common.LeftPadBytes(common.HexToAddress(spender).Bytes(), 32)...)
+	allowance, err := c.callUint(ctx, address, data)
+	if err != nil {
+		c.recordMetric("collateral_allowance", start, false, err.Error())
+		return 0, fmt.Errorf("failed to get %s allowance: %w", c.collateralName(), err)
+	}
+
+	c.recordMetric("collateral_allowance", start, true, "")
+	// Unlimited approvals do not fit an int64; report them as the largest amount
+	if !allowance.IsInt64() {
+		return amount.USDC(1<<63 - 1), nil
+	}
+	return amount.USDC(allowance.Int64()), nil
+}
+
+// CheckTradingCollateral returns an error matching ErrWrongCollateral when owner
+// has no USDC.e but does hold native USDC, which explains an otherwise puzzling
+// zero collateral balance from the CLOB. An empty owner means the signer's address.
+func (c *Client) CheckTradingCollateral(ctx context.Context, owner string) error {
+	bridged, err := c.TokenBalance(ctx, types.CollateralBridged, owner)
+	if err != nil || bridged > 0 {
+		return err
+	}
+	if _, err := c.collateralAddress(types.CollateralNative); err != nil {
+		return nil
+	}
+	native, err := c.TokenBalance(ctx, types.CollateralNative, owner)
+	if err != nil {
+		return err
+	}
+	if native > 0 {
+		return fmt.Errorf("%w: %s USDC held, swap it for USDC.e to trade", ErrWrongCollateral, native)
+	}
+	return nil
+}
+
+// collateralAddress returns the address of a collateral token on the client's chain
+func (c *Client) collateralAddress(token types.CollateralToken) (string, error) {
+	config, exists := client.GetContractConfig(c.chainID.Int64(), false)
+	if !exists {
+		return "", fmt.Errorf("%w: %d", client.ErrUnsupportedChainID, c.chainID.Int64())
+	}
+	address, ok := config.CollateralAddress(token)
+	if !ok {
+		return "", fmt.Errorf("no %s token on chain ID %d", token, c.chainID.Int64())
+	}
+	return address, nil
+}
+
+// collateralName returns the selected collateral token's name
+func (c *Client) collateralName() types.CollateralToken {
+	if c.collateral == "" {
+		return types.CollateralBridged
+	}
+	return c.collateral
+}
+
+// resolveOwner parses owner, defaulting to the signer's address
+func (c *Client) resolveOwner(owner string) (common.Address, error) {
+	if owner == "" {
+		return common.HexToAddress(c.signer.AddressHex()), nil
+	}
+	if !common.IsHexAddress(owner) {
+		return common.Address{}, fmt.Errorf("invalid owner address: %s", owner)
+	}
+	return common.HexToAddress(owner), nil
+}
+
+// callUint makes a read-only contract call returning a single uint256
+func (c *Client) callUint(ctx context.Context, to string, data []byte) (*big.Int, error) {
+	var result hexutil.Bytes
+	call := map[string]string{"to": to, "data": hexutil.Encode(data)}
+	if err := c.call(ctx, &result, "eth_call", call, "latest"); err != nil {
+		return nil, err
+	}
+	if len(result) < 32 {
+		return nil, fmt.Errorf("unexpected eth_call result of %d bytes", len(result))
+	}
+	return new(big.Int).SetBytes(result[:32]), nil
+}
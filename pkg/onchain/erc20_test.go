@@ -0,0 +1,82 @@
+package onchain
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+
+	"github.com/MaDal776/polymarket-go-client/pkg/types"
+)
+
+const (
+	testBridgedUSDC = "0x2791Bca1f2de4661ED88A30C99A7a9449Aa84174"
+	testNativeUSDC  = "0x3c499c542cEF5E3811e1192ce70d8cC03d5c3359"
+)
+
+// tokenNode answers balanceOf calls with a fixed balance per token address
+func tokenNode(t *testing.T, balances map[string]int64) *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var req struct {
+			ID     int64             `json:"id"`
+			Method string            `json:"method"`
+			Params []json.RawMessage `json:"params"`
+		}
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil || req.Method != "eth_call" {
+			t.Errorf("Unexpected request %s: %v", req.Method, err)
+			return
+		}
+		var call struct {
+			To string `json:"to"`
+		}
+		json.Unmarshal(req.Params[0], &call)
+
+		balance := big.NewInt(balances[strings.ToLower(call.To)])
+		result := hexutil.Encode(common.LeftPadBytes(balance.Bytes(), 32))
+		json.NewEncoder(w).Encode(map[string]interface{}{"jsonrpc": "2.0", "id": req.ID, "result": result})
+	}))
+}
+
+func TestCollateralBalanceSelectsToken(t *testing.T) {
+	node := tokenNode(t, map[string]int64{
+		strings.ToLower(testBridgedUSDC): 2500000,
+		strings.ToLower(testNativeUSDC):  7000000,
+	})
+	defer node.Close()
+	c := newTestClient(t, node.URL)
+
+	balance, err := c.CollateralBalance(context.Background(), "")
+	if err != nil {
+		t.Fatalf("CollateralBalance failed: %v", err)
+	}
+	if balance.String() != "2.5" {
+		t.Errorf("Expected 2.5 USDC.e, got %s", balance)
+	}
+
+	c.SetCollateral(types.CollateralNative)
+	balance, err = c.CollateralBalance(context.Background(), "")
+	if err != nil {
+		t.Fatalf("CollateralBalance failed: %v", err)
+	}
+	if balance.String() != "7" {
+		t.Errorf("Expected 7 USDC, got %s", balance)
+	}
+}
+
+func TestCheckTradingCollateralFlagsNativeUSDC(t *testing.T) {
+	node := tokenNode(t, map[string]int64{strings.ToLower(testNativeUSDC): 5000000})
+	defer node.Close()
+	c := newTestClient(t, node.URL)
+
+	err := c.CheckTradingCollateral(context.Background(), "")
+	if !errors.Is(err, ErrWrongCollateral) {
+		t.Fatalf("Expected ErrWrongCollateral, got %v", err)
+	}
+}
@@ -0,0 +1,161 @@
+package onchain
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+)
+
+// FillMonitorConfig configures a FillMonitor
+type FillMonitorConfig struct {
+	Maker         string        // Address whose orders are watched; default the signer's address
+	FromBlock     uint64        // First block to scan; default the latest block at the first poll
+	Confirmations uint64        // Blocks an event must be buried under before it is delivered
+	Interval      time.Duration // Polling interval used by Run; default 5s
+
+	OnFill  func(event FillEvent)
+	OnError func(err error) // Called when a poll fails in Run
+}
+
+// FillMonitor watches the exchange contracts for fills of an address's orders.
+// It confirms fills independently of the CLOB: an order ID returned by PostOrder
+// is the order's hash, so tracking it lets Filled report whether the order
+// settled on-chain.
+type FillMonitor struct {
+	client *Client
+	config FillMonitorConfig
+
+	mu      sync.Mutex
+	next    uint64 // Next block to scan; 0 before the first poll
+	tracked map[string]bool
+	fills   map[string][]FillEvent
+}
+
+// NewFillMonitor creates a monitor reading logs through c
+func NewFillMonitor(c *Client, config FillMonitorConfig) (*FillMonitor, error) {
+	if c == nil {
+		return nil, fmt.Errorf("onchain client is required")
+	}
+	if config.Maker == "" {
+		config.Maker = c.Address()
+	}
+	if !common.IsHexAddress(config.Maker) {
+		return nil, fmt.Errorf("invalid maker address: %s", config.Maker)
+	}
+	if config.Interval <= 0 {
+		config.Interval = 5 * time.Second
+	}
+
+	return &FillMonitor{
+		client:  c,
+		config:  config,
+		next:    config.FromBlock,
+		tracked: make(map[string]bool),
+		fills:   make(map[string][]FillEvent),
+	}, nil
+}
+
+// Track adds order hashes (CLOB order IDs) whose fills are recorded
+func (m *FillMonitor) Track(orderHashes ...string) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	for _, hash := range orderHashes {
+		m.tracked[strings.ToLower(hash)] = true
+	}
+}
+
+// Untrack stops recording fills of order hashes and forgets the ones recorded
+func (m *FillMonitor) Untrack(orderHashes ...string) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	for _, hash := range orderHashes {
+		delete(m.tracked, strings.ToLower(hash))
+		delete(m.fills, strings.ToLower(hash))
+	}
+}
+
+// Fills returns the OrderFilled events seen for a tracked order
+func (m *FillMonitor) Fills(orderHash string) []FillEvent {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return append([]FillEvent(nil), m.fills[strings.ToLower(orderHash)]...)
+}
+
+// Filled reports whether a tracked order has been filled on-chain
+func (m *FillMonitor) Filled(orderHash string) bool {
+	return len(m.Fills(orderHash)) > 0
+}
+
+// Poll scans the blocks since the last poll, records fills of tracked orders
+// and passes every event to OnFill. It returns the number of events delivered.
+func (m *FillMonitor) Poll(ctx context.Context) (int, error) {
+	latest, err := m.client.BlockNumber(ctx)
+	if err != nil {
+		return 0, err
+	}
+	if latest < m.config.Confirmations {
+		return 0, nil
+	}
+	head := latest - m.config.Confirmations
+
+	m.mu.Lock()
+	from := m.next
+	m.mu.Unlock()
+	if from == 0 {
+		from = head
+	}
+	if from > head {
+		return 0, nil
+	}
+
+	events, err := m.client.GetFillEvents(ctx, FillFilter{FromBlock: from, ToBlock: head, Maker: m.config.Maker})
+	if err != nil {
+		return 0, err
+	}
+
+	delivered := make([]FillEvent, 0, len(events))
+	m.mu.Lock()
+	m.next = head + 1
+	for _, event := range events {
+		if event.Removed {
+			continue
+		}
+		hash := strings.ToLower(event.OrderHash)
+		event.Tracked = m.tracked[hash]
+		// OrdersMatched repeats the taker's OrderFilled event, so only the latter counts as a fill
+		if event.Tracked && event.Event == EventOrderFilled {
+			m.fills[hash] = append(m.fills[hash], event)
+		}
+		delivered = append(delivered, event)
+	}
+	m.mu.Unlock()
+
+	if m.config.OnFill != nil {
+		for _, event := range delivered {
+			m.config.OnFill(event)
+		}
+	}
+	return len(delivered), nil
+}
+
+// Run calls Poll every Interval until ctx is cancelled
+func (m *FillMonitor) Run(ctx context.Context) {
+	ticker := time.NewTicker(m.config.Interval)
+	defer ticker.Stop()
+
+	for {
+		if _, err := m.Poll(ctx); err != nil && m.config.OnError != nil {
+			m.config.OnError(err)
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
@@ -0,0 +1,95 @@
+package onchain
+
+import (
+	"context"
+	"encoding/json"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+)
+
+const testOrderHash = "0x9b2f0e1c4b3a29d6f6b0f7a1c4f1a8e2a0c6d5e4b3a2918f7e6d5c4b3a291807"
+
+// fillNode serves one OrderFilled log in block 100 and records the getLogs range
+func fillNode(t *testing.T, ranges *[][2]string) *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var req struct {
+			ID     int64             `json:"id"`
+			Method string            `json:"method"`
+			Params []json.RawMessage `json:"params"`
+		}
+		json.NewDecoder(r.Body).Decode(&req)
+
+		var result interface{}
+		switch req.Method {
+		case "eth_blockNumber":
+			result = "0x66" // 102
+		case "eth_getLogs":
+			var query struct {
+				FromBlock string `json:"fromBlock"`
+				ToBlock   string `json:"toBlock"`
+			}
+			json.Unmarshal(req.Params[0], &query)
+			*ranges = append(*ranges, [2]string{query.FromBlock, query.ToBlock})
+
+			data := make([]byte, 0, 160)
+			for _, value := range []int64{0, 1234, 5000000, 10000000, 0} {
+				data = append(data, common.LeftPadBytes(big.NewInt(value).Bytes(), 32)...)
+			}
+			result = []map[string]interface{}{{
+				"address":         "0x4bFb41d5B3570DeFd03C39a9A4D8dE6Bd8B8982E",
+				"topics":          []string{orderFilledTopic.Hex(), testOrderHash, common.BytesToHash(common.HexToAddress(testBridgedUSDC).Bytes()).Hex(), common.Hash{}.Hex()},
+				"data":            hexutil.Encode(data),
+				"blockNumber":     "0x64",
+				"transactionHash": "0xab",
+				"logIndex":        "0x3",
+			}}
+		default:
+			t.Errorf("Unexpected method %s", req.Method)
+		}
+		json.NewEncoder(w).Encode(map[string]interface{}{"jsonrpc": "2.0", "id": req.ID, "result": result})
+	}))
+}
+
+func TestFillMonitorRecordsTrackedFills(t *testing.T) {
+	var ranges [][2]string
+	node := fillNode(t, &ranges)
+	defer node.Close()
+	c := newTestClient(t, node.URL)
+
+	var delivered []FillEvent
+	monitor, err := NewFillMonitor(c, FillMonitorConfig{
+		FromBlock:     90,
+		Confirmations: 2,
+		OnFill:        func(event FillEvent) { delivered = append(delivered, event) },
+	})
+	if err != nil {
+		t.Fatalf("NewFillMonitor failed: %v", err)
+	}
+	monitor.Track(testOrderHash)
+
+	if _, err := monitor.Poll(context.Background()); err != nil {
+		t.Fatalf("Poll failed: %v", err)
+	}
+	if len(ranges) != 1 || ranges[0] != [2]string{"0x5a", "0x64"} {
+		t.Errorf("Expected blocks 90 to 100, got %v", ranges)
+	}
+	if len(delivered) != 1 || !delivered[0].Tracked || delivered[0].Event != EventOrderFilled {
+		t.Fatalf("Expected one tracked OrderFilled event, got %+v", delivered)
+	}
+	if delivered[0].TakerAssetID.Int64() != 1234 || delivered[0].TakerAmount.Int64() != 10000000 {
+		t.Errorf("Decoded %+v", delivered[0])
+	}
+	if !monitor.Filled(testOrderHash) {
+		t.Error("Expected the tracked order to be filled")
+	}
+
+	// Nothing new until the head moves past the scanned blocks
+	if n, err := monitor.Poll(context.Background()); err != nil || n != 0 || len(ranges) != 1 {
+		t.Errorf("Expected no rescan, got %d events, %v, ranges %v", n, err, ranges)
+	}
+}
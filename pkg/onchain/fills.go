@@ -0,0 +1,193 @@
+package onchain
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+	"strings"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/crypto"
+
+	"github.com/MaDal776/polymarket-go-client/pkg/client"
+)
+
+// Exchange event topics
+var (
+	orderFilledTopic   = common.BytesToHash(crypto.Keccak256([]byte("OrderFilled(bytes32,address,address,uint256,uint256,uint256,uint256,uint256)")))
+	ordersMatchedTopic = common.BytesToHash(crypto.Keccak256([]byte("OrdersMatched(bytes32,address,uint256,uint256,uint256,uint256)")))
+)
+
+// Exchange events
+const (
+	EventOrderFilled   = "OrderFilled"   // An order was (partially) filled; emitted for every order in a match
+	EventOrdersMatched = "OrdersMatched" // A taker order was matched against one or more makers
+)
+
+// MaxLogRange is the most blocks GetFillEvents asks a node for in one call
+const MaxLogRange = 2000
+
+// FillEvent is an OrderFilled or OrdersMatched event of an exchange contract.
+// OrderHash is the order's EIP-712 hash, which is also its CLOB order ID.
+type FillEvent struct {
+	Event        string   `json:"event"`
+	Exchange     string   `json:"exchange"`
+	OrderHash    string   `json:"order_hash"` // For OrdersMatched, the taker order
+	Maker        string   `json:"maker"`      // For OrdersMatched, the taker order's maker
+	Taker        string   `json:"taker,omitempty"`
+	MakerAssetID *big.Int `json:"maker_asset_id"` // 0 is USDC, otherwise an outcome token ID
+	TakerAssetID *big.Int `json:"taker_asset_id"`
+	MakerAmount  *big.Int `json:"maker_amount"` // Filled, in base units
+	TakerAmount  *big.Int `json:"taker_amount"`
+	Fee          *big.Int `json:"fee,omitempty"`
+	TxHash       string   `json:"tx_hash"`
+	BlockNumber  uint64   `json:"block_number"`
+	LogIndex     uint64   `json:"log_index"`
+	Removed      bool     `json:"removed,omitempty"` // Dropped by a chain reorganization
+	Tracked      bool     `json:"tracked,omitempty"` // Set by FillMonitor when OrderHash is a tracked order
+}
+
+// FillFilter selects the fill events GetFillEvents returns
+type FillFilter struct {
+	FromBlock uint64
+	ToBlock   uint64 // 0 means the latest block
+	Maker     string // Only events for orders made by this address; the funder for proxy and Safe wallets
+}
+
+// rpcLog is a log entry returned by eth_getLogs
+type rpcLog struct {
+	Address     string         `json:"address"`
+	Topics      []common.Hash  `json:"topics"`
+	Data        hexutil.Bytes  `json:"data"`
+	BlockNumber hexutil.Uint64 `json:"blockNumber"`
+	TxHash      string         `json:"transactionHash"`
+	LogIndex    hexutil.Uint64 `json:"logIndex"`
+	Removed     bool           `json:"removed"`
+}
+
+// BlockNumber returns the latest block number
+func (c *Client) BlockNumber(ctx context.Context) (uint64, error) {
+	var number hexutil.Uint64
+	if err := c.call(ctx, &number, "eth_blockNumber"); err != nil {
+		return 0, fmt.Errorf("failed to get block number: %w", err)
+	}
+	return uint64(number), nil
+}
+
+// GetFillEvents returns the fill events of both exchange contracts (regular and
+// neg risk) in a block range, oldest first. Ranges longer than MaxLogRange are
+// split into several calls.
+func (c *Client) GetFillEvents(ctx context.Context, filter FillFilter) ([]FillEvent, error) {
+	start := time.Now()
+
+	exchanges := c.exchangeAddresses()
+	if len(exchanges) == 0 {
+		c.recordMetric("fill_events", start, false, "unsupported chain")
+		return nil, fmt.Errorf("%w: %d", client.ErrUnsupportedChainID, c.chainID.Int64())
+	}
+	if filter.Maker != "" && !common.IsHexAddress(filter.Maker) {
+		c.recordMetric("fill_events", start, false, "invalid maker")
+		return nil, fmt.Errorf("invalid maker address: %s", filter.Maker)
+	}
+	if filter.ToBlock == 0 {
+		latest, err := c.BlockNumber(ctx)
+		if err != nil {
+			c.recordMetric("fill_events", start, false, err.Error())
+			return nil, err
+		}
+		filter.ToBlock = latest
+	}
+
+	// Both events index the order's maker as their second topic
+	topics := []interface{}{[]common.Hash{orderFilledTopic, ordersMatchedTopic}, nil}
+	if filter.Maker != "" {
+		topics = append(topics, common.BytesToHash(common.HexToAddress(filter.Maker).Bytes()))
+	}
+
+	events := make([]FillEvent, 0)
+	for from := filter.FromBlock; from <= filter.ToBlock; from += MaxLogRange {
+		to := from + MaxLogRange - 1
+		if to > filter.ToBlock {
+			to = filter.ToBlock
+		}
+		query := map[string]interface{}{
+			"address":   exchanges,
+			"fromBlock": hexutil.EncodeUint64(from),
+			"toBlock":   hexutil.EncodeUint64(to),
+			"topics":    topics,
+		}
+
+		var logs []rpcLog
+		if err := c.call(ctx, &logs, "eth_getLogs", query); err != nil {
+			c.recordMetric("fill_events", start, false, err.Error())
+			return nil, fmt.Errorf("failed to get fill events: %w", err)
+		}
+		for _, log := range logs {
+			if event, ok := decodeFillEvent(log); ok {
+				events = append(events, event)
+			}
+		}
+	}
+
+	c.recordMetric("fill_events", start, true, "")
+	return events, nil
+}
+
+// exchangeAddresses returns the exchange contracts on the client's chain
+func (c *Client) exchangeAddresses() []string {
+	addresses := make([]string, 0, 2)
+	for _, negRisk := range []bool{false, true} {
+		if config, exists := client.GetContractConfig(c.chainID.Int64(), negRisk); exists && config.Exchange != "" {
+			addresses = append(addresses, config.Exchange)
+		}
+	}
+	return addresses
+}
+
+// decodeFillEvent decodes an OrderFilled or OrdersMatched log
+func decodeFillEvent(log rpcLog) (FillEvent, bool) {
+	if len(log.Topics) < 3 {
+		return FillEvent{}, false
+	}
+	event := FillEvent{
+		Exchange:    strings.ToLower(log.Address),
+		OrderHash:   log.Topics[1].Hex(),
+		Maker:       common.BytesToAddress(log.Topics[2].Bytes()).Hex(),
+		TxHash:      log.TxHash,
+		BlockNumber: uint64(log.BlockNumber),
+		LogIndex:    uint64(log.LogIndex),
+		Removed:     log.Removed,
+	}
+
+	words := 4
+	switch log.Topics[0] {
+	case orderFilledTopic:
+		if len(log.Topics) < 4 {
+			return FillEvent{}, false
+		}
+		event.Event = EventOrderFilled
+		event.Taker = common.BytesToAddress(log.Topics[3].Bytes()).Hex()
+		words = 5
+	case ordersMatchedTopic:
+		event.Event = EventOrdersMatched
+	default:
+		return FillEvent{}, false
+	}
+	if len(log.Data) < words*32 {
+		return FillEvent{}, false
+	}
+
+	word := func(i int) *big.Int {
+		return new(big.Int).SetBytes(log.Data[i*32 : (i+1)*32])
+	}
+	event.MakerAssetID = word(0)
+	event.TakerAssetID = word(1)
+	event.MakerAmount = word(2)
+	event.TakerAmount = word(3)
+	if words == 5 {
+		event.Fee = word(4)
+	}
+	return event, true
+}
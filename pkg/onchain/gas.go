@@ -0,0 +1,114 @@
+package onchain
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/big"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+)
+
+// weiPerPOL converts wei to POL
+var weiPerPOL = new(big.Float).SetFloat64(1e18)
+
+// ErrInsufficientGas is returned when the signer cannot pay for a transaction
+var ErrInsufficientGas = errors.New("insufficient gas")
+
+// GasError reports a POL balance too small for the estimated cost of a transaction
+type GasError struct {
+	Address  string   // The address that pays for gas: the signer, not a proxy or Safe funder
+	Balance  *big.Int // In wei
+	Required *big.Int // Estimated gas limit times gas price, in wei
+}
+
+func (e *GasError) Error() string {
+	return fmt.Sprintf("%v: %s holds %s POL, the transaction needs about %s POL",
+		ErrInsufficientGas, e.Address, FormatPOL(e.Balance), FormatPOL(e.Required))
+}
+
+func (e *GasError) Unwrap() error {
+	return ErrInsufficientGas
+}
+
+// GasEstimate is the expected cost of a transaction
+type GasEstimate struct {
+	GasLimit uint64   // Estimated gas plus GasMargin
+	GasPrice *big.Int // In wei
+	Cost     *big.Int // GasLimit times GasPrice, in wei
+}
+
+// FormatPOL formats an amount in wei as POL
+func FormatPOL(wei *big.Int) string {
+	if wei == nil {
+		return "0"
+	}
+	return new(big.Float).Quo(new(big.Float).SetInt(wei), weiPerPOL).Text('f', 6)
+}
+
+// GasBalance returns the signer's POL balance in wei
+func (c *Client) GasBalance(ctx context.Context) (*big.Int, error) {
+	var balance hexutil.Big
+	if err := c.call(ctx, &balance, "eth_getBalance", c.Address(), "latest"); err != nil {
+		return nil, fmt.Errorf("failed to get POL balance: %w", err)
+	}
+	return balance.ToInt(), nil
+}
+
+// EstimateGas returns the expected cost of sending a call to a contract
+func (c *Client) EstimateGas(ctx context.Context, to string, data []byte) (*GasEstimate, error) {
+	if !common.IsHexAddress(to) {
+		return nil, fmt.Errorf("invalid contract address: %s", to)
+	}
+	call := map[string]string{"from": c.Address(), "to": to, "data": hexutil.Encode(data)}
+
+	var gasPrice, gas hexutil.Big
+	if err := c.call(ctx, &gasPrice, "eth_gasPrice"); err != nil {
+		return nil, fmt.Errorf("failed to get gas price: %w", err)
+	}
+	if err := c.call(ctx, &gas, "eth_estimateGas", call); err != nil {
+		return nil, fmt.Errorf("failed to estimate gas: %w", err)
+	}
+	gasLimit := gas.ToInt().Uint64() * (100 + GasMargin) / 100
+
+	return &GasEstimate{
+		GasLimit: gasLimit,
+		GasPrice: gasPrice.ToInt(),
+		Cost:     new(big.Int).Mul(new(big.Int).SetUint64(gasLimit), gasPrice.ToInt()),
+	}, nil
+}
+
+// CheckGas estimates the cost of sending a call to a contract and returns a
+// *GasError when the signer's POL balance does not cover it. SendTransaction
+// runs this check itself; call it directly to fail before preparing a batch of
+// transactions.
+func (c *Client) CheckGas(ctx context.Context, to string, data []byte) (*GasEstimate, error) {
+	start := time.Now()
+
+	estimate, err := c.EstimateGas(ctx, to, data)
+	if err != nil {
+		c.recordMetric("gas_check", start, false, err.Error())
+		return nil, err
+	}
+	if err := c.checkBalance(ctx, estimate.Cost); err != nil {
+		c.recordMetric("gas_check", start, false, err.Error())
+		return estimate, err
+	}
+
+	c.recordMetric("gas_check", start, true, "")
+	return estimate, nil
+}
+
+// checkBalance returns a *GasError when the signer holds less than required wei
+func (c *Client) checkBalance(ctx context.Context, required *big.Int) error {
+	balance, err := c.GasBalance(ctx)
+	if err != nil {
+		return err
+	}
+	if balance.Cmp(required) < 0 {
+		return &GasError{Address: c.Address(), Balance: balance, Required: required}
+	}
+	return nil
+}
@@ -0,0 +1,55 @@
+package onchain
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestSendTransactionChecksGasBalance(t *testing.T) {
+	sent := false
+	node := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var req struct {
+			ID     int64  `json:"id"`
+			Method string `json:"method"`
+		}
+		json.NewDecoder(r.Body).Decode(&req)
+
+		var result interface{}
+		switch req.Method {
+		case "eth_getTransactionCount":
+			result = "0x0"
+		case "eth_gasPrice":
+			result = "0x6fc23ac00" // 30 gwei
+		case "eth_estimateGas":
+			result = "0x186a0" // 100000, 120000 with the margin
+		case "eth_getBalance":
+			result = "0x38d7ea4c68000" // 0.001 POL
+		case "eth_sendRawTransaction":
+			sent = true
+			result = "0x"
+		}
+		json.NewEncoder(w).Encode(map[string]interface{}{"jsonrpc": "2.0", "id": req.ID, "result": result})
+	}))
+	defer node.Close()
+	c := newTestClient(t, node.URL)
+
+	_, err := c.SendTransaction(context.Background(), testBridgedUSDC, []byte{0x01})
+	if !errors.Is(err, ErrInsufficientGas) {
+		t.Fatalf("Expected ErrInsufficientGas, got %v", err)
+	}
+	if sent {
+		t.Error("Expected no transaction to be sent")
+	}
+
+	var gasErr *GasError
+	if !errors.As(err, &gasErr) {
+		t.Fatalf("Expected *GasError, got %T", err)
+	}
+	if FormatPOL(gasErr.Required) != "0.003600" {
+		t.Errorf("Expected 0.0036 POL required, got %s", FormatPOL(gasErr.Required))
+	}
+}
@@ -0,0 +1,295 @@
+// Package onchain works with the Polygon contracts trading relies on: collateral
+// and CTF approvals, splits and merges, balances, and fill and settlement monitoring.
+//
+// Gasless approval is only partly implemented. SignPermit and ApproveWithPermit
+// cover EIP-2612 permits, which native USDC supports. USDC.e, the collateral the
+// exchange settles in, has no permit function; approving it without gas needs
+// its meta-transaction function or Permit2, and neither is implemented. A fresh
+// wallet still needs gas to approve USDC.e, e.g. with EnsureTradingReady.
+package onchain
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"math/big"
+	"net/http"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/rlp"
+
+	"github.com/MaDal776/polymarket-go-client/pkg/client"
+	"github.com/MaDal776/polymarket-go-client/pkg/signer"
+	"github.com/MaDal776/polymarket-go-client/pkg/types"
+	"github.com/MaDal776/polymarket-go-client/pkg/version"
+)
+
+// GasMargin is added to the estimated gas limit of every transaction, in percent
+const GasMargin = 20
+
+// Client sends transactions to the Polymarket contracts through a JSON-RPC node.
+// Transactions are sent from the signer's address, so it only acts on positions
+// held by an EOA wallet (signature type 0), not by a proxy or Safe wallet.
+type Client struct {
+	rpcURL     string
+	userAgent  string
+	httpClient *http.Client
+	signer     *signer.Signer
+	chainID    *big.Int
+	collateral types.CollateralToken
+	negRisk    func(tokenID string) (bool, error)
+	nextID     atomic.Int64
+	metrics    []types.PerformanceMetrics
+	metricsMu  sync.Mutex
+}
+
+// NewClient creates a client for the node at rpcURL
+func NewClient(rpcURL string, s *signer.Signer) (*Client, error) {
+	if rpcURL == "" {
+		return nil, fmt.Errorf("RPC URL is required")
+	}
+	if s == nil {
+		return nil, client.ErrNoSigner
+	}
+
+	return &Client{
+		rpcURL:     rpcURL,
+		userAgent:  version.UserAgent(""),
+		httpClient: &http.Client{Timeout: 30 * time.Second},
+		signer:     s,
+		chainID:    big.NewInt(s.ChainID()),
+		metrics:    make([]types.PerformanceMetrics, 0),
+	}, nil
+}
+
+// SetUserAgent names the application in the User-Agent sent to the node,
+// ahead of the library version
+func (c *Client) SetUserAgent(app string) {
+	c.userAgent = version.UserAgent(app)
+}
+
+// Address returns the address transactions are sent from
+func (c *Client) Address() string {
+	return c.signer.AddressHex()
+}
+
+// Receipt is the outcome of a mined transaction
+type Receipt struct {
+	TxHash      string `json:"tx_hash"`
+	BlockNumber uint64 `json:"block_number"`
+	GasUsed     uint64 `json:"gas_used"`
+	Success     bool   `json:"success"`
+}
+
+// SendTransaction signs and sends a call to a contract, returning the transaction hash.
+// It returns a *GasError without sending when the signer cannot pay for gas.
+func (c *Client) SendTransaction(ctx context.Context, to string, data []byte) (string, error) {
+	start := time.Now()
+
+	if !common.IsHexAddress(to) {
+		c.recordMetric("transaction_send", start, false, "invalid address")
+		return "", fmt.Errorf("invalid contract address: %s", to)
+	}
+	from := c.signer.AddressHex()
+
+	var nonce hexutil.Big
+	if err := c.call(ctx, &nonce, "eth_getTransactionCount", from, "pending"); err != nil {
+		c.recordMetric("transaction_send", start, false, err.Error())
+		return "", fmt.Errorf("failed to get nonce: %w", err)
+	}
+	estimate, err := c.EstimateGas(ctx, to, data)
+	if err != nil {
+		c.recordMetric("transaction_send", start, false, err.Error())
+		return "", err
+	}
+	if err := c.checkBalance(ctx, estimate.Cost); err != nil {
+		c.recordMetric("transaction_send", start, false, err.Error())
+		return "", err
+	}
+
+	raw, err := c.signTransaction(nonce.ToInt().Uint64(), estimate.GasPrice, estimate.GasLimit, common.HexToAddress(to), data)
+	if err != nil {
+		c.recordMetric("transaction_send", start, false, err.Error())
+		return "", err
+	}
+
+	var txHash string
+	if err := c.call(ctx, &txHash, "eth_sendRawTransaction", hexutil.Encode(raw)); err != nil {
+		c.recordMetric("transaction_send", start, false, err.Error())
+		return "", fmt.Errorf("failed to send transaction: %w", err)
+	}
+
+	c.recordMetric("transaction_send", start, true, "")
+	return txHash, nil
+}
+
+// WaitForReceipt polls for the receipt of a transaction until it is mined or ctx ends
+func (c *Client) WaitForReceipt(ctx context.Context, txHash string) (*Receipt, error) {
+	start := time.Now()
+	ticker := time.NewTicker(2 * time.Second)
+	defer ticker.Stop()
+
+	for {
+		receipt, err := c.TransactionReceipt(ctx, txHash)
+		if err != nil {
+			c.recordMetric("receipt_wait", start, false, err.Error())
+			return nil, err
+		}
+		if receipt != nil {
+			c.recordMetric("receipt_wait", start, true, "")
+			return receipt, nil
+		}
+
+		select {
+		case <-ctx.Done():
+			c.recordMetric("receipt_wait", start, false, ctx.Err().Error())
+			return nil, ctx.Err()
+		case <-ticker.C:
+		}
+	}
+}
+
+// TransactionReceipt returns the receipt of a transaction, or nil when it is not mined
+func (c *Client) TransactionReceipt(ctx context.Context, txHash string) (*Receipt, error) {
+	var receipt *struct {
+		TxHash      string         `json:"transactionHash"`
+		BlockNumber hexutil.Uint64 `json:"blockNumber"`
+		GasUsed     hexutil.Uint64 `json:"gasUsed"`
+		Status      hexutil.Uint64 `json:"status"`
+	}
+	if err := c.call(ctx, &receipt, "eth_getTransactionReceipt", txHash); err != nil {
+		return nil, fmt.Errorf("failed to get receipt: %w", err)
+	}
+	if receipt == nil {
+		return nil, nil
+	}
+	return &Receipt{
+		TxHash:      receipt.TxHash,
+		BlockNumber: uint64(receipt.BlockNumber),
+		GasUsed:     uint64(receipt.GasUsed),
+		Success:     receipt.Status == 1,
+	}, nil
+}
+
+// signTransaction builds and signs a legacy EIP-155 transaction
+func (c *Client) signTransaction(nonce uint64, gasPrice *big.Int, gasLimit uint64, to common.Address, data []byte) ([]byte, error) {
+	unsigned, err := rlp.EncodeToBytes([]interface{}{nonce, gasPrice, gasLimit, to, big.NewInt(0), data, c.chainID, uint(0), uint(0)})
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode transaction: %w", err)
+	}
+
+	signature, err := c.signer.Sign(crypto.Keccak256(unsigned))
+	if err != nil {
+		return nil, fmt.Errorf("failed to sign transaction: %w", err)
+	}
+
+	// Signer.Sign returns v as 27/28; EIP-155 folds the chain ID into it
+	v := new(big.Int).Mul(c.chainID, big.NewInt(2))
+	v.Add(v, big.NewInt(int64(signature[64]-27)+35))
+	r := new(big.Int).SetBytes(signature[:32])
+	s := new(big.Int).SetBytes(signature[32:64])
+
+	raw, err := rlp.EncodeToBytes([]interface{}{nonce, gasPrice, gasLimit, to, big.NewInt(0), data, v, r, s})
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode transaction: %w", err)
+	}
+	return raw, nil
+}
+
+// rpcError is the error object of a JSON-RPC response
+type rpcError struct {
+	Code    int    `json:"code"`
+	Message string `json:"message"`
+}
+
+func (e *rpcError) Error() string {
+	return fmt.Sprintf("RPC error %d: %s", e.Code, e.Message)
+}
+
+// call makes a JSON-RPC call and decodes its result into result
+func (c *Client) call(ctx context.Context, result interface{}, method string, params ...interface{}) error {
+	if params == nil {
+		params = []interface{}{}
+	}
+	body, err := json.Marshal(map[string]interface{}{
+		"jsonrpc": "2.0",
+		"id":      c.nextID.Add(1),
+		"method":  method,
+		"params":  params,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to encode request: %w", err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, "POST", c.rpcURL, bytes.NewReader(body))
+	if err != nil {
+		return fmt.Errorf("failed to create request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("User-Agent", c.userAgent)
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("failed to make request: %w", err)
+	}
+	defer resp.Body.Close()
+
+	respBody, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return fmt.Errorf("failed to read response: %w", err)
+	}
+	if resp.StatusCode >= 400 {
+		return fmt.Errorf("HTTP %d: %s", resp.StatusCode, strings.TrimSpace(string(respBody)))
+	}
+
+	var envelope struct {
+		Result json.RawMessage `json:"result"`
+		Error  *rpcError       `json:"error"`
+	}
+	if err := json.Unmarshal(respBody, &envelope); err != nil {
+		return fmt.Errorf("failed to parse response: %w", err)
+	}
+	if envelope.Error != nil {
+		return fmt.Errorf("%s: %w", method, envelope.Error)
+	}
+	if err := json.Unmarshal(envelope.Result, result); err != nil {
+		return fmt.Errorf("failed to parse %s result: %w", method, err)
+	}
+	return nil
+}
+
+// GetMetrics returns performance metrics
+func (c *Client) GetMetrics() []types.PerformanceMetrics {
+	c.metricsMu.Lock()
+	defer c.metricsMu.Unlock()
+	return append([]types.PerformanceMetrics(nil), c.metrics...)
+}
+
+// ClearMetrics clears performance metrics
+func (c *Client) ClearMetrics() {
+	c.metricsMu.Lock()
+	defer c.metricsMu.Unlock()
+	c.metrics = make([]types.PerformanceMetrics, 0)
+}
+
+// recordMetric records a performance metric
+func (c *Client) recordMetric(operation string, startTime time.Time, success bool, errorMsg string) {
+	metric := types.PerformanceMetrics{
+		Operation: operation,
+		StartTime: startTime,
+		Duration:  time.Since(startTime),
+		Success:   success,
+		Error:     errorMsg,
+	}
+	c.metricsMu.Lock()
+	c.metrics = append(c.metrics, metric)
+	c.metricsMu.Unlock()
+}
@@ -0,0 +1,154 @@
+package onchain
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/rlp"
+
+	"github.com/MaDal776/polymarket-go-client/pkg/signer"
+)
+
+const testPrivateKey = "0x4c0883a69102937d6231471b5dbb6204fe5129617082792ae468d01a3f362318"
+
+const testConditionID = "0x5f65177b394277fd294cd75650044e32ba009a95022d88a0c1d565897d72f8f1"
+
+// rpcTx is a decoded legacy transaction
+type rpcTx struct {
+	Nonce    uint64
+	GasPrice *big.Int
+	Gas      uint64
+	To       common.Address
+	Value    *big.Int
+	Data     []byte
+	V, R, S  *big.Int
+}
+
+// fakeNode answers the calls made by SendTransaction and captures the raw transaction
+func fakeNode(t *testing.T, raw *[]byte) *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var req struct {
+			ID     int64             `json:"id"`
+			Method string            `json:"method"`
+			Params []json.RawMessage `json:"params"`
+		}
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			t.Errorf("Invalid request: %v", err)
+			return
+		}
+
+		var result interface{}
+		switch req.Method {
+		case "eth_getTransactionCount":
+			result = "0x7"
+		case "eth_gasPrice":
+			result = "0x6fc23ac00"
+		case "eth_estimateGas":
+			result = "0x186a0"
+		case "eth_getBalance":
+			result = "0xde0b6b3a7640000"
+		case "eth_sendRawTransaction":
+			var encoded string
+			json.Unmarshal(req.Params[0], &encoded)
+			*raw = hexutil.MustDecode(encoded)
+			result = "0x" + string(bytes.Repeat([]byte("ab"), 32))
+		default:
+			t.Errorf("Unexpected method %s", req.Method)
+		}
+		json.NewEncoder(w).Encode(map[string]interface{}{"jsonrpc": "2.0", "id": req.ID, "result": result})
+	}))
+}
+
+func newTestClient(t *testing.T, url string) *Client {
+	s, err := signer.NewSigner(testPrivateKey, 137)
+	if err != nil {
+		t.Fatalf("Failed to create signer: %v", err)
+	}
+	c, err := NewClient(url, s)
+	if err != nil {
+		t.Fatalf("Failed to create client: %v", err)
+	}
+	return c
+}
+
+func TestMergePositionsSignsTransaction(t *testing.T) {
+	var raw []byte
+	node := fakeNode(t, &raw)
+	defer node.Close()
+	c := newTestClient(t, node.URL)
+
+	if _, err := c.MergePositions(context.Background(), testConditionID, 12.34, false); err != nil {
+		t.Fatalf("MergePositions failed: %v", err)
+	}
+
+	var tx rpcTx
+	if err := rlp.DecodeBytes(raw, &tx); err != nil {
+		t.Fatalf("Failed to decode transaction: %v", err)
+	}
+	if tx.Nonce != 7 || tx.Gas != 120000 {
+		t.Errorf("Nonce %d, gas %d; want 7, 120000", tx.Nonce, tx.Gas)
+	}
+	if tx.To != common.HexToAddress("0x4D97DCd97eC945f40cF65F87097ACe5EA0476045") {
+		t.Errorf("Sent to %s, want the conditional tokens contract", tx.To.Hex())
+	}
+
+	condition, _ := parseBytes32(testConditionID)
+	want := encodeCTFMerge(common.HexToAddress("0x2791Bca1f2de4661ED88A30C99A7a9449Aa84174"), condition, big.NewInt(12340000))
+	if !bytes.Equal(tx.Data, want) {
+		t.Errorf("Calldata %x, want %x", tx.Data, want)
+	}
+
+	// Recover the sender from the EIP-155 signature
+	unsigned, _ := rlp.EncodeToBytes([]interface{}{tx.Nonce, tx.GasPrice, tx.Gas, tx.To, tx.Value, tx.Data, big.NewInt(137), uint(0), uint(0)})
+	recovery := new(big.Int).Sub(tx.V, big.NewInt(137*2+35))
+	signature := append(common.LeftPadBytes(tx.R.Bytes(), 32), common.LeftPadBytes(tx.S.Bytes(), 32)...)
+	signature = append(signature, byte(recovery.Uint64()))
+	pub, err := crypto.SigToPub(crypto.Keccak256(unsigned), signature)
+	if err != nil {
+		t.Fatalf("Failed to recover signer: %v", err)
+	}
+	if got := crypto.PubkeyToAddress(*pub).Hex(); got != c.Address() {
+		t.Errorf("Recovered %s, want %s", got, c.Address())
+	}
+}
+
+func TestMergePositionsNegRiskUsesAdapter(t *testing.T) {
+	var raw []byte
+	node := fakeNode(t, &raw)
+	defer node.Close()
+	c := newTestClient(t, node.URL)
+
+	if _, err := c.MergePositions(context.Background(), testConditionID, 5, true); err != nil {
+		t.Fatalf("MergePositions failed: %v", err)
+	}
+
+	var tx rpcTx
+	if err := rlp.DecodeBytes(raw, &tx); err != nil {
+		t.Fatalf("Failed to decode transaction: %v", err)
+	}
+	if tx.To != common.HexToAddress("0xd91E80cF2E7be2e162c6513ceD06f1dD0dA35296") {
+		t.Errorf("Sent to %s, want the neg risk adapter", tx.To.Hex())
+	}
+	if len(tx.Data) != 4+64 || !bytes.Equal(tx.Data[:4], adapterMergeSelector) {
+		t.Errorf("Unexpected calldata %x", tx.Data)
+	}
+}
+
+func TestMergePositionsRejectsBadInput(t *testing.T) {
+	c := newTestClient(t, "http://127.0.0.1:0")
+
+	if _, err := c.MergePositions(context.Background(), "0x1234", 1, false); err == nil {
+		t.Error("Expected invalid condition ID to fail")
+	}
+	if _, err := c.MergePositions(context.Background(), testConditionID, 0, false); err == nil {
+		t.Error("Expected zero amount to fail")
+	}
+}
@@ -0,0 +1,180 @@
+package onchain
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/big"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/common/math"
+	"github.com/ethereum/go-ethereum/crypto"
+
+	"github.com/MaDal776/polymarket-go-client/pkg/amount"
+	"github.com/MaDal776/polymarket-go-client/pkg/types"
+)
+
+// MaxAllowance passed to SignPermit approves the spender without limit
+const MaxAllowance = amount.USDC(1<<63 - 1)
+
+// EIP-2612 selectors and type hash
+var (
+	permitSelector          = crypto.Keccak256([]byte("permit(address,address,uint256,uint256,uint8,bytes32,bytes32)"))[:4]
+	noncesSelector          = crypto.Keccak256([]byte("nonces(address)"))[:4]
+	domainSeparatorSelector = crypto.Keccak256([]byte("DOMAIN_SEPARATOR()"))[:4]
+	permitTypeHash          = crypto.Keccak256([]byte("Permit(address owner,address spender,uint256 value,uint256 nonce,uint256 deadline)"))
+)
+
+// ErrPermitUnsupported is returned by SignPermit when the token does not
+// implement EIP-2612: it is USDC.e, or it has no nonces or DOMAIN_SEPARATOR
+// function. Native USDC does implement it; the bridged USDC.e the exchange
+// settles in does not, so its approval needs an approve transaction paid by
+// the owner, as EnsureTradingReady sends.
+var ErrPermitUnsupported = errors.New("token does not support EIP-2612 permits")
+
+// Permit is a signed EIP-2612 approval. Anyone can submit it to the token, so
+// the owner needs no gas to grant the allowance.
+type Permit struct {
+	Token    string   `json:"token"`
+	Owner    string   `json:"owner"`
+	Spender  string   `json:"spender"`
+	Value    *big.Int `json:"value"`
+	Nonce    *big.Int `json:"nonce"`
+	Deadline int64    `json:"deadline"` // Unix seconds
+	V        uint8    `json:"v"`
+	R        [32]byte `json:"r"`
+	S        [32]byte `json:"s"`
+}
+
+// Calldata encodes the token's permit call, for a relayer or a bundled call
+func (p *Permit) Calldata() []byte {
+	data := make([]byte, 0, 4+32*7)
+	data = append(data, permitSelector...)
+	data = append(data, common.LeftPadBytes(common.HexToAddress(p.Owner).Bytes(), 32)...)
+	data = append(data, common.LeftPadBytes(common.HexToAddress(p.Spender).Bytes(), 32)...)
+	data = append(data, word(p.Value)...)
+	data = append(data, word(big.NewInt(p.Deadline))...)
+	data = append(data, word(big.NewInt(int64(p.V)))...)
+	data = append(data, p.R[:]...)
+	data = append(data, p.S[:]...)
+	return data
+}
+
+// Relayer sends a call on the owner's behalf and pays its gas. A Client whose
+// signer holds MATIC is one; so is a wrapper around a relayer service.
+type Relayer interface {
+	SendTransaction(ctx context.Context, to string, data []byte) (string, error)
+}
+
+// SignPermit signs an EIP-2612 permit letting spender, such as an exchange
+// contract from client.GetContractConfig, move value of a collateral token from
+// the signer's wallet until deadline. The token's nonce and domain separator are
+// read from the chain; no transaction is sent.
+//
+// Only EIP-2612 permit is supported, not Permit2, so this works for native USDC
+// but not for USDC.e, the collateral the exchange actually settles in. For
+// USDC.e it returns ErrPermitUnsupported without calling the chain.
+func (c *Client) SignPermit(ctx context.Context, token types.CollateralToken, spender string, value amount.USDC, deadline time.Time) (*Permit, error) {
+	start := time.Now()
+
+	if token == types.CollateralBridged || token == "" {
+		c.recordMetric("permit_signing", start, false, "permit unsupported")
+		return nil, fmt.Errorf("%w: %s has no permit function; approve it with a transaction, e.g. EnsureTradingReady", ErrPermitUnsupported, types.CollateralBridged)
+	}
+	address, err := c.collateralAddress(token)
+	if err != nil {
+		c.recordMetric("permit_signing", start, false, err.Error())
+		return nil, err
+	}
+	if !common.IsHexAddress(spender) {
+		c.recordMetric("permit_signing", start, false, "invalid spender")
+		return nil, fmt.Errorf("invalid spender address: %s", spender)
+	}
+	if value <= 0 {
+		c.recordMetric("permit_signing", start, false, "invalid value")
+		return nil, fmt.Errorf("invalid permit value: %s", value)
+	}
+	if !deadline.After(time.Now()) {
+		c.recordMetric("permit_signing", start, false, "deadline passed")
+		return nil, fmt.Errorf("permit deadline %s has passed", deadline.Format(time.RFC3339))
+	}
+
+	owner := common.HexToAddress(c.signer.AddressHex())
+	nonce, err := c.permitCall(ctx, address, append(append([]byte{}, noncesSelector...), common.LeftPadBytes(owner.Bytes(), 32)...))
+	if err != nil {
+		c.recordMetric("permit_signing", start, false, err.Error())
+		return nil, fmt.Errorf("failed to get %s permit nonce: %w", token, err)
+	}
+	domain, err := c.permitCall(ctx, address, domainSeparatorSelector)
+	if err != nil {
+		c.recordMetric("permit_signing", start, false, err.Error())
+		return nil, fmt.Errorf("failed to get %s domain separator: %w", token, err)
+	}
+
+	permit := &Permit{
+		Token:    address,
+		Owner:    owner.Hex(),
+		Spender:  common.HexToAddress(spender).Hex(),
+		Value:    value.ToBigInt(),
+		Nonce:    nonce,
+		Deadline: deadline.Unix(),
+	}
+	if value == MaxAllowance {
+		permit.Value = math.MaxBig256
+	}
+
+	structHash := crypto.Keccak256(
+		permitTypeHash,
+		common.LeftPadBytes(owner.Bytes(), 32),
+		common.LeftPadBytes(common.HexToAddress(permit.Spender).Bytes(), 32),
+		word(permit.Value),
+		word(permit.Nonce),
+		word(big.NewInt(permit.Deadline)),
+	)
+	signature, err := c.signer.SignEIP712(word(domain), structHash)
+	if err != nil {
+		c.recordMetric("permit_signing", start, false, err.Error())
+		return nil, fmt.Errorf("failed to sign permit: %w", err)
+	}
+	copy(permit.R[:], signature[:32])
+	copy(permit.S[:], signature[32:64])
+	permit.V = signature[64]
+
+	c.recordMetric("permit_signing", start, true, "")
+	return permit, nil
+}
+
+// ApproveWithPermit signs a permit for spender and has relayer submit it,
+// returning the transaction hash. Use WaitForReceipt to confirm it.
+func (c *Client) ApproveWithPermit(ctx context.Context, token types.CollateralToken, spender string, value amount.USDC, deadline time.Time, relayer Relayer) (string, error) {
+	permit, err := c.SignPermit(ctx, token, spender, value, deadline)
+	if err != nil {
+		return "", err
+	}
+	txHash, err := relayer.SendTransaction(ctx, permit.Token, permit.Calldata())
+	if err != nil {
+		return "", fmt.Errorf("failed to relay permit: %w", err)
+	}
+	return txHash, nil
+}
+
+// permitCall makes a read-only call to one of a token's EIP-2612 functions,
+// reporting a revert or an empty result as ErrPermitUnsupported
+func (c *Client) permitCall(ctx context.Context, to string, data []byte) (*big.Int, error) {
+	var result hexutil.Bytes
+	call := map[string]string{"to": to, "data": hexutil.Encode(data)}
+	err := c.call(ctx, &result, "eth_call", call, "latest")
+	var rpcErr *rpcError
+	if errors.As(err, &rpcErr) {
+		return nil, fmt.Errorf("%w: %v", ErrPermitUnsupported, err)
+	}
+	if err != nil {
+		return nil, err
+	}
+	if len(result) < 32 {
+		return nil, ErrPermitUnsupported
+	}
+	return new(big.Int).SetBytes(result[:32]), nil
+}
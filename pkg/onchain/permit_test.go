@@ -0,0 +1,120 @@
+package onchain
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/crypto"
+
+	"github.com/MaDal776/polymarket-go-client/pkg/types"
+	"github.com/MaDal776/polymarket-go-client/pkg/utils"
+)
+
+// permitNode answers nonces and DOMAIN_SEPARATOR calls on native USDC and
+// reverts them on every other token, or on every token without a domain
+func permitNode(t *testing.T, domain []byte) *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var req struct {
+			ID     int64             `json:"id"`
+			Method string            `json:"method"`
+			Params []json.RawMessage `json:"params"`
+		}
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil || req.Method != "eth_call" {
+			t.Errorf("Unexpected request %s: %v", req.Method, err)
+			return
+		}
+		var call struct {
+			To   string `json:"to"`
+			Data string `json:"data"`
+		}
+		json.Unmarshal(req.Params[0], &call)
+
+		response := map[string]interface{}{"jsonrpc": "2.0", "id": req.ID}
+		data := hexutil.MustDecode(call.Data)
+		switch {
+		case domain == nil || !strings.EqualFold(call.To, testNativeUSDC):
+			response["error"] = map[string]interface{}{"code": 3, "message": "execution reverted"}
+		case bytes.Equal(data[:4], noncesSelector):
+			response["result"] = hexutil.Encode(common.LeftPadBytes([]byte{5}, 32))
+		case bytes.Equal(data[:4], domainSeparatorSelector):
+			response["result"] = hexutil.Encode(domain)
+		default:
+			t.Errorf("Unexpected call %s", call.Data)
+		}
+		json.NewEncoder(w).Encode(response)
+	}))
+}
+
+// relayerFunc records the call it is asked to send
+type relayerFunc func(to string, data []byte)
+
+func (f relayerFunc) SendTransaction(ctx context.Context, to string, data []byte) (string, error) {
+	f(to, data)
+	return "0xfeed", nil
+}
+
+func TestApproveWithPermit(t *testing.T) {
+	domain := crypto.Keccak256([]byte("test domain"))
+	node := permitNode(t, domain)
+	defer node.Close()
+	c := newTestClient(t, node.URL)
+
+	spender := "0x4bFb41d5B3570DeFd03C39a9A4D8dE6Bd8B8982E"
+	deadline := time.Now().Add(time.Hour)
+	permit, err := c.SignPermit(context.Background(), types.CollateralNative, spender, MaxAllowance, deadline)
+	if err != nil {
+		t.Fatalf("SignPermit failed: %v", err)
+	}
+	if permit.Nonce.Int64() != 5 || permit.Deadline != deadline.Unix() || permit.Value.BitLen() != 256 {
+		t.Errorf("Unexpected permit: %+v", permit)
+	}
+
+	// The signature recovers to the owner over the token's domain
+	structHash := crypto.Keccak256(permitTypeHash,
+		common.LeftPadBytes(common.HexToAddress(permit.Owner).Bytes(), 32),
+		common.LeftPadBytes(common.HexToAddress(spender).Bytes(), 32),
+		word(permit.Value), word(big.NewInt(5)), word(big.NewInt(permit.Deadline)))
+	signature := append(append(permit.R[:], permit.S[:]...), permit.V-27)
+	pub, err := crypto.SigToPub(utils.CreateEIP712Hash(domain, structHash), signature)
+	if err != nil || crypto.PubkeyToAddress(*pub).Hex() != c.Address() {
+		t.Errorf("Permit signature does not recover to the owner: %v", err)
+	}
+
+	var relayedTo string
+	var relayed []byte
+	relayer := relayerFunc(func(to string, data []byte) { relayedTo, relayed = to, data })
+	txHash, err := c.ApproveWithPermit(context.Background(), types.CollateralNative, spender, MaxAllowance, deadline, relayer)
+	if err != nil || txHash != "0xfeed" {
+		t.Fatalf("ApproveWithPermit = %q, %v", txHash, err)
+	}
+	if !strings.EqualFold(relayedTo, testNativeUSDC) || !bytes.Equal(relayed[:4], permitSelector) || len(relayed) != 4+32*7 {
+		t.Errorf("Unexpected relayed call to %s: %x", relayedTo, relayed)
+	}
+}
+
+func TestSignPermitUnsupported(t *testing.T) {
+	node := permitNode(t, nil)
+	defer node.Close()
+	c := newTestClient(t, node.URL)
+
+	// USDC.e is refused without a call; a token whose calls revert is refused too
+	for _, token := range []types.CollateralToken{types.CollateralBridged, types.CollateralNative} {
+		_, err := c.SignPermit(context.Background(), token, testBridgedUSDC, 1000000, time.Now().Add(time.Hour))
+		if !errors.Is(err, ErrPermitUnsupported) {
+			t.Fatalf("Expected ErrPermitUnsupported for %s, got %v", token, err)
+		}
+	}
+	if _, err := c.SignPermit(context.Background(), types.CollateralNative, testBridgedUSDC, 1000000, time.Now().Add(-time.Minute)); err == nil {
+		t.Error("Expected a past deadline to fail")
+	}
+}
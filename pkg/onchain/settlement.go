@@ -0,0 +1,240 @@
+package onchain
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/MaDal776/polymarket-go-client/pkg/types"
+)
+
+// Settlement defaults
+const (
+	DefaultConfirmations = 32              // Blocks after which a Polygon transaction is treated as final
+	DefaultStuckAfter    = 2 * time.Minute // How long a trade may go unsettled before OnStuck
+)
+
+// SettlementState is how far a trade's settlement transaction has progressed
+type SettlementState string
+
+const (
+	SettlementPending SettlementState = "PENDING" // No transaction hash yet, or not in a block
+	SettlementMined   SettlementState = "MINED"   // In a block, fewer than Confirmations deep
+	SettlementFinal   SettlementState = "FINAL"   // Confirmations deep with a successful receipt
+	SettlementFailed  SettlementState = "FAILED"  // Reverted, or reported FAILED by the CLOB
+)
+
+// Settlement is the on-chain status of a matched trade
+type Settlement struct {
+	Trade         types.Trade     `json:"trade"`
+	State         SettlementState `json:"state"`
+	BlockNumber   uint64          `json:"block_number,omitempty"`
+	Confirmations uint64          `json:"confirmations"`
+	Since         time.Time       `json:"since"` // When the trade was added
+	Stuck         bool            `json:"stuck"` // Not final after StuckAfter
+}
+
+// SettlementConfig configures a SettlementTracker
+type SettlementConfig struct {
+	Confirmations uint64        // Default DefaultConfirmations
+	StuckAfter    time.Duration // Default DefaultStuckAfter
+	Interval      time.Duration // Polling interval used by Run; default 5s
+
+	OnFinal  func(settlement Settlement) // Book the fill here; the trade is then forgotten
+	OnFailed func(settlement Settlement) // The trade is then forgotten
+	OnStuck  func(settlement Settlement) // Called once per trade
+	OnError  func(err error)             // Called when a poll fails in Run
+}
+
+// SettlementTracker follows the settlement transactions of matched trades until
+// they are final. The CLOB reports a trade CONFIRMED once it sees the transaction
+// mined; the tracker checks the receipt itself and waits for Confirmations blocks
+// so accounting only books fills that can no longer be reorganized away.
+type SettlementTracker struct {
+	client *Client
+	config SettlementConfig
+
+	mu     sync.Mutex
+	trades map[string]*Settlement
+}
+
+// NewSettlementTracker creates a tracker reading receipts through c
+func NewSettlementTracker(c *Client, config SettlementConfig) (*SettlementTracker, error) {
+	if c == nil {
+		return nil, fmt.Errorf("onchain client is required")
+	}
+	if config.Confirmations == 0 {
+		config.Confirmations = DefaultConfirmations
+	}
+	if config.StuckAfter <= 0 {
+		config.StuckAfter = DefaultStuckAfter
+	}
+	if config.Interval <= 0 {
+		config.Interval = 5 * time.Second
+	}
+
+	return &SettlementTracker{
+		client: c,
+		config: config,
+		trades: make(map[string]*Settlement),
+	}, nil
+}
+
+// Add tracks trades, e.g. from GetTrades or TradesIter. Adding a trade already
+// tracked updates it, so a transaction hash or status reported later is picked up.
+func (t *SettlementTracker) Add(trades ...types.Trade) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	for _, trade := range trades {
+		if existing, tracked := t.trades[trade.ID]; tracked {
+			existing.Trade = trade
+			continue
+		}
+		t.trades[trade.ID] = &Settlement{Trade: trade, State: SettlementPending, Since: time.Now()}
+	}
+}
+
+// Get returns the settlement of a tracked trade
+func (t *SettlementTracker) Get(tradeID string) (Settlement, bool) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	settlement, tracked := t.trades[tradeID]
+	if !tracked {
+		return Settlement{}, false
+	}
+	return *settlement, true
+}
+
+// Unsettled returns the tracked trades that are not final yet, oldest first
+func (t *SettlementTracker) Unsettled() []Settlement {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	settlements := make([]Settlement, 0, len(t.trades))
+	for _, settlement := range t.trades {
+		settlements = append(settlements, *settlement)
+	}
+	sort.Slice(settlements, func(i, j int) bool {
+		return settlements[i].Since.Before(settlements[j].Since)
+	})
+	return settlements
+}
+
+// Poll checks the receipt of every tracked trade and delivers the ones that became
+// final, failed or stuck. It returns the number of trades that became final.
+func (t *SettlementTracker) Poll(ctx context.Context) (int, error) {
+	latest, err := t.client.BlockNumber(ctx)
+	if err != nil {
+		return 0, err
+	}
+
+	var final, failed, stuck []Settlement
+	var firstErr error
+	for _, settlement := range t.Unsettled() {
+		next, err := t.check(ctx, settlement, latest)
+		if err != nil {
+			if firstErr == nil {
+				firstErr = err
+			}
+			continue
+		}
+		if !next.Stuck && (next.State == SettlementPending || next.State == SettlementMined) && time.Since(next.Since) > t.config.StuckAfter {
+			next.Stuck = true
+			stuck = append(stuck, next)
+		}
+
+		t.mu.Lock()
+		current, tracked := t.trades[next.Trade.ID]
+		if tracked {
+			// Keep a trade update that arrived while the receipt was fetched
+			next.Trade = current.Trade
+			switch next.State {
+			case SettlementFinal:
+				delete(t.trades, next.Trade.ID)
+				final = append(final, next)
+			case SettlementFailed:
+				delete(t.trades, next.Trade.ID)
+				failed = append(failed, next)
+			default:
+				*current = next
+			}
+		}
+		t.mu.Unlock()
+	}
+
+	for _, settlement := range stuck {
+		if t.config.OnStuck != nil {
+			t.config.OnStuck(settlement)
+		}
+	}
+	for _, settlement := range failed {
+		if t.config.OnFailed != nil {
+			t.config.OnFailed(settlement)
+		}
+	}
+	for _, settlement := range final {
+		if t.config.OnFinal != nil {
+			t.config.OnFinal(settlement)
+		}
+	}
+	return len(final), firstErr
+}
+
+// Run calls Poll every Interval until ctx is cancelled
+func (t *SettlementTracker) Run(ctx context.Context) {
+	ticker := time.NewTicker(t.config.Interval)
+	defer ticker.Stop()
+
+	for {
+		if _, err := t.Poll(ctx); err != nil && t.config.OnError != nil {
+			t.config.OnError(err)
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// check works out a trade's settlement state at the latest block
+func (t *SettlementTracker) check(ctx context.Context, settlement Settlement, latest uint64) (Settlement, error) {
+	if strings.EqualFold(settlement.Trade.Status, "FAILED") {
+		settlement.State = SettlementFailed
+		return settlement, nil
+	}
+	if settlement.Trade.TransactionHash == "" {
+		return settlement, nil
+	}
+
+	receipt, err := t.client.TransactionReceipt(ctx, settlement.Trade.TransactionHash)
+	if err != nil {
+		return settlement, fmt.Errorf("trade %s: %w", settlement.Trade.ID, err)
+	}
+	if receipt == nil {
+		// Not mined yet, or dropped from the chain by a reorganization
+		settlement.State = SettlementPending
+		settlement.BlockNumber = 0
+		settlement.Confirmations = 0
+		return settlement, nil
+	}
+	if !receipt.Success {
+		settlement.State = SettlementFailed
+		settlement.BlockNumber = receipt.BlockNumber
+		return settlement, nil
+	}
+
+	settlement.BlockNumber = receipt.BlockNumber
+	settlement.Confirmations = 0
+	if latest >= receipt.BlockNumber {
+		settlement.Confirmations = latest - receipt.BlockNumber + 1
+	}
+	settlement.State = SettlementMined
+	if settlement.Confirmations >= t.config.Confirmations {
+		settlement.State = SettlementFinal
+	}
+	return settlement, nil
+}
@@ -0,0 +1,92 @@
+package onchain
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/MaDal776/polymarket-go-client/pkg/types"
+)
+
+// receiptNode serves block 100 and receipts from a fixed table
+func receiptNode(t *testing.T, receipts map[string]map[string]string) *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var req struct {
+			ID     int64             `json:"id"`
+			Method string            `json:"method"`
+			Params []json.RawMessage `json:"params"`
+		}
+		json.NewDecoder(r.Body).Decode(&req)
+
+		var result interface{}
+		switch req.Method {
+		case "eth_blockNumber":
+			result = "0x64"
+		case "eth_getTransactionReceipt":
+			var txHash string
+			json.Unmarshal(req.Params[0], &txHash)
+			if receipt, mined := receipts[txHash]; mined {
+				result = receipt
+			}
+		default:
+			t.Errorf("Unexpected method %s", req.Method)
+		}
+		json.NewEncoder(w).Encode(map[string]interface{}{"jsonrpc": "2.0", "id": req.ID, "result": result})
+	}))
+}
+
+func TestSettlementTrackerStates(t *testing.T) {
+	node := receiptNode(t, map[string]map[string]string{
+		"0x01": {"transactionHash": "0x01", "blockNumber": "0x3c", "status": "0x1"}, // block 60: 41 confirmations
+		"0x02": {"transactionHash": "0x02", "blockNumber": "0x5a", "status": "0x1"}, // block 90: 11 confirmations
+		"0x03": {"transactionHash": "0x03", "blockNumber": "0x5a", "status": "0x0"},
+	})
+	defer node.Close()
+	c := newTestClient(t, node.URL)
+
+	var final, failed, stuck []string
+	tracker, err := NewSettlementTracker(c, SettlementConfig{
+		StuckAfter: time.Nanosecond,
+		OnFinal:    func(s Settlement) { final = append(final, s.Trade.ID) },
+		OnFailed:   func(s Settlement) { failed = append(failed, s.Trade.ID) },
+		OnStuck:    func(s Settlement) { stuck = append(stuck, s.Trade.ID) },
+	})
+	if err != nil {
+		t.Fatalf("NewSettlementTracker failed: %v", err)
+	}
+	tracker.Add(
+		types.Trade{ID: "final", Status: "CONFIRMED", TransactionHash: "0x01"},
+		types.Trade{ID: "mined", Status: "MINED", TransactionHash: "0x02"},
+		types.Trade{ID: "reverted", Status: "MINED", TransactionHash: "0x03"},
+		types.Trade{ID: "pending", Status: "MATCHED"},
+	)
+	time.Sleep(time.Millisecond)
+
+	n, err := tracker.Poll(context.Background())
+	if err != nil || n != 1 {
+		t.Fatalf("Expected one final trade, got %d, %v", n, err)
+	}
+	if len(final) != 1 || final[0] != "final" || len(failed) != 1 || failed[0] != "reverted" {
+		t.Errorf("Got final %v, failed %v", final, failed)
+	}
+	if len(stuck) != 2 {
+		t.Errorf("Expected the mined and pending trades stuck, got %v", stuck)
+	}
+
+	mined, tracked := tracker.Get("mined")
+	if !tracked || mined.State != SettlementMined || mined.Confirmations != 11 {
+		t.Errorf("Expected mined with 11 confirmations, got %+v", mined)
+	}
+	if len(tracker.Unsettled()) != 2 {
+		t.Errorf("Expected 2 unsettled trades, got %d", len(tracker.Unsettled()))
+	}
+
+	// Stuck is reported once per trade
+	tracker.Poll(context.Background())
+	if len(stuck) != 2 {
+		t.Errorf("Expected no repeated stuck reports, got %v", stuck)
+	}
+}
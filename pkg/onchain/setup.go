@@ -0,0 +1,188 @@
+package onchain
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/math"
+	"github.com/ethereum/go-ethereum/crypto"
+
+	"github.com/MaDal776/polymarket-go-client/pkg/amount"
+	"github.com/MaDal776/polymarket-go-client/pkg/client"
+	"github.com/MaDal776/polymarket-go-client/pkg/types"
+)
+
+// Approval function selectors
+var (
+	approveSelector           = crypto.Keccak256([]byte("approve(address,uint256)"))[:4]
+	isApprovedForAllSelector  = crypto.Keccak256([]byte("isApprovedForAll(address,address)"))[:4]
+	setApprovalForAllSelector = crypto.Keccak256([]byte("setApprovalForAll(address,bool)"))[:4]
+)
+
+// Tokens an Approval covers
+const (
+	ApprovalCollateral        = "collateral"         // USDC.e allowance, needed to buy
+	ApprovalConditionalTokens = "conditional_tokens" // Outcome token operator approval, needed to sell
+)
+
+// Approval is one allowance trading a market needs
+type Approval struct {
+	Token   string `json:"token"`   // ApprovalCollateral or ApprovalConditionalTokens
+	Spender string `json:"spender"` // Exchange or neg risk adapter
+	Granted bool   `json:"granted"`
+	TxHash  string `json:"tx_hash,omitempty"` // Set when EnsureTradingReady sent the approval
+}
+
+// TradingReadiness is the result of EnsureTradingReady
+type TradingReadiness struct {
+	TokenID    string      `json:"token_id"`
+	NegRisk    bool        `json:"neg_risk"`
+	Collateral amount.USDC `json:"collateral"` // USDC.e balance
+	Approvals  []Approval  `json:"approvals"`
+	Ready      bool        `json:"ready"`
+	Reasons    []string    `json:"reasons,omitempty"` // Why the account is not ready
+}
+
+// SetNegRiskLookup sets how EnsureTradingReady tells whether a token belongs to
+// a neg risk market, typically a ClobClient's GetNegRisk. Without one, the
+// approvals of both the regular and the neg risk contracts are ensured.
+func (c *Client) SetNegRiskLookup(lookup func(tokenID string) (bool, error)) {
+	c.negRisk = lookup
+}
+
+// EnsureTradingReady prepares the signer's wallet to trade tokenID. It checks
+// the USDC.e allowance and the outcome token approval of every contract the
+// market settles through, sends an unlimited approval for each one missing and
+// waits for them to be mined. The returned readiness lists every approval and
+// the USDC.e balance; it is not Ready when the balance is zero. An error is
+// returned when a lookup or an approval transaction fails, including a
+// *GasError when the signer cannot pay for gas.
+func (c *Client) EnsureTradingReady(ctx context.Context, tokenID string) (*TradingReadiness, error) {
+	start := time.Now()
+
+	readiness := &TradingReadiness{TokenID: tokenID, Approvals: make([]Approval, 0, 6)}
+	spenders, err := c.tradingSpenders(tokenID, readiness)
+	if err != nil {
+		c.recordMetric("trading_setup", start, false, err.Error())
+		return nil, err
+	}
+	config, _ := client.GetContractConfig(c.chainID.Int64(), false)
+	owner := common.LeftPadBytes(common.HexToAddress(c.signer.AddressHex()).Bytes(), 32)
+
+	balance, err := c.TokenBalance(ctx, types.CollateralBridged, "")
+	if err != nil {
+		c.recordMetric("trading_setup", start, false, err.Error())
+		return nil, err
+	}
+	readiness.Collateral = balance
+
+	// Check every approval, then send the missing ones
+	for _, spender := range spenders {
+		readiness.Approvals = append(readiness.Approvals,
+			Approval{Token: ApprovalCollateral, Spender: spender},
+			Approval{Token: ApprovalConditionalTokens, Spender: spender})
+	}
+	for i := range readiness.Approvals {
+		approval := &readiness.Approvals[i]
+		spender := common.LeftPadBytes(common.HexToAddress(approval.Spender).Bytes(), 32)
+
+		var granted *big.Int
+		if approval.Token == ApprovalCollateral {
+			granted, err = c.callUint(ctx, config.Collateral, abiCall(allowanceSelector, owner, spender))
+		} else {
+			granted, err = c.callUint(ctx, config.ConditionalTokens, abiCall(isApprovedForAllSelector, owner, spender))
+		}
+		if err != nil {
+			c.recordMetric("trading_setup", start, false, err.Error())
+			return nil, fmt.Errorf("failed to check %s approval of %s: %w", approval.Token, approval.Spender, err)
+		}
+		approval.Granted = granted.Sign() > 0 && (approval.Token != ApprovalCollateral || granted.Cmp(balance.ToBigInt()) >= 0)
+		if approval.Granted {
+			continue
+		}
+
+		var to string
+		var data []byte
+		if approval.Token == ApprovalCollateral {
+			to, data = config.Collateral, abiCall(approveSelector, spender, word(math.MaxBig256))
+		} else {
+			to, data = config.ConditionalTokens, abiCall(setApprovalForAllSelector, spender, word(big.NewInt(1)))
+		}
+		approval.TxHash, err = c.SendTransaction(ctx, to, data)
+		if err != nil {
+			c.recordMetric("trading_setup", start, false, err.Error())
+			return nil, fmt.Errorf("failed to approve %s for %s: %w", approval.Spender, approval.Token, err)
+		}
+	}
+
+	// Wait for the approvals sent
+	for i := range readiness.Approvals {
+		approval := &readiness.Approvals[i]
+		if approval.TxHash == "" {
+			continue
+		}
+		receipt, err := c.WaitForReceipt(ctx, approval.TxHash)
+		if err != nil {
+			c.recordMetric("trading_setup", start, false, err.Error())
+			return nil, fmt.Errorf("failed to confirm approval %s: %w", approval.TxHash, err)
+		}
+		if !receipt.Success {
+			c.recordMetric("trading_setup", start, false, "approval reverted")
+			return nil, fmt.Errorf("approval %s of %s for %s reverted", approval.TxHash, approval.Spender, approval.Token)
+		}
+		approval.Granted = true
+	}
+
+	if balance == 0 {
+		reason := "no USDC.e balance"
+		if err := c.CheckTradingCollateral(ctx, ""); err != nil {
+			reason = err.Error()
+		}
+		readiness.Reasons = append(readiness.Reasons, reason)
+	}
+	readiness.Ready = len(readiness.Reasons) == 0
+
+	c.recordMetric("trading_setup", start, true, "")
+	return readiness, nil
+}
+
+// tradingSpenders returns the contracts that move collateral and outcome tokens
+// when tokenID trades: the exchange, or the neg risk exchange and adapter
+func (c *Client) tradingSpenders(tokenID string, readiness *TradingReadiness) ([]string, error) {
+	config, exists := client.GetContractConfig(c.chainID.Int64(), false)
+	negRiskConfig, _ := client.GetContractConfig(c.chainID.Int64(), true)
+	if !exists {
+		return nil, fmt.Errorf("%w: %d", client.ErrUnsupportedChainID, c.chainID.Int64())
+	}
+
+	regular := []string{config.Exchange}
+	negRisk := []string{negRiskConfig.Exchange}
+	if negRiskConfig.NegRiskAdapter != "" {
+		negRisk = append(negRisk, negRiskConfig.NegRiskAdapter)
+	}
+	if c.negRisk == nil {
+		return append(regular, negRisk...), nil
+	}
+
+	isNegRisk, err := c.negRisk(tokenID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get neg risk of token %s: %w", tokenID, err)
+	}
+	readiness.NegRisk = isNegRisk
+	if isNegRisk {
+		return negRisk, nil
+	}
+	return regular, nil
+}
+
+// abiCall concatenates a selector and its ABI-encoded arguments
+func abiCall(selector []byte, args ...[]byte) []byte {
+	data := append(make([]byte, 0, 4+32*len(args)), selector...)
+	for _, arg := range args {
+		data = append(data, arg...)
+	}
+	return data
+}
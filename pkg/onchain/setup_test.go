@@ -0,0 +1,142 @@
+package onchain
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/rlp"
+)
+
+// approvalNode is a node holding a USDC.e balance and the approvals granted so
+// far, which approval transactions sent to it add to
+type approvalNode struct {
+	mu      sync.Mutex
+	balance int64
+	granted map[string]bool // Selector of the check plus spender word
+	sent    int
+}
+
+func (n *approvalNode) serve(t *testing.T) *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var req struct {
+			ID     int64             `json:"id"`
+			Method string            `json:"method"`
+			Params []json.RawMessage `json:"params"`
+		}
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			t.Errorf("Invalid request: %v", err)
+			return
+		}
+		n.mu.Lock()
+		defer n.mu.Unlock()
+
+		var result interface{}
+		switch req.Method {
+		case "eth_call":
+			var call struct {
+				Data string `json:"data"`
+			}
+			json.Unmarshal(req.Params[0], &call)
+			data := hexutil.MustDecode(call.Data)
+			value := big.NewInt(0)
+			switch {
+			case bytes.Equal(data[:4], balanceOfSelector):
+				value.SetInt64(n.balance)
+			case n.granted[fmt.Sprintf("%x%x", data[:4], data[36:68])]:
+				value.SetInt64(1 << 62)
+			}
+			result = hexutil.Encode(word(value))
+		case "eth_getTransactionCount":
+			result = hexutil.EncodeUint64(uint64(n.sent))
+		case "eth_gasPrice":
+			result = "0x6fc23ac00"
+		case "eth_estimateGas":
+			result = "0xc350"
+		case "eth_getBalance":
+			result = "0xde0b6b3a7640000"
+		case "eth_sendRawTransaction":
+			var encoded string
+			json.Unmarshal(req.Params[0], &encoded)
+			var tx rpcTx
+			if err := rlp.DecodeBytes(hexutil.MustDecode(encoded), &tx); err != nil {
+				t.Errorf("Failed to decode transaction: %v", err)
+			}
+			check := allowanceSelector
+			if bytes.Equal(tx.Data[:4], setApprovalForAllSelector) {
+				check = isApprovedForAllSelector
+			}
+			n.granted[fmt.Sprintf("%x%x", check, tx.Data[4:36])] = true
+			n.sent++
+			result = hexutil.Encode(word(big.NewInt(int64(n.sent))))
+		case "eth_getTransactionReceipt":
+			var hash string
+			json.Unmarshal(req.Params[0], &hash)
+			result = map[string]string{"transactionHash": hash, "blockNumber": "0x10", "gasUsed": "0xc350", "status": "0x1"}
+		default:
+			t.Errorf("Unexpected method %s", req.Method)
+		}
+		json.NewEncoder(w).Encode(map[string]interface{}{"jsonrpc": "2.0", "id": req.ID, "result": result})
+	}))
+}
+
+func TestEnsureTradingReady(t *testing.T) {
+	exchange := common.LeftPadBytes(common.HexToAddress("0x4bFb41d5B3570DeFd03C39a9A4D8dE6Bd8B8982E").Bytes(), 32)
+	node := &approvalNode{
+		balance: 25000000,
+		granted: map[string]bool{fmt.Sprintf("%x%x", allowanceSelector, exchange): true},
+	}
+	server := node.serve(t)
+	defer server.Close()
+	c := newTestClient(t, server.URL)
+	c.SetNegRiskLookup(func(tokenID string) (bool, error) { return false, nil })
+
+	readiness, err := c.EnsureTradingReady(context.Background(), "123")
+	if err != nil {
+		t.Fatalf("EnsureTradingReady failed: %v", err)
+	}
+	if !readiness.Ready || readiness.Collateral.String() != "25" || len(readiness.Approvals) != 2 {
+		t.Fatalf("Unexpected readiness: %+v", readiness)
+	}
+	// Only the missing outcome token approval is sent
+	if node.sent != 1 || readiness.Approvals[0].TxHash != "" || readiness.Approvals[1].TxHash == "" {
+		t.Errorf("Expected one approval sent, got %d: %+v", node.sent, readiness.Approvals)
+	}
+	for _, approval := range readiness.Approvals {
+		if !approval.Granted {
+			t.Errorf("Approval not granted: %+v", approval)
+		}
+	}
+
+	// A second call finds everything in place
+	if _, err := c.EnsureTradingReady(context.Background(), "123"); err != nil || node.sent != 1 {
+		t.Errorf("Expected no new approvals, got %d: %v", node.sent, err)
+	}
+}
+
+func TestEnsureTradingReadyWithoutLookup(t *testing.T) {
+	node := &approvalNode{granted: make(map[string]bool)}
+	server := node.serve(t)
+	defer server.Close()
+	c := newTestClient(t, server.URL)
+
+	readiness, err := c.EnsureTradingReady(context.Background(), "123")
+	if err != nil {
+		t.Fatalf("EnsureTradingReady failed: %v", err)
+	}
+	// Exchange, neg risk exchange and adapter, for both tokens
+	if len(readiness.Approvals) != 6 || node.sent != 6 {
+		t.Errorf("Expected 6 approvals sent, got %d of %d", node.sent, len(readiness.Approvals))
+	}
+	if readiness.Ready || len(readiness.Reasons) != 1 {
+		t.Errorf("Expected not ready without collateral: %+v", readiness)
+	}
+}
@@ -4,11 +4,13 @@ import (
 	"fmt"
 	"math/big"
 	"math/rand"
+	"strings"
+	"sync"
 	"time"
 
-	"polymarket-clob-go/pkg/signer"
-	"polymarket-clob-go/pkg/types"
-	"polymarket-clob-go/pkg/utils"
+	"github.com/MaDal776/polymarket-go-client/pkg/signer"
+	"github.com/MaDal776/polymarket-go-client/pkg/types"
+	"github.com/MaDal776/polymarket-go-client/pkg/utils"
 )
 
 const (
@@ -19,9 +21,19 @@ const (
 // OrderBuilder handles order creation and signing
 type OrderBuilder struct {
 	signer        *signer.Signer
+	walletMu      sync.RWMutex // Guards signatureType and funder, see SetWallet
 	signatureType int
 	funder        string
 	metrics       []types.PerformanceMetrics
+	metricsMu     sync.Mutex
+	
+	// Metrics output, set by SetMetricsOutput and guarded by metricsMu
+	onMetric       func(metric types.PerformanceMetrics)
+	discardMetrics bool
+	
+	// EIP712 domain separators keyed by chain ID and exchange address
+	domainMu sync.RWMutex
+	domains  map[string][]byte
 }
 
 // NewOrderBuilder creates a new order builder
@@ -41,11 +53,38 @@ func NewOrderBuilder(s *signer.Signer, signatureType *int, funder *string) *Orde
 		signatureType: sigType,
 		funder:        funderAddr,
 		metrics:       make([]types.PerformanceMetrics, 0),
+		domains:       make(map[string][]byte),
 	}
 }
 
+// SignatureType returns the signature type used for orders
+func (ob *OrderBuilder) SignatureType() int {
+	signatureType, _ := ob.Wallet()
+	return signatureType
+}
+
+// SetWallet changes the signature type and funder of orders created from now on
+func (ob *OrderBuilder) SetWallet(signatureType int, funder string) {
+	ob.walletMu.Lock()
+	defer ob.walletMu.Unlock()
+	ob.signatureType = signatureType
+	ob.funder = funder
+}
+
+// Wallet returns the signature type and funder orders are created with
+func (ob *OrderBuilder) Wallet() (int, string) {
+	ob.walletMu.RLock()
+	defer ob.walletMu.RUnlock()
+	return ob.signatureType, ob.funder
+}
+
 // CreateOrder creates and signs a limit order
 func (ob *OrderBuilder) CreateOrder(orderArgs types.OrderArgs, options types.CreateOrderOptions, exchangeAddress string) (*types.SignedOrder, error) {
+	return ob.CreateOrderTimed(orderArgs, options, exchangeAddress, types.NewStageTimer(time.Now()))
+}
+
+// CreateOrderTimed is CreateOrder, marking the amount calculation, hashing and signing stages on timer
+func (ob *OrderBuilder) CreateOrderTimed(orderArgs types.OrderArgs, options types.CreateOrderOptions, exchangeAddress string, timer *types.StageTimer) (*types.SignedOrder, error) {
 	start := time.Now()
 	
 	// Get order amounts
@@ -54,10 +93,12 @@ func (ob *OrderBuilder) CreateOrder(orderArgs types.OrderArgs, options types.Cre
 		ob.recordMetric("order_creation", start, false, err.Error())
 		return nil, fmt.Errorf("failed to calculate order amounts: %w", err)
 	}
+	timer.Mark(types.StageAmounts)
+	signatureType, funder := ob.Wallet()
 	
 	// Create order data
 	orderData := types.OrderData{
-		Maker:         ob.funder,
+		Maker:         funder,
 		Taker:         orderArgs.Taker,
 		TokenID:       orderArgs.TokenID,
 		MakerAmount:   makerAmount,
@@ -67,11 +108,11 @@ func (ob *OrderBuilder) CreateOrder(orderArgs types.OrderArgs, options types.Cre
 		Nonce:         fmt.Sprintf("%d", orderArgs.Nonce),
 		Signer:        ob.signer.AddressHex(),
 		Expiration:    fmt.Sprintf("%d", orderArgs.Expiration),
-		SignatureType: ob.signatureType,
+		SignatureType: signatureType,
 	}
 	
 	// Sign the order
-	signedOrder, err := ob.signOrder(orderData, exchangeAddress)
+	signedOrder, err := ob.signOrder(orderData, exchangeAddress, timer)
 	if err != nil {
 		ob.recordMetric("order_creation", start, false, err.Error())
 		return nil, fmt.Errorf("failed to sign order: %w", err)
@@ -83,6 +124,11 @@ func (ob *OrderBuilder) CreateOrder(orderArgs types.OrderArgs, options types.Cre
 
 // CreateMarketOrder creates and signs a market order
 func (ob *OrderBuilder) CreateMarketOrder(orderArgs types.MarketOrderArgs, options types.CreateOrderOptions, exchangeAddress string) (*types.SignedOrder, error) {
+	return ob.CreateMarketOrderTimed(orderArgs, options, exchangeAddress, types.NewStageTimer(time.Now()))
+}
+
+// CreateMarketOrderTimed is CreateMarketOrder, marking the amount calculation, hashing and signing stages on timer
+func (ob *OrderBuilder) CreateMarketOrderTimed(orderArgs types.MarketOrderArgs, options types.CreateOrderOptions, exchangeAddress string, timer *types.StageTimer) (*types.SignedOrder, error) {
 	start := time.Now()
 	
 	// Get market order amounts
@@ -91,10 +137,12 @@ func (ob *OrderBuilder) CreateMarketOrder(orderArgs types.MarketOrderArgs, optio
 		ob.recordMetric("market_order_creation", start, false, err.Error())
 		return nil, fmt.Errorf("failed to calculate market order amounts: %w", err)
 	}
+	timer.Mark(types.StageAmounts)
+	signatureType, funder := ob.Wallet()
 	
 	// Create order data (market orders have expiration = 0)
 	orderData := types.OrderData{
-		Maker:         ob.funder,
+		Maker:         funder,
 		Taker:         orderArgs.Taker,
 		TokenID:       orderArgs.TokenID,
 		MakerAmount:   makerAmount,
@@ -104,11 +152,11 @@ func (ob *OrderBuilder) CreateMarketOrder(orderArgs types.MarketOrderArgs, optio
 		Nonce:         fmt.Sprintf("%d", orderArgs.Nonce),
 		Signer:        ob.signer.AddressHex(),
 		Expiration:    "0", // Market orders don't expire
-		SignatureType: ob.signatureType,
+		SignatureType: signatureType,
 	}
 	
 	// Sign the order
-	signedOrder, err := ob.signOrder(orderData, exchangeAddress)
+	signedOrder, err := ob.signOrder(orderData, exchangeAddress, timer)
 	if err != nil {
 		ob.recordMetric("market_order_creation", start, false, err.Error())
 		return nil, fmt.Errorf("failed to sign market order: %w", err)
@@ -224,8 +272,8 @@ func (ob *OrderBuilder) getMarketOrderAmounts(side types.OrderSide, amount, pric
 	return sideInt, makerAmount, takerAmount, nil
 }
 
-// signOrder signs an order using EIP712
-func (ob *OrderBuilder) signOrder(orderData types.OrderData, exchangeAddress string) (*types.SignedOrder, error) {
+// signOrder signs an order using EIP712, marking the hashing and signing stages on timer
+func (ob *OrderBuilder) signOrder(orderData types.OrderData, exchangeAddress string, timer *types.StageTimer) (*types.SignedOrder, error) {
 	start := time.Now()
 	
 	// Generate salt using Python-compatible method
@@ -234,7 +282,9 @@ func (ob *OrderBuilder) signOrder(orderData types.OrderData, exchangeAddress str
 	salt := int64(now * rand.Float64())
 	
 	// Create order hash for signing using EIP712 (matches py_order_utils)
-	orderHash := utils.CreateOrderEIP712Hash(orderData, salt, exchangeAddress, ob.signer.ChainID())
+	domainSeparator := ob.domainSeparator(exchangeAddress)
+	orderHash := utils.CreateEIP712Hash(domainSeparator, utils.CreateOrderStructHash(orderData, salt))
+	timer.Mark(types.StageHash)
 	
 	// Sign the hash
 	signature, err := ob.signer.Sign(orderHash)
@@ -242,6 +292,7 @@ func (ob *OrderBuilder) signOrder(orderData types.OrderData, exchangeAddress str
 		ob.recordMetric("order_signing", start, false, err.Error())
 		return nil, fmt.Errorf("failed to sign order hash: %w", err)
 	}
+	timer.Mark(types.StageSign)
 	
 	// Convert side integer to OrderSide string
 	var sideStr types.OrderSide
@@ -272,13 +323,48 @@ func (ob *OrderBuilder) signOrder(orderData types.OrderData, exchangeAddress str
 	return signedOrder, nil
 }
 
+// domainSeparator returns the cached Polymarket domain separator for an exchange on the signer's chain
+func (ob *OrderBuilder) domainSeparator(exchangeAddress string) []byte {
+	chainID := ob.signer.ChainID()
+	key := fmt.Sprintf("%d:%s", chainID, strings.ToLower(exchangeAddress))
+	
+	ob.domainMu.RLock()
+	domain, exists := ob.domains[key]
+	ob.domainMu.RUnlock()
+	if exists {
+		return domain
+	}
+	
+	domain = utils.CreatePolymarketDomain(chainID, exchangeAddress)
+	ob.domainMu.Lock()
+	ob.domains[key] = domain
+	ob.domainMu.Unlock()
+	return domain
+}
+
+// SetMetricsOutput sets a callback for every recorded metric and whether
+// metrics are also kept for GetMetrics; disabling buffering drops those kept so far
+func (ob *OrderBuilder) SetMetricsOutput(onMetric func(metric types.PerformanceMetrics), buffer bool) {
+	ob.metricsMu.Lock()
+	defer ob.metricsMu.Unlock()
+	ob.onMetric = onMetric
+	ob.discardMetrics = !buffer
+	if !buffer {
+		ob.metrics = make([]types.PerformanceMetrics, 0)
+	}
+}
+
 // GetMetrics returns performance metrics
 func (ob *OrderBuilder) GetMetrics() []types.PerformanceMetrics {
-	return ob.metrics
+	ob.metricsMu.Lock()
+	defer ob.metricsMu.Unlock()
+	return append([]types.PerformanceMetrics(nil), ob.metrics...)
 }
 
 // ClearMetrics clears performance metrics
 func (ob *OrderBuilder) ClearMetrics() {
+	ob.metricsMu.Lock()
+	defer ob.metricsMu.Unlock()
 	ob.metrics = make([]types.PerformanceMetrics, 0)
 }
 
@@ -291,5 +377,14 @@ func (ob *OrderBuilder) recordMetric(operation string, startTime time.Time, succ
 		Success:   success,
 		Error:     errorMsg,
 	}
-	ob.metrics = append(ob.metrics, metric)
+	ob.metricsMu.Lock()
+	if !ob.discardMetrics {
+		ob.metrics = append(ob.metrics, metric)
+	}
+	onMetric := ob.onMetric
+	ob.metricsMu.Unlock()
+	
+	if onMetric != nil {
+		onMetric(metric)
+	}
 }
\ No newline at end of file
@@ -0,0 +1,37 @@
+package orderbuilder
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/MaDal776/polymarket-go-client/pkg/signer"
+	"github.com/MaDal776/polymarket-go-client/pkg/utils"
+)
+
+// Well-known development key; never holds funds
+const testPrivateKey = "0xac0974bec39a17e36ba4a6b4d238ff944bacb478cbed5efcae784d7bf4f2ff80"
+
+const testExchange = "0x4bFb41d5B3570DeFd03C39a9A4D8dE6Bd8B8982E"
+
+func newTestBuilder(t testing.TB) *OrderBuilder {
+	s, err := signer.NewSigner(testPrivateKey, 137)
+	if err != nil {
+		t.Fatalf("Failed to create signer: %v", err)
+	}
+	return NewOrderBuilder(s, nil, nil)
+}
+
+func TestDomainSeparatorIsCached(t *testing.T) {
+	ob := newTestBuilder(t)
+
+	first := ob.domainSeparator(testExchange)
+	if !bytes.Equal(first, utils.CreatePolymarketDomain(137, testExchange)) {
+		t.Fatal("Cached domain separator differs from a freshly computed one")
+	}
+
+	// Address case must not create a second entry
+	ob.domainSeparator("0x4bfb41d5b3570defd03c39a9a4d8de6bd8b8982e")
+	if len(ob.domains) != 1 {
+		t.Errorf("Expected 1 cached domain, got %d", len(ob.domains))
+	}
+}
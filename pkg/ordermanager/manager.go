@@ -0,0 +1,708 @@
+package ordermanager
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/MaDal776/polymarket-go-client/pkg/client"
+	"github.com/MaDal776/polymarket-go-client/pkg/portfolio"
+	"github.com/MaDal776/polymarket-go-client/pkg/types"
+	"github.com/MaDal776/polymarket-go-client/pkg/utils"
+)
+
+// sizeEpsilon is the smallest quantity treated as non-zero
+const sizeEpsilon = 1e-9
+
+// ErrNothingRemaining is returned by Requote when the order has no unfilled size left
+var ErrNothingRemaining = errors.New("order has no unfilled size")
+
+// ErrNotReposted is returned by Requote when the order was cancelled but its
+// remainder could not be determined, so nothing was posted in its place
+var ErrNotReposted = errors.New("order cancelled but not reposted")
+
+// State represents the lifecycle state of a managed order
+type State string
+
+const (
+	StatePending         State = "PENDING"          // Submitted, not yet acknowledged as live
+	StateLive            State = "LIVE"             // Resting on the book, nothing matched
+	StatePartiallyFilled State = "PARTIALLY_FILLED" // Resting with part of its size matched
+	StateFilled          State = "FILLED"           // Fully matched
+	StateCancelled       State = "CANCELLED"        // Cancelled, possibly after partial fills
+	StateRejected        State = "REJECTED"         // Refused by the client or the exchange
+)
+
+// IsTerminal reports whether no further transitions are possible from the state
+func (s State) IsTerminal() bool {
+	return s == StateFilled || s == StateCancelled || s == StateRejected
+}
+
+// validTransitions lists the states reachable from each state
+var validTransitions = map[State][]State{
+	StatePending:         {StateLive, StatePartiallyFilled, StateFilled, StateCancelled, StateRejected},
+	StateLive:            {StatePartiallyFilled, StateFilled, StateCancelled},
+	StatePartiallyFilled: {StatePartiallyFilled, StateFilled, StateCancelled},
+}
+
+// canTransition reports whether from -> to is allowed
+func canTransition(from, to State) bool {
+	if from == to {
+		return true
+	}
+	for _, next := range validTransitions[from] {
+		if next == to {
+			return true
+		}
+	}
+	return false
+}
+
+// ManagedOrder is an order tracked through its lifecycle
+type ManagedOrder struct {
+	ID          string             `json:"id"`
+	Tag         string             `json:"tag,omitempty"`
+	TokenID     string             `json:"token_id"`
+	Side        types.OrderSide    `json:"side"`
+	Price       float64            `json:"price"`
+	Size        float64            `json:"size"`
+	SizeMatched float64            `json:"size_matched"`
+	OrderType   types.OrderType    `json:"order_type"`
+	State       State              `json:"state"`
+	Error       string             `json:"error,omitempty"`
+	CreatedAt   time.Time          `json:"created_at"`
+	UpdatedAt   time.Time          `json:"updated_at"`
+	Signed      *types.SignedOrder `json:"signed,omitempty"`
+	Args        *types.OrderArgs   `json:"args,omitempty"` // Arguments the order was created from, or read back from the signed order
+
+	reportedMatched float64
+	tradeMatched    float64
+	fills           map[string]bool
+	version         uint64 // Bumped for every record written to the store
+}
+
+// Remaining returns the unmatched size of the order
+func (o *ManagedOrder) Remaining() float64 {
+	return math.Max(o.Size-o.SizeMatched, 0)
+}
+
+// Exposure summarizes open (unmatched) order size for a token
+type Exposure struct {
+	TokenID      string  `json:"token_id"`
+	OpenOrders   int     `json:"open_orders"`
+	BuySize      float64 `json:"buy_size"`
+	BuyNotional  float64 `json:"buy_notional"`
+	SellSize     float64 `json:"sell_size"`
+	SellNotional float64 `json:"sell_notional"`
+}
+
+// OrderManager owns order submission and tracks each order's lifecycle
+type OrderManager struct {
+	trader client.Trader
+
+	mu       sync.Mutex
+	orders   map[string]*ManagedOrder
+	rejected []*ManagedOrder
+	onUpdate func(order ManagedOrder)
+
+	// Persistence, set by SetStore
+	store        Store
+	onStoreError func(err error)
+	storeMu      sync.Mutex        // Serializes store writes, held after mu is released
+	saved        map[string]uint64 // Order ID -> version of the last record written
+}
+
+// NewOrderManager creates an order manager trading through trader
+func NewOrderManager(trader client.Trader) *OrderManager {
+	return &OrderManager{
+		trader:   trader,
+		orders:   make(map[string]*ManagedOrder),
+		rejected: make([]*ManagedOrder, 0),
+		saved:    make(map[string]uint64),
+	}
+}
+
+// OnUpdate registers a callback invoked with a copy of an order after every state or fill change
+func (m *OrderManager) OnUpdate(callback func(order ManagedOrder)) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.onUpdate = callback
+}
+
+// Submit creates, signs and posts an order, tracking it from submission
+func (m *OrderManager) Submit(orderArgs types.OrderArgs, options *types.CreateOrderOptions, orderType types.OrderType, tag string) (*ManagedOrder, error) {
+	signedOrder, err := m.trader.CreateOrder(orderArgs, options)
+	if err != nil {
+		order := &ManagedOrder{
+			Tag:       tag,
+			TokenID:   orderArgs.TokenID,
+			Side:      orderArgs.Side,
+			Price:     orderArgs.Price,
+			Size:      orderArgs.Size,
+			OrderType: orderType,
+			State:     StateRejected,
+			Error:     err.Error(),
+			CreatedAt: time.Now(),
+			UpdatedAt: time.Now(),
+		}
+		m.mu.Lock()
+		m.rejected = append(m.rejected, order)
+		m.mu.Unlock()
+		m.notify(order)
+		return order, fmt.Errorf("failed to create order: %w", err)
+	}
+	return m.submitSigned(signedOrder, orderType, tag, &orderArgs)
+}
+
+// SubmitSigned posts an already signed order, tracking it from submission
+func (m *OrderManager) SubmitSigned(signedOrder *types.SignedOrder, orderType types.OrderType, tag string) (*ManagedOrder, error) {
+	return m.submitSigned(signedOrder, orderType, tag, nil)
+}
+
+// submitSigned posts a signed order created from args, which are read back
+// from the signed order when nil
+func (m *OrderManager) submitSigned(signedOrder *types.SignedOrder, orderType types.OrderType, tag string, args *types.OrderArgs) (*ManagedOrder, error) {
+	price, size, err := utils.SignedOrderPriceAndSize(signedOrder)
+	if err != nil {
+		return nil, err
+	}
+	if args == nil {
+		args = signedOrderArgs(signedOrder, price, size)
+	}
+
+	order := &ManagedOrder{
+		Tag:       tag,
+		TokenID:   signedOrder.TokenID,
+		Side:      signedOrder.Side,
+		Price:     price,
+		Size:      size,
+		OrderType: orderType,
+		State:     StatePending,
+		CreatedAt: time.Now(),
+		UpdatedAt: time.Now(),
+		Signed:    signedOrder,
+		Args:      args,
+		fills:     make(map[string]bool),
+	}
+
+	pendingID, err := m.savePending(order)
+	if err != nil {
+		m.reject(order, err.Error())
+		return m.snapshot(order), err
+	}
+
+	result, err := m.trader.PostOrder(signedOrder, orderType)
+	if err != nil {
+		// The pending record stays when the order may have reached the
+		// exchange, so Recover can look it up
+		if client.IsRejected(err) {
+			m.forgetPending(pendingID)
+		}
+		m.reject(order, err.Error())
+		return m.snapshot(order), fmt.Errorf("failed to post order: %w", err)
+	}
+
+	success, _ := result["success"].(bool)
+	orderID, _ := result["orderID"].(string)
+	if !success || orderID == "" {
+		m.forgetPending(pendingID)
+		message, _ := result["errorMsg"].(string)
+		if message == "" {
+			message = "order rejected"
+		}
+		m.reject(order, message)
+		return m.snapshot(order), fmt.Errorf("order rejected: %s", message)
+	}
+	if pendingID != orderID {
+		m.forgetPending(pendingID)
+	}
+
+	m.mu.Lock()
+	order.ID = orderID
+	m.orders[orderID] = order
+	status, _ := result["status"].(string)
+	m.applyPostStatus(order, status, result)
+	m.mu.Unlock()
+
+	m.notify(order)
+	return m.snapshot(order), nil
+}
+
+// Cancel cancels a managed order
+func (m *OrderManager) Cancel(orderID string) error {
+	result, err := m.trader.CancelOrder(orderID)
+	if err != nil {
+		return fmt.Errorf("failed to cancel order %s: %w", orderID, err)
+	}
+	m.applyCancelResult(result)
+	return nil
+}
+
+// CancelAll cancels every open order on the account
+func (m *OrderManager) CancelAll() error {
+	result, err := m.trader.CancelAll()
+	if err != nil {
+		return fmt.Errorf("failed to cancel all orders: %w", err)
+	}
+	m.applyCancelResult(result)
+	return nil
+}
+
+// ApplyFill matches a fill to its order and advances the order's state.
+// It returns false when the fill does not belong to a managed order.
+func (m *OrderManager) ApplyFill(fill portfolio.Fill) bool {
+	m.mu.Lock()
+	order, exists := m.orders[fill.OrderID]
+	if !exists {
+		m.mu.Unlock()
+		return false
+	}
+
+	key := fill.TradeID
+	if key != "" && order.fills[key] {
+		m.mu.Unlock()
+		return true
+	}
+	if key != "" {
+		order.fills[key] = true
+	}
+
+	order.tradeMatched += fill.Size
+	m.updateMatched(order)
+	m.mu.Unlock()
+
+	m.notify(order)
+	return true
+}
+
+// ApplyTrade matches the fills in a trade notification to managed orders.
+// It returns the number of fills that belonged to managed orders.
+func (m *OrderManager) ApplyTrade(trade types.Trade) (int, error) {
+	fills, err := portfolio.FillsFromTrade(trade, m.trader.GetAddress())
+	if err != nil {
+		return 0, err
+	}
+
+	matched := 0
+	for _, fill := range fills {
+		if m.ApplyFill(fill) {
+			matched++
+		}
+	}
+	return matched, nil
+}
+
+// ApplyOpenOrder reconciles a managed order with the server's view of it.
+// It returns false when the order is not managed.
+func (m *OrderManager) ApplyOpenOrder(openOrder types.OpenOrder) bool {
+	m.mu.Lock()
+	order, exists := m.orders[openOrder.ID]
+	if !exists {
+		m.mu.Unlock()
+		return false
+	}
+
+	if matched, err := strconv.ParseFloat(openOrder.SizeMatched, 64); err == nil && matched > order.reportedMatched {
+		order.reportedMatched = matched
+		m.updateMatched(order)
+	}
+
+	switch strings.TrimPrefix(strings.ToUpper(openOrder.Status), "ORDER_STATUS_") {
+	case "LIVE":
+		m.transition(order, StateLive)
+	case "MATCHED":
+		order.reportedMatched = order.Size
+		m.updateMatched(order)
+	case "CANCELED", "CANCELLED", "CANCELED_MARKET_RESOLVED", "INVALID":
+		m.transition(order, StateCancelled)
+	}
+	m.mu.Unlock()
+
+	m.notify(order)
+	return true
+}
+
+// ApplyOrderEvent applies an order message from the user websocket channel,
+// whose size_matched is the order's total matched size so far.
+// It returns false when the order is not managed.
+func (m *OrderManager) ApplyOrderEvent(event types.OrderEvent) bool {
+	m.mu.Lock()
+	order, exists := m.orders[event.ID]
+	if !exists {
+		m.mu.Unlock()
+		return false
+	}
+
+	if matched, err := strconv.ParseFloat(event.SizeMatched, 64); err == nil && matched > order.reportedMatched {
+		order.reportedMatched = matched
+		m.updateMatched(order)
+	}
+
+	switch types.OrderEventType(strings.ToUpper(string(event.Type))) {
+	case types.OrderEventPlacement:
+		m.transition(order, StateLive)
+	case types.OrderEventCancellation:
+		m.transition(order, StateCancelled)
+	}
+	m.mu.Unlock()
+
+	m.notify(order)
+	return true
+}
+
+// Refresh fetches a single order from the exchange and applies its state
+func (m *OrderManager) Refresh(orderID string) error {
+	openOrder, err := m.trader.GetOrder(orderID)
+	if err != nil {
+		return fmt.Errorf("failed to refresh order %s: %w", orderID, err)
+	}
+	if openOrder.ID == "" {
+		openOrder.ID = orderID
+	}
+	m.ApplyOpenOrder(*openOrder)
+	return nil
+}
+
+// Sync applies the exchange's open orders to managed orders and refreshes
+// locally open orders the exchange no longer lists, which have filled or been cancelled
+func (m *OrderManager) Sync() error {
+	remote, err := m.trader.GetOpenOrders(nil)
+	if err != nil {
+		return fmt.Errorf("failed to get open orders: %w", err)
+	}
+
+	listed := make(map[string]bool, len(remote))
+	for _, openOrder := range remote {
+		listed[openOrder.ID] = true
+		m.ApplyOpenOrder(openOrder)
+	}
+
+	for _, id := range m.OpenOrderIDs() {
+		if listed[id] {
+			continue
+		}
+		if err := m.Refresh(id); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Order returns a copy of a managed order
+func (m *OrderManager) Order(orderID string) (ManagedOrder, bool) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	order, exists := m.orders[orderID]
+	if !exists {
+		return ManagedOrder{}, false
+	}
+	return copyOrder(order), true
+}
+
+// Remaining returns the unmatched size of a managed order
+func (m *OrderManager) Remaining(orderID string) (float64, bool) {
+	order, exists := m.Order(orderID)
+	if !exists {
+		return 0, false
+	}
+	return order.Remaining(), true
+}
+
+// Requote cancels an open order and posts its unfilled remainder at price, with
+// the arguments it was created from (token, side, fee rate, nonce, expiration
+// and taker), order type and tag. The order is refreshed after the cancel so
+// that fills racing the cancel are not posted again. The remainder is rounded
+// down to whole cents of a share; ErrNothingRemaining is returned when nothing
+// is left to post. When the refresh fails the order stays cancelled and the
+// error matches ErrNotReposted.
+func (m *OrderManager) Requote(orderID string, price float64, options *types.CreateOrderOptions) (*ManagedOrder, error) {
+	order, exists := m.Order(orderID)
+	if !exists {
+		return nil, fmt.Errorf("order %s is not managed", orderID)
+	}
+	if order.State.IsTerminal() {
+		return nil, fmt.Errorf("order %s is %s", orderID, order.State)
+	}
+
+	if err := m.Cancel(orderID); err != nil {
+		return nil, err
+	}
+	if err := m.Refresh(orderID); err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrNotReposted, err)
+	}
+	remaining, exists := m.Remaining(orderID)
+	if !exists {
+		return nil, fmt.Errorf("%w: order %s is no longer managed", ErrNotReposted, orderID)
+	}
+	remaining = utils.RoundDown(remaining+sizeEpsilon, 2)
+	if remaining <= 0 {
+		return nil, ErrNothingRemaining
+	}
+
+	args := types.OrderArgs{TokenID: order.TokenID, Side: order.Side}
+	if order.Args != nil {
+		args = *order.Args
+	}
+	args.Price = price
+	args.Size = remaining
+	args.Notional = 0
+	return m.Submit(args, options, order.OrderType, order.Tag)
+}
+
+// Orders returns copies of all managed orders, including rejected ones, oldest first
+func (m *OrderManager) Orders() []ManagedOrder {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	orders := make([]ManagedOrder, 0, len(m.orders)+len(m.rejected))
+	for _, order := range m.orders {
+		orders = append(orders, copyOrder(order))
+	}
+	for _, order := range m.rejected {
+		orders = append(orders, copyOrder(order))
+	}
+	sort.Slice(orders, func(i, j int) bool {
+		return orders[i].CreatedAt.Before(orders[j].CreatedAt)
+	})
+	return orders
+}
+
+// OpenOrders returns copies of the orders that are not in a terminal state
+func (m *OrderManager) OpenOrders() []ManagedOrder {
+	open := make([]ManagedOrder, 0)
+	for _, order := range m.Orders() {
+		if !order.State.IsTerminal() {
+			open = append(open, order)
+		}
+	}
+	return open
+}
+
+// OpenOrderIDs returns the IDs of the orders that are not in a terminal state
+func (m *OrderManager) OpenOrderIDs() []string {
+	open := m.OpenOrders()
+	ids := make([]string, 0, len(open))
+	for _, order := range open {
+		ids = append(ids, order.ID)
+	}
+	return ids
+}
+
+// LocalOrders lists the open orders for a portfolio.Reconciler, whose
+// CorrectOrders the manager can also serve as
+func (m *OrderManager) LocalOrders() []portfolio.LocalOrder {
+	open := m.OpenOrders()
+	orders := make([]portfolio.LocalOrder, 0, len(open))
+	for _, order := range open {
+		orders = append(orders, portfolio.LocalOrder{ID: order.ID, TokenID: order.TokenID, Remaining: order.Remaining()})
+	}
+	return orders
+}
+
+// OpenOrderCount returns the number of open orders for a token
+func (m *OrderManager) OpenOrderCount(tokenID string) (int, error) {
+	ids, err := m.TokenOpenOrderIDs(tokenID)
+	return len(ids), err
+}
+
+// TokenOpenOrderIDs returns the IDs of the open orders for a token.
+// Its signature matches risk.RiskTrader.SetOpenOrderSource.
+func (m *OrderManager) TokenOpenOrderIDs(tokenID string) ([]string, error) {
+	ids := make([]string, 0)
+	for _, order := range m.OpenOrders() {
+		if order.TokenID == tokenID {
+			ids = append(ids, order.ID)
+		}
+	}
+	return ids, nil
+}
+
+// Exposure returns the open order exposure per token, sorted by token ID
+func (m *OrderManager) Exposure() []Exposure {
+	byToken := make(map[string]*Exposure)
+	for _, order := range m.OpenOrders() {
+		exposure, exists := byToken[order.TokenID]
+		if !exists {
+			exposure = &Exposure{TokenID: order.TokenID}
+			byToken[order.TokenID] = exposure
+		}
+		exposure.OpenOrders++
+		remaining := order.Remaining()
+		if order.Side == types.BUY {
+			exposure.BuySize += remaining
+			exposure.BuyNotional += remaining * order.Price
+		} else {
+			exposure.SellSize += remaining
+			exposure.SellNotional += remaining * order.Price
+		}
+	}
+
+	exposures := make([]Exposure, 0, len(byToken))
+	for _, exposure := range byToken {
+		exposures = append(exposures, *exposure)
+	}
+	sort.Slice(exposures, func(i, j int) bool {
+		return exposures[i].TokenID < exposures[j].TokenID
+	})
+	return exposures
+}
+
+// applyPostStatus sets the state from the post response. Callers hold m.mu.
+func (m *OrderManager) applyPostStatus(order *ManagedOrder, status string, result map[string]interface{}) {
+	switch status {
+	case "matched":
+		// Taker fills: the response reports the matched amounts
+		shares := result["takingAmount"]
+		if order.Side == types.SELL {
+			shares = result["makingAmount"]
+		}
+		if matched, err := strconv.ParseFloat(fmt.Sprintf("%v", shares), 64); err == nil {
+			order.reportedMatched = matched
+		} else {
+			order.reportedMatched = order.Size
+		}
+		m.updateMatched(order)
+	case "live":
+		m.transition(order, StateLive)
+	case "unmatched":
+		m.transition(order, StateCancelled)
+	default:
+		// "delayed" and unknown statuses stay pending until fills or cancels arrive
+	}
+
+	// Immediate-or-cancel types never rest on the book
+	if (order.OrderType == types.FOK || order.OrderType == types.FAK) && !order.State.IsTerminal() && status != "delayed" {
+		m.transition(order, StateCancelled)
+	}
+}
+
+// updateMatched recomputes the matched size and state. Callers hold m.mu.
+func (m *OrderManager) updateMatched(order *ManagedOrder) {
+	order.SizeMatched = math.Min(math.Max(order.reportedMatched, order.tradeMatched), order.Size)
+	if order.Remaining() <= sizeEpsilon {
+		m.transition(order, StateFilled)
+	} else if order.SizeMatched > sizeEpsilon {
+		m.transition(order, StatePartiallyFilled)
+	}
+	order.UpdatedAt = time.Now()
+}
+
+// transition moves an order to a new state if the lifecycle allows it. Callers hold m.mu.
+func (m *OrderManager) transition(order *ManagedOrder, to State) {
+	if !canTransition(order.State, to) {
+		return
+	}
+	order.State = to
+	order.UpdatedAt = time.Now()
+}
+
+// reject records an order the client or exchange refused
+func (m *OrderManager) reject(order *ManagedOrder, message string) {
+	m.mu.Lock()
+	m.transition(order, StateRejected)
+	order.Error = message
+	m.rejected = append(m.rejected, order)
+	m.mu.Unlock()
+	m.notify(order)
+}
+
+// applyCancelResult marks the orders listed in a cancel response as cancelled
+func (m *OrderManager) applyCancelResult(result map[string]interface{}) {
+	updated := make([]*ManagedOrder, 0)
+
+	m.mu.Lock()
+	for _, id := range stringList(result["canceled"]) {
+		if order, exists := m.orders[id]; exists && !order.State.IsTerminal() {
+			m.transition(order, StateCancelled)
+			updated = append(updated, order)
+		}
+	}
+	m.mu.Unlock()
+
+	for _, order := range updated {
+		m.notify(order)
+	}
+}
+
+// notify persists the order and passes a copy of it to the update callback
+func (m *OrderManager) notify(order *ManagedOrder) {
+	m.mu.Lock()
+	callback := m.onUpdate
+	snapshot := copyOrder(order)
+	store, onStoreError := m.store, m.onStoreError
+	orderID := order.ID
+	var record []byte
+	var version uint64
+	var err error
+	if store != nil && orderID != "" {
+		order.version++
+		version = order.version
+		record, err = encodeRecord(order, false)
+	}
+	m.mu.Unlock()
+
+	if record != nil {
+		err = m.saveRecord(store, orderID, version, record)
+	}
+	if err != nil && onStoreError != nil {
+		onStoreError(fmt.Errorf("failed to save order %s: %w", orderID, err))
+	}
+	if callback != nil {
+		callback(snapshot)
+	}
+}
+
+// signedOrderArgs reads the order arguments back from a signed order
+func signedOrderArgs(signedOrder *types.SignedOrder, price, size float64) *types.OrderArgs {
+	feeRateBps, _ := strconv.Atoi(signedOrder.FeeRateBps)
+	nonce, _ := strconv.ParseInt(signedOrder.Nonce, 10, 64)
+	expiration, _ := strconv.ParseInt(signedOrder.Expiration, 10, 64)
+	return &types.OrderArgs{
+		TokenID:    signedOrder.TokenID,
+		Price:      price,
+		Size:       size,
+		Side:       signedOrder.Side,
+		FeeRateBps: feeRateBps,
+		Nonce:      nonce,
+		Expiration: expiration,
+		Taker:      signedOrder.Taker,
+	}
+}
+
+// snapshot returns a copy of the order that callers may keep
+func (m *OrderManager) snapshot(order *ManagedOrder) *ManagedOrder {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	copied := copyOrder(order)
+	return &copied
+}
+
+// copyOrder copies an order without its internal bookkeeping
+func copyOrder(order *ManagedOrder) ManagedOrder {
+	copied := *order
+	copied.fills = nil
+	return copied
+}
+
+// stringList converts a decoded JSON array of strings
+func stringList(value interface{}) []string {
+	switch v := value.(type) {
+	case []string:
+		return v
+	case []interface{}:
+		list := make([]string, 0, len(v))
+		for _, item := range v {
+			if s, ok := item.(string); ok {
+				list = append(list, s)
+			}
+		}
+		return list
+	}
+	return nil
+}
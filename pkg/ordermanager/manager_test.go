@@ -0,0 +1,252 @@
+package ordermanager
+
+import (
+	"errors"
+	"strconv"
+	"testing"
+
+	"github.com/MaDal776/polymarket-go-client/pkg/client"
+	"github.com/MaDal776/polymarket-go-client/pkg/portfolio"
+	"github.com/MaDal776/polymarket-go-client/pkg/types"
+)
+
+const testToken = "91094360697357622623953793720402150934374522251651348543981406747516093190659"
+
+var _ portfolio.OrderCorrector = (*OrderManager)(nil)
+
+// fakeTrader acknowledges every order as live; other Trader methods are not used
+type fakeTrader struct {
+	client.Trader
+	status string
+	open   []types.OpenOrder
+	orders map[string]types.OpenOrder
+	nextID string // Order ID of the next post; default 0xabc
+	reject bool   // Refuse posts with an error message
+
+	created    []types.OrderArgs
+	refreshErr error
+}
+
+func (f *fakeTrader) GetOpenOrders(params *types.OpenOrderParams) ([]types.OpenOrder, error) {
+	return f.open, nil
+}
+
+func (f *fakeTrader) GetOrder(orderID string) (*types.OpenOrder, error) {
+	if f.refreshErr != nil {
+		return nil, f.refreshErr
+	}
+	order := f.orders[orderID]
+	return &order, nil
+}
+
+func (f *fakeTrader) PostOrder(signedOrder *types.SignedOrder, orderType types.OrderType) (map[string]interface{}, error) {
+	if f.reject {
+		return map[string]interface{}{"success": false, "errorMsg": "not enough balance"}, nil
+	}
+	orderID := "0xabc"
+	if f.nextID != "" {
+		orderID = f.nextID
+	}
+	return map[string]interface{}{"success": true, "orderID": orderID, "status": f.status}, nil
+}
+
+func (f *fakeTrader) CreateOrder(orderArgs types.OrderArgs, options *types.CreateOrderOptions) (*types.SignedOrder, error) {
+	f.created = append(f.created, orderArgs)
+	usdc := int64(orderArgs.Price*orderArgs.Size*1e6 + 0.5)
+	shares := int64(orderArgs.Size*1e6 + 0.5)
+	return &types.SignedOrder{
+		TokenID:     orderArgs.TokenID,
+		Side:        orderArgs.Side,
+		MakerAmount: strconv.FormatInt(usdc, 10),
+		TakerAmount: strconv.FormatInt(shares, 10),
+	}, nil
+}
+
+func (f *fakeTrader) CancelOrder(orderID string) (map[string]interface{}, error) {
+	return map[string]interface{}{"canceled": []interface{}{orderID}}, nil
+}
+
+func testOrder() *types.SignedOrder {
+	// BUY 10 shares at 0.5
+	return &types.SignedOrder{TokenID: testToken, Side: types.BUY, MakerAmount: "5000000", TakerAmount: "10000000"}
+}
+
+func TestLifecycleFromLiveToFilled(t *testing.T) {
+	m := NewOrderManager(&fakeTrader{status: "live"})
+
+	order, err := m.SubmitSigned(testOrder(), types.GTC, "quote")
+	if err != nil {
+		t.Fatalf("Failed to submit order: %v", err)
+	}
+	if order.State != StateLive {
+		t.Fatalf("Expected state %s, got %s", StateLive, order.State)
+	}
+
+	m.ApplyFill(portfolio.Fill{TradeID: "t1", OrderID: "0xabc", Size: 4})
+	m.ApplyFill(portfolio.Fill{TradeID: "t1", OrderID: "0xabc", Size: 4}) // duplicate
+	current, _ := m.Order("0xabc")
+	if current.State != StatePartiallyFilled || current.SizeMatched != 4 {
+		t.Fatalf("Expected partial fill of 4, got %s / %f", current.State, current.SizeMatched)
+	}
+
+	exposure := m.Exposure()
+	if len(exposure) != 1 || exposure[0].BuySize != 6 || exposure[0].BuyNotional != 3 {
+		t.Errorf("Unexpected exposure: %+v", exposure)
+	}
+
+	m.ApplyFill(portfolio.Fill{TradeID: "t2", OrderID: "0xabc", Size: 6})
+	current, _ = m.Order("0xabc")
+	if current.State != StateFilled {
+		t.Errorf("Expected state %s, got %s", StateFilled, current.State)
+	}
+	if len(m.OpenOrders()) != 0 {
+		t.Error("Expected no open orders after fill")
+	}
+}
+
+func TestCancelledOrderIgnoresLateTransitions(t *testing.T) {
+	m := NewOrderManager(&fakeTrader{status: "live"})
+
+	if _, err := m.SubmitSigned(testOrder(), types.GTC, ""); err != nil {
+		t.Fatalf("Failed to submit order: %v", err)
+	}
+	if err := m.Cancel("0xabc"); err != nil {
+		t.Fatalf("Failed to cancel: %v", err)
+	}
+
+	m.mu.Lock()
+	m.transition(m.orders["0xabc"], StateLive)
+	m.mu.Unlock()
+
+	current, _ := m.Order("0xabc")
+	if current.State != StateCancelled {
+		t.Errorf("Expected cancelled order to stay cancelled, got %s", current.State)
+	}
+}
+
+func TestSyncAppliesServerState(t *testing.T) {
+	trader := &fakeTrader{status: "live"}
+	m := NewOrderManager(trader)
+	if _, err := m.SubmitSigned(testOrder(), types.GTC, ""); err != nil {
+		t.Fatalf("Failed to submit order: %v", err)
+	}
+
+	trader.open = []types.OpenOrder{{ID: "0xabc", Status: "LIVE", SizeMatched: "3", OriginalSize: "10"}}
+	if err := m.Sync(); err != nil {
+		t.Fatalf("Failed to sync: %v", err)
+	}
+	current, _ := m.Order("0xabc")
+	if current.State != StatePartiallyFilled || current.SizeMatched != 3 {
+		t.Fatalf("Expected partial fill of 3, got %s / %f", current.State, current.SizeMatched)
+	}
+
+	// No longer listed as open: the manager asks for the order and learns it filled
+	trader.open = nil
+	trader.orders = map[string]types.OpenOrder{"0xabc": {ID: "0xabc", Status: "MATCHED", SizeMatched: "10"}}
+	if err := m.Sync(); err != nil {
+		t.Fatalf("Failed to sync: %v", err)
+	}
+	current, _ = m.Order("0xabc")
+	if current.State != StateFilled {
+		t.Errorf("Expected state %s, got %s", StateFilled, current.State)
+	}
+}
+
+func TestRequotePostsRemainder(t *testing.T) {
+	trader := &fakeTrader{status: "live"}
+	m := NewOrderManager(trader)
+	if _, err := m.SubmitSigned(testOrder(), types.GTC, "quote"); err != nil {
+		t.Fatalf("Failed to submit order: %v", err)
+	}
+
+	m.ApplyOrderEvent(types.OrderEvent{ID: "0xabc", Type: types.OrderEventUpdate, SizeMatched: "2.5"})
+	if remaining, ok := m.Remaining("0xabc"); !ok || remaining != 7.5 {
+		t.Fatalf("Expected 7.5 remaining, got %v", remaining)
+	}
+
+	// Another share fills while the cancel is in flight
+	trader.orders = map[string]types.OpenOrder{"0xabc": {ID: "0xabc", Status: "CANCELED", SizeMatched: "3.5"}}
+	trader.nextID = "0xdef"
+	order, err := m.Requote("0xabc", 0.52, nil)
+	if err != nil {
+		t.Fatalf("Requote failed: %v", err)
+	}
+	if order.ID != "0xdef" || order.Size != 6.5 || order.Price != 0.52 || order.Tag != "quote" || order.Side != types.BUY {
+		t.Errorf("Unexpected requoted order %+v", order)
+	}
+	old, _ := m.Order("0xabc")
+	if old.State != StateCancelled || old.SizeMatched != 3.5 {
+		t.Errorf("Expected the original order cancelled with 3.5 matched, got %s / %v", old.State, old.SizeMatched)
+	}
+
+	m.ApplyOrderEvent(types.OrderEvent{ID: "0xdef", Type: types.OrderEventUpdate, SizeMatched: "6.5"})
+	if _, err := m.Requote("0xdef", 0.53, nil); err == nil {
+		t.Errorf("Expected a filled order to be rejected")
+	}
+
+	trader.nextID = "0x123"
+	if _, err := m.SubmitSigned(testOrder(), types.GTC, ""); err != nil {
+		t.Fatalf("Failed to submit order: %v", err)
+	}
+	trader.orders["0x123"] = types.OpenOrder{ID: "0x123", Status: "MATCHED", SizeMatched: "10"}
+	if _, err := m.Requote("0x123", 0.53, nil); !errors.Is(err, ErrNothingRemaining) {
+		t.Errorf("Expected ErrNothingRemaining, got %v", err)
+	}
+}
+
+func TestRequoteKeepsOrderArgs(t *testing.T) {
+	trader := &fakeTrader{status: "live"}
+	m := NewOrderManager(trader)
+	args := types.OrderArgs{TokenID: testToken, Price: 0.5, Size: 10, Side: types.BUY, FeeRateBps: 7, Nonce: 3, Expiration: 1900000000, Taker: "0xtaker"}
+	if _, err := m.Submit(args, nil, types.GTD, "quote"); err != nil {
+		t.Fatalf("Failed to submit order: %v", err)
+	}
+
+	trader.orders = map[string]types.OpenOrder{"0xabc": {ID: "0xabc", Status: "CANCELED", SizeMatched: "4"}}
+	trader.nextID = "0xdef"
+	if _, err := m.Requote("0xabc", 0.52, nil); err != nil {
+		t.Fatalf("Requote failed: %v", err)
+	}
+	want := args
+	want.Price = 0.52
+	want.Size = 6
+	if got := trader.created[1]; got != want {
+		t.Errorf("Expected requote args %+v, got %+v", want, got)
+	}
+
+	// Signed orders are requoted with the arguments they were signed with
+	signed := testOrder()
+	signed.Nonce = "5"
+	signed.FeeRateBps = "9"
+	trader.nextID = "0x123"
+	if _, err := m.SubmitSigned(signed, types.GTC, ""); err != nil {
+		t.Fatalf("Failed to submit order: %v", err)
+	}
+	trader.orders["0x123"] = types.OpenOrder{ID: "0x123", Status: "CANCELED"}
+	trader.nextID = "0x456"
+	if _, err := m.Requote("0x123", 0.51, nil); err != nil {
+		t.Fatalf("Requote failed: %v", err)
+	}
+	if got := trader.created[2]; got.Nonce != 5 || got.FeeRateBps != 9 || got.Size != 10 || got.Price != 0.51 {
+		t.Errorf("Unexpected requote args %+v", got)
+	}
+}
+
+func TestRequoteReportsCancelWhenRefreshFails(t *testing.T) {
+	trader := &fakeTrader{status: "live"}
+	m := NewOrderManager(trader)
+	if _, err := m.SubmitSigned(testOrder(), types.GTC, ""); err != nil {
+		t.Fatalf("Failed to submit order: %v", err)
+	}
+
+	trader.refreshErr = errors.New("HTTP 503")
+	if _, err := m.Requote("0xabc", 0.52, nil); !errors.Is(err, ErrNotReposted) {
+		t.Fatalf("Expected ErrNotReposted, got %v", err)
+	}
+	if order, _ := m.Order("0xabc"); order.State != StateCancelled {
+		t.Errorf("Expected the order to be cancelled, got %s", order.State)
+	}
+	if len(trader.created) != 0 {
+		t.Errorf("Expected nothing to be reposted, got %+v", trader.created)
+	}
+}
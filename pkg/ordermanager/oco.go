@@ -0,0 +1,336 @@
+package ordermanager
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+)
+
+// OCOGroup is a set of one-cancels-other orders: once one of them fills, the
+// others are cancelled
+type OCOGroup struct {
+	ID          string    `json:"id"`
+	OrderIDs    []string  `json:"order_ids"`
+	Threshold   float64   `json:"threshold"`              // Matched fraction of an order's size that triggers the group; 1 waits for a full fill
+	TriggeredBy string    `json:"triggered_by,omitempty"` // The order that reached the threshold
+	TriggeredAt time.Time `json:"triggered_at"`
+	Cancelled   []string  `json:"cancelled,omitempty"` // Orders cancelled by the group
+	CreatedAt   time.Time `json:"created_at"`
+}
+
+// Triggered reports whether an order of the group reached the threshold
+func (g *OCOGroup) Triggered() bool {
+	return g.TriggeredBy != ""
+}
+
+// OCO cancels the remaining orders of a group as soon as one of them fills.
+// It is driven by the order manager's updates: register Observe with
+// OrderManager.OnUpdate and feed the manager from the user event stream
+// (ApplyOrderEvent, ApplyTrade) or from Sync.
+type OCO struct {
+	manager *OrderManager
+
+	mu        sync.Mutex
+	groups    map[string]*OCOGroup
+	byOrder   map[string]string
+	onTrigger func(group OCOGroup)
+
+	// Persistence, set by SetStore
+	store   Store
+	onError func(err error)
+}
+
+// NewOCO creates OCO groups over the orders of manager
+func NewOCO(manager *OrderManager) *OCO {
+	return &OCO{
+		manager: manager,
+		groups:  make(map[string]*OCOGroup),
+		byOrder: make(map[string]string),
+	}
+}
+
+// SetStore persists every group to store after each change. Use a store separate
+// from the order manager's: records are keyed by group ID. onError, if set, is
+// called when a write or a cancel fails.
+func (o *OCO) SetStore(store Store, onError func(err error)) {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+	o.store = store
+	o.onError = onError
+}
+
+// OnTrigger registers a callback invoked with a copy of a group after it
+// triggered and the cancels of its other orders were sent
+func (o *OCO) OnTrigger(callback func(group OCOGroup)) {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+	o.onTrigger = callback
+}
+
+// Add groups managed orders so that the first one to match threshold of its
+// size (default 1, a full fill) cancels the others. An order belongs to at most
+// one group. Orders that already reached the threshold trigger the group at once.
+func (o *OCO) Add(groupID string, orderIDs []string, threshold float64) error {
+	if groupID == "" {
+		return fmt.Errorf("group ID is required")
+	}
+	if len(orderIDs) < 2 {
+		return fmt.Errorf("an OCO group needs at least 2 orders, got %d", len(orderIDs))
+	}
+	if threshold <= 0 {
+		threshold = 1
+	}
+	if threshold > 1 {
+		return fmt.Errorf("threshold must be at most 1, got %g", threshold)
+	}
+
+	orders := make([]ManagedOrder, 0, len(orderIDs))
+	for _, id := range orderIDs {
+		order, exists := o.manager.Order(id)
+		if !exists {
+			return fmt.Errorf("order %s is not managed", id)
+		}
+		orders = append(orders, order)
+	}
+
+	o.mu.Lock()
+	if _, exists := o.groups[groupID]; exists {
+		o.mu.Unlock()
+		return fmt.Errorf("OCO group %s already exists", groupID)
+	}
+	for _, id := range orderIDs {
+		if other, exists := o.byOrder[id]; exists {
+			o.mu.Unlock()
+			return fmt.Errorf("order %s already belongs to OCO group %s", id, other)
+		}
+	}
+	group := &OCOGroup{
+		ID:        groupID,
+		OrderIDs:  append([]string(nil), orderIDs...),
+		Threshold: threshold,
+		CreatedAt: time.Now(),
+	}
+	o.groups[groupID] = group
+	for _, id := range orderIDs {
+		o.byOrder[id] = groupID
+	}
+	o.mu.Unlock()
+
+	o.save(group)
+	for _, order := range orders {
+		o.Observe(order)
+	}
+	return nil
+}
+
+// Remove forgets a group without cancelling its orders
+func (o *OCO) Remove(groupID string) error {
+	o.mu.Lock()
+	group, exists := o.groups[groupID]
+	if exists {
+		delete(o.groups, groupID)
+		for _, id := range group.OrderIDs {
+			delete(o.byOrder, id)
+		}
+	}
+	store := o.store
+	o.mu.Unlock()
+
+	if !exists {
+		return fmt.Errorf("OCO group %s not found", groupID)
+	}
+	if store != nil {
+		if err := store.Delete(groupID); err != nil {
+			return fmt.Errorf("failed to delete OCO group %s: %w", groupID, err)
+		}
+	}
+	return nil
+}
+
+// Group returns a copy of a group
+func (o *OCO) Group(groupID string) (OCOGroup, bool) {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+
+	group, exists := o.groups[groupID]
+	if !exists {
+		return OCOGroup{}, false
+	}
+	return copyGroup(group), true
+}
+
+// Groups returns copies of every group, oldest first
+func (o *OCO) Groups() []OCOGroup {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+
+	groups := make([]OCOGroup, 0, len(o.groups))
+	for _, group := range o.groups {
+		groups = append(groups, copyGroup(group))
+	}
+	sort.Slice(groups, func(i, j int) bool {
+		return groups[i].CreatedAt.Before(groups[j].CreatedAt)
+	})
+	return groups
+}
+
+// Observe triggers the group of an order that reached its threshold. Its
+// signature matches OrderManager.OnUpdate.
+func (o *OCO) Observe(order ManagedOrder) {
+	o.mu.Lock()
+	groupID, exists := o.byOrder[order.ID]
+	if !exists {
+		o.mu.Unlock()
+		return
+	}
+	group := o.groups[groupID]
+	if group.Triggered() || order.Size <= 0 || order.SizeMatched <= sizeEpsilon ||
+		order.SizeMatched < group.Threshold*order.Size-sizeEpsilon {
+		o.mu.Unlock()
+		return
+	}
+	group.TriggeredBy = order.ID
+	group.TriggeredAt = time.Now()
+	o.mu.Unlock()
+
+	o.save(group)
+	o.cancelOthers(groupID)
+
+	o.mu.Lock()
+	callback := o.onTrigger
+	snapshot := copyGroup(group)
+	o.mu.Unlock()
+	if callback != nil {
+		callback(snapshot)
+	}
+}
+
+// Recover loads the groups saved in the store and finishes any cancels that
+// were interrupted. Recover the order manager first so the group's orders are
+// known. It returns the number of groups loaded.
+func (o *OCO) Recover() (int, error) {
+	o.mu.Lock()
+	store := o.store
+	o.mu.Unlock()
+	if store == nil {
+		return 0, fmt.Errorf("no OCO store configured")
+	}
+
+	records, err := store.Load()
+	if err != nil {
+		return 0, fmt.Errorf("failed to load OCO groups: %w", err)
+	}
+
+	o.mu.Lock()
+	for id, data := range records {
+		var group OCOGroup
+		if err := json.Unmarshal(data, &group); err != nil {
+			o.mu.Unlock()
+			return 0, fmt.Errorf("failed to decode OCO group %s: %w", id, err)
+		}
+		o.groups[id] = &group
+		for _, orderID := range group.OrderIDs {
+			o.byOrder[orderID] = id
+		}
+	}
+	o.mu.Unlock()
+
+	return len(records), o.Check()
+}
+
+// Check re-evaluates every group against the manager's orders, triggering the
+// groups whose fills were missed and retrying cancels that failed
+func (o *OCO) Check() error {
+	var errs error
+	for _, group := range o.Groups() {
+		if group.Triggered() {
+			errs = errors.Join(errs, o.cancelOthers(group.ID))
+			continue
+		}
+		for _, id := range group.OrderIDs {
+			if order, exists := o.manager.Order(id); exists {
+				o.Observe(order)
+			}
+		}
+	}
+	return errs
+}
+
+// cancelOthers cancels the open orders of a triggered group other than the one
+// that triggered it
+func (o *OCO) cancelOthers(groupID string) error {
+	o.mu.Lock()
+	group, exists := o.groups[groupID]
+	if !exists {
+		o.mu.Unlock()
+		return nil
+	}
+	done := make(map[string]bool, len(group.Cancelled)+1)
+	done[group.TriggeredBy] = true
+	for _, id := range group.Cancelled {
+		done[id] = true
+	}
+	others := make([]string, 0, len(group.OrderIDs))
+	for _, id := range group.OrderIDs {
+		if !done[id] {
+			others = append(others, id)
+		}
+	}
+	o.mu.Unlock()
+
+	var errs error
+	cancelled := make([]string, 0, len(others))
+	for _, id := range others {
+		if order, exists := o.manager.Order(id); exists && order.State.IsTerminal() {
+			continue
+		}
+		if err := o.manager.Cancel(id); err != nil {
+			errs = errors.Join(errs, err)
+			continue
+		}
+		cancelled = append(cancelled, id)
+	}
+
+	o.mu.Lock()
+	group.Cancelled = append(group.Cancelled, cancelled...)
+	onError := o.onError
+	o.mu.Unlock()
+
+	o.save(group)
+	if errs != nil {
+		errs = fmt.Errorf("OCO group %s: %w", groupID, errs)
+		if onError != nil {
+			onError(errs)
+		}
+	}
+	return errs
+}
+
+// save persists a group when a store is configured
+func (o *OCO) save(group *OCOGroup) {
+	o.mu.Lock()
+	store, onError := o.store, o.onError
+	record, err := json.Marshal(group)
+	o.mu.Unlock()
+
+	if store == nil {
+		return
+	}
+	if err == nil {
+		err = store.Put(group.ID, record)
+	}
+	if err != nil && onError != nil {
+		onError(fmt.Errorf("failed to save OCO group %s: %w", group.ID, err))
+	}
+}
+
+// copyGroup copies a group, including its slices
+func copyGroup(group *OCOGroup) OCOGroup {
+	copied := *group
+	copied.OrderIDs = append([]string(nil), group.OrderIDs...)
+	copied.Cancelled = append([]string(nil), group.Cancelled...)
+	return copied
+}
@@ -0,0 +1,115 @@
+package ordermanager
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/MaDal776/polymarket-go-client/pkg/types"
+)
+
+// cancelTrader records cancels and can fail them
+type cancelTrader struct {
+	fakeTrader
+	cancelled []string
+	failures  map[string]bool
+}
+
+func (f *cancelTrader) CancelOrder(orderID string) (map[string]interface{}, error) {
+	if f.failures[orderID] {
+		return nil, errors.New("HTTP 503")
+	}
+	f.cancelled = append(f.cancelled, orderID)
+	return f.fakeTrader.CancelOrder(orderID)
+}
+
+// submitGroup submits one order per ID
+func submitGroup(t *testing.T, m *OrderManager, trader *cancelTrader, ids ...string) {
+	t.Helper()
+	for _, id := range ids {
+		trader.nextID = id
+		if _, err := m.SubmitSigned(testOrder(), types.GTC, ""); err != nil {
+			t.Fatalf("Failed to submit order: %v", err)
+		}
+	}
+}
+
+func TestOCOCancelsOthersOnFill(t *testing.T) {
+	trader := &cancelTrader{fakeTrader: fakeTrader{status: "live"}}
+	m := NewOrderManager(trader)
+	submitGroup(t, m, trader, "take-profit", "stop", "other")
+
+	oco := NewOCO(m)
+	m.OnUpdate(oco.Observe)
+	triggered := make([]OCOGroup, 0)
+	oco.OnTrigger(func(group OCOGroup) { triggered = append(triggered, group) })
+	store := NewMemoryStore()
+	oco.SetStore(store, nil)
+
+	if err := oco.Add("exit", []string{"take-profit", "stop"}, 0.5); err != nil {
+		t.Fatalf("Add failed: %v", err)
+	}
+	if err := oco.Add("again", []string{"stop", "other"}, 0); err == nil {
+		t.Errorf("Expected an order in two groups to be rejected")
+	}
+
+	m.ApplyOrderEvent(types.OrderEvent{ID: "stop", Type: types.OrderEventUpdate, SizeMatched: "4"})
+	if len(trader.cancelled) != 0 {
+		t.Fatalf("Expected no cancel below the threshold, got %v", trader.cancelled)
+	}
+	m.ApplyOrderEvent(types.OrderEvent{ID: "stop", Type: types.OrderEventUpdate, SizeMatched: "5"})
+	if len(trader.cancelled) != 1 || trader.cancelled[0] != "take-profit" {
+		t.Fatalf("Expected take-profit cancelled, got %v", trader.cancelled)
+	}
+	if order, _ := m.Order("take-profit"); order.State != StateCancelled {
+		t.Errorf("Expected take-profit cancelled, got %s", order.State)
+	}
+	if len(triggered) != 1 || triggered[0].TriggeredBy != "stop" || len(triggered[0].Cancelled) != 1 {
+		t.Errorf("Unexpected trigger callbacks %+v", triggered)
+	}
+
+	// Further fills do not trigger the group again
+	m.ApplyOrderEvent(types.OrderEvent{ID: "stop", Type: types.OrderEventUpdate, SizeMatched: "10"})
+	if len(trader.cancelled) != 1 || len(triggered) != 1 {
+		t.Errorf("Expected a single trigger, got %v cancels", trader.cancelled)
+	}
+
+	recovered := NewOCO(m)
+	recovered.SetStore(store, nil)
+	if n, err := recovered.Recover(); n != 1 || err != nil {
+		t.Fatalf("Expected 1 group recovered, got %d, %v", n, err)
+	}
+	if group, _ := recovered.Group("exit"); group.TriggeredBy != "stop" || group.Threshold != 0.5 {
+		t.Errorf("Unexpected recovered group %+v", group)
+	}
+}
+
+func TestOCORetriesFailedCancels(t *testing.T) {
+	trader := &cancelTrader{fakeTrader: fakeTrader{status: "live"}, failures: map[string]bool{"b": true}}
+	m := NewOrderManager(trader)
+	submitGroup(t, m, trader, "a", "b")
+
+	oco := NewOCO(m)
+	m.OnUpdate(oco.Observe)
+	var reported error
+	store := NewMemoryStore()
+	oco.SetStore(store, func(err error) { reported = err })
+	if err := oco.Add("pair", []string{"a", "b"}, 1); err != nil {
+		t.Fatalf("Add failed: %v", err)
+	}
+
+	m.ApplyOrderEvent(types.OrderEvent{ID: "a", Type: types.OrderEventUpdate, SizeMatched: "10"})
+	if reported == nil {
+		t.Fatalf("Expected the failed cancel to be reported")
+	}
+
+	// After a restart the saved group finishes the cancel
+	trader.failures = nil
+	recovered := NewOCO(m)
+	recovered.SetStore(store, nil)
+	if _, err := recovered.Recover(); err != nil {
+		t.Fatalf("Recover failed: %v", err)
+	}
+	if group, _ := recovered.Group("pair"); len(group.Cancelled) != 1 || group.Cancelled[0] != "b" {
+		t.Errorf("Expected b cancelled on recovery, got %+v", group)
+	}
+}
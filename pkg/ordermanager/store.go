@@ -0,0 +1,400 @@
+package ordermanager
+
+import (
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"sync"
+	"time"
+
+	bolt "go.etcd.io/bbolt"
+
+	"github.com/MaDal776/polymarket-go-client/pkg/client"
+	"github.com/MaDal776/polymarket-go-client/pkg/types"
+)
+
+// Store persists order records across restarts. Records are opaque JSON keyed by order ID.
+type Store interface {
+	Put(orderID string, record []byte) error
+	Delete(orderID string) error
+	Load() (map[string][]byte, error)
+}
+
+// orderRecord is what the manager persists: the order plus the bookkeeping
+// needed to keep counting fills correctly after a restart
+type orderRecord struct {
+	ManagedOrder
+	ReportedMatched float64  `json:"reported_matched"`
+	TradeMatched    float64  `json:"trade_matched"`
+	Fills           []string `json:"fills,omitempty"`
+	Posting         bool     `json:"posting,omitempty"` // Saved before the post was answered
+
+	Flags types.OrderFlags `json:"flags"` // The signed order's client-side settings, which its JSON leaves out
+}
+
+// orderIdentifier is implemented by traders that can tell a signed order's
+// exchange ID, its order hash, before posting it. An empty ID means unknown.
+type orderIdentifier interface {
+	OrderID(signedOrder *types.SignedOrder) (string, error)
+}
+
+// SetStore persists every order with an exchange ID to store after each change.
+// When the trader can hash orders, as *client.ClobClient can, an order is also
+// saved under its hash before it is posted, and is not posted if that write
+// fails, so an order whose post is interrupted by a crash is still recovered.
+// onError, if set, is called when a later write fails; the order is still tracked in memory.
+func (m *OrderManager) SetStore(store Store, onError func(err error)) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.store = store
+	m.onStoreError = onError
+}
+
+// Recover loads the orders saved in the store and re-syncs them against the
+// exchange, so open orders, tags and fills survive a restart. It returns the
+// number of orders loaded.
+func (m *OrderManager) Recover() (int, error) {
+	m.mu.Lock()
+	store := m.store
+	m.mu.Unlock()
+	if store == nil {
+		return 0, fmt.Errorf("no order store configured")
+	}
+
+	records, err := store.Load()
+	if err != nil {
+		return 0, fmt.Errorf("failed to load orders: %w", err)
+	}
+
+	posting := make([]string, 0)
+	m.mu.Lock()
+	for id, data := range records {
+		var record orderRecord
+		if err := json.Unmarshal(data, &record); err != nil {
+			m.mu.Unlock()
+			return 0, fmt.Errorf("failed to decode order %s: %w", id, err)
+		}
+		order := record.ManagedOrder
+		order.ID = id
+		order.reportedMatched = record.ReportedMatched
+		order.tradeMatched = record.TradeMatched
+		order.fills = make(map[string]bool, len(record.Fills))
+		for _, fill := range record.Fills {
+			order.fills[fill] = true
+		}
+		if order.Signed != nil {
+			order.Signed.OrderFlags = record.Flags
+		}
+		m.orders[id] = &order
+		if record.Posting {
+			posting = append(posting, id)
+		}
+	}
+	m.mu.Unlock()
+
+	for _, id := range posting {
+		if err := m.resolvePosting(id); err != nil {
+			return len(records), err
+		}
+	}
+	if err := m.Sync(); err != nil {
+		return len(records), fmt.Errorf("failed to sync recovered orders: %w", err)
+	}
+	return len(records), nil
+}
+
+// Prune forgets terminal orders last updated before cutoff, removing them from
+// memory and from the store, and forgets rejected orders recorded before cutoff.
+// It returns the number of orders pruned.
+func (m *OrderManager) Prune(cutoff time.Time) (int, error) {
+	m.mu.Lock()
+	pruned := make([]string, 0)
+	for id, order := range m.orders {
+		if order.State.IsTerminal() && order.UpdatedAt.Before(cutoff) {
+			delete(m.orders, id)
+			pruned = append(pruned, id)
+		}
+	}
+	// Rejected orders were never stored; they only need forgetting
+	kept := m.rejected[:0]
+	for _, order := range m.rejected {
+		if order.UpdatedAt.Before(cutoff) {
+			continue
+		}
+		kept = append(kept, order)
+	}
+	for i := len(kept); i < len(m.rejected); i++ {
+		m.rejected[i] = nil
+	}
+	rejected := len(m.rejected) - len(kept)
+	m.rejected = kept
+	store := m.store
+	m.mu.Unlock()
+
+	if store != nil {
+		for _, id := range pruned {
+			if err := m.deleteRecord(store, id); err != nil {
+				return len(pruned) + rejected, fmt.Errorf("failed to delete order %s: %w", id, err)
+			}
+		}
+	}
+	return len(pruned) + rejected, nil
+}
+
+// resolvePosting looks up an order saved before its post was answered. An
+// order the exchange has is tracked from its state there; one it does not
+// have never reached it and is forgotten. The record is kept when the
+// exchange cannot be asked.
+func (m *OrderManager) resolvePosting(orderID string) error {
+	openOrder, err := m.trader.GetOrder(orderID)
+	if err == nil && openOrder != nil && openOrder.ID != "" {
+		m.ApplyOpenOrder(*openOrder)
+		return nil
+	}
+	if err != nil && !client.IsRejected(err) {
+		return fmt.Errorf("failed to look up order %s: %w", orderID, err)
+	}
+
+	m.mu.Lock()
+	delete(m.orders, orderID)
+	store := m.store
+	m.mu.Unlock()
+	if err := m.deleteRecord(store, orderID); err != nil {
+		return fmt.Errorf("failed to delete order %s: %w", orderID, err)
+	}
+	return nil
+}
+
+// savePending saves an order under its order hash before it is posted. It
+// returns the ID saved under, empty when there is no store or the trader
+// cannot hash orders.
+func (m *OrderManager) savePending(order *ManagedOrder) (string, error) {
+	m.mu.Lock()
+	store := m.store
+	m.mu.Unlock()
+	identifier, ok := m.trader.(orderIdentifier)
+	if store == nil || !ok {
+		return "", nil
+	}
+	orderID, err := identifier.OrderID(order.Signed)
+	if err != nil {
+		return "", fmt.Errorf("failed to hash order: %w", err)
+	}
+	if orderID == "" {
+		return "", nil
+	}
+
+	m.mu.Lock()
+	order.version++
+	version := order.version
+	pending := *order
+	pending.ID = orderID
+	record, err := encodeRecord(&pending, true)
+	m.mu.Unlock()
+	if err == nil {
+		err = m.saveRecord(store, orderID, version, record)
+	}
+	if err != nil {
+		return "", fmt.Errorf("failed to save order %s before posting: %w", orderID, err)
+	}
+	return orderID, nil
+}
+
+// forgetPending deletes the record savePending wrote for an order that was
+// not posted, or was posted under another ID
+func (m *OrderManager) forgetPending(orderID string) {
+	if orderID == "" {
+		return
+	}
+	m.mu.Lock()
+	store, onStoreError := m.store, m.onStoreError
+	m.mu.Unlock()
+	if err := m.deleteRecord(store, orderID); err != nil && onStoreError != nil {
+		onStoreError(fmt.Errorf("failed to delete order %s: %w", orderID, err))
+	}
+}
+
+// saveRecord writes version of an order's record, unless a later version was
+// already written. Records are encoded under m.mu but written after it is
+// released, so two updates of an order can reach here out of order.
+func (m *OrderManager) saveRecord(store Store, orderID string, version uint64, record []byte) error {
+	m.storeMu.Lock()
+	defer m.storeMu.Unlock()
+	if version <= m.saved[orderID] {
+		return nil
+	}
+	m.saved[orderID] = version
+	return store.Put(orderID, record)
+}
+
+// deleteRecord removes an order's record
+func (m *OrderManager) deleteRecord(store Store, orderID string) error {
+	m.storeMu.Lock()
+	defer m.storeMu.Unlock()
+	delete(m.saved, orderID)
+	if store == nil {
+		return nil
+	}
+	return store.Delete(orderID)
+}
+
+// encodeRecord serializes an order for the store, marked as posting when it
+// is saved before the post is answered. Callers hold m.mu.
+func encodeRecord(order *ManagedOrder, posting bool) ([]byte, error) {
+	record := orderRecord{
+		ManagedOrder:    copyOrder(order),
+		ReportedMatched: order.reportedMatched,
+		TradeMatched:    order.tradeMatched,
+		Posting:         posting,
+	}
+	for fill := range order.fills {
+		record.Fills = append(record.Fills, fill)
+	}
+	if order.Signed != nil {
+		record.Flags = order.Signed.OrderFlags
+	}
+	return json.Marshal(record)
+}
+
+// MemoryStore is an in-memory Store, useful in tests
+type MemoryStore struct {
+	mu      sync.Mutex
+	records map[string][]byte
+}
+
+// NewMemoryStore creates an empty in-memory store
+func NewMemoryStore() *MemoryStore {
+	return &MemoryStore{records: make(map[string][]byte)}
+}
+
+// Put saves a record
+func (s *MemoryStore) Put(orderID string, record []byte) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.records[orderID] = append([]byte(nil), record...)
+	return nil
+}
+
+// Delete removes a record
+func (s *MemoryStore) Delete(orderID string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	delete(s.records, orderID)
+	return nil
+}
+
+// Load returns a copy of every record
+func (s *MemoryStore) Load() (map[string][]byte, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	records := make(map[string][]byte, len(s.records))
+	for id, record := range s.records {
+		records[id] = append([]byte(nil), record...)
+	}
+	return records, nil
+}
+
+// ordersBucket holds one key per order ID
+var ordersBucket = []byte("orders")
+
+// BoltStore is a Store backed by a BoltDB file
+type BoltStore struct {
+	db *bolt.DB
+}
+
+// OpenBoltStore opens or creates the BoltDB file at path. Only one process may open it at a time.
+func OpenBoltStore(path string) (*BoltStore, error) {
+	db, err := bolt.Open(path, 0600, &bolt.Options{Timeout: 5 * time.Second})
+	if err != nil {
+		return nil, fmt.Errorf("failed to open order store: %w", err)
+	}
+	err = db.Update(func(tx *bolt.Tx) error {
+		_, err := tx.CreateBucketIfNotExists(ordersBucket)
+		return err
+	})
+	if err != nil {
+		db.Close()
+		return nil, fmt.Errorf("failed to initialize order store: %w", err)
+	}
+	return &BoltStore{db: db}, nil
+}
+
+// Put saves a record
+func (s *BoltStore) Put(orderID string, record []byte) error {
+	return s.db.Update(func(tx *bolt.Tx) error {
+		return tx.Bucket(ordersBucket).Put([]byte(orderID), record)
+	})
+}
+
+// Delete removes a record
+func (s *BoltStore) Delete(orderID string) error {
+	return s.db.Update(func(tx *bolt.Tx) error {
+		return tx.Bucket(ordersBucket).Delete([]byte(orderID))
+	})
+}
+
+// Load returns every record
+func (s *BoltStore) Load() (map[string][]byte, error) {
+	records := make(map[string][]byte)
+	err := s.db.View(func(tx *bolt.Tx) error {
+		return tx.Bucket(ordersBucket).ForEach(func(k, v []byte) error {
+			// Values are only valid during the transaction
+			records[string(k)] = append([]byte(nil), v...)
+			return nil
+		})
+	})
+	return records, err
+}
+
+// Close closes the database file
+func (s *BoltStore) Close() error {
+	return s.db.Close()
+}
+
+// SQLiteStore is a Store backed by a table in a SQLite database. The caller
+// opens the database with the driver of their choice, e.g.
+// github.com/mattn/go-sqlite3 ("sqlite3") or modernc.org/sqlite ("sqlite").
+type SQLiteStore struct {
+	db *sql.DB
+}
+
+// NewSQLiteStore keeps orders in db, creating its orders table if needed
+func NewSQLiteStore(db *sql.DB) (*SQLiteStore, error) {
+	_, err := db.Exec(`CREATE TABLE IF NOT EXISTS orders (id TEXT PRIMARY KEY, record BLOB NOT NULL)`)
+	if err != nil {
+		return nil, fmt.Errorf("failed to initialize order store: %w", err)
+	}
+	return &SQLiteStore{db: db}, nil
+}
+
+// Put saves a record
+func (s *SQLiteStore) Put(orderID string, record []byte) error {
+	_, err := s.db.Exec(`INSERT INTO orders (id, record) VALUES (?, ?) ON CONFLICT(id) DO UPDATE SET record = excluded.record`, orderID, record)
+	return err
+}
+
+// Delete removes a record
+func (s *SQLiteStore) Delete(orderID string) error {
+	_, err := s.db.Exec(`DELETE FROM orders WHERE id = ?`, orderID)
+	return err
+}
+
+// Load returns every record
+func (s *SQLiteStore) Load() (map[string][]byte, error) {
+	rows, err := s.db.Query(`SELECT id, record FROM orders`)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	records := make(map[string][]byte)
+	for rows.Next() {
+		var id string
+		var record []byte
+		if err := rows.Scan(&id, &record); err != nil {
+			return nil, err
+		}
+		records[id] = record
+	}
+	return records, rows.Err()
+}
@@ -0,0 +1,52 @@
+//go:build cgo
+
+package ordermanager
+
+import (
+	"database/sql"
+	"path/filepath"
+	"testing"
+
+	_ "github.com/mattn/go-sqlite3"
+)
+
+// The SQLite store is tested against github.com/mattn/go-sqlite3, which needs
+// cgo; without it this file is skipped.
+
+func TestSQLiteStore(t *testing.T) {
+	db, err := sql.Open("sqlite3", filepath.Join(t.TempDir(), "orders.db"))
+	if err != nil {
+		t.Fatalf("Failed to open database: %v", err)
+	}
+	defer db.Close()
+
+	store, err := NewSQLiteStore(db)
+	if err != nil {
+		t.Fatalf("Failed to create store: %v", err)
+	}
+	if err := store.Put("0xabc", []byte(`{"id":"0xabc"}`)); err != nil {
+		t.Fatalf("Put failed: %v", err)
+	}
+	if err := store.Put("0xabc", []byte(`{"id":"0xabc","tag":"quote"}`)); err != nil {
+		t.Fatalf("Put failed: %v", err)
+	}
+	if err := store.Put("0xdef", []byte(`{"id":"0xdef"}`)); err != nil {
+		t.Fatalf("Put failed: %v", err)
+	}
+	if err := store.Delete("0xdef"); err != nil {
+		t.Fatalf("Delete failed: %v", err)
+	}
+
+	// The table survives creating the store again
+	reopened, err := NewSQLiteStore(db)
+	if err != nil {
+		t.Fatalf("Failed to reopen store: %v", err)
+	}
+	records, err := reopened.Load()
+	if err != nil {
+		t.Fatalf("Load failed: %v", err)
+	}
+	if len(records) != 1 || string(records["0xabc"]) != `{"id":"0xabc","tag":"quote"}` {
+		t.Errorf("Unexpected records %v", records)
+	}
+}
@@ -0,0 +1,234 @@
+package ordermanager
+
+import (
+	"encoding/json"
+	"errors"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/MaDal776/polymarket-go-client/pkg/client"
+	"github.com/MaDal776/polymarket-go-client/pkg/portfolio"
+	"github.com/MaDal776/polymarket-go-client/pkg/types"
+)
+
+func TestRecoverRestoresOrders(t *testing.T) {
+	store := NewMemoryStore()
+	m := NewOrderManager(&fakeTrader{status: "live"})
+	m.SetStore(store, func(err error) { t.Errorf("Store error: %v", err) })
+
+	if _, err := m.SubmitSigned(testOrder(), types.GTC, "quote"); err != nil {
+		t.Fatalf("Failed to submit order: %v", err)
+	}
+	m.ApplyFill(portfolio.Fill{TradeID: "t1", OrderID: "0xabc", Size: 4})
+
+	// A restarted process sees the order still resting on the book
+	trader := &fakeTrader{open: []types.OpenOrder{{ID: "0xabc", Status: "LIVE", SizeMatched: "4"}}}
+	restarted := NewOrderManager(trader)
+	restarted.SetStore(store, nil)
+	loaded, err := restarted.Recover()
+	if err != nil {
+		t.Fatalf("Recover failed: %v", err)
+	}
+	if loaded != 1 {
+		t.Fatalf("Loaded %d orders, want 1", loaded)
+	}
+
+	order, ok := restarted.Order("0xabc")
+	if !ok || order.Tag != "quote" || order.State != StatePartiallyFilled || order.SizeMatched != 4 {
+		t.Fatalf("Unexpected recovered order %+v", order)
+	}
+
+	// The fill seen before the restart is not counted twice
+	restarted.ApplyFill(portfolio.Fill{TradeID: "t1", OrderID: "0xabc", Size: 4})
+	if order, _ := restarted.Order("0xabc"); order.SizeMatched != 4 {
+		t.Errorf("Replayed fill counted again: matched %v", order.SizeMatched)
+	}
+
+	if err := restarted.Cancel("0xabc"); err != nil {
+		t.Fatalf("Cancel failed: %v", err)
+	}
+	pruned, err := restarted.Prune(time.Now().Add(time.Second))
+	if err != nil || pruned != 1 {
+		t.Fatalf("Pruned %d orders (%v), want 1", pruned, err)
+	}
+	if records, _ := store.Load(); len(records) != 0 {
+		t.Errorf("Expected pruned order to be deleted, %d records left", len(records))
+	}
+}
+
+func TestPruneForgetsRejectedOrders(t *testing.T) {
+	trader := &fakeTrader{reject: true}
+	m := NewOrderManager(trader)
+	if _, err := m.SubmitSigned(testOrder(), types.GTC, ""); err == nil {
+		t.Fatalf("Expected the post to be rejected")
+	}
+	time.Sleep(time.Millisecond)
+	cutoff := time.Now()
+	time.Sleep(time.Millisecond)
+	if _, err := m.SubmitSigned(testOrder(), types.GTC, ""); err == nil {
+		t.Fatalf("Expected the post to be rejected")
+	}
+
+	pruned, err := m.Prune(cutoff)
+	if err != nil || pruned != 1 {
+		t.Fatalf("Pruned %d orders (%v), want 1", pruned, err)
+	}
+	if orders := m.Orders(); len(orders) != 1 || orders[0].State != StateRejected {
+		t.Errorf("Expected the later rejection to be kept, got %+v", orders)
+	}
+}
+
+func TestBoltStore(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "orders.db")
+	store, err := OpenBoltStore(path)
+	if err != nil {
+		t.Fatalf("Failed to open store: %v", err)
+	}
+	if err := store.Put("0xabc", []byte(`{"id":"0xabc"}`)); err != nil {
+		t.Fatalf("Put failed: %v", err)
+	}
+	if err := store.Put("0xdef", []byte(`{"id":"0xdef"}`)); err != nil {
+		t.Fatalf("Put failed: %v", err)
+	}
+	if err := store.Delete("0xdef"); err != nil {
+		t.Fatalf("Delete failed: %v", err)
+	}
+	store.Close()
+
+	reopened, err := OpenBoltStore(path)
+	if err != nil {
+		t.Fatalf("Failed to reopen store: %v", err)
+	}
+	defer reopened.Close()
+	records, err := reopened.Load()
+	if err != nil {
+		t.Fatalf("Load failed: %v", err)
+	}
+	if len(records) != 1 || string(records["0xabc"]) != `{"id":"0xabc"}` {
+		t.Errorf("Unexpected records %v", records)
+	}
+}
+
+// hashingTrader knows an order's ID before posting it and checks it was saved
+type hashingTrader struct {
+	fakeTrader
+	store   *MemoryStore
+	postErr error
+	saved   bool // A posting record was in the store during the post
+}
+
+func (h *hashingTrader) OrderID(signedOrder *types.SignedOrder) (string, error) {
+	return "0xabc", nil
+}
+
+func (h *hashingTrader) PostOrder(signedOrder *types.SignedOrder, orderType types.OrderType) (map[string]interface{}, error) {
+	records, _ := h.store.Load()
+	var record orderRecord
+	if data, ok := records["0xabc"]; ok && json.Unmarshal(data, &record) == nil {
+		h.saved = record.Posting && record.State == StatePending
+	}
+	if h.postErr != nil {
+		return nil, h.postErr
+	}
+	return h.fakeTrader.PostOrder(signedOrder, orderType)
+}
+
+func TestOrderIsSavedBeforePosting(t *testing.T) {
+	store := NewMemoryStore()
+	trader := &hashingTrader{fakeTrader: fakeTrader{status: "live"}, store: store, postErr: errors.New("connection reset")}
+	m := NewOrderManager(trader)
+	m.SetStore(store, func(err error) { t.Errorf("Store error: %v", err) })
+
+	if _, err := m.SubmitSigned(testOrder(), types.GTC, "quote"); err == nil {
+		t.Fatal("Expected the post to fail")
+	}
+	if !trader.saved {
+		t.Fatal("Expected the order to be saved under its hash before posting")
+	}
+
+	// The post may have reached the exchange, which did take the order
+	restarted := NewOrderManager(&fakeTrader{orders: map[string]types.OpenOrder{"0xabc": {ID: "0xabc", Status: "LIVE"}}})
+	restarted.SetStore(store, nil)
+	if _, err := restarted.Recover(); err != nil {
+		t.Fatalf("Recover failed: %v", err)
+	}
+	if order, ok := restarted.Order("0xabc"); !ok || order.State != StateLive || order.Tag != "quote" {
+		t.Fatalf("Expected the interrupted order to be recovered live, got %+v", order)
+	}
+
+	// An order the exchange never took is forgotten on recovery
+	lost := NewMemoryStore()
+	trader = &hashingTrader{fakeTrader: fakeTrader{status: "live"}, store: lost, postErr: errors.New("connection reset")}
+	m = NewOrderManager(trader)
+	m.SetStore(lost, nil)
+	m.SubmitSigned(testOrder(), types.GTC, "")
+	restarted = NewOrderManager(&fakeTrader{})
+	restarted.SetStore(lost, nil)
+	if _, err := restarted.Recover(); err != nil {
+		t.Fatalf("Recover failed: %v", err)
+	}
+	if _, ok := restarted.Order("0xabc"); ok {
+		t.Error("Expected an order missing from the exchange to be forgotten")
+	}
+	if records, _ := lost.Load(); len(records) != 0 {
+		t.Errorf("Expected the lost order's record to be deleted, %d left", len(records))
+	}
+}
+
+func TestRejectedOrderIsNotKept(t *testing.T) {
+	store := NewMemoryStore()
+	trader := &hashingTrader{store: store, postErr: &client.APIError{StatusCode: 400, Message: "not enough balance"}}
+	m := NewOrderManager(trader)
+	m.SetStore(store, func(err error) { t.Errorf("Store error: %v", err) })
+
+	if _, err := m.SubmitSigned(testOrder(), types.GTC, ""); err == nil {
+		t.Fatal("Expected the post to fail")
+	}
+	if !trader.saved {
+		t.Fatal("Expected the order to be saved before posting")
+	}
+	if records, _ := store.Load(); len(records) != 0 {
+		t.Errorf("Expected the rejected order's record to be deleted, %d left", len(records))
+	}
+}
+
+func TestStoreKeepsTheLatestRecord(t *testing.T) {
+	store := NewMemoryStore()
+	m := NewOrderManager(&fakeTrader{})
+	if err := m.saveRecord(store, "0xabc", 2, []byte("second")); err != nil {
+		t.Fatalf("saveRecord failed: %v", err)
+	}
+	// An older update written late must not overwrite the newer one
+	if err := m.saveRecord(store, "0xabc", 1, []byte("first")); err != nil {
+		t.Fatalf("saveRecord failed: %v", err)
+	}
+	if records, _ := store.Load(); string(records["0xabc"]) != "second" {
+		t.Errorf("Expected the latest record, got %q", records["0xabc"])
+	}
+}
+
+func TestRecoverRestoresClientFlags(t *testing.T) {
+	store := NewMemoryStore()
+	m := NewOrderManager(&fakeTrader{status: "live"})
+	m.SetStore(store, func(err error) { t.Errorf("Store error: %v", err) })
+
+	signed := testOrder()
+	signed.Owner = "owner-key"
+	signed.AllowCross = true
+	signed.PriceAdjustment = &types.PriceAdjustment{Requested: 0.503, Price: 0.5, Mode: types.RoundDown}
+	if _, err := m.SubmitSigned(signed, types.GTC, ""); err != nil {
+		t.Fatalf("Failed to submit order: %v", err)
+	}
+
+	restarted := NewOrderManager(&fakeTrader{open: []types.OpenOrder{{ID: "0xabc", Status: "LIVE"}}})
+	restarted.SetStore(store, nil)
+	if _, err := restarted.Recover(); err != nil {
+		t.Fatalf("Recover failed: %v", err)
+	}
+	order, _ := restarted.Order("0xabc")
+	if order.Signed == nil || order.Signed.Owner != "owner-key" || !order.Signed.AllowCross ||
+		order.Signed.PriceAdjustment == nil || *order.Signed.PriceAdjustment != *signed.PriceAdjustment {
+		t.Errorf("Client flags were not restored: %+v", order.Signed)
+	}
+}
@@ -0,0 +1,585 @@
+package paper
+
+import (
+	"fmt"
+	"math"
+	"math/big"
+	"sort"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/MaDal776/polymarket-go-client/pkg/amount"
+	"github.com/MaDal776/polymarket-go-client/pkg/client"
+	"github.com/MaDal776/polymarket-go-client/pkg/types"
+	"github.com/MaDal776/polymarket-go-client/pkg/utils"
+)
+
+// sizeEpsilon is the smallest quantity treated as non-zero
+const sizeEpsilon = 1e-9
+
+// Order statuses reported by the simulated exchange, matching the CLOB API
+const (
+	StatusLive      = "live"
+	StatusMatched   = "matched"
+	StatusUnmatched = "unmatched"
+	StatusCancelled = "cancelled"
+)
+
+// Account is a snapshot of the simulated account
+type Account struct {
+	Cash      float64            `json:"cash"`
+	Reserved  float64            `json:"reserved"`
+	Positions map[string]float64 `json:"positions"`
+}
+
+// simOrder is a resting order in the simulated account
+type simOrder struct {
+	id         string
+	tokenID    string
+	side       types.OrderSide
+	price      float64
+	size       float64
+	matched    float64
+	orderType  types.OrderType
+	expiration int64
+	createdAt  time.Time
+	seq        int64  // Order of arrival, for time priority
+	status     string // Open order status: LIVE, MATCHED or CANCELED
+}
+
+func (o *simOrder) remaining() float64 {
+	return o.size - o.matched
+}
+
+// toOpenOrder converts a resting order to the API's open order format
+func (o *simOrder) toOpenOrder() types.OpenOrder {
+	return types.OpenOrder{
+		ID:           o.id,
+		Status:       o.status,
+		AssetID:      o.tokenID,
+		Side:         o.side,
+		Price:        strconv.FormatFloat(o.price, 'f', -1, 64),
+		OriginalSize: strconv.FormatFloat(o.size, 'f', -1, 64),
+		SizeMatched:  strconv.FormatFloat(o.matched, 'f', -1, 64),
+		OrderType:    o.orderType,
+		Expiration:   strconv.FormatInt(o.expiration, 10),
+		CreatedAt:    o.createdAt.Unix(),
+	}
+}
+
+// PaperClient simulates order execution against the live order book.
+// It implements client.Trader so it can replace the live client without code changes:
+// market data and order signing go to the live client, while posting, cancelling,
+// balances, open orders and trades are served from a local simulated account.
+type PaperClient struct {
+	live client.Trader
+
+	mu        sync.Mutex
+	cash      float64
+	reserved  float64
+	positions map[string]float64
+	orders    map[string]*simOrder // Resting orders
+	done      map[string]*simOrder // Filled, killed and cancelled orders, kept for GetOrder
+	trades    []types.Trade
+	nextID    int64
+}
+
+var _ client.Trader = (*PaperClient)(nil)
+
+// NewPaperClient creates a paper trading client starting with the given USDC balance
+func NewPaperClient(live client.Trader, startingCash float64) *PaperClient {
+	return &PaperClient{
+		live:      live,
+		cash:      startingCash,
+		positions: make(map[string]float64),
+		orders:    make(map[string]*simOrder),
+		done:      make(map[string]*simOrder),
+		trades:    make([]types.Trade, 0),
+	}
+}
+
+// Deposit adds simulated shares of a token to the account
+func (p *PaperClient) Deposit(tokenID string, shares float64) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.positions[tokenID] += shares
+}
+
+// Account returns a snapshot of the simulated account
+func (p *PaperClient) Account() Account {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	positions := make(map[string]float64, len(p.positions))
+	for tokenID, size := range p.positions {
+		positions[tokenID] = size
+	}
+	return Account{Cash: p.cash, Reserved: p.reserved, Positions: positions}
+}
+
+// GetAddress returns the live client's address
+func (p *PaperClient) GetAddress() string {
+	return p.live.GetAddress()
+}
+
+// GetTickSize gets the tick size from the live client
+func (p *PaperClient) GetTickSize(tokenID string) (types.TickSize, error) {
+	return p.live.GetTickSize(tokenID)
+}
+
+// GetNegRisk gets the neg risk flag from the live client
+func (p *PaperClient) GetNegRisk(tokenID string) (bool, error) {
+	return p.live.GetNegRisk(tokenID)
+}
+
+// GetOrderBook gets the order book from the live client
+func (p *PaperClient) GetOrderBook(tokenID string) (*types.OrderBookSummary, error) {
+	return p.live.GetOrderBook(tokenID)
+}
+
+// GetMidpoint gets the midpoint from the live client
+func (p *PaperClient) GetMidpoint(tokenID string) (*types.MidpointResponse, error) {
+	return p.live.GetMidpoint(tokenID)
+}
+
+// GetPrice gets the price from the live client
+func (p *PaperClient) GetPrice(tokenID string, side types.OrderSide) (*types.PriceResponse, error) {
+	return p.live.GetPrice(tokenID, side)
+}
+
+// CreateOrder signs an order with the live client; signing never touches the exchange
+func (p *PaperClient) CreateOrder(orderArgs types.OrderArgs, options *types.CreateOrderOptions) (*types.SignedOrder, error) {
+	return p.live.CreateOrder(orderArgs, options)
+}
+
+// GetBalanceAllowance returns the simulated collateral or conditional token balance
+func (p *PaperClient) GetBalanceAllowance(params *types.BalanceAllowanceParams) (*types.BalanceAllowanceResponse, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	balance := p.cash - p.reserved
+	if params != nil && params.AssetType == types.CONDITIONAL {
+		balance = p.availableShares(params.TokenID)
+	}
+
+	return &types.BalanceAllowanceResponse{
+		Balance:   utils.ToTokenDecimals(balance).String(),
+		Allowance: new(big.Int).Lsh(big.NewInt(1), 255).String(),
+	}, nil
+}
+
+// PostOrder matches a signed order against the live book in the simulated account
+func (p *PaperClient) PostOrder(signedOrder *types.SignedOrder, orderType types.OrderType) (map[string]interface{}, error) {
+	order, err := p.decodeOrder(signedOrder, orderType)
+	if err != nil {
+		return nil, err
+	}
+
+	book, err := p.live.GetOrderBook(order.tokenID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get order book: %w", err)
+	}
+	fills, err := matchable(book, order.side, order.price, order.size)
+	if err != nil {
+		return nil, err
+	}
+	filled := 0.0
+	for _, fill := range fills {
+		filled += fill.size
+	}
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	// Funds check against the full order, as the exchange does
+	if order.side == types.BUY && order.size*order.price > p.cash-p.reserved+sizeEpsilon {
+		return rejection("not enough balance / allowance"), nil
+	}
+	if order.side == types.SELL && order.size > p.availableShares(order.tokenID)+sizeEpsilon {
+		return rejection("not enough balance / allowance"), nil
+	}
+
+	switch orderType {
+	case types.FOK:
+		if filled < order.size-sizeEpsilon {
+			return rejection("order couldn't be fully filled. FOK orders are fully filled or killed."), nil
+		}
+	case types.FAK:
+		if filled <= sizeEpsilon {
+			return rejection("no orders found to match with FAK order. FAK orders are partially filled or killed if no match is found."), nil
+		}
+	}
+
+	making, taking := 0.0, 0.0
+	for _, fill := range fills {
+		p.execute(order, fill.price, fill.size, "TAKER")
+		if order.side == types.BUY {
+			making += fill.price * fill.size
+			taking += fill.size
+		} else {
+			making += fill.size
+			taking += fill.price * fill.size
+		}
+	}
+
+	status := StatusMatched
+	switch {
+	case order.remaining() <= sizeEpsilon:
+		p.finish(order, "MATCHED")
+	case orderType == types.GTC || orderType == types.GTD:
+		p.rest(order)
+		if filled <= sizeEpsilon {
+			status = StatusLive
+		}
+	default:
+		// The unfilled remainder of a FAK is killed
+		p.finish(order, "CANCELED")
+	}
+
+	return map[string]interface{}{
+		"success":            true,
+		"errorMsg":           "",
+		"orderID":            order.id,
+		"status":             status,
+		"makingAmount":       formatAmount(making),
+		"takingAmount":       formatAmount(taking),
+		"transactionsHashes": []string{},
+	}, nil
+}
+
+// CreateAndPostOrder signs an order and posts it to the simulated account,
+// as GTD when it expires and GTC otherwise, like the live client
+func (p *PaperClient) CreateAndPostOrder(orderArgs types.OrderArgs, options *types.CreateOrderOptions) (map[string]interface{}, error) {
+	signedOrder, err := p.CreateOrder(orderArgs, options)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create order: %w", err)
+	}
+	return p.PostOrder(signedOrder, client.PostOrderType(signedOrder))
+}
+
+// CancelOrder cancels a resting simulated order
+func (p *PaperClient) CancelOrder(orderID string) (map[string]interface{}, error) {
+	return p.CancelOrders([]string{orderID})
+}
+
+// CancelOrders cancels resting simulated orders by ID
+func (p *PaperClient) CancelOrders(orderIDs []string) (map[string]interface{}, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	canceled := make([]string, 0, len(orderIDs))
+	notCanceled := make(map[string]interface{})
+	for _, id := range orderIDs {
+		if _, exists := p.orders[id]; !exists {
+			notCanceled[id] = "order can't be found - already canceled or matched"
+			continue
+		}
+		p.cancel(id)
+		canceled = append(canceled, id)
+	}
+
+	return map[string]interface{}{
+		"canceled":     canceled,
+		"not_canceled": notCanceled,
+	}, nil
+}
+
+// CancelAll cancels every resting simulated order
+func (p *PaperClient) CancelAll() (map[string]interface{}, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	canceled := make([]string, 0, len(p.orders))
+	for id := range p.orders {
+		p.cancel(id)
+		canceled = append(canceled, id)
+	}
+	sort.Strings(canceled)
+
+	return map[string]interface{}{
+		"canceled":     canceled,
+		"not_canceled": map[string]interface{}{},
+	}, nil
+}
+
+// GetOrder returns a simulated order. Like the exchange, it still reports
+// orders that have filled or been cancelled, with status MATCHED or CANCELED.
+func (p *PaperClient) GetOrder(orderID string) (*types.OpenOrder, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	order, exists := p.orders[orderID]
+	if !exists {
+		order, exists = p.done[orderID]
+	}
+	if !exists {
+		return nil, fmt.Errorf("order %s not found", orderID)
+	}
+	openOrder := order.toOpenOrder()
+	return &openOrder, nil
+}
+
+// GetOpenOrders returns the resting simulated orders
+func (p *PaperClient) GetOpenOrders(params *types.OpenOrderParams) ([]types.OpenOrder, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	orders := make([]types.OpenOrder, 0, len(p.orders))
+	for _, order := range p.orders {
+		if params != nil {
+			if params.ID != "" && params.ID != order.id {
+				continue
+			}
+			if params.AssetID != "" && params.AssetID != order.tokenID {
+				continue
+			}
+		}
+		orders = append(orders, order.toOpenOrder())
+	}
+	sort.Slice(orders, func(i, j int) bool {
+		return orders[i].ID < orders[j].ID
+	})
+	return orders, nil
+}
+
+// GetTrades returns the simulated trades
+func (p *PaperClient) GetTrades(params *types.TradeParams) ([]types.Trade, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	trades := make([]types.Trade, 0, len(p.trades))
+	for _, trade := range p.trades {
+		if params != nil && params.AssetID != "" && params.AssetID != trade.AssetID {
+			continue
+		}
+		trades = append(trades, trade)
+	}
+	return trades, nil
+}
+
+// Sync re-checks resting orders against fresh books, filling those the market has crossed.
+// Orders fill in time priority, and each book level's size is used up once
+// across them. It returns the number of orders that received fills.
+func (p *PaperClient) Sync() (int, error) {
+	p.mu.Lock()
+	tokens := make(map[string]bool)
+	for _, order := range p.orders {
+		tokens[order.tokenID] = true
+	}
+	p.mu.Unlock()
+
+	touched := 0
+	for tokenID := range tokens {
+		book, err := p.live.GetOrderBook(tokenID)
+		if err != nil {
+			return touched, fmt.Errorf("failed to get order book for %s: %w", tokenID, err)
+		}
+
+		bids, asks, err := book.Levels()
+		if err != nil {
+			return touched, fmt.Errorf("invalid book: %w", err)
+		}
+		// Buys take asks and sells take bids, from copies drawn down as they fill
+		liquidity := map[types.OrderSide][]types.PriceLevel{
+			types.BUY:  append([]types.PriceLevel(nil), asks...),
+			types.SELL: append([]types.PriceLevel(nil), bids...),
+		}
+
+		p.mu.Lock()
+		resting := make([]*simOrder, 0)
+		for _, order := range p.orders {
+			if order.tokenID == tokenID {
+				resting = append(resting, order)
+			}
+		}
+		sort.Slice(resting, func(i, j int) bool { return resting[i].seq < resting[j].seq })
+
+		for _, order := range resting {
+			id := order.id
+			if order.expiration > 0 && time.Now().Unix() >= order.expiration {
+				p.cancel(id)
+				continue
+			}
+			fills := take(liquidity[order.side], order.side, order.price, order.remaining())
+			if len(fills) == 0 {
+				continue
+			}
+			for _, fill := range fills {
+				// Resting orders fill at their own limit price as the maker
+				p.execute(order, order.price, fill.size, "MAKER")
+			}
+			touched++
+			if order.remaining() <= sizeEpsilon {
+				delete(p.orders, id)
+				p.finish(order, "MATCHED")
+			}
+		}
+		p.mu.Unlock()
+	}
+	return touched, nil
+}
+
+// decodeOrder recovers price and size from a signed order's amounts
+func (p *PaperClient) decodeOrder(signedOrder *types.SignedOrder, orderType types.OrderType) (*simOrder, error) {
+	price, size, err := utils.SignedOrderPriceAndSize(signedOrder)
+	if err != nil {
+		return nil, err
+	}
+	expiration, _ := strconv.ParseInt(signedOrder.Expiration, 10, 64)
+
+	order := &simOrder{
+		tokenID:    signedOrder.TokenID,
+		side:       signedOrder.Side,
+		price:      price,
+		size:       size,
+		orderType:  orderType,
+		expiration: expiration,
+		createdAt:  time.Now(),
+		status:     "LIVE",
+	}
+
+	p.mu.Lock()
+	p.nextID++
+	order.seq = p.nextID
+	order.id = fmt.Sprintf("paper-%d", p.nextID)
+	p.mu.Unlock()
+	return order, nil
+}
+
+// execute applies a fill to the account and records a trade. Callers hold p.mu.
+func (p *PaperClient) execute(order *simOrder, price, size float64, traderSide string) {
+	order.matched += size
+	if order.side == types.BUY {
+		p.cash -= price * size
+		p.positions[order.tokenID] += size
+		if traderSide == "MAKER" {
+			p.reserved -= order.price * size
+		}
+	} else {
+		p.cash += price * size
+		p.positions[order.tokenID] -= size
+	}
+
+	trade := types.Trade{
+		ID:         fmt.Sprintf("%s-%d", order.id, len(p.trades)+1),
+		AssetID:    order.tokenID,
+		Side:       order.side,
+		Size:       strconv.FormatFloat(size, 'f', -1, 64),
+		Price:      strconv.FormatFloat(price, 'f', -1, 64),
+		Status:     "CONFIRMED",
+		MatchTime:  strconv.FormatInt(time.Now().Unix(), 10),
+		TraderSide: traderSide,
+	}
+	if traderSide == "TAKER" {
+		trade.TakerOrderID = order.id
+	} else {
+		trade.MakerOrders = []types.MakerOrder{{
+			OrderID:       order.id,
+			MakerAddress:  p.live.GetAddress(),
+			MatchedAmount: trade.Size,
+			Price:         trade.Price,
+			AssetID:       order.tokenID,
+			Side:          order.side,
+		}}
+	}
+	p.trades = append(p.trades, trade)
+}
+
+// rest places the unfilled remainder of an order in the simulated book. Callers hold p.mu.
+func (p *PaperClient) rest(order *simOrder) {
+	if order.side == types.BUY {
+		p.reserved += order.price * order.remaining()
+	}
+	p.orders[order.id] = order
+}
+
+// cancel removes a resting order and releases its reservation. Callers hold p.mu.
+func (p *PaperClient) cancel(id string) {
+	order, exists := p.orders[id]
+	if !exists {
+		return
+	}
+	if order.side == types.BUY {
+		p.reserved -= order.price * order.remaining()
+	}
+	delete(p.orders, id)
+	p.finish(order, "CANCELED")
+}
+
+// finish records an order that is no longer resting. Callers hold p.mu.
+func (p *PaperClient) finish(order *simOrder, status string) {
+	order.status = status
+	p.done[order.id] = order
+}
+
+// availableShares returns shares not committed to resting sell orders. Callers hold p.mu.
+func (p *PaperClient) availableShares(tokenID string) float64 {
+	available := p.positions[tokenID]
+	for _, order := range p.orders {
+		if order.tokenID == tokenID && order.side == types.SELL {
+			available -= order.remaining()
+		}
+	}
+	return available
+}
+
+type fill struct {
+	price float64
+	size  float64
+}
+
+// matchable walks the opposite side of the book and returns the fills an order
+// with the given limit price and size would receive
+func matchable(book *types.OrderBookSummary, side types.OrderSide, limit, size float64) ([]fill, error) {
+	bids, asks, err := book.Levels()
+	if err != nil {
+		return nil, fmt.Errorf("invalid book: %w", err)
+	}
+	levels := asks
+	if side == types.SELL {
+		levels = bids
+	}
+	return take(append([]types.PriceLevel(nil), levels...), side, limit, size), nil
+}
+
+// take fills an order with the given limit price and size from best-first
+// levels of the opposite side, reducing their sizes by what it takes
+func take(levels []types.PriceLevel, side types.OrderSide, limit, size float64) []fill {
+	fills := make([]fill, 0)
+	remaining := size
+	for i, level := range levels {
+		if remaining <= sizeEpsilon {
+			break
+		}
+		if side == types.BUY && level.Price > limit+sizeEpsilon {
+			break
+		}
+		if side == types.SELL && level.Price < limit-sizeEpsilon {
+			break
+		}
+		qty := math.Min(remaining, level.Size)
+		if qty <= sizeEpsilon {
+			continue
+		}
+		fills = append(fills, fill{price: level.Price, size: qty})
+		levels[i].Size -= qty
+		remaining -= qty
+	}
+	return fills
+}
+
+// rejection builds an unsuccessful order response in the API's format
+func rejection(message string) map[string]interface{} {
+	return map[string]interface{}{
+		"success":  false,
+		"errorMsg": message,
+		"orderID":  "",
+		"status":   "",
+	}
+}
+
+// formatAmount formats a human amount as the API's decimal string
+func formatAmount(value float64) string {
+	return amount.SharesFromFloat(value).ToHuman()
+}
@@ -0,0 +1,192 @@
+package paper
+
+import (
+	"math"
+	"strconv"
+	"testing"
+
+	"github.com/MaDal776/polymarket-go-client/pkg/client"
+	"github.com/MaDal776/polymarket-go-client/pkg/ordermanager"
+	"github.com/MaDal776/polymarket-go-client/pkg/types"
+)
+
+const testToken = "91094360697357622623953793720402150934374522251651348543981406747516093190659"
+
+// fakeLive serves a fixed order book; other Trader methods are not used
+type fakeLive struct {
+	client.Trader
+	book *types.OrderBookSummary
+}
+
+func (f *fakeLive) GetOrderBook(tokenID string) (*types.OrderBookSummary, error) {
+	return f.book, nil
+}
+
+func (f *fakeLive) GetAddress() string {
+	return "0x0000000000000000000000000000000000000001"
+}
+
+// CreateOrder builds an unsigned BUY order with the args' price and size
+func (f *fakeLive) CreateOrder(orderArgs types.OrderArgs, options *types.CreateOrderOptions) (*types.SignedOrder, error) {
+	makerAmount := int64(math.Round(orderArgs.Price * orderArgs.Size * 1e6))
+	takerAmount := int64(math.Round(orderArgs.Size * 1e6))
+	return buyOrder(strconv.FormatInt(makerAmount, 10), strconv.FormatInt(takerAmount, 10)), nil
+}
+
+func buyOrder(makerAmount, takerAmount string) *types.SignedOrder {
+	return &types.SignedOrder{
+		TokenID:     testToken,
+		Side:        types.BUY,
+		MakerAmount: makerAmount,
+		TakerAmount: takerAmount,
+		Expiration:  "0",
+	}
+}
+
+func TestPostOrderFillsAgainstBook(t *testing.T) {
+	live := &fakeLive{book: &types.OrderBookSummary{
+		Asks: []types.OrderSummary{{Price: "0.52", Size: "5"}, {Price: "0.50", Size: "5"}},
+	}}
+	p := NewPaperClient(live, 100)
+
+	// Buy 10 shares at 0.55: 5 @ 0.50 and 5 @ 0.52
+	result, err := p.PostOrder(buyOrder("5500000", "10000000"), types.GTC)
+	if err != nil {
+		t.Fatalf("Failed to post order: %v", err)
+	}
+	if result["status"] != StatusMatched {
+		t.Errorf("Expected status %s, got %v", StatusMatched, result["status"])
+	}
+
+	account := p.Account()
+	if account.Positions[testToken] != 10 {
+		t.Errorf("Expected 10 shares, got %f", account.Positions[testToken])
+	}
+	if diff := account.Cash - (100 - 5.1); diff > 1e-9 || diff < -1e-9 {
+		t.Errorf("Expected cash 94.9, got %f", account.Cash)
+	}
+}
+
+func TestPostOrderFOKRejectsPartialFill(t *testing.T) {
+	live := &fakeLive{book: &types.OrderBookSummary{
+		Asks: []types.OrderSummary{{Price: "0.50", Size: "5"}},
+	}}
+	p := NewPaperClient(live, 100)
+
+	result, err := p.PostOrder(buyOrder("5000000", "10000000"), types.FOK)
+	if err != nil {
+		t.Fatalf("Failed to post order: %v", err)
+	}
+	if result["success"] != false {
+		t.Error("Expected FOK order to be killed")
+	}
+	if p.Account().Cash != 100 {
+		t.Error("Expected killed order to leave the account unchanged")
+	}
+}
+
+func TestRestingOrderFillsOnSync(t *testing.T) {
+	live := &fakeLive{book: &types.OrderBookSummary{
+		Asks: []types.OrderSummary{{Price: "0.60", Size: "50"}},
+	}}
+	p := NewPaperClient(live, 100)
+
+	result, err := p.PostOrder(buyOrder("5000000", "10000000"), types.GTC)
+	if err != nil {
+		t.Fatalf("Failed to post order: %v", err)
+	}
+	if result["status"] != StatusLive {
+		t.Fatalf("Expected resting order, got %v", result["status"])
+	}
+	if p.Account().Reserved != 5 {
+		t.Errorf("Expected 5 reserved, got %f", p.Account().Reserved)
+	}
+
+	live.book = &types.OrderBookSummary{Asks: []types.OrderSummary{{Price: "0.48", Size: "50"}}}
+	if _, err := p.Sync(); err != nil {
+		t.Fatalf("Failed to sync: %v", err)
+	}
+
+	account := p.Account()
+	if account.Positions[testToken] != 10 || account.Reserved != 0 || account.Cash != 95 {
+		t.Errorf("Unexpected account after sync: %+v", account)
+	}
+	orders, _ := p.GetOpenOrders(nil)
+	if len(orders) != 0 {
+		t.Errorf("Expected no open orders, got %d", len(orders))
+	}
+}
+
+func TestSyncSharesLevelsInTimePriority(t *testing.T) {
+	live := &fakeLive{book: &types.OrderBookSummary{
+		Asks: []types.OrderSummary{{Price: "0.60", Size: "50"}},
+	}}
+	p := NewPaperClient(live, 100)
+
+	// Two resting BUYs of 10 at 0.5
+	for i := 0; i < 2; i++ {
+		if _, err := p.PostOrder(buyOrder("5000000", "10000000"), types.GTC); err != nil {
+			t.Fatalf("Failed to post order: %v", err)
+		}
+	}
+
+	// Only 15 shares cross; the older order takes 10 and the newer one the rest
+	live.book = &types.OrderBookSummary{Asks: []types.OrderSummary{{Price: "0.48", Size: "15"}}}
+	touched, err := p.Sync()
+	if err != nil {
+		t.Fatalf("Failed to sync: %v", err)
+	}
+	if touched != 2 {
+		t.Errorf("Expected both orders to fill, got %d", touched)
+	}
+	if account := p.Account(); account.Positions[testToken] != 15 {
+		t.Errorf("Expected 15 shares bought, got %v", account.Positions[testToken])
+	}
+	orders, _ := p.GetOpenOrders(nil)
+	if len(orders) != 1 || orders[0].ID != "paper-2" || orders[0].SizeMatched != "5" {
+		t.Errorf("Expected the newer order left with 5 matched, got %+v", orders)
+	}
+}
+
+func TestOrderManagerOverPaperClient(t *testing.T) {
+	live := &fakeLive{book: &types.OrderBookSummary{
+		Asks: []types.OrderSummary{{Price: "0.60", Size: "50"}},
+	}}
+	p := NewPaperClient(live, 100)
+	m := ordermanager.NewOrderManager(p)
+
+	first, err := m.SubmitSigned(buyOrder("5000000", "10000000"), types.GTC, "")
+	if err != nil {
+		t.Fatalf("SubmitSigned: %v", err)
+	}
+
+	// The cancelled order is still reported, so the requote can read its remainder
+	second, err := m.Requote(first.ID, 0.45, nil)
+	if err != nil {
+		t.Fatalf("Requote: %v", err)
+	}
+	if second.Price != 0.45 || second.Size != 10 || second.State != ordermanager.StateLive {
+		t.Errorf("Unexpected requoted order %+v", second)
+	}
+	if order, _ := m.Order(first.ID); order.State != ordermanager.StateCancelled {
+		t.Errorf("Expected the first order cancelled, got %s", order.State)
+	}
+	if order, err := p.GetOrder(first.ID); err != nil || order.Status != "CANCELED" {
+		t.Errorf("Expected the cancelled order to be reported, got %+v, %v", order, err)
+	}
+
+	// The market crosses the requoted order, which no longer rests afterwards
+	live.book = &types.OrderBookSummary{Asks: []types.OrderSummary{{Price: "0.40", Size: "50"}}}
+	if _, err := p.Sync(); err != nil {
+		t.Fatalf("Sync: %v", err)
+	}
+	if err := m.Sync(); err != nil {
+		t.Fatalf("OrderManager.Sync: %v", err)
+	}
+	if order, _ := m.Order(second.ID); order.State != ordermanager.StateFilled || order.SizeMatched != 10 {
+		t.Errorf("Expected the requoted order filled, got %+v", order)
+	}
+	if order, err := p.GetOrder(second.ID); err != nil || order.Status != "MATCHED" || order.SizeMatched != "10" {
+		t.Errorf("Expected the filled order to be reported, got %+v, %v", order, err)
+	}
+}
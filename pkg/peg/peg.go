@@ -0,0 +1,533 @@
+// Package peg emulates pegged orders: resting orders kept at a fixed offset
+// from the best bid, best ask or midpoint. Each poll re-prices every peg from
+// the current books and replaces the orders whose reference moved more than
+// their tolerance, cancelling and posting them in batches to limit API calls.
+// A replaced order is re-posted for the peg's unfilled size, and a peg is
+// retired once its orders have filled its Size.
+package peg
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math"
+	"sort"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/MaDal776/polymarket-go-client/pkg/client"
+	"github.com/MaDal776/polymarket-go-client/pkg/types"
+	"github.com/MaDal776/polymarket-go-client/pkg/utils"
+)
+
+// Reference is the book price a peg follows
+type Reference string
+
+const (
+	ReferenceBestBid Reference = "BEST_BID"
+	ReferenceBestAsk Reference = "BEST_ASK"
+	ReferenceMid     Reference = "MID"
+)
+
+// Peg describes one pegged order
+type Peg struct {
+	ID        string                    `json:"id"` // Required and unique
+	TokenID   string                    `json:"token_id"`
+	Side      types.OrderSide           `json:"side"`
+	Reference Reference                 `json:"reference"` // Default: the best bid for a buy, the best ask for a sell
+	Offset    float64                   `json:"offset"`    // Added to the reference, e.g. -0.01 to bid a cent below the best bid
+	Size      float64                   `json:"size"`
+	Tolerance float64                   `json:"tolerance"` // Reference move tolerated before the order is replaced; 0 follows every tick
+	Options   *types.CreateOrderOptions `json:"-"`
+}
+
+// Quote is the order currently resting for a peg
+type Quote struct {
+	Peg       Peg       `json:"peg"`
+	OrderID   string    `json:"order_id"` // Empty while the peg has no resting order
+	Price     float64   `json:"price"`
+	Reference float64   `json:"reference"` // Reference price the order was priced from
+	Filled    float64   `json:"filled"`    // Size matched by the peg's replaced orders
+	PostedAt  time.Time `json:"posted_at"`
+
+	unsettled bool // OrderID was cancelled but its matched size is not known yet
+}
+
+// Trader signs, batch-posts, batch-cancels and looks up orders and fetches
+// books (e.g. the CLOB client)
+type Trader interface {
+	GetTickSize(tokenID string) (types.TickSize, error)
+	GetOrderBooks(ctx context.Context, tokenIDs []string, concurrency int) (map[string]*types.OrderBookSummary, error)
+	CreateOrder(orderArgs types.OrderArgs, options *types.CreateOrderOptions) (*types.SignedOrder, error)
+	PostOrders(orders []types.PostOrdersArgs) ([]map[string]interface{}, error)
+	CancelOrders(orderIDs []string) (map[string]interface{}, error)
+	GetOrder(orderID string) (*types.OpenOrder, error)
+}
+
+var _ Trader = (*client.ClobClient)(nil)
+
+// Config configures a Pegger
+type Config struct {
+	Trader      Trader          // Required
+	OrderType   types.OrderType // GTC or GTD; default GTC
+	ExpireAfter time.Duration   // Lifetime of each GTD order; default the client's OrderDefaults.ExpireAfter
+	Interval    time.Duration   // Interval used by Run; default 5s
+	Concurrency int             // Books fetched at once; default 4
+	OnRequote   func(quote Quote)
+	OnFilled    func(quote Quote) // Called when a peg is retired because its Size has filled
+	OnError     func(err error)
+}
+
+// Pegger keeps pegged orders at their target prices. When an order is
+// replaced, the size it matched is looked up and only the rest of the peg's
+// Size is posted again.
+type Pegger struct {
+	config Config
+
+	mu     sync.Mutex
+	quotes map[string]*Quote
+}
+
+// NewPegger creates a pegger trading through config.Trader
+func NewPegger(config Config) (*Pegger, error) {
+	if config.Trader == nil {
+		return nil, fmt.Errorf("trader is required")
+	}
+	if config.OrderType == "" {
+		config.OrderType = types.GTC
+	}
+	if config.OrderType != types.GTC && config.OrderType != types.GTD {
+		return nil, fmt.Errorf("pegged orders must rest on the book, got order type %s", config.OrderType)
+	}
+	if config.Interval <= 0 {
+		config.Interval = 5 * time.Second
+	}
+	if config.Concurrency <= 0 {
+		config.Concurrency = 4
+	}
+	return &Pegger{config: config, quotes: make(map[string]*Quote)}, nil
+}
+
+// Add registers a peg. Its order is posted by the next Poll.
+func (p *Pegger) Add(peg Peg) error {
+	if peg.ID == "" || peg.TokenID == "" {
+		return fmt.Errorf("peg ID and token ID are required")
+	}
+	if peg.Side != types.BUY && peg.Side != types.SELL {
+		return fmt.Errorf("invalid side: %s", peg.Side)
+	}
+	if peg.Size <= 0 {
+		return fmt.Errorf("peg size must be positive")
+	}
+	if peg.Reference == "" {
+		peg.Reference = ReferenceBestBid
+		if peg.Side == types.SELL {
+			peg.Reference = ReferenceBestAsk
+		}
+	}
+	if peg.Reference != ReferenceBestBid && peg.Reference != ReferenceBestAsk && peg.Reference != ReferenceMid {
+		return fmt.Errorf("invalid reference: %s", peg.Reference)
+	}
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if _, exists := p.quotes[peg.ID]; exists {
+		return fmt.Errorf("peg %s already exists", peg.ID)
+	}
+	p.quotes[peg.ID] = &Quote{Peg: peg}
+	return nil
+}
+
+// Remove forgets a peg and cancels its resting order
+func (p *Pegger) Remove(id string) error {
+	p.mu.Lock()
+	quote, exists := p.quotes[id]
+	delete(p.quotes, id)
+	p.mu.Unlock()
+
+	if !exists {
+		return fmt.Errorf("peg %s not found", id)
+	}
+	if quote.OrderID == "" {
+		return nil
+	}
+	if _, err := p.config.Trader.CancelOrders([]string{quote.OrderID}); err != nil {
+		return fmt.Errorf("failed to cancel peg %s: %w", id, err)
+	}
+	return nil
+}
+
+// CancelAll cancels every resting pegged order in one request. The pegs stay
+// registered and their unfilled size is re-posted by the next Poll.
+func (p *Pegger) CancelAll() error {
+	resting := make([]Quote, 0)
+	for _, quote := range p.Quotes() {
+		if quote.OrderID != "" {
+			resting = append(resting, quote)
+		}
+	}
+	_, err := p.cancel(resting)
+	return err
+}
+
+// Quotes returns copies of every peg's quote, sorted by peg ID
+func (p *Pegger) Quotes() []Quote {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	quotes := make([]Quote, 0, len(p.quotes))
+	for _, quote := range p.quotes {
+		quotes = append(quotes, *quote)
+	}
+	sort.Slice(quotes, func(i, j int) bool {
+		return quotes[i].Peg.ID < quotes[j].Peg.ID
+	})
+	return quotes
+}
+
+// requote is a peg whose order is being replaced
+type requote struct {
+	quote     Quote
+	price     float64
+	reference float64
+	size      float64 // Unfilled size to post
+	post      bool    // False when the book no longer has the reference price
+}
+
+// Poll re-prices every peg from the current books, cancels the orders whose
+// reference moved beyond their tolerance in one request and posts their
+// replacements in batches. It returns the number of orders posted.
+func (p *Pegger) Poll(ctx context.Context) (int, error) {
+	quotes := p.Quotes()
+	if len(quotes) == 0 {
+		return 0, nil
+	}
+	tokenIDs := make([]string, 0, len(quotes))
+	seen := make(map[string]bool)
+	for _, quote := range quotes {
+		if !seen[quote.Peg.TokenID] {
+			seen[quote.Peg.TokenID] = true
+			tokenIDs = append(tokenIDs, quote.Peg.TokenID)
+		}
+	}
+	books, errs := p.config.Trader.GetOrderBooks(ctx, tokenIDs, p.config.Concurrency)
+
+	requotes := make([]requote, 0)
+	for _, quote := range quotes {
+		book, ok := books[quote.Peg.TokenID]
+		if !ok || book == nil {
+			continue
+		}
+		tickSize, err := p.tickSize(quote.Peg)
+		if err != nil {
+			errs = errors.Join(errs, err)
+			continue
+		}
+		reference, price, ok := Target(quote.Peg, book, tickSize)
+		if !ok {
+			if quote.OrderID != "" {
+				requotes = append(requotes, requote{quote: quote})
+			}
+			continue
+		}
+		if quote.OrderID != "" && !quote.unsettled && (price == quote.Price || math.Abs(reference-quote.Reference) <= quote.Peg.Tolerance+1e-9) {
+			continue
+		}
+		requotes = append(requotes, requote{quote: quote, price: price, reference: reference, post: true})
+	}
+	if len(requotes) == 0 {
+		return 0, p.pollError(errs)
+	}
+
+	resting := make([]Quote, 0, len(requotes))
+	for _, r := range requotes {
+		if r.quote.OrderID != "" {
+			resting = append(resting, r.quote)
+		}
+	}
+	// Post nothing for a peg unless its old order is gone, so a peg never
+	// has two orders, and post only what the old order left unfilled
+	free, err := p.cancel(resting)
+	errs = errors.Join(errs, err)
+	ready := make([]requote, 0, len(requotes))
+	for _, r := range requotes {
+		if r.quote.OrderID != "" {
+			size, ok := free[r.quote.Peg.ID]
+			if !ok {
+				continue
+			}
+			r.size = size
+		} else {
+			r.size = unfilled(r.quote)
+		}
+		ready = append(ready, r)
+	}
+
+	posted, err := p.post(ready)
+	return posted, p.pollError(errors.Join(errs, err))
+}
+
+// cancel cancels the resting orders of quotes in one request and settles each
+// peg with the size its order matched. It returns the unfilled size of the pegs
+// left without an order. A peg whose order the exchange did not cancel and
+// that is still live keeps it; a peg whose Size has filled is retired.
+func (p *Pegger) cancel(quotes []Quote) (map[string]float64, error) {
+	free := make(map[string]float64, len(quotes))
+	if len(quotes) == 0 {
+		return free, nil
+	}
+	ids := make([]string, 0, len(quotes))
+	for _, quote := range quotes {
+		ids = append(ids, quote.OrderID)
+	}
+	result, err := p.config.Trader.CancelOrders(ids)
+	if err != nil {
+		return free, fmt.Errorf("failed to cancel pegged orders: %w", err)
+	}
+	cancelled, notCancelled := cancelResult(result)
+
+	var errs error
+	retired := make([]Quote, 0)
+	for _, quote := range quotes {
+		// A cancel refused because the order already filled or was cancelled
+		// leaves the order as gone as a successful one; its status tells
+		order, err := p.config.Trader.GetOrder(quote.OrderID)
+		if err != nil {
+			errs = errors.Join(errs, fmt.Errorf("peg %s: failed to get order %s: %w", quote.Peg.ID, quote.OrderID, err))
+			if cancelled[quote.OrderID] {
+				p.markUnsettled(quote)
+			}
+			continue
+		}
+		if !cancelled[quote.OrderID] && !client.IsTerminalOrderStatus(order.Status) {
+			errs = errors.Join(errs, fmt.Errorf("peg %s: order %s was not cancelled: %s", quote.Peg.ID, quote.OrderID, notCancelled[quote.OrderID]))
+			continue
+		}
+		matched, err := strconv.ParseFloat(order.SizeMatched, 64)
+		if err != nil && order.SizeMatched != "" {
+			errs = errors.Join(errs, fmt.Errorf("peg %s: invalid size matched %q", quote.Peg.ID, order.SizeMatched))
+			p.markUnsettled(quote)
+			continue
+		}
+
+		p.mu.Lock()
+		current, exists := p.quotes[quote.Peg.ID]
+		if exists && current.OrderID == quote.OrderID {
+			current.OrderID = ""
+			current.unsettled = false
+			current.Filled += matched
+			if size := unfilled(*current); size > 0 {
+				free[quote.Peg.ID] = size
+			} else {
+				delete(p.quotes, quote.Peg.ID)
+				retired = append(retired, *current)
+			}
+		}
+		p.mu.Unlock()
+	}
+
+	if p.config.OnFilled != nil {
+		for _, quote := range retired {
+			p.config.OnFilled(quote)
+		}
+	}
+	return free, errs
+}
+
+// markUnsettled flags a peg whose cancelled order's fills are not yet known,
+// so the next Poll looks the order up again before posting
+func (p *Pegger) markUnsettled(quote Quote) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if current, exists := p.quotes[quote.Peg.ID]; exists && current.OrderID == quote.OrderID {
+		current.unsettled = true
+	}
+}
+
+// unfilled returns the size of a peg not yet matched, in whole cents of a share
+func unfilled(quote Quote) float64 {
+	return utils.RoundDown(quote.Peg.Size-quote.Filled+1e-9, 2)
+}
+
+// cancelResult reads the cancelled order IDs and the reasons others were not
+// cancelled from a cancel response
+func cancelResult(result map[string]interface{}) (map[string]bool, map[string]string) {
+	cancelled := make(map[string]bool)
+	switch ids := result["canceled"].(type) {
+	case []string:
+		for _, id := range ids {
+			cancelled[id] = true
+		}
+	case []interface{}:
+		for _, id := range ids {
+			if id, ok := id.(string); ok {
+				cancelled[id] = true
+			}
+		}
+	}
+	notCancelled := make(map[string]string)
+	if reasons, ok := result["not_canceled"].(map[string]interface{}); ok {
+		for id, reason := range reasons {
+			notCancelled[id] = fmt.Sprint(reason)
+		}
+	}
+	return cancelled, notCancelled
+}
+
+// Run calls Poll every Interval until ctx is cancelled, then cancels the
+// resting orders
+func (p *Pegger) Run(ctx context.Context) {
+	ticker := time.NewTicker(p.config.Interval)
+	defer ticker.Stop()
+
+	for {
+		if _, err := p.Poll(ctx); err != nil && ctx.Err() == nil && p.config.OnError != nil {
+			p.config.OnError(err)
+		}
+
+		select {
+		case <-ctx.Done():
+			if err := p.CancelAll(); err != nil && p.config.OnError != nil {
+				p.config.OnError(err)
+			}
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// Target returns a peg's reference price and its order price in book: the
+// reference plus the offset, snapped away from the spread onto the tick grid and
+// kept from crossing the opposite best price. ok is false when the book lacks
+// the reference price.
+func Target(peg Peg, book *types.OrderBookSummary, tickSize types.TickSize) (reference, price float64, ok bool) {
+	bid, hasBid := book.BestBid()
+	ask, hasAsk := book.BestAsk()
+	switch peg.Reference {
+	case ReferenceBestBid:
+		reference, ok = bid.Price, hasBid
+	case ReferenceBestAsk:
+		reference, ok = ask.Price, hasAsk
+	default:
+		reference, ok = book.Mid()
+	}
+	if !ok {
+		return 0, 0, false
+	}
+
+	tick := utils.ParseTickSize(tickSize)
+	if peg.Side == types.BUY {
+		price = utils.SnapPrice(reference+peg.Offset, tickSize, types.RoundDown)
+		if hasAsk && price > ask.Price-tick+1e-9 {
+			price = utils.SnapPrice(ask.Price-tick, tickSize, types.RoundDown)
+		}
+	} else {
+		price = utils.SnapPrice(reference+peg.Offset, tickSize, types.RoundUp)
+		if hasBid && price < bid.Price+tick-1e-9 {
+			price = utils.SnapPrice(bid.Price+tick, tickSize, types.RoundUp)
+		}
+	}
+	return reference, price, true
+}
+
+// post signs the replacement orders and posts them in batches
+func (p *Pegger) post(requotes []requote) (int, error) {
+	var errs error
+	orders := make([]types.PostOrdersArgs, 0, len(requotes))
+	signed := make([]requote, 0, len(requotes))
+	for _, r := range requotes {
+		if !r.post {
+			continue
+		}
+		var options *types.CreateOrderOptions
+		if r.quote.Peg.Options != nil {
+			copied := *r.quote.Peg.Options
+			options = &copied
+		}
+		signedOrder, err := p.config.Trader.CreateOrder(types.OrderArgs{
+			TokenID:     r.quote.Peg.TokenID,
+			Price:       r.price,
+			Size:        r.size,
+			Side:        r.quote.Peg.Side,
+			ExpireAfter: p.config.ExpireAfter,
+		}, options)
+		if err != nil {
+			errs = errors.Join(errs, fmt.Errorf("peg %s: failed to create order: %w", r.quote.Peg.ID, err))
+			continue
+		}
+		orders = append(orders, types.PostOrdersArgs{Order: signedOrder, OrderType: p.config.OrderType})
+		signed = append(signed, r)
+	}
+
+	posted := 0
+	for start := 0; start < len(orders); start += client.MaxBatchOrders {
+		end := start + client.MaxBatchOrders
+		if end > len(orders) {
+			end = len(orders)
+		}
+		results, err := p.config.Trader.PostOrders(orders[start:end])
+		if err != nil {
+			errs = errors.Join(errs, fmt.Errorf("failed to post pegged orders: %w", err))
+			continue
+		}
+		for i := start; i < end; i++ {
+			r := signed[i]
+			var result map[string]interface{}
+			if i-start < len(results) {
+				result = results[i-start]
+			}
+			orderID, _ := result["orderID"].(string)
+			if success, _ := result["success"].(bool); !success || orderID == "" {
+				message, _ := result["errorMsg"].(string)
+				errs = errors.Join(errs, fmt.Errorf("peg %s: order rejected: %s", r.quote.Peg.ID, message))
+				continue
+			}
+			posted++
+			p.update(r, orderID)
+		}
+	}
+	return posted, errs
+}
+
+// update records a peg's new resting order
+func (p *Pegger) update(r requote, orderID string) {
+	p.mu.Lock()
+	quote, exists := p.quotes[r.quote.Peg.ID]
+	if !exists {
+		// Removed while the order was being posted
+		p.mu.Unlock()
+		p.config.Trader.CancelOrders([]string{orderID})
+		return
+	}
+	quote.OrderID = orderID
+	quote.Price = r.price
+	quote.Reference = r.reference
+	quote.PostedAt = time.Now()
+	copied := *quote
+	p.mu.Unlock()
+
+	if p.config.OnRequote != nil {
+		p.config.OnRequote(copied)
+	}
+}
+
+// tickSize returns the tick size set in a peg's options or the token's
+func (p *Pegger) tickSize(peg Peg) (types.TickSize, error) {
+	if peg.Options != nil && peg.Options.TickSize != "" {
+		return peg.Options.TickSize, nil
+	}
+	tickSize, err := p.config.Trader.GetTickSize(peg.TokenID)
+	if err != nil {
+		return "", fmt.Errorf("peg %s: failed to get tick size: %w", peg.ID, err)
+	}
+	return tickSize, nil
+}
+
+// pollError wraps the errors of a poll
+func (p *Pegger) pollError(err error) error {
+	if err == nil {
+		return nil
+	}
+	return fmt.Errorf("failed to update some pegs: %w", err)
+}
@@ -0,0 +1,179 @@
+package peg
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/MaDal776/polymarket-go-client/pkg/risk"
+	"github.com/MaDal776/polymarket-go-client/pkg/types"
+)
+
+// Orders can be placed through the risk checks
+var _ Trader = (*risk.RiskTrader)(nil)
+
+// fakeTrader serves settable books and records batch calls. Orders in matched
+// have filled that size; a filled order can no longer be cancelled.
+type fakeTrader struct {
+	books     map[string]*types.OrderBookSummary
+	prices    []float64
+	sizes     []float64
+	posts     int
+	cancels   [][]string
+	nextID    int
+	matched   map[string]float64
+	cancelled map[string]bool
+}
+
+func (f *fakeTrader) GetTickSize(tokenID string) (types.TickSize, error) {
+	return types.TickSize001, nil
+}
+
+func (f *fakeTrader) GetOrderBooks(ctx context.Context, tokenIDs []string, concurrency int) (map[string]*types.OrderBookSummary, error) {
+	return f.books, nil
+}
+
+func (f *fakeTrader) CreateOrder(orderArgs types.OrderArgs, options *types.CreateOrderOptions) (*types.SignedOrder, error) {
+	f.prices = append(f.prices, orderArgs.Price)
+	f.sizes = append(f.sizes, orderArgs.Size)
+	return &types.SignedOrder{TokenID: orderArgs.TokenID, Side: orderArgs.Side}, nil
+}
+
+func (f *fakeTrader) PostOrders(orders []types.PostOrdersArgs) ([]map[string]interface{}, error) {
+	f.posts++
+	results := make([]map[string]interface{}, 0, len(orders))
+	for range orders {
+		f.nextID++
+		results = append(results, map[string]interface{}{"success": true, "orderID": fmt.Sprintf("order-%d", f.nextID)})
+	}
+	return results, nil
+}
+
+func (f *fakeTrader) CancelOrders(orderIDs []string) (map[string]interface{}, error) {
+	f.cancels = append(f.cancels, orderIDs)
+	if f.cancelled == nil {
+		f.cancelled = make(map[string]bool)
+	}
+	canceled := make([]interface{}, 0, len(orderIDs))
+	notCanceled := make(map[string]interface{})
+	for _, id := range orderIDs {
+		if f.filled(id) || f.cancelled[id] {
+			notCanceled[id] = "order can't be found - already canceled or matched"
+			continue
+		}
+		f.cancelled[id] = true
+		canceled = append(canceled, id)
+	}
+	return map[string]interface{}{"canceled": canceled, "not_canceled": notCanceled}, nil
+}
+
+// filled reports whether order-N matched the size of the Nth order created
+func (f *fakeTrader) filled(orderID string) bool {
+	var n int
+	fmt.Sscanf(orderID, "order-%d", &n)
+	return n > 0 && n <= len(f.sizes) && f.matched[orderID] >= f.sizes[n-1]
+}
+
+func (f *fakeTrader) GetOrder(orderID string) (*types.OpenOrder, error) {
+	status := "LIVE"
+	if f.filled(orderID) {
+		status = "MATCHED"
+	} else if f.cancelled[orderID] {
+		status = "CANCELED"
+	}
+	return &types.OpenOrder{ID: orderID, Status: status, SizeMatched: fmt.Sprint(f.matched[orderID])}, nil
+}
+
+func book(bid, ask string) *types.OrderBookSummary {
+	return &types.OrderBookSummary{
+		Bids: []types.OrderSummary{{Price: bid, Size: "100"}},
+		Asks: []types.OrderSummary{{Price: ask, Size: "100"}},
+	}
+}
+
+func TestPollRequotesDriftedPegs(t *testing.T) {
+	trader := &fakeTrader{books: map[string]*types.OrderBookSummary{"a": book("0.40", "0.50"), "b": book("0.60", "0.70")}}
+	p, err := NewPegger(Config{Trader: trader})
+	if err != nil {
+		t.Fatalf("Failed to create pegger: %v", err)
+	}
+	p.Add(Peg{ID: "bid", TokenID: "a", Side: types.BUY, Offset: -0.01, Size: 10, Tolerance: 0.01})
+	p.Add(Peg{ID: "ask", TokenID: "b", Side: types.SELL, Size: 10})
+
+	if n, err := p.Poll(context.Background()); n != 2 || err != nil {
+		t.Fatalf("Expected 2 orders posted, got %d, %v", n, err)
+	}
+	if trader.posts != 1 || trader.prices[0] != 0.7 || trader.prices[1] != 0.39 {
+		t.Fatalf("Expected one batch at 0.70 and 0.39, got %d batches at %v", trader.posts, trader.prices)
+	}
+
+	// The bid moves within tolerance and the ask moves beyond it
+	trader.books = map[string]*types.OrderBookSummary{"a": book("0.41", "0.50"), "b": book("0.60", "0.68")}
+	if n, err := p.Poll(context.Background()); n != 1 || err != nil {
+		t.Fatalf("Expected 1 order replaced, got %d, %v", n, err)
+	}
+	if len(trader.cancels) != 1 || len(trader.cancels[0]) != 1 || trader.cancels[0][0] != "order-1" {
+		t.Errorf("Expected the ask order cancelled, got %v", trader.cancels)
+	}
+	quotes := p.Quotes()
+	if quotes[0].Peg.ID != "ask" || quotes[0].OrderID != "order-3" || quotes[0].Price != 0.68 {
+		t.Errorf("Unexpected ask quote %+v", quotes[0])
+	}
+	if quotes[1].OrderID != "order-2" || quotes[1].Price != 0.39 {
+		t.Errorf("Expected the bid quote kept, got %+v", quotes[1])
+	}
+
+	if err := p.CancelAll(); err != nil || len(trader.cancels) != 2 || len(trader.cancels[1]) != 2 {
+		t.Errorf("Expected both orders cancelled in one request, got %v", trader.cancels)
+	}
+}
+
+func TestTargetDoesNotCross(t *testing.T) {
+	peg := Peg{Side: types.BUY, Reference: ReferenceMid, Offset: 0.02}
+	if _, price, ok := Target(peg, book("0.40", "0.42"), types.TickSize001); !ok || price != 0.41 {
+		t.Errorf("Expected the buy held a tick below the ask, got %v", price)
+	}
+	peg = Peg{Side: types.SELL, Reference: ReferenceBestAsk}
+	if _, _, ok := Target(peg, &types.OrderBookSummary{Bids: []types.OrderSummary{{Price: "0.4", Size: "1"}}}, types.TickSize001); ok {
+		t.Errorf("Expected a book without asks to have no target")
+	}
+}
+
+func TestPollPostsOnlyTheUnfilledSize(t *testing.T) {
+	trader := &fakeTrader{books: map[string]*types.OrderBookSummary{"a": book("0.40", "0.50")}, matched: map[string]float64{}}
+	var filled []Quote
+	p, err := NewPegger(Config{Trader: trader, OnFilled: func(quote Quote) { filled = append(filled, quote) }})
+	if err != nil {
+		t.Fatalf("Failed to create pegger: %v", err)
+	}
+	p.Add(Peg{ID: "bid", TokenID: "a", Side: types.BUY, Size: 10})
+	if n, err := p.Poll(context.Background()); n != 1 || err != nil {
+		t.Fatalf("Expected the peg posted, got %d, %v", n, err)
+	}
+
+	// 4 of the first order fill before the bid moves, so 6 are re-posted
+	trader.matched["order-1"] = 4
+	trader.books["a"] = book("0.42", "0.50")
+	if n, err := p.Poll(context.Background()); n != 1 || err != nil {
+		t.Fatalf("Expected the peg replaced, got %d, %v", n, err)
+	}
+	if trader.sizes[1] != 6 {
+		t.Errorf("Expected 6 re-posted, got %v", trader.sizes)
+	}
+	if quotes := p.Quotes(); quotes[0].Filled != 4 || quotes[0].OrderID != "order-2" {
+		t.Errorf("Unexpected quote %+v", quotes[0])
+	}
+
+	// The replacement fills completely: its cancel is refused and the peg retires
+	trader.matched["order-2"] = 6
+	trader.books["a"] = book("0.44", "0.50")
+	if n, err := p.Poll(context.Background()); n != 0 || err != nil {
+		t.Fatalf("Expected nothing posted for a filled peg, got %d, %v", n, err)
+	}
+	if len(trader.sizes) != 2 || len(p.Quotes()) != 0 {
+		t.Errorf("Expected the peg retired without posting, got sizes %v and quotes %+v", trader.sizes, p.Quotes())
+	}
+	if len(filled) != 1 || filled[0].Filled != 10 {
+		t.Errorf("Expected OnFilled for the peg, got %+v", filled)
+	}
+}
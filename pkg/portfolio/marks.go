@@ -0,0 +1,91 @@
+package portfolio
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"strconv"
+
+	"github.com/MaDal776/polymarket-go-client/pkg/types"
+)
+
+// marketMessage is a market websocket channel message, as far as marking needs it
+type marketMessage struct {
+	EventType    string               `json:"event_type"`
+	AssetID      string               `json:"asset_id"`
+	Bids         []types.OrderSummary `json:"bids"`
+	Asks         []types.OrderSummary `json:"asks"`
+	BestBid      string               `json:"best_bid"`
+	BestAsk      string               `json:"best_ask"`
+	PriceChanges []struct {
+		AssetID string `json:"asset_id"`
+		BestBid string `json:"best_bid"`
+		BestAsk string `json:"best_ask"`
+	} `json:"price_changes"`
+}
+
+// MarkFromMarketMessage marks positions from a market websocket channel
+// message, a single event or an array of them. Book snapshots mark at the
+// book's midpoint; price changes and best bid/ask updates at the midpoint of
+// the best quotes they carry. One-sided quotes and other events are ignored.
+// It returns the number of marks applied.
+func (p *Portfolio) MarkFromMarketMessage(data []byte) (int, error) {
+	var messages []marketMessage
+	if trimmed := bytes.TrimSpace(data); len(trimmed) > 0 && trimmed[0] == '[' {
+		if err := json.Unmarshal(trimmed, &messages); err != nil {
+			return 0, fmt.Errorf("failed to decode market message: %w", err)
+		}
+	} else {
+		var message marketMessage
+		if err := json.Unmarshal(trimmed, &message); err != nil {
+			return 0, fmt.Errorf("failed to decode market message: %w", err)
+		}
+		messages = append(messages, message)
+	}
+
+	marked := 0
+	for _, message := range messages {
+		switch message.EventType {
+		case "book":
+			book := types.OrderBookSummary{AssetID: message.AssetID, Bids: message.Bids, Asks: message.Asks}
+			if mid, ok := book.Mid(); ok && p.markIfHeld(message.AssetID, mid) {
+				marked++
+			}
+		case "best_bid_ask":
+			if mid, ok := quoteMid(message.BestBid, message.BestAsk); ok && p.markIfHeld(message.AssetID, mid) {
+				marked++
+			}
+		case "price_change":
+			for _, change := range message.PriceChanges {
+				if mid, ok := quoteMid(change.BestBid, change.BestAsk); ok && p.markIfHeld(change.AssetID, mid) {
+					marked++
+				}
+			}
+		}
+	}
+	return marked, nil
+}
+
+// markIfHeld marks a token's position, reporting whether one is tracked
+func (p *Portfolio) markIfHeld(tokenID string, price float64) bool {
+	if _, held := p.Position(tokenID); !held {
+		return false
+	}
+	p.Mark(tokenID, price)
+	return true
+}
+
+// quoteMid returns the midpoint of a best bid and ask, both present and
+// parseable. An empty side is reported by the channel as a bid of 0 or an
+// ask of 1.
+func quoteMid(bestBid, bestAsk string) (float64, bool) {
+	bid, err := strconv.ParseFloat(bestBid, 64)
+	if err != nil || bid <= 0 {
+		return 0, false
+	}
+	ask, err := strconv.ParseFloat(bestAsk, 64)
+	if err != nil || ask >= 1 {
+		return 0, false
+	}
+	return (bid + ask) / 2, true
+}
@@ -0,0 +1,59 @@
+package portfolio
+
+import (
+	"testing"
+
+	"github.com/MaDal776/polymarket-go-client/pkg/types"
+)
+
+func TestMarkAtZeroIsAMark(t *testing.T) {
+	p := NewPortfolio()
+	if err := p.ApplyFill(Fill{TradeID: "t1", TokenID: testToken, Side: types.BUY, Price: 0.4, Size: 10}); err != nil {
+		t.Fatalf("Failed to apply fill: %v", err)
+	}
+	if pos, _ := p.Position(testToken); pos.Marked || pos.UnrealizedPnL != 0 {
+		t.Fatalf("Expected an unmarked position, got %+v", pos)
+	}
+
+	// A resolved losing outcome is worth nothing
+	p.Mark(testToken, 0)
+	if pos, _ := p.Position(testToken); !pos.Marked || !almostEqual(pos.UnrealizedPnL, -4) {
+		t.Errorf("Expected a mark at 0 to lose 4, got %+v", pos)
+	}
+}
+
+func TestMarkFromMarketMessage(t *testing.T) {
+	p := NewPortfolio()
+	p.SetPosition("a", 10, 0.4)
+	p.SetPosition("b", 5, 0.5)
+
+	book := `{"event_type":"book","asset_id":"a","bids":[{"price":"0.44","size":"10"}],"asks":[{"price":"0.46","size":"10"}]}`
+	marked, err := p.MarkFromMarketMessage([]byte(book))
+	if err != nil || marked != 1 {
+		t.Fatalf("Expected one mark from the book, got %d (%v)", marked, err)
+	}
+	if pos, _ := p.Position("a"); !almostEqual(pos.MarkPrice, 0.45) || !almostEqual(pos.UnrealizedPnL, 0.5) {
+		t.Errorf("Expected a mark at 0.45, got %+v", pos)
+	}
+
+	// Untracked tokens and one-sided quotes are skipped
+	changes := `[{"event_type":"price_change","market":"0xm","price_changes":[` +
+		`{"asset_id":"a","price":"0.5","size":"1","side":"BUY","best_bid":"0.5","best_ask":"0.52"},` +
+		`{"asset_id":"b","price":"0.5","size":"1","side":"BUY","best_bid":"0.5","best_ask":"1"},` +
+		`{"asset_id":"c","price":"0.5","size":"1","side":"BUY","best_bid":"0.5","best_ask":"0.52"}]},` +
+		`{"event_type":"last_trade_price","asset_id":"b","price":"0.9"}]`
+	marked, err = p.MarkFromMarketMessage([]byte(changes))
+	if err != nil || marked != 1 {
+		t.Fatalf("Expected one mark from the price changes, got %d (%v)", marked, err)
+	}
+	if pos, _ := p.Position("a"); !almostEqual(pos.MarkPrice, 0.51) {
+		t.Errorf("Expected a mark at 0.51, got %v", pos.MarkPrice)
+	}
+	if pos, _ := p.Position("b"); pos.Marked {
+		t.Errorf("Expected b to stay unmarked, got %+v", pos)
+	}
+
+	if _, err := p.MarkFromMarketMessage([]byte(`{"event_type":`)); err == nil {
+		t.Error("Expected a malformed message to be rejected")
+	}
+}
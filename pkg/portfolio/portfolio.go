@@ -0,0 +1,388 @@
+package portfolio
+
+import (
+	"fmt"
+	"math"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/MaDal776/polymarket-go-client/pkg/fees"
+	"github.com/MaDal776/polymarket-go-client/pkg/types"
+)
+
+// sizeEpsilon is the smallest position size treated as non-zero
+const sizeEpsilon = 1e-9
+
+// FillDedupeWindow is the number of applied fills remembered to ignore duplicates
+const FillDedupeWindow = 10000
+
+// Fill represents a single execution against one of our orders
+type Fill struct {
+	TradeID   string          `json:"trade_id"`
+	OrderID   string          `json:"order_id"`
+	TokenID   string          `json:"token_id"`
+	Side      types.OrderSide `json:"side"`
+	Price     float64         `json:"price"`
+	Size      float64         `json:"size"`
+	Fee       float64         `json:"fee"`        // Fee valued in USDC, charged to realized PnL
+	FeeShares float64         `json:"fee_shares"` // Shares withheld from a BUY as its fee
+	Timestamp time.Time       `json:"timestamp"`
+}
+
+// Position represents the net holding in a single token
+type Position struct {
+	TokenID       string    `json:"token_id"`
+	Size          float64   `json:"size"` // Positive for long, negative for short
+	AvgPrice      float64   `json:"avg_price"`
+	RealizedPnL   float64   `json:"realized_pnl"`
+	UnrealizedPnL float64   `json:"unrealized_pnl"`
+	MarkPrice     float64   `json:"mark_price"`
+	Marked        bool      `json:"marked"` // MarkPrice was set; a mark of 0 is a valid price
+	Fees          float64   `json:"fees"`
+	UpdatedAt     time.Time `json:"updated_at"`
+}
+
+// MidpointSource provides midpoint prices used to mark positions
+type MidpointSource interface {
+	GetMidpoint(tokenID string) (*types.MidpointResponse, error)
+}
+
+// Portfolio tracks positions and PnL built from fills
+type Portfolio struct {
+	mu        sync.RWMutex
+	positions map[string]*Position
+	seen      map[string]bool
+	seenOrder []string // Keys of seen, oldest first
+}
+
+// NewPortfolio creates an empty portfolio
+func NewPortfolio() *Portfolio {
+	return &Portfolio{
+		positions: make(map[string]*Position),
+		seen:      make(map[string]bool),
+	}
+}
+
+// ApplyFill updates the position for the fill's token.
+// Fills carrying a TradeID that is among the last FillDedupeWindow applied
+// are ignored.
+func (p *Portfolio) ApplyFill(fill Fill) error {
+	if fill.TokenID == "" {
+		return fmt.Errorf("fill is missing token ID")
+	}
+	if fill.Size <= 0 {
+		return fmt.Errorf("invalid fill size: %f", fill.Size)
+	}
+	if fill.Price < 0 || fill.Price > 1 {
+		return fmt.Errorf("invalid fill price: %f", fill.Price)
+	}
+
+	if fill.FeeShares < 0 || fill.FeeShares >= fill.Size {
+		return fmt.Errorf("invalid fill fee shares: %f", fill.FeeShares)
+	}
+
+	// A BUY receives its size less the shares withheld as its fee
+	size := fill.Size
+	var signedSize float64
+	switch fill.Side {
+	case types.BUY:
+		size -= fill.FeeShares
+		signedSize = size
+	case types.SELL:
+		if fill.FeeShares != 0 {
+			return fmt.Errorf("SELL fill cannot carry fee shares")
+		}
+		signedSize = -size
+	default:
+		return fmt.Errorf("invalid fill side: %s", fill.Side)
+	}
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if fill.TradeID != "" {
+		key := fill.TradeID + "/" + fill.OrderID
+		if p.seen[key] {
+			return nil
+		}
+		p.seen[key] = true
+		p.seenOrder = append(p.seenOrder, key)
+		if len(p.seenOrder) > FillDedupeWindow {
+			delete(p.seen, p.seenOrder[0])
+			p.seenOrder = p.seenOrder[1:]
+		}
+	}
+
+	pos, exists := p.positions[fill.TokenID]
+	if !exists {
+		pos = &Position{TokenID: fill.TokenID}
+		p.positions[fill.TokenID] = pos
+	}
+
+	if pos.Size == 0 || sameSign(pos.Size, signedSize) {
+		// Increasing the position: blend the average entry price
+		newSize := pos.Size + signedSize
+		pos.AvgPrice = (math.Abs(pos.Size)*pos.AvgPrice + size*fill.Price) / math.Abs(newSize)
+		pos.Size = newSize
+	} else {
+		// Reducing (and possibly flipping) the position: realize PnL on the closed part
+		closed := math.Min(math.Abs(pos.Size), size)
+		direction := 1.0
+		if pos.Size < 0 {
+			direction = -1.0
+		}
+		pos.RealizedPnL += (fill.Price - pos.AvgPrice) * closed * direction
+		pos.Size += signedSize
+
+		if math.Abs(pos.Size) < sizeEpsilon {
+			pos.Size = 0
+			pos.AvgPrice = 0
+		} else if !sameSign(pos.Size, direction) {
+			// Flipped through zero: the remainder opens at the fill price
+			pos.AvgPrice = fill.Price
+		}
+	}
+
+	pos.Fees += fill.Fee
+	pos.RealizedPnL -= fill.Fee
+	pos.UnrealizedPnL = unrealized(pos)
+
+	pos.UpdatedAt = fill.Timestamp
+	if pos.UpdatedAt.IsZero() {
+		pos.UpdatedAt = time.Now()
+	}
+	return nil
+}
+
+// ApplyTrades converts polled trades into fills for the given address and applies them.
+// It returns the number of fills applied.
+func (p *Portfolio) ApplyTrades(trades []types.Trade, address string) (int, error) {
+	applied := 0
+	for _, trade := range trades {
+		fills, err := FillsFromTrade(trade, address)
+		if err != nil {
+			return applied, err
+		}
+		for _, fill := range fills {
+			if err := p.ApplyFill(fill); err != nil {
+				return applied, fmt.Errorf("failed to apply trade %s: %w", trade.ID, err)
+			}
+			applied++
+		}
+	}
+	return applied, nil
+}
+
+// SetPosition overwrites the size and average price of a token's position,
+// keeping its accumulated PnL and fees. It is used to correct drift.
+func (p *Portfolio) SetPosition(tokenID string, size, avgPrice float64) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	pos, exists := p.positions[tokenID]
+	if !exists {
+		pos = &Position{TokenID: tokenID}
+		p.positions[tokenID] = pos
+	}
+	pos.Size = size
+	pos.AvgPrice = avgPrice
+	if size == 0 {
+		pos.AvgPrice = 0
+	}
+	pos.UnrealizedPnL = unrealized(pos)
+	pos.UpdatedAt = time.Now()
+}
+
+// Mark sets the mark price for a token and recomputes its unrealized PnL
+func (p *Portfolio) Mark(tokenID string, price float64) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	pos, exists := p.positions[tokenID]
+	if !exists {
+		return
+	}
+	pos.MarkPrice = price
+	pos.Marked = true
+	pos.UnrealizedPnL = unrealized(pos)
+}
+
+// MarkToMarket marks every open position against the midpoint from src
+func (p *Portfolio) MarkToMarket(src MidpointSource) error {
+	for _, tokenID := range p.openTokens() {
+		resp, err := src.GetMidpoint(tokenID)
+		if err != nil {
+			return fmt.Errorf("failed to get midpoint for %s: %w", tokenID, err)
+		}
+		mid, err := strconv.ParseFloat(resp.Mid, 64)
+		if err != nil {
+			return fmt.Errorf("invalid midpoint %q for %s: %w", resp.Mid, tokenID, err)
+		}
+		p.Mark(tokenID, mid)
+	}
+	return nil
+}
+
+// Position returns a copy of the position for a token
+func (p *Portfolio) Position(tokenID string) (Position, bool) {
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+
+	pos, exists := p.positions[tokenID]
+	if !exists {
+		return Position{}, false
+	}
+	return *pos, true
+}
+
+// Positions returns copies of all tracked positions sorted by token ID
+func (p *Portfolio) Positions() []Position {
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+
+	positions := make([]Position, 0, len(p.positions))
+	for _, pos := range p.positions {
+		positions = append(positions, *pos)
+	}
+	sort.Slice(positions, func(i, j int) bool {
+		return positions[i].TokenID < positions[j].TokenID
+	})
+	return positions
+}
+
+// TotalPnL returns the realized and unrealized PnL summed across all positions
+func (p *Portfolio) TotalPnL() (realized, unrealized float64) {
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+
+	for _, pos := range p.positions {
+		realized += pos.RealizedPnL
+		unrealized += pos.UnrealizedPnL
+	}
+	return realized, unrealized
+}
+
+// openTokens returns the token IDs with a non-zero position
+func (p *Portfolio) openTokens() []string {
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+
+	tokens := make([]string, 0, len(p.positions))
+	for tokenID, pos := range p.positions {
+		if pos.Size != 0 {
+			tokens = append(tokens, tokenID)
+		}
+	}
+	return tokens
+}
+
+// FillsFromTrade extracts the fills belonging to address from a trade, either
+// polled or received as a user websocket trade event. Taker trades yield a
+// single fill; maker trades yield one fill per maker order of ours, matched by
+// maker address or, for websocket events, by the API key owning the event.
+// Websocket events carry no trader side: they are taker fills when none of
+// their maker orders is ours. Fees are charged at the trade's fee rate, in
+// shares on a BUY and in USDC on a SELL.
+func FillsFromTrade(trade types.Trade, address string) ([]Fill, error) {
+	timestamp := parseUnix(trade.MatchTime)
+
+	ours := make([]types.MakerOrder, 0)
+	for _, makerOrder := range trade.MakerOrders {
+		if strings.EqualFold(makerOrder.MakerAddress, address) ||
+			(makerOrder.Owner != "" && makerOrder.Owner == trade.Owner) {
+			ours = append(ours, makerOrder)
+		}
+	}
+
+	if strings.EqualFold(trade.TraderSide, "TAKER") || (trade.TraderSide == "" && len(ours) == 0) {
+		fill, err := tradeFill(trade, trade.TakerOrderID, trade.AssetID, trade.Side, trade.Price, trade.Size, trade.FeeRateBps)
+		if err != nil {
+			return nil, err
+		}
+		fill.Timestamp = timestamp
+		return []Fill{fill}, nil
+	}
+
+	fills := make([]Fill, 0, len(ours))
+	for _, makerOrder := range ours {
+		side := makerOrder.Side
+		if side == "" {
+			// Websocket maker orders carry no side: a maker trades against
+			// the taker's side on the same token, with it on the complement
+			side = trade.Side
+			if makerOrder.AssetID == trade.AssetID {
+				side = opposite(trade.Side)
+			}
+		}
+		fill, err := tradeFill(trade, makerOrder.OrderID, makerOrder.AssetID, side, makerOrder.Price, makerOrder.MatchedAmount, makerOrder.FeeRateBps)
+		if err != nil {
+			return nil, err
+		}
+		fill.Timestamp = timestamp
+		fills = append(fills, fill)
+	}
+	return fills, nil
+}
+
+// tradeFill parses one order's share of a trade into a fill
+func tradeFill(trade types.Trade, orderID, tokenID string, side types.OrderSide, price, size, feeRateBps string) (Fill, error) {
+	fillPrice, err := strconv.ParseFloat(price, 64)
+	if err != nil {
+		return Fill{}, fmt.Errorf("invalid price for order %s in trade %s: %w", orderID, trade.ID, err)
+	}
+	fillSize, err := strconv.ParseFloat(size, 64)
+	if err != nil {
+		return Fill{}, fmt.Errorf("invalid size for order %s in trade %s: %w", orderID, trade.ID, err)
+	}
+	// An empty rate means no fee
+	bps := 0.0
+	if feeRateBps != "" {
+		if bps, err = strconv.ParseFloat(feeRateBps, 64); err != nil {
+			return Fill{}, fmt.Errorf("invalid fee rate for order %s in trade %s: %w", orderID, trade.ID, err)
+		}
+	}
+	fee := fees.Calculate(side, fillPrice, fillSize, int(math.Round(bps)))
+	return Fill{
+		TradeID:   trade.ID,
+		OrderID:   orderID,
+		TokenID:   tokenID,
+		Side:      side,
+		Price:     fillPrice,
+		Size:      fillSize,
+		Fee:       fee.Value,
+		FeeShares: fee.Shares,
+	}, nil
+}
+
+// opposite returns the other side of the book
+func opposite(side types.OrderSide) types.OrderSide {
+	if side == types.BUY {
+		return types.SELL
+	}
+	return types.BUY
+}
+
+// unrealized computes the unrealized PnL of a position at its mark price
+func unrealized(pos *Position) float64 {
+	if pos.Size == 0 || !pos.Marked {
+		return 0
+	}
+	return (pos.MarkPrice - pos.AvgPrice) * pos.Size
+}
+
+// sameSign reports whether a and b have the same sign
+func sameSign(a, b float64) bool {
+	return (a > 0 && b > 0) || (a < 0 && b < 0)
+}
+
+// parseUnix parses a unix-seconds string, returning the zero time on failure
+func parseUnix(value string) time.Time {
+	seconds, err := strconv.ParseInt(value, 10, 64)
+	if err != nil {
+		return time.Time{}
+	}
+	return time.Unix(seconds, 0)
+}
@@ -0,0 +1,198 @@
+package portfolio
+
+import (
+	"fmt"
+	"math"
+	"testing"
+
+	"github.com/MaDal776/polymarket-go-client/pkg/types"
+)
+
+const testToken = "91094360697357622623953793720402150934374522251651348543981406747516093190659"
+
+func almostEqual(a, b float64) bool {
+	return math.Abs(a-b) < 1e-9
+}
+
+func TestApplyFillAveragesAndRealizes(t *testing.T) {
+	p := NewPortfolio()
+
+	fills := []Fill{
+		{TradeID: "t1", TokenID: testToken, Side: types.BUY, Price: 0.40, Size: 10},
+		{TradeID: "t2", TokenID: testToken, Side: types.BUY, Price: 0.60, Size: 10},
+		{TradeID: "t3", TokenID: testToken, Side: types.SELL, Price: 0.70, Size: 5},
+	}
+	for _, fill := range fills {
+		if err := p.ApplyFill(fill); err != nil {
+			t.Fatalf("Failed to apply fill: %v", err)
+		}
+	}
+
+	pos, ok := p.Position(testToken)
+	if !ok {
+		t.Fatal("Expected position to exist")
+	}
+	if !almostEqual(pos.Size, 15) {
+		t.Errorf("Expected size 15, got %f", pos.Size)
+	}
+	if !almostEqual(pos.AvgPrice, 0.5) {
+		t.Errorf("Expected avg price 0.5, got %f", pos.AvgPrice)
+	}
+	if !almostEqual(pos.RealizedPnL, 1.0) {
+		t.Errorf("Expected realized PnL 1.0, got %f", pos.RealizedPnL)
+	}
+
+	p.Mark(testToken, 0.55)
+	pos, _ = p.Position(testToken)
+	if !almostEqual(pos.UnrealizedPnL, 0.75) {
+		t.Errorf("Expected unrealized PnL 0.75, got %f", pos.UnrealizedPnL)
+	}
+}
+
+func TestApplyFillIgnoresDuplicates(t *testing.T) {
+	p := NewPortfolio()
+	fill := Fill{TradeID: "t1", TokenID: testToken, Side: types.BUY, Price: 0.5, Size: 10}
+
+	for i := 0; i < 2; i++ {
+		if err := p.ApplyFill(fill); err != nil {
+			t.Fatalf("Failed to apply fill: %v", err)
+		}
+	}
+
+	pos, _ := p.Position(testToken)
+	if !almostEqual(pos.Size, 10) {
+		t.Errorf("Expected duplicate fill to be ignored, got size %f", pos.Size)
+	}
+}
+
+func TestFillsFromMakerTrade(t *testing.T) {
+	trade := types.Trade{
+		ID:         "trade-1",
+		TraderSide: "MAKER",
+		MatchTime:  "1700000000",
+		MakerOrders: []types.MakerOrder{
+			{OrderID: "o1", MakerAddress: "0xAbC", MatchedAmount: "4", Price: "0.3", AssetID: testToken, Side: types.SELL},
+			{OrderID: "o2", MakerAddress: "0xdef", MatchedAmount: "6", Price: "0.3", AssetID: testToken, Side: types.SELL},
+		},
+	}
+
+	fills, err := FillsFromTrade(trade, "0xabc")
+	if err != nil {
+		t.Fatalf("Failed to extract fills: %v", err)
+	}
+	if len(fills) != 1 {
+		t.Fatalf("Expected 1 fill, got %d", len(fills))
+	}
+	if fills[0].OrderID != "o1" || !almostEqual(fills[0].Size, 4) {
+		t.Errorf("Unexpected fill: %+v", fills[0])
+	}
+}
+
+func TestFillsFromWebsocketTradeEvent(t *testing.T) {
+	// A user channel trade event: no trader side and no maker addresses
+	event := types.Trade{
+		ID:           "trade-2",
+		TakerOrderID: "taker-1",
+		AssetID:      testToken,
+		Side:         types.BUY,
+		Price:        "0.4",
+		Size:         "10",
+		FeeRateBps:   "100",
+		Owner:        "key-1",
+		MatchTime:    "1700000000",
+		MakerOrders: []types.MakerOrder{
+			{OrderID: "m1", Owner: "key-2", MatchedAmount: "10", Price: "0.4", AssetID: testToken},
+		},
+	}
+
+	fills, err := FillsFromTrade(event, "0xabc")
+	if err != nil {
+		t.Fatalf("Failed to extract fills: %v", err)
+	}
+	if len(fills) != 1 || fills[0].OrderID != "taker-1" || fills[0].Side != types.BUY || !almostEqual(fills[0].Size, 10) {
+		t.Fatalf("Expected the taker fill, got %+v", fills)
+	}
+	// 1% of min(0.4, 0.6) per share, withheld in shares: 0.04 / 0.4
+	if !almostEqual(fills[0].Fee, 0.04) || !almostEqual(fills[0].FeeShares, 0.1) {
+		t.Errorf("Expected fee 0.04 as 0.1 shares, got %f and %f", fills[0].Fee, fills[0].FeeShares)
+	}
+
+	// The same event delivered to the maker's API key
+	event.Owner = "key-2"
+	fills, err = FillsFromTrade(event, "0xabc")
+	if err != nil {
+		t.Fatalf("Failed to extract fills: %v", err)
+	}
+	if len(fills) != 1 || fills[0].OrderID != "m1" || fills[0].Side != types.SELL {
+		t.Fatalf("Expected a SELL fill for the maker order, got %+v", fills)
+	}
+	if fills[0].Fee != 0 {
+		t.Errorf("Expected no fee without a maker fee rate, got %f", fills[0].Fee)
+	}
+}
+
+func TestApplyFillDeductsFees(t *testing.T) {
+	p := NewPortfolio()
+	fills := []Fill{
+		{TradeID: "t1", TokenID: testToken, Side: types.BUY, Price: 0.4, Size: 10, Fee: 0.04},
+		{TradeID: "t2", TokenID: testToken, Side: types.SELL, Price: 0.5, Size: 10, Fee: 0.05},
+	}
+	for _, fill := range fills {
+		if err := p.ApplyFill(fill); err != nil {
+			t.Fatalf("Failed to apply fill: %v", err)
+		}
+	}
+
+	pos, _ := p.Position(testToken)
+	if !almostEqual(pos.Fees, 0.09) || !almostEqual(pos.RealizedPnL, 0.91) {
+		t.Errorf("Expected fees 0.09 and net realized PnL 0.91, got %f and %f", pos.Fees, pos.RealizedPnL)
+	}
+}
+
+func TestApplyTradesChargesBuyFeeInShares(t *testing.T) {
+	p := NewPortfolio()
+	trades := []types.Trade{
+		{ID: "t1", TraderSide: "TAKER", TakerOrderID: "o1", AssetID: testToken, Side: types.BUY, Price: "0.4", Size: "100", FeeRateBps: "200"},
+		{ID: "t2", TraderSide: "TAKER", TakerOrderID: "o2", AssetID: testToken, Side: types.SELL, Price: "0.5", Size: "50", FeeRateBps: "200"},
+	}
+
+	if _, err := p.ApplyTrades(trades[:1], "0xabc"); err != nil {
+		t.Fatalf("Failed to apply trades: %v", err)
+	}
+	// 2% of min(0.4, 0.6) * 100 / 0.4 = 2 shares withheld, worth 0.8 USDC
+	pos, _ := p.Position(testToken)
+	if !almostEqual(pos.Size, 98) || !almostEqual(pos.AvgPrice, 0.4) {
+		t.Errorf("Expected 98 shares at 0.4 after the fee, got %f at %f", pos.Size, pos.AvgPrice)
+	}
+	if !almostEqual(pos.Fees, 0.8) || !almostEqual(pos.RealizedPnL, -0.8) {
+		t.Errorf("Expected a 0.8 fee charged to PnL, got %f and %f", pos.Fees, pos.RealizedPnL)
+	}
+
+	if _, err := p.ApplyTrades(trades[1:], "0xabc"); err != nil {
+		t.Fatalf("Failed to apply trades: %v", err)
+	}
+	// The SELL fee is USDC only: 2% of 0.5 * 50 = 0.5, leaving the size alone
+	pos, _ = p.Position(testToken)
+	if !almostEqual(pos.Size, 48) || !almostEqual(pos.Fees, 1.3) {
+		t.Errorf("Expected 48 shares and fees 1.3, got %f and %f", pos.Size, pos.Fees)
+	}
+	if !almostEqual(pos.RealizedPnL, 0.1*50-1.3) {
+		t.Errorf("Expected realized PnL %f, got %f", 0.1*50-1.3, pos.RealizedPnL)
+	}
+}
+
+func TestApplyFillForgetsOldestBeyondWindow(t *testing.T) {
+	p := NewPortfolio()
+	for i := 0; i <= FillDedupeWindow; i++ {
+		fill := Fill{TradeID: fmt.Sprintf("t%d", i), TokenID: testToken, Side: types.BUY, Price: 0.5, Size: 1}
+		if err := p.ApplyFill(fill); err != nil {
+			t.Fatalf("Failed to apply fill: %v", err)
+		}
+	}
+	if len(p.seen) != FillDedupeWindow || len(p.seenOrder) != FillDedupeWindow {
+		t.Fatalf("Expected %d remembered fills, got %d", FillDedupeWindow, len(p.seen))
+	}
+	if p.seen["t0/"] || !p.seen[fmt.Sprintf("t%d/", FillDedupeWindow)] {
+		t.Errorf("Expected the oldest fill to be forgotten and the newest remembered")
+	}
+}
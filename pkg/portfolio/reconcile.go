@@ -0,0 +1,310 @@
+package portfolio
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"sort"
+	"strconv"
+	"time"
+
+	"github.com/MaDal776/polymarket-go-client/pkg/types"
+)
+
+// DriftKind identifies the type of discrepancy found during reconciliation
+type DriftKind string
+
+const (
+	DriftPositionSize   DriftKind = "POSITION_SIZE"    // Local and remote sizes differ
+	DriftMissingLocal   DriftKind = "MISSING_LOCAL"    // Remote position not tracked locally
+	DriftOrderNotRemote DriftKind = "ORDER_NOT_REMOTE" // Local open order unknown to the server
+	DriftOrderNotLocal  DriftKind = "ORDER_NOT_LOCAL"  // Server open order unknown locally
+	DriftOrderSize      DriftKind = "ORDER_SIZE"       // Local and remote unmatched order sizes differ
+)
+
+// Drift describes a single discrepancy between local and server state
+type Drift struct {
+	Kind       DriftKind `json:"kind"`
+	TokenID    string    `json:"token_id,omitempty"`
+	OrderID    string    `json:"order_id,omitempty"`
+	LocalSize  float64   `json:"local_size,omitempty"`
+	RemoteSize float64   `json:"remote_size,omitempty"`
+	Corrected  bool      `json:"corrected"`
+}
+
+// ReconcileReport is the result of a single reconciliation pass
+type ReconcileReport struct {
+	Time   time.Time `json:"time"`
+	Drifts []Drift   `json:"drifts"` // Sorted by kind, token and order ID
+}
+
+// HasDrift reports whether any discrepancy was found
+func (r *ReconcileReport) HasDrift() bool {
+	return len(r.Drifts) > 0
+}
+
+// PositionSource provides server-side positions (e.g. the data API client)
+type PositionSource interface {
+	GetPositions(user string) ([]types.UserPosition, error)
+}
+
+// CompletePositionSource is a PositionSource whose GetPositions returns every
+// position or fails, such as dataapi.DataClient. Only with one is a token
+// missing from the server's positions known to be flat, so AutoCorrect zeroes
+// it; with other sources the drift is reported but not corrected.
+type CompletePositionSource interface {
+	PositionSource
+	CompletePositions() bool
+}
+
+// OpenOrderSource provides server-side open orders (e.g. the CLOB client)
+type OpenOrderSource interface {
+	GetOpenOrders(params *types.OpenOrderParams) ([]types.OpenOrder, error)
+}
+
+// LocalOrder is an order believed open locally
+type LocalOrder struct {
+	ID        string
+	TokenID   string
+	Remaining float64 // Unmatched size
+}
+
+// OrderCorrector brings locally tracked orders in line with the server, e.g.
+// an ordermanager.OrderManager
+type OrderCorrector interface {
+	// ApplyOpenOrder updates a tracked order from the server's copy, reporting
+	// whether the order is tracked
+	ApplyOpenOrder(order types.OpenOrder) bool
+	// Refresh re-reads a tracked order the server no longer lists as open
+	Refresh(orderID string) error
+}
+
+// ReconcilerConfig configures a Reconciler
+type ReconcilerConfig struct {
+	User          string                        // Address holding the positions (funder / proxy wallet)
+	Positions     PositionSource                // Required
+	Orders        OpenOrderSource               // Optional; order checks are skipped when nil
+	LocalOrders   func() []LocalOrder           // Optional; orders believed open locally
+	Tolerance     float64                       // Size difference ignored as rounding noise
+	AutoCorrect   bool                          // Overwrite local positions with server sizes, and correct orders through CorrectOrders
+	CorrectOrders OrderCorrector                // Optional; with AutoCorrect, updates local orders that drifted
+	Interval      time.Duration                 // Interval used by Run
+	OnReport      func(report *ReconcileReport) // Called after each pass in Run
+	OnError       func(err error)               // Called when a pass in Run fails
+}
+
+// Reconciler compares locally tracked state against the server
+type Reconciler struct {
+	portfolio *Portfolio
+	config    ReconcilerConfig
+}
+
+// NewReconciler creates a reconciler for the given portfolio
+func NewReconciler(p *Portfolio, config ReconcilerConfig) (*Reconciler, error) {
+	if p == nil {
+		return nil, fmt.Errorf("portfolio is required")
+	}
+	if config.Positions == nil {
+		return nil, fmt.Errorf("position source is required")
+	}
+	if config.User == "" {
+		return nil, fmt.Errorf("user address is required")
+	}
+	if config.Tolerance <= 0 {
+		config.Tolerance = 1e-6
+	}
+	if config.Interval <= 0 {
+		config.Interval = time.Minute
+	}
+
+	return &Reconciler{
+		portfolio: p,
+		config:    config,
+	}, nil
+}
+
+// Reconcile runs a single reconciliation pass
+func (r *Reconciler) Reconcile() (*ReconcileReport, error) {
+	report := &ReconcileReport{Time: time.Now()}
+
+	if err := r.reconcilePositions(report); err != nil {
+		return nil, err
+	}
+	if err := r.reconcileOrders(report); err != nil {
+		return nil, err
+	}
+
+	sort.Slice(report.Drifts, func(i, j int) bool {
+		a, b := report.Drifts[i], report.Drifts[j]
+		if a.Kind != b.Kind {
+			return a.Kind < b.Kind
+		}
+		if a.TokenID != b.TokenID {
+			return a.TokenID < b.TokenID
+		}
+		return a.OrderID < b.OrderID
+	})
+	return report, nil
+}
+
+// Run reconciles every Interval until ctx is cancelled
+func (r *Reconciler) Run(ctx context.Context) {
+	ticker := time.NewTicker(r.config.Interval)
+	defer ticker.Stop()
+
+	for {
+		report, err := r.Reconcile()
+		if err != nil {
+			if r.config.OnError != nil {
+				r.config.OnError(err)
+			}
+		} else if r.config.OnReport != nil {
+			r.config.OnReport(report)
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// reconcilePositions compares portfolio positions with the server's
+func (r *Reconciler) reconcilePositions(report *ReconcileReport) error {
+	remote, err := r.config.Positions.GetPositions(r.config.User)
+	if err != nil {
+		return fmt.Errorf("failed to get remote positions: %w", err)
+	}
+
+	remoteByToken := make(map[string]types.UserPosition, len(remote))
+	for _, pos := range remote {
+		remoteByToken[pos.Asset] = pos
+	}
+	complete := false
+	if source, ok := r.config.Positions.(CompletePositionSource); ok {
+		complete = source.CompletePositions()
+	}
+
+	// Positions tracked locally
+	for _, local := range r.portfolio.Positions() {
+		remotePos, exists := remoteByToken[local.TokenID]
+		remoteSize := 0.0
+		if exists {
+			remoteSize = remotePos.Size
+		}
+		if math.Abs(local.Size-remoteSize) <= r.config.Tolerance {
+			continue
+		}
+
+		drift := Drift{
+			Kind:       DriftPositionSize,
+			TokenID:    local.TokenID,
+			LocalSize:  local.Size,
+			RemoteSize: remoteSize,
+		}
+		// A position missing from a partial list may simply be on a page not fetched
+		if r.config.AutoCorrect && (exists || complete) {
+			r.portfolio.SetPosition(local.TokenID, remoteSize, remotePos.AvgPrice)
+			drift.Corrected = true
+		}
+		report.Drifts = append(report.Drifts, drift)
+	}
+
+	// Positions only known to the server
+	for tokenID, remotePos := range remoteByToken {
+		if _, exists := r.portfolio.Position(tokenID); exists {
+			continue
+		}
+		if math.Abs(remotePos.Size) <= r.config.Tolerance {
+			continue
+		}
+
+		drift := Drift{
+			Kind:       DriftMissingLocal,
+			TokenID:    tokenID,
+			RemoteSize: remotePos.Size,
+		}
+		if r.config.AutoCorrect {
+			r.portfolio.SetPosition(tokenID, remotePos.Size, remotePos.AvgPrice)
+			drift.Corrected = true
+		}
+		report.Drifts = append(report.Drifts, drift)
+	}
+
+	return nil
+}
+
+// reconcileOrders compares locally known open orders with the server's:
+// orders missing on either side and orders whose unmatched sizes differ
+func (r *Reconciler) reconcileOrders(report *ReconcileReport) error {
+	if r.config.Orders == nil || r.config.LocalOrders == nil {
+		return nil
+	}
+
+	remote, err := r.config.Orders.GetOpenOrders(nil)
+	if err != nil {
+		return fmt.Errorf("failed to get remote open orders: %w", err)
+	}
+
+	remoteByID := make(map[string]types.OpenOrder, len(remote))
+	for _, order := range remote {
+		remoteByID[order.ID] = order
+	}
+
+	localIDs := make(map[string]bool)
+	for _, local := range r.config.LocalOrders() {
+		localIDs[local.ID] = true
+		remoteOrder, exists := remoteByID[local.ID]
+		if !exists {
+			drift := Drift{Kind: DriftOrderNotRemote, TokenID: local.TokenID, OrderID: local.ID, LocalSize: local.Remaining}
+			drift.Corrected = r.refreshOrder(local.ID)
+			report.Drifts = append(report.Drifts, drift)
+			continue
+		}
+
+		remoteSize := remaining(remoteOrder)
+		if math.Abs(local.Remaining-remoteSize) <= r.config.Tolerance {
+			continue
+		}
+		drift := Drift{Kind: DriftOrderSize, TokenID: local.TokenID, OrderID: local.ID, LocalSize: local.Remaining, RemoteSize: remoteSize}
+		drift.Corrected = r.applyOrder(remoteOrder)
+		report.Drifts = append(report.Drifts, drift)
+	}
+
+	for id, remoteOrder := range remoteByID {
+		if localIDs[id] {
+			continue
+		}
+		drift := Drift{Kind: DriftOrderNotLocal, TokenID: remoteOrder.AssetID, OrderID: id, RemoteSize: remaining(remoteOrder)}
+		drift.Corrected = r.applyOrder(remoteOrder)
+		report.Drifts = append(report.Drifts, drift)
+	}
+
+	return nil
+}
+
+// applyOrder passes the server's copy of an order to CorrectOrders when
+// correcting, reporting whether it was taken
+func (r *Reconciler) applyOrder(order types.OpenOrder) bool {
+	if !r.config.AutoCorrect || r.config.CorrectOrders == nil {
+		return false
+	}
+	return r.config.CorrectOrders.ApplyOpenOrder(order)
+}
+
+// refreshOrder re-reads an order the server no longer lists when correcting,
+// reporting whether that succeeded
+func (r *Reconciler) refreshOrder(orderID string) bool {
+	if !r.config.AutoCorrect || r.config.CorrectOrders == nil {
+		return false
+	}
+	return r.config.CorrectOrders.Refresh(orderID) == nil
+}
+
+// remaining returns the unmatched size of a server order
+func remaining(order types.OpenOrder) float64 {
+	original, _ := strconv.ParseFloat(order.OriginalSize, 64)
+	matched, _ := strconv.ParseFloat(order.SizeMatched, 64)
+	return math.Max(original-matched, 0)
+}
@@ -0,0 +1,203 @@
+package portfolio
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/MaDal776/polymarket-go-client/pkg/dataapi"
+	"github.com/MaDal776/polymarket-go-client/pkg/types"
+)
+
+// The data API client pages through every position
+var _ CompletePositionSource = (*dataapi.DataClient)(nil)
+
+// fakeServer serves fixed positions and open orders
+type fakeServer struct {
+	positions []types.UserPosition
+	orders    []types.OpenOrder
+	err       error
+}
+
+func (f *fakeServer) GetPositions(user string) ([]types.UserPosition, error) {
+	return f.positions, f.err
+}
+
+func (f *fakeServer) GetOpenOrders(params *types.OpenOrderParams) ([]types.OpenOrder, error) {
+	return f.orders, f.err
+}
+
+// fakeCorrector tracks the orders listed in tracked and records corrections
+type fakeCorrector struct {
+	tracked   map[string]bool
+	applied   []string
+	refreshed []string
+}
+
+func (f *fakeCorrector) ApplyOpenOrder(order types.OpenOrder) bool {
+	if !f.tracked[order.ID] {
+		return false
+	}
+	f.applied = append(f.applied, order.ID)
+	return true
+}
+
+func (f *fakeCorrector) Refresh(orderID string) error {
+	f.refreshed = append(f.refreshed, orderID)
+	return nil
+}
+
+func TestReconcilePositions(t *testing.T) {
+	p := NewPortfolio()
+	p.SetPosition("a", 10, 0.5)
+	p.SetPosition("b", 5, 0.4)
+	server := &fakeServer{positions: []types.UserPosition{
+		{Asset: "a", Size: 10, AvgPrice: 0.5},
+		{Asset: "b", Size: 7, AvgPrice: 0.45},
+		{Asset: "c", Size: 3, AvgPrice: 0.2},
+	}}
+
+	r, err := NewReconciler(p, ReconcilerConfig{User: "0xme", Positions: server})
+	if err != nil {
+		t.Fatalf("NewReconciler: %v", err)
+	}
+	report, err := r.Reconcile()
+	if err != nil {
+		t.Fatalf("Reconcile: %v", err)
+	}
+	want := []Drift{
+		{Kind: DriftMissingLocal, TokenID: "c", RemoteSize: 3},
+		{Kind: DriftPositionSize, TokenID: "b", LocalSize: 5, RemoteSize: 7},
+	}
+	if len(report.Drifts) != len(want) {
+		t.Fatalf("Expected %d drifts, got %+v", len(want), report.Drifts)
+	}
+	for i := range want {
+		if report.Drifts[i] != want[i] {
+			t.Errorf("Drift %d: expected %+v, got %+v", i, want[i], report.Drifts[i])
+		}
+	}
+	if pos, _ := p.Position("b"); pos.Size != 5 {
+		t.Errorf("Expected the position to be left alone without AutoCorrect, got %v", pos.Size)
+	}
+
+	r.config.AutoCorrect = true
+	if report, _ = r.Reconcile(); !report.Drifts[0].Corrected || !report.Drifts[1].Corrected {
+		t.Errorf("Expected the drifts to be corrected, got %+v", report.Drifts)
+	}
+	if report, _ = r.Reconcile(); report.HasDrift() {
+		t.Errorf("Expected no drift after correcting, got %+v", report.Drifts)
+	}
+}
+
+// completeServer is a fakeServer known to list every position
+type completeServer struct {
+	fakeServer
+}
+
+func (c *completeServer) CompletePositions() bool {
+	return true
+}
+
+func TestReconcileZeroesMissingPositionsOnlyWhenComplete(t *testing.T) {
+	for _, complete := range []bool{false, true} {
+		p := NewPortfolio()
+		p.SetPosition("a", 10, 0.5)
+		var source PositionSource = &fakeServer{}
+		if complete {
+			source = &completeServer{}
+		}
+
+		r, err := NewReconciler(p, ReconcilerConfig{User: "0xme", Positions: source, AutoCorrect: true})
+		if err != nil {
+			t.Fatalf("NewReconciler: %v", err)
+		}
+		report, err := r.Reconcile()
+		if err != nil {
+			t.Fatalf("Reconcile: %v", err)
+		}
+		if len(report.Drifts) != 1 || report.Drifts[0].Corrected != complete {
+			t.Errorf("complete=%v: unexpected drifts %+v", complete, report.Drifts)
+		}
+		want := 10.0
+		if complete {
+			want = 0
+		}
+		if pos, _ := p.Position("a"); pos.Size != want {
+			t.Errorf("complete=%v: expected size %v, got %v", complete, want, pos.Size)
+		}
+	}
+}
+
+func TestReconcileOrders(t *testing.T) {
+	server := &fakeServer{orders: []types.OpenOrder{
+		{ID: "0x1", AssetID: "a", OriginalSize: "10", SizeMatched: "0"},
+		{ID: "0x2", AssetID: "a", OriginalSize: "10", SizeMatched: "4"},
+		{ID: "0x4", AssetID: "b", OriginalSize: "5", SizeMatched: "0"},
+	}}
+	local := []LocalOrder{
+		{ID: "0x1", TokenID: "a", Remaining: 10},
+		{ID: "0x2", TokenID: "a", Remaining: 10},
+		{ID: "0x3", TokenID: "b", Remaining: 2},
+	}
+	corrector := &fakeCorrector{tracked: map[string]bool{"0x1": true, "0x2": true, "0x3": true}}
+	config := ReconcilerConfig{
+		User:          "0xme",
+		Positions:     server,
+		Orders:        server,
+		LocalOrders:   func() []LocalOrder { return local },
+		CorrectOrders: corrector,
+	}
+
+	r, err := NewReconciler(NewPortfolio(), config)
+	if err != nil {
+		t.Fatalf("NewReconciler: %v", err)
+	}
+	report, err := r.Reconcile()
+	if err != nil {
+		t.Fatalf("Reconcile: %v", err)
+	}
+	want := []Drift{
+		{Kind: DriftOrderNotLocal, TokenID: "b", OrderID: "0x4", RemoteSize: 5},
+		{Kind: DriftOrderNotRemote, TokenID: "b", OrderID: "0x3", LocalSize: 2},
+		{Kind: DriftOrderSize, TokenID: "a", OrderID: "0x2", LocalSize: 10, RemoteSize: 6},
+	}
+	if len(report.Drifts) != len(want) {
+		t.Fatalf("Expected %d drifts, got %+v", len(want), report.Drifts)
+	}
+	for i := range want {
+		if report.Drifts[i] != want[i] {
+			t.Errorf("Drift %d: expected %+v, got %+v", i, want[i], report.Drifts[i])
+		}
+	}
+	if len(corrector.applied) != 0 || len(corrector.refreshed) != 0 {
+		t.Errorf("Expected no corrections without AutoCorrect")
+	}
+
+	// The extra order is not tracked locally, so only the others are corrected
+	r.config.AutoCorrect = true
+	report, err = r.Reconcile()
+	if err != nil {
+		t.Fatalf("Reconcile: %v", err)
+	}
+	if report.Drifts[0].Corrected || !report.Drifts[1].Corrected || !report.Drifts[2].Corrected {
+		t.Errorf("Unexpected corrections %+v", report.Drifts)
+	}
+	if len(corrector.refreshed) != 1 || corrector.refreshed[0] != "0x3" || len(corrector.applied) != 1 || corrector.applied[0] != "0x2" {
+		t.Errorf("Expected 0x3 refreshed and 0x2 applied, got %v / %v", corrector.refreshed, corrector.applied)
+	}
+}
+
+func TestReconcileReportsSourceErrors(t *testing.T) {
+	server := &fakeServer{err: errors.New("unavailable")}
+	r, err := NewReconciler(NewPortfolio(), ReconcilerConfig{User: "0xme", Positions: server})
+	if err != nil {
+		t.Fatalf("NewReconciler: %v", err)
+	}
+	if _, err := r.Reconcile(); err == nil {
+		t.Error("Expected the position source error")
+	}
+
+	if _, err := NewReconciler(NewPortfolio(), ReconcilerConfig{Positions: server}); err == nil {
+		t.Error("Expected a missing user to be refused")
+	}
+}
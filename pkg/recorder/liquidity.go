@@ -0,0 +1,226 @@
+package recorder
+
+import (
+	"bufio"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/MaDal776/polymarket-go-client/pkg/export"
+	"github.com/MaDal776/polymarket-go-client/pkg/types"
+)
+
+// Liquidity defaults
+const (
+	DefaultDepthBand = 0.01
+	DefaultMaxGap    = time.Minute
+)
+
+// LiquidityConfig configures ComputeLiquidity
+type LiquidityConfig struct {
+	DepthBand float64       // Depth is counted within this fraction of the midpoint; default DefaultDepthBand (1%)
+	MaxGap    time.Duration // Snapshots further apart are a recording gap, not counted; default DefaultMaxGap
+}
+
+// Liquidity summarizes a token's recorded books over a period. Each snapshot
+// holds until the next one, so the averages are weighted by time. Depth only
+// covers the levels the recorder kept.
+type Liquidity struct {
+	TokenID   string        `json:"token_id"`
+	From      time.Time     `json:"from"`
+	To        time.Time     `json:"to"`
+	Observed  time.Duration `json:"observed"` // Time covered by snapshots, excluding gaps
+	Snapshots int           `json:"snapshots"`
+
+	TwoSidedUptime float64 `json:"two_sided_uptime"` // Fraction of Observed with both a bid and an ask
+	AvgSpread      float64 `json:"avg_spread"`       // Over the two-sided time
+	AvgBidDepth    float64 `json:"avg_bid_depth"`    // Shares bid within DepthBand of the midpoint; 0 while one-sided
+	AvgAskDepth    float64 `json:"avg_ask_depth"`    // Shares offered within DepthBand of the midpoint; 0 while one-sided
+}
+
+// ComputeLiquidity returns the liquidity of every token in snapshots, sorted
+// by token ID. Snapshots need not be in order.
+func ComputeLiquidity(snapshots []Snapshot, config LiquidityConfig) []Liquidity {
+	if config.DepthBand <= 0 {
+		config.DepthBand = DefaultDepthBand
+	}
+	if config.MaxGap <= 0 {
+		config.MaxGap = DefaultMaxGap
+	}
+
+	byToken := make(map[string][]Snapshot)
+	for _, snapshot := range snapshots {
+		byToken[snapshot.TokenID] = append(byToken[snapshot.TokenID], snapshot)
+	}
+
+	results := make([]Liquidity, 0, len(byToken))
+	for tokenID, token := range byToken {
+		sort.SliceStable(token, func(i, j int) bool { return token[i].Timestamp.Before(token[j].Timestamp) })
+		results = append(results, tokenLiquidity(tokenID, token, config))
+	}
+	sort.Slice(results, func(i, j int) bool { return results[i].TokenID < results[j].TokenID })
+	return results
+}
+
+// tokenLiquidity computes the liquidity of one token's snapshots in time order
+func tokenLiquidity(tokenID string, snapshots []Snapshot, config LiquidityConfig) Liquidity {
+	liquidity := Liquidity{
+		TokenID:   tokenID,
+		From:      snapshots[0].Timestamp,
+		To:        snapshots[len(snapshots)-1].Timestamp,
+		Snapshots: len(snapshots),
+	}
+
+	var observed, twoSided, spread, bidDepth, askDepth float64
+	for i := 0; i+1 < len(snapshots); i++ {
+		snapshot := snapshots[i]
+		held := snapshots[i+1].Timestamp.Sub(snapshot.Timestamp)
+		if held <= 0 || held > config.MaxGap {
+			continue
+		}
+		weight := held.Seconds()
+		observed += weight
+		if snapshot.BestBid <= 0 || snapshot.BestAsk <= 0 {
+			continue
+		}
+		twoSided += weight
+		spread += weight * (snapshot.BestAsk - snapshot.BestBid)
+
+		mid := (snapshot.BestBid + snapshot.BestAsk) / 2
+		for _, level := range snapshot.Bids {
+			if level.Price >= mid*(1-config.DepthBand) {
+				bidDepth += weight * level.Size
+			}
+		}
+		for _, level := range snapshot.Asks {
+			if level.Price <= mid*(1+config.DepthBand) {
+				askDepth += weight * level.Size
+			}
+		}
+	}
+
+	liquidity.Observed = time.Duration(observed * float64(time.Second))
+	if observed > 0 {
+		liquidity.TwoSidedUptime = twoSided / observed
+		liquidity.AvgBidDepth = bidDepth / observed
+		liquidity.AvgAskDepth = askDepth / observed
+	}
+	if twoSided > 0 {
+		liquidity.AvgSpread = spread / twoSided
+	}
+	return liquidity
+}
+
+// ReadSnapshots reads a snapshot file written by a Recorder. The format is
+// taken from the file extension.
+func ReadSnapshots(path string) ([]Snapshot, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open snapshot file: %w", err)
+	}
+	defer file.Close()
+
+	if strings.TrimPrefix(filepath.Ext(path), ".") == string(export.CSV) {
+		return readCSV(file)
+	}
+	return readJSONL(file)
+}
+
+// readJSONL reads one snapshot per line, skipping blank lines
+func readJSONL(r io.Reader) ([]Snapshot, error) {
+	snapshots := make([]Snapshot, 0)
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 0, 64*1024), 16*1024*1024)
+	for line := 1; scanner.Scan(); line++ {
+		if strings.TrimSpace(scanner.Text()) == "" {
+			continue
+		}
+		var snapshot Snapshot
+		if err := json.Unmarshal(scanner.Bytes(), &snapshot); err != nil {
+			return nil, fmt.Errorf("line %d: failed to parse snapshot: %w", line, err)
+		}
+		snapshots = append(snapshots, snapshot)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read snapshots: %w", err)
+	}
+	return snapshots, nil
+}
+
+// readCSV reads the rows written by writeCSV
+func readCSV(r io.Reader) ([]Snapshot, error) {
+	rows, err := csv.NewReader(r).ReadAll()
+	if err != nil {
+		return nil, fmt.Errorf("failed to read snapshots: %w", err)
+	}
+
+	snapshots := make([]Snapshot, 0, len(rows))
+	for i, row := range rows {
+		if i == 0 && len(row) > 0 && row[0] == csvHeader[0] {
+			continue
+		}
+		if len(row) != len(csvHeader) {
+			return nil, fmt.Errorf("row %d: expected %d columns, got %d", i+1, len(csvHeader), len(row))
+		}
+		snapshot, err := parseRow(row)
+		if err != nil {
+			return nil, fmt.Errorf("row %d: %w", i+1, err)
+		}
+		snapshots = append(snapshots, snapshot)
+	}
+	return snapshots, nil
+}
+
+// parseRow parses a CSV row in csvHeader order
+func parseRow(row []string) (Snapshot, error) {
+	timestamp, err := time.Parse(time.RFC3339Nano, row[0])
+	if err != nil {
+		return Snapshot{}, fmt.Errorf("invalid timestamp: %w", err)
+	}
+	snapshot := Snapshot{Timestamp: timestamp, TokenID: row[1], Market: row[2], Hash: row[11]}
+
+	numbers := []*float64{&snapshot.BestBid, &snapshot.BestAsk, &snapshot.Midpoint, &snapshot.Spread, &snapshot.BidDepth, &snapshot.AskDepth}
+	for i, number := range numbers {
+		if *number, err = strconv.ParseFloat(row[3+i], 64); err != nil {
+			return Snapshot{}, fmt.Errorf("invalid %s: %w", csvHeader[3+i], err)
+		}
+	}
+	if snapshot.Bids, err = parseLevels(row[9]); err != nil {
+		return Snapshot{}, fmt.Errorf("invalid bids: %w", err)
+	}
+	if snapshot.Asks, err = parseLevels(row[10]); err != nil {
+		return Snapshot{}, fmt.Errorf("invalid asks: %w", err)
+	}
+	return snapshot, nil
+}
+
+// parseLevels parses price:size pairs separated by semicolons
+func parseLevels(value string) ([]types.PriceLevel, error) {
+	levels := make([]types.PriceLevel, 0)
+	if value == "" {
+		return levels, nil
+	}
+	for _, pair := range strings.Split(value, ";") {
+		price, size, found := strings.Cut(pair, ":")
+		if !found {
+			return nil, fmt.Errorf("invalid level %q", pair)
+		}
+		level := types.PriceLevel{}
+		var err error
+		if level.Price, err = strconv.ParseFloat(price, 64); err != nil {
+			return nil, fmt.Errorf("invalid level %q", pair)
+		}
+		if level.Size, err = strconv.ParseFloat(size, 64); err != nil {
+			return nil, fmt.Errorf("invalid level %q", pair)
+		}
+		levels = append(levels, level)
+	}
+	return levels, nil
+}
@@ -0,0 +1,90 @@
+package recorder
+
+import (
+	"context"
+	"math"
+	"testing"
+	"time"
+
+	"github.com/MaDal776/polymarket-go-client/pkg/export"
+	"github.com/MaDal776/polymarket-go-client/pkg/types"
+)
+
+func TestComputeLiquidity(t *testing.T) {
+	start := time.Date(2024, 3, 1, 12, 0, 0, 0, time.UTC)
+	at := func(seconds int) time.Time { return start.Add(time.Duration(seconds) * time.Second) }
+	twoSided := func(seconds int, bid, ask float64) Snapshot {
+		return Snapshot{
+			Timestamp: at(seconds),
+			TokenID:   "a",
+			BestBid:   bid,
+			BestAsk:   ask,
+			Bids:      []types.PriceLevel{{Price: bid, Size: 100}, {Price: bid - 0.05, Size: 500}},
+			Asks:      []types.PriceLevel{{Price: ask, Size: 50}},
+		}
+	}
+
+	snapshots := []Snapshot{
+		twoSided(30, 0.498, 0.502), // Out of order on purpose
+		twoSided(0, 0.498, 0.502),
+		{Timestamp: at(10), TokenID: "a", BestBid: 0.498, Bids: []types.PriceLevel{{Price: 0.498, Size: 100}}},
+		twoSided(20, 0.45, 0.55),
+		twoSided(40, 0.498, 0.502),
+		twoSided(600, 0.498, 0.502), // After a recording gap
+		{Timestamp: at(0), TokenID: "b"},
+	}
+	results := ComputeLiquidity(snapshots, LiquidityConfig{})
+	if len(results) != 2 || results[0].TokenID != "a" || results[1].TokenID != "b" {
+		t.Fatalf("Expected results for a and b, got %+v", results)
+	}
+
+	a := results[0]
+	if a.Snapshots != 6 || a.Observed != 40*time.Second || !a.From.Equal(at(0)) || !a.To.Equal(at(600)) {
+		t.Errorf("Unexpected coverage: %+v", a)
+	}
+	if a.TwoSidedUptime != 0.75 {
+		t.Errorf("Expected 75%% two-sided uptime, got %v", a.TwoSidedUptime)
+	}
+	// Spreads 0.004, 0.10, 0.004 over 10s each
+	if math.Abs(a.AvgSpread-0.036) > 1e-9 {
+		t.Errorf("Expected average spread 0.036, got %v", a.AvgSpread)
+	}
+	// Within 1% of 0.5: the best bid and ask while tight; nothing while wide or one-sided
+	if math.Abs(a.AvgBidDepth-50) > 1e-9 || math.Abs(a.AvgAskDepth-25) > 1e-9 {
+		t.Errorf("Expected depths 50 / 25, got %v / %v", a.AvgBidDepth, a.AvgAskDepth)
+	}
+	if b := results[1]; b.Observed != 0 || b.TwoSidedUptime != 0 {
+		t.Errorf("Expected a single snapshot to cover no time, got %+v", b)
+	}
+}
+
+func TestReadSnapshotsRoundTrip(t *testing.T) {
+	for _, format := range []export.Format{export.CSV, export.JSONL} {
+		r, err := NewRecorder(fakeBooks{}, Config{Tokens: []string{"a", "b"}, Dir: t.TempDir(), Format: format, Levels: -1})
+		if err != nil {
+			t.Fatalf("Failed to create recorder: %v", err)
+		}
+		now := time.Date(2024, 3, 1, 12, 0, 0, 0, time.UTC)
+		r.now = func() time.Time { return now }
+		r.Poll(context.Background())
+		now = now.Add(10 * time.Second)
+		r.Poll(context.Background())
+
+		snapshots, err := ReadSnapshots(r.Path(now))
+		if err != nil {
+			t.Fatalf("%s: ReadSnapshots failed: %v", format, err)
+		}
+		if len(snapshots) != 4 {
+			t.Fatalf("%s: expected 4 snapshots, got %d", format, len(snapshots))
+		}
+		s := snapshots[3]
+		if s.TokenID != "b" || !s.Timestamp.Equal(now) || s.BestBid != 0.45 || len(s.Asks) != 2 || s.Asks[1] != (types.PriceLevel{Price: 0.6, Size: 50}) {
+			t.Errorf("%s: unexpected snapshot %+v", format, s)
+		}
+
+		results := ComputeLiquidity(snapshots, LiquidityConfig{})
+		if len(results) != 2 || results[0].TwoSidedUptime != 1 || math.Abs(results[0].AvgSpread-0.1) > 1e-9 {
+			t.Errorf("%s: unexpected liquidity %+v", format, results)
+		}
+	}
+}
@@ -0,0 +1,278 @@
+// Package recorder snapshots order books to local files at a fixed interval,
+// building a research dataset of books, midpoints and spreads without any
+// separate infrastructure. Snapshots are appended to one file per UTC day.
+package recorder
+
+import (
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/MaDal776/polymarket-go-client/pkg/export"
+	"github.com/MaDal776/polymarket-go-client/pkg/types"
+)
+
+// BookSource fetches order books (e.g. the CLOB client). Books that were
+// fetched are returned even when others fail.
+type BookSource interface {
+	GetOrderBooks(ctx context.Context, tokenIDs []string, concurrency int) (map[string]*types.OrderBookSummary, error)
+}
+
+// Snapshot is the state of one token's book at a point in time. Prices and
+// spreads are 0 on a side without orders.
+type Snapshot struct {
+	Timestamp time.Time          `json:"timestamp"`
+	TokenID   string             `json:"token_id"`
+	Market    string             `json:"market"`
+	BestBid   float64            `json:"best_bid"`
+	BestAsk   float64            `json:"best_ask"`
+	Midpoint  float64            `json:"midpoint"`
+	Spread    float64            `json:"spread"`
+	BidDepth  float64            `json:"bid_depth"` // Shares on the recorded bid levels
+	AskDepth  float64            `json:"ask_depth"` // Shares on the recorded ask levels
+	Bids      []types.PriceLevel `json:"bids"`      // Best first
+	Asks      []types.PriceLevel `json:"asks"`      // Best first
+	Hash      string             `json:"hash,omitempty"`
+}
+
+// csvHeader lists the CSV columns in output order. Levels are written as
+// price:size pairs separated by semicolons.
+var csvHeader = []string{
+	"timestamp", "token_id", "market", "best_bid", "best_ask", "midpoint", "spread",
+	"bid_depth", "ask_depth", "bids", "asks", "hash",
+}
+
+// Config configures a Recorder
+type Config struct {
+	Tokens      []string      // Tokens to snapshot; required
+	Dir         string        // Directory the files are written to; required
+	Format      export.Format // export.CSV or export.JSONL; default JSONL
+	Levels      int           // Price levels kept per side; default 10, negative keeps every level
+	Interval    time.Duration // Interval used by Run; default 10s
+	Concurrency int           // Books fetched at once; default 4
+	OnError     func(err error)
+}
+
+// Recorder appends book snapshots to daily files
+type Recorder struct {
+	source BookSource
+	config Config
+	now    func() time.Time
+}
+
+// NewRecorder creates a recorder reading from source
+func NewRecorder(source BookSource, config Config) (*Recorder, error) {
+	if source == nil {
+		return nil, fmt.Errorf("book source is required")
+	}
+	if len(config.Tokens) == 0 {
+		return nil, fmt.Errorf("at least one token is required")
+	}
+	if config.Dir == "" {
+		return nil, fmt.Errorf("output directory is required")
+	}
+	if config.Format == "" {
+		config.Format = export.JSONL
+	}
+	if config.Format != export.CSV && config.Format != export.JSONL {
+		return nil, fmt.Errorf("unsupported snapshot format: %s", config.Format)
+	}
+	if config.Levels == 0 {
+		config.Levels = 10
+	}
+	if config.Interval <= 0 {
+		config.Interval = 10 * time.Second
+	}
+	if config.Concurrency <= 0 {
+		config.Concurrency = 4
+	}
+	if err := os.MkdirAll(config.Dir, 0755); err != nil {
+		return nil, fmt.Errorf("failed to create output directory: %w", err)
+	}
+
+	return &Recorder{source: source, config: config, now: time.Now}, nil
+}
+
+// Path returns the file snapshots taken at t are written to
+func (r *Recorder) Path(t time.Time) string {
+	return filepath.Join(r.config.Dir, fmt.Sprintf("snapshots-%s.%s", t.UTC().Format("2006-01-02"), r.config.Format))
+}
+
+// Poll snapshots every token once and appends the snapshots to the day's file.
+// Tokens whose books could not be fetched are skipped and reported in the error.
+// It returns the number of snapshots written.
+func (r *Recorder) Poll(ctx context.Context) (int, error) {
+	books, fetchErr := r.source.GetOrderBooks(ctx, r.config.Tokens, r.config.Concurrency)
+	now := r.now().UTC()
+
+	snapshots := make([]Snapshot, 0, len(books))
+	for _, tokenID := range r.config.Tokens {
+		book, ok := books[tokenID]
+		if !ok || book == nil {
+			continue
+		}
+		snapshot, err := Take(book, r.config.Levels, now)
+		if err != nil {
+			fetchErr = errors.Join(fetchErr, fmt.Errorf("%s: %w", tokenID, err))
+			continue
+		}
+		if snapshot.TokenID == "" {
+			snapshot.TokenID = tokenID
+		}
+		snapshots = append(snapshots, snapshot)
+	}
+
+	if len(snapshots) > 0 {
+		if err := r.write(r.Path(now), snapshots); err != nil {
+			return 0, err
+		}
+	}
+	if fetchErr != nil {
+		return len(snapshots), fmt.Errorf("failed to snapshot some books: %w", fetchErr)
+	}
+	return len(snapshots), nil
+}
+
+// Run calls Poll every Interval until ctx is cancelled
+func (r *Recorder) Run(ctx context.Context) {
+	ticker := time.NewTicker(r.config.Interval)
+	defer ticker.Stop()
+
+	for {
+		if _, err := r.Poll(ctx); err != nil && ctx.Err() == nil && r.config.OnError != nil {
+			r.config.OnError(err)
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// Take snapshots a book at t, keeping levels price levels per side (every level
+// when levels is negative)
+func Take(book *types.OrderBookSummary, levels int, t time.Time) (Snapshot, error) {
+	bids, asks, err := book.Levels()
+	if err != nil {
+		return Snapshot{}, err
+	}
+	snapshot := Snapshot{
+		Timestamp: t,
+		TokenID:   book.AssetID,
+		Market:    book.Market,
+		Bids:      top(bids, levels),
+		Asks:      top(asks, levels),
+		Hash:      book.Hash,
+	}
+	if bid, ok := book.BestBid(); ok {
+		snapshot.BestBid = bid.Price
+	}
+	if ask, ok := book.BestAsk(); ok {
+		snapshot.BestAsk = ask.Price
+	}
+	snapshot.Midpoint, _ = book.Mid()
+	snapshot.Spread, _ = book.Spread()
+	for _, level := range snapshot.Bids {
+		snapshot.BidDepth += level.Size
+	}
+	for _, level := range snapshot.Asks {
+		snapshot.AskDepth += level.Size
+	}
+	return snapshot, nil
+}
+
+// write appends snapshots to path, starting a CSV file with its header
+func (r *Recorder) write(path string, snapshots []Snapshot) error {
+	file, err := os.OpenFile(path, os.O_CREATE|os.O_APPEND|os.O_WRONLY, 0644)
+	if err != nil {
+		return fmt.Errorf("failed to open snapshot file: %w", err)
+	}
+	info, err := file.Stat()
+	if err != nil {
+		file.Close()
+		return fmt.Errorf("failed to open snapshot file: %w", err)
+	}
+
+	if r.config.Format == export.CSV {
+		err = writeCSV(file, snapshots, info.Size() == 0)
+	} else {
+		err = writeJSONL(file, snapshots)
+	}
+	if closeErr := file.Close(); err == nil && closeErr != nil {
+		err = fmt.Errorf("failed to write snapshots: %w", closeErr)
+	}
+	return err
+}
+
+// writeCSV writes snapshots as CSV rows, preceded by the header when asked
+func writeCSV(file *os.File, snapshots []Snapshot, header bool) error {
+	writer := csv.NewWriter(file)
+	if header {
+		if err := writer.Write(csvHeader); err != nil {
+			return fmt.Errorf("failed to write CSV header: %w", err)
+		}
+	}
+	for _, s := range snapshots {
+		row := []string{
+			s.Timestamp.Format(time.RFC3339Nano),
+			s.TokenID,
+			s.Market,
+			formatFloat(s.BestBid),
+			formatFloat(s.BestAsk),
+			formatFloat(s.Midpoint),
+			formatFloat(s.Spread),
+			formatFloat(s.BidDepth),
+			formatFloat(s.AskDepth),
+			formatLevels(s.Bids),
+			formatLevels(s.Asks),
+			s.Hash,
+		}
+		if err := writer.Write(row); err != nil {
+			return fmt.Errorf("failed to write CSV row: %w", err)
+		}
+	}
+	writer.Flush()
+	return writer.Error()
+}
+
+// writeJSONL writes snapshots as one JSON object per line
+func writeJSONL(file *os.File, snapshots []Snapshot) error {
+	encoder := json.NewEncoder(file)
+	for _, s := range snapshots {
+		if err := encoder.Encode(s); err != nil {
+			return fmt.Errorf("failed to write JSON snapshot: %w", err)
+		}
+	}
+	return nil
+}
+
+// top returns the first n levels, or all of them when n is negative
+func top(levels []types.PriceLevel, n int) []types.PriceLevel {
+	if n >= 0 && n < len(levels) {
+		levels = levels[:n]
+	}
+	return append([]types.PriceLevel{}, levels...)
+}
+
+// formatLevels formats levels as price:size pairs separated by semicolons
+func formatLevels(levels []types.PriceLevel) string {
+	parts := make([]string, 0, len(levels))
+	for _, level := range levels {
+		parts = append(parts, formatFloat(level.Price)+":"+formatFloat(level.Size))
+	}
+	return strings.Join(parts, ";")
+}
+
+// formatFloat formats a number without trailing zeros
+func formatFloat(value float64) string {
+	return strconv.FormatFloat(value, 'f', -1, 64)
+}
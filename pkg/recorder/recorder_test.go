@@ -0,0 +1,109 @@
+package recorder
+
+import (
+	"bufio"
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/MaDal776/polymarket-go-client/pkg/export"
+	"github.com/MaDal776/polymarket-go-client/pkg/types"
+)
+
+// fakeBooks serves a fixed book for every token except "missing"
+type fakeBooks struct{}
+
+func (fakeBooks) GetOrderBooks(ctx context.Context, tokenIDs []string, concurrency int) (map[string]*types.OrderBookSummary, error) {
+	books := make(map[string]*types.OrderBookSummary)
+	var err error
+	for _, tokenID := range tokenIDs {
+		if tokenID == "missing" {
+			err = fmt.Errorf("missing: HTTP 404")
+			continue
+		}
+		books[tokenID] = &types.OrderBookSummary{
+			AssetID: tokenID,
+			Market:  "0xmarket",
+			Bids:    []types.OrderSummary{{Price: "0.40", Size: "30"}, {Price: "0.45", Size: "10"}},
+			Asks:    []types.OrderSummary{{Price: "0.60", Size: "50"}, {Price: "0.55", Size: "20"}},
+		}
+	}
+	return books, err
+}
+
+func TestPollWritesJSONL(t *testing.T) {
+	dir := t.TempDir()
+	r, err := NewRecorder(fakeBooks{}, Config{Tokens: []string{"a", "missing", "b"}, Dir: dir, Levels: 1})
+	if err != nil {
+		t.Fatalf("Failed to create recorder: %v", err)
+	}
+	day := time.Date(2024, 3, 1, 23, 59, 0, 0, time.UTC)
+	r.now = func() time.Time { return day }
+
+	for i := 0; i < 2; i++ {
+		n, err := r.Poll(context.Background())
+		if n != 2 || err == nil {
+			t.Fatalf("Expected 2 snapshots and an error for the missing book, got %d, %v", n, err)
+		}
+	}
+
+	file, err := os.Open(r.Path(day))
+	if err != nil {
+		t.Fatalf("Failed to open snapshot file: %v", err)
+	}
+	defer file.Close()
+	snapshots := make([]Snapshot, 0)
+	scanner := bufio.NewScanner(file)
+	for scanner.Scan() {
+		var s Snapshot
+		if err := json.Unmarshal(scanner.Bytes(), &s); err != nil {
+			t.Fatalf("Invalid line %s: %v", scanner.Text(), err)
+		}
+		snapshots = append(snapshots, s)
+	}
+	if len(snapshots) != 4 {
+		t.Fatalf("Expected 4 snapshots appended, got %d", len(snapshots))
+	}
+	s := snapshots[1]
+	if s.TokenID != "b" || s.BestBid != 0.45 || s.BestAsk != 0.55 || s.Midpoint != 0.5 || len(s.Bids) != 1 || s.AskDepth != 20 {
+		t.Errorf("Unexpected snapshot %+v", s)
+	}
+}
+
+func TestPollWritesCSVWithOneHeader(t *testing.T) {
+	dir := t.TempDir()
+	r, err := NewRecorder(fakeBooks{}, Config{Tokens: []string{"a"}, Dir: dir, Format: export.CSV, Levels: -1})
+	if err != nil {
+		t.Fatalf("Failed to create recorder: %v", err)
+	}
+	now := time.Now()
+	r.now = func() time.Time { return now }
+	r.Poll(context.Background())
+	r.Poll(context.Background())
+
+	file, err := os.Open(r.Path(now))
+	if err != nil {
+		t.Fatalf("Failed to open snapshot file: %v", err)
+	}
+	defer file.Close()
+	rows, err := csv.NewReader(file).ReadAll()
+	if err != nil {
+		t.Fatalf("Invalid CSV: %v", err)
+	}
+	if len(rows) != 3 || rows[0][0] != "timestamp" || rows[1][0] == "timestamp" {
+		t.Fatalf("Expected a header and 2 rows, got %v", rows)
+	}
+	if rows[1][9] != "0.45:10;0.4:30" || rows[1][10] != "0.55:20;0.6:50" {
+		t.Errorf("Unexpected levels %q / %q", rows[1][9], rows[1][10])
+	}
+}
+
+func TestNewRecorderRejectsParquet(t *testing.T) {
+	if _, err := NewRecorder(fakeBooks{}, Config{Tokens: []string{"a"}, Dir: t.TempDir(), Format: "parquet"}); err == nil {
+		t.Errorf("Expected an unsupported format to be rejected")
+	}
+}
@@ -0,0 +1,270 @@
+package rewards
+
+import (
+	"fmt"
+	"math"
+	"sort"
+
+	"github.com/MaDal776/polymarket-go-client/pkg/types"
+	"github.com/MaDal776/polymarket-go-client/pkg/utils"
+)
+
+// allocationSteps is how finely capital is divided when allocating between markets
+const allocationSteps = 200
+
+// MarketInput is a market the optimizer may quote
+type MarketInput struct {
+	Market *types.Market           // Needs two tokens, a tick size and rewards parameters
+	Book   *types.OrderBookSummary // The first outcome's book
+}
+
+// Limits bound the optimizer's proposal
+type Limits struct {
+	Capital      float64 // USDC to commit across all markets; required
+	MaxPerMarket float64 // USDC per market; 0 means no limit
+	MaxMarkets   int     // Markets to quote; 0 means no limit
+	MinDistance  float64 // Closest a quote may be to the midpoint, in cents, to limit fills
+	Levels       int     // Price levels per outcome, one tick apart; default 1
+}
+
+// MarketPlan is the proposed quoting of one market
+type MarketPlan struct {
+	ConditionID   string            `json:"condition_id"`
+	Midpoint      float64           `json:"midpoint"`
+	Orders        []types.OrderArgs `json:"orders"` // GTC bids on both outcomes, ready to sign
+	Capital       float64           `json:"capital"`
+	Estimate      Estimate          `json:"estimate"`
+	Competing     float64           `json:"competing"` // Q_min of the liquidity already on the book
+	ExpectedDaily float64           `json:"expected_daily"`
+}
+
+// Plan is the optimizer's proposal
+type Plan struct {
+	Markets       []MarketPlan      `json:"markets"`
+	Capital       float64           `json:"capital"`
+	ExpectedDaily float64           `json:"expected_daily"`
+	Skipped       map[string]string `json:"skipped,omitempty"` // Condition ID -> reason
+}
+
+// candidate is a market being allocated capital
+type candidate struct {
+	input     MarketInput
+	params    Params
+	midpoint  float64
+	firstBids []float64 // Prices of the bids on the first outcome, nearest first
+	otherBids []float64 // Prices of the bids on the second outcome, nearest first
+	perShare  float64   // Q_min per share quoted on each outcome
+	cost      float64   // USDC per share quoted on each outcome
+	competing float64
+	rate      float64
+	minShares float64
+	maxShares float64
+	shares    float64
+}
+
+// reward returns the expected daily reward with shares quoted on each outcome
+func (c *candidate) reward(shares float64) float64 {
+	return ExpectedDaily(c.rate, shares*c.perShare, c.competing)
+}
+
+// Optimize proposes two-sided quotes that maximize expected daily rewards within limits.
+// Each market is quoted with bids on both outcomes, which needs only USDC and
+// scores as two-sided liquidity. Capital goes where it adds the most reward per dollar,
+// accounting for the competing liquidity already on each book.
+func Optimize(inputs []MarketInput, limits Limits) (*Plan, error) {
+	if limits.Capital <= 0 {
+		return nil, fmt.Errorf("capital must be positive")
+	}
+	if limits.Levels <= 0 {
+		limits.Levels = 1
+	}
+
+	plan := &Plan{Markets: make([]MarketPlan, 0), Skipped: make(map[string]string)}
+	candidates := make([]*candidate, 0, len(inputs))
+	for _, input := range inputs {
+		c, reason := newCandidate(input, limits)
+		if c == nil {
+			plan.Skipped[input.Market.ConditionID] = reason
+			continue
+		}
+		candidates = append(candidates, c)
+	}
+
+	allocate(candidates, limits)
+
+	for _, c := range candidates {
+		if c.shares <= 0 {
+			plan.Skipped[c.input.Market.ConditionID] = "no capital allocated"
+			continue
+		}
+		marketPlan := c.plan(limits.Levels)
+		plan.Markets = append(plan.Markets, marketPlan)
+		plan.Capital += marketPlan.Capital
+		plan.ExpectedDaily += marketPlan.ExpectedDaily
+	}
+	sort.Slice(plan.Markets, func(i, j int) bool {
+		return plan.Markets[i].ExpectedDaily > plan.Markets[j].ExpectedDaily
+	})
+	return plan, nil
+}
+
+// newCandidate prices a market's quotes, or returns why it cannot be quoted
+func newCandidate(input MarketInput, limits Limits) (*candidate, string) {
+	market := input.Market
+	params := ParamsFromMarket(market)
+	rate := market.Rewards.DailyRate()
+	switch {
+	case len(market.Tokens) != 2:
+		return nil, "not a binary market"
+	case rate <= 0 || params.MaxSpread <= 0:
+		return nil, "no rewards"
+	case market.MinimumTickSize <= 0:
+		return nil, "unknown tick size"
+	case input.Book == nil:
+		return nil, "no order book"
+	}
+
+	midpoint, ok := AdjustedMidpoint(input.Book, params.MinSize)
+	if !ok {
+		return nil, "no two-sided book"
+	}
+	competing, _ := ScoreBook(params, input.Book)
+
+	c := &candidate{
+		input:     input,
+		params:    params,
+		midpoint:  midpoint,
+		competing: competing.QMin,
+		rate:      rate,
+		minShares: math.Max(params.MinSize, 1) * float64(limits.Levels),
+		maxShares: math.Inf(1),
+	}
+
+	// Bids must rest below the opposite best ask: the second outcome's asks mirror the first's bids
+	firstCeiling, otherCeiling := 1.0, 1.0
+	if ask, ok := input.Book.BestAsk(); ok {
+		firstCeiling = ask.Price
+	}
+	if bid, ok := input.Book.BestBid(); ok {
+		otherCeiling = 1 - bid.Price
+	}
+	c.firstBids = ladder(midpoint, limits, market.MinimumTickSize, firstCeiling)
+	c.otherBids = ladder(1-midpoint, limits, market.MinimumTickSize, otherCeiling)
+	if c.firstBids == nil || c.otherBids == nil {
+		return nil, "ladder runs off the price grid"
+	}
+
+	quotes := make([]Quote, 0, 2*limits.Levels)
+	for i := range c.firstBids {
+		quotes = append(quotes, Quote{Outcome: First, Side: types.BUY, Price: c.firstBids[i], Size: 1})
+		quotes = append(quotes, Quote{Outcome: Second, Side: types.BUY, Price: c.otherBids[i], Size: 1})
+		c.cost += c.firstBids[i] + c.otherBids[i]
+	}
+	// Score per share, with MinSize lifted so the unit quotes are not rejected for size
+	unit := params
+	unit.MinSize = 0
+	estimate := Score(unit, midpoint, quotes)
+	levels := float64(limits.Levels)
+	c.perShare = estimate.QMin / levels
+	c.cost /= levels
+	if c.perShare <= 0 || c.cost <= 0 {
+		return nil, "no quote within the reward spread"
+	}
+
+	if limits.MaxPerMarket > 0 {
+		c.maxShares = limits.MaxPerMarket / c.cost
+	}
+	if c.maxShares < c.minShares {
+		return nil, "per-market limit below the reward minimum size"
+	}
+	return c, ""
+}
+
+// ladder returns limits.Levels bid prices on the tick grid, nearest the midpoint
+// first, strictly below it (so the two outcomes' bids cannot match each other),
+// at least MinDistance from it and below ceiling. It returns nil when the ladder
+// would reach zero.
+func ladder(midpoint float64, limits Limits, tick, ceiling float64) []float64 {
+	decimals := utils.DecimalPlaces(tick)
+	ticks := math.Floor((midpoint-limits.MinDistance/100)/tick + 1e-9)
+	if ticks*tick >= midpoint-1e-9 {
+		ticks--
+	}
+	ticks = math.Min(ticks, math.Floor((ceiling-tick)/tick+1e-9))
+
+	prices := make([]float64, 0, limits.Levels)
+	for i := 0; i < limits.Levels; i++ {
+		price := utils.RoundNormal((ticks-float64(i))*tick, decimals)
+		if price < tick {
+			return nil
+		}
+		prices = append(prices, price)
+	}
+	return prices
+}
+
+// allocate hands out capital in steps to the market with the best marginal
+// reward per dollar, opening a market only with at least its minimum size
+func allocate(candidates []*candidate, limits Limits) {
+	remaining := limits.Capital
+	step := limits.Capital / allocationSteps
+	opened := 0
+
+	for remaining > 0 {
+		var best *candidate
+		var bestShares, bestGain float64
+		for _, c := range candidates {
+			if c.shares == 0 && limits.MaxMarkets > 0 && opened >= limits.MaxMarkets {
+				continue
+			}
+			add := step / c.cost
+			if c.shares == 0 {
+				add = math.Max(add, c.minShares)
+			}
+			add = math.Min(add, math.Min(c.maxShares-c.shares, remaining/c.cost))
+			if add <= 0 || c.shares+add < c.minShares {
+				continue
+			}
+			gain := (c.reward(c.shares+add) - c.reward(c.shares)) / (add * c.cost)
+			if gain > bestGain {
+				best, bestShares, bestGain = c, add, gain
+			}
+		}
+		if best == nil {
+			return
+		}
+		if best.shares == 0 {
+			opened++
+		}
+		best.shares += bestShares
+		remaining -= bestShares * best.cost
+	}
+}
+
+// plan turns an allocation into orders split evenly across the ladder
+func (c *candidate) plan(levels int) MarketPlan {
+	market := c.input.Market
+	perLevel := utils.RoundDown(c.shares/float64(levels), 2)
+
+	result := MarketPlan{
+		ConditionID: market.ConditionID,
+		Midpoint:    c.midpoint,
+		Orders:      make([]types.OrderArgs, 0, 2*levels),
+		Competing:   c.competing,
+	}
+	quotes := make([]Quote, 0, 2*levels)
+	for i := 0; i < levels; i++ {
+		result.Orders = append(result.Orders,
+			types.OrderArgs{TokenID: market.Tokens[0].TokenID, Price: c.firstBids[i], Size: perLevel, Side: types.BUY},
+			types.OrderArgs{TokenID: market.Tokens[1].TokenID, Price: c.otherBids[i], Size: perLevel, Side: types.BUY},
+		)
+		quotes = append(quotes,
+			Quote{Outcome: First, Side: types.BUY, Price: c.firstBids[i], Size: perLevel},
+			Quote{Outcome: Second, Side: types.BUY, Price: c.otherBids[i], Size: perLevel},
+		)
+		result.Capital += perLevel * (c.firstBids[i] + c.otherBids[i])
+	}
+	result.Estimate = Score(c.params, c.midpoint, quotes)
+	result.ExpectedDaily = ExpectedDaily(c.rate, result.Estimate.QMin, c.competing)
+	return result
+}
@@ -0,0 +1,92 @@
+package rewards
+
+import (
+	"testing"
+
+	"github.com/MaDal776/polymarket-go-client/pkg/types"
+)
+
+func testMarket(id string, dailyRate float64) *types.Market {
+	return &types.Market{
+		ConditionID:     id,
+		Tokens:          []types.MarketToken{{TokenID: id + "-yes"}, {TokenID: id + "-no"}},
+		MinimumTickSize: 0.01,
+		Rewards: types.MarketRewards{
+			Rates:     []types.RewardRate{{RewardsDailyRate: dailyRate}},
+			MinSize:   20,
+			MaxSpread: 3,
+		},
+	}
+}
+
+func testBook(depth string) *types.OrderBookSummary {
+	return &types.OrderBookSummary{
+		Bids: []types.OrderSummary{{Price: "0.49", Size: depth}},
+		Asks: []types.OrderSummary{{Price: "0.51", Size: depth}},
+	}
+}
+
+func TestOptimizePrefersLessCompetition(t *testing.T) {
+	inputs := []MarketInput{
+		{Market: testMarket("crowded", 100), Book: testBook("100000")},
+		{Market: testMarket("quiet", 100), Book: testBook("100")},
+		{Market: testMarket("unrewarded", 0), Book: testBook("100")},
+	}
+	plan, err := Optimize(inputs, Limits{Capital: 500})
+	if err != nil {
+		t.Fatalf("Optimize failed: %v", err)
+	}
+
+	if plan.Skipped["unrewarded"] != "no rewards" {
+		t.Errorf("Unexpected skip reasons %v", plan.Skipped)
+	}
+	if len(plan.Markets) == 0 || plan.Markets[0].ConditionID != "quiet" {
+		t.Fatalf("Expected the quiet market first, got %+v", plan.Markets)
+	}
+	if plan.Capital > 500+1e-9 {
+		t.Errorf("Committed %v, more than the capital", plan.Capital)
+	}
+
+	quiet := plan.Markets[0]
+	if len(quiet.Orders) != 2 || quiet.Orders[0].TokenID != "quiet-yes" || quiet.Orders[1].TokenID != "quiet-no" {
+		t.Fatalf("Unexpected orders %+v", quiet.Orders)
+	}
+	// Nearest tick below the midpoint on both outcomes, below the opposite best asks
+	if quiet.Orders[0].Price != 0.49 || quiet.Orders[1].Price != 0.49 {
+		t.Errorf("Unexpected prices %v / %v", quiet.Orders[0].Price, quiet.Orders[1].Price)
+	}
+	if !quiet.Estimate.TwoSided || quiet.ExpectedDaily <= 0 {
+		t.Errorf("Unexpected estimate %+v", quiet.Estimate)
+	}
+}
+
+func TestOptimizeRespectsLimits(t *testing.T) {
+	inputs := []MarketInput{
+		{Market: testMarket("a", 100), Book: testBook("100")},
+		{Market: testMarket("b", 100), Book: testBook("100")},
+	}
+	plan, err := Optimize(inputs, Limits{Capital: 1000, MaxPerMarket: 100, MaxMarkets: 1, MinDistance: 2, Levels: 2})
+	if err != nil {
+		t.Fatalf("Optimize failed: %v", err)
+	}
+	if len(plan.Markets) != 1 {
+		t.Fatalf("Expected one market, got %d", len(plan.Markets))
+	}
+
+	market := plan.Markets[0]
+	if market.Capital > 100+1e-9 {
+		t.Errorf("Committed %v to one market, limit 100", market.Capital)
+	}
+	if len(market.Orders) != 4 {
+		t.Fatalf("Expected 2 levels on 2 outcomes, got %+v", market.Orders)
+	}
+	for _, order := range market.Orders {
+		if order.Price > 0.48 || order.Size < 20 {
+			t.Errorf("Order %+v violates MinDistance or the reward minimum size", order)
+		}
+	}
+
+	if _, err := Optimize(inputs, Limits{}); err == nil {
+		t.Error("Expected an error without capital")
+	}
+}
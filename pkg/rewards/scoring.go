@@ -0,0 +1,208 @@
+// Package rewards estimates liquidity reward scores using Polymarket's published rules.
+//
+// A resting order within MaxSpread cents of the midpoint scores
+//
+//	S = ((v - s) / v)^2 * b * size
+//
+// where v is the max spread, s the order's distance from the midpoint in cents
+// and b the market multiplier. Orders smaller than MinSize do not score. Scores
+// are summed per side of the market: Q_one counts bids on the first outcome and
+// asks on the second, Q_two asks on the first and bids on the second. The
+// scored value is
+//
+//	Q_min = max(min(Q_one, Q_two), max(Q_one/c, Q_two/c))   midpoint in [0.10, 0.90]
+//	Q_min = min(Q_one, Q_two)                               otherwise
+//
+// so one-sided quotes earn a third (c = 3) near the middle and nothing at the extremes.
+package rewards
+
+import (
+	"math"
+
+	"github.com/MaDal776/polymarket-go-client/pkg/types"
+)
+
+// Defaults from the reward rules
+const (
+	DefaultScaleFactor = 3.0  // c: how much one-sided liquidity is discounted
+	TwoSidedLow        = 0.10 // Below this midpoint only two-sided liquidity scores
+	TwoSidedHigh       = 0.90 // Above this midpoint only two-sided liquidity scores
+)
+
+// Params are the reward parameters of a market
+type Params struct {
+	MaxSpread   float64 // v, in cents from the midpoint
+	MinSize     float64 // Smallest order size that scores
+	Multiplier  float64 // b; default 1
+	ScaleFactor float64 // c; default DefaultScaleFactor
+}
+
+// ParamsFromMarket returns a market's reward parameters
+func ParamsFromMarket(market *types.Market) Params {
+	return Params{MaxSpread: market.Rewards.MaxSpread, MinSize: market.Rewards.MinSize}.withDefaults()
+}
+
+// withDefaults fills in the multiplier and scale factor
+func (p Params) withDefaults() Params {
+	if p.Multiplier <= 0 {
+		p.Multiplier = 1
+	}
+	if p.ScaleFactor <= 0 {
+		p.ScaleFactor = DefaultScaleFactor
+	}
+	return p
+}
+
+// Outcome selects the outcome a quote is placed on
+type Outcome int
+
+const (
+	First  Outcome = 0 // The outcome the midpoint refers to (e.g. Yes)
+	Second Outcome = 1 // Its complement (e.g. No), priced at 1 - midpoint
+)
+
+// Quote is a planned or resting order
+type Quote struct {
+	Outcome Outcome         `json:"outcome"`
+	Side    types.OrderSide `json:"side"`
+	Price   float64         `json:"price"`
+	Size    float64         `json:"size"`
+}
+
+// QuoteScore is the score of one quote and why it does or does not score
+type QuoteScore struct {
+	Quote  Quote   `json:"quote"`
+	Spread float64 `json:"spread"` // Distance from the outcome's midpoint, in cents
+	Score  float64 `json:"score"`
+	Reason string  `json:"reason,omitempty"` // Set when Score is 0
+}
+
+// Estimate is the reward score of a set of quotes
+type Estimate struct {
+	Midpoint float64      `json:"midpoint"`
+	QOne     float64      `json:"q_one"`
+	QTwo     float64      `json:"q_two"`
+	QMin     float64      `json:"q_min"`
+	TwoSided bool         `json:"two_sided"` // Both Q_one and Q_two are positive
+	Quotes   []QuoteScore `json:"quotes"`
+}
+
+// Earns reports whether the quotes earn any rewards
+func (e Estimate) Earns() bool {
+	return e.QMin > 0
+}
+
+// ScoreQuote scores one quote against the first outcome's midpoint
+func ScoreQuote(params Params, midpoint float64, quote Quote) QuoteScore {
+	params = params.withDefaults()
+	mid := midpoint
+	if quote.Outcome == Second {
+		mid = 1 - midpoint
+	}
+	result := QuoteScore{Quote: quote, Spread: math.Abs(quote.Price-mid) * 100}
+
+	switch {
+	case params.MaxSpread <= 0:
+		result.Reason = "market has no reward spread"
+	case quote.Size < params.MinSize:
+		result.Reason = "size below reward minimum"
+	case result.Spread >= params.MaxSpread:
+		result.Reason = "outside reward spread"
+	case quote.Side == types.BUY && quote.Price > mid, quote.Side == types.SELL && quote.Price < mid:
+		result.Reason = "crosses the midpoint"
+	default:
+		ratio := (params.MaxSpread - result.Spread) / params.MaxSpread
+		result.Score = ratio * ratio * params.Multiplier * quote.Size
+	}
+	return result
+}
+
+// Score estimates the reward score of quotes at the first outcome's midpoint
+func Score(params Params, midpoint float64, quotes []Quote) Estimate {
+	params = params.withDefaults()
+	estimate := Estimate{Midpoint: midpoint, Quotes: make([]QuoteScore, 0, len(quotes))}
+	for _, quote := range quotes {
+		scored := ScoreQuote(params, midpoint, quote)
+		estimate.Quotes = append(estimate.Quotes, scored)
+		if countsForFirstSide(quote) {
+			estimate.QOne += scored.Score
+		} else {
+			estimate.QTwo += scored.Score
+		}
+	}
+	estimate.TwoSided = estimate.QOne > 0 && estimate.QTwo > 0
+	estimate.QMin = QMin(params, midpoint, estimate.QOne, estimate.QTwo)
+	return estimate
+}
+
+// QMin combines the two side scores, discounting one-sided liquidity
+func QMin(params Params, midpoint, qOne, qTwo float64) float64 {
+	params = params.withDefaults()
+	if midpoint < TwoSidedLow || midpoint > TwoSidedHigh {
+		return math.Min(qOne, qTwo)
+	}
+	return math.Max(math.Min(qOne, qTwo), math.Max(qOne/params.ScaleFactor, qTwo/params.ScaleFactor))
+}
+
+// countsForFirstSide reports whether a quote adds to Q_one (bids on the first
+// outcome, asks on the second) rather than Q_two
+func countsForFirstSide(quote Quote) bool {
+	return (quote.Outcome == First) == (quote.Side == types.BUY)
+}
+
+// AdjustedMidpoint returns the midpoint of the best bid and ask at least minSize
+// large, which is the midpoint rewards are measured from
+func AdjustedMidpoint(book *types.OrderBookSummary, minSize float64) (float64, bool) {
+	bids, asks, err := book.Levels()
+	if err != nil {
+		return 0, false
+	}
+	bid, okBid := firstLevel(bids, minSize)
+	ask, okAsk := firstLevel(asks, minSize)
+	if !okBid || !okAsk {
+		return 0, false
+	}
+	return (bid + ask) / 2, true
+}
+
+// firstLevel returns the price of the best level with at least minSize shares
+func firstLevel(levels []types.PriceLevel, minSize float64) (float64, bool) {
+	for _, level := range levels {
+		if level.Size >= minSize {
+			return level.Price, true
+		}
+	}
+	return 0, false
+}
+
+// ScoreBook scores the liquidity resting on the first outcome's book. The second
+// outcome's book mirrors it, so this covers the whole market. Each price level is
+// scored as one order.
+func ScoreBook(params Params, book *types.OrderBookSummary) (Estimate, bool) {
+	midpoint, ok := AdjustedMidpoint(book, params.MinSize)
+	if !ok {
+		return Estimate{}, false
+	}
+	bids, asks, err := book.Levels()
+	if err != nil {
+		return Estimate{}, false
+	}
+
+	quotes := make([]Quote, 0, len(bids)+len(asks))
+	for _, level := range bids {
+		quotes = append(quotes, Quote{Outcome: First, Side: types.BUY, Price: level.Price, Size: level.Size})
+	}
+	for _, level := range asks {
+		quotes = append(quotes, Quote{Outcome: First, Side: types.SELL, Price: level.Price, Size: level.Size})
+	}
+	return Score(params, midpoint, quotes), true
+}
+
+// ExpectedDaily estimates the daily rewards of a score given the score of the
+// competing liquidity, assuming both persist for the whole day
+func ExpectedDaily(dailyRate, own, competing float64) float64 {
+	if own <= 0 {
+		return 0
+	}
+	return dailyRate * own / (own + competing)
+}
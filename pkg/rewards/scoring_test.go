@@ -0,0 +1,90 @@
+package rewards
+
+import (
+	"math"
+	"testing"
+
+	"github.com/MaDal776/polymarket-go-client/pkg/types"
+)
+
+var testParams = Params{MaxSpread: 3, MinSize: 20}
+
+func near(a, b float64) bool {
+	return math.Abs(a-b) < 1e-9
+}
+
+func TestScoreQuote(t *testing.T) {
+	// 1 cent from the midpoint with a 3 cent max spread: ((3-1)/3)^2 * 100
+	scored := ScoreQuote(testParams, 0.5, Quote{Outcome: First, Side: types.BUY, Price: 0.49, Size: 100})
+	if !near(scored.Spread, 1) || !near(scored.Score, 4.0/9*100) {
+		t.Errorf("Unexpected score %+v", scored)
+	}
+
+	// The second outcome is measured from 1 - midpoint
+	scored = ScoreQuote(testParams, 0.6, Quote{Outcome: Second, Side: types.BUY, Price: 0.39, Size: 100})
+	if !near(scored.Spread, 1) || scored.Score == 0 {
+		t.Errorf("Unexpected complement score %+v", scored)
+	}
+
+	tests := []struct {
+		quote  Quote
+		reason string
+	}{
+		{Quote{Side: types.BUY, Price: 0.49, Size: 10}, "size below reward minimum"},
+		{Quote{Side: types.BUY, Price: 0.47, Size: 100}, "outside reward spread"},
+		{Quote{Side: types.BUY, Price: 0.51, Size: 100}, "crosses the midpoint"},
+	}
+	for _, tt := range tests {
+		if scored := ScoreQuote(testParams, 0.5, tt.quote); scored.Score != 0 || scored.Reason != tt.reason {
+			t.Errorf("%+v: got %+v, want reason %q", tt.quote, scored, tt.reason)
+		}
+	}
+}
+
+func TestScoreTwoSidedness(t *testing.T) {
+	bid := Quote{Outcome: First, Side: types.BUY, Price: 0.49, Size: 100}
+	ask := Quote{Outcome: First, Side: types.SELL, Price: 0.51, Size: 100}
+	noBid := Quote{Outcome: Second, Side: types.BUY, Price: 0.49, Size: 100}
+
+	// One-sided near the middle scores a third
+	oneSided := Score(testParams, 0.5, []Quote{bid})
+	if oneSided.TwoSided || !near(oneSided.QMin, oneSided.QOne/3) {
+		t.Errorf("Unexpected one-sided estimate %+v", oneSided)
+	}
+
+	// A bid on the complement counts as the other side
+	twoSided := Score(testParams, 0.5, []Quote{bid, noBid})
+	if !twoSided.TwoSided || !near(twoSided.QMin, twoSided.QOne) {
+		t.Errorf("Unexpected two-sided estimate %+v", twoSided)
+	}
+	if both := Score(testParams, 0.5, []Quote{bid, ask}); !near(both.QMin, twoSided.QMin) {
+		t.Errorf("Ask and complement bid should score alike: %v vs %v", both.QMin, twoSided.QMin)
+	}
+
+	// At the extremes one-sided liquidity earns nothing
+	extreme := Score(testParams, 0.95, []Quote{{Outcome: First, Side: types.BUY, Price: 0.94, Size: 100}})
+	if extreme.Earns() {
+		t.Errorf("Expected no rewards one-sided at 0.95: %+v", extreme)
+	}
+}
+
+func TestScoreBook(t *testing.T) {
+	book := &types.OrderBookSummary{
+		Bids: []types.OrderSummary{{Price: "0.50", Size: "5"}, {Price: "0.48", Size: "100"}},
+		Asks: []types.OrderSummary{{Price: "0.52", Size: "100"}},
+	}
+	// The 5 share bid is below MinSize, so the midpoint is (0.48 + 0.52) / 2
+	mid, ok := AdjustedMidpoint(book, testParams.MinSize)
+	if !ok || !near(mid, 0.5) {
+		t.Fatalf("Adjusted midpoint %v (%v), want 0.5", mid, ok)
+	}
+
+	estimate, ok := ScoreBook(testParams, book)
+	if !ok || !estimate.TwoSided || !near(estimate.QOne, estimate.QTwo) {
+		t.Errorf("Unexpected book estimate %+v", estimate)
+	}
+
+	if daily := ExpectedDaily(100, estimate.QMin, estimate.QMin*3); !near(daily, 25) {
+		t.Errorf("Expected 25 of 100 daily with a quarter of the score, got %v", daily)
+	}
+}
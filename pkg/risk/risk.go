@@ -0,0 +1,407 @@
+package risk
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math"
+	"strconv"
+	"sync"
+
+	"github.com/MaDal776/polymarket-go-client/pkg/client"
+	"github.com/MaDal776/polymarket-go-client/pkg/portfolio"
+	"github.com/MaDal776/polymarket-go-client/pkg/types"
+	"github.com/MaDal776/polymarket-go-client/pkg/utils"
+)
+
+// ErrLimitExceeded is matched (via errors.Is) by every LimitError
+var ErrLimitExceeded = errors.New("risk limit exceeded")
+
+// LimitKind identifies which risk limit rejected an order
+type LimitKind string
+
+const (
+	LimitOrderNotional LimitKind = "ORDER_NOTIONAL" // Order size * price above MaxOrderNotional
+	LimitOpenOrders    LimitKind = "OPEN_ORDERS"    // Too many open orders in the market
+	LimitNetPosition   LimitKind = "NET_POSITION"   // Fill would push the position past MaxNetPosition
+	LimitPriceBand     LimitKind = "PRICE_BAND"     // Price outside MinPrice/MaxPrice
+	LimitMidDeviation  LimitKind = "MID_DEVIATION"  // Price too far from the midpoint
+	LimitCross         LimitKind = "CROSS"          // Limit price through the best opposite quote
+)
+
+// LimitError reports an order rejected locally by a risk limit
+type LimitError struct {
+	Kind    LimitKind `json:"kind"`
+	TokenID string    `json:"token_id"`
+	Value   float64   `json:"value"`
+	Limit   float64   `json:"limit"`
+}
+
+// Error implements error
+func (e *LimitError) Error() string {
+	return fmt.Sprintf("risk limit %s exceeded for token %s: %g (limit %g)", e.Kind, e.TokenID, e.Value, e.Limit)
+}
+
+// Unwrap allows errors.Is(err, ErrLimitExceeded)
+func (e *LimitError) Unwrap() error {
+	return ErrLimitExceeded
+}
+
+// Limits configures the checks applied before an order is posted. Zero values disable a check.
+type Limits struct {
+	MaxOrderNotional       float64 // Maximum size * price of a single order, in USDC
+	MaxOpenOrdersPerMarket int     // Maximum open orders per market (condition ID), including the new one
+	MaxNetPosition         float64 // Maximum absolute net shares per token after the order fills
+	MinPrice               float64 // Lowest acceptable limit price
+	MaxPrice               float64 // Highest acceptable limit price
+	MaxMidDeviation        float64 // Maximum absolute distance between price and midpoint
+	RejectCross            bool    // Reject GTC and GTD orders priced through the best opposite quote unless created with AllowCross
+}
+
+// PositionProvider provides current positions (e.g. portfolio.Portfolio)
+type PositionProvider interface {
+	Position(tokenID string) (portfolio.Position, bool)
+}
+
+// BatchTrader is implemented by traders that fetch books and post orders in
+// batches and requote ladders (e.g. the CLOB client). RiskTrader's batch methods
+// need the wrapped trader to implement it, which lets a RiskTrader stand in for
+// the client in components such as peg and basket.
+type BatchTrader interface {
+	GetOrderBooks(ctx context.Context, tokenIDs []string, concurrency int) (map[string]*types.OrderBookSummary, error)
+	PostOrders(orders []types.PostOrdersArgs) ([]map[string]interface{}, error)
+	RequoteLadder(ctx context.Context, cancelIDs []string, newOrders []types.PostOrdersArgs) (*client.RequoteResult, error)
+}
+
+// RiskTrader wraps a client.Trader and rejects orders that violate Limits
+type RiskTrader struct {
+	client.Trader
+	limits    Limits
+	positions PositionProvider
+
+	mu           sync.Mutex
+	openOrders   func(tokenID string) ([]string, error) // Set by SetOpenOrderSource; nil lists the exchange's orders
+	markets      map[string]string                      // Token -> condition ID
+	marketTokens map[string][]string                    // Condition ID -> tokens seen
+}
+
+var (
+	_ client.Trader = (*RiskTrader)(nil)
+	_ BatchTrader   = (*RiskTrader)(nil)
+	_ BatchTrader   = (*client.ClobClient)(nil)
+)
+
+// NewRiskTrader wraps trader with pre-trade risk checks. positions may be nil,
+// in which case MaxNetPosition is not enforced.
+func NewRiskTrader(trader client.Trader, limits Limits, positions PositionProvider) *RiskTrader {
+	return &RiskTrader{
+		Trader:       trader,
+		limits:       limits,
+		positions:    positions,
+		markets:      make(map[string]string),
+		marketTokens: make(map[string][]string),
+	}
+}
+
+// SetOpenOrderSource replaces the exchange as the source of open orders with a
+// local one, such as an order manager, returning the IDs of a token's open
+// orders. A market's orders are those of every token of it the risk trader
+// has checked an order for.
+func (r *RiskTrader) SetOpenOrderSource(source func(tokenID string) ([]string, error)) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.openOrders = source
+}
+
+// Limits returns the configured limits
+func (r *RiskTrader) Limits() Limits {
+	return r.limits
+}
+
+// PostOrder checks the order against the limits before posting it
+func (r *RiskTrader) PostOrder(signedOrder *types.SignedOrder, orderType types.OrderType) (map[string]interface{}, error) {
+	if err := r.checkOrder(signedOrder, orderType, newBatch(nil)); err != nil {
+		return nil, err
+	}
+	return r.Trader.PostOrder(signedOrder, orderType)
+}
+
+// GetOrderBooks fetches books through the wrapped trader
+func (r *RiskTrader) GetOrderBooks(ctx context.Context, tokenIDs []string, concurrency int) (map[string]*types.OrderBookSummary, error) {
+	batcher, err := r.batchTrader()
+	if err != nil {
+		return nil, err
+	}
+	return batcher.GetOrderBooks(ctx, tokenIDs, concurrency)
+}
+
+// PostOrders checks every order against the limits and posts them in one
+// batch. Each order is checked as if the ones before it were already open, so
+// the batch as a whole stays within the position and open order limits.
+// Nothing is posted when any order fails a check.
+func (r *RiskTrader) PostOrders(orders []types.PostOrdersArgs) ([]map[string]interface{}, error) {
+	batcher, err := r.batchTrader()
+	if err != nil {
+		return nil, err
+	}
+	if err := r.checkOrders(orders, nil); err != nil {
+		return nil, err
+	}
+	return batcher.PostOrders(orders)
+}
+
+// RequoteLadder checks every new order against the limits, as PostOrders does,
+// before cancelling cancelIDs and posting the new orders. The cancelled orders
+// do not count towards the open order limit. Nothing is cancelled or posted
+// when any new order fails a check.
+func (r *RiskTrader) RequoteLadder(ctx context.Context, cancelIDs []string, newOrders []types.PostOrdersArgs) (*client.RequoteResult, error) {
+	batcher, err := r.batchTrader()
+	if err != nil {
+		return nil, err
+	}
+	if err := r.checkOrders(newOrders, cancelIDs); err != nil {
+		return nil, err
+	}
+	return batcher.RequoteLadder(ctx, cancelIDs, newOrders)
+}
+
+// OrderID returns the exchange ID of a signed order from the wrapped trader,
+// or an empty ID when the wrapped trader cannot tell it before posting
+func (r *RiskTrader) OrderID(signedOrder *types.SignedOrder) (string, error) {
+	identifier, ok := r.Trader.(interface {
+		OrderID(signedOrder *types.SignedOrder) (string, error)
+	})
+	if !ok {
+		return "", nil
+	}
+	return identifier.OrderID(signedOrder)
+}
+
+// batchTrader returns the wrapped trader's batch methods
+func (r *RiskTrader) batchTrader() (BatchTrader, error) {
+	batcher, ok := r.Trader.(BatchTrader)
+	if !ok {
+		return nil, fmt.Errorf("trader %T does not post batches", r.Trader)
+	}
+	return batcher, nil
+}
+
+// checkOrders checks each order of a batch, posted after cancelling cancelIDs,
+// reporting the first that fails
+func (r *RiskTrader) checkOrders(orders []types.PostOrdersArgs, cancelIDs []string) error {
+	b := newBatch(cancelIDs)
+	for i, args := range orders {
+		if args.Order == nil {
+			return fmt.Errorf("order %d is missing", i)
+		}
+		if err := r.checkOrder(args.Order, args.OrderType, b); err != nil {
+			return fmt.Errorf("order %d: %w", i, err)
+		}
+	}
+	return nil
+}
+
+// checkOrder runs the limit checks and, for resting orders, the cross check
+func (r *RiskTrader) checkOrder(signedOrder *types.SignedOrder, orderType types.OrderType, b *batch) error {
+	if err := r.check(signedOrder, b); err != nil {
+		return err
+	}
+	if r.limits.RejectCross && !signedOrder.AllowCross && (orderType == types.GTC || orderType == types.GTD) {
+		return r.checkCross(signedOrder)
+	}
+	return nil
+}
+
+// CreateAndPostOrder creates an order and posts it through the risk checks,
+// as GTD when it expires and GTC otherwise
+func (r *RiskTrader) CreateAndPostOrder(orderArgs types.OrderArgs, options *types.CreateOrderOptions) (map[string]interface{}, error) {
+	signedOrder, err := r.Trader.CreateOrder(orderArgs, options)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create order: %w", err)
+	}
+	return r.PostOrder(signedOrder, client.PostOrderType(signedOrder))
+}
+
+// batch tracks the orders of a batch checked so far, so that each order is
+// checked as if the ones before it were already open
+type batch struct {
+	shares    map[string]float64 // Token -> net shares of the earlier orders
+	orders    map[string]int     // Condition ID -> earlier orders
+	open      map[string]int     // Condition ID -> open orders, counted once per batch
+	cancelled map[string]bool    // Orders cancelled before the batch is posted
+}
+
+// newBatch starts a batch posted after cancelling cancelIDs
+func newBatch(cancelIDs []string) *batch {
+	b := &batch{
+		shares:    make(map[string]float64),
+		orders:    make(map[string]int),
+		open:      make(map[string]int),
+		cancelled: make(map[string]bool, len(cancelIDs)),
+	}
+	for _, id := range cancelIDs {
+		b.cancelled[id] = true
+	}
+	return b
+}
+
+// Check validates a signed order against every configured limit
+func (r *RiskTrader) Check(signedOrder *types.SignedOrder) error {
+	return r.check(signedOrder, newBatch(nil))
+}
+
+// check validates a signed order against every configured limit, counting the
+// earlier orders of its batch, and adds it to the batch when it passes
+func (r *RiskTrader) check(signedOrder *types.SignedOrder, b *batch) error {
+	price, size, err := utils.SignedOrderPriceAndSize(signedOrder)
+	if err != nil {
+		return err
+	}
+	tokenID := signedOrder.TokenID
+	limits := r.limits
+
+	if limits.MaxOrderNotional > 0 && price*size > limits.MaxOrderNotional {
+		return &LimitError{Kind: LimitOrderNotional, TokenID: tokenID, Value: price * size, Limit: limits.MaxOrderNotional}
+	}
+	if limits.MinPrice > 0 && price < limits.MinPrice {
+		return &LimitError{Kind: LimitPriceBand, TokenID: tokenID, Value: price, Limit: limits.MinPrice}
+	}
+	if limits.MaxPrice > 0 && price > limits.MaxPrice {
+		return &LimitError{Kind: LimitPriceBand, TokenID: tokenID, Value: price, Limit: limits.MaxPrice}
+	}
+
+	shares := size
+	if signedOrder.Side == types.SELL {
+		shares = -size
+	}
+	if limits.MaxNetPosition > 0 && r.positions != nil {
+		current := b.shares[tokenID]
+		if pos, exists := r.positions.Position(tokenID); exists {
+			current += pos.Size
+		}
+		after := current + shares
+		// Orders that reduce exposure are always allowed
+		if math.Abs(after) > limits.MaxNetPosition && math.Abs(after) > math.Abs(current) {
+			return &LimitError{Kind: LimitNetPosition, TokenID: tokenID, Value: math.Abs(after), Limit: limits.MaxNetPosition}
+		}
+	}
+
+	if limits.MaxMidDeviation > 0 {
+		resp, err := r.Trader.GetMidpoint(tokenID)
+		if err != nil {
+			return fmt.Errorf("failed to get midpoint for risk check: %w", err)
+		}
+		mid, err := strconv.ParseFloat(resp.Mid, 64)
+		if err != nil {
+			return fmt.Errorf("invalid midpoint %q: %w", resp.Mid, err)
+		}
+		if deviation := math.Abs(price - mid); deviation > limits.MaxMidDeviation {
+			return &LimitError{Kind: LimitMidDeviation, TokenID: tokenID, Value: deviation, Limit: limits.MaxMidDeviation}
+		}
+	}
+
+	market := ""
+	if limits.MaxOpenOrdersPerMarket > 0 {
+		if market, err = r.marketOf(tokenID); err != nil {
+			return err
+		}
+		open, counted := b.open[market]
+		if !counted {
+			if open, err = r.countOpenOrders(market, tokenID, b.cancelled); err != nil {
+				return fmt.Errorf("failed to count open orders for risk check: %w", err)
+			}
+			b.open[market] = open
+		}
+		if total := open + b.orders[market] + 1; total > limits.MaxOpenOrdersPerMarket {
+			return &LimitError{Kind: LimitOpenOrders, TokenID: tokenID, Value: float64(total), Limit: float64(limits.MaxOpenOrdersPerMarket)}
+		}
+	}
+
+	b.shares[tokenID] += shares
+	b.orders[market]++
+	return nil
+}
+
+// checkCross rejects a limit order that would trade against the current book.
+// The limit reported is the best opposite price.
+func (r *RiskTrader) checkCross(signedOrder *types.SignedOrder) error {
+	book, err := r.Trader.GetOrderBook(signedOrder.TokenID)
+	if err != nil {
+		return fmt.Errorf("failed to get order book for risk check: %w", err)
+	}
+	err = client.CheckCross(signedOrder, book)
+	var cross *client.CrossError
+	if errors.As(err, &cross) {
+		return &LimitError{Kind: LimitCross, TokenID: cross.TokenID, Value: cross.Price, Limit: cross.BestPrice}
+	}
+	return err
+}
+
+// marketOf returns the condition ID of a token's market, looked up from its
+// order book and cached. Tokens whose book names no market are their own market.
+func (r *RiskTrader) marketOf(tokenID string) (string, error) {
+	r.mu.Lock()
+	market, exists := r.markets[tokenID]
+	r.mu.Unlock()
+	if exists {
+		return market, nil
+	}
+
+	book, err := r.Trader.GetOrderBook(tokenID)
+	if err != nil {
+		return "", fmt.Errorf("failed to look up market for risk check: %w", err)
+	}
+	market = book.Market
+	if market == "" {
+		market = tokenID
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if _, exists := r.markets[tokenID]; !exists {
+		r.markets[tokenID] = market
+		r.marketTokens[market] = append(r.marketTokens[market], tokenID)
+	}
+	return market, nil
+}
+
+// countOpenOrders counts the open orders in a market, leaving out cancelled
+// ones. Without a source set by SetOpenOrderSource they are listed from the
+// exchange; tokenID is listed on its own when it is its own market.
+func (r *RiskTrader) countOpenOrders(market, tokenID string, cancelled map[string]bool) (int, error) {
+	r.mu.Lock()
+	source := r.openOrders
+	tokens := append([]string(nil), r.marketTokens[market]...)
+	r.mu.Unlock()
+
+	ids := make([]string, 0)
+	if source == nil {
+		params := &types.OpenOrderParams{Market: market}
+		if market == tokenID {
+			params = &types.OpenOrderParams{AssetID: tokenID}
+		}
+		orders, err := r.Trader.GetOpenOrders(params)
+		if err != nil {
+			return 0, err
+		}
+		for _, order := range orders {
+			ids = append(ids, order.ID)
+		}
+	} else {
+		for _, token := range tokens {
+			tokenIDs, err := source(token)
+			if err != nil {
+				return 0, err
+			}
+			ids = append(ids, tokenIDs...)
+		}
+	}
+
+	open := 0
+	for _, id := range ids {
+		if !cancelled[id] {
+			open++
+		}
+	}
+	return open, nil
+}
@@ -0,0 +1,289 @@
+package risk
+
+import (
+	"context"
+	"errors"
+	"strconv"
+	"testing"
+
+	"github.com/MaDal776/polymarket-go-client/pkg/client"
+	"github.com/MaDal776/polymarket-go-client/pkg/portfolio"
+	"github.com/MaDal776/polymarket-go-client/pkg/types"
+)
+
+const testToken = "91094360697357622623953793720402150934374522251651348543981406747516093190659"
+
+type fakeTrader struct {
+	client.Trader
+	posted     int
+	markets    map[string]string // Token -> condition ID named by its book
+	open       []types.OpenOrder // Open orders on the exchange
+	orderTypes []types.OrderType // Order type of each post
+}
+
+func (f *fakeTrader) GetOpenOrders(params *types.OpenOrderParams) ([]types.OpenOrder, error) {
+	orders := make([]types.OpenOrder, 0)
+	for _, order := range f.open {
+		if (params.Market == "" || order.Market == params.Market) && (params.AssetID == "" || order.AssetID == params.AssetID) {
+			orders = append(orders, order)
+		}
+	}
+	return orders, nil
+}
+
+func (f *fakeTrader) PostOrder(signedOrder *types.SignedOrder, orderType types.OrderType) (map[string]interface{}, error) {
+	f.posted++
+	f.orderTypes = append(f.orderTypes, orderType)
+	return map[string]interface{}{"success": true}, nil
+}
+
+// CreateOrder builds an order from the args, expiring like the args
+func (f *fakeTrader) CreateOrder(orderArgs types.OrderArgs, options *types.CreateOrderOptions) (*types.SignedOrder, error) {
+	o := tokenOrder(orderArgs.TokenID, orderArgs.Side, orderArgs.Price, orderArgs.Size)
+	o.Expiration = itoa(orderArgs.Expiration)
+	return o, nil
+}
+
+func (f *fakeTrader) GetMidpoint(tokenID string) (*types.MidpointResponse, error) {
+	return &types.MidpointResponse{Mid: "0.5"}, nil
+}
+
+func (f *fakeTrader) GetOrderBook(tokenID string) (*types.OrderBookSummary, error) {
+	return &types.OrderBookSummary{
+		Market:  f.markets[tokenID],
+		AssetID: tokenID,
+		Bids:    []types.OrderSummary{{Price: "0.48", Size: "100"}},
+		Asks:    []types.OrderSummary{{Price: "0.52", Size: "100"}},
+	}, nil
+}
+
+// batchTrader adds the batch methods to fakeTrader
+type batchTrader struct {
+	fakeTrader
+	batches  [][]types.PostOrdersArgs
+	cancels  [][]string
+	booksFor []string
+}
+
+func (f *batchTrader) GetOrderBooks(ctx context.Context, tokenIDs []string, concurrency int) (map[string]*types.OrderBookSummary, error) {
+	f.booksFor = append(f.booksFor, tokenIDs...)
+	return map[string]*types.OrderBookSummary{}, nil
+}
+
+func (f *batchTrader) PostOrders(orders []types.PostOrdersArgs) ([]map[string]interface{}, error) {
+	f.batches = append(f.batches, orders)
+	return make([]map[string]interface{}, len(orders)), nil
+}
+
+func (f *batchTrader) RequoteLadder(ctx context.Context, cancelIDs []string, newOrders []types.PostOrdersArgs) (*client.RequoteResult, error) {
+	f.cancels = append(f.cancels, cancelIDs)
+	f.batches = append(f.batches, newOrders)
+	return &client.RequoteResult{}, nil
+}
+
+// order builds a signed order for size shares at price
+func order(side types.OrderSide, price, size float64) *types.SignedOrder {
+	return tokenOrder(testToken, side, price, size)
+}
+
+// tokenOrder builds a signed order on tokenID for size shares at price
+func tokenOrder(tokenID string, side types.OrderSide, price, size float64) *types.SignedOrder {
+	usdc := int64(price * size * 1e6)
+	shares := int64(size * 1e6)
+	o := &types.SignedOrder{TokenID: tokenID, Side: side}
+	if side == types.BUY {
+		o.MakerAmount, o.TakerAmount = itoa(usdc), itoa(shares)
+	} else {
+		o.MakerAmount, o.TakerAmount = itoa(shares), itoa(usdc)
+	}
+	return o
+}
+
+func itoa(v int64) string {
+	return strconv.FormatInt(v, 10)
+}
+
+func TestRejectsOversizedNotional(t *testing.T) {
+	trader := &fakeTrader{}
+	r := NewRiskTrader(trader, Limits{MaxOrderNotional: 10}, nil)
+
+	_, err := r.PostOrder(order(types.BUY, 0.5, 40), types.GTC)
+	var limitErr *LimitError
+	if !errors.As(err, &limitErr) || limitErr.Kind != LimitOrderNotional {
+		t.Fatalf("Expected notional limit error, got %v", err)
+	}
+	if !errors.Is(err, ErrLimitExceeded) {
+		t.Error("Expected error to match ErrLimitExceeded")
+	}
+	if trader.posted != 0 {
+		t.Error("Rejected order must not be posted")
+	}
+
+	if _, err := r.PostOrder(order(types.BUY, 0.5, 10), types.GTC); err != nil {
+		t.Fatalf("Expected order within limits to pass, got %v", err)
+	}
+}
+
+func TestNetPositionAllowsReducingOrders(t *testing.T) {
+	p := portfolio.NewPortfolio()
+	p.SetPosition(testToken, 100, 0.5)
+	r := NewRiskTrader(&fakeTrader{}, Limits{MaxNetPosition: 80}, p)
+
+	if err := r.Check(order(types.BUY, 0.5, 10)); !errors.Is(err, ErrLimitExceeded) {
+		t.Errorf("Expected increasing order to be rejected, got %v", err)
+	}
+	if err := r.Check(order(types.SELL, 0.5, 10)); err != nil {
+		t.Errorf("Expected reducing order to pass, got %v", err)
+	}
+}
+
+func TestMidDeviation(t *testing.T) {
+	r := NewRiskTrader(&fakeTrader{}, Limits{MaxMidDeviation: 0.1}, nil)
+
+	if err := r.Check(order(types.BUY, 0.7, 10)); !errors.Is(err, ErrLimitExceeded) {
+		t.Errorf("Expected price far from mid to be rejected, got %v", err)
+	}
+}
+
+func TestRejectCross(t *testing.T) {
+	trader := &fakeTrader{}
+	r := NewRiskTrader(trader, Limits{RejectCross: true}, nil)
+
+	_, err := r.PostOrder(order(types.BUY, 0.55, 10), types.GTC)
+	var limitErr *LimitError
+	if !errors.As(err, &limitErr) || limitErr.Kind != LimitCross || limitErr.Limit != 0.52 {
+		t.Fatalf("Expected cross limit error against the 0.52 ask, got %v", err)
+	}
+	if _, err := r.PostOrder(order(types.SELL, 0.5, 10), types.GTC); err != nil {
+		t.Errorf("Expected resting sell to pass, got %v", err)
+	}
+	if _, err := r.PostOrder(order(types.BUY, 0.55, 10), types.FAK); err != nil {
+		t.Errorf("Expected FAK order to skip the cross check, got %v", err)
+	}
+
+	allowed := order(types.BUY, 0.55, 10)
+	allowed.AllowCross = true
+	if _, err := r.PostOrder(allowed, types.GTC); err != nil {
+		t.Errorf("Expected AllowCross order to pass, got %v", err)
+	}
+	if trader.posted != 3 {
+		t.Errorf("Expected 3 orders posted, got %d", trader.posted)
+	}
+}
+
+func TestBatchesAreChecked(t *testing.T) {
+	trader := &batchTrader{}
+	r := NewRiskTrader(trader, Limits{MaxOrderNotional: 10}, nil)
+
+	ok := types.PostOrdersArgs{Order: order(types.BUY, 0.5, 10), OrderType: types.GTC}
+	tooBig := types.PostOrdersArgs{Order: order(types.BUY, 0.5, 40), OrderType: types.GTC}
+	if _, err := r.PostOrders([]types.PostOrdersArgs{ok, tooBig}); !errors.Is(err, ErrLimitExceeded) {
+		t.Fatalf("Expected the batch to be refused, got %v", err)
+	}
+	if _, err := r.RequoteLadder(context.Background(), []string{"0x1"}, []types.PostOrdersArgs{tooBig}); !errors.Is(err, ErrLimitExceeded) {
+		t.Fatalf("Expected the ladder to be refused, got %v", err)
+	}
+	if len(trader.batches) != 0 || len(trader.cancels) != 0 {
+		t.Fatalf("Refused batches must not reach the trader")
+	}
+
+	if _, err := r.PostOrders([]types.PostOrdersArgs{ok, ok}); err != nil {
+		t.Fatalf("Expected orders within limits to pass, got %v", err)
+	}
+	if _, err := r.RequoteLadder(context.Background(), []string{"0x1"}, []types.PostOrdersArgs{ok}); err != nil {
+		t.Fatalf("Expected the ladder to pass, got %v", err)
+	}
+	if _, err := r.GetOrderBooks(context.Background(), []string{testToken}, 1); err != nil {
+		t.Fatalf("GetOrderBooks failed: %v", err)
+	}
+	if len(trader.batches) != 2 || len(trader.cancels) != 1 || len(trader.booksFor) != 1 {
+		t.Errorf("Expected the batches to be passed through, got %d batches, %d cancels", len(trader.batches), len(trader.cancels))
+	}
+
+	// Traders without batch methods are refused rather than bypassing the checks
+	plain := NewRiskTrader(&fakeTrader{}, Limits{}, nil)
+	if _, err := plain.PostOrders([]types.PostOrdersArgs{ok}); err == nil {
+		t.Error("Expected an error for a trader without PostOrders")
+	}
+}
+
+func TestOpenOrdersAreCountedPerMarket(t *testing.T) {
+	trader := &fakeTrader{
+		markets: map[string]string{"yes": "0xmarket", "no": "0xmarket"},
+		open: []types.OpenOrder{
+			{ID: "0x1", Market: "0xmarket", AssetID: "yes"},
+			{ID: "0x2", Market: "0xother", AssetID: "other"},
+		},
+	}
+	r := NewRiskTrader(trader, Limits{MaxOpenOrdersPerMarket: 2}, nil)
+
+	// The order on the other outcome counts towards the market
+	if err := r.Check(tokenOrder("no", types.BUY, 0.5, 10)); err != nil {
+		t.Fatalf("Expected a second order in the market to pass, got %v", err)
+	}
+	trader.open = append(trader.open, types.OpenOrder{ID: "0x3", Market: "0xmarket", AssetID: "yes"})
+	var limitErr *LimitError
+	if err := r.Check(tokenOrder("no", types.BUY, 0.5, 10)); !errors.As(err, &limitErr) || limitErr.Kind != LimitOpenOrders || limitErr.Value != 3 {
+		t.Fatalf("Expected the market's open order limit, got %v", err)
+	}
+
+	// A local source is asked for every token seen in the market
+	asked := make([]string, 0)
+	r.SetOpenOrderSource(func(tokenID string) ([]string, error) {
+		asked = append(asked, tokenID)
+		if tokenID == "yes" {
+			return []string{"0x1"}, nil
+		}
+		return nil, nil
+	})
+	if err := r.Check(tokenOrder("yes", types.BUY, 0.5, 10)); err != nil {
+		t.Fatalf("Expected the local count to be used, got %v", err)
+	}
+	if len(asked) != 2 {
+		t.Errorf("Expected both outcomes to be counted, asked %v", asked)
+	}
+}
+
+func TestBatchCountsItsOwnOrders(t *testing.T) {
+	trader := &batchTrader{fakeTrader: fakeTrader{
+		markets: map[string]string{testToken: "0xmarket"},
+		open:    []types.OpenOrder{{ID: "0x1", Market: "0xmarket", AssetID: testToken}},
+	}}
+	p := portfolio.NewPortfolio()
+	r := NewRiskTrader(trader, Limits{MaxOpenOrdersPerMarket: 2, MaxNetPosition: 25}, p)
+
+	buy := types.PostOrdersArgs{Order: order(types.BUY, 0.5, 10), OrderType: types.GTC}
+	var limitErr *LimitError
+	if _, err := r.PostOrders([]types.PostOrdersArgs{buy, buy}); !errors.As(err, &limitErr) || limitErr.Kind != LimitOpenOrders {
+		t.Fatalf("Expected the batch to exceed the open order limit, got %v", err)
+	}
+
+	// Replacing the open order frees its slot, but three buys exceed the position limit
+	if _, err := r.RequoteLadder(context.Background(), []string{"0x1"}, []types.PostOrdersArgs{buy, buy}); err != nil {
+		t.Fatalf("Expected the ladder to replace the open order, got %v", err)
+	}
+	r = NewRiskTrader(trader, Limits{MaxNetPosition: 25}, p)
+	if _, err := r.PostOrders([]types.PostOrdersArgs{buy, buy, buy}); !errors.As(err, &limitErr) || limitErr.Kind != LimitNetPosition || limitErr.Value != 30 {
+		t.Fatalf("Expected the batch to exceed the position limit, got %v", err)
+	}
+	if len(trader.batches) != 1 {
+		t.Errorf("Expected only the ladder to be posted, got %d batches", len(trader.batches))
+	}
+}
+
+func TestCreateAndPostOrderPostsExpiringOrdersAsGTD(t *testing.T) {
+	trader := &fakeTrader{}
+	r := NewRiskTrader(trader, Limits{}, nil)
+
+	args := types.OrderArgs{TokenID: testToken, Side: types.BUY, Price: 0.5, Size: 10}
+	if _, err := r.CreateAndPostOrder(args, nil); err != nil {
+		t.Fatalf("CreateAndPostOrder: %v", err)
+	}
+	args.Expiration = 1900000000
+	if _, err := r.CreateAndPostOrder(args, nil); err != nil {
+		t.Fatalf("CreateAndPostOrder: %v", err)
+	}
+	if len(trader.orderTypes) != 2 || trader.orderTypes[0] != types.GTC || trader.orderTypes[1] != types.GTD {
+		t.Errorf("Expected GTC then GTD, got %v", trader.orderTypes)
+	}
+}
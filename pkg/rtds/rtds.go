@@ -0,0 +1,283 @@
+// Package rtds reads Polymarket's real-time data socket (RTDS), the feed the
+// web UI uses for crypto reference prices and comments. This module has no
+// websocket dependency: Stream runs over any connection with the methods of a
+// github.com/gorilla/websocket Conn, so callers dial with the library they
+// already use and pass the connection in.
+package rtds
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// DefaultURL is the RTDS websocket endpoint
+const DefaultURL = "wss://ws-live-data.polymarket.com"
+
+// TextMessage is the websocket text frame type, equal to websocket.TextMessage
+const TextMessage = 1
+
+// Topics published by the socket
+const (
+	TopicCryptoPrices          = "crypto_prices"           // Binance spot prices
+	TopicCryptoPricesChainlink = "crypto_prices_chainlink" // Chainlink reference prices, used to resolve crypto markets
+	TopicComments              = "comments"
+)
+
+// Message types of the comments topic
+const (
+	CommentCreated  = "comment_created"
+	CommentRemoved  = "comment_removed"
+	ReactionCreated = "reaction_created"
+	ReactionRemoved = "reaction_removed"
+)
+
+// Subscription selects messages of one topic. An empty Type subscribes to every
+// type of the topic, "*" in the protocol.
+type Subscription struct {
+	Topic   string `json:"topic"`
+	Type    string `json:"type"`
+	Filters string `json:"filters,omitempty"`
+}
+
+// CryptoPrices subscribes to Binance prices of symbols such as "btcusdt", or of
+// every symbol when none are given
+func CryptoPrices(symbols ...string) Subscription {
+	return Subscription{Topic: TopicCryptoPrices, Type: "update", Filters: strings.ToLower(strings.Join(symbols, ","))}
+}
+
+// ChainlinkPrice subscribes to the Chainlink price of a pair such as "btc/usd"
+func ChainlinkPrice(symbol string) Subscription {
+	filters, _ := json.Marshal(map[string]string{"symbol": strings.ToLower(symbol)})
+	return Subscription{Topic: TopicCryptoPricesChainlink, Type: "*", Filters: string(filters)}
+}
+
+// Comments subscribes to the comments and reactions on an entity, e.g. an
+// "Event" and its Gamma ID
+func Comments(entityType string, entityID int64) Subscription {
+	filters, _ := json.Marshal(map[string]interface{}{"parentEntityType": entityType, "parentEntityID": entityID})
+	return Subscription{Topic: TopicComments, Type: "*", Filters: string(filters)}
+}
+
+// SubscribeMessage returns the frame that subscribes to subscriptions
+func SubscribeMessage(subscriptions ...Subscription) ([]byte, error) {
+	return actionMessage("subscribe", subscriptions)
+}
+
+// UnsubscribeMessage returns the frame that ends subscriptions
+func UnsubscribeMessage(subscriptions ...Subscription) ([]byte, error) {
+	return actionMessage("unsubscribe", subscriptions)
+}
+
+// actionMessage encodes a subscription change
+func actionMessage(action string, subscriptions []Subscription) ([]byte, error) {
+	if len(subscriptions) == 0 {
+		return nil, fmt.Errorf("at least one subscription is required")
+	}
+	subs := make([]Subscription, 0, len(subscriptions))
+	for _, sub := range subscriptions {
+		if sub.Topic == "" {
+			return nil, fmt.Errorf("subscription topic is required")
+		}
+		if sub.Type == "" {
+			sub.Type = "*"
+		}
+		subs = append(subs, sub)
+	}
+	return json.Marshal(map[string]interface{}{"action": action, "subscriptions": subs})
+}
+
+// Message is a frame published on a topic
+type Message struct {
+	Topic        string          `json:"topic"`
+	Type         string          `json:"type"`
+	Timestamp    int64           `json:"timestamp"` // Unix milliseconds
+	ConnectionID string          `json:"connection_id,omitempty"`
+	Payload      json.RawMessage `json:"payload"`
+}
+
+// CryptoPrice is a price update of the crypto_prices topics
+type CryptoPrice struct {
+	Symbol    string  `json:"symbol"`
+	Timestamp int64   `json:"timestamp"` // Unix milliseconds of the observation
+	Value     float64 `json:"value"`
+}
+
+// Time returns the time of the observation
+func (p *CryptoPrice) Time() time.Time {
+	return time.UnixMilli(p.Timestamp)
+}
+
+// Comment is a comment or reaction of the comments topic
+type Comment struct {
+	ID               string `json:"id"`
+	Body             string `json:"body,omitempty"`
+	ParentEntityType string `json:"parentEntityType"`
+	ParentEntityID   int64  `json:"parentEntityID"`
+	ParentCommentID  string `json:"parentCommentID,omitempty"`
+	UserAddress      string `json:"userAddress"`
+	ReplyAddress     string `json:"replyAddress,omitempty"`
+	ReactionType     string `json:"reactionType,omitempty"`
+	CreatedAt        string `json:"createdAt"`
+	UpdatedAt        string `json:"updatedAt,omitempty"`
+}
+
+// Decode parses a frame. Frames that are not JSON messages, such as the pong
+// answering a ping, return a nil message and no error.
+func Decode(data []byte) (*Message, error) {
+	trimmed := strings.TrimSpace(string(data))
+	if trimmed == "" || !strings.HasPrefix(trimmed, "{") {
+		return nil, nil
+	}
+	var message Message
+	if err := json.Unmarshal(data, &message); err != nil {
+		return nil, fmt.Errorf("failed to decode RTDS message: %w", err)
+	}
+	return &message, nil
+}
+
+// CryptoPrice decodes the payload of a crypto_prices message
+func (m *Message) CryptoPrice() (*CryptoPrice, error) {
+	if m.Topic != TopicCryptoPrices && m.Topic != TopicCryptoPricesChainlink {
+		return nil, fmt.Errorf("message on topic %s is not a crypto price", m.Topic)
+	}
+	var raw struct {
+		Symbol    string      `json:"symbol"`
+		Timestamp int64       `json:"timestamp"`
+		Value     json.Number `json:"value"`
+	}
+	if err := json.Unmarshal(m.Payload, &raw); err != nil {
+		return nil, fmt.Errorf("failed to decode crypto price: %w", err)
+	}
+	value, err := strconv.ParseFloat(raw.Value.String(), 64)
+	if err != nil {
+		return nil, fmt.Errorf("invalid crypto price %q: %w", raw.Value, err)
+	}
+	return &CryptoPrice{Symbol: raw.Symbol, Timestamp: raw.Timestamp, Value: value}, nil
+}
+
+// Comment decodes the payload of a comments message
+func (m *Message) Comment() (*Comment, error) {
+	if m.Topic != TopicComments {
+		return nil, fmt.Errorf("message on topic %s is not a comment", m.Topic)
+	}
+	var comment Comment
+	if err := json.Unmarshal(m.Payload, &comment); err != nil {
+		return nil, fmt.Errorf("failed to decode comment: %w", err)
+	}
+	return &comment, nil
+}
+
+// Conn is a websocket connection, e.g. a *websocket.Conn from gorilla/websocket
+type Conn interface {
+	ReadMessage() (messageType int, data []byte, err error)
+	WriteMessage(messageType int, data []byte) error
+	Close() error
+}
+
+// Config configures Stream
+type Config struct {
+	Subscriptions []Subscription // Required
+	PingInterval  time.Duration  // Default 5s; the server drops quiet connections
+	OnPrice       func(price CryptoPrice)
+	OnComment     func(messageType string, comment Comment)
+	OnMessage     func(message Message) // Every decoded message, including the ones above
+	OnError       func(err error)       // Messages that could not be decoded
+}
+
+// Stream subscribes on conn and dispatches messages to the callbacks until the
+// connection fails or ctx ends, closing conn either way. It returns the read
+// error, or ctx's error. Reconnecting is up to the caller: dial again and call
+// Stream with the same config.
+func Stream(ctx context.Context, conn Conn, config Config) error {
+	subscribe, err := SubscribeMessage(config.Subscriptions...)
+	if err != nil {
+		conn.Close()
+		return err
+	}
+	if config.PingInterval <= 0 {
+		config.PingInterval = 5 * time.Second
+	}
+
+	// gorilla/websocket allows one concurrent writer
+	var writeMu sync.Mutex
+	write := func(data []byte) error {
+		writeMu.Lock()
+		defer writeMu.Unlock()
+		return conn.WriteMessage(TextMessage, data)
+	}
+	if err := write(subscribe); err != nil {
+		conn.Close()
+		return fmt.Errorf("failed to subscribe: %w", err)
+	}
+
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	var closeOnce sync.Once
+	closeConn := func() { closeOnce.Do(func() { conn.Close() }) }
+	defer closeConn()
+
+	go func() {
+		ticker := time.NewTicker(config.PingInterval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ctx.Done():
+				closeConn()
+				return
+			case <-ticker.C:
+				if err := write([]byte("ping")); err != nil {
+					closeConn()
+					return
+				}
+			}
+		}
+	}()
+
+	for {
+		_, data, err := conn.ReadMessage()
+		if err != nil {
+			if ctx.Err() != nil {
+				return ctx.Err()
+			}
+			return fmt.Errorf("RTDS connection failed: %w", err)
+		}
+		dispatch(data, config)
+	}
+}
+
+// dispatch decodes one frame and passes it to the matching callbacks
+func dispatch(data []byte, config Config) {
+	message, err := Decode(data)
+	if err == nil && message == nil {
+		return
+	}
+	if err == nil && config.OnMessage != nil {
+		config.OnMessage(*message)
+	}
+	if err == nil {
+		switch message.Topic {
+		case TopicCryptoPrices, TopicCryptoPricesChainlink:
+			if config.OnPrice != nil {
+				var price *CryptoPrice
+				if price, err = message.CryptoPrice(); err == nil {
+					config.OnPrice(*price)
+				}
+			}
+		case TopicComments:
+			if config.OnComment != nil {
+				var comment *Comment
+				if comment, err = message.Comment(); err == nil {
+					config.OnComment(message.Type, *comment)
+				}
+			}
+		}
+	}
+	if err != nil && config.OnError != nil {
+		config.OnError(err)
+	}
+}
@@ -0,0 +1,119 @@
+package rtds
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+)
+
+// fakeConn replays frames and records what is written
+type fakeConn struct {
+	mu      sync.Mutex
+	frames  chan []byte
+	written []string
+	closed  chan struct{}
+	once    sync.Once
+}
+
+func newFakeConn(frames ...string) *fakeConn {
+	c := &fakeConn{frames: make(chan []byte, len(frames)), closed: make(chan struct{})}
+	for _, frame := range frames {
+		c.frames <- []byte(frame)
+	}
+	return c
+}
+
+func (c *fakeConn) ReadMessage() (int, []byte, error) {
+	select {
+	case frame := <-c.frames:
+		return TextMessage, frame, nil
+	case <-c.closed:
+		return 0, nil, errors.New("use of closed connection")
+	}
+}
+
+func (c *fakeConn) WriteMessage(messageType int, data []byte) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.written = append(c.written, string(data))
+	return nil
+}
+
+func (c *fakeConn) Close() error {
+	c.once.Do(func() { close(c.closed) })
+	return nil
+}
+
+func TestSubscribeMessage(t *testing.T) {
+	data, err := SubscribeMessage(CryptoPrices("BTCUSDT", "ethusdt"), Comments("Event", 100))
+	if err != nil {
+		t.Fatalf("SubscribeMessage failed: %v", err)
+	}
+	var decoded struct {
+		Action        string         `json:"action"`
+		Subscriptions []Subscription `json:"subscriptions"`
+	}
+	if err := json.Unmarshal(data, &decoded); err != nil {
+		t.Fatalf("Invalid message %s: %v", data, err)
+	}
+	if decoded.Action != "subscribe" || len(decoded.Subscriptions) != 2 || decoded.Subscriptions[0].Filters != "btcusdt,ethusdt" {
+		t.Errorf("Unexpected subscribe message %s", data)
+	}
+	if decoded.Subscriptions[1].Filters != `{"parentEntityID":100,"parentEntityType":"Event"}` {
+		t.Errorf("Unexpected comment filters %s", decoded.Subscriptions[1].Filters)
+	}
+	if _, err := SubscribeMessage(); err == nil {
+		t.Errorf("Expected an empty subscription list to be rejected")
+	}
+}
+
+func TestStreamDispatches(t *testing.T) {
+	conn := newFakeConn(
+		`{"topic":"crypto_prices","type":"update","timestamp":1753314064237,"payload":{"symbol":"btcusdt","timestamp":1753314064213,"value":67234.5}}`,
+		`pong`,
+		`{"topic":"comments","type":"comment_created","timestamp":1753314064300,"payload":{"id":"1","body":"up only","parentEntityType":"Event","parentEntityID":100,"userAddress":"0xabc"}}`,
+		`{"topic":"crypto_prices","type":"update","payload":{"symbol":"ethusdt","value":"oops"}}`,
+	)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	var prices []CryptoPrice
+	var comments []Comment
+	var errs []error
+	done := make(chan error)
+	go func() {
+		done <- Stream(ctx, conn, Config{
+			Subscriptions: []Subscription{CryptoPrices("btcusdt")},
+			OnPrice:       func(price CryptoPrice) { prices = append(prices, price) },
+			OnComment:     func(messageType string, comment Comment) { comments = append(comments, comment) },
+			OnError: func(err error) {
+				errs = append(errs, err)
+				cancel()
+			},
+		})
+	}()
+
+	select {
+	case err := <-done:
+		if !errors.Is(err, context.Canceled) {
+			t.Errorf("Expected the stream to end with ctx, got %v", err)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatalf("Stream did not return after ctx was cancelled")
+	}
+
+	if len(prices) != 1 || prices[0].Value != 67234.5 || prices[0].Time().UnixMilli() != 1753314064213 {
+		t.Errorf("Unexpected prices %+v", prices)
+	}
+	if len(comments) != 1 || comments[0].Body != "up only" || comments[0].ParentEntityID != 100 {
+		t.Errorf("Unexpected comments %+v", comments)
+	}
+	if len(errs) != 1 {
+		t.Errorf("Expected the malformed price reported, got %v", errs)
+	}
+	if len(conn.written) == 0 || conn.written[0][:22] != `{"action":"subscribe",` {
+		t.Errorf("Expected the subscription sent first, got %v", conn.written)
+	}
+}
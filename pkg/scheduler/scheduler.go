@@ -0,0 +1,271 @@
+package scheduler
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/MaDal776/polymarket-go-client/pkg/client"
+	"github.com/MaDal776/polymarket-go-client/pkg/clock"
+	"github.com/MaDal776/polymarket-go-client/pkg/types"
+)
+
+// State is the lifecycle state of a scheduled job
+type State string
+
+const (
+	StateScheduled State = "SCHEDULED"
+	StateSubmitted State = "SUBMITTED"
+	StateFailed    State = "FAILED"
+	StateCancelled State = "CANCELLED"
+)
+
+// Job is an order waiting for its activation time
+type Job struct {
+	ID          string                    `json:"id"`
+	ActivateAt  time.Time                 `json:"activate_at"` // Exchange server time
+	Signed      *types.SignedOrder        `json:"signed,omitempty"`
+	Args        *types.OrderArgs          `json:"args,omitempty"` // Deferred signing
+	Options     *types.CreateOrderOptions `json:"options,omitempty"`
+	OrderType   types.OrderType           `json:"order_type"`
+	State       State                     `json:"state"`
+	SubmittedAt time.Time                 `json:"submitted_at,omitempty"` // Exchange server time
+	Result      map[string]interface{}    `json:"result,omitempty"`
+	Error       string                    `json:"error,omitempty"`
+}
+
+// clockSource is implemented by traders that keep server time, like a ClobClient
+type clockSource interface {
+	Clock() clock.Clock
+}
+
+// Config configures a Scheduler
+type Config struct {
+	Lead      time.Duration // Post this much before activation to absorb network latency
+	SignAhead time.Duration // Sign deferred orders this much before activation; default 2s
+	OnSubmit  func(job Job) // Called after every submission attempt
+	Clock     clock.Clock   // Exchange server time; default the trader's Clock(), or local time if it has none
+}
+
+// Scheduler posts orders at their activation time, measured in exchange server time
+type Scheduler struct {
+	trader client.Trader
+	config Config
+	clock  clock.Clock
+
+	mu   sync.Mutex
+	jobs map[string]*Job
+	wake chan struct{}
+}
+
+// NewScheduler creates a scheduler. Given a ClobClient, it runs on the
+// client's clock, which SyncServerTime keeps on server time.
+func NewScheduler(trader client.Trader, config Config) *Scheduler {
+	if config.SignAhead <= 0 {
+		config.SignAhead = 2 * time.Second
+	}
+	now := config.Clock
+	if now == nil {
+		now = clock.System
+		if source, ok := trader.(clockSource); ok {
+			now = source.Clock()
+		}
+	}
+	return &Scheduler{
+		trader: trader,
+		config: config,
+		clock:  now,
+		jobs:   make(map[string]*Job),
+		wake:   make(chan struct{}, 1),
+	}
+}
+
+// ScheduleSigned schedules an already signed order for activation at the given server time
+func (s *Scheduler) ScheduleSigned(signedOrder *types.SignedOrder, orderType types.OrderType, activateAt time.Time) (string, error) {
+	if signedOrder == nil {
+		return "", fmt.Errorf("signed order is required")
+	}
+	return s.add(&Job{Signed: signedOrder, OrderType: orderType, ActivateAt: activateAt})
+}
+
+// ScheduleDeferred schedules an order that is signed shortly before its activation
+func (s *Scheduler) ScheduleDeferred(orderArgs types.OrderArgs, options *types.CreateOrderOptions, orderType types.OrderType, activateAt time.Time) (string, error) {
+	return s.add(&Job{Args: &orderArgs, Options: options, OrderType: orderType, ActivateAt: activateAt})
+}
+
+// Cancel removes a job that has not been submitted
+func (s *Scheduler) Cancel(id string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	job, exists := s.jobs[id]
+	if !exists {
+		return fmt.Errorf("job %s not found", id)
+	}
+	if job.State != StateScheduled {
+		return fmt.Errorf("job %s is %s", id, job.State)
+	}
+	job.State = StateCancelled
+	return nil
+}
+
+// Jobs returns copies of all jobs ordered by activation time
+func (s *Scheduler) Jobs() []Job {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	jobs := make([]Job, 0, len(s.jobs))
+	for _, job := range s.jobs {
+		jobs = append(jobs, *job)
+	}
+	sort.Slice(jobs, func(i, j int) bool {
+		return jobs[i].ActivateAt.Before(jobs[j].ActivateAt)
+	})
+	return jobs
+}
+
+// Run submits jobs as they become due until ctx is cancelled
+func (s *Scheduler) Run(ctx context.Context) {
+	for {
+		wait := s.process()
+
+		timer := time.NewTimer(wait)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return
+		case <-s.wake:
+			timer.Stop()
+		case <-timer.C:
+		}
+	}
+}
+
+// process signs and submits due jobs and returns how long to wait for the next one
+func (s *Scheduler) process() time.Duration {
+	const idle = time.Minute
+
+	now := s.clock.Now()
+	s.mu.Lock()
+	due := make([]*Job, 0)
+	toSign := make([]*Job, 0)
+	wait := idle
+	for _, job := range s.jobs {
+		if job.State != StateScheduled {
+			continue
+		}
+
+		sendAt := job.ActivateAt.Add(-s.config.Lead)
+		if !now.Before(sendAt) {
+			due = append(due, job)
+			continue
+		}
+		if job.Signed == nil && !now.Before(sendAt.Add(-s.config.SignAhead)) {
+			toSign = append(toSign, job)
+		}
+
+		next := sendAt.Sub(now)
+		if job.Signed == nil && next > s.config.SignAhead {
+			next -= s.config.SignAhead
+		}
+		if next < wait {
+			wait = next
+		}
+	}
+	s.mu.Unlock()
+
+	for _, job := range toSign {
+		s.sign(job)
+	}
+	sort.Slice(due, func(i, j int) bool {
+		return due[i].ActivateAt.Before(due[j].ActivateAt)
+	})
+	for _, job := range due {
+		s.submit(job)
+	}
+
+	if len(toSign) > 0 && wait > 10*time.Millisecond {
+		wait = 10 * time.Millisecond
+	}
+	return wait
+}
+
+// sign signs a deferred job ahead of its activation
+func (s *Scheduler) sign(job *Job) {
+	signedOrder, err := s.trader.CreateOrder(*job.Args, job.Options)
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if err != nil {
+		job.State = StateFailed
+		job.Error = fmt.Sprintf("failed to sign order: %v", err)
+		return
+	}
+	job.Signed = signedOrder
+}
+
+// submit posts a due job
+func (s *Scheduler) submit(job *Job) {
+	s.mu.Lock()
+	if job.State != StateScheduled {
+		s.mu.Unlock()
+		return
+	}
+	signedOrder := job.Signed
+	s.mu.Unlock()
+
+	var result map[string]interface{}
+	var err error
+	if signedOrder == nil {
+		signedOrder, err = s.trader.CreateOrder(*job.Args, job.Options)
+	}
+	if err == nil {
+		result, err = s.trader.PostOrder(signedOrder, job.OrderType)
+	}
+
+	s.mu.Lock()
+	job.Signed = signedOrder
+	job.SubmittedAt = s.clock.Now()
+	job.Result = result
+	job.State = StateSubmitted
+	if err != nil {
+		job.State = StateFailed
+		job.Error = err.Error()
+	}
+	callback := s.config.OnSubmit
+	snapshot := *job
+	s.mu.Unlock()
+
+	if callback != nil {
+		callback(snapshot)
+	}
+}
+
+// add validates and registers a job
+func (s *Scheduler) add(job *Job) (string, error) {
+	if job.OrderType == "" {
+		job.OrderType = types.GTC
+	}
+	if job.ActivateAt.IsZero() {
+		return "", fmt.Errorf("activation time is required")
+	}
+
+	b := make([]byte, 8)
+	rand.Read(b)
+	job.ID = hex.EncodeToString(b)
+	job.State = StateScheduled
+
+	s.mu.Lock()
+	s.jobs[job.ID] = job
+	s.mu.Unlock()
+
+	select {
+	case s.wake <- struct{}{}:
+	default:
+	}
+	return job.ID, nil
+}
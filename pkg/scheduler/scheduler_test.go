@@ -0,0 +1,119 @@
+package scheduler
+
+import (
+	"testing"
+	"time"
+
+	"github.com/MaDal776/polymarket-go-client/pkg/client"
+	"github.com/MaDal776/polymarket-go-client/pkg/clock"
+	"github.com/MaDal776/polymarket-go-client/pkg/types"
+)
+
+// fakeClock is a local clock moved by hand
+type fakeClock struct{ now time.Time }
+
+func (f *fakeClock) Now() time.Time { return f.now }
+
+// fakeTrader signs and acknowledges every order; other Trader methods are not used
+type fakeTrader struct {
+	client.Trader
+	signed int
+	posted []*types.SignedOrder
+}
+
+func (f *fakeTrader) CreateOrder(orderArgs types.OrderArgs, options *types.CreateOrderOptions) (*types.SignedOrder, error) {
+	f.signed++
+	return &types.SignedOrder{TokenID: orderArgs.TokenID, Side: orderArgs.Side}, nil
+}
+
+func (f *fakeTrader) PostOrder(signedOrder *types.SignedOrder, orderType types.OrderType) (map[string]interface{}, error) {
+	f.posted = append(f.posted, signedOrder)
+	return map[string]interface{}{"success": true, "orderID": "0x1"}, nil
+}
+
+// clockTrader is a trader keeping server time, like a ClobClient
+type clockTrader struct {
+	fakeTrader
+	clock clock.Clock
+}
+
+func (c *clockTrader) Clock() clock.Clock { return c.clock }
+
+func TestNewSchedulerUsesTheTradersClock(t *testing.T) {
+	server := &fakeClock{now: time.Unix(1030, 0)}
+	if s := NewScheduler(&clockTrader{clock: server}, Config{}); s.clock != server {
+		t.Error("Expected the trader's clock by default")
+	}
+
+	configured := &fakeClock{now: time.Unix(1000, 0)}
+	if s := NewScheduler(&clockTrader{clock: server}, Config{Clock: configured}); s.clock != configured {
+		t.Error("Expected Config.Clock to take precedence")
+	}
+	if s := NewScheduler(&fakeTrader{}, Config{}); s.clock != clock.System {
+		t.Error("Expected the system clock for a trader without one")
+	}
+}
+
+func TestProcessSubmitsOnServerTime(t *testing.T) {
+	local := &fakeClock{now: time.Unix(1000, 0)}
+	var submitted []Job
+	// A synced client's clock, 100.5s ahead of the local one
+	server := clock.NewOffset(local)
+	server.SetOffset(100500 * time.Millisecond)
+	trader := &clockTrader{clock: server}
+	s := NewScheduler(trader, Config{
+		Lead:      time.Second,
+		SignAhead: 5 * time.Second,
+		OnSubmit:  func(job Job) { submitted = append(submitted, job) },
+	})
+
+	// Activation times are on the server clock. Each process call stands in
+	// for a wake-up of Run.
+	activateAt := time.Unix(1110, 0)
+	signedID, _ := s.ScheduleSigned(&types.SignedOrder{TokenID: "a"}, types.GTC, activateAt)
+	deferredID, _ := s.ScheduleDeferred(types.OrderArgs{TokenID: "b"}, nil, types.FOK, activateAt.Add(2*time.Second))
+	cancelledID, _ := s.ScheduleSigned(&types.SignedOrder{TokenID: "c"}, types.GTC, activateAt)
+	if err := s.Cancel(cancelledID); err != nil {
+		t.Fatalf("Cancel: %v", err)
+	}
+
+	// Server time 1100.5: nothing is due, and the wait runs to the deferred
+	// order's signing window
+	wait := s.process()
+	if len(trader.posted) != 0 || trader.signed != 0 {
+		t.Fatalf("Expected nothing to be signed or posted yet")
+	}
+	if wait != 5500*time.Millisecond {
+		t.Errorf("Expected to wait 5.5s to sign, got %v", wait)
+	}
+
+	// Server time 1109.5: the signed order is within its lead, and the
+	// deferred one within its signing window
+	local.now = time.Unix(1009, 0)
+	s.process()
+	if len(trader.posted) != 1 || trader.posted[0].TokenID != "a" || trader.signed != 1 {
+		t.Fatalf("Expected the signed order posted and the deferred one signed, got %d posted, %d signed", len(trader.posted), trader.signed)
+	}
+	if len(submitted) != 1 || submitted[0].ID != signedID || submitted[0].State != StateSubmitted ||
+		!submitted[0].SubmittedAt.Equal(time.Unix(1109, 500000000)) {
+		t.Errorf("Unexpected submission %+v", submitted)
+	}
+
+	// Server time 1111.5: the deferred order is posted as signed earlier
+	local.now = time.Unix(1011, 0)
+	s.process()
+	if len(trader.posted) != 2 || trader.posted[1].TokenID != "b" || trader.signed != 1 {
+		t.Fatalf("Expected the deferred order posted without signing again")
+	}
+
+	states := make(map[string]State)
+	for _, job := range s.Jobs() {
+		states[job.ID] = job.State
+	}
+	if states[signedID] != StateSubmitted || states[deferredID] != StateSubmitted || states[cancelledID] != StateCancelled {
+		t.Errorf("Unexpected job states %v", states)
+	}
+	if err := s.Cancel(signedID); err == nil {
+		t.Error("Expected a submitted job not to be cancellable")
+	}
+}
@@ -3,12 +3,13 @@ package signer
 import (
 	"crypto/ecdsa"
 	"fmt"
+	"sync"
 	"time"
 
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/crypto"
-	"polymarket-clob-go/pkg/types"
-	"polymarket-clob-go/pkg/utils"
+	"github.com/MaDal776/polymarket-go-client/pkg/types"
+	"github.com/MaDal776/polymarket-go-client/pkg/utils"
 )
 
 // Signer handles cryptographic operations
@@ -17,6 +18,11 @@ type Signer struct {
 	address    common.Address
 	chainID    int64
 	metrics    []types.PerformanceMetrics
+	metricsMu  sync.Mutex
+	
+	// Metrics output, set by SetMetricsOutput and guarded by metricsMu
+	onMetric       func(metric types.PerformanceMetrics)
+	discardMetrics bool
 }
 
 // NewSigner creates a new signer instance
@@ -131,13 +137,29 @@ func (s *Signer) SignClobAuth(timestamp int64, nonce int64) (string, error) {
 	return signatureHex, nil
 }
 
+// SetMetricsOutput sets a callback for every recorded metric and whether
+// metrics are also kept for GetMetrics; disabling buffering drops those kept so far
+func (s *Signer) SetMetricsOutput(onMetric func(metric types.PerformanceMetrics), buffer bool) {
+	s.metricsMu.Lock()
+	defer s.metricsMu.Unlock()
+	s.onMetric = onMetric
+	s.discardMetrics = !buffer
+	if !buffer {
+		s.metrics = make([]types.PerformanceMetrics, 0)
+	}
+}
+
 // GetMetrics returns performance metrics
 func (s *Signer) GetMetrics() []types.PerformanceMetrics {
-	return s.metrics
+	s.metricsMu.Lock()
+	defer s.metricsMu.Unlock()
+	return append([]types.PerformanceMetrics(nil), s.metrics...)
 }
 
 // ClearMetrics clears performance metrics
 func (s *Signer) ClearMetrics() {
+	s.metricsMu.Lock()
+	defer s.metricsMu.Unlock()
 	s.metrics = make([]types.PerformanceMetrics, 0)
 }
 
@@ -150,5 +172,14 @@ func (s *Signer) recordMetric(operation string, startTime time.Time, success boo
 		Success:   success,
 		Error:     errorMsg,
 	}
-	s.metrics = append(s.metrics, metric)
+	s.metricsMu.Lock()
+	if !s.discardMetrics {
+		s.metrics = append(s.metrics, metric)
+	}
+	onMetric := s.onMetric
+	s.metricsMu.Unlock()
+	
+	if onMetric != nil {
+		onMetric(metric)
+	}
 }
\ No newline at end of file
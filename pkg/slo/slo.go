@@ -0,0 +1,247 @@
+package slo
+
+import (
+	"math"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/MaDal776/polymarket-go-client/pkg/types"
+)
+
+// OrderPosting is the metric operation recorded by ClobClient.PostOrder
+const OrderPosting = "order_posting"
+
+// BreachKind identifies which objective was violated
+type BreachKind string
+
+const (
+	BreachLatency   BreachKind = "P99_LATENCY" // p99 latency exceeded Threshold.P99
+	BreachErrorRate BreachKind = "ERROR_RATE"  // Error rate exceeded Threshold.ErrorRate
+)
+
+// Threshold configures the objectives for one operation
+type Threshold struct {
+	P99       time.Duration // Breach when p99 latency exceeds this; 0 disables
+	ErrorRate float64       // Breach when the failed fraction exceeds this (0-1); 0 disables
+}
+
+// Stats summarizes the samples of one operation currently in the window
+type Stats struct {
+	Operation string        `json:"operation"`
+	Count     int           `json:"count"`
+	Errors    int           `json:"errors"`
+	ErrorRate float64       `json:"error_rate"`
+	P50       time.Duration `json:"p50"`
+	P90       time.Duration `json:"p90"`
+	P99       time.Duration `json:"p99"`
+	Max       time.Duration `json:"max"`
+}
+
+// Breach describes an objective crossing its threshold
+type Breach struct {
+	Kind      BreachKind `json:"kind"`
+	Operation string     `json:"operation"`
+	Threshold Threshold  `json:"threshold"`
+	Stats     Stats      `json:"stats"`
+	Time      time.Time  `json:"time"`
+}
+
+// Config configures a Tracker
+type Config struct {
+	Window     time.Duration        // Samples older than this are dropped; default 5m
+	MaxSamples int                  // Samples kept per operation; default 1000
+	MinSamples int                  // Samples required before evaluating thresholds; default 20
+	Thresholds map[string]Threshold // Objectives keyed by operation, e.g. OrderPosting
+	OnBreach   func(breach Breach)  // Called when an objective starts being violated
+	OnRecover  func(breach Breach)  // Called when a violated objective is met again
+}
+
+// sample is a single observed operation
+type sample struct {
+	at       time.Time
+	duration time.Duration
+	failed   bool
+}
+
+// Tracker keeps rolling latency and error statistics per operation
+type Tracker struct {
+	config Config
+
+	mu       sync.Mutex
+	samples  map[string][]sample
+	breached map[string]map[BreachKind]bool
+}
+
+// NewTracker creates a tracker
+func NewTracker(config Config) *Tracker {
+	if config.Window <= 0 {
+		config.Window = 5 * time.Minute
+	}
+	if config.MaxSamples <= 0 {
+		config.MaxSamples = 1000
+	}
+	if config.MinSamples <= 0 {
+		config.MinSamples = 20
+	}
+
+	return &Tracker{
+		config:   config,
+		samples:  make(map[string][]sample),
+		breached: make(map[string]map[BreachKind]bool),
+	}
+}
+
+// Record adds a metric to its operation's window and checks the operation's thresholds
+func (t *Tracker) Record(metric types.PerformanceMetrics) {
+	at := metric.StartTime.Add(metric.Duration)
+	if metric.StartTime.IsZero() {
+		at = time.Now()
+	}
+
+	t.mu.Lock()
+	samples := append(t.samples[metric.Operation], sample{at: at, duration: metric.Duration, failed: !metric.Success})
+	samples = t.trim(samples, at)
+	t.samples[metric.Operation] = samples
+
+	threshold, watched := t.config.Thresholds[metric.Operation]
+	if !watched || len(samples) < t.config.MinSamples {
+		t.mu.Unlock()
+		return
+	}
+	stats := summarize(metric.Operation, samples)
+	started, recovered := t.transitions(metric.Operation, threshold, stats)
+	t.mu.Unlock()
+
+	for _, kind := range started {
+		if t.config.OnBreach != nil {
+			t.config.OnBreach(Breach{Kind: kind, Operation: metric.Operation, Threshold: threshold, Stats: stats, Time: at})
+		}
+	}
+	for _, kind := range recovered {
+		if t.config.OnRecover != nil {
+			t.config.OnRecover(Breach{Kind: kind, Operation: metric.Operation, Threshold: threshold, Stats: stats, Time: at})
+		}
+	}
+}
+
+// Stats returns the current statistics for an operation
+func (t *Tracker) Stats(operation string) (Stats, bool) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	samples := t.trim(t.samples[operation], time.Now())
+	t.samples[operation] = samples
+	if len(samples) == 0 {
+		return Stats{}, false
+	}
+	return summarize(operation, samples), true
+}
+
+// AllStats returns the statistics of every operation seen, sorted by operation
+func (t *Tracker) AllStats() []Stats {
+	t.mu.Lock()
+	operations := make([]string, 0, len(t.samples))
+	for operation := range t.samples {
+		operations = append(operations, operation)
+	}
+	t.mu.Unlock()
+	sort.Strings(operations)
+
+	all := make([]Stats, 0, len(operations))
+	for _, operation := range operations {
+		if stats, ok := t.Stats(operation); ok {
+			all = append(all, stats)
+		}
+	}
+	return all
+}
+
+// Breached reports whether an objective of the operation is currently violated
+func (t *Tracker) Breached(operation string) bool {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	for _, active := range t.breached[operation] {
+		if active {
+			return true
+		}
+	}
+	return false
+}
+
+// trim drops samples outside the window or beyond MaxSamples
+func (t *Tracker) trim(samples []sample, now time.Time) []sample {
+	cutoff := now.Add(-t.config.Window)
+	drop := 0
+	for drop < len(samples) && samples[drop].at.Before(cutoff) {
+		drop++
+	}
+	if excess := len(samples) - drop - t.config.MaxSamples; excess > 0 {
+		drop += excess
+	}
+	if drop == 0 {
+		return samples
+	}
+	return append(samples[:0], samples[drop:]...)
+}
+
+// transitions updates the breach state and returns the kinds that started and ended
+func (t *Tracker) transitions(operation string, threshold Threshold, stats Stats) (started, recovered []BreachKind) {
+	current := map[BreachKind]bool{
+		BreachLatency:   threshold.P99 > 0 && stats.P99 > threshold.P99,
+		BreachErrorRate: threshold.ErrorRate > 0 && stats.ErrorRate > threshold.ErrorRate,
+	}
+
+	previous := t.breached[operation]
+	if previous == nil {
+		previous = make(map[BreachKind]bool)
+		t.breached[operation] = previous
+	}
+	for _, kind := range []BreachKind{BreachLatency, BreachErrorRate} {
+		switch {
+		case current[kind] && !previous[kind]:
+			started = append(started, kind)
+		case !current[kind] && previous[kind]:
+			recovered = append(recovered, kind)
+		}
+		previous[kind] = current[kind]
+	}
+	return started, recovered
+}
+
+// summarize computes statistics over a non-empty sample set
+func summarize(operation string, samples []sample) Stats {
+	durations := make([]time.Duration, len(samples))
+	errors := 0
+	for i, s := range samples {
+		durations[i] = s.duration
+		if s.failed {
+			errors++
+		}
+	}
+	sort.Slice(durations, func(i, j int) bool { return durations[i] < durations[j] })
+
+	return Stats{
+		Operation: operation,
+		Count:     len(samples),
+		Errors:    errors,
+		ErrorRate: float64(errors) / float64(len(samples)),
+		P50:       percentile(durations, 0.50),
+		P90:       percentile(durations, 0.90),
+		P99:       percentile(durations, 0.99),
+		Max:       durations[len(durations)-1],
+	}
+}
+
+// percentile returns the nearest-rank percentile of sorted durations
+func percentile(sorted []time.Duration, p float64) time.Duration {
+	rank := int(math.Ceil(p*float64(len(sorted)))) - 1
+	if rank < 0 {
+		rank = 0
+	}
+	if rank >= len(sorted) {
+		rank = len(sorted) - 1
+	}
+	return sorted[rank]
+}
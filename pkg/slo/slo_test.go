@@ -0,0 +1,77 @@
+package slo
+
+import (
+	"testing"
+	"time"
+
+	"github.com/MaDal776/polymarket-go-client/pkg/types"
+)
+
+func metric(operation string, start time.Time, d time.Duration, success bool) types.PerformanceMetrics {
+	return types.PerformanceMetrics{Operation: operation, StartTime: start, Duration: d, Success: success}
+}
+
+func TestStatsPercentiles(t *testing.T) {
+	tracker := NewTracker(Config{})
+	now := time.Now()
+	for i := 1; i <= 100; i++ {
+		tracker.Record(metric("op", now, time.Duration(i)*time.Millisecond, i%10 != 0))
+	}
+
+	stats, ok := tracker.Stats("op")
+	if !ok {
+		t.Fatal("Expected stats for op")
+	}
+	if stats.Count != 100 || stats.Errors != 10 {
+		t.Errorf("Expected 100 samples with 10 errors, got %+v", stats)
+	}
+	if stats.P50 != 50*time.Millisecond || stats.P99 != 99*time.Millisecond || stats.Max != 100*time.Millisecond {
+		t.Errorf("Unexpected percentiles: %+v", stats)
+	}
+}
+
+func TestBreachAndRecover(t *testing.T) {
+	var breaches, recoveries []Breach
+	tracker := NewTracker(Config{
+		MaxSamples: 20,
+		MinSamples: 10,
+		Thresholds: map[string]Threshold{OrderPosting: {P99: 50 * time.Millisecond}},
+		OnBreach:   func(b Breach) { breaches = append(breaches, b) },
+		OnRecover:  func(b Breach) { recoveries = append(recoveries, b) },
+	})
+	now := time.Now()
+
+	for i := 0; i < 10; i++ {
+		tracker.Record(metric(OrderPosting, now, 10*time.Millisecond, true))
+	}
+	tracker.Record(metric(OrderPosting, now, 200*time.Millisecond, true))
+	if len(breaches) != 1 || breaches[0].Kind != BreachLatency {
+		t.Fatalf("Expected one latency breach, got %+v", breaches)
+	}
+	if !tracker.Breached(OrderPosting) {
+		t.Error("Expected operation to be breached")
+	}
+
+	// The slow sample ages out once MaxSamples fast samples follow it
+	for i := 0; i < 20; i++ {
+		tracker.Record(metric(OrderPosting, now, 10*time.Millisecond, true))
+	}
+	if len(recoveries) != 1 {
+		t.Fatalf("Expected one recovery, got %+v", recoveries)
+	}
+	if len(breaches) != 1 {
+		t.Errorf("Expected no further breaches, got %d", len(breaches))
+	}
+}
+
+func TestWindowDropsOldSamples(t *testing.T) {
+	tracker := NewTracker(Config{Window: time.Minute})
+	old := time.Now().Add(-2 * time.Minute)
+	tracker.Record(metric("op", old, time.Millisecond, true))
+	tracker.Record(metric("op", time.Now(), time.Millisecond, true))
+
+	stats, _ := tracker.Stats("op")
+	if stats.Count != 1 {
+		t.Errorf("Expected the old sample to be dropped, got %d samples", stats.Count)
+	}
+}
@@ -0,0 +1,269 @@
+package strategy
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/MaDal776/polymarket-go-client/pkg/client"
+	"github.com/MaDal776/polymarket-go-client/pkg/ordermanager"
+	"github.com/MaDal776/polymarket-go-client/pkg/portfolio"
+	"github.com/MaDal776/polymarket-go-client/pkg/risk"
+	"github.com/MaDal776/polymarket-go-client/pkg/types"
+)
+
+// Strategy is user trading logic driven by the Runner.
+// All callbacks are invoked from a single goroutine, so implementations need no locking.
+type Strategy interface {
+	OnBook(ctx *Context, book *types.OrderBookSummary) error
+	OnFill(ctx *Context, fill portfolio.Fill) error
+	OnTimer(ctx *Context, now time.Time) error
+}
+
+// Starter is implemented by strategies that need setup before the first event
+type Starter interface {
+	OnStart(ctx *Context) error
+}
+
+// Stopper is implemented by strategies that need cleanup when the runner exits
+type Stopper interface {
+	OnStop(ctx *Context) error
+}
+
+// Context gives strategy callbacks access to trading and account state
+type Context struct {
+	context.Context
+	Trader    client.Trader              // Live or paper client, risk-wrapped when RunnerConfig.Risk is set
+	Orders    *ordermanager.OrderManager // RunnerConfig.Orders, or nil
+	Portfolio *portfolio.Portfolio       // Positions built from the runner's fills
+	Logf      func(format string, args ...interface{})
+}
+
+// RunnerConfig configures a Runner
+type RunnerConfig struct {
+	Tokens        []string                       // Tokens whose books are delivered to OnBook
+	BookInterval  time.Duration                  // Book polling interval; default 2s, ignored when Books is set
+	FillInterval  time.Duration                  // Trade polling interval; default 5s, ignored when Fills is set
+	TimerInterval time.Duration                  // OnTimer interval; 0 disables
+	Books         <-chan *types.OrderBookSummary // Optional push feed replacing book polling
+	Fills         <-chan portfolio.Fill          // Optional push feed replacing trade polling
+	Portfolio     *portfolio.Portfolio           // Optional; a new portfolio is created when nil
+	Risk          *risk.RiskTrader               // Optional; replaces the trader given to the strategy so its orders are checked
+	Orders        *ordermanager.OrderManager     // Optional; fills are applied to it. Create it over Risk so its submissions are checked too
+	StopOnError   bool                           // Stop the runner when a callback returns an error
+	OnError       func(err error)                // Called for feed and callback errors
+	Logf          func(format string, args ...interface{})
+}
+
+// Runner wires a Strategy to market data, fills and a timer
+type Runner struct {
+	trader   client.Trader
+	strategy Strategy
+	config   RunnerConfig
+	seen     map[string]int64 // Fill key -> unix time of its trade
+	since    int64            // Trades before this time are not fetched again
+}
+
+// NewRunner creates a runner for the strategy trading through trader. With
+// both Risk and Orders configured, the risk checks count open orders from the
+// order manager instead of querying the exchange.
+func NewRunner(trader client.Trader, s Strategy, config RunnerConfig) (*Runner, error) {
+	if trader == nil {
+		return nil, fmt.Errorf("trader is required")
+	}
+	if s == nil {
+		return nil, fmt.Errorf("strategy is required")
+	}
+	if config.BookInterval <= 0 {
+		config.BookInterval = 2 * time.Second
+	}
+	if config.FillInterval <= 0 {
+		config.FillInterval = 5 * time.Second
+	}
+	if config.Portfolio == nil {
+		config.Portfolio = portfolio.NewPortfolio()
+	}
+	if config.Logf == nil {
+		config.Logf = func(string, ...interface{}) {}
+	}
+	if config.Risk != nil {
+		trader = config.Risk
+		if config.Orders != nil {
+			config.Risk.SetOpenOrderSource(config.Orders.TokenOpenOrderIDs)
+		}
+	}
+
+	return &Runner{
+		trader:   trader,
+		strategy: s,
+		config:   config,
+		seen:     make(map[string]int64),
+		since:    time.Now().Unix(),
+	}, nil
+}
+
+// Portfolio returns the portfolio maintained by the runner
+func (r *Runner) Portfolio() *portfolio.Portfolio {
+	return r.config.Portfolio
+}
+
+// Run delivers events to the strategy until ctx is cancelled or, with
+// StopOnError, a callback fails
+func (r *Runner) Run(ctx context.Context) error {
+	sctx := &Context{
+		Context:   ctx,
+		Trader:    r.trader,
+		Orders:    r.config.Orders,
+		Portfolio: r.config.Portfolio,
+		Logf:      r.config.Logf,
+	}
+
+	if starter, ok := r.strategy.(Starter); ok {
+		if err := starter.OnStart(sctx); err != nil {
+			return fmt.Errorf("strategy failed to start: %w", err)
+		}
+	}
+	if stopper, ok := r.strategy.(Stopper); ok {
+		defer func() {
+			if err := stopper.OnStop(sctx); err != nil {
+				r.reportError(fmt.Errorf("strategy failed to stop: %w", err))
+			}
+		}()
+	}
+
+	var bookTick, fillTick, timerTick <-chan time.Time
+	if r.config.Books == nil && len(r.config.Tokens) > 0 {
+		ticker := time.NewTicker(r.config.BookInterval)
+		defer ticker.Stop()
+		bookTick = ticker.C
+	}
+	if r.config.Fills == nil {
+		ticker := time.NewTicker(r.config.FillInterval)
+		defer ticker.Stop()
+		fillTick = ticker.C
+	}
+	if r.config.TimerInterval > 0 {
+		ticker := time.NewTicker(r.config.TimerInterval)
+		defer ticker.Stop()
+		timerTick = ticker.C
+	}
+
+	for {
+		var err error
+		select {
+		case <-ctx.Done():
+			return nil
+		case book, ok := <-r.config.Books:
+			if !ok {
+				return fmt.Errorf("book feed closed")
+			}
+			err = r.strategy.OnBook(sctx, book)
+		case fill, ok := <-r.config.Fills:
+			if !ok {
+				return fmt.Errorf("fill feed closed")
+			}
+			err = r.deliverFill(sctx, fill)
+		case <-bookTick:
+			err = r.pollBooks(sctx)
+		case <-fillTick:
+			err = r.pollFills(sctx)
+		case now := <-timerTick:
+			err = r.strategy.OnTimer(sctx, now)
+		}
+
+		if err != nil {
+			r.reportError(err)
+			if r.config.StopOnError {
+				return err
+			}
+		}
+	}
+}
+
+// pollBooks fetches and delivers the book of every configured token
+func (r *Runner) pollBooks(sctx *Context) error {
+	for _, tokenID := range r.config.Tokens {
+		book, err := r.trader.GetOrderBook(tokenID)
+		if err != nil {
+			r.reportError(fmt.Errorf("failed to get order book for %s: %w", tokenID, err))
+			continue
+		}
+		if err := r.strategy.OnBook(sctx, book); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// pollFills fetches trades since the latest one seen and delivers fills not
+// seen before
+func (r *Runner) pollFills(sctx *Context) error {
+	trades, err := r.trader.GetTrades(&types.TradeParams{After: r.since})
+	if err != nil {
+		r.reportError(fmt.Errorf("failed to get trades: %w", err))
+		return nil
+	}
+
+	for _, trade := range trades {
+		fills, err := portfolio.FillsFromTrade(trade, r.trader.GetAddress())
+		if err != nil {
+			r.reportError(err)
+			continue
+		}
+		for _, fill := range fills {
+			if err := r.deliverFill(sctx, fill); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// deliverFill applies a fill to the portfolio and order manager and passes it
+// to the strategy once
+func (r *Runner) deliverFill(sctx *Context, fill portfolio.Fill) error {
+	if fill.TradeID != "" {
+		key := fill.TradeID + "/" + fill.OrderID
+		if _, seen := r.seen[key]; seen {
+			return nil
+		}
+		r.seen[key] = r.advance(fill.Timestamp)
+	}
+
+	if err := r.config.Portfolio.ApplyFill(fill); err != nil {
+		return fmt.Errorf("failed to apply fill: %w", err)
+	}
+	if r.config.Orders != nil {
+		r.config.Orders.ApplyFill(fill)
+	}
+	return r.strategy.OnFill(sctx, fill)
+}
+
+// advance moves the trade polling window up to a fill's trade time and forgets
+// fills from before it, which later polls no longer return. Trades at exactly
+// since may be returned again, so their fills are kept. It returns the time
+// the fill is remembered under; fills without a time use the current window.
+func (r *Runner) advance(timestamp time.Time) int64 {
+	if timestamp.IsZero() {
+		return r.since
+	}
+	at := timestamp.Unix()
+	if at <= r.since {
+		return at
+	}
+
+	r.since = at
+	for key, seenAt := range r.seen {
+		if seenAt < at {
+			delete(r.seen, key)
+		}
+	}
+	return at
+}
+
+// reportError passes an error to the configured handler
+func (r *Runner) reportError(err error) {
+	if r.config.OnError != nil {
+		r.config.OnError(err)
+	}
+}
@@ -0,0 +1,161 @@
+package strategy
+
+import (
+	"context"
+	"errors"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/MaDal776/polymarket-go-client/pkg/client"
+	"github.com/MaDal776/polymarket-go-client/pkg/ordermanager"
+	"github.com/MaDal776/polymarket-go-client/pkg/portfolio"
+	"github.com/MaDal776/polymarket-go-client/pkg/risk"
+	"github.com/MaDal776/polymarket-go-client/pkg/types"
+)
+
+const testToken = "123"
+
+// fakeTrader returns its trades at or after the requested time, serves empty
+// books and acknowledges every order; other Trader methods are not used
+type fakeTrader struct {
+	client.Trader
+	trades []types.Trade
+	after  []int64 // After of each GetTrades call
+}
+
+func (f *fakeTrader) GetTrades(params *types.TradeParams) ([]types.Trade, error) {
+	f.after = append(f.after, params.After)
+	trades := make([]types.Trade, 0)
+	for _, trade := range f.trades {
+		at, _ := strconv.ParseInt(trade.MatchTime, 10, 64)
+		if at >= params.After {
+			trades = append(trades, trade)
+		}
+	}
+	return trades, nil
+}
+
+func (f *fakeTrader) GetOrderBook(tokenID string) (*types.OrderBookSummary, error) {
+	return &types.OrderBookSummary{Market: "0xmarket", AssetID: tokenID}, nil
+}
+
+func (f *fakeTrader) GetAddress() string {
+	return "0xme"
+}
+
+func (f *fakeTrader) PostOrder(signedOrder *types.SignedOrder, orderType types.OrderType) (map[string]interface{}, error) {
+	return map[string]interface{}{"success": true, "orderID": "0x1", "status": "live"}, nil
+}
+
+// recorder is a strategy that records what it is given
+type recorder struct {
+	fills  []portfolio.Fill
+	start  *Context
+	starts int
+}
+
+func (s *recorder) OnBook(ctx *Context, book *types.OrderBookSummary) error { return nil }
+func (s *recorder) OnTimer(ctx *Context, now time.Time) error               { return nil }
+
+func (s *recorder) OnFill(ctx *Context, fill portfolio.Fill) error {
+	s.fills = append(s.fills, fill)
+	return nil
+}
+
+func (s *recorder) OnStart(ctx *Context) error {
+	s.start = ctx
+	s.starts++
+	return nil
+}
+
+func takerTrade(id, orderID string, at int64, size string) types.Trade {
+	return types.Trade{
+		ID:           id,
+		TakerOrderID: orderID,
+		AssetID:      testToken,
+		Side:         types.BUY,
+		Price:        "0.5",
+		Size:         size,
+		MatchTime:    strconv.FormatInt(at, 10),
+		TraderSide:   "TAKER",
+	}
+}
+
+func TestPollFillsAdvancesSinceAndForgetsOldFills(t *testing.T) {
+	trader := &fakeTrader{}
+	strategy := &recorder{}
+	runner, err := NewRunner(trader, strategy, RunnerConfig{})
+	if err != nil {
+		t.Fatalf("NewRunner: %v", err)
+	}
+	start := runner.since
+	trader.trades = []types.Trade{
+		takerTrade("t1", "0x1", start+10, "1"),
+		takerTrade("t2", "0x2", start+20, "1"),
+	}
+
+	if err := runner.pollFills(&Context{Context: context.Background()}); err != nil {
+		t.Fatalf("pollFills: %v", err)
+	}
+	if len(strategy.fills) != 2 {
+		t.Fatalf("expected 2 fills, got %d", len(strategy.fills))
+	}
+	if runner.since != start+20 {
+		t.Fatalf("expected since to advance to %d, got %d", start+20, runner.since)
+	}
+	if _, kept := runner.seen["t1/0x1"]; kept || len(runner.seen) != 1 {
+		t.Fatalf("expected only the fill at since to be remembered, got %v", runner.seen)
+	}
+
+	// The trade at since comes back and must not be delivered again
+	trader.trades = append(trader.trades, takerTrade("t3", "0x3", start+20, "1"))
+	if err := runner.pollFills(&Context{Context: context.Background()}); err != nil {
+		t.Fatalf("pollFills: %v", err)
+	}
+	if trader.after[1] != start+20 {
+		t.Fatalf("expected second poll after %d, got %d", start+20, trader.after[1])
+	}
+	if len(strategy.fills) != 3 || strategy.fills[2].TradeID != "t3" {
+		t.Fatalf("expected only t3 to be delivered, got %+v", strategy.fills)
+	}
+}
+
+func TestRunnerWiresOrderManagerAndRisk(t *testing.T) {
+	trader := &fakeTrader{}
+	checked := risk.NewRiskTrader(trader, risk.Limits{MaxOpenOrdersPerMarket: 1}, nil)
+	orders := ordermanager.NewOrderManager(checked)
+	strategy := &recorder{}
+	runner, err := NewRunner(trader, strategy, RunnerConfig{Risk: checked, Orders: orders})
+	if err != nil {
+		t.Fatalf("NewRunner: %v", err)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	if err := runner.Run(ctx); err != nil {
+		t.Fatalf("Run: %v", err)
+	}
+	if strategy.starts != 1 || strategy.start.Trader != checked || strategy.start.Orders != orders {
+		t.Fatalf("expected the strategy to trade through the risk trader and order manager")
+	}
+
+	// BUY 10 at 0.5. The fake trader has no open orders endpoint, so the
+	// second order is only refused if the count comes from the order manager.
+	order := &types.SignedOrder{TokenID: testToken, Side: types.BUY, MakerAmount: "5000000", TakerAmount: "10000000"}
+	if _, err := orders.SubmitSigned(order, types.GTC, ""); err != nil {
+		t.Fatalf("SubmitSigned: %v", err)
+	}
+	if _, err := orders.SubmitSigned(order, types.GTC, ""); !errors.Is(err, risk.ErrLimitExceeded) {
+		t.Fatalf("expected the open order limit, got %v", err)
+	}
+
+	trader.trades = []types.Trade{takerTrade("t1", "0x1", runner.since+1, "4")}
+	if err := runner.pollFills(strategy.start); err != nil {
+		t.Fatalf("pollFills: %v", err)
+	}
+	managed, ok := orders.Order("0x1")
+	if !ok || managed.SizeMatched != 4 {
+		t.Fatalf("expected the fill to reach the order manager, got %+v", managed)
+	}
+}
@@ -0,0 +1,154 @@
+package testvectors
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/common/math"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/signer/core/apitypes"
+)
+
+// The vectors' expected outputs are recomputed here with go-ethereum's generic
+// EIP-712 encoder and ECDSA, and with crypto/hmac, which share no code with the
+// SDK's signing path
+
+var eip712Domain = []apitypes.Type{
+	{Name: "name", Type: "string"},
+	{Name: "version", Type: "string"},
+	{Name: "chainId", Type: "uint256"},
+	{Name: "verifyingContract", Type: "address"},
+}
+
+var orderType = []apitypes.Type{
+	{Name: "salt", Type: "uint256"},
+	{Name: "maker", Type: "address"},
+	{Name: "signer", Type: "address"},
+	{Name: "taker", Type: "address"},
+	{Name: "tokenId", Type: "uint256"},
+	{Name: "makerAmount", Type: "uint256"},
+	{Name: "takerAmount", Type: "uint256"},
+	{Name: "expiration", Type: "uint256"},
+	{Name: "nonce", Type: "uint256"},
+	{Name: "feeRateBps", Type: "uint256"},
+	{Name: "side", Type: "uint8"},
+	{Name: "signatureType", Type: "uint8"},
+}
+
+var clobAuthType = []apitypes.Type{
+	{Name: "address", Type: "address"},
+	{Name: "timestamp", Type: "string"},
+	{Name: "nonce", Type: "uint256"},
+	{Name: "message", Type: "string"},
+}
+
+// referenceSign signs digest with PrivateKey, with v as 27/28
+func referenceSign(t *testing.T, digest []byte) string {
+	key, err := crypto.HexToECDSA(strings.TrimPrefix(PrivateKey, "0x"))
+	if err != nil {
+		t.Fatalf("Invalid private key: %v", err)
+	}
+	signature, err := crypto.Sign(digest, key)
+	if err != nil {
+		t.Fatalf("Failed to sign: %v", err)
+	}
+	signature[64] += 27
+	return hexutil.Encode(signature)
+}
+
+func TestOrdersMatchReference(t *testing.T) {
+	for _, v := range Orders {
+		typed := apitypes.TypedData{
+			Types:       apitypes.Types{"EIP712Domain": eip712Domain, "Order": orderType},
+			PrimaryType: "Order",
+			Domain: apitypes.TypedDataDomain{
+				Name:              "Polymarket CTF Exchange",
+				Version:           "1",
+				ChainId:           math.NewHexOrDecimal256(v.ChainID),
+				VerifyingContract: v.Exchange,
+			},
+			Message: apitypes.TypedDataMessage{
+				"salt":          fmt.Sprint(v.Salt),
+				"maker":         v.Maker,
+				"signer":        v.Signer,
+				"taker":         v.Taker,
+				"tokenId":       v.TokenID,
+				"makerAmount":   v.MakerAmount,
+				"takerAmount":   v.TakerAmount,
+				"expiration":    v.Expiration,
+				"nonce":         v.Nonce,
+				"feeRateBps":    v.FeeRateBps,
+				"side":          fmt.Sprint(v.Side),
+				"signatureType": fmt.Sprint(v.SignatureType),
+			},
+		}
+		structHash, err := typed.HashStruct("Order", typed.Message)
+		if err != nil {
+			t.Fatalf("%s: %v", v.Name, err)
+		}
+		digest, _, err := apitypes.TypedDataAndHash(typed)
+		if err != nil {
+			t.Fatalf("%s: %v", v.Name, err)
+		}
+		for _, err := range []error{
+			compare(v.Name, "reference struct hash", hexutil.Encode(structHash), v.StructHash),
+			compare(v.Name, "reference digest", hexutil.Encode(digest), v.Digest),
+			compare(v.Name, "reference signature", referenceSign(t, digest), v.Signature),
+		} {
+			if err != nil {
+				t.Error(err)
+			}
+		}
+	}
+}
+
+func TestClobAuthsMatchReference(t *testing.T) {
+	for _, v := range ClobAuths {
+		typed := apitypes.TypedData{
+			Types:       apitypes.Types{"EIP712Domain": eip712Domain[:3], "ClobAuth": clobAuthType},
+			PrimaryType: "ClobAuth",
+			Domain: apitypes.TypedDataDomain{
+				Name:    "ClobAuthDomain",
+				Version: "1",
+				ChainId: math.NewHexOrDecimal256(v.ChainID),
+			},
+			Message: apitypes.TypedDataMessage{
+				"address":   Address,
+				"timestamp": fmt.Sprint(v.Timestamp),
+				"nonce":     fmt.Sprint(v.Nonce),
+				"message":   "This message attests that I control the given wallet",
+			},
+		}
+		digest, _, err := apitypes.TypedDataAndHash(typed)
+		if err != nil {
+			t.Fatalf("%s: %v", v.Name, err)
+		}
+		if err := compare(v.Name, "reference digest", hexutil.Encode(digest), v.Digest); err != nil {
+			t.Error(err)
+		}
+		if err := compare(v.Name, "reference signature", referenceSign(t, digest), v.Signature); err != nil {
+			t.Error(err)
+		}
+	}
+}
+
+func TestHMACsMatchReference(t *testing.T) {
+	for _, v := range HMACs {
+		secret, err := base64.URLEncoding.DecodeString(v.Secret)
+		if err != nil {
+			t.Fatalf("%s: %v", v.Name, err)
+		}
+		message := fmt.Sprintf("%d%s%s%s", v.Timestamp, v.Method, v.Path, strings.ReplaceAll(v.Body, "'", `"`))
+		mac := hmac.New(sha256.New, secret)
+		mac.Write([]byte(message))
+		signature := base64.URLEncoding.EncodeToString(mac.Sum(nil))
+		if err := compare(v.Name, "reference signature", signature, v.Signature); err != nil {
+			t.Error(err)
+		}
+	}
+}
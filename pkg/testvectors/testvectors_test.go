@@ -0,0 +1,41 @@
+package testvectors
+
+import "testing"
+
+func TestOrders(t *testing.T) {
+	for _, v := range Orders {
+		if err := CheckOrder(v); err != nil {
+			t.Error(err)
+		}
+	}
+}
+
+func TestClobAuths(t *testing.T) {
+	for _, v := range ClobAuths {
+		if err := CheckClobAuth(v); err != nil {
+			t.Error(err)
+		}
+	}
+}
+
+func TestHMACs(t *testing.T) {
+	for _, v := range HMACs {
+		if err := CheckHMAC(v); err != nil {
+			t.Error(err)
+		}
+	}
+}
+
+func TestCheckReportsMismatch(t *testing.T) {
+	v := Orders[0]
+	v.MakerAmount = "5500001"
+	if err := CheckOrder(v); err == nil {
+		t.Error("Expected a tampered order to fail")
+	}
+
+	h := HMACs[0]
+	h.Path = "/data/trades"
+	if err := CheckHMAC(h); err == nil {
+		t.Error("Expected a tampered request to fail")
+	}
+}
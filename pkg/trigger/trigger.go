@@ -0,0 +1,484 @@
+package trigger
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/MaDal776/polymarket-go-client/pkg/client"
+	"github.com/MaDal776/polymarket-go-client/pkg/types"
+)
+
+// Kind labels the purpose of a trigger
+type Kind string
+
+const (
+	KindStopLoss   Kind = "STOP_LOSS"
+	KindTakeProfit Kind = "TAKE_PROFIT"
+	KindOnPrint    Kind = "ON_PRINT"
+)
+
+// Condition is the comparison applied to the reference price
+type Condition string
+
+const (
+	Below Condition = "BELOW" // Fire when the reference price is at or below TriggerPrice
+	Above Condition = "ABOVE" // Fire when the reference price is at or above TriggerPrice
+)
+
+// Reference is the price a trigger is evaluated against
+type Reference string
+
+const (
+	RefMidpoint  Reference = "MIDPOINT"
+	RefBestBid   Reference = "BEST_BID"
+	RefBestAsk   Reference = "BEST_ASK"
+	RefLastPrint Reference = "LAST_PRINT"
+)
+
+// State is the lifecycle state of a trigger
+type State string
+
+const (
+	StateArmed     State = "ARMED"
+	StateFired     State = "FIRED"
+	StateFailed    State = "FAILED"
+	StateCancelled State = "CANCELLED"
+)
+
+// Trigger is a conditional order held client-side until its condition is met
+type Trigger struct {
+	ID           string                    `json:"id"`
+	Kind         Kind                      `json:"kind"`
+	TokenID      string                    `json:"token_id"`
+	Condition    Condition                 `json:"condition"`
+	Reference    Reference                 `json:"reference"`
+	TriggerPrice float64                   `json:"trigger_price"`
+	Order        types.OrderArgs           `json:"order"`
+	OrderType    types.OrderType           `json:"order_type"`
+	Options      *types.CreateOrderOptions `json:"options,omitempty"`
+	State        State                     `json:"state"`
+	CreatedAt    time.Time                 `json:"created_at"`
+	FiredAt      time.Time                 `json:"fired_at,omitempty"`
+	FiredPrice   float64                   `json:"fired_price,omitempty"`
+	Result       map[string]interface{}    `json:"result,omitempty"`
+	Error        string                    `json:"error,omitempty"`
+}
+
+// NewStopLoss creates a trigger that sells size shares with a FOK order at
+// limitPrice once the best bid falls to stopPrice
+func NewStopLoss(tokenID string, stopPrice, limitPrice, size float64) Trigger {
+	return Trigger{
+		Kind:         KindStopLoss,
+		TokenID:      tokenID,
+		Condition:    Below,
+		Reference:    RefBestBid,
+		TriggerPrice: stopPrice,
+		Order:        types.OrderArgs{TokenID: tokenID, Price: limitPrice, Size: size, Side: types.SELL},
+		OrderType:    types.FOK,
+	}
+}
+
+// NewTakeProfit creates a trigger that sells size shares with a FOK order at
+// limitPrice once the best bid rises to targetPrice
+func NewTakeProfit(tokenID string, targetPrice, limitPrice, size float64) Trigger {
+	return Trigger{
+		Kind:         KindTakeProfit,
+		TokenID:      tokenID,
+		Condition:    Above,
+		Reference:    RefBestBid,
+		TriggerPrice: targetPrice,
+		Order:        types.OrderArgs{TokenID: tokenID, Price: limitPrice, Size: size, Side: types.SELL},
+		OrderType:    types.FOK,
+	}
+}
+
+// Store persists triggers so they survive restarts
+type Store interface {
+	Load() ([]Trigger, error)
+	Save(triggers []Trigger) error
+}
+
+// FileStore persists triggers as a JSON file
+type FileStore struct {
+	path string
+}
+
+// NewFileStore creates a store backed by the JSON file at path
+func NewFileStore(path string) *FileStore {
+	return &FileStore{path: path}
+}
+
+// Load reads the triggers from disk; a missing file yields no triggers
+func (f *FileStore) Load() ([]Trigger, error) {
+	data, err := os.ReadFile(f.path)
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read trigger store: %w", err)
+	}
+
+	var triggers []Trigger
+	if err := json.Unmarshal(data, &triggers); err != nil {
+		return nil, fmt.Errorf("failed to parse trigger store: %w", err)
+	}
+	return triggers, nil
+}
+
+// Save atomically replaces the triggers on disk
+func (f *FileStore) Save(triggers []Trigger) error {
+	data, err := json.MarshalIndent(triggers, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to marshal triggers: %w", err)
+	}
+
+	tmp, err := os.CreateTemp(filepath.Dir(f.path), ".triggers-*")
+	if err != nil {
+		return fmt.Errorf("failed to create temp file: %w", err)
+	}
+	defer os.Remove(tmp.Name())
+
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		return fmt.Errorf("failed to write triggers: %w", err)
+	}
+	if err := tmp.Sync(); err != nil {
+		tmp.Close()
+		return fmt.Errorf("failed to sync triggers: %w", err)
+	}
+	if err := tmp.Close(); err != nil {
+		return fmt.Errorf("failed to write triggers: %w", err)
+	}
+
+	// The temp file's directory entry must be durable before it replaces the
+	// store, and the rename itself once it has
+	if err := syncDir(filepath.Dir(f.path)); err != nil {
+		return err
+	}
+	if err := os.Rename(tmp.Name(), f.path); err != nil {
+		return fmt.Errorf("failed to replace trigger store: %w", err)
+	}
+	return syncDir(filepath.Dir(f.path))
+}
+
+// syncDir flushes a directory's entries to disk
+func syncDir(path string) error {
+	dir, err := os.Open(path)
+	if err != nil {
+		return fmt.Errorf("failed to open trigger store directory: %w", err)
+	}
+	defer dir.Close()
+	if err := dir.Sync(); err != nil {
+		return fmt.Errorf("failed to sync trigger store directory: %w", err)
+	}
+	return nil
+}
+
+// Engine evaluates triggers against market data and submits their orders when they fire
+type Engine struct {
+	trader client.Trader
+	store  Store
+
+	mu       sync.Mutex
+	triggers map[string]*Trigger
+	onFire   func(trigger Trigger)
+	onError  func(err error)
+}
+
+// NewEngine creates an engine, restoring triggers from store (which may be nil)
+func NewEngine(trader client.Trader, store Store) (*Engine, error) {
+	e := &Engine{
+		trader:   trader,
+		store:    store,
+		triggers: make(map[string]*Trigger),
+	}
+
+	if store != nil {
+		triggers, err := store.Load()
+		if err != nil {
+			return nil, err
+		}
+		for i := range triggers {
+			t := triggers[i]
+			e.triggers[t.ID] = &t
+		}
+	}
+	return e, nil
+}
+
+// OnFire registers a callback invoked after a trigger fires, successfully or not
+func (e *Engine) OnFire(callback func(trigger Trigger)) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	e.onFire = callback
+}
+
+// OnError registers a callback invoked when a book cannot be fetched by Run or
+// a trigger's state cannot be persisted
+func (e *Engine) OnError(callback func(err error)) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	e.onError = callback
+}
+
+// Add arms a trigger and returns its ID. A trigger that cannot be persisted
+// is not added.
+func (e *Engine) Add(t Trigger) (string, error) {
+	if t.TokenID == "" {
+		return "", fmt.Errorf("trigger is missing token ID")
+	}
+	if t.Condition != Below && t.Condition != Above {
+		return "", fmt.Errorf("invalid trigger condition: %s", t.Condition)
+	}
+	if t.TriggerPrice <= 0 || t.TriggerPrice >= 1 {
+		return "", fmt.Errorf("invalid trigger price: %f", t.TriggerPrice)
+	}
+	if t.Reference == "" {
+		t.Reference = RefMidpoint
+	}
+	if t.OrderType == "" {
+		t.OrderType = types.FOK
+	}
+	if t.Order.TokenID == "" {
+		t.Order.TokenID = t.TokenID
+	}
+	if t.ID == "" {
+		t.ID = newID()
+	}
+	t.State = StateArmed
+	t.CreatedAt = time.Now()
+
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	previous, replaced := e.triggers[t.ID]
+	e.triggers[t.ID] = &t
+	if err := e.persist(); err != nil {
+		// Not persisted, so not armed
+		if replaced {
+			e.triggers[t.ID] = previous
+		} else {
+			delete(e.triggers, t.ID)
+		}
+		return "", fmt.Errorf("failed to persist trigger %s: %w", t.ID, err)
+	}
+	return t.ID, nil
+}
+
+// Cancel disarms a trigger. It stays armed when that cannot be persisted.
+func (e *Engine) Cancel(id string) error {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	t, exists := e.triggers[id]
+	if !exists {
+		return fmt.Errorf("trigger %s not found", id)
+	}
+	if t.State != StateArmed {
+		return fmt.Errorf("trigger %s is %s", id, t.State)
+	}
+	t.State = StateCancelled
+	if err := e.persist(); err != nil {
+		t.State = StateArmed
+		return fmt.Errorf("failed to persist cancelling trigger %s: %w", id, err)
+	}
+	return nil
+}
+
+// Triggers returns copies of all triggers, oldest first
+func (e *Engine) Triggers() []Trigger {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	triggers := make([]Trigger, 0, len(e.triggers))
+	for _, t := range e.triggers {
+		triggers = append(triggers, *t)
+	}
+	sort.Slice(triggers, func(i, j int) bool {
+		return triggers[i].CreatedAt.Before(triggers[j].CreatedAt)
+	})
+	return triggers
+}
+
+// OnBook evaluates armed triggers for the book's token. A one-sided book still
+// provides its best bid or ask; the midpoint needs both sides.
+func (e *Engine) OnBook(book *types.OrderBookSummary) {
+	prices := bookPrices(book)
+	if len(prices) == 0 {
+		return
+	}
+	e.evaluate(book.AssetID, prices)
+}
+
+// OnPrint evaluates armed LAST_PRINT triggers against a trade print
+func (e *Engine) OnPrint(tokenID string, price float64) {
+	e.evaluate(tokenID, map[Reference]float64{RefLastPrint: price})
+}
+
+// Run polls the book of every token with an armed trigger each interval until ctx is cancelled
+func (e *Engine) Run(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		for _, tokenID := range e.armedTokens() {
+			book, err := e.trader.GetOrderBook(tokenID)
+			if err != nil {
+				e.reportError(fmt.Errorf("failed to get order book of %s: %w", tokenID, err))
+				continue
+			}
+			if book.AssetID == "" {
+				book.AssetID = tokenID
+			}
+			e.OnBook(book)
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// evaluate fires the armed triggers for tokenID whose condition holds. They
+// are marked fired and persisted before their orders are submitted, so a crash
+// cannot fire them twice; when that cannot be persisted they stay armed and
+// nothing is submitted.
+func (e *Engine) evaluate(tokenID string, prices map[Reference]float64) {
+	e.mu.Lock()
+	firing := make([]*Trigger, 0)
+	for _, t := range e.triggers {
+		if t.State != StateArmed || t.TokenID != tokenID {
+			continue
+		}
+		price, ok := prices[t.Reference]
+		if !ok {
+			continue
+		}
+		if (t.Condition == Below && price <= t.TriggerPrice) || (t.Condition == Above && price >= t.TriggerPrice) {
+			t.State = StateFired
+			t.FiredAt = time.Now()
+			t.FiredPrice = price
+			firing = append(firing, t)
+		}
+	}
+	if len(firing) > 0 {
+		if err := e.persist(); err != nil {
+			for _, t := range firing {
+				t.State = StateArmed
+				t.FiredAt = time.Time{}
+				t.FiredPrice = 0
+			}
+			callback := e.onError
+			e.mu.Unlock()
+			if callback != nil {
+				callback(fmt.Errorf("not firing %d triggers for %s: %w", len(firing), tokenID, err))
+			}
+			return
+		}
+	}
+	e.mu.Unlock()
+
+	for _, t := range firing {
+		e.submit(t)
+	}
+}
+
+// submit creates and posts the trigger's order
+func (e *Engine) submit(t *Trigger) {
+	var result map[string]interface{}
+	signedOrder, err := e.trader.CreateOrder(t.Order, t.Options)
+	if err == nil {
+		result, err = e.trader.PostOrder(signedOrder, t.OrderType)
+	}
+
+	e.mu.Lock()
+	t.Result = result
+	if err != nil {
+		t.State = StateFailed
+		t.Error = err.Error()
+	}
+	persistErr := e.persist()
+	callback := e.onFire
+	snapshot := *t
+	e.mu.Unlock()
+
+	if persistErr != nil {
+		e.reportError(fmt.Errorf("failed to persist result of trigger %s: %w", t.ID, persistErr))
+	}
+	if callback != nil {
+		callback(snapshot)
+	}
+}
+
+// reportError passes err to the OnError callback, if one is registered
+func (e *Engine) reportError(err error) {
+	e.mu.Lock()
+	callback := e.onError
+	e.mu.Unlock()
+	if callback != nil {
+		callback(err)
+	}
+}
+
+// armedTokens returns the tokens that have at least one armed trigger
+func (e *Engine) armedTokens() []string {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	seen := make(map[string]bool)
+	tokens := make([]string, 0)
+	for _, t := range e.triggers {
+		if t.State == StateArmed && !seen[t.TokenID] {
+			seen[t.TokenID] = true
+			tokens = append(tokens, t.TokenID)
+		}
+	}
+	return tokens
+}
+
+// persist saves every trigger to the store. Callers hold e.mu.
+func (e *Engine) persist() error {
+	if e.store == nil {
+		return nil
+	}
+	triggers := make([]Trigger, 0, len(e.triggers))
+	for _, t := range e.triggers {
+		triggers = append(triggers, *t)
+	}
+	return e.store.Save(triggers)
+}
+
+// bookPrices returns the reference prices a book provides: the best bid and
+// ask of the sides that have orders, and the midpoint when both do
+func bookPrices(book *types.OrderBookSummary) map[Reference]float64 {
+	prices := make(map[Reference]float64, 3)
+	bid, okBid := book.BestBid()
+	if okBid {
+		prices[RefBestBid] = bid.Price
+	}
+	ask, okAsk := book.BestAsk()
+	if okAsk {
+		prices[RefBestAsk] = ask.Price
+	}
+	if okBid && okAsk {
+		prices[RefMidpoint] = (bid.Price + ask.Price) / 2
+	}
+	return prices
+}
+
+// newID returns a random trigger ID
+func newID() string {
+	b := make([]byte, 8)
+	rand.Read(b)
+	return hex.EncodeToString(b)
+}
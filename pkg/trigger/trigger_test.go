@@ -0,0 +1,214 @@
+package trigger
+
+import (
+	"context"
+	"errors"
+	"path/filepath"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/MaDal776/polymarket-go-client/pkg/client"
+	"github.com/MaDal776/polymarket-go-client/pkg/types"
+)
+
+// fakeTrader records the orders posted; other Trader methods are not used
+type fakeTrader struct {
+	client.Trader
+	mu      sync.Mutex
+	posted  []types.OrderArgs
+	bookErr error
+}
+
+func (f *fakeTrader) GetOrderBook(tokenID string) (*types.OrderBookSummary, error) {
+	return nil, f.bookErr
+}
+
+func (f *fakeTrader) CreateOrder(orderArgs types.OrderArgs, options *types.CreateOrderOptions) (*types.SignedOrder, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.posted = append(f.posted, orderArgs)
+	return &types.SignedOrder{}, nil
+}
+
+func (f *fakeTrader) PostOrder(signedOrder *types.SignedOrder, orderType types.OrderType) (map[string]interface{}, error) {
+	return map[string]interface{}{"success": true, "orderID": "0xabc"}, nil
+}
+
+// failingStore fails every save while fail is set
+type failingStore struct {
+	fail bool
+}
+
+func (f *failingStore) Load() ([]Trigger, error) { return nil, nil }
+
+func (f *failingStore) Save(triggers []Trigger) error {
+	if f.fail {
+		return errors.New("disk full")
+	}
+	return nil
+}
+
+func book(tokenID, bid, ask string) *types.OrderBookSummary {
+	book := &types.OrderBookSummary{AssetID: tokenID}
+	if bid != "" {
+		book.Bids = []types.OrderSummary{{Price: bid, Size: "100"}}
+	}
+	if ask != "" {
+		book.Asks = []types.OrderSummary{{Price: ask, Size: "100"}}
+	}
+	return book
+}
+
+func TestStopLossFires(t *testing.T) {
+	trader := &fakeTrader{}
+	engine, err := NewEngine(trader, nil)
+	if err != nil {
+		t.Fatalf("Failed to create engine: %v", err)
+	}
+	var fired []Trigger
+	engine.OnFire(func(trigger Trigger) { fired = append(fired, trigger) })
+
+	id, err := engine.Add(NewStopLoss("a", 0.4, 0.35, 10))
+	if err != nil {
+		t.Fatalf("Add failed: %v", err)
+	}
+	engine.OnBook(book("a", "0.45", "0.47"))
+	if len(fired) != 0 {
+		t.Fatalf("Expected no fire above the stop, got %+v", fired)
+	}
+
+	// The asks are gone, but the bid alone is enough
+	engine.OnBook(book("a", "0.39", ""))
+	if len(fired) != 1 || fired[0].ID != id || fired[0].State != StateFired || fired[0].FiredPrice != 0.39 {
+		t.Fatalf("Expected the stop loss to fire at 0.39, got %+v", fired)
+	}
+	if len(trader.posted) != 1 || trader.posted[0].Side != types.SELL || trader.posted[0].Price != 0.35 {
+		t.Errorf("Unexpected orders %+v", trader.posted)
+	}
+
+	// A fired trigger does not fire again
+	engine.OnBook(book("a", "0.30", ""))
+	if len(fired) != 1 {
+		t.Errorf("Expected a single fire, got %d", len(fired))
+	}
+}
+
+func TestCancelledTriggerDoesNotFire(t *testing.T) {
+	trader := &fakeTrader{}
+	engine, _ := NewEngine(trader, nil)
+	id, _ := engine.Add(NewTakeProfit("a", 0.6, 0.58, 10))
+
+	if err := engine.Cancel(id); err != nil {
+		t.Fatalf("Cancel failed: %v", err)
+	}
+	if err := engine.Cancel(id); err == nil {
+		t.Error("Expected cancelling twice to fail")
+	}
+	engine.OnBook(book("a", "0.65", "0.66"))
+	if len(trader.posted) != 0 || engine.Triggers()[0].State != StateCancelled {
+		t.Errorf("Expected the cancelled trigger to stay put: %+v", engine.Triggers())
+	}
+}
+
+func TestTriggersPersistAndRecover(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "triggers.json")
+	trader := &fakeTrader{}
+	engine, _ := NewEngine(trader, NewFileStore(path))
+	armed, _ := engine.Add(NewStopLoss("a", 0.4, 0.35, 10))
+	fired, _ := engine.Add(NewStopLoss("b", 0.4, 0.35, 10))
+	engine.OnBook(book("b", "0.3", "0.32"))
+
+	restored, err := NewEngine(trader, NewFileStore(path))
+	if err != nil {
+		t.Fatalf("Failed to restore engine: %v", err)
+	}
+	states := make(map[string]State)
+	for _, trigger := range restored.Triggers() {
+		states[trigger.ID] = trigger.State
+	}
+	if len(states) != 2 || states[armed] != StateArmed || states[fired] != StateFired {
+		t.Fatalf("Unexpected restored states %v", states)
+	}
+
+	// The restored engine keeps evaluating the armed trigger, not the fired one
+	restored.OnBook(book("a", "0.39", ""))
+	restored.OnBook(book("b", "0.2", ""))
+	if len(trader.posted) != 2 || trader.posted[1].TokenID != "a" {
+		t.Errorf("Unexpected orders %+v", trader.posted)
+	}
+}
+
+func TestPersistFailureKeepsTriggerArmed(t *testing.T) {
+	trader := &fakeTrader{}
+	store := &failingStore{}
+	engine, _ := NewEngine(trader, store)
+	var errs []error
+	engine.OnError(func(err error) { errs = append(errs, err) })
+	engine.Add(NewStopLoss("a", 0.4, 0.35, 10))
+
+	store.fail = true
+	engine.OnBook(book("a", "0.3", "0.32"))
+	if len(trader.posted) != 0 || len(errs) != 1 || engine.Triggers()[0].State != StateArmed {
+		t.Fatalf("Expected no order and an armed trigger, got %d orders, errors %v, %+v", len(trader.posted), errs, engine.Triggers())
+	}
+
+	store.fail = false
+	engine.OnBook(book("a", "0.3", "0.32"))
+	if len(trader.posted) != 1 || engine.Triggers()[0].State != StateFired {
+		t.Errorf("Expected the trigger to fire once persisting works, got %+v", engine.Triggers())
+	}
+}
+
+func TestPersistFailureRollsBackAddAndCancel(t *testing.T) {
+	trader := &fakeTrader{}
+	store := &failingStore{fail: true}
+	engine, _ := NewEngine(trader, store)
+
+	if _, err := engine.Add(NewStopLoss("a", 0.4, 0.35, 10)); err == nil {
+		t.Fatal("Expected Add to fail when the trigger cannot be persisted")
+	}
+	engine.OnBook(book("a", "0.3", "0.32"))
+	if len(engine.Triggers()) != 0 || len(trader.posted) != 0 {
+		t.Fatalf("Expected the unpersisted trigger to be dropped, got %+v", engine.Triggers())
+	}
+
+	store.fail = false
+	id, err := engine.Add(NewStopLoss("a", 0.4, 0.35, 10))
+	if err != nil {
+		t.Fatalf("Add failed: %v", err)
+	}
+	store.fail = true
+	if err := engine.Cancel(id); err == nil {
+		t.Fatal("Expected Cancel to fail when the cancellation cannot be persisted")
+	}
+	if engine.Triggers()[0].State != StateArmed {
+		t.Fatalf("Expected the trigger to stay armed, got %+v", engine.Triggers())
+	}
+}
+
+func TestRunReportsBookErrors(t *testing.T) {
+	trader := &fakeTrader{bookErr: errors.New("timeout")}
+	engine, _ := NewEngine(trader, nil)
+	engine.Add(NewStopLoss("a", 0.4, 0.35, 10))
+
+	ctx, cancel := context.WithCancel(context.Background())
+	reported := make(chan error, 1)
+	engine.OnError(func(err error) {
+		select {
+		case reported <- err:
+		default:
+		}
+		cancel()
+	})
+	go engine.Run(ctx, time.Hour)
+
+	select {
+	case err := <-reported:
+		if !errors.Is(err, trader.bookErr) {
+			t.Errorf("Unexpected error %v", err)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("Expected the book error to be reported")
+	}
+}
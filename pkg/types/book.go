@@ -0,0 +1,333 @@
+package types
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sort"
+
+	"github.com/shopspring/decimal"
+)
+
+// PriceLevel is a parsed order book level
+type PriceLevel struct {
+	Price float64 `json:"price"`
+	Size  float64 `json:"size"`
+}
+
+// DecimalLevel is an order book level parsed exactly
+type DecimalLevel struct {
+	Price decimal.Decimal `json:"price"`
+	Size  decimal.Decimal `json:"size"`
+}
+
+// InvalidLevelError reports a book level that could not be parsed and was skipped
+type InvalidLevelError struct {
+	Side  string // "bids" or "asks"
+	Index int    // Position in the book's list
+	Level OrderSummary
+	Err   error
+}
+
+func (e *InvalidLevelError) Error() string {
+	return fmt.Sprintf("invalid %s level %d (price %q, size %q): %v", e.Side, e.Index, e.Level.Price, e.Level.Size, e.Err)
+}
+
+func (e *InvalidLevelError) Unwrap() error {
+	return e.Err
+}
+
+// parsedBook holds both sides of a book sorted best price first, with the
+// levels they were parsed from
+type parsedBook struct {
+	bids, asks []DecimalLevel
+	err        error
+	sourceBids []OrderSummary
+	sourceAsks []OrderSummary
+}
+
+// UnmarshalJSON decodes the book and parses its price levels once
+func (b *OrderBookSummary) UnmarshalJSON(data []byte) error {
+	type plain OrderBookSummary
+	var decoded plain
+	if err := json.Unmarshal(data, &decoded); err != nil {
+		return err
+	}
+	*b = OrderBookSummary(decoded)
+	b.parsed = parseBook(b.Bids, b.Asks)
+	return nil
+}
+
+// DecimalLevels returns the bids and asks as exact decimals, each sorted best
+// price first. Levels that do not parse are skipped; err then joins an
+// *InvalidLevelError for each, and the valid levels are still returned.
+// Books decoded from JSON are parsed once, and again only after their Bids or
+// Asks change. The returned slices must not be modified.
+func (b *OrderBookSummary) DecimalLevels() (bids, asks []DecimalLevel, err error) {
+	parsed := b.parsed
+	if parsed == nil || !sameLevels(parsed.sourceBids, b.Bids) || !sameLevels(parsed.sourceAsks, b.Asks) {
+		parsed = parseBook(b.Bids, b.Asks)
+	}
+	return parsed.bids, parsed.asks, parsed.err
+}
+
+// Levels returns the bids and asks as numbers, each sorted best price first.
+// Bad levels are skipped and reported as by DecimalLevels.
+func (b *OrderBookSummary) Levels() (bids, asks []PriceLevel, err error) {
+	decimalBids, decimalAsks, err := b.DecimalLevels()
+	return floatLevels(decimalBids), floatLevels(decimalAsks), err
+}
+
+// BestBid returns the highest bid
+func (b *OrderBookSummary) BestBid() (PriceLevel, bool) {
+	bids, _, _ := b.DecimalLevels()
+	if len(bids) == 0 {
+		return PriceLevel{}, false
+	}
+	return floatLevel(bids[0]), true
+}
+
+// BestAsk returns the lowest ask
+func (b *OrderBookSummary) BestAsk() (PriceLevel, bool) {
+	_, asks, _ := b.DecimalLevels()
+	if len(asks) == 0 {
+		return PriceLevel{}, false
+	}
+	return floatLevel(asks[0]), true
+}
+
+// Mid returns the midpoint of the best bid and ask of a two-sided book
+func (b *OrderBookSummary) Mid() (float64, bool) {
+	bids, asks, _ := b.DecimalLevels()
+	if len(bids) == 0 || len(asks) == 0 {
+		return 0, false
+	}
+	return bids[0].Price.Add(asks[0].Price).Div(decimal.NewFromInt(2)).InexactFloat64(), true
+}
+
+// Spread returns the best ask minus the best bid of a two-sided book
+func (b *OrderBookSummary) Spread() (float64, bool) {
+	bids, asks, _ := b.DecimalLevels()
+	if len(bids) == 0 || len(asks) == 0 {
+		return 0, false
+	}
+	return asks[0].Price.Sub(bids[0].Price).InexactFloat64(), true
+}
+
+// DepthWithin returns the total size on each side within band of that side's best price.
+// A band of zero or less counts the whole side.
+func (b *OrderBookSummary) DepthWithin(band float64) (bidSize, askSize float64) {
+	bids, asks, _ := b.DecimalLevels()
+	return depthWithin(bids, band), depthWithin(asks, band)
+}
+
+// CumulativeSizeToPrice returns the size and notional available to a taker on side
+// at prices up to (BUY) or down to (SELL) limit
+func (b *OrderBookSummary) CumulativeSizeToPrice(side OrderSide, limit float64) (size, notional float64) {
+	bids, asks, _ := b.DecimalLevels()
+
+	levels := asks
+	if side == SELL {
+		levels = bids
+	}
+	bound := decimal.NewFromFloat(limit)
+	total, value := decimal.Zero, decimal.Zero
+	for _, level := range levels {
+		if side == BUY && level.Price.GreaterThan(bound) {
+			break
+		}
+		if side == SELL && level.Price.LessThan(bound) {
+			break
+		}
+		total = total.Add(level.Size)
+		value = value.Add(level.Size.Mul(level.Price))
+	}
+	return total.InexactFloat64(), value.InexactFloat64()
+}
+
+// Imbalance returns (bid size - ask size) / (bid size + ask size) over each
+// side's best levels, from -1 (only asks) to 1 (only bids). levels of zero or
+// less counts whole sides.
+func (b *OrderBookSummary) Imbalance(levels int) (float64, bool) {
+	bids, asks, _ := b.Levels()
+	bidSize, _ := topLevels(bids, levels)
+	askSize, _ := topLevels(asks, levels)
+	if bidSize+askSize <= 0 {
+		return 0, false
+	}
+	return (bidSize - askSize) / (bidSize + askSize), true
+}
+
+// WeightedMid returns the midpoint of the size-weighted average prices of each
+// side's best levels. With one level it is the plain midpoint.
+func (b *OrderBookSummary) WeightedMid(levels int) (float64, bool) {
+	bidPrice, _, askPrice, _, ok := b.sideAverages(levels)
+	if !ok {
+		return 0, false
+	}
+	return (bidPrice + askPrice) / 2, true
+}
+
+// Microprice returns the average bid and ask prices of each side's best levels,
+// each weighted by the size on the opposite side. It leans towards the ask when
+// bids outsize asks, anticipating the next price move better than the
+// midpoint. With one level it is the classic top-of-book microprice.
+func (b *OrderBookSummary) Microprice(levels int) (float64, bool) {
+	bidPrice, bidSize, askPrice, askSize, ok := b.sideAverages(levels)
+	if !ok {
+		return 0, false
+	}
+	return (bidPrice*askSize + askPrice*bidSize) / (bidSize + askSize), true
+}
+
+// sideAverages returns the size-weighted average price and total size of each
+// side's best levels of a two-sided book
+func (b *OrderBookSummary) sideAverages(levels int) (bidPrice, bidSize, askPrice, askSize float64, ok bool) {
+	bids, asks, _ := b.Levels()
+	bidSize, bidNotional := topLevels(bids, levels)
+	askSize, askNotional := topLevels(asks, levels)
+	if bidSize <= 0 || askSize <= 0 {
+		return 0, 0, 0, 0, false
+	}
+	return bidNotional / bidSize, bidSize, askNotional / askSize, askSize, true
+}
+
+// topLevels sums the size and notional of the first n best-first levels, or all
+// of them when n is zero or less
+func topLevels(levels []PriceLevel, n int) (size, notional float64) {
+	if n > 0 && n < len(levels) {
+		levels = levels[:n]
+	}
+	for _, level := range levels {
+		size += level.Size
+		notional += level.Size * level.Price
+	}
+	return size, notional
+}
+
+// DepthPoint is one price level of a cumulative depth chart
+type DepthPoint struct {
+	Price    float64 `json:"price"`
+	Size     float64 `json:"size"`     // Shares at this price and every better one
+	Notional float64 `json:"notional"` // USDC value of those shares at their prices
+}
+
+// Depth is one side of a cumulative depth chart, best price first
+type Depth []DepthPoint
+
+// DepthChart returns the cumulative depth of each side, best price first, e.g.
+// for plotting or estimating what a taker order of some size would pay. Bad
+// levels are left out and reported as by DecimalLevels.
+func (b *OrderBookSummary) DepthChart() (bids, asks Depth, err error) {
+	bidLevels, askLevels, err := b.DecimalLevels()
+	return cumulative(bidLevels), cumulative(askLevels), err
+}
+
+// Fill returns the average and worst price of taking size shares from this
+// side. ok is false when the side holds fewer shares.
+func (d Depth) Fill(size float64) (average, worst float64, ok bool) {
+	if size <= 0 || len(d) == 0 || d[len(d)-1].Size < size-1e-9 {
+		return 0, 0, false
+	}
+	i := sort.Search(len(d), func(i int) bool { return d[i].Size >= size-1e-9 })
+	notional := d[i].Notional - (d[i].Size-size)*d[i].Price
+	return notional / size, d[i].Price, true
+}
+
+// cumulative accumulates best-first levels into depth points
+func cumulative(levels []DecimalLevel) Depth {
+	depth := make(Depth, 0, len(levels))
+	size, notional := decimal.Zero, decimal.Zero
+	for _, level := range levels {
+		size = size.Add(level.Size)
+		notional = notional.Add(level.Size.Mul(level.Price))
+		depth = append(depth, DepthPoint{Price: level.Price.InexactFloat64(), Size: size.InexactFloat64(), Notional: notional.InexactFloat64()})
+	}
+	return depth
+}
+
+// parseBook parses and sorts both sides of a book, skipping bad levels
+func parseBook(bids, asks []OrderSummary) *parsedBook {
+	parsedBids, bidErrs := parseLevels("bids", bids)
+	parsedAsks, askErrs := parseLevels("asks", asks)
+
+	// The API lists bids ascending and asks descending; normalize to best first
+	sort.SliceStable(parsedBids, func(i, j int) bool { return parsedBids[i].Price.GreaterThan(parsedBids[j].Price) })
+	sort.SliceStable(parsedAsks, func(i, j int) bool { return parsedAsks[i].Price.LessThan(parsedAsks[j].Price) })
+	return &parsedBook{
+		bids:       parsedBids,
+		asks:       parsedAsks,
+		err:        errors.Join(append(bidErrs, askErrs...)...),
+		sourceBids: append([]OrderSummary(nil), bids...),
+		sourceAsks: append([]OrderSummary(nil), asks...),
+	}
+}
+
+// parseLevels converts string price levels to decimals, returning an error for each it skips
+func parseLevels(side string, summaries []OrderSummary) ([]DecimalLevel, []error) {
+	levels := make([]DecimalLevel, 0, len(summaries))
+	var errs []error
+	for i, summary := range summaries {
+		price, err := decimal.NewFromString(summary.Price)
+		if err == nil && (price.IsNegative() || price.GreaterThan(decimal.NewFromInt(1))) {
+			err = fmt.Errorf("price out of range")
+		}
+		if err != nil {
+			errs = append(errs, &InvalidLevelError{Side: side, Index: i, Level: summary, Err: err})
+			continue
+		}
+		size, err := decimal.NewFromString(summary.Size)
+		if err == nil && size.IsNegative() {
+			err = fmt.Errorf("negative size")
+		}
+		if err != nil {
+			errs = append(errs, &InvalidLevelError{Side: side, Index: i, Level: summary, Err: err})
+			continue
+		}
+		levels = append(levels, DecimalLevel{Price: price, Size: size})
+	}
+	return levels, errs
+}
+
+// sameLevels reports whether two lists hold the same levels in the same order
+func sameLevels(a, b []OrderSummary) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
+
+// floatLevel converts a decimal level to numbers
+func floatLevel(level DecimalLevel) PriceLevel {
+	return PriceLevel{Price: level.Price.InexactFloat64(), Size: level.Size.InexactFloat64()}
+}
+
+// floatLevels converts decimal levels to numbers
+func floatLevels(levels []DecimalLevel) []PriceLevel {
+	converted := make([]PriceLevel, len(levels))
+	for i, level := range levels {
+		converted[i] = floatLevel(level)
+	}
+	return converted
+}
+
+// depthWithin sums the size of best-first levels within band of the first level
+func depthWithin(levels []DecimalLevel, band float64) float64 {
+	if len(levels) == 0 {
+		return 0
+	}
+	width := decimal.NewFromFloat(band)
+	total := decimal.Zero
+	for _, level := range levels {
+		if band > 0 && level.Price.Sub(levels[0].Price).Abs().GreaterThan(width) {
+			break
+		}
+		total = total.Add(level.Size)
+	}
+	return total.InexactFloat64()
+}
@@ -0,0 +1,160 @@
+package types
+
+import (
+	"encoding/json"
+	"errors"
+	"math"
+	"strings"
+	"testing"
+)
+
+func TestOrderBookSummaryAnalytics(t *testing.T) {
+	// Levels in the order the API returns them: bids ascending, asks descending
+	data := `{"asset_id":"tok","bids":[{"price":"0.40","size":"30"},{"price":"0.44","size":"10"},{"price":"0.45","size":"5"}],` +
+		`"asks":[{"price":"0.60","size":"50"},{"price":"0.50","size":"20"},{"price":"0.48","size":"8"}]}`
+
+	var book OrderBookSummary
+	if err := json.Unmarshal([]byte(data), &book); err != nil {
+		t.Fatalf("Failed to decode book: %v", err)
+	}
+
+	bid, _ := book.BestBid()
+	ask, _ := book.BestAsk()
+	if bid.Price != 0.45 || ask.Price != 0.48 {
+		t.Fatalf("Expected best 0.45/0.48, got %v/%v", bid.Price, ask.Price)
+	}
+	if mid, _ := book.Mid(); math.Abs(mid-0.465) > 1e-9 {
+		t.Errorf("Expected mid 0.465, got %v", mid)
+	}
+	if spread, _ := book.Spread(); math.Abs(spread-0.03) > 1e-9 {
+		t.Errorf("Expected spread 0.03, got %v", spread)
+	}
+
+	bidDepth, askDepth := book.DepthWithin(0.02)
+	if bidDepth != 15 || askDepth != 28 {
+		t.Errorf("Expected depth 15/28 within 0.02, got %v/%v", bidDepth, askDepth)
+	}
+
+	size, notional := book.CumulativeSizeToPrice(BUY, 0.50)
+	if size != 28 || math.Abs(notional-(8*0.48+20*0.50)) > 1e-9 {
+		t.Errorf("Expected 28 shares to 0.50, got %v (notional %v)", size, notional)
+	}
+	size, _ = book.CumulativeSizeToPrice(SELL, 0.44)
+	if size != 15 {
+		t.Errorf("Expected 15 shares down to 0.44, got %v", size)
+	}
+}
+
+func TestOrderBookSummarySignals(t *testing.T) {
+	book := &OrderBookSummary{
+		Bids: []OrderSummary{{Price: "0.40", Size: "100"}, {Price: "0.45", Size: "30"}},
+		Asks: []OrderSummary{{Price: "0.55", Size: "20"}, {Price: "0.50", Size: "10"}},
+	}
+
+	if imbalance, _ := book.Imbalance(1); math.Abs(imbalance-0.5) > 1e-9 {
+		t.Errorf("Expected top-level imbalance 0.5, got %v", imbalance)
+	}
+	if imbalance, _ := book.Imbalance(0); math.Abs(imbalance-(130.0-30)/160) > 1e-9 {
+		t.Errorf("Expected whole-book imbalance %v, got %v", (130.0-30)/160, imbalance)
+	}
+
+	// Bids outsize asks three to one, so the microprice sits three quarters of the way to the ask
+	if micro, _ := book.Microprice(1); math.Abs(micro-0.4875) > 1e-9 {
+		t.Errorf("Expected microprice 0.4875, got %v", micro)
+	}
+	if mid, _ := book.WeightedMid(1); math.Abs(mid-0.475) > 1e-9 {
+		t.Errorf("Expected top-level weighted mid 0.475, got %v", mid)
+	}
+
+	bidAvg := (0.45*30 + 0.40*100) / 130
+	askAvg := (0.50*10 + 0.55*20) / 30
+	if mid, _ := book.WeightedMid(2); math.Abs(mid-(bidAvg+askAvg)/2) > 1e-9 {
+		t.Errorf("Expected weighted mid %v, got %v", (bidAvg+askAvg)/2, mid)
+	}
+	if micro, _ := book.Microprice(5); math.Abs(micro-(bidAvg*30+askAvg*130)/160) > 1e-9 {
+		t.Errorf("Expected two-level microprice %v, got %v", (bidAvg*30+askAvg*130)/160, micro)
+	}
+
+	oneSided := &OrderBookSummary{Bids: []OrderSummary{{Price: "0.40", Size: "10"}}}
+	if _, ok := oneSided.Microprice(1); ok {
+		t.Errorf("Expected no microprice for a one-sided book")
+	}
+	if imbalance, ok := oneSided.Imbalance(1); !ok || imbalance != 1 {
+		t.Errorf("Expected imbalance 1 for a bid-only book, got %v", imbalance)
+	}
+}
+
+func TestOrderBookSummaryDepthChart(t *testing.T) {
+	book := &OrderBookSummary{
+		Bids: []OrderSummary{{Price: "0.40", Size: "100"}, {Price: "0.45", Size: "30"}},
+		Asks: []OrderSummary{{Price: "0.55", Size: "20"}, {Price: "0.50", Size: "10"}},
+	}
+	bids, asks, err := book.DepthChart()
+	if err != nil {
+		t.Fatalf("DepthChart failed: %v", err)
+	}
+	if len(bids) != 2 || bids[0] != (DepthPoint{Price: 0.45, Size: 30, Notional: 13.5}) || bids[1].Size != 130 || math.Abs(bids[1].Notional-53.5) > 1e-9 {
+		t.Errorf("Unexpected bid depth %+v", bids)
+	}
+	if len(asks) != 2 || asks[1].Price != 0.55 || asks[1].Size != 30 || math.Abs(asks[1].Notional-16) > 1e-9 {
+		t.Errorf("Unexpected ask depth %+v", asks)
+	}
+
+	// 20 shares: 10 at 0.50 and 10 at 0.55
+	average, worst, ok := asks.Fill(20)
+	if !ok || math.Abs(average-0.525) > 1e-9 || worst != 0.55 {
+		t.Errorf("Expected 20 shares at 0.525 (worst 0.55), got %v, %v, %v", average, worst, ok)
+	}
+	if _, worst, _ := asks.Fill(10); worst != 0.50 {
+		t.Errorf("Expected 10 shares to fill at the best ask, got %v", worst)
+	}
+	if _, _, ok := asks.Fill(31); ok {
+		t.Errorf("Expected a size beyond the book not to fill")
+	}
+}
+
+func TestOrderBookSummarySkipsMalformedLevels(t *testing.T) {
+	var book OrderBookSummary
+	data := `{"bids":[{"price":"abc","size":"1"},{"price":"0.40","size":"5"}],"asks":[{"price":"0.50","size":"x"},{"price":"0.60","size":"2"}]}`
+	if err := json.Unmarshal([]byte(data), &book); err != nil {
+		t.Fatalf("Expected a book with bad levels to decode, got %v", err)
+	}
+	bids, asks, err := book.Levels()
+	if len(bids) != 1 || bids[0].Price != 0.4 || len(asks) != 1 || asks[0].Price != 0.6 {
+		t.Errorf("Expected the valid levels to be kept, got %+v / %+v", bids, asks)
+	}
+	var invalid *InvalidLevelError
+	if !errors.As(err, &invalid) || invalid.Side != "bids" || invalid.Index != 0 || !strings.Contains(err.Error(), "asks level 0") {
+		t.Errorf("Expected each bad level to be reported, got %v", err)
+	}
+	if spread, ok := book.Spread(); !ok || spread != 0.2 {
+		t.Errorf("Expected a spread of 0.2 from the valid levels, got %v", spread)
+	}
+
+	built := OrderBookSummary{Bids: []OrderSummary{{Price: "0.3", Size: "1"}}}
+	if _, ok := built.Mid(); ok {
+		t.Error("Expected one-sided book to have no mid")
+	}
+	if bid, ok := built.BestBid(); !ok || bid.Price != 0.3 {
+		t.Errorf("Expected best bid 0.3 on a book built in code, got %v", bid)
+	}
+}
+
+func TestOrderBookSummaryLevelsFollowChanges(t *testing.T) {
+	var book OrderBookSummary
+	if err := json.Unmarshal([]byte(`{"bids":[{"price":"0.40","size":"5"}],"asks":[{"price":"0.60","size":"2"}]}`), &book); err != nil {
+		t.Fatalf("Failed to decode book: %v", err)
+	}
+	if bid, _ := book.BestBid(); bid.Price != 0.4 {
+		t.Fatalf("Expected best bid 0.4, got %v", bid.Price)
+	}
+
+	// Levels written after decoding are not hidden by the parsed copy
+	book.Bids[0] = OrderSummary{Price: "0.42", Size: "5"}
+	book.Asks = append(book.Asks, OrderSummary{Price: "0.55", Size: "1"})
+	bid, _ := book.BestBid()
+	ask, _ := book.BestAsk()
+	if bid.Price != 0.42 || ask.Price != 0.55 {
+		t.Errorf("Expected best 0.42/0.55 after the change, got %v/%v", bid.Price, ask.Price)
+	}
+}
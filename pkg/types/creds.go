@@ -0,0 +1,106 @@
+package types
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"strings"
+)
+
+// Environment variable names used for API credentials by py-clob-client and the Polymarket docs
+const (
+	EnvApiKey        = "CLOB_API_KEY"
+	EnvApiSecret     = "CLOB_SECRET"
+	EnvApiPassphrase = "CLOB_PASS_PHRASE"
+)
+
+// Accepted JSON field names for each credential, in order of preference:
+// this package and py-clob-client (api_key), the API response and the TypeScript client (apiKey / secret),
+// and the short names used in the docs (key).
+var (
+	apiKeyFields        = []string{"api_key", "apiKey", "key"}
+	apiSecretFields     = []string{"api_secret", "apiSecret", "secret"}
+	apiPassphraseFields = []string{"api_passphrase", "apiPassphrase", "passphrase"}
+)
+
+// UnmarshalJSON accepts credentials stored by this package, py-clob-client,
+// the TypeScript client or returned directly by the API key endpoints
+func (c *ApiCreds) UnmarshalJSON(data []byte) error {
+	var raw map[string]interface{}
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return err
+	}
+
+	c.ApiKey = firstString(raw, apiKeyFields)
+	c.ApiSecret = firstString(raw, apiSecretFields)
+	c.ApiPassphrase = firstString(raw, apiPassphraseFields)
+	return nil
+}
+
+// MarshalEnv encodes the credentials as .env lines
+func (c ApiCreds) MarshalEnv() string {
+	return fmt.Sprintf("%s=%s\n%s=%s\n%s=%s\n",
+		EnvApiKey, c.ApiKey,
+		EnvApiSecret, c.ApiSecret,
+		EnvApiPassphrase, c.ApiPassphrase)
+}
+
+// UnmarshalEnv reads credentials from .env content, ignoring unrelated keys
+func (c *ApiCreds) UnmarshalEnv(data []byte) error {
+	values, err := ParseDotEnv(string(data))
+	if err != nil {
+		return err
+	}
+	if value, ok := values[EnvApiKey]; ok {
+		c.ApiKey = value
+	}
+	if value, ok := values[EnvApiSecret]; ok {
+		c.ApiSecret = value
+	}
+	if value, ok := values[EnvApiPassphrase]; ok {
+		c.ApiPassphrase = value
+	}
+
+	if c.ApiKey == "" || c.ApiSecret == "" || c.ApiPassphrase == "" {
+		return fmt.Errorf("incomplete API credentials: %s, %s and %s are required", EnvApiKey, EnvApiSecret, EnvApiPassphrase)
+	}
+	return nil
+}
+
+// ParseDotEnv parses .env content: KEY=VALUE lines, ignoring blank lines,
+// comments and an "export " prefix. A value wrapped in matching single or
+// double quotes is unquoted.
+func ParseDotEnv(content string) (map[string]string, error) {
+	values := make(map[string]string)
+	scanner := bufio.NewScanner(strings.NewReader(content))
+	lineNumber := 0
+	for scanner.Scan() {
+		lineNumber++
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		line = strings.TrimPrefix(line, "export ")
+
+		key, value, found := strings.Cut(line, "=")
+		if !found {
+			return nil, fmt.Errorf("line %d: expected KEY=VALUE", lineNumber)
+		}
+		value = strings.TrimSpace(value)
+		if len(value) >= 2 && (value[0] == '"' || value[0] == '\'') && value[len(value)-1] == value[0] {
+			value = value[1 : len(value)-1]
+		}
+		values[strings.TrimSpace(key)] = value
+	}
+	return values, scanner.Err()
+}
+
+// firstString returns the first non-empty string value among keys
+func firstString(raw map[string]interface{}, keys []string) string {
+	for _, key := range keys {
+		if value, ok := raw[key].(string); ok && value != "" {
+			return value
+		}
+	}
+	return ""
+}
@@ -0,0 +1,56 @@
+package types
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+func TestApiCredsUnmarshalFormats(t *testing.T) {
+	inputs := []string{
+		`{"api_key":"k","api_secret":"s","api_passphrase":"p"}`,
+		`{"apiKey":"k","secret":"s","passphrase":"p"}`,
+		`{"key":"k","secret":"s","passphrase":"p"}`,
+	}
+
+	for _, input := range inputs {
+		var creds ApiCreds
+		if err := json.Unmarshal([]byte(input), &creds); err != nil {
+			t.Fatalf("Failed to unmarshal %s: %v", input, err)
+		}
+		if creds.ApiKey != "k" || creds.ApiSecret != "s" || creds.ApiPassphrase != "p" {
+			t.Errorf("Unexpected creds from %s: %+v", input, creds)
+		}
+	}
+}
+
+func TestApiCredsEnvRoundTrip(t *testing.T) {
+	creds := ApiCreds{ApiKey: "k", ApiSecret: "s", ApiPassphrase: "p"}
+
+	var decoded ApiCreds
+	if err := decoded.UnmarshalEnv([]byte("# stored creds\n" + creds.MarshalEnv())); err != nil {
+		t.Fatalf("Failed to unmarshal env: %v", err)
+	}
+	if decoded != creds {
+		t.Errorf("Expected %+v, got %+v", creds, decoded)
+	}
+
+	var partial ApiCreds
+	if err := partial.UnmarshalEnv([]byte("CLOB_API_KEY=k\n")); err == nil {
+		t.Error("Expected incomplete credentials to be rejected")
+	}
+}
+
+func TestParseDotEnvQuotes(t *testing.T) {
+	values, err := ParseDotEnv("export CLOB_API_KEY=\"k\"\nCLOB_SECRET='s='\nCLOB_PASS_PHRASE=\"p'\n")
+	if err != nil {
+		t.Fatalf("ParseDotEnv failed: %v", err)
+	}
+	// Only matching quotes are removed
+	if values[EnvApiKey] != "k" || values[EnvApiSecret] != "s=" || values[EnvApiPassphrase] != `"p'` {
+		t.Errorf("Unexpected values %v", values)
+	}
+
+	if _, err := ParseDotEnv("CLOB_API_KEY\n"); err == nil {
+		t.Error("Expected a line without = to be rejected")
+	}
+}
@@ -0,0 +1,131 @@
+package types
+
+import (
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// SaltEncoding controls how the salt of a signed order is written on the wire
+type SaltEncoding int
+
+const (
+	SaltAsNumber SaltEncoding = iota // 123456 (what the CLOB API and py-clob-client send)
+	SaltAsString                     // "123456"
+)
+
+// SideEncoding controls how the side of a signed order is written on the wire
+type SideEncoding int
+
+const (
+	SideAsString SideEncoding = iota // "BUY" / "SELL" (what the CLOB API expects)
+	SideAsNumber                     // 0 / 1, as used in the signed EIP712 struct
+)
+
+// OrderEncoding selects the wire format of signed orders.
+// The zero value matches the format accepted by the CLOB API.
+type OrderEncoding struct {
+	Salt SaltEncoding
+	Side SideEncoding
+}
+
+// DefaultOrderEncoding is the encoding the CLOB API expects
+var DefaultOrderEncoding = OrderEncoding{Salt: SaltAsNumber, Side: SideAsString}
+
+// wireOrder mirrors SignedOrder with a variable salt and side representation.
+// Field order matches SignedOrder so the default encoding equals json.Marshal.
+type wireOrder struct {
+	Salt          interface{} `json:"salt"`
+	Maker         string      `json:"maker"`
+	Signer        string      `json:"signer"`
+	Taker         string      `json:"taker"`
+	TokenID       string      `json:"tokenId"`
+	MakerAmount   string      `json:"makerAmount"`
+	TakerAmount   string      `json:"takerAmount"`
+	Expiration    string      `json:"expiration"`
+	Nonce         string      `json:"nonce"`
+	FeeRateBps    string      `json:"feeRateBps"`
+	Side          interface{} `json:"side"`
+	SignatureType int         `json:"signatureType"`
+	Signature     string      `json:"signature"`
+}
+
+// MarshalWire encodes the signed order with the given encoding
+func (o SignedOrder) MarshalWire(enc OrderEncoding) (json.RawMessage, error) {
+	if o.Side != BUY && o.Side != SELL {
+		return nil, fmt.Errorf("invalid order side: %q", o.Side)
+	}
+
+	w := wireOrder{
+		Salt:          o.Salt,
+		Maker:         o.Maker,
+		Signer:        o.Signer,
+		Taker:         o.Taker,
+		TokenID:       o.TokenID,
+		MakerAmount:   o.MakerAmount,
+		TakerAmount:   o.TakerAmount,
+		Expiration:    o.Expiration,
+		Nonce:         o.Nonce,
+		FeeRateBps:    o.FeeRateBps,
+		Side:          o.Side,
+		SignatureType: o.SignatureType,
+		Signature:     o.Signature,
+	}
+	if enc.Salt == SaltAsString {
+		w.Salt = strconv.FormatInt(o.Salt, 10)
+	}
+	if enc.Side == SideAsNumber {
+		w.Side = 0
+		if o.Side == SELL {
+			w.Side = 1
+		}
+	}
+	return json.Marshal(w)
+}
+
+// UnmarshalJSON accepts the salt as a number or a string and the side as "BUY"/"SELL" or 0/1
+func (o *SignedOrder) UnmarshalJSON(data []byte) error {
+	type plain SignedOrder
+	var w struct {
+		plain
+		Salt json.RawMessage `json:"salt"`
+		Side json.RawMessage `json:"side"`
+	}
+	if err := json.Unmarshal(data, &w); err != nil {
+		return err
+	}
+	*o = SignedOrder(w.plain)
+
+	if len(w.Salt) > 0 && string(w.Salt) != "null" {
+		salt, err := strconv.ParseInt(strings.Trim(string(w.Salt), `"`), 10, 64)
+		if err != nil {
+			return fmt.Errorf("invalid salt %s: %w", w.Salt, err)
+		}
+		o.Salt = salt
+	}
+
+	switch strings.ToUpper(strings.Trim(string(w.Side), `"`)) {
+	case "BUY", "0":
+		o.Side = BUY
+	case "SELL", "1":
+		o.Side = SELL
+	case "", "NULL":
+	default:
+		return fmt.Errorf("invalid side %s", w.Side)
+	}
+	return nil
+}
+
+// MarshalWire encodes the order request, writing the embedded order with the given encoding
+func (r OrderRequest) MarshalWire(enc OrderEncoding) (json.RawMessage, error) {
+	order, err := r.Order.MarshalWire(enc)
+	if err != nil {
+		return nil, err
+	}
+	return json.Marshal(struct {
+		Order     json.RawMessage `json:"order"`
+		Owner     string          `json:"owner"`
+		OrderType OrderType       `json:"orderType"`
+	}{order, r.Owner, r.OrderType})
+}
@@ -0,0 +1,45 @@
+package types
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+)
+
+func TestSignedOrderDefaultWireEncoding(t *testing.T) {
+	order := SignedOrder{Salt: 1234567, Side: SELL, MakerAmount: "10", TakerAmount: "5"}
+
+	data, err := order.MarshalWire(DefaultOrderEncoding)
+	if err != nil {
+		t.Fatalf("Failed to encode order: %v", err)
+	}
+	if !strings.Contains(string(data), `"salt":1234567`) || !strings.Contains(string(data), `"side":"SELL"`) {
+		t.Errorf("Expected numeric salt and string side, got %s", data)
+	}
+
+	// The default encoding must match plain json.Marshal, which the HMAC signature is built from
+	plain, _ := json.Marshal(order)
+	if string(plain) != string(data) {
+		t.Errorf("Expected default encoding to equal json.Marshal\n got %s\nwant %s", data, plain)
+	}
+}
+
+func TestSignedOrderAlternateEncodingRoundTrip(t *testing.T) {
+	order := SignedOrder{Salt: 42, Side: BUY, Signature: "0xabc"}
+
+	data, err := order.MarshalWire(OrderEncoding{Salt: SaltAsString, Side: SideAsNumber})
+	if err != nil {
+		t.Fatalf("Failed to encode order: %v", err)
+	}
+	if !strings.Contains(string(data), `"salt":"42"`) || !strings.Contains(string(data), `"side":0`) {
+		t.Errorf("Expected string salt and numeric side, got %s", data)
+	}
+
+	var decoded SignedOrder
+	if err := json.Unmarshal(data, &decoded); err != nil {
+		t.Fatalf("Failed to decode order: %v", err)
+	}
+	if decoded != order {
+		t.Errorf("Expected %+v, got %+v", order, decoded)
+	}
+}
@@ -0,0 +1,45 @@
+package types
+
+import (
+	"encoding/json"
+	"fmt"
+)
+
+// ExportedOrderVersion is the version of the exported signed order format
+const ExportedOrderVersion = 1
+
+// ExportedOrder is a signed order saved for posting later, possibly by another
+// process. The order is kept exactly as signed; the other fields let the
+// importing side check it was signed for the same chain and exchange.
+type ExportedOrder struct {
+	Version   int         `json:"version"`
+	ChainID   int64       `json:"chain_id"`
+	Exchange  string      `json:"exchange"` // The verifying contract the order was signed for
+	NegRisk   bool        `json:"neg_risk"`
+	OrderType OrderType   `json:"order_type"`
+	CreatedAt int64       `json:"created_at"` // Unix seconds
+	Flags     OrderFlags  `json:"flags"`      // The order's client-side settings, e.g. the API key to post it under
+	Order     SignedOrder `json:"order"`
+}
+
+// ParseExportedOrder decodes an exported order and checks its version and required fields
+func ParseExportedOrder(data []byte) (*ExportedOrder, error) {
+	var exported ExportedOrder
+	if err := json.Unmarshal(data, &exported); err != nil {
+		return nil, fmt.Errorf("failed to parse exported order: %w", err)
+	}
+	if exported.Version != ExportedOrderVersion {
+		return nil, fmt.Errorf("unsupported exported order version %d", exported.Version)
+	}
+	switch {
+	case exported.ChainID == 0:
+		return nil, fmt.Errorf("exported order has no chain ID")
+	case exported.Exchange == "":
+		return nil, fmt.Errorf("exported order has no exchange address")
+	case exported.OrderType == "":
+		return nil, fmt.Errorf("exported order has no order type")
+	case exported.Order.Signature == "":
+		return nil, fmt.Errorf("exported order is not signed")
+	}
+	return &exported, nil
+}
@@ -0,0 +1,70 @@
+package types
+
+// Comment is a comment on a Gamma event, series or market
+type Comment struct {
+	ID               string         `json:"id"`
+	Body             string         `json:"body"`
+	ParentEntityType string         `json:"parentEntityType"` // "Event", "Series" or "market"
+	ParentEntityID   int64          `json:"parentEntityID"`
+	ParentCommentID  string         `json:"parentCommentID,omitempty"` // Set on replies
+	UserAddress      string         `json:"userAddress"`
+	CreatedAt        string         `json:"createdAt"` // RFC 3339
+	UpdatedAt        string         `json:"updatedAt,omitempty"`
+	ReactionCount    int            `json:"reactionCount"`
+	Profile          CommentProfile `json:"profile"`
+}
+
+// CommentProfile is the public profile of a comment's author
+type CommentProfile struct {
+	Name      string `json:"name"`
+	Pseudonym string `json:"pseudonym"`
+}
+
+// Activity types reported by the data API
+const (
+	ActivityTrade      = "TRADE"
+	ActivitySplit      = "SPLIT"
+	ActivityMerge      = "MERGE"
+	ActivityRedeem     = "REDEEM"
+	ActivityReward     = "REWARD"
+	ActivityConversion = "CONVERSION"
+)
+
+// Activity is an on-chain action of a user from the data API activity feed
+type Activity struct {
+	ProxyWallet     string    `json:"proxyWallet"`
+	Timestamp       int64     `json:"timestamp"` // Unix seconds
+	ConditionID     string    `json:"conditionId"`
+	Type            string    `json:"type"`
+	Size            float64   `json:"size"`
+	USDCSize        float64   `json:"usdcSize"`
+	TransactionHash string    `json:"transactionHash"`
+	Price           float64   `json:"price"`
+	Asset           string    `json:"asset"`
+	Side            OrderSide `json:"side"`
+	OutcomeIndex    int       `json:"outcomeIndex"`
+	Title           string    `json:"title"`
+	Slug            string    `json:"slug"`
+	Outcome         string    `json:"outcome"`
+	Name            string    `json:"name"`
+	Pseudonym       string    `json:"pseudonym"`
+}
+
+// PublicTrade is a trade from the data API's platform-wide trade feed
+type PublicTrade struct {
+	ProxyWallet     string    `json:"proxyWallet"`
+	Side            OrderSide `json:"side"`
+	Asset           string    `json:"asset"`
+	ConditionID     string    `json:"conditionId"`
+	Size            float64   `json:"size"`
+	Price           float64   `json:"price"`
+	Timestamp       int64     `json:"timestamp"` // Unix seconds
+	Title           string    `json:"title"`
+	Slug            string    `json:"slug"`
+	EventSlug       string    `json:"eventSlug"`
+	Outcome         string    `json:"outcome"`
+	OutcomeIndex    int       `json:"outcomeIndex"`
+	Name            string    `json:"name"`
+	Pseudonym       string    `json:"pseudonym"`
+	TransactionHash string    `json:"transactionHash"`
+}
@@ -0,0 +1,22 @@
+package types
+
+// PriceHistoryParams selects a token's price history. Either Interval or a
+// StartTs / EndTs range is used.
+type PriceHistoryParams struct {
+	Market   string // Token ID
+	Interval string // "1m", "1h", "6h", "1d", "1w" or "max", ending now
+	Fidelity int    // Resolution in minutes; 0 lets the server choose
+	StartTs  int64  // Unix seconds
+	EndTs    int64  // Unix seconds
+}
+
+// PricePoint is one sample of a price history
+type PricePoint struct {
+	T int64   `json:"t"` // Unix seconds
+	P float64 `json:"p"`
+}
+
+// PriceHistory is the response of the prices history endpoint
+type PriceHistory struct {
+	History []PricePoint `json:"history"`
+}
@@ -0,0 +1,57 @@
+package types
+
+import (
+	"strings"
+	"time"
+)
+
+// TokenByOutcome returns the token for an outcome label, compared case-insensitively
+func (m *Market) TokenByOutcome(outcome string) (MarketToken, bool) {
+	for _, token := range m.Tokens {
+		if strings.EqualFold(token.Outcome, outcome) {
+			return token, true
+		}
+	}
+	return MarketToken{}, false
+}
+
+// TokenIDs returns the token IDs of all outcomes in order
+func (m *Market) TokenIDs() []string {
+	ids := make([]string, 0, len(m.Tokens))
+	for _, token := range m.Tokens {
+		ids = append(ids, token.TokenID)
+	}
+	return ids
+}
+
+// EndTime parses EndDate, returning false when it is empty or malformed
+func (m *Market) EndTime() (time.Time, bool) {
+	if m.EndDate == "" {
+		return time.Time{}, false
+	}
+	for _, layout := range []string{time.RFC3339, "2006-01-02"} {
+		if t, err := time.Parse(layout, m.EndDate); err == nil {
+			return t, true
+		}
+	}
+	return time.Time{}, false
+}
+
+// DailyRate returns the total daily rewards across assets
+func (r MarketRewards) DailyRate() float64 {
+	total := 0.0
+	for _, rate := range r.Rates {
+		total += rate.RewardsDailyRate
+	}
+	return total
+}
+
+// MarketStats are a market's activity figures, in USDC
+type MarketStats struct {
+	ConditionID  string  `json:"condition_id"`
+	Slug         string  `json:"slug,omitempty"`
+	Volume24h    float64 `json:"volume_24h"`
+	Volume       float64 `json:"volume"`
+	Liquidity    float64 `json:"liquidity"`
+	OpenInterest float64 `json:"open_interest"`
+}
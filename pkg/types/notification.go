@@ -0,0 +1,94 @@
+package types
+
+import (
+	"encoding/json"
+	"fmt"
+	"strconv"
+)
+
+// NotificationType identifies the event a notification reports
+type NotificationType int
+
+const (
+	NotificationOrderCancelled NotificationType = 1 // One of the account's orders was cancelled
+	NotificationOrderFilled    NotificationType = 2 // One of the account's orders was (partially) matched
+	NotificationMarketResolved NotificationType = 4 // A market the account traded resolved
+)
+
+// Notification is an account event returned by the notifications endpoint
+type Notification struct {
+	ID        string              `json:"id"`
+	Type      NotificationType    `json:"type"`
+	Owner     string              `json:"owner"`
+	Timestamp int64               `json:"timestamp,omitempty"`
+	Payload   NotificationPayload `json:"payload"`
+}
+
+// NotificationPayload holds the commonly used payload fields. Fields has every
+// payload value as a string, including ones without a dedicated field.
+type NotificationPayload struct {
+	OrderID         string            `json:"order_id,omitempty"`
+	TradeID         string            `json:"trade_id,omitempty"`
+	AssetID         string            `json:"asset_id,omitempty"`
+	Market          string            `json:"market,omitempty"`
+	Side            string            `json:"side,omitempty"`
+	Price           string            `json:"price,omitempty"`
+	MatchedSize     string            `json:"matched_size,omitempty"`
+	OriginalSize    string            `json:"original_size,omitempty"`
+	RemainingSize   string            `json:"remaining_size,omitempty"`
+	Outcome         string            `json:"outcome,omitempty"`
+	Question        string            `json:"question,omitempty"`
+	TransactionHash string            `json:"transaction_hash,omitempty"`
+	Fields          map[string]string `json:"fields,omitempty"`
+}
+
+// UnmarshalJSON accepts numeric or string IDs and payload values of any scalar type
+func (n *Notification) UnmarshalJSON(data []byte) error {
+	var raw map[string]interface{}
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return err
+	}
+
+	n.ID = scalarString(raw["id"])
+	n.Owner = scalarString(raw["owner"])
+	if kind, err := strconv.Atoi(scalarString(raw["type"])); err == nil {
+		n.Type = NotificationType(kind)
+	}
+	if timestamp, err := strconv.ParseInt(scalarString(raw["timestamp"]), 10, 64); err == nil {
+		n.Timestamp = timestamp
+	}
+
+	n.Payload = NotificationPayload{Fields: make(map[string]string)}
+	payload, _ := raw["payload"].(map[string]interface{})
+	for key, value := range payload {
+		n.Payload.Fields[key] = scalarString(value)
+	}
+	fields := n.Payload.Fields
+	n.Payload.OrderID = fields["order_id"]
+	n.Payload.TradeID = fields["trade_id"]
+	n.Payload.AssetID = fields["asset_id"]
+	n.Payload.Market = fields["market"]
+	n.Payload.Side = fields["side"]
+	n.Payload.Price = fields["price"]
+	n.Payload.MatchedSize = fields["matched_size"]
+	n.Payload.OriginalSize = fields["original_size"]
+	n.Payload.RemainingSize = fields["remaining_size"]
+	n.Payload.Outcome = fields["outcome"]
+	n.Payload.Question = fields["question"]
+	n.Payload.TransactionHash = fields["transaction_hash"]
+	return nil
+}
+
+// scalarString formats a decoded JSON scalar; numbers keep their integer form
+func scalarString(value interface{}) string {
+	switch v := value.(type) {
+	case nil:
+		return ""
+	case string:
+		return v
+	case float64:
+		return strconv.FormatFloat(v, 'f', -1, 64)
+	default:
+		return fmt.Sprintf("%v", v)
+	}
+}
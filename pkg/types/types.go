@@ -3,6 +3,8 @@ package types
 import (
 	"math/big"
 	"time"
+
+	"github.com/MaDal776/polymarket-go-client/pkg/amount"
 )
 
 // OrderSide represents the side of an order
@@ -41,26 +43,42 @@ type ApiCreds struct {
 
 // OrderArgs represents order arguments
 type OrderArgs struct {
-	TokenID     string    `json:"token_id"`
-	Price       float64   `json:"price"`
-	Size        float64   `json:"size"`
-	Side        OrderSide `json:"side"`
-	FeeRateBps  int       `json:"fee_rate_bps"`
-	Nonce       int64     `json:"nonce"`
-	Expiration  int64     `json:"expiration"`
-	Taker       string    `json:"taker"`
+	TokenID     string        `json:"token_id"`
+	Price       float64       `json:"price"`
+	Size        float64       `json:"size"`
+	Side        OrderSide     `json:"side"`
+	FeeRateBps  int           `json:"fee_rate_bps"`
+	Nonce       int64         `json:"nonce"`
+	Expiration  int64         `json:"expiration"`
+	ExpireAfter time.Duration `json:"expire_after,omitempty"` // Order lifetime on the exchange; sets Expiration (see ClobClient.ExpirationIn) when it is 0
+	Notional    float64       `json:"notional,omitempty"`     // Sets Size to the shares worth this many USDC at Price when Size is 0
+	Taker       string        `json:"taker"`
 }
 
-// MarketOrderArgs represents market order arguments
+// MarketOrderArgs represents market order arguments. Size a BUY with Spend and a
+// SELL with Shares; the exchange reads a buy's amount as USDC and a sell's as shares.
 type MarketOrderArgs struct {
-	TokenID     string    `json:"token_id"`
-	Amount      float64   `json:"amount"`
-	Side        OrderSide `json:"side"`
-	Price       float64   `json:"price,omitempty"`
-	FeeRateBps  int       `json:"fee_rate_bps"`
-	Nonce       int64     `json:"nonce"`
-	Taker       string    `json:"taker"`
-	OrderType   OrderType `json:"order_type"`
+	TokenID        string        `json:"token_id"`
+	Amount         float64       `json:"amount"`           // Deprecated: USDC for a BUY, shares for a SELL; use Spend or Shares
+	Spend          amount.USDC   `json:"spend,omitempty"`  // USDC a BUY spends
+	Shares         amount.Shares `json:"shares,omitempty"` // Shares a SELL sells
+	Side           OrderSide     `json:"side"`
+	Price          float64       `json:"price,omitempty"`
+	FeeRateBps     int           `json:"fee_rate_bps"`
+	Nonce          int64         `json:"nonce"`
+	Taker          string        `json:"taker"`
+	OrderType      OrderType     `json:"order_type"`
+	MaxSlippageBps int           `json:"max_slippage_bps,omitempty"` // Max distance from the best price when pricing from the book; 0 disables
+}
+
+// MarketBuy returns the arguments of a market order spending spend USDC on tokenID
+func MarketBuy(tokenID string, spend amount.USDC, orderType OrderType) MarketOrderArgs {
+	return MarketOrderArgs{TokenID: tokenID, Spend: spend, Side: BUY, OrderType: orderType}
+}
+
+// MarketSell returns the arguments of a market order selling shares of tokenID
+func MarketSell(tokenID string, shares amount.Shares, orderType OrderType) MarketOrderArgs {
+	return MarketOrderArgs{TokenID: tokenID, Shares: shares, Side: SELL, OrderType: orderType}
 }
 
 // OrderData represents the order data structure for signing
@@ -80,19 +98,30 @@ type OrderData struct {
 
 // SignedOrder represents a signed order
 type SignedOrder struct {
-	Salt      int64 `json:"salt"`  // Should be int like Python
-	Maker     string `json:"maker"`
-	Signer    string `json:"signer"`
-	Taker     string `json:"taker"`
-	TokenID   string `json:"tokenId"`
-	MakerAmount string `json:"makerAmount"`
-	TakerAmount string `json:"takerAmount"`
-	Expiration  string `json:"expiration"`
-	Nonce       string `json:"nonce"`
-	FeeRateBps  string `json:"feeRateBps"`
-	Side        OrderSide `json:"side"`  // Use OrderSide type for proper JSON serialization
-	SignatureType int  `json:"signatureType"`
-	Signature   string `json:"signature"`
+	Salt          int64     `json:"salt"` // Encoded as a number by default; see OrderEncoding
+	Maker         string    `json:"maker"`
+	Signer        string    `json:"signer"`
+	Taker         string    `json:"taker"`
+	TokenID       string    `json:"tokenId"`
+	MakerAmount   string    `json:"makerAmount"`
+	TakerAmount   string    `json:"takerAmount"`
+	Expiration    string    `json:"expiration"`
+	Nonce         string    `json:"nonce"`
+	FeeRateBps    string    `json:"feeRateBps"`
+	Side          OrderSide `json:"side"` // Use OrderSide type for proper JSON serialization
+	SignatureType int       `json:"signatureType"`
+	Signature     string    `json:"signature"`
+
+	OrderFlags `json:"-"` // Client-side settings, never signed or posted
+}
+
+// OrderFlags are the client-side settings of a signed order. They are not part
+// of the order's signed or posted JSON, so they are saved next to it wherever a
+// signed order is kept, e.g. by ExportedOrder.
+type OrderFlags struct {
+	PriceAdjustment *PriceAdjustment `json:"price_adjustment,omitempty"` // Set when CreateOrder rounded the requested price
+	AllowCross      bool             `json:"allow_cross,omitempty"`      // Copied from CreateOrderOptions; skips cross checks before posting
+	Owner           string           `json:"owner,omitempty"`            // API key the order is posted under when it differs from the posting client's
 }
 
 // OrderRequest represents the request body for posting an order
@@ -102,6 +131,12 @@ type OrderRequest struct {
 	OrderType OrderType   `json:"orderType"`
 }
 
+// PostOrdersArgs is one order of a batch post
+type PostOrdersArgs struct {
+	Order     *SignedOrder `json:"order"`
+	OrderType OrderType    `json:"orderType"`
+}
+
 // TickSize represents valid tick sizes
 type TickSize string
 
@@ -121,15 +156,80 @@ type RoundConfig struct {
 
 // CreateOrderOptions represents options for creating orders
 type CreateOrderOptions struct {
-	TickSize TickSize `json:"tick_size"`
-	NegRisk  bool     `json:"neg_risk"`
+	TickSize      TickSize       `json:"tick_size"`
+	NegRisk       bool           `json:"neg_risk"`
+	PriceRounding *PriceRounding `json:"price_rounding,omitempty"` // Snap off-tick prices instead of rejecting them
+	AllowCross    bool           `json:"allow_cross,omitempty"`    // Exempt the order from cross checks, e.g. a deliberately aggressive limit order
+}
+
+// RoundingMode selects how a price is snapped to the tick grid
+type RoundingMode string
+
+const (
+	RoundNearest RoundingMode = "NEAREST"
+	RoundDown    RoundingMode = "DOWN"
+	RoundUp      RoundingMode = "UP"
+)
+
+// PriceRounding configures automatic price rounding for each side.
+// An empty mode leaves that side's prices unrounded.
+type PriceRounding struct {
+	Buy  RoundingMode `json:"buy"`
+	Sell RoundingMode `json:"sell"`
+}
+
+// Common rounding policies
+var (
+	RoundPassive    = &PriceRounding{Buy: RoundDown, Sell: RoundUp} // Never pay more / receive less than requested
+	RoundAggressive = &PriceRounding{Buy: RoundUp, Sell: RoundDown} // Favor getting filled
+	RoundToNearest  = &PriceRounding{Buy: RoundNearest, Sell: RoundNearest}
+)
+
+// Mode returns the rounding mode for side
+func (r *PriceRounding) Mode(side OrderSide) RoundingMode {
+	if r == nil {
+		return ""
+	}
+	if side == SELL {
+		return r.Sell
+	}
+	return r.Buy
 }
 
+// PriceAdjustment reports a price changed by automatic rounding
+type PriceAdjustment struct {
+	Requested float64      `json:"requested"`
+	Price     float64      `json:"price"`
+	Mode      RoundingMode `json:"mode"`
+}
+
+// CollateralToken selects one of the USDC tokens of a chain
+type CollateralToken string
+
+const (
+	CollateralBridged CollateralToken = "USDC.e" // Bridged USDC, the collateral the exchange settles in
+	CollateralNative  CollateralToken = "USDC"   // Circle's native USDC
+)
+
 // ContractConfig represents contract configuration
 type ContractConfig struct {
-	Exchange           string `json:"exchange"`
-	Collateral         string `json:"collateral"`
-	ConditionalTokens  string `json:"conditional_tokens"`
+	Exchange          string `json:"exchange"`
+	Collateral        string `json:"collateral"`
+	ConditionalTokens string `json:"conditional_tokens"`
+	NegRiskAdapter    string `json:"neg_risk_adapter,omitempty"`  // Neg risk markets only
+	NativeCollateral  string `json:"native_collateral,omitempty"` // Native USDC, where the chain has it
+}
+
+// CollateralAddress returns the address of a collateral token, or false when the
+// chain has no such token
+func (c ContractConfig) CollateralAddress(token CollateralToken) (string, bool) {
+	switch token {
+	case CollateralBridged, "":
+		return c.Collateral, c.Collateral != ""
+	case CollateralNative:
+		return c.NativeCollateral, c.NativeCollateral != ""
+	}
+	return "", false
 }
 
 // RequestArgs represents request arguments for signing
@@ -154,6 +254,56 @@ type PerformanceMetrics struct {
 	Duration  time.Duration `json:"duration"`
 	Success   bool          `json:"success"`
 	Error     string        `json:"error,omitempty"`
+	RequestID string        `json:"request_id,omitempty"` // Set for HTTP requests
+	Stages    []Stage       `json:"stages,omitempty"`     // Breakdown of Duration, set for order creation and posting
+}
+
+// Order submission stages reported in PerformanceMetrics.Stages
+const (
+	StageOptions  = "options"      // Tick size and neg risk lookup
+	StageValidate = "validation"   // Price snapping, sizing and validation
+	StageAmounts  = "amounts"      // Maker and taker amount calculation
+	StageHash     = "eip712_hash"  // Order struct and EIP712 hashing
+	StageSign     = "signing"      // ECDSA signature
+	StageChecks   = "checks"       // Pre-post checks such as the cross and midpoint checks
+	StageMarshal  = "marshal"      // Request body encoding
+	StageHeaders  = "hmac_headers" // L2 header build, including the HMAC
+	StageHTTP     = "http"         // Round trip, including rate limiting and retries
+)
+
+// Stage is the time spent in one step of an operation
+type Stage struct {
+	Name     string        `json:"name"`
+	Duration time.Duration `json:"duration"`
+}
+
+// StageTimer splits an operation's duration into consecutive stages
+type StageTimer struct {
+	start  time.Time
+	last   time.Time
+	stages []Stage
+}
+
+// NewStageTimer starts timing stages at start
+func NewStageTimer(start time.Time) *StageTimer {
+	return &StageTimer{start: start, last: start, stages: make([]Stage, 0, 8)}
+}
+
+// Start returns the time the first stage started
+func (t *StageTimer) Start() time.Time {
+	return t.start
+}
+
+// Mark ends the current stage, naming it
+func (t *StageTimer) Mark(name string) {
+	now := time.Now()
+	t.stages = append(t.stages, Stage{Name: name, Duration: now.Sub(t.last)})
+	t.last = now
+}
+
+// Stages returns the stages marked so far
+func (t *StageTimer) Stages() []Stage {
+	return t.stages
 }
 
 // OrderBookSummary represents order book data
@@ -164,6 +314,8 @@ type OrderBookSummary struct {
 	Bids      []OrderSummary `json:"bids"`
 	Asks      []OrderSummary `json:"asks"`
 	Hash      string         `json:"hash"`
+
+	parsed *parsedBook // Levels parsed at decode time; see book.go
 }
 
 // OrderSummary represents a single order in the book
@@ -208,4 +360,191 @@ type BookParams struct {
 type PricesRequest struct {
 	TokenID string    `json:"token_id"`
 	Side    OrderSide `json:"side"`
-}
\ No newline at end of file
+}
+
+// MidpointResponse represents the midpoint price response for a token
+type MidpointResponse struct {
+	Mid string `json:"mid"`
+}
+
+// TradeParams represents filters for trade history queries
+type TradeParams struct {
+	ID           string `json:"id,omitempty"`
+	MakerAddress string `json:"maker_address,omitempty"`
+	Market       string `json:"market,omitempty"`
+	AssetID      string `json:"asset_id,omitempty"`
+	Before       int64  `json:"before,omitempty"`
+	After        int64  `json:"after,omitempty"`
+}
+
+// MakerOrder represents a maker order matched within a trade
+type MakerOrder struct {
+	OrderID       string    `json:"order_id"`
+	Owner         string    `json:"owner"`
+	MakerAddress  string    `json:"maker_address"`
+	MatchedAmount string    `json:"matched_amount"`
+	Price         string    `json:"price"`
+	FeeRateBps    string    `json:"fee_rate_bps"`
+	AssetID       string    `json:"asset_id"`
+	Outcome       string    `json:"outcome"`
+	Side          OrderSide `json:"side"`
+}
+
+// Trade represents a trade returned by the trades endpoint
+type Trade struct {
+	ID              string       `json:"id"`
+	TakerOrderID    string       `json:"taker_order_id"`
+	Market          string       `json:"market"`
+	AssetID         string       `json:"asset_id"`
+	Side            OrderSide    `json:"side"`
+	Size            string       `json:"size"`
+	FeeRateBps      string       `json:"fee_rate_bps"`
+	Price           string       `json:"price"`
+	Status          string       `json:"status"`
+	MatchTime       string       `json:"match_time"`
+	LastUpdate      string       `json:"last_update"`
+	Outcome         string       `json:"outcome"`
+	BucketIndex     int          `json:"bucket_index"`
+	Owner           string       `json:"owner"`
+	MakerAddress    string       `json:"maker_address"`
+	TransactionHash string       `json:"transaction_hash"`
+	TraderSide      string       `json:"trader_side"`
+	MakerOrders     []MakerOrder `json:"maker_orders"`
+}
+
+// PaginatedTrades represents a page of trades
+type PaginatedTrades struct {
+	Data       []Trade `json:"data"`
+	NextCursor string  `json:"next_cursor"`
+	Limit      int     `json:"limit"`
+	Count      int     `json:"count"`
+}
+
+// OpenOrderParams represents filters for open order queries
+type OpenOrderParams struct {
+	ID      string `json:"id,omitempty"`
+	Market  string `json:"market,omitempty"`
+	AssetID string `json:"asset_id,omitempty"`
+}
+
+// UserPosition represents a position reported by the data API
+type UserPosition struct {
+	ProxyWallet   string  `json:"proxyWallet"`
+	Asset         string  `json:"asset"`
+	ConditionID   string  `json:"conditionId"`
+	Size          float64 `json:"size"`
+	AvgPrice      float64 `json:"avgPrice"`
+	InitialValue  float64 `json:"initialValue"`
+	CurrentValue  float64 `json:"currentValue"`
+	CashPnl       float64 `json:"cashPnl"`
+	PercentPnl    float64 `json:"percentPnl"`
+	RealizedPnl   float64 `json:"realizedPnl"`
+	CurPrice      float64 `json:"curPrice"`
+	Redeemable    bool    `json:"redeemable"`
+	Title         string  `json:"title"`
+	Slug          string  `json:"slug"`
+	Outcome       string  `json:"outcome"`
+	OutcomeIndex  int     `json:"outcomeIndex"`
+	OppositeAsset string  `json:"oppositeAsset"`
+	EndDate       string  `json:"endDate"`
+	NegativeRisk  bool    `json:"negativeRisk"`
+}
+
+// MarketToken is one outcome token of a market
+type MarketToken struct {
+	TokenID string  `json:"token_id"`
+	Outcome string  `json:"outcome"`
+	Price   float64 `json:"price"`
+	Winner  bool    `json:"winner"`
+}
+
+// Market represents a market as returned by the CLOB markets endpoints.
+// The Gamma client converts its responses to the same type.
+type Market struct {
+	ConditionID      string        `json:"condition_id"`
+	QuestionID       string        `json:"question_id"`
+	Question         string        `json:"question"`
+	Description      string        `json:"description"`
+	Slug             string        `json:"market_slug"`
+	Tokens           []MarketToken `json:"tokens"`
+	MinimumTickSize  float64       `json:"minimum_tick_size"`
+	MinimumOrderSize float64       `json:"minimum_order_size"`
+	NegRisk          bool          `json:"neg_risk"`
+	NegRiskMarketID  string        `json:"neg_risk_market_id"`
+	Active           bool          `json:"active"`
+	Closed           bool          `json:"closed"`
+	Archived         bool          `json:"archived"`
+	AcceptingOrders  bool          `json:"accepting_orders"`
+	AcceptingFrom    string        `json:"accepting_order_timestamp"` // When the market started (or starts) accepting orders
+	EnableOrderBook  bool          `json:"enable_order_book"`
+	EndDate          string        `json:"end_date_iso"`
+	MakerBaseFee     int           `json:"maker_base_fee"`
+	TakerBaseFee     int           `json:"taker_base_fee"`
+	SecondsDelay     int           `json:"seconds_delay"`
+	Rewards          MarketRewards `json:"rewards"`
+	Icon             string        `json:"icon"`
+	Image            string        `json:"image"`
+}
+
+// MarketRewards are a market's liquidity reward parameters
+type MarketRewards struct {
+	Rates     []RewardRate `json:"rates"`
+	MinSize   float64      `json:"min_size"`   // Smallest order size that scores
+	MaxSpread float64      `json:"max_spread"` // Farthest distance from the midpoint that scores, in cents
+}
+
+// RewardRate is the daily reward paid in one asset
+type RewardRate struct {
+	AssetAddress     string  `json:"asset_address"`
+	RewardsDailyRate float64 `json:"rewards_daily_rate"`
+}
+
+// PaginatedMarkets represents a page of markets
+type PaginatedMarkets struct {
+	Data       []Market `json:"data"`
+	NextCursor string   `json:"next_cursor"`
+	Limit      int      `json:"limit"`
+	Count      int      `json:"count"`
+}
+
+// OpenOrder represents an order as reported by the order endpoints
+type OpenOrder struct {
+	ID              string    `json:"id"`
+	Status          string    `json:"status"`
+	Owner           string    `json:"owner"`
+	MakerAddress    string    `json:"maker_address"`
+	Market          string    `json:"market"`
+	AssetID         string    `json:"asset_id"`
+	Side            OrderSide `json:"side"`
+	OriginalSize    string    `json:"original_size"`
+	SizeMatched     string    `json:"size_matched"`
+	Price           string    `json:"price"`
+	Outcome         string    `json:"outcome"`
+	OrderType       OrderType `json:"order_type"`
+	Expiration      string    `json:"expiration"`
+	AssociateTrades []string  `json:"associate_trades"`
+	CreatedAt       int64     `json:"created_at"`
+}
+
+// OrderEventType is the kind of change an order event reports
+type OrderEventType string
+
+const (
+	OrderEventPlacement    OrderEventType = "PLACEMENT"    // The order was accepted onto the book
+	OrderEventUpdate       OrderEventType = "UPDATE"       // Part of the order was matched
+	OrderEventCancellation OrderEventType = "CANCELLATION" // The order was cancelled
+)
+
+// OrderEvent is an order message from the user websocket channel
+type OrderEvent struct {
+	ID           string         `json:"id"`
+	Type         OrderEventType `json:"type"`
+	Owner        string         `json:"owner"`
+	Market       string         `json:"market"`
+	AssetID      string         `json:"asset_id"`
+	Side         OrderSide      `json:"side"`
+	Price        string         `json:"price"`
+	OriginalSize string         `json:"original_size"`
+	SizeMatched  string         `json:"size_matched"`
+	Timestamp    string         `json:"timestamp"`
+}
@@ -1,12 +1,18 @@
 package utils
 
 import (
+	"encoding/binary"
 	"fmt"
+	"math"
 	"math/big"
+	"strconv"
+	"strings"
+	"sync"
 
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/crypto"
-	"polymarket-clob-go/pkg/types"
+	"github.com/MaDal776/polymarket-go-client/pkg/amount"
+	"github.com/MaDal776/polymarket-go-client/pkg/types"
 )
 
 const (
@@ -14,16 +20,35 @@ const (
 	ClobVersion    = "1"
 )
 
+// orderTypeHash is keccak256 of the Order type string, which must match the field order from py_order_utils
+var orderTypeHash = crypto.Keccak256([]byte("Order(uint256 salt,address maker,address signer,address taker,uint256 tokenId,uint256 makerAmount,uint256 takerAmount,uint256 expiration,uint256 nonce,uint256 feeRateBps,uint8 side,uint8 signatureType)"))
+
+// orderStructSize is the encoded size of an Order: the type hash plus 12 fields of 32 bytes
+const orderStructSize = 32 * 13
+
+// Pools for the order hashing fast path
+var (
+	keccakPool = sync.Pool{New: func() interface{} { return crypto.NewKeccakState() }}
+	bufferPool = sync.Pool{New: func() interface{} { return new([orderStructSize]byte) }}
+)
+
+// keccak256 hashes data with a pooled hasher
+func keccak256(data ...[]byte) []byte {
+	h := keccakPool.Get().(crypto.KeccakState)
+	h.Reset()
+	for _, d := range data {
+		h.Write(d)
+	}
+	out := make([]byte, 32)
+	h.Read(out)
+	keccakPool.Put(h)
+	return out
+}
+
 // CreateEIP712Hash creates an EIP712 hash according to the standard
 func CreateEIP712Hash(domainSeparator, structHash []byte) []byte {
 	// EIP712 standard: keccak256("\x19\x01" ‖ domainSeparator ‖ hashStruct(message))
-	prefix := []byte("\x19\x01")
-	data := make([]byte, 0, 2+32+32)
-	data = append(data, prefix...)
-	data = append(data, domainSeparator...)
-	data = append(data, structHash...)
-	
-	return crypto.Keccak256(data)
+	return keccak256([]byte("\x19\x01"), domainSeparator, structHash)
 }
 
 // CreateClobAuthDomain creates the EIP712 domain separator for CLOB auth
@@ -78,15 +103,10 @@ func EncodeClobAuth(auth types.ClobAuth) []byte {
 	return crypto.Keccak256(encoded)
 }
 
-// ToTokenDecimals converts a float to token decimals (6 decimals)
-func ToTokenDecimals(amount float64) *big.Int {
-	// Polymarket uses 6 decimals
-	multiplier := big.NewInt(1000000)
-	amountBig := big.NewFloat(amount)
-	amountBig.Mul(amountBig, big.NewFloat(0).SetInt(multiplier))
-	
-	result, _ := amountBig.Int(nil)
-	return result
+// ToTokenDecimals converts a float to token decimals (6 decimals), rounding to the
+// nearest unit so float noise (0.29 -> 289999.99...) does not lose a unit
+func ToTokenDecimals(value float64) *big.Int {
+	return big.NewInt(amount.ToUnits(value))
 }
 
 // RoundDown rounds down to specified decimal places
@@ -138,6 +158,29 @@ func ValidatePrice(price float64, tickSize types.TickSize) bool {
 	return price >= tickSizeFloat && price <= (1.0-tickSizeFloat)
 }
 
+// SnapPrice rounds price onto the tick grid in the given direction and clamps it
+// to the valid range [tick, 1-tick]. An empty mode returns the price unchanged.
+func SnapPrice(price float64, tickSize types.TickSize, mode types.RoundingMode) float64 {
+	tick := ParseTickSize(tickSize)
+	if tick == 0 || mode == "" {
+		return price
+	}
+	
+	// The epsilon keeps prices already on the grid from moving a tick
+	steps := price / tick
+	switch mode {
+	case types.RoundDown:
+		steps = math.Floor(steps + 1e-9)
+	case types.RoundUp:
+		steps = math.Ceil(steps - 1e-9)
+	default:
+		steps = math.Round(steps)
+	}
+	
+	snapped := math.Max(tick, math.Min(steps*tick, 1-tick))
+	return RoundNormal(snapped, GetRoundingConfig(tickSize).Price)
+}
+
 // ParseTickSize converts TickSize to float64
 func ParseTickSize(tickSize types.TickSize) float64 {
 	switch tickSize {
@@ -150,8 +193,24 @@ func ParseTickSize(tickSize types.TickSize) float64 {
 	case types.TickSize00001:
 		return 0.0001
 	default:
-        return 0, fmt.Errorf("unsupported tick size: %s", tickSize)
+		return 0
+	}
+}
+
+// ParseTickSizeString parses a server-formatted tick size such as "0.01", "0.0100" or "1e-2"
+// into one of the supported TickSize values. Unknown ticks are rejected.
+func ParseTickSizeString(s string) (types.TickSize, error) {
+	value, err := strconv.ParseFloat(strings.TrimSpace(s), 64)
+	if err != nil {
+		return "", fmt.Errorf("invalid tick size %q: %w", s, err)
+	}
+	
+	for _, tickSize := range []types.TickSize{types.TickSize01, types.TickSize001, types.TickSize0001, types.TickSize00001} {
+		if math.Abs(value-ParseTickSize(tickSize)) < 1e-12 {
+			return tickSize, nil
+		}
 	}
+	return "", fmt.Errorf("unsupported tick size: %s", s)
 }
 
 // GetRoundingConfig returns rounding configuration for tick size
@@ -170,6 +229,17 @@ func GetRoundingConfig(tickSize types.TickSize) types.RoundConfig {
 	}
 }
 
+// SizeForNotional returns the share size that buys or sells notional USDC worth
+// at price, rounded down to the size decimals of tickSize so the order's value
+// does not exceed notional
+func SizeForNotional(notional, price float64, tickSize types.TickSize) float64 {
+	if notional <= 0 || price <= 0 {
+		return 0
+	}
+	// Nudge up so exact quotients such as 0.3 / 0.1 survive float noise
+	return RoundDown(notional/price+1e-9, GetRoundingConfig(tickSize).Size)
+}
+
 // CreateOrderEIP712Hash creates an EIP712 hash for order signing
 // This implements the exact same structure as py_order_utils
 func CreateOrderEIP712Hash(orderData types.OrderData, salt int64, exchangeAddress string, chainID int64) []byte {
@@ -213,81 +283,70 @@ func CreatePolymarketDomain(chainID int64, exchangeAddress string) []byte {
 // CreateOrderStructHash creates the struct hash for Order
 // Order(uint256 salt,address maker,address signer,address taker,uint256 tokenId,uint256 makerAmount,uint256 takerAmount,uint256 expiration,uint256 nonce,uint256 feeRateBps,uint8 side,uint8 signatureType)
 func CreateOrderStructHash(orderData types.OrderData, salt int64) []byte {
-	// Order type hash - MUST match the exact field order from py_order_utils
-	orderTypeHash := crypto.Keccak256([]byte("Order(uint256 salt,address maker,address signer,address taker,uint256 tokenId,uint256 makerAmount,uint256 takerAmount,uint256 expiration,uint256 nonce,uint256 feeRateBps,uint8 side,uint8 signatureType)"))
-	
-	// Convert salt to big.Int
-	saltBig := big.NewInt(salt)
-	saltBytes := make([]byte, 32)
-	saltBig.FillBytes(saltBytes)
-	
-	// Parse addresses and pad to 32 bytes
-	makerAddr := common.HexToAddress(orderData.Maker)
-	makerBytes := make([]byte, 32)
-	copy(makerBytes[12:], makerAddr.Bytes())
-	
-	signerAddr := common.HexToAddress(orderData.Signer)
-	signerBytes := make([]byte, 32)
-	copy(signerBytes[12:], signerAddr.Bytes())
-	
-	takerAddr := common.HexToAddress(orderData.Taker)
-	takerBytes := make([]byte, 32)
-	copy(takerBytes[12:], takerAddr.Bytes())
-	
-	// Parse tokenId
-	tokenIdBig := new(big.Int)
-	tokenIdBig.SetString(orderData.TokenID, 10)
-	tokenIdBytes := make([]byte, 32)
-	tokenIdBig.FillBytes(tokenIdBytes)
-	
-	// MakerAmount and TakerAmount are already *big.Int
-	makerAmountBytes := make([]byte, 32)
-	orderData.MakerAmount.FillBytes(makerAmountBytes)
-	
-	takerAmountBytes := make([]byte, 32)
-	orderData.TakerAmount.FillBytes(takerAmountBytes)
-	
-	// Parse expiration
-	expirationBig := new(big.Int)
-	expirationBig.SetString(orderData.Expiration, 10)
-	expirationBytes := make([]byte, 32)
-	expirationBig.FillBytes(expirationBytes)
-	
-	// Parse nonce
-	nonceBig := new(big.Int)
-	nonceBig.SetString(orderData.Nonce, 10)
-	nonceBytes := make([]byte, 32)
-	nonceBig.FillBytes(nonceBytes)
-	
-	// Parse feeRateBps
-	feeRateBig := new(big.Int)
-	feeRateBig.SetString(orderData.FeeRateBps, 10)
-	feeRateBytes := make([]byte, 32)
-	feeRateBig.FillBytes(feeRateBytes)
-	
-	// Side as uint8 (padded to 32 bytes)
-	sideBytes := make([]byte, 32)
-	sideBytes[31] = byte(orderData.Side)
-	
-	// SignatureType as uint8 (padded to 32 bytes)
-	sigTypeBytes := make([]byte, 32)
-	sigTypeBytes[31] = byte(orderData.SignatureType)
-	
-	// Combine all fields in the exact order from py_order_utils
-	encoded := make([]byte, 0, 32*13) // 13 fields * 32 bytes each
-	encoded = append(encoded, orderTypeHash...)
-	encoded = append(encoded, saltBytes...)
-	encoded = append(encoded, makerBytes...)
-	encoded = append(encoded, signerBytes...)
-	encoded = append(encoded, takerBytes...)
-	encoded = append(encoded, tokenIdBytes...)
-	encoded = append(encoded, makerAmountBytes...)
-	encoded = append(encoded, takerAmountBytes...)
-	encoded = append(encoded, expirationBytes...)
-	encoded = append(encoded, nonceBytes...)
-	encoded = append(encoded, feeRateBytes...)
-	encoded = append(encoded, sideBytes...)
-	encoded = append(encoded, sigTypeBytes...)
+	buf := bufferPool.Get().(*[orderStructSize]byte)
+	defer bufferPool.Put(buf)
+	*buf = [orderStructSize]byte{}
+	
+	// Combine all fields in the exact order from py_order_utils, each in a 32-byte word
+	copy(buf[0:32], orderTypeHash)
+	binary.BigEndian.PutUint64(buf[56:64], uint64(salt))
+	putAddress(buf[64:96], orderData.Maker)
+	putAddress(buf[96:128], orderData.Signer)
+	putAddress(buf[128:160], orderData.Taker)
+	putDecimal(buf[160:192], orderData.TokenID)
+	orderData.MakerAmount.FillBytes(buf[192:224])
+	orderData.TakerAmount.FillBytes(buf[224:256])
+	putDecimal(buf[256:288], orderData.Expiration)
+	putDecimal(buf[288:320], orderData.Nonce)
+	putDecimal(buf[320:352], orderData.FeeRateBps)
+	buf[383] = byte(orderData.Side)
+	buf[415] = byte(orderData.SignatureType)
+	
+	return keccak256(buf[:])
+}
+
+// putAddress writes a hex address left-padded into a 32-byte word
+func putAddress(word []byte, address string) {
+	addr := common.HexToAddress(address)
+	copy(word[12:], addr[:])
+}
+
+// putDecimal writes a decimal string as a uint256 word, using big.Int only for values beyond uint64
+func putDecimal(word []byte, value string) {
+	if value == "" {
+		return
+	}
+	if n, err := strconv.ParseUint(value, 10, 64); err == nil {
+		binary.BigEndian.PutUint64(word[24:], n)
+		return
+	}
+	if n, ok := new(big.Int).SetString(value, 10); ok {
+		n.FillBytes(word)
+	}
+}
+
+// SignedOrderPriceAndSize recovers the limit price and share size from a signed order's amounts
+func SignedOrderPriceAndSize(signedOrder *types.SignedOrder) (float64, float64, error) {
+	makerAmount, err := strconv.ParseFloat(signedOrder.MakerAmount, 64)
+	if err != nil {
+		return 0, 0, fmt.Errorf("invalid maker amount: %w", err)
+	}
+	takerAmount, err := strconv.ParseFloat(signedOrder.TakerAmount, 64)
+	if err != nil {
+		return 0, 0, fmt.Errorf("invalid taker amount: %w", err)
+	}
+	if makerAmount <= 0 || takerAmount <= 0 {
+		return 0, 0, fmt.Errorf("order amounts must be positive")
+	}
 	
-	return crypto.Keccak256(encoded)
-}
\ No newline at end of file
+	switch signedOrder.Side {
+	case types.BUY:
+		// Maker gives USDC, taker gives shares
+		return math.Round(makerAmount/takerAmount*1e6) / 1e6, takerAmount / 1e6, nil
+	case types.SELL:
+		// Maker gives shares, taker gives USDC
+		return math.Round(takerAmount/makerAmount*1e6) / 1e6, makerAmount / 1e6, nil
+	default:
+		return 0, 0, fmt.Errorf("invalid order side: %s", signedOrder.Side)
+	}
+}
@@ -0,0 +1,165 @@
+package utils
+
+import (
+	"bytes"
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+
+	"github.com/MaDal776/polymarket-go-client/pkg/types"
+)
+
+func TestParseTickSizeString(t *testing.T) {
+	cases := map[string]types.TickSize{
+		"0.1":    types.TickSize01,
+		"0.01":   types.TickSize001,
+		"0.0100": types.TickSize001,
+		"1e-3":   types.TickSize0001,
+		"0.0001": types.TickSize00001,
+	}
+	for input, want := range cases {
+		got, err := ParseTickSizeString(input)
+		if err != nil {
+			t.Errorf("ParseTickSizeString(%q) failed: %v", input, err)
+			continue
+		}
+		if got != want {
+			t.Errorf("ParseTickSizeString(%q) = %s, want %s", input, got, want)
+		}
+	}
+
+	for _, input := range []string{"0.05", "abc", ""} {
+		if _, err := ParseTickSizeString(input); err == nil {
+			t.Errorf("Expected ParseTickSizeString(%q) to fail", input)
+		}
+	}
+}
+
+func TestSnapPrice(t *testing.T) {
+	cases := []struct {
+		price    float64
+		tickSize types.TickSize
+		mode     types.RoundingMode
+		want     float64
+	}{
+		{0.555, types.TickSize001, types.RoundDown, 0.55},
+		{0.555, types.TickSize001, types.RoundUp, 0.56},
+		{0.554, types.TickSize001, types.RoundNearest, 0.55},
+		{0.55, types.TickSize001, types.RoundUp, 0.55},
+		{0.29, types.TickSize01, types.RoundDown, 0.2},
+		{0.999, types.TickSize001, types.RoundUp, 0.99},
+		{0.001, types.TickSize001, types.RoundDown, 0.01},
+		{0.12345, types.TickSize0001, types.RoundNearest, 0.123},
+		{0.555, types.TickSize001, "", 0.555},
+	}
+	for _, c := range cases {
+		if got := SnapPrice(c.price, c.tickSize, c.mode); got != c.want {
+			t.Errorf("SnapPrice(%v, %s, %q) = %v, want %v", c.price, c.tickSize, c.mode, got, c.want)
+		}
+	}
+}
+
+func testOrderData() types.OrderData {
+	return types.OrderData{
+		Maker:         "0xf39Fd6e51aad88F6F4ce6aB8827279cffFb92266",
+		Signer:        "0xf39Fd6e51aad88F6F4ce6aB8827279cffFb92266",
+		Taker:         "0x0000000000000000000000000000000000000000",
+		TokenID:       "91094360697357622623953793720402150934374522251651348543981406747516093190659",
+		MakerAmount:   big.NewInt(5000000),
+		TakerAmount:   big.NewInt(10000000),
+		Side:          0,
+		FeeRateBps:    "0",
+		Nonce:         "0",
+		Expiration:    "0",
+		SignatureType: 0,
+	}
+}
+
+// referenceOrderStructHash encodes the order field by field with big.Int, as the spec reads
+func referenceOrderStructHash(orderData types.OrderData, salt int64) []byte {
+	word := func(n *big.Int) []byte { return common.LeftPadBytes(n.Bytes(), 32) }
+	decimal := func(s string) []byte {
+		n, _ := new(big.Int).SetString(s, 10)
+		return word(n)
+	}
+	address := func(s string) []byte { return common.LeftPadBytes(common.HexToAddress(s).Bytes(), 32) }
+
+	return crypto.Keccak256(
+		crypto.Keccak256([]byte("Order(uint256 salt,address maker,address signer,address taker,uint256 tokenId,uint256 makerAmount,uint256 takerAmount,uint256 expiration,uint256 nonce,uint256 feeRateBps,uint8 side,uint8 signatureType)")),
+		word(big.NewInt(salt)),
+		address(orderData.Maker),
+		address(orderData.Signer),
+		address(orderData.Taker),
+		decimal(orderData.TokenID),
+		word(orderData.MakerAmount),
+		word(orderData.TakerAmount),
+		decimal(orderData.Expiration),
+		decimal(orderData.Nonce),
+		decimal(orderData.FeeRateBps),
+		word(big.NewInt(int64(orderData.Side))),
+		word(big.NewInt(int64(orderData.SignatureType))),
+	)
+}
+
+func TestSizeForNotional(t *testing.T) {
+	cases := []struct {
+		notional, price float64
+		want            float64
+	}{
+		{100, 0.55, 181.81},
+		{0.3, 0.1, 3},
+		{10, 0.5, 20},
+		{10, 0, 0},
+		{-5, 0.5, 0},
+	}
+	for _, c := range cases {
+		if got := SizeForNotional(c.notional, c.price, types.TickSize001); got != c.want {
+			t.Errorf("SizeForNotional(%v, %v) = %v, want %v", c.notional, c.price, got, c.want)
+		}
+	}
+}
+
+func TestCreateOrderStructHashMatchesReference(t *testing.T) {
+	orderData := testOrderData()
+	variants := []func(*types.OrderData){
+		func(o *types.OrderData) {},
+		func(o *types.OrderData) { o.Side = 1; o.Expiration = "1900000000" },
+		func(o *types.OrderData) { o.Nonce = "340282366920938463463374607431768211455"; o.FeeRateBps = "100" },
+		func(o *types.OrderData) { o.TokenID = "123"; o.SignatureType = 2 },
+	}
+
+	for i, apply := range variants {
+		data := orderData
+		apply(&data)
+		for _, salt := range []int64{0, 1, 1234567890123} {
+			got := CreateOrderStructHash(data, salt)
+			want := referenceOrderStructHash(data, salt)
+			if !bytes.Equal(got, want) {
+				t.Errorf("variant %d salt %d: struct hash %x, want %x", i, salt, got, want)
+			}
+		}
+	}
+}
+
+func TestCreateEIP712HashMatchesReference(t *testing.T) {
+	domain := CreatePolymarketDomain(137, "0x4bFb41d5B3570DeFd03C39a9A4D8dE6Bd8B8982E")
+	structHash := CreateOrderStructHash(testOrderData(), 42)
+
+	got := CreateEIP712Hash(domain, structHash)
+	want := crypto.Keccak256([]byte("\x19\x01"), domain, structHash)
+	if !bytes.Equal(got, want) {
+		t.Errorf("EIP712 hash %x, want %x", got, want)
+	}
+}
+
+func BenchmarkCreateOrderEIP712Hash(b *testing.B) {
+	domain := CreatePolymarketDomain(137, "0x4bFb41d5B3570DeFd03C39a9A4D8dE6Bd8B8982E")
+	orderData := testOrderData()
+
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		CreateEIP712Hash(domain, CreateOrderStructHash(orderData, int64(i)))
+	}
+}
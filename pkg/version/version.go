@@ -0,0 +1,29 @@
+// Package version reports the library version and the User-Agent the API
+// clients identify themselves with.
+package version
+
+import (
+	"runtime"
+	"strings"
+)
+
+// Version is the library version
+const Version = "1.0.0"
+
+// Product names the library in User-Agent strings
+const Product = "polymarket-go-client"
+
+// Header carries Version on every API request, apart from the User-Agent, so
+// requests can be traced to a release even behind proxies that rewrite it
+const Header = "X-Client-Version"
+
+// UserAgent returns the User-Agent sent with API requests, e.g.
+// "my-bot/2.1 polymarket-go-client/1.0.0 (go1.22.1; linux/amd64)". An empty
+// app leaves out the application part.
+func UserAgent(app string) string {
+	ua := Product + "/" + Version + " (" + runtime.Version() + "; " + runtime.GOOS + "/" + runtime.GOARCH + ")"
+	if app = strings.TrimSpace(app); app != "" {
+		return app + " " + ua
+	}
+	return ua
+}
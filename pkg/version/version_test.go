@@ -0,0 +1,16 @@
+package version
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestUserAgent(t *testing.T) {
+	ua := UserAgent("")
+	if !strings.HasPrefix(ua, Product+"/"+Version+" (go") {
+		t.Errorf("Unexpected default User-Agent %q", ua)
+	}
+	if got := UserAgent(" my-bot/2.1 "); got != "my-bot/2.1 "+ua {
+		t.Errorf("Expected the app to prefix the default, got %q", got)
+	}
+}